@@ -363,6 +363,40 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/assoc",
+		Name: "kind",
+		Desc: "holds the kind of associated NPC",
+		Values: []*enumValue{
+			{Key: "contact"},
+			{Key: "patron"},
+			{Key: "ally"},
+			{Key: "enemy"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/assoc",
+		Name: "frequency",
+		Desc: "holds the frequency of appearance of an associated NPC",
+		Values: []*enumValue{
+			{Key: "constantly", String: "Constantly"},
+			{Key: "fifteen", String: "Almost all the time (15 or less)"},
+			{Key: "twelve", String: "Quite often (12 or less)"},
+			{Key: "nine", String: "Fairly often (9 or less)"},
+			{Key: "six", String: "Quite rarely (6 or less)"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/assoc",
+		Name: "reliability",
+		Desc: "holds the reliability of a contact",
+		Values: []*enumValue{
+			{Key: "completely", String: "Completely reliable"},
+			{Key: "usually", String: "Usually reliable"},
+			{Key: "somewhat", String: "Somewhat reliable"},
+			{Key: "unreliable", String: "Unreliable"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/attribute",
 		Name: "placement",
@@ -410,6 +444,74 @@ var allEnums = []*enumInfo{
 			{Key: "toggle"},
 			{Key: "page_ref"},
 			{Key: "markdown"},
+			{Key: "stepper"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/combat",
+		Name: "maneuver",
+		Desc: "holds the maneuver a combatant has chosen for their turn",
+		Values: []*enumValue{
+			{Key: "do_nothing"},
+			{Key: "move"},
+			{Key: "change_posture"},
+			{Key: "aim"},
+			{Key: "evaluate"},
+			{Key: "attack"},
+			{Key: "feint"},
+			{Key: "all_out_attack", String: "All-Out Attack"},
+			{Key: "move_and_attack", String: "Move and Attack"},
+			{Key: "all_out_defense", String: "All-Out Defense"},
+			{Key: "concentrate"},
+			{Key: "ready"},
+			{Key: "wait"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/combat",
+		Name: "posture",
+		Desc: "holds the posture of a combatant",
+		Values: []*enumValue{
+			{Key: "standing"},
+			{Key: "crouching"},
+			{Key: "kneeling"},
+			{Key: "crawling"},
+			{Key: "sitting"},
+			{Key: "lying_prone"},
+			{Key: "lying_face_up"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/combat",
+		Name: "flag",
+		Desc: "holds a status flag affecting a combatant",
+		Values: []*enumValue{
+			{Key: "stunned"},
+			{Key: "mentally_stunned"},
+			{Key: "shocked"},
+			{Key: "reeling"},
+			{Key: "tired"},
+			{Key: "grappled"},
+			{Key: "unconscious"},
+			{Key: "dead"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/condition",
+		Name: "type",
+		Desc: "holds a temporary condition that may affect a character",
+		Values: []*enumValue{
+			{Key: "stunned"},
+			{Key: "prone"},
+			{Key: "grappled"},
+			{Key: "shock"},
+			{Key: "moderate_pain"},
+			{Key: "severe_pain"},
+			{Key: "terrible_pain"},
+			{Key: "coughing"},
+			{Key: "nauseated"},
+			{Key: "tipsy"},
+			{Key: "drunk"},
 		},
 	},
 	{
@@ -428,6 +530,9 @@ var allEnums = []*enumInfo{
 				Key:    "meta_trait",
 				String: "Meta-Trait",
 			},
+			{Key: "alternate_form"},
+			{Key: "power"},
+			{Key: "sorcery"},
 		},
 	},
 	{
@@ -494,6 +599,24 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/ectype",
+		Name: "type",
+		Desc: "holds the type of an equipment container",
+		Values: []*enumValue{
+			{Key: "normal"},
+			{
+				Name:   "ExtraDimensional",
+				Key:    "extra_dimensional",
+				String: "Extra-dimensional",
+				Alt:    "Extra-dimensional (contents are weightless)",
+			},
+			{
+				Key: "payload",
+				Alt: "Payload (contents count against Payload capacity instead of encumbrance)",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/emcost",
 		Name: "type",
@@ -773,6 +896,43 @@ var allEnums = []*enumInfo{
 				Key:    "contained_weight_reduction",
 				String: "Reduces the contained weight by",
 			},
+			{
+				Key:    "skill_grant",
+				String: "Grants the skill",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/fluency",
+		Name: "level",
+		Desc: "holds the comprehension level of a language",
+		Values: []*enumValue{
+			{Key: "none"},
+			{Key: "broken"},
+			{Key: "accented"},
+			{Key: "native"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/merge",
+		Name: "resolution",
+		Desc: "holds the approach to take when merged data conflicts with existing data",
+		Values: []*enumValue{
+			{
+				Name:   "KeepBoth",
+				Key:    "keep_both",
+				String: "Keep both",
+			},
+			{
+				Name:   "Replace",
+				Key:    "replace",
+				String: "Replace existing",
+			},
+			{
+				Name:   "Skip",
+				Key:    "skip",
+				String: "Skip incoming",
+			},
 		},
 	},
 	{
@@ -798,6 +958,7 @@ var allEnums = []*enumInfo{
 			{Key: "markov_letter", OldKeys: []string{"markov_chain"}},
 			{Key: "markov_run"},
 			{Key: "compound"},
+			{Key: "syllable"},
 		},
 	},
 	{
@@ -911,6 +1072,89 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/quality",
+		Name: "target",
+		Desc: "holds the kind of equipment a quality preset may be applied to",
+		Values: []*enumValue{
+			{Key: "any", String: "Any equipment"},
+			{Name: "MeleeWeapon", Key: "melee", String: "Melee weapons"},
+			{Name: "RangedWeapon", Key: "ranged", String: "Ranged weapons"},
+			{Key: "armor", String: "Armor"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/recharge",
+		Name: "type",
+		Desc: "holds the way an item's uses are restored",
+		Values: []*enumValue{
+			{Key: "none", String: "Does not recharge"},
+			{Name: "PerDay", Key: "per_day", String: "Recharges daily"},
+			{Name: "PerSession", Key: "per_session", String: "Recharges each session"},
+			{Key: "consumable", String: "Consumable"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/reputation",
+		Name: "affected",
+		Desc: "holds the portion of people affected by a reputation",
+		Values: []*enumValue{
+			{Key: "everyone", String: "Almost everyone"},
+			{Key: "all_but_one_class", String: "Almost everyone except one large class"},
+			{Key: "large_class", String: "A large class of people"},
+			{Key: "small_class", String: "A small class of people"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/reputation",
+		Name: "recognition",
+		Desc: "holds the frequency with which a reputation is recognized",
+		Values: []*enumValue{
+			{Key: "always", String: "All the time"},
+			{Key: "sometimes", String: "Sometimes (10 or less)"},
+			{Key: "occasionally", String: "Occasionally (7 or less)"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/rest",
+		Name: "kind",
+		Desc: "holds the kind of rest a character may take to recover spent pools",
+		Values: []*enumValue{
+			{Name: "Short", Key: "short", String: "Short Rest"},
+			{Name: "Long", Key: "long", String: "Long Rest"},
+			{Key: "sleep", String: "Sleep"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/rpm",
+		Name: "effect",
+		Desc: "holds one of the effects used to build a Ritual Path Magic ritual",
+		Values: []*enumValue{
+			{Key: "sense", String: "Sense"},
+			{Key: "strengthen", String: "Strengthen"},
+			{Key: "restore", String: "Restore"},
+			{Key: "control", String: "Control"},
+			{Key: "destroy", String: "Destroy"},
+			{Key: "create", String: "Create"},
+			{Key: "transform", String: "Transform"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/rpm",
+		Name: "path",
+		Desc: "holds one of the paths of Ritual Path Magic",
+		Values: []*enumValue{
+			{Key: "body", String: "Body"},
+			{Key: "chance", String: "Chance"},
+			{Key: "crossroads", String: "Crossroads"},
+			{Key: "energy", String: "Energy"},
+			{Key: "magic", String: "Magic"},
+			{Key: "matter", String: "Matter"},
+			{Key: "mind", String: "Mind"},
+			{Key: "spirit", String: "Spirit"},
+			{Key: "undead", String: "Undead"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/selfctrl",
 		Name: "adjustment",
@@ -953,6 +1197,34 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/sense",
+		Name: "type",
+		Desc: "holds the type of sense used for a Perception-based roll",
+		Values: []*enumValue{
+			{Key: "vision"},
+			{Key: "hearing"},
+			{Key: "taste_smell", String: "Taste & Smell"},
+			{Key: "touch"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/sheetmode",
+		Name: "mode",
+		Desc: "holds the mode a character sheet is being used in",
+		Values: []*enumValue{
+			{
+				Name:   "Play",
+				Key:    "play",
+				String: "Play",
+			},
+			{
+				Name:   "Creation",
+				Key:    "creation",
+				String: "Creation",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/skillsel",
 		Name: "type",
@@ -973,6 +1245,32 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/skillsort",
+		Name: "order",
+		Desc: "holds the order in which skills and spells are listed on a sheet",
+		Values: []*enumValue{
+			{
+				Name:   "Manual",
+				Key:    "manual",
+				String: "As Arranged",
+			},
+			{
+				Name:   "EffectiveLevel",
+				Key:    "effective_level",
+				String: "By Effective Level",
+			},
+			{
+				Name:   "PointsPerLevel",
+				Key:    "points_per_level",
+				String: "By Points per Level",
+			},
+			{
+				Key:    "attribute",
+				String: "By Attribute",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/spellcmp",
 		Name: "type",
@@ -1233,6 +1531,72 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/vtarget",
+		Name: "type",
+		Desc: "holds the type of data a validation rule is applied to",
+		Values: []*enumValue{
+			{
+				Name:   "Character",
+				Key:    "character",
+				String: "to the character",
+			},
+			{
+				Name:   "Attributes",
+				Key:    "attributes",
+				String: "to each attribute",
+			},
+			{
+				Name:   "Traits",
+				Key:    "traits",
+				String: "to each trait",
+			},
+			{
+				Name:   "Skills",
+				Key:    "skills",
+				String: "to each skill",
+			},
+			{
+				Name:   "Spells",
+				Key:    "spells",
+				String: "to each spell",
+			},
+			{
+				Name:   "Equipment",
+				Key:    "equipment",
+				String: "to each piece of equipment",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/wound",
+		Name: "type",
+		Desc: "holds the type of damage an attack inflicts",
+		Values: []*enumValue{
+			{Key: "crushing", String: "Crushing (cr)"},
+			{Key: "cutting", String: "Cutting (cut)"},
+			{Key: "impaling", String: "Impaling (imp)"},
+			{Key: "small_piercing", String: "Small Piercing (pi-)"},
+			{Key: "piercing", String: "Piercing (pi)"},
+			{Key: "large_piercing", String: "Large Piercing (pi+)"},
+			{Key: "huge_piercing", String: "Huge Piercing (pi++)"},
+			{Key: "burning", String: "Burning (burn)"},
+			{Key: "corrosion", String: "Corrosion (cor)"},
+			{Key: "toxic", String: "Toxic (tox)"},
+			{Key: "fatigue", String: "Fatigue (fat)"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/wound",
+		Name: "tolerance",
+		Desc: "holds the injury tolerance that alters how much injury penetrating damage inflicts",
+		Values: []*enumValue{
+			{Key: "none"},
+			{Key: "unliving"},
+			{Key: "homogeneous"},
+			{Key: "diffuse"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/wsel",
 		Name: "type",
@@ -1311,6 +1675,26 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/wtcond",
+		Name: "condition",
+		Desc: "holds the condition under which an equipment weight rule applies",
+		Values: []*enumValue{
+			{
+				Key:    "equipped",
+				String: "when equipped",
+			},
+			{
+				Name:   "NotEquipped",
+				Key:    "not_equipped",
+				String: "when not equipped",
+			},
+			{
+				Key:    "contained",
+				String: "when inside a container",
+			},
+		},
+	},
 	{
 		Pkg:  "model/paper",
 		Name: "orientation",
@@ -0,0 +1,183 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package imgutil
+
+import (
+	"bytes"
+	"hash/fnv"
+	"image"
+	_ "image/gif"  // Register the gif decoder
+	_ "image/jpeg" // Register the jpeg decoder
+	_ "image/png"  // Register the png decoder
+	"sync"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register the webp decoder
+)
+
+const maxThumbnails = 64
+
+var thumbnails = &thumbnailCache{entries: make(map[thumbnailKey]*thumbnailEntry)}
+
+type thumbnailKey struct {
+	hash         uint64
+	length       int
+	maxDimension int
+}
+
+type thumbnailEntry struct {
+	img     *unison.Image
+	waiters []func()
+	pending bool
+}
+
+type thumbnailCache struct {
+	lock    sync.Mutex
+	entries map[thumbnailKey]*thumbnailEntry
+	order   []thumbnailKey
+}
+
+// Thumbnail returns a downscaled image for the image data, no larger than maxDimension pixels on either side. If the
+// thumbnail has not been created yet, nil is returned and the data is decoded and scaled on a background goroutine.
+// Once it is available, ready will be called on the UI thread, at which point a subsequent call will return the
+// thumbnail. Data that fails to decode always returns nil.
+func Thumbnail(data []byte, maxDimension int, ready func()) *unison.Image {
+	if len(data) == 0 || maxDimension < 1 {
+		return nil
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data) //nolint:errcheck // Cannot fail
+	key := thumbnailKey{hash: h.Sum64(), length: len(data), maxDimension: maxDimension}
+	return thumbnails.lookup(key, data, ready)
+}
+
+// ThumbnailNow works like Thumbnail, but decodes and scales the image data on the calling goroutine if it isn't
+// already available, rather than deferring the work. This is intended for callers, such as exports, that need the
+// image immediately.
+func ThumbnailNow(data []byte, maxDimension int) *unison.Image {
+	if len(data) == 0 || maxDimension < 1 {
+		return nil
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data) //nolint:errcheck // Cannot fail
+	key := thumbnailKey{hash: h.Sum64(), length: len(data), maxDimension: maxDimension}
+	thumbnails.lock.Lock()
+	if entry, ok := thumbnails.entries[key]; ok && !entry.pending {
+		thumbnails.lock.Unlock()
+		return entry.img
+	}
+	thumbnails.lock.Unlock()
+	pixels, err := scaledPixels(data, maxDimension)
+	if err != nil {
+		errs.Log(err)
+		return nil
+	}
+	img, err := unison.NewImageFromPixels(pixels.Rect.Dx(), pixels.Rect.Dy(), pixels.Pix, 0.5)
+	if err != nil {
+		errs.Log(errs.NewWithCause("unable to create thumbnail", err))
+		return nil
+	}
+	thumbnails.lock.Lock()
+	entry, ok := thumbnails.entries[key]
+	if !ok {
+		entry = &thumbnailEntry{}
+		thumbnails.entries[key] = entry
+		thumbnails.order = append(thumbnails.order, key)
+	}
+	if entry.img == nil {
+		entry.img = img
+	}
+	thumbnails.lock.Unlock()
+	return img
+}
+
+func (c *thumbnailCache) lookup(key thumbnailKey, data []byte, ready func()) *unison.Image {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		if entry.pending && ready != nil {
+			entry.waiters = append(entry.waiters, ready)
+		}
+		return entry.img
+	}
+	entry := &thumbnailEntry{pending: true}
+	if ready != nil {
+		entry.waiters = append(entry.waiters, ready)
+	}
+	c.entries[key] = entry
+	c.order = append(c.order, key)
+	for len(c.order) > maxThumbnails {
+		oldest := c.order[0]
+		if e := c.entries[oldest]; e != nil && e.pending {
+			break
+		}
+		delete(c.entries, oldest)
+		c.order = c.order[1:]
+	}
+	go c.decode(key, data)
+	return nil
+}
+
+func (c *thumbnailCache) decode(key thumbnailKey, data []byte) {
+	pixels, err := scaledPixels(data, key.maxDimension)
+	if err != nil {
+		errs.Log(err)
+	}
+	// Creating the image itself must happen on the UI thread, but the expensive part has already been done.
+	unison.InvokeTask(func() {
+		var img *unison.Image
+		if pixels != nil {
+			if img, err = unison.NewImageFromPixels(pixels.Rect.Dx(), pixels.Rect.Dy(), pixels.Pix, 0.5); err != nil {
+				errs.Log(errs.NewWithCause("unable to create thumbnail", err))
+				img = nil
+			}
+		}
+		c.lock.Lock()
+		entry := c.entries[key]
+		var waiters []func()
+		if entry != nil {
+			entry.img = img
+			entry.pending = false
+			waiters = entry.waiters
+			entry.waiters = nil
+		}
+		c.lock.Unlock()
+		for _, f := range waiters {
+			f()
+		}
+	})
+}
+
+func scaledPixels(data []byte, maxDimension int) (*image.NRGBA, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errs.NewWithCause("unable to decode image", err)
+	}
+	bounds := src.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width < 1 || height < 1 {
+		return nil, errs.New("image has no content")
+	}
+	if width > maxDimension || height > maxDimension {
+		if width > height {
+			height = max(height*maxDimension/width, 1)
+			width = maxDimension
+		} else {
+			width = max(width*maxDimension/height, 1)
+			height = maxDimension
+		}
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Rect, src, bounds, draw.Src, nil)
+	return dst, nil
+}
@@ -57,22 +57,51 @@ func main() {
 	var convertFiles bool
 	cl.NewGeneralOption(&convertFiles).SetName("convert").SetSingle('c').
 		SetUsage(i18n.Text("Converts all files specified on the command line to the current data format. If a directory is specified, it will be traversed recursively and all files found will be converted. After all files have been processed, GCS will exit"))
+	var dryRun bool
+	cl.NewGeneralOption(&dryRun).SetName("dry-run").
+		SetUsage(i18n.Text("When used with --convert, reports which files would be upgraded and what would change in them, without modifying anything"))
 	var syncSheetsAndTemplates bool
 	cl.NewGeneralOption(&syncSheetsAndTemplates).SetName("sync").SetSingle('S').
 		SetUsage(fmt.Sprintf(i18n.Text("Syncs all character sheet (%s) and template (%s) files specified on the command line with their library sources. If a directory is specified, it will be traversed recursively and all files found will be converted. After all files have been processed, GCS will exit"), gurps.SheetExt, gurps.TemplatesExt))
 	cl.NewGeneralOption(&fxp.DebugVariableResolver).SetName("debug-variable-resolver")
 	var backgroundOnly bool
 	cl.NewGeneralOption(&backgroundOnly).SetName("web-server-only").SetSingle('w').SetUsage(i18n.Text("Starts the web server and does not bring up the user interface. If the server has not been configured, just exits"))
+	cmds := []cmdline.Cmd{&ux.ExportCmd{}, &server.ServeCmd{}}
+	for _, cmd := range cmds {
+		cl.AddCommand(cmd)
+	}
 	fileList := rotation.ParseAndSetupLogging(cl, false)
 	slog.SetDefault(slog.New(tracelog.New(&tracelog.Config{Sink: log.Default().Writer()})))
 	ux.RegisterKnownFileTypes()
 	settings := gurps.GlobalSettings() // Here to force early initialization
 
+	// A file that happens to share a command's name is still opened as a file.
+	if len(fileList) != 0 && !fs.FileExists(fileList[0]) {
+		for _, cmd := range cmds {
+			if fileList[0] == cmd.Name() {
+				if err := cl.RunCommand(fileList); err != nil {
+					cl.FatalMsg(err.Error())
+				}
+				atexit.Exit(0)
+			}
+		}
+	}
+
 	if convertFiles && syncSheetsAndTemplates {
 		cl.FatalMsg(i18n.Text("Cannot specify both --convert and --sync"))
 	}
 
+	if dryRun && !convertFiles {
+		cl.FatalMsg(i18n.Text("--dry-run may only be used with --convert"))
+	}
+
 	switch {
+	case convertFiles && dryRun:
+		report, err := gurps.PlanMigration(fileList...)
+		if err != nil {
+			cl.FatalMsg(err.Error())
+		}
+		fmt.Print(report.String())
 	case convertFiles:
 		if err := gurps.Convert(fileList...); err != nil {
 			cl.FatalMsg(err.Error())
@@ -37,6 +37,10 @@ var (
 var (
 	Header   = &unison.ThemeColor{Light: unison.RGB(80, 80, 80), Dark: unison.RGB(64, 64, 64)}
 	OnHeader = Header.DeriveOn()
+	Gain     = &unison.ThemeColor{Light: unison.RGB(0, 128, 0), Dark: unison.RGB(64, 176, 64)}
+	OnGain   = Gain.DeriveOn()
+	Loss     = &unison.ThemeColor{Light: unison.RGB(176, 0, 0), Dark: unison.RGB(208, 64, 64)}
+	OnLoss   = Loss.DeriveOn()
 )
 
 // ThemedColor holds a themed color.
@@ -77,6 +81,8 @@ func initialize() {
 		{ID: "tooltip", Title: "Tooltip", Color: unison.ThemeTooltip},
 		{ID: "error", Title: "Error", Color: unison.ThemeError},
 		{ID: "warning", Title: "Warning", Color: unison.ThemeWarning},
+		{ID: "gain", Title: "Points Gain", Color: Gain},
+		{ID: "loss", Title: "Points Loss", Color: Loss},
 	}
 	factory = make([]*ThemedColor, len(current))
 	for i, c := range current {
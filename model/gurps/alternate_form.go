@@ -0,0 +1,101 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+var (
+	alternateFormPercentage = fxp.From(90)
+	alternateFormMinimum    = fxp.Fifteen
+)
+
+// IsAlternateForm returns true if the trait is a container holding the traits of an alternate form.
+func (t *Trait) IsAlternateForm() bool {
+	return t.Container() && t.ContainerType == container.AlternateForm
+}
+
+// alternateFormCost returns the cost of the Alternate Form advantage for this form: 90% of the cost of the traits the
+// form adds, with a minimum of 15 points.
+func (t *Trait) alternateFormCost() fxp.Int {
+	var points fxp.Int
+	for _, one := range t.Children {
+		points += one.formPackagePoints()
+	}
+	return fxp.ApplyRounding(calculateModifierPoints(points, alternateFormPercentage), t.RoundCostDown).
+		Max(alternateFormMinimum)
+}
+
+// formPackagePoints returns the points of the trait as part of an alternate form's package, whether or not the form is
+// currently active.
+func (t *Trait) formPackagePoints() fxp.Int {
+	if t.IsAlternateForm() {
+		return t.alternateFormCost()
+	}
+	if t.Disabled {
+		return 0
+	}
+	return t.pointsWith((*Trait).formPackagePoints)
+}
+
+// AlternateForms returns the alternate forms the character has.
+func (e *Entity) AlternateForms() []*Trait {
+	var list []*Trait
+	Traverse(func(t *Trait) bool {
+		if t.IsAlternateForm() {
+			list = append(list, t)
+		}
+		return false
+	}, false, false, e.Traits...)
+	return list
+}
+
+// ActiveAlternateForm returns the alternate form the character is currently in, or nil if they are in their native
+// form.
+func (e *Entity) ActiveAlternateForm() *Trait {
+	for _, form := range e.AlternateForms() {
+		if !form.Disabled {
+			return form
+		}
+	}
+	return nil
+}
+
+// SwitchAlternateForm makes the given form the active one, disabling the traits of all others. Pass nil to return to
+// the native form. The switch is recorded in the change log. Returns false if the character was already in that form.
+func (e *Entity) SwitchAlternateForm(form *Trait) bool {
+	forms := e.AlternateForms()
+	changed := false
+	for _, one := range forms {
+		if disabled := one != form; one.Disabled != disabled {
+			one.Disabled = disabled
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+	name := i18n.Text("native form")
+	if form != nil {
+		name = form.String()
+	}
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: fmt.Sprintf(i18n.Text("Switched to %s"), name),
+	})
+	e.Recalculate()
+	return true
+}
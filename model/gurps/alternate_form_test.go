@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAlternateForms(t *testing.T) {
+	e := NewEntity()
+	newForm := func(name string, points int) *Trait {
+		form := NewTrait(e, nil, true)
+		form.Name = name
+		form.ContainerType = container.AlternateForm
+		child := NewTrait(e, form, false)
+		child.Name = name + " Trait"
+		child.BasePoints = fxp.From(points)
+		form.Children = []*Trait{child}
+		return form
+	}
+	wolf := newForm("Wolf", 40)
+	bird := newForm("Bird", 10)
+	e.Traits = []*Trait{wolf, bird}
+	e.Recalculate()
+
+	check.Equal(t, fxp.From(36), wolf.AdjustedPoints(), "90% of the form's traits")
+	check.Equal(t, fxp.Fifteen, bird.AdjustedPoints(), "minimum of 15 points")
+
+	check.True(t, e.SwitchAlternateForm(nil))
+	check.Nil(t, e.ActiveAlternateForm())
+	check.True(t, wolf.Disabled)
+	check.True(t, bird.Disabled)
+	check.Equal(t, fxp.From(36), wolf.AdjustedPoints(), "inactive forms are still paid for")
+	check.Equal(t, fxp.From(51), e.PointsBreakdown().Advantages)
+
+	check.True(t, e.SwitchAlternateForm(wolf))
+	check.Equal(t, wolf, e.ActiveAlternateForm())
+	check.False(t, wolf.Disabled)
+	check.True(t, bird.Disabled)
+	check.Equal(t, fxp.From(51), e.PointsBreakdown().Advantages, "switching doesn't change the cost")
+	check.False(t, e.SwitchAlternateForm(wolf), "already in that form")
+	check.Equal(t, 2, len(e.ChangeLog))
+}
@@ -94,6 +94,17 @@ func (a *Ancestry) RandomGender(not string) string {
 	return ""
 }
 
+// GenderNames returns the names of the genders this ancestry has options for.
+func (a *Ancestry) GenderNames() []string {
+	list := make([]string, 0, len(a.GenderOptions))
+	for _, one := range a.GenderOptions {
+		if one.Value != nil && one.Value.Name != "" {
+			list = append(list, one.Value.Name)
+		}
+	}
+	return list
+}
+
 // GenderedOptions returns the options for the specified gender, or nil.
 func (a *Ancestry) GenderedOptions(gender string) *AncestryOptions {
 	gender = strings.TrimSpace(gender)
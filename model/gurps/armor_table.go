@@ -0,0 +1,124 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// ArmorPiece holds a piece of equipped armor and the DR it provides at a hit location, keyed by the type of attack it
+// applies to. Flexible is set if any of that DR is flexible.
+type ArmorPiece struct {
+	Equipment *Equipment
+	DR        map[string]int
+	Flexible  bool
+}
+
+// ArmorLocation holds the equipped armor covering a hit location, along with the subtotals for it.
+type ArmorLocation struct {
+	Location *HitLocation
+	Pieces   []*ArmorPiece
+	DR       map[string]int
+	Weight   fxp.Weight
+	Cost     fxp.Int
+}
+
+// ArmorTable returns the hit locations covered by equipped armor, in the order they appear in the body type, along with
+// the pieces covering each. Armor is any equipped equipment that provides DR. Armor covering a location that has a
+// sub-table also covers the locations within the sub-table, just as it does for the DR shown on the sheet.
+func (e *Entity) ArmorTable() []*ArmorLocation {
+	var list []*ArmorLocation
+	seen := make(map[string]bool)
+	defUnits := e.SheetSettings.DefaultWeightUnits
+	var walk func(body *Body)
+	walk = func(body *Body) {
+		for _, loc := range body.Locations {
+			if !seen[loc.LocID] {
+				seen[loc.LocID] = true
+				if armorLoc := e.armorLocation(loc, defUnits); armorLoc != nil {
+					list = append(list, armorLoc)
+				}
+			}
+			if loc.SubTable != nil {
+				walk(loc.SubTable)
+			}
+		}
+	}
+	walk(e.SheetSettings.BodyType)
+	return list
+}
+
+func (e *Entity) armorLocation(loc *HitLocation, defUnits fxp.WeightUnit) *ArmorLocation {
+	var armorLoc *ArmorLocation
+	pieces := make(map[*Equipment]*ArmorPiece)
+	for h := loc; h != nil; {
+		for _, bonus := range e.drBonusesFor(h.LocID) {
+			eqp, ok := bonus.Owner().(*Equipment)
+			if !ok {
+				continue
+			}
+			if armorLoc == nil {
+				armorLoc = &ArmorLocation{
+					Location: loc,
+					DR:       make(map[string]int),
+				}
+			}
+			piece, exists := pieces[eqp]
+			if !exists {
+				piece = &ArmorPiece{
+					Equipment: eqp,
+					DR:        make(map[string]int),
+				}
+				pieces[eqp] = piece
+				armorLoc.Pieces = append(armorLoc.Pieces, piece)
+				armorLoc.Weight += eqp.ExtendedWeight(false, defUnits)
+				armorLoc.Cost += eqp.ExtendedValue()
+			}
+			key := strings.ToLower(bonus.Specialization)
+			amt := fxp.As[int](bonus.AdjustedAmount())
+			piece.DR[key] += amt
+			piece.Flexible = piece.Flexible || bonus.Flexible
+			armorLoc.DR[key] += amt
+		}
+		if h.owningTable == nil {
+			break
+		}
+		h = h.owningTable.owningLocation
+	}
+	return armorLoc
+}
+
+// ArmorTotals returns the total weight and cost of the armor in the table, counting each piece only once, no matter how
+// many locations it covers.
+func ArmorTotals(table []*ArmorLocation, defUnits fxp.WeightUnit) (weight fxp.Weight, cost fxp.Int) {
+	seen := make(map[*Equipment]bool)
+	for _, loc := range table {
+		for _, piece := range loc.Pieces {
+			if !seen[piece.Equipment] {
+				seen[piece.Equipment] = true
+				weight += piece.Equipment.ExtendedWeight(false, defUnits)
+				cost += piece.Equipment.ExtendedValue()
+			}
+		}
+	}
+	return weight, cost
+}
+
+// DoffArmor unequips all of the equipped armor.
+func (e *Entity) DoffArmor() {
+	for _, loc := range e.ArmorTable() {
+		for _, piece := range loc.Pieces {
+			piece.Equipment.Equipped = false
+		}
+	}
+	e.Recalculate()
+}
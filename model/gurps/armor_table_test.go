@@ -0,0 +1,65 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestArmorTable(t *testing.T) {
+	e := gurps.NewEntity()
+	vest := gurps.NewEquipment(e, nil, false)
+	vest.Name = "Vest"
+	vest.Value = fxp.From(100)
+	vestDR := gurps.NewDRBonus()
+	vestDR.Amount = fxp.Three
+	vest.Features = gurps.Features{vestDR}
+	coat := gurps.NewEquipment(e, nil, false)
+	coat.Name = "Coat"
+	coat.Value = fxp.From(50)
+	coatDR := gurps.NewDRBonus()
+	coatDR.Locations = []string{gurps.TorsoID, "arm"}
+	coat.Features = gurps.Features{coatDR}
+	sword := gurps.NewEquipment(e, nil, false)
+	sword.Name = "Sword"
+	sword.Value = fxp.From(500)
+	e.CarriedEquipment = []*gurps.Equipment{vest, coat, sword}
+	e.Recalculate()
+
+	table := e.ArmorTable()
+	var torso, arm *gurps.ArmorLocation
+	for _, one := range table {
+		switch one.Location.LocID {
+		case gurps.TorsoID:
+			torso = one
+		case "arm":
+			arm = one
+		}
+	}
+	check.NotNil(t, torso)
+	check.Equal(t, 2, len(torso.Pieces))
+	check.Equal(t, 4, torso.DR[gurps.AllID])
+	check.Equal(t, fxp.From(150), torso.Cost)
+	check.NotNil(t, arm)
+	check.Equal(t, 1, len(arm.Pieces))
+	check.Equal(t, coat, arm.Pieces[0].Equipment)
+	_, cost := gurps.ArmorTotals(table, e.SheetSettings.DefaultWeightUnits)
+	check.Equal(t, fxp.From(150), cost, "each piece is only counted once")
+
+	e.DoffArmor()
+	check.False(t, vest.Equipped)
+	check.False(t, coat.Equipped)
+	check.True(t, sword.Equipped)
+	check.Equal(t, 0, len(e.ArmorTable()))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/assoc"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Associate holds a Contact, Patron, Ally, or Enemy of a character.
+type Associate struct {
+	Name           string            `json:"name"`
+	Kind           assoc.Kind        `json:"kind"`
+	EffectiveSkill int               `json:"effective_skill,omitempty"`
+	Reliability    assoc.Reliability `json:"reliability,omitempty"`
+	Frequency      assoc.Frequency   `json:"frequency"`
+	BasePoints     fxp.Int           `json:"base_points,omitempty"`
+	TraitID        tid.TID           `json:"trait_id,omitempty"`
+	Notes          string            `json:"notes,omitempty"`
+}
+
+// NewAssociate creates a new Associate.
+func NewAssociate(kind assoc.Kind) *Associate {
+	a := &Associate{
+		Kind:      kind,
+		Frequency: assoc.Nine,
+	}
+	switch kind {
+	case assoc.Contact:
+		a.EffectiveSkill = 12
+		a.Reliability = assoc.Somewhat
+	case assoc.Enemy:
+		a.BasePoints = -fxp.Ten
+	default:
+		a.BasePoints = fxp.Ten
+	}
+	return a
+}
+
+// Cost returns the number of points this associate should cost. Contacts derive their base cost from their effective
+// skill and are further adjusted by their reliability. All are adjusted by their frequency of appearance.
+func (a *Associate) Cost() fxp.Int {
+	base := a.BasePoints
+	if a.Kind == assoc.Contact {
+		base = fxp.From(min(max((a.EffectiveSkill-9)/3, 1), 4)).Mul(a.Reliability.Multiplier())
+	}
+	cost := base.Mul(a.Frequency.Multiplier()).Ceil()
+	if a.Kind == assoc.Contact && cost < fxp.One {
+		cost = fxp.One
+	}
+	return cost
+}
+
+// RollAppearance makes a roll to determine whether the associate appears. The second return value is false if no roll
+// is needed because the associate is always present.
+func (a *Associate) RollAppearance() (SuccessRoll, bool) {
+	target := a.Frequency.Target()
+	if target == 0 {
+		return SuccessRoll{}, false
+	}
+	return RollAgainst(target), true
+}
+
+// CloneAssociateList creates a clone of the provided Associate list.
+func CloneAssociateList(list []*Associate) []*Associate {
+	clone := make([]*Associate, len(list))
+	for i := 0; i < len(list); i++ {
+		a := *list[i]
+		clone[i] = &a
+	}
+	return clone
+}
+
+// SetAssociates sets a new associate list.
+func (e *Entity) SetAssociates(list []*Associate) {
+	e.Associates = CloneAssociateList(list)
+}
+
+// TraitByID returns the trait with the given ID, or nil.
+func (e *Entity) TraitByID(id tid.TID) *Trait {
+	var found *Trait
+	Traverse(func(t *Trait) bool {
+		if t.TID == id {
+			found = t
+			return true
+		}
+		return false
+	}, false, false, e.Traits...)
+	return found
+}
+
+func (e *Entity) checkAssociates(issues []*ValidationIssue) []*ValidationIssue {
+	for _, one := range e.Associates {
+		if strings.TrimSpace(one.Name) == "" || one.TraitID == "" {
+			continue
+		}
+		t := e.TraitByID(one.TraitID)
+		if t == nil {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("%s %s is linked to a trait that no longer exists"), one.Kind.String(),
+					one.Name),
+			})
+			continue
+		}
+		if cost, spent := one.Cost(), t.AdjustedPoints(); cost != spent {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("%s %s should cost %s points, but the linked trait %s costs %s"),
+					one.Kind.String(), one.Name, cost.String(), t.String(), spent.String()),
+			})
+		}
+	}
+	return issues
+}
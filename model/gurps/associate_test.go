@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/assoc"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAssociateCost(t *testing.T) {
+	a := NewAssociate(assoc.Contact)
+	a.EffectiveSkill = 15
+	a.Reliability = assoc.Usually
+	a.Frequency = assoc.Twelve
+	check.Equal(t, fxp.Eight, a.Cost())
+
+	a.EffectiveSkill = 12
+	a.Reliability = assoc.Unreliable
+	a.Frequency = assoc.Six
+	check.Equal(t, fxp.One, a.Cost(), "contacts cost at least 1 point")
+
+	a = NewAssociate(assoc.Enemy)
+	a.BasePoints = -fxp.Twenty
+	a.Frequency = assoc.Six
+	check.Equal(t, -fxp.Ten, a.Cost())
+
+	a = NewAssociate(assoc.Ally)
+	a.Frequency = assoc.Constantly
+	_, ok := a.RollAppearance()
+	check.False(t, ok)
+}
+
+func TestAssociateValidation(t *testing.T) {
+	e := NewEntity()
+	trait := NewTrait(e, nil, false)
+	trait.Name = "Patron"
+	trait.BasePoints = fxp.Twenty
+	e.Traits = []*Trait{trait}
+	a := NewAssociate(assoc.Patron)
+	a.Name = "The Guild"
+	a.Frequency = assoc.Twelve
+	a.TraitID = trait.TID
+	e.Associates = []*Associate{a}
+	e.Recalculate()
+	check.Equal(t, 0, len(e.checkAssociates(nil)))
+
+	a.Frequency = assoc.Fifteen
+	check.Equal(t, 1, len(e.checkAssociates(nil)))
+
+	e.Traits = nil
+	check.Equal(t, 1, len(e.checkAssociates(nil)), "missing linked trait")
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+const backupTimestampFormat = "20060102-150405.000"
+
+// BackupDir returns the directory automatic backups are written into. It lives next to the settings file.
+func BackupDir() string {
+	return filepath.Join(filepath.Dir(SettingsPath), cmdline.AppCmdName+"_backups")
+}
+
+// backupDirFor returns the directory within dir that holds the backups of the file at filePath. The checksum of the
+// path keeps files with the same name in different directories apart.
+func backupDirFor(dir, filePath string) string {
+	name := filepath.Base(filePath)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return filepath.Join(dir, fmt.Sprintf("%s-%08x", name, crc32.ChecksumIEEE([]byte(filePath))))
+}
+
+// Backups returns the paths of the backups in dir of the file at filePath, oldest first.
+func Backups(dir, filePath string) ([]string, error) {
+	backupDir := backupDirFor(dir, filePath)
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	list := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			list = append(list, filepath.Join(backupDir, entry.Name()))
+		}
+	}
+	// The names start with a timestamp, so sorting them puts them in chronological order.
+	slices.Sort(list)
+	return list, nil
+}
+
+// WriteBackup writes data, the current content of the file at filePath, as a timestamped backup in dir, noting the
+// operation that is about to be performed in the backup's name. If the most recent backup already holds the same
+// content, no new backup is written and an empty path is returned. Once written, the oldest backups beyond the
+// retention count are removed.
+func WriteBackup(dir, filePath, operation string, data any, retention int) (string, error) {
+	var buffer bytes.Buffer
+	if err := jio.Save(context.Background(), &buffer, data); err != nil {
+		return "", err
+	}
+	list, err := Backups(dir, filePath)
+	if err != nil {
+		return "", err
+	}
+	if len(list) != 0 {
+		var last []byte
+		if last, err = os.ReadFile(list[len(list)-1]); err == nil && bytes.Equal(last, buffer.Bytes()) {
+			return "", nil
+		}
+	}
+	backupDir := backupDirFor(dir, filePath)
+	if err = os.MkdirAll(backupDir, 0o750); err != nil {
+		return "", errs.Wrap(err)
+	}
+	now := time.Now()
+	name := now.Format(backupTimestampFormat)
+	if len(list) != 0 {
+		// Keep the names in chronological order, even if the previous backup was written within the same millisecond.
+		last := filepath.Base(list[len(list)-1])
+		for len(last) >= len(name) && name <= last[:len(name)] {
+			now = now.Add(time.Millisecond)
+			name = now.Format(backupTimestampFormat)
+		}
+	}
+	if operation = strings.TrimSpace(operation); operation != "" {
+		name += " " + strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`/\:*?"<>|`, r) {
+				return '_'
+			}
+			return r
+		}, operation)
+	}
+	backupPath := filepath.Join(backupDir, name+filepath.Ext(filePath))
+	if err = os.WriteFile(backupPath, buffer.Bytes(), 0o640); err != nil {
+		return "", errs.Wrap(err)
+	}
+	list = append(list, backupPath)
+	for len(list) > max(retention, 1) {
+		if err = os.Remove(list[0]); err != nil {
+			return backupPath, errs.Wrap(err)
+		}
+		list = list[1:]
+	}
+	return backupPath, nil
+}
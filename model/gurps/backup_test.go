@@ -0,0 +1,48 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWriteBackup(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join("characters", "Hero"+SheetExt)
+	data := map[string]int{"version": 1}
+
+	first, err := WriteBackup(dir, filePath, "Apply Template", data, 2)
+	check.NoError(t, err)
+	check.True(t, strings.HasSuffix(first, " Apply Template"+SheetExt))
+	second, err := WriteBackup(dir, filePath, "Merge", data, 2)
+	check.NoError(t, err)
+	check.Equal(t, "", second, "unchanged content is not backed up again")
+
+	for i := 2; i <= 4; i++ {
+		data["version"] = i
+		_, err = WriteBackup(dir, filePath, "Sync", data, 2)
+		check.NoError(t, err)
+	}
+	list, err := Backups(dir, filePath)
+	check.NoError(t, err)
+	check.Equal(t, 2, len(list), "older backups beyond the retention count are removed")
+	content, err := os.ReadFile(list[1])
+	check.NoError(t, err)
+	check.Contains(t, string(content), `"version": 4`)
+
+	list, err = Backups(dir, filepath.Join("other", "Hero"+SheetExt))
+	check.NoError(t, err)
+	check.Equal(t, 0, len(list), "files with the same name elsewhere are kept apart")
+}
@@ -34,6 +34,10 @@ type BodyData struct {
 	Name      string         `json:"name,omitempty"`
 	Roll      *dice.Dice     `json:"roll"`
 	Locations []*HitLocation `json:"locations,omitempty"`
+	// Weighted tables treat each location's slots as a relative weight and are rolled with a single die having as many
+	// sides as the total weight, rather than with Roll. This suits tables that don't map neatly onto a 3d or 1d roll,
+	// such as those used for many animals and vehicles.
+	Weighted bool `json:"weighted,omitempty"`
 }
 
 // Body holds a set of hit locations.
@@ -113,6 +117,7 @@ func (b *Body) Clone(entity *Entity, owningLocation *HitLocation) *Body {
 			Name:      b.Name,
 			Roll:      dice.New(b.Roll.String()),
 			Locations: make([]*HitLocation, len(b.Locations)),
+			Weighted:  b.Weighted,
 		},
 		owningLocation: owningLocation,
 	}
@@ -155,7 +160,7 @@ func (b *Body) SetOwningLocation(loc *HitLocation) {
 }
 
 func (b *Body) updateRollRanges() {
-	start := b.Roll.Minimum(false)
+	start := b.TableRoll().Minimum(false)
 	for _, location := range b.Locations {
 		start = location.updateRollRange(start)
 	}
@@ -220,6 +225,9 @@ func (b *Body) CRC64() uint64 {
 func (b *Body) crc64(c uint64) uint64 {
 	c = crc.String(c, b.Name)
 	c = crc.String(c, b.Roll.String())
+	if b.Weighted {
+		c = crc.Bool(c, true)
+	}
 	c = crc.Number(c, len(b.Locations))
 	for _, loc := range b.Locations {
 		c = loc.crc64(c)
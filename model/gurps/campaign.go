@@ -13,6 +13,9 @@ import (
 	"bytes"
 	"context"
 	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/model/kinds"
@@ -29,17 +32,27 @@ type Campaign struct {
 
 // CampaignData holds the campaign file data.
 type CampaignData struct {
-	Version       int            `json:"version"`
-	ID            tid.TID        `json:"id"`
-	SheetSettings *SheetSettings `json:"settings,omitempty"`
-	Traits        []*Trait       `json:"traits,omitempty"`
-	Skills        []*Skill       `json:"skills,omitempty"`
-	Spells        []*Spell       `json:"spells,omitempty"`
-	Equipment     []*Equipment   `json:"equipment,omitempty"`
-	Notes         []*Note        `json:"notes,omitempty"`
-	Templates     []*Template    `json:"templates,omitempty"`
-	Characters    []*Entity      `json:"characters,omitempty"`
-	Documents     []*Document    `json:"documents,omitempty"`
+	Version        int                   `json:"version"`
+	ID             tid.TID               `json:"id"`
+	SheetSettings  *SheetSettings        `json:"settings,omitempty"`
+	NewCharacter   *NewCharacterDefaults `json:"new_character,omitempty"`
+	Traits         []*Trait              `json:"traits,omitempty"`
+	Skills         []*Skill              `json:"skills,omitempty"`
+	Spells         []*Spell              `json:"spells,omitempty"`
+	Equipment      []*Equipment          `json:"equipment,omitempty"`
+	Notes          []*Note               `json:"notes,omitempty"`
+	Templates      []*Template           `json:"templates,omitempty"`
+	Characters     []*Entity             `json:"characters,omitempty"`
+	Documents      []*Document           `json:"documents,omitempty"`
+	Members        []*CampaignMember     `json:"members,omitempty"`
+	RollWebhookURL string                `json:"roll_webhook_url,omitempty"`
+}
+
+// CampaignMember refers to a file that belongs to a campaign, such as a player character's sheet, a library of NPCs, a
+// template or a set of notes.
+type CampaignMember struct {
+	Path string `json:"path"`
+	NPC  bool   `json:"npc,omitempty"`
 }
 
 // NewCampaignFromFile loads a Campaign from a file.
@@ -51,6 +64,9 @@ func NewCampaignFromFile(fileSystem fs.FS, filePath string) (*Campaign, error) {
 	if err := jio.CheckVersion(campaign.Version); err != nil {
 		return nil, err
 	}
+	if ValidateRollWebhookURL(campaign.RollWebhookURL) != nil {
+		campaign.RollWebhookURL = ""
+	}
 	return &campaign, nil
 }
 
@@ -64,6 +80,87 @@ func NewCampaign() *Campaign {
 	}
 }
 
+// CloneCampaignMembers creates a clone of the provided CampaignMember list.
+func CloneCampaignMembers(list []*CampaignMember) []*CampaignMember {
+	clone := make([]*CampaignMember, len(list))
+	for i, one := range list {
+		member := *one
+		clone[i] = &member
+	}
+	return clone
+}
+
+// IsSheet returns true if the member is a character sheet.
+func (m *CampaignMember) IsSheet() bool {
+	return strings.EqualFold(filepath.Ext(m.Path), SheetExt)
+}
+
+// Member returns the member with the given path, or nil.
+func (c *Campaign) Member(filePath string) *CampaignMember {
+	filePath = filepath.Clean(filePath)
+	for _, one := range c.Members {
+		if one.Path == filePath {
+			return one
+		}
+	}
+	return nil
+}
+
+// PartySheetPaths returns the paths of the player character sheets in the campaign, in the order they were added.
+func (c *Campaign) PartySheetPaths() []string {
+	var list []string
+	for _, one := range c.Members {
+		if one.IsSheet() && !one.NPC {
+			list = append(list, one.Path)
+		}
+	}
+	return list
+}
+
+// AddMember adds the file at filePath to the campaign. Returns false if it was already a member.
+func (c *Campaign) AddMember(filePath string, npc bool) bool {
+	if c.Member(filePath) != nil {
+		return false
+	}
+	c.Members = append(c.Members, &CampaignMember{
+		Path: filepath.Clean(filePath),
+		NPC:  npc,
+	})
+	return true
+}
+
+// RemoveMember removes the file at filePath from the campaign. Returns false if it wasn't a member.
+func (c *Campaign) RemoveMember(filePath string) bool {
+	filePath = filepath.Clean(filePath)
+	for i, one := range c.Members {
+		if one.Path == filePath {
+			c.Members = slices.Delete(c.Members, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// ApplySheetSettingsTo replaces the entity's sheet settings with a copy of the campaign's, which take precedence over
+// the global sheet settings for all member sheets. Returns true if the entity's settings were changed.
+func (c *Campaign) ApplySheetSettingsTo(e *Entity) bool {
+	if c.SheetSettings == nil {
+		return false
+	}
+	var before, after bytes.Buffer
+	if err := jio.Save(context.Background(), &before, e.SheetSettings); err != nil {
+		errs.Log(err)
+	}
+	if err := jio.Save(context.Background(), &after, c.SheetSettings); err != nil {
+		errs.Log(err)
+	}
+	if bytes.Equal(before.Bytes(), after.Bytes()) {
+		return false
+	}
+	e.SheetSettings = c.SheetSettings.Clone(e)
+	return true
+}
+
 // Save the Campaign to a file as JSON.
 func (c *Campaign) Save(filePath string) error {
 	return jio.SaveToFile(context.Background(), filePath, c)
@@ -0,0 +1,63 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCampaignMembers(t *testing.T) {
+	c := NewCampaign()
+	hero := filepath.Join("campaign", "Hero"+SheetExt)
+	check.True(t, c.AddMember(hero, false))
+	check.False(t, c.AddMember(filepath.Join("campaign", ".", "Hero"+SheetExt), true), "paths are compared cleaned")
+	check.True(t, c.AddMember(filepath.Join("campaign", "Villains"), true))
+	check.Equal(t, 2, len(c.Members))
+	check.True(t, c.Member(hero).IsSheet())
+	check.False(t, c.Member(hero).NPC)
+	check.False(t, c.Members[1].IsSheet())
+	check.True(t, c.AddMember(filepath.Join("campaign", "Thug"+SheetExt), true))
+	check.Equal(t, []string{hero}, c.PartySheetPaths(), "NPCs and folders aren't part of the party")
+	check.True(t, c.RemoveMember(filepath.Join("campaign", "Thug"+SheetExt)))
+
+	clone := CloneCampaignMembers(c.Members)
+	check.True(t, c.RemoveMember(hero))
+	check.False(t, c.RemoveMember(hero))
+	check.Equal(t, 1, len(c.Members))
+	check.Equal(t, 2, len(clone), "clones are unaffected")
+}
+
+func TestCampaignSheetSettings(t *testing.T) {
+	c := NewCampaign()
+	e := NewEntity()
+	check.False(t, c.ApplySheetSettingsTo(e), "new sheets start with the same settings")
+	c.SheetSettings.UseHalfStatDefaults = !c.SheetSettings.UseHalfStatDefaults
+	check.True(t, c.ApplySheetSettingsTo(e))
+	check.Equal(t, c.SheetSettings.UseHalfStatDefaults, e.SheetSettings.UseHalfStatDefaults)
+	check.False(t, c.SheetSettings == e.SheetSettings, "the entity gets its own copy")
+	check.False(t, c.ApplySheetSettingsTo(e))
+}
+
+func TestCampaignRollWebhook(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Good.campaign": {Data: []byte(`{"version": 5, "roll_webhook_url": "https://discord.com/api/webhooks/1/abc"}`)},
+		"Bad.campaign":  {Data: []byte(`{"version": 5, "roll_webhook_url": "ftp://example.com/hook"}`)},
+	}
+	c, err := NewCampaignFromFile(fsys, "Good.campaign")
+	check.NoError(t, err)
+	check.Equal(t, "https://discord.com/api/webhooks/1/abc", c.RollWebhookURL)
+	c, err = NewCampaignFromFile(fsys, "Bad.campaign")
+	check.NoError(t, err)
+	check.Equal(t, "", c.RollWebhookURL, "unusable webhooks are dropped when loading")
+}
@@ -10,6 +10,7 @@
 package gurps
 
 import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
 	"github.com/richardwilkes/unison/enums/align"
 )
@@ -28,6 +29,18 @@ const (
 	HeaderStackedWeight = "stacked-weight"
 )
 
+// Possible values for CellData.Roll.
+const (
+	NoCellRoll CellRoll = iota
+	// SuccessCellRoll makes a success roll against the level in the cell's primary text.
+	SuccessCellRoll
+	// DamageCellRoll rolls the dice in the cell's primary text.
+	DamageCellRoll
+)
+
+// CellRoll identifies the roll, if any, made when a cell is clicked.
+type CellRoll byte
+
 // HeaderData holds data for creating a column header's visual representation.
 type HeaderData struct {
 	Title           string
@@ -47,8 +60,11 @@ type CellData struct {
 	Secondary         string
 	Tooltip           string
 	UnsatisfiedReason string
+	Warning           string
 	TemplateInfo      string
 	InlineTag         string
+	Roll              CellRoll
+	PointsDelta       fxp.Int
 }
 
 // ForSort returns a string that can be used to sort or search against for this data.
@@ -63,7 +79,7 @@ func (c *CellData) ForSort() string {
 		if c.Checked {
 			return "√"
 		}
-	case cell.PageRef, cell.Tags, cell.Markdown:
+	case cell.PageRef, cell.Tags, cell.Markdown, cell.Stepper:
 		return c.Primary
 	}
 	return ""
@@ -0,0 +1,137 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// ChangeLogEntry holds a description of a change made to a character while in play mode.
+type ChangeLogEntry struct {
+	When        jio.Time `json:"when"`
+	Description string   `json:"description"`
+}
+
+// ChangeSnapshot holds the state of a character at a point in time, for later comparison.
+type ChangeSnapshot struct {
+	totalPoints fxp.Int
+	attributes  map[string]changeSnapshotItem
+	attrOrder   []string
+	items       map[tid.TID]changeSnapshotItem
+	order       []tid.TID
+}
+
+type changeSnapshotItem struct {
+	kind   string
+	name   string
+	amount string
+}
+
+// TakeChangeSnapshot records the current state of the character so that changes can be logged later.
+func (e *Entity) TakeChangeSnapshot() *ChangeSnapshot {
+	snapshot := &ChangeSnapshot{
+		totalPoints: e.TotalPoints,
+		attributes:  make(map[string]changeSnapshotItem),
+		items:       make(map[tid.TID]changeSnapshotItem),
+	}
+	for _, attr := range e.Attributes.List() {
+		if def := attr.AttributeDef(); def != nil {
+			snapshot.attributes[attr.AttrID] = changeSnapshotItem{
+				kind:   i18n.Text("attribute"),
+				name:   def.Name,
+				amount: attr.Maximum().String(),
+			}
+			snapshot.attrOrder = append(snapshot.attrOrder, attr.AttrID)
+		}
+	}
+	Traverse(func(t *Trait) bool {
+		snapshot.add(t.TID, i18n.Text("trait"), t.String(), fmt.Sprintf(i18n.Text("%s points"), t.AdjustedPoints()))
+		return false
+	}, false, true, e.Traits...)
+	Traverse(func(s *Skill) bool {
+		snapshot.add(s.TID, i18n.Text("skill"), s.String(), fmt.Sprintf(i18n.Text("%s points"), s.Points))
+		return false
+	}, false, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		snapshot.add(s.TID, i18n.Text("spell"), s.String(), fmt.Sprintf(i18n.Text("%s points"), s.Points))
+		return false
+	}, false, true, e.Spells...)
+	f := func(eqp *Equipment) bool {
+		snapshot.add(eqp.TID, i18n.Text("equipment"), eqp.String(), fmt.Sprintf(i18n.Text("quantity %s"), eqp.Quantity))
+		return false
+	}
+	Traverse(f, false, false, e.CarriedEquipment...)
+	Traverse(f, false, false, e.OtherEquipment...)
+	return snapshot
+}
+
+func (s *ChangeSnapshot) add(id tid.TID, kind, name, amount string) {
+	s.items[id] = changeSnapshotItem{
+		kind:   kind,
+		name:   name,
+		amount: amount,
+	}
+	s.order = append(s.order, id)
+}
+
+// LogChangesSince compares the current state of the character against the snapshot and appends entries to the change
+// log for each difference found. Returns true if any entries were added.
+func (e *Entity) LogChangesSince(snapshot *ChangeSnapshot) bool {
+	if snapshot == nil {
+		return false
+	}
+	current := e.TakeChangeSnapshot()
+	var descriptions []string
+	if current.totalPoints != snapshot.totalPoints {
+		descriptions = append(descriptions, fmt.Sprintf(i18n.Text("Total points changed from %s to %s"),
+			snapshot.totalPoints, current.totalPoints))
+	}
+	for _, id := range snapshot.attrOrder {
+		was := snapshot.attributes[id]
+		if now, ok := current.attributes[id]; ok && now.amount != was.amount {
+			descriptions = append(descriptions, was.changed(now))
+		}
+	}
+	for _, id := range snapshot.order {
+		was := snapshot.items[id]
+		if now, ok := current.items[id]; !ok {
+			descriptions = append(descriptions, fmt.Sprintf(i18n.Text("Removed %s: %s"), was.kind, was.name))
+		} else if now.amount != was.amount {
+			descriptions = append(descriptions, was.changed(now))
+		}
+	}
+	for _, id := range current.order {
+		if _, ok := snapshot.items[id]; !ok {
+			now := current.items[id]
+			descriptions = append(descriptions, fmt.Sprintf(i18n.Text("Added %s: %s (%s)"), now.kind, now.name,
+				now.amount))
+		}
+	}
+	if len(descriptions) == 0 {
+		return false
+	}
+	when := jio.Now()
+	for _, one := range descriptions {
+		e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+			When:        when,
+			Description: one,
+		})
+	}
+	return true
+}
+
+func (c changeSnapshotItem) changed(now changeSnapshotItem) string {
+	return fmt.Sprintf(i18n.Text("Changed %s %s from %s to %s"), c.kind, now.name, c.amount, now.amount)
+}
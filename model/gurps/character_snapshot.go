@@ -0,0 +1,175 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+)
+
+// SnapshotRow records an attribute, trait, skill or spell as it was when a snapshot was taken.
+type SnapshotRow struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Points fxp.Int `json:"points,omitempty"`
+	Level  fxp.Int `json:"level,omitempty"`
+}
+
+// CharacterSnapshot records the state of a character at a moment in time, so that it can be compared with the state at
+// another, such as the end of the previous session.
+type CharacterSnapshot struct {
+	When        jio.Time       `json:"when"`
+	Label       string         `json:"label,omitempty"`
+	TotalPoints fxp.Int        `json:"total_points"`
+	SpentPoints fxp.Int        `json:"spent_points"`
+	Attributes  []*SnapshotRow `json:"attributes,omitempty"`
+	Traits      []*SnapshotRow `json:"traits,omitempty"`
+	Skills      []*SnapshotRow `json:"skills,omitempty"`
+	Spells      []*SnapshotRow `json:"spells,omitempty"`
+}
+
+// SnapshotRowChange describes a row whose points or level differ between two snapshots.
+type SnapshotRowChange struct {
+	Before *SnapshotRow
+	After  *SnapshotRow
+}
+
+// SnapshotCategoryDiff holds the differences between two snapshots for one category of rows.
+type SnapshotCategoryDiff struct {
+	Added   []*SnapshotRow
+	Removed []*SnapshotRow
+	Changed []*SnapshotRowChange
+}
+
+// SnapshotDiff holds the differences between two snapshots.
+type SnapshotDiff struct {
+	TotalPointsDelta fxp.Int
+	SpentPointsDelta fxp.Int
+	Attributes       SnapshotCategoryDiff
+	Traits           SnapshotCategoryDiff
+	Skills           SnapshotCategoryDiff
+	Spells           SnapshotCategoryDiff
+}
+
+// TakeCharacterSnapshot records the current state of the character.
+func (e *Entity) TakeCharacterSnapshot(label string) *CharacterSnapshot {
+	s := &CharacterSnapshot{
+		When:        jio.Now(),
+		Label:       label,
+		TotalPoints: e.TotalPoints,
+		SpentPoints: e.PointsBreakdown().Total(),
+	}
+	if e.Attributes != nil {
+		for _, attr := range e.Attributes.List() {
+			if def := attr.AttributeDef(); def != nil && !def.IsSeparator() {
+				s.Attributes = append(s.Attributes, &SnapshotRow{
+					ID:     attr.AttrID,
+					Name:   def.Name,
+					Points: attr.PointCost(),
+					Level:  attr.Maximum(),
+				})
+			}
+		}
+	}
+	Traverse(func(t *Trait) bool {
+		s.Traits = append(s.Traits, &SnapshotRow{
+			ID:     string(t.TID),
+			Name:   t.String(),
+			Points: t.AdjustedPoints(),
+		})
+		return false
+	}, false, true, e.Traits...)
+	Traverse(func(sk *Skill) bool {
+		s.Skills = append(s.Skills, &SnapshotRow{
+			ID:     string(sk.TID),
+			Name:   sk.String(),
+			Points: sk.AdjustedPoints(nil),
+			Level:  sk.LevelData.Level,
+		})
+		return false
+	}, false, true, e.Skills...)
+	Traverse(func(sp *Spell) bool {
+		s.Spells = append(s.Spells, &SnapshotRow{
+			ID:     string(sp.TID),
+			Name:   sp.String(),
+			Points: sp.AdjustedPoints(nil),
+			Level:  sp.LevelData.Level,
+		})
+		return false
+	}, false, true, e.Spells...)
+	return s
+}
+
+// RecordSnapshot adds a snapshot of the character's current state to its history. If nothing has changed since the
+// most recent snapshot, no new one is added and nil is returned.
+func (e *Entity) RecordSnapshot(label string) *CharacterSnapshot {
+	s := e.TakeCharacterSnapshot(label)
+	if len(e.Snapshots) != 0 && DiffSnapshots(e.Snapshots[len(e.Snapshots)-1], s).Empty() {
+		return nil
+	}
+	e.Snapshots = append(e.Snapshots, s)
+	return s
+}
+
+// CloneSnapshots creates a copy of the snapshots. The snapshots themselves are never modified once taken, so they are
+// shared rather than copied.
+func CloneSnapshots(list []*CharacterSnapshot) []*CharacterSnapshot {
+	return slices.Clone(list)
+}
+
+// DiffSnapshots returns the differences between two snapshots.
+func DiffSnapshots(before, after *CharacterSnapshot) *SnapshotDiff {
+	return &SnapshotDiff{
+		TotalPointsDelta: after.TotalPoints - before.TotalPoints,
+		SpentPointsDelta: after.SpentPoints - before.SpentPoints,
+		Attributes:       diffSnapshotRows(before.Attributes, after.Attributes),
+		Traits:           diffSnapshotRows(before.Traits, after.Traits),
+		Skills:           diffSnapshotRows(before.Skills, after.Skills),
+		Spells:           diffSnapshotRows(before.Spells, after.Spells),
+	}
+}
+
+func diffSnapshotRows(before, after []*SnapshotRow) SnapshotCategoryDiff {
+	var diff SnapshotCategoryDiff
+	previous := make(map[string]*SnapshotRow, len(before))
+	for _, row := range before {
+		previous[row.ID] = row
+	}
+	for _, row := range after {
+		old, ok := previous[row.ID]
+		if !ok {
+			diff.Added = append(diff.Added, row)
+			continue
+		}
+		delete(previous, row.ID)
+		if old.Points != row.Points || old.Level != row.Level || old.Name != row.Name {
+			diff.Changed = append(diff.Changed, &SnapshotRowChange{Before: old, After: row})
+		}
+	}
+	for _, row := range before {
+		if _, ok := previous[row.ID]; ok {
+			diff.Removed = append(diff.Removed, row)
+		}
+	}
+	return diff
+}
+
+// Empty returns true if there are no differences in the category.
+func (d *SnapshotCategoryDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Empty returns true if the snapshots had no differences.
+func (d *SnapshotDiff) Empty() bool {
+	return d.TotalPointsDelta == 0 && d.SpentPointsDelta == 0 && d.Attributes.Empty() && d.Traits.Empty() &&
+		d.Skills.Empty() && d.Spells.Empty()
+}
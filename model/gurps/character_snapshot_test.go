@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCharacterSnapshots(t *testing.T) {
+	e := NewEntity()
+	trait := NewTrait(e, nil, false)
+	trait.Name = "Luck"
+	trait.BasePoints = fxp.Fifteen
+	e.SetTraitList([]*Trait{trait})
+
+	check.NotNil(t, e.RecordSnapshot("Session 1"))
+	check.Nil(t, e.RecordSnapshot("Session 2"), "nothing changed since the last snapshot")
+	check.Equal(t, 1, len(e.Snapshots))
+
+	trait.BasePoints = fxp.Thirty
+	added := NewTrait(e, nil, false)
+	added.Name = "Fit"
+	added.BasePoints = fxp.Five
+	e.SetTraitList([]*Trait{trait, added})
+	e.TotalPoints += fxp.Ten
+	e.Attributes.Set[StrengthID].Adjustment = fxp.One
+
+	diff := DiffSnapshots(e.Snapshots[0], e.TakeCharacterSnapshot(""))
+	check.False(t, diff.Empty())
+	check.Equal(t, fxp.Ten, diff.TotalPointsDelta)
+	check.Equal(t, fxp.From(30), diff.SpentPointsDelta)
+	check.Equal(t, 1, len(diff.Traits.Added))
+	check.Equal(t, "Fit", diff.Traits.Added[0].Name)
+	check.Equal(t, 1, len(diff.Traits.Changed))
+	check.Equal(t, fxp.Fifteen, diff.Traits.Changed[0].Before.Points)
+	check.Equal(t, fxp.Thirty, diff.Traits.Changed[0].After.Points)
+	check.Equal(t, 0, len(diff.Traits.Removed))
+	check.Equal(t, 1, len(diff.Attributes.Changed))
+
+	e.SetTraitList([]*Trait{added})
+	diff = DiffSnapshots(e.Snapshots[0], e.TakeCharacterSnapshot(""))
+	check.Equal(t, 1, len(diff.Traits.Removed))
+	check.Equal(t, "Luck", diff.Traits.Removed[0].Name)
+}
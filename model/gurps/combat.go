@@ -0,0 +1,263 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"io/fs"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/combat"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xmath/crc"
+)
+
+// Combat holds the state of a combat being tracked.
+type Combat struct {
+	CombatData
+}
+
+// CombatData holds the combat file data.
+type CombatData struct {
+	Version    int          `json:"version"`
+	ID         tid.TID      `json:"id"`
+	Round      int          `json:"round,omitempty"`
+	Turn       int          `json:"turn,omitempty"`
+	Combatants []*Combatant `json:"combatants,omitempty"`
+}
+
+// Combatant holds a single participant in a Combat.
+type Combatant struct {
+	ID         tid.TID         `json:"id"`
+	Name       string          `json:"name"`
+	Source     string          `json:"source,omitempty"`
+	Initiative fxp.Int         `json:"initiative"`
+	Tiebreak   fxp.Int         `json:"tiebreak,omitempty"`
+	HP         fxp.Int         `json:"hp"`
+	MaxHP      fxp.Int         `json:"max_hp"`
+	FP         fxp.Int         `json:"fp"`
+	MaxFP      fxp.Int         `json:"max_fp"`
+	Maneuver   combat.Maneuver `json:"maneuver"`
+	Posture    combat.Posture  `json:"posture"`
+	Flags      []combat.Flag   `json:"flags,omitempty"`
+	Notes      string          `json:"notes,omitempty"`
+}
+
+// NewCombatFromFile loads a Combat from a file.
+func NewCombatFromFile(fileSystem fs.FS, filePath string) (*Combat, error) {
+	var c Combat
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &c); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(c.Version); err != nil {
+		return nil, err
+	}
+	c.EnsureValidity()
+	return &c, nil
+}
+
+// NewCombat creates a new Combat.
+func NewCombat() *Combat {
+	return &Combat{
+		CombatData: CombatData{
+			ID: tid.MustNewTID(kinds.Combat),
+		},
+	}
+}
+
+// Clone creates a deep copy of this.
+func (c *Combat) Clone() *Combat {
+	clone := *c
+	clone.Combatants = make([]*Combatant, len(c.Combatants))
+	for i, one := range c.Combatants {
+		combatant := *one
+		combatant.Flags = slices.Clone(one.Flags)
+		clone.Combatants[i] = &combatant
+	}
+	return &clone
+}
+
+// NewCombatantFromEntity creates a new Combatant from the current state of an entity. The source should be the path of
+// the file the entity was loaded from, if any, so that the combatant can be refreshed later.
+func NewCombatantFromEntity(entity *Entity, source string) *Combatant {
+	c := &Combatant{
+		ID:     tid.MustNewTID(kinds.Combatant),
+		Source: source,
+	}
+	c.UpdateFromEntity(entity)
+	if attr := entity.ResolveAttribute(HitPointsID); attr != nil {
+		c.HP = attr.Current()
+	}
+	if attr := entity.ResolveAttribute(FatiguePointsID); attr != nil {
+		c.FP = attr.Current()
+	}
+	return c
+}
+
+// UpdateFromEntity updates the name, initiative and maximum HP & FP of the combatant from the entity. The current HP &
+// FP being tracked are left alone.
+func (c *Combatant) UpdateFromEntity(entity *Entity) {
+	c.Name = entity.Profile.Name
+	c.Initiative = entity.ResolveAttributeCurrent(BasicSpeedID)
+	c.Tiebreak = entity.ResolveAttributeCurrent(DexterityID)
+	if attr := entity.ResolveAttribute(HitPointsID); attr != nil {
+		c.MaxHP = attr.Maximum()
+	}
+	if attr := entity.ResolveAttribute(FatiguePointsID); attr != nil {
+		c.MaxFP = attr.Maximum()
+	}
+}
+
+// HasFlag returns true if the combatant has the given status flag.
+func (c *Combatant) HasFlag(flag combat.Flag) bool {
+	return slices.Contains(c.Flags, flag)
+}
+
+// ToggleFlag adds the status flag to the combatant if it isn't present, or removes it if it is.
+func (c *Combatant) ToggleFlag(flag combat.Flag) {
+	if i := slices.Index(c.Flags, flag); i != -1 {
+		c.Flags = slices.Delete(c.Flags, i, i+1)
+	} else {
+		c.Flags = append(c.Flags, flag)
+		slices.Sort(c.Flags)
+	}
+}
+
+// EnsureValidity checks the current data for validity and if it isn't valid, makes it so.
+func (c *Combat) EnsureValidity() {
+	for _, one := range c.Combatants {
+		one.Maneuver = one.Maneuver.EnsureValid()
+		one.Posture = one.Posture.EnsureValid()
+		for i, flag := range one.Flags {
+			one.Flags[i] = flag.EnsureValid()
+		}
+		one.Flags = slices.Compact(one.Flags)
+	}
+	c.Round = max(c.Round, 0)
+	if c.Turn < 0 || c.Turn >= len(c.Combatants) {
+		c.Turn = 0
+	}
+}
+
+// Started returns true if the first round of the combat has begun.
+func (c *Combat) Started() bool {
+	return c.Round > 0
+}
+
+// Current returns the combatant whose turn it is, or nil if the combat hasn't started.
+func (c *Combat) Current() *Combatant {
+	if !c.Started() || c.Turn < 0 || c.Turn >= len(c.Combatants) {
+		return nil
+	}
+	return c.Combatants[c.Turn]
+}
+
+// Add the combatants and re-sort into initiative order.
+func (c *Combat) Add(combatants ...*Combatant) {
+	c.Combatants = append(c.Combatants, combatants...)
+	c.SortByInitiative()
+}
+
+// Remove the combatant with the given ID. If it was that combatant's turn, the turn passes to the next one.
+func (c *Combat) Remove(id tid.TID) {
+	i := slices.IndexFunc(c.Combatants, func(one *Combatant) bool { return one.ID == id })
+	if i == -1 {
+		return
+	}
+	c.Combatants = slices.Delete(c.Combatants, i, i+1)
+	switch {
+	case i < c.Turn:
+		c.Turn--
+	case c.Turn >= len(c.Combatants):
+		c.Turn = 0
+	}
+}
+
+// SortByInitiative sorts the combatants by Basic Speed, then DX, highest first, keeping the current turn with the same
+// combatant.
+func (c *Combat) SortByInitiative() {
+	current := c.Current()
+	slices.SortStableFunc(c.Combatants, func(a, b *Combatant) int {
+		if result := cmp.Compare(b.Initiative, a.Initiative); result != 0 {
+			return result
+		}
+		return cmp.Compare(b.Tiebreak, a.Tiebreak)
+	})
+	if current != nil {
+		c.Turn = slices.Index(c.Combatants, current)
+	}
+}
+
+// NextTurn advances to the next combatant's turn, starting a new round after the last one. Starts the combat if it
+// hasn't been started yet.
+func (c *Combat) NextTurn() {
+	if len(c.Combatants) == 0 {
+		return
+	}
+	if !c.Started() {
+		c.Round = 1
+		c.Turn = 0
+		return
+	}
+	c.Turn++
+	if c.Turn >= len(c.Combatants) {
+		c.Turn = 0
+		c.Round++
+	}
+}
+
+// PreviousTurn backs up to the previous combatant's turn.
+func (c *Combat) PreviousTurn() {
+	if !c.Started() || len(c.Combatants) == 0 {
+		return
+	}
+	c.Turn--
+	if c.Turn < 0 {
+		if c.Round == 1 {
+			c.Turn = 0
+			return
+		}
+		c.Turn = len(c.Combatants) - 1
+		c.Round--
+	}
+}
+
+// Reset the combat so that it hasn't started yet.
+func (c *Combat) Reset() {
+	c.Round = 0
+	c.Turn = 0
+}
+
+// Save the Combat to a file as JSON.
+func (c *Combat) Save(filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, c)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Combat) MarshalJSON() ([]byte, error) {
+	c.Version = jio.CurrentDataVersion
+	return json.Marshal(&c.CombatData)
+}
+
+// CRC64 computes a CRC-64 value for the canonical disk format of the data.
+func (c *Combat) CRC64() uint64 {
+	var buffer bytes.Buffer
+	if err := jio.Save(context.Background(), &buffer, c); err != nil {
+		return 0
+	}
+	return crc.Bytes(0, buffer.Bytes())
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/combat"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+func newTestCombatant(name string, speed, dx int) *Combatant {
+	return &Combatant{
+		ID:         tid.MustNewTID(kinds.Combatant),
+		Name:       name,
+		Initiative: fxp.From(speed),
+		Tiebreak:   fxp.From(dx),
+	}
+}
+
+func combatantNames(c *Combat) []string {
+	names := make([]string, 0, len(c.Combatants))
+	for _, one := range c.Combatants {
+		names = append(names, one.Name)
+	}
+	return names
+}
+
+func TestCombatTurnOrder(t *testing.T) {
+	c := NewCombat()
+	c.Add(newTestCombatant("Slow", 5, 10), newTestCombatant("Fast", 7, 10), newTestCombatant("Nimble", 5, 13))
+	check.Equal(t, []string{"Fast", "Nimble", "Slow"}, combatantNames(c))
+	check.Nil(t, c.Current(), "nobody acts before the combat starts")
+
+	c.NextTurn()
+	check.Equal(t, 1, c.Round)
+	check.Equal(t, "Fast", c.Current().Name)
+	c.PreviousTurn()
+	check.Equal(t, "Fast", c.Current().Name, "can't back up past the start")
+	c.NextTurn()
+	c.NextTurn()
+	check.Equal(t, "Slow", c.Current().Name)
+	c.NextTurn()
+	check.Equal(t, 2, c.Round)
+	check.Equal(t, "Fast", c.Current().Name)
+	c.PreviousTurn()
+	check.Equal(t, 1, c.Round)
+	check.Equal(t, "Slow", c.Current().Name)
+
+	c.Add(newTestCombatant("Quickest", 8, 10))
+	check.Equal(t, "Slow", c.Current().Name, "adding a combatant keeps the current turn")
+	c.Remove(c.Combatants[0].ID)
+	check.Equal(t, "Slow", c.Current().Name, "removing an earlier combatant keeps the current turn")
+	c.Remove(c.Current().ID)
+	check.Equal(t, "Fast", c.Current().Name, "removing the last combatant wraps around")
+
+	c.Reset()
+	check.False(t, c.Started())
+}
+
+func TestCombatantFlags(t *testing.T) {
+	c := newTestCombatant("Bob", 5, 10)
+	c.ToggleFlag(combat.Shocked)
+	c.ToggleFlag(combat.Stunned)
+	check.Equal(t, []combat.Flag{combat.Stunned, combat.Shocked}, c.Flags)
+	check.True(t, c.HasFlag(combat.Shocked))
+	c.ToggleFlag(combat.Shocked)
+	check.False(t, c.HasFlag(combat.Shocked))
+
+	data, err := json.Marshal(c)
+	check.NoError(t, err)
+	var loaded Combatant
+	check.NoError(t, json.Unmarshal(data, &loaded))
+	check.Equal(t, c, &loaded)
+}
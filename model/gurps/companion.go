@@ -0,0 +1,167 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Companion holds a lightweight record for a mount or animal companion that belongs to a character, for when a full
+// character sheet of its own would be overkill.
+type Companion struct {
+	Name     string             `json:"name"`
+	Kind     string             `json:"kind,omitempty"`
+	ST       int                `json:"st"`
+	DX       int                `json:"dx"`
+	IQ       int                `json:"iq"`
+	HT       int                `json:"ht"`
+	Will     int                `json:"will"`
+	Per      int                `json:"per"`
+	Speed    fxp.Int            `json:"speed"`
+	Move     int                `json:"move"`
+	Dodge    int                `json:"dodge"`
+	SM       int                `json:"sm,omitempty"`
+	DR       int                `json:"dr,omitempty"`
+	HP       int                `json:"hp"`
+	HPDamage int                `json:"hp_damage,omitempty"`
+	Attacks  []*CompanionAttack `json:"attacks,omitempty"`
+	Notes    string             `json:"notes,omitempty"`
+}
+
+// CompanionAttack holds one of a Companion's attacks.
+type CompanionAttack struct {
+	Name   string `json:"name"`
+	Skill  int    `json:"skill"`
+	Damage string `json:"damage,omitempty"`
+	Reach  string `json:"reach,omitempty"`
+}
+
+// NewCompanion creates a new Companion with the statistics of an average riding horse.
+func NewCompanion() *Companion {
+	return &Companion{
+		Kind:  i18n.Text("Riding Horse"),
+		ST:    18,
+		DX:    9,
+		IQ:    3,
+		HT:    11,
+		Will:  10,
+		Per:   10,
+		Speed: fxp.Five,
+		Move:  8,
+		Dodge: 8,
+		SM:    1,
+		HP:    18,
+		Attacks: []*CompanionAttack{
+			{
+				Name:   i18n.Text("Kick"),
+				Skill:  9,
+				Damage: "1d+1 cr",
+				Reach:  "C,1",
+			},
+		},
+	}
+}
+
+// CurrentHP returns the current hit points.
+func (c *Companion) CurrentHP() int {
+	return c.HP - c.HPDamage
+}
+
+// SetCurrentHP sets the current hit points by adjusting the damage taken.
+func (c *Companion) SetCurrentHP(hp int) {
+	c.HPDamage = max(c.HP-hp, 0)
+}
+
+// Title returns the name and kind of the companion, suitable for a heading.
+func (c *Companion) Title() string {
+	name := strings.TrimSpace(c.Name)
+	kind := strings.TrimSpace(c.Kind)
+	switch {
+	case name == "" && kind == "":
+		return i18n.Text("Unnamed Companion")
+	case name == "":
+		return kind
+	case kind == "":
+		return name
+	default:
+		return name + " (" + kind + ")"
+	}
+}
+
+// StatLine returns the companion's attributes and secondary characteristics in the compact form used by stat blocks.
+func (c *Companion) StatLine() string {
+	parts := []string{
+		fmt.Sprintf("ST %d", c.ST),
+		fmt.Sprintf("DX %d", c.DX),
+		fmt.Sprintf("IQ %d", c.IQ),
+		fmt.Sprintf("HT %d", c.HT),
+		fmt.Sprintf("HP %d/%d", c.CurrentHP(), c.HP),
+		fmt.Sprintf("Will %d", c.Will),
+		fmt.Sprintf("Per %d", c.Per),
+		"Speed " + c.Speed.String(),
+		fmt.Sprintf("Move %d", c.Move),
+		fmt.Sprintf("Dodge %d", c.Dodge),
+	}
+	if c.SM != 0 {
+		parts = append(parts, fmt.Sprintf("SM %+d", c.SM))
+	}
+	if c.DR != 0 {
+		parts = append(parts, fmt.Sprintf("DR %d", c.DR))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// String returns the attack in the compact form used by stat blocks.
+func (a *CompanionAttack) String() string {
+	var buffer strings.Builder
+	buffer.WriteString(fmt.Sprintf("%s (%d)", a.Name, a.Skill))
+	if a.Damage != "" {
+		buffer.WriteString(": ")
+		buffer.WriteString(a.Damage)
+	}
+	if a.Reach != "" {
+		buffer.WriteString(fmt.Sprintf(i18n.Text(", Reach %s"), a.Reach))
+	}
+	return buffer.String()
+}
+
+// CloneCompanionList creates a clone of the provided Companion list.
+func CloneCompanionList(list []*Companion) []*Companion {
+	clone := make([]*Companion, len(list))
+	for i, one := range list {
+		c := *one
+		c.Attacks = make([]*CompanionAttack, len(one.Attacks))
+		for j, attack := range one.Attacks {
+			a := *attack
+			c.Attacks[j] = &a
+		}
+		clone[i] = &c
+	}
+	return clone
+}
+
+// SetCompanions sets a new companion list.
+func (e *Entity) SetCompanions(list []*Companion) {
+	e.Companions = CloneCompanionList(list)
+}
+
+// CompanionsForExport returns the companions that should be included in exports, which is none unless the sheet
+// settings ask for them.
+func (e *Entity) CompanionsForExport() []*Companion {
+	if !e.SheetSettings.IncludeCompanionsInExports {
+		return nil
+	}
+	return slices.Clone(e.Companions)
+}
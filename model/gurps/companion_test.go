@@ -0,0 +1,40 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCompanion(t *testing.T) {
+	c := NewCompanion()
+	c.Name = "Bucephalus"
+	check.Equal(t, "Bucephalus (Riding Horse)", c.Title())
+	c.SetCurrentHP(12)
+	check.Equal(t, 6, c.HPDamage)
+	check.Equal(t, 12, c.CurrentHP())
+	c.SetCurrentHP(25)
+	check.Equal(t, 0, c.HPDamage, "healing can't exceed full HP")
+	check.Equal(t, "ST 18; DX 9; IQ 3; HT 11; HP 18/18; Will 10; Per 10; Speed 5; Move 8; Dodge 8; SM +1",
+		c.StatLine())
+	check.Equal(t, "Kick (9): 1d+1 cr, Reach C,1", c.Attacks[0].String())
+
+	clone := CloneCompanionList([]*Companion{c})
+	clone[0].Attacks[0].Skill = 12
+	check.Equal(t, 9, c.Attacks[0].Skill, "clones must not share attacks")
+
+	e := NewEntity()
+	e.SetCompanions([]*Companion{c})
+	check.Equal(t, 0, len(e.CompanionsForExport()))
+	e.SheetSettings.IncludeCompanionsInExports = true
+	check.Equal(t, 1, len(e.CompanionsForExport()))
+}
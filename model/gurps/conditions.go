@@ -0,0 +1,121 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/condition"
+)
+
+// MaxShockPenalty is the largest penalty that shock from injury may impose.
+const MaxShockPenalty = 4
+
+// HasCondition returns true if the condition is currently affecting the entity.
+func (e *Entity) HasCondition(c condition.Type) bool {
+	return slices.Contains(e.Conditions, c)
+}
+
+// SetCondition turns the condition on or off. The entity should be recalculated afterward.
+func (e *Entity) SetCondition(c condition.Type, active bool) {
+	c = c.EnsureValid()
+	if active == e.HasCondition(c) {
+		return
+	}
+	if active {
+		e.Conditions = append(e.Conditions, c)
+		slices.Sort(e.Conditions)
+	} else {
+		e.Conditions = slices.DeleteFunc(e.Conditions, func(one condition.Type) bool { return one == c })
+		if c == condition.Shock {
+			e.ShockPenalty = 0
+		}
+	}
+}
+
+// EffectiveShockPenalty returns the amount subtracted from DX and IQ while the shock condition is active.
+func (e *Entity) EffectiveShockPenalty() int {
+	return min(max(e.ShockPenalty, 1), MaxShockPenalty)
+}
+
+// ConditionFeatures returns the temporary features imposed by the condition.
+func (e *Entity) ConditionFeatures(c condition.Type) Features {
+	var dx, iq, dodge int
+	switch c {
+	case condition.Stunned:
+		dodge = -4
+	case condition.Prone:
+		dodge = -3
+	case condition.Grappled:
+		dx = -4
+	case condition.Shock:
+		dx = -e.EffectiveShockPenalty()
+		iq = dx
+	case condition.ModeratePain:
+		dx = -2
+		iq = -2
+	case condition.SeverePain:
+		dx = -4
+		iq = -4
+	case condition.TerriblePain:
+		dx = -6
+		iq = -6
+	case condition.Coughing:
+		dx = -3
+		iq = -1
+	case condition.Nauseated:
+		dx = -2
+		iq = -2
+		dodge = -1
+	case condition.Tipsy:
+		dx = -1
+		iq = -1
+	case condition.Drunk:
+		dx = -2
+		iq = -2
+	}
+	var list Features
+	for _, one := range []struct {
+		attrID string
+		amount int
+	}{
+		{attrID: DexterityID, amount: dx},
+		{attrID: IntelligenceID, amount: iq},
+		{attrID: DodgeID, amount: dodge},
+	} {
+		if one.amount != 0 {
+			bonus := NewAttributeBonus(one.attrID)
+			bonus.Amount = fxp.From(one.amount)
+			list = append(list, bonus)
+		}
+	}
+	return list
+}
+
+// ConditionMoveLimit returns the maximum Move permitted by the current conditions, if any of them limit it.
+func (e *Entity) ConditionMoveLimit() (limit int, limited bool) {
+	limit = -1
+	for _, c := range e.Conditions {
+		var one int
+		switch c {
+		case condition.Grappled:
+			one = 0
+		case condition.Prone:
+			one = 1
+		default:
+			continue
+		}
+		if limit == -1 || one < limit {
+			limit = one
+		}
+	}
+	return limit, limit != -1
+}
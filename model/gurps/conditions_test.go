@@ -0,0 +1,56 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/condition"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestConditions(t *testing.T) {
+	e := NewEntity()
+	e.Recalculate()
+	dodge := e.Dodge(encumbrance.No)
+	move := e.Move(encumbrance.No)
+
+	e.SetCondition(condition.Stunned, true)
+	e.Recalculate()
+	check.Equal(t, dodge-4, e.Dodge(encumbrance.No), "stunned")
+	check.Equal(t, move, e.Move(encumbrance.No), "stunned doesn't limit move")
+
+	e.SetCondition(condition.Prone, true)
+	e.SetCondition(condition.Prone, true)
+	check.Equal(t, []condition.Type{condition.Stunned, condition.Prone}, e.Conditions, "no duplicates")
+	e.Recalculate()
+	check.Equal(t, dodge-7, e.Dodge(encumbrance.No), "stunned and prone")
+	check.Equal(t, 1, e.Move(encumbrance.No), "prone limits move")
+
+	e.SetCondition(condition.Stunned, false)
+	e.SetCondition(condition.Prone, false)
+	e.SetCondition(condition.SeverePain, true)
+	e.Recalculate()
+	check.Equal(t, fxp.Six, e.Attributes.Current(DexterityID), "severe pain DX")
+	check.Equal(t, fxp.Six, e.Attributes.Current(IntelligenceID), "severe pain IQ")
+
+	e.SetCondition(condition.SeverePain, false)
+	e.SetCondition(condition.Shock, true)
+	e.ShockPenalty = 7
+	e.Recalculate()
+	check.Equal(t, fxp.Six, e.Attributes.Current(IntelligenceID), "shock is capped")
+	e.SetCondition(condition.Shock, false)
+	check.Equal(t, 0, e.ShockPenalty, "clearing shock resets its penalty")
+	e.Recalculate()
+	check.Equal(t, fxp.Ten, e.Attributes.Current(IntelligenceID))
+	check.Equal(t, 0, len(e.Conditions))
+}
@@ -18,8 +18,10 @@ import (
 
 	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/server/websettings"
 	"github.com/richardwilkes/toolbox/collection"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/toolbox/xio/fs"
@@ -28,10 +30,35 @@ import (
 
 // Convert the GCS files found in the given paths to the current file format.
 func Convert(paths ...string) error {
+	list, err := convertibleFiles(paths...)
+	if err != nil {
+		return err
+	}
+	var tmpDir string
+	if tmpDir, err = os.MkdirTemp("", "gcs-convert-"); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }() //nolint:errcheck // Nothing useful can be done about a failure here
+	for _, p := range list {
+		fmt.Printf(i18n.Text("Processing %s\n"), p)
+		if _, _, err = migrateFile(p, p, tmpDir); err != nil {
+			return err
+		}
+	}
+	if len(list) == 1 {
+		fmt.Println(i18n.Text("Processed 1 file"))
+	} else {
+		fmt.Printf(i18n.Text("Processed %d files\n"), len(list))
+	}
+	return nil
+}
+
+// convertibleFiles returns the GCS files found in the given paths, traversing directories recursively.
+func convertibleFiles(paths ...string) ([]string, error) {
 	var err error
 	paths, err = fs.UniquePaths(paths...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	extSet := collection.NewSet(GCSExtensions()...)
 	extSet.Add(GCSSecondaryExtensions()...)
@@ -42,182 +69,223 @@ func Convert(paths ...string) error {
 	}
 	list := pathSet.Values()
 	txt.SortStringsNaturalAscending(list)
-	for _, p := range list {
-		fmt.Printf(i18n.Text("Processing %s\n"), p)
-		switch strings.ToLower(filepath.Ext(p)) {
-		case TraitsExt:
-			var data []*Trait
-			if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraits(data, p); err != nil {
-				return err
-			}
-		case TraitModifiersExt:
-			var data []*TraitModifier
-			if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraitModifiers(data, p); err != nil {
-				return err
-			}
-		case EquipmentExt:
-			var data []*Equipment
-			if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipment(data, p); err != nil {
-				return err
-			}
-		case EquipmentModifiersExt:
-			var data []*EquipmentModifier
-			if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipmentModifiers(data, p); err != nil {
-				return err
-			}
-		case SkillsExt:
-			var data []*Skill
-			if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSkills(data, p); err != nil {
-				return err
-			}
-		case SpellsExt:
-			var data []*Spell
-			if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSpells(data, p); err != nil {
-				return err
-			}
-		case NotesExt:
-			var data []*Note
-			if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveNotes(data, p); err != nil {
-				return err
-			}
-		case TemplatesExt:
-			var tmpl *Template
-			if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = tmpl.Save(p); err != nil {
-				return err
-			}
-		// TODO: Re-enable Campaign files
-		// case CampaignExt:
-		// 	var campaign *Campaign
-		// 	if campaign, err = NewCampaignFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-		// 		return err
-		// 	}
-		// 	if err = campaign.Save(p); err != nil {
-		// 		return err
-		// 	}
-		case SheetExt:
-			var entity *Entity
-			if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = entity.Save(p); err != nil {
-				return err
-			}
-		case AncestryExt:
-			var data *Ancestry
-			if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
-			var data *AttributeDefs
-			if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case BodyExt, BodyExtAlt:
-			var data *Body
-			if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case CalendarExt:
-			// Currently have no version info, so nothing to update
-		case ColorSettingsExt:
-			var data *colors.Colors
-			if data, err = colors.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case FontSettingsExt:
-			var data *fonts.Fonts
-			if data, err = fonts.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case GeneralSettingsExt:
-			var data *GeneralSettings
-			if data, err = NewGeneralSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case KeySettingsExt:
-			var data *KeyBindings
-			if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case NamesExt:
-			// Currently have no version info, so nothing to update
-		case PageRefSettingsExt:
-			var data *PageRefs
-			if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case SheetSettingsExt:
-			var data *SheetSettings
-			if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case WebSettingsExt:
-			var data *websettings.Settings
-			if data, err = websettings.NewSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
+	return list, nil
+}
+
+// migrateFile applies any registered raw migrations to the file at src, then loads it and writes it to dst in the
+// current format. tmpDir is used to hold intermediate data. Returns false for handled if the file type has no version
+// information and was left alone.
+func migrateFile(src, dst, tmpDir string) (applied []*jio.Migration, handled bool, err error) {
+	var data []byte
+	if data, err = os.ReadFile(src); err != nil {
+		return nil, false, errs.NewWithCause(src, err)
+	}
+	if data, applied, err = jio.ApplyMigrations(data); err != nil {
+		return nil, false, errs.NewWithCause(src, err)
+	}
+	if len(applied) != 0 {
+		src = filepath.Join(tmpDir, "migrating"+filepath.Ext(src))
+		if err = os.WriteFile(src, data, 0o640); err != nil {
+			return nil, false, errs.Wrap(err)
 		}
 	}
-	if len(list) == 1 {
-		fmt.Println(i18n.Text("Processed 1 file"))
-	} else {
-		fmt.Printf(i18n.Text("Processed %d files\n"), len(list))
+	if handled, err = convertFile(src, dst); err != nil {
+		return nil, false, err
 	}
-	return nil
+	return applied, handled, nil
+}
+
+// convertFile loads the file at src and writes it to dst in the current format. Returns false if the file type has no
+// version information and was left alone.
+func convertFile(src, dst string) (bool, error) {
+	var err error
+	switch strings.ToLower(filepath.Ext(src)) {
+	case TraitsExt:
+		var data []*Trait
+		if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveTraits(data, dst); err != nil {
+			return false, err
+		}
+	case TraitModifiersExt:
+		var data []*TraitModifier
+		if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveTraitModifiers(data, dst); err != nil {
+			return false, err
+		}
+	case EquipmentExt:
+		var data []*Equipment
+		if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveEquipment(data, dst); err != nil {
+			return false, err
+		}
+	case EquipmentModifiersExt:
+		var data []*EquipmentModifier
+		if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveEquipmentModifiers(data, dst); err != nil {
+			return false, err
+		}
+	case SkillsExt:
+		var data []*Skill
+		if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveSkills(data, dst); err != nil {
+			return false, err
+		}
+	case SpellsExt:
+		var data []*Spell
+		if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveSpells(data, dst); err != nil {
+			return false, err
+		}
+	case NotesExt:
+		var data []*Note
+		if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = SaveNotes(data, dst); err != nil {
+			return false, err
+		}
+	case TemplatesExt:
+		var tmpl *Template
+		if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = tmpl.Save(dst); err != nil {
+			return false, err
+		}
+	case CampaignExt:
+		var campaign *Campaign
+		if campaign, err = NewCampaignFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = campaign.Save(dst); err != nil {
+			return false, err
+		}
+	case SheetExt:
+		var entity *Entity
+		if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = entity.Save(dst); err != nil {
+			return false, err
+		}
+	case AncestryExt:
+		var data *Ancestry
+		if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
+		var data *AttributeDefs
+		if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case BodyExt, BodyExtAlt:
+		var data *Body
+		if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case CalendarExt:
+		// Currently have no version info, so nothing to update
+		return false, nil
+	case ColorSettingsExt:
+		var data *colors.Colors
+		if data, err = colors.NewFromFS(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case FontSettingsExt:
+		var data *fonts.Fonts
+		if data, err = fonts.NewFromFS(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case GeneralSettingsExt:
+		var data *GeneralSettings
+		if data, err = NewGeneralSettingsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case KeySettingsExt:
+		var data *KeyBindings
+		if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case NamesExt:
+		// Currently have no version info, so nothing to update
+		return false, nil
+	case PageRefSettingsExt:
+		var data *PageRefs
+		if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case QualityPresetsExt:
+		var data *QualityPresets
+		if data, err = NewQualityPresetsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case QuickReferenceExt:
+		var data *QuickReference
+		if data, err = NewQuickReferenceFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case SheetSettingsExt:
+		var data *SheetSettings
+		if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	case WebSettingsExt:
+		var data *websettings.Settings
+		if data, err = websettings.NewSettingsFromFile(os.DirFS(filepath.Dir(src)), filepath.Base(src)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dst); err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
 }
 
 func convertWalker(pathSet, extSet collection.Set[string]) func(path string, d iofs.DirEntry, err error) error {
@@ -0,0 +1,59 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
+)
+
+// creationExcess holds the amounts by which the entity exceeds each of its creation limits.
+type creationExcess struct {
+	points        fxp.Int
+	disadvantages fxp.Int
+	quirks        fxp.Int
+}
+
+func (e *Entity) creationExcess() creationExcess {
+	var excess creationExcess
+	if unspent := e.UnspentPoints(); unspent < 0 {
+		excess.points = -unspent
+	}
+	pb := e.PointsBreakdown()
+	if limit := e.SheetSettings.CreationDisadvantageLimit; limit > 0 && -pb.Disadvantages > limit {
+		excess.disadvantages = -pb.Disadvantages - limit
+	}
+	if limit := e.SheetSettings.CreationQuirkLimit; limit > 0 && -pb.Quirks > limit {
+		excess.quirks = -pb.Quirks - limit
+	}
+	return excess
+}
+
+// ApplyWithinCreationLimits calls apply to make a change to the entity. While in creation mode, the change is then
+// checked against the creation limits and, if it pushed the entity further past any of them, revert is called to undo
+// it. Changes that leave the entity no further past its limits than it already was are always permitted. Returns true
+// if the change was kept.
+func (e *Entity) ApplyWithinCreationLimits(apply, revert func()) bool {
+	if e == nil || e.Mode != sheetmode.Creation {
+		apply()
+		return true
+	}
+	e.Recalculate()
+	before := e.creationExcess()
+	apply()
+	e.Recalculate()
+	after := e.creationExcess()
+	if after.points > before.points || after.disadvantages > before.disadvantages || after.quirks > before.quirks {
+		revert()
+		e.Recalculate()
+		return false
+	}
+	return true
+}
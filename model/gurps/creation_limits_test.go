@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestApplyWithinCreationLimits(t *testing.T) {
+	e := NewEntity()
+	e.Recalculate()
+	e.TotalPoints = e.PointsBreakdown().Total() + fxp.Ten
+	e.SheetSettings.CreationDisadvantageLimit = fxp.Ten
+	e.Mode = sheetmode.Creation
+	trait := NewTrait(e, nil, false)
+	add := func(points int) bool {
+		list := e.Traits
+		return e.ApplyWithinCreationLimits(func() {
+			trait.BasePoints = fxp.From(points)
+			e.SetTraitList(append(list, trait))
+		}, func() { e.SetTraitList(list) })
+	}
+
+	check.True(t, add(10), "spending exactly the available points is permitted")
+	check.Equal(t, 1, len(e.Traits))
+	e.SetTraitList(nil)
+	check.False(t, add(15), "overspending is blocked")
+	check.Equal(t, 0, len(e.Traits), "and the change is reverted")
+	check.False(t, add(-15), "so is exceeding the disadvantage limit")
+	check.Equal(t, 0, len(e.Traits))
+
+	e.TotalPoints -= fxp.Twenty
+	check.True(t, add(-5), "changes that don't make an existing excess worse are permitted")
+
+	e.SetTraitList(nil)
+	e.Mode = sheetmode.Play
+	check.True(t, add(50), "play mode doesn't enforce the creation limits")
+	check.Equal(t, 1, len(e.Traits))
+}
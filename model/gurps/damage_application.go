@@ -0,0 +1,209 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+)
+
+// Hit location IDs that have special wounding multipliers.
+const (
+	skullLocID  = "skull"
+	eyeLocID    = "eye"
+	faceLocID   = "face"
+	neckLocID   = "neck"
+	vitalsLocID = "vitals"
+)
+
+var limbLocIDs = map[string]bool{
+	"arm":  true,
+	"leg":  true,
+	"hand": true,
+	"foot": true,
+}
+
+// DamageApplication holds the details of an incoming attack that is to be applied to a character.
+type DamageApplication struct {
+	Damage       int
+	Type         wound.Type
+	LocationID   string
+	ArmorDivisor fxp.Int
+	Tolerance    wound.Tolerance
+}
+
+// DamageResult holds the outcome of resolving an attack against a character.
+type DamageResult struct {
+	DR          int
+	Penetrating int
+	Multiplier  fxp.Int
+	Injury      int
+	PoolID      string
+}
+
+// NewDamageApplication creates a new DamageApplication against the character's torso, using the injury tolerance
+// granted by the character's traits.
+func (e *Entity) NewDamageApplication() *DamageApplication {
+	return &DamageApplication{
+		Type:         wound.Crushing,
+		LocationID:   TorsoID,
+		ArmorDivisor: fxp.One,
+		Tolerance:    e.InjuryTolerance(),
+	}
+}
+
+// InjuryTolerance returns the most protective injury tolerance granted by the character's enabled traits, looking for
+// "Injury Tolerance" traits whose name or enabled modifiers mention Diffuse, Homogeneous, or Unliving.
+func (e *Entity) InjuryTolerance() wound.Tolerance {
+	result := wound.None
+	Traverse(func(t *Trait) bool {
+		if !strings.Contains(strings.ToLower(t.Name), "injury tolerance") {
+			return false
+		}
+		names := []string{t.String()}
+		Traverse(func(mod *TraitModifier) bool {
+			names = append(names, mod.Name)
+			return false
+		}, true, true, t.Modifiers...)
+		for _, name := range names {
+			name = strings.ToLower(name)
+			for _, one := range []wound.Tolerance{wound.Diffuse, wound.Homogeneous, wound.Unliving} {
+				if one > result && strings.Contains(name, one.Key()) {
+					result = one
+				}
+			}
+		}
+		return false
+	}, true, false, e.Traits...)
+	return result
+}
+
+// DRAgainst returns the DR the location provides against the type of damage.
+func (e *Entity) DRAgainst(locationID string, damageType wound.Type) int {
+	loc := e.SheetSettings.BodyType.LookupLocationByID(e, locationID)
+	if loc == nil {
+		return 0
+	}
+	drMap := loc.DR(e, nil, nil)
+	dr := drMap[AllID]
+	if specialization := damageType.DRSpecialization(); specialization != "" {
+		dr += drMap[specialization]
+	}
+	return max(dr, 0)
+}
+
+// ResolveDamage determines the injury the attack would inflict upon the character.
+func (e *Entity) ResolveDamage(d *DamageApplication) *DamageResult {
+	result := &DamageResult{
+		DR:         e.DRAgainst(d.LocationID, d.Type),
+		Multiplier: WoundingMultiplier(d.Type, d.LocationID, d.Tolerance),
+		PoolID:     HitPointsID,
+	}
+	if d.Type == wound.Fatigue {
+		result.PoolID = FatiguePointsID
+	}
+	dr := fxp.From(result.DR)
+	if d.ArmorDivisor > 0 && d.ArmorDivisor != fxp.One {
+		dr = dr.Div(d.ArmorDivisor).Trunc()
+	}
+	result.Penetrating = max(d.Damage-fxp.As[int](dr), 0)
+	if result.Penetrating > 0 {
+		result.Injury = max(fxp.As[int](fxp.From(result.Penetrating).Mul(result.Multiplier).Trunc()), 1)
+		if d.Tolerance == wound.Diffuse {
+			if d.Type.IsImpalingOrPiercing() {
+				result.Injury = min(result.Injury, 1)
+			} else {
+				result.Injury = min(result.Injury, 2)
+			}
+		}
+	}
+	return result
+}
+
+// ApplyInjury adds the injury to the damage already taken by the pool.
+func (e *Entity) ApplyInjury(poolID string, injury int) {
+	if attr := e.ResolveAttribute(poolID); attr != nil {
+		attr.Damage = (attr.Damage + fxp.From(injury)).Max(0)
+	}
+}
+
+// WoundingMultiplier returns the multiplier applied to penetrating damage of the given type striking the location.
+func WoundingMultiplier(damageType wound.Type, locationID string, tolerance wound.Tolerance) fxp.Int {
+	piercing := damageType.IsImpalingOrPiercing()
+	if tolerance == wound.Diffuse || tolerance == wound.Homogeneous {
+		// These have no vulnerable spots, so the location doesn't matter.
+		locationID = ""
+	}
+	switch {
+	case locationID == skullLocID && damageType != wound.Toxic:
+		return fxp.Four
+	case locationID == eyeLocID && (piercing || damageType == wound.Burning):
+		return fxp.Four
+	case locationID == vitalsLocID && piercing:
+		return fxp.Three
+	case locationID == neckLocID && damageType == wound.Cutting:
+		return fxp.Two
+	case locationID == neckLocID && (damageType == wound.Crushing || damageType == wound.Corrosion):
+		return fxp.OneAndAHalf
+	case locationID == faceLocID && damageType == wound.Corrosion:
+		return fxp.OneAndAHalf
+	}
+	if piercing {
+		switch tolerance {
+		case wound.Unliving:
+			return unlivingMultiplier(damageType)
+		case wound.Homogeneous:
+			return homogeneousMultiplier(damageType)
+		default:
+		}
+		if limbLocIDs[locationID] && (damageType == wound.Impaling || damageType == wound.LargePiercing ||
+			damageType == wound.HugePiercing) {
+			return fxp.One
+		}
+	}
+	switch damageType {
+	case wound.Cutting, wound.LargePiercing:
+		return fxp.OneAndAHalf
+	case wound.Impaling, wound.HugePiercing:
+		return fxp.Two
+	case wound.SmallPiercing:
+		return fxp.Half
+	default:
+		return fxp.One
+	}
+}
+
+func unlivingMultiplier(damageType wound.Type) fxp.Int {
+	switch damageType {
+	case wound.LargePiercing:
+		return fxp.Half
+	case wound.Piercing:
+		return fxp.One.Div(fxp.Three)
+	case wound.SmallPiercing:
+		return fxp.Fifth
+	default:
+		return fxp.One
+	}
+}
+
+func homogeneousMultiplier(damageType wound.Type) fxp.Int {
+	switch damageType {
+	case wound.LargePiercing:
+		return fxp.One.Div(fxp.Three)
+	case wound.Piercing:
+		return fxp.Fifth
+	case wound.SmallPiercing:
+		return fxp.Tenth
+	default:
+		return fxp.Half
+	}
+}
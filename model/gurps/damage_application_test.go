@@ -0,0 +1,60 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWoundingMultiplier(t *testing.T) {
+	check.Equal(t, fxp.One, WoundingMultiplier(wound.Crushing, TorsoID, wound.None))
+	check.Equal(t, fxp.OneAndAHalf, WoundingMultiplier(wound.Cutting, TorsoID, wound.None))
+	check.Equal(t, fxp.Two, WoundingMultiplier(wound.Impaling, TorsoID, wound.None))
+	check.Equal(t, fxp.Half, WoundingMultiplier(wound.SmallPiercing, TorsoID, wound.None))
+	check.Equal(t, fxp.Four, WoundingMultiplier(wound.Crushing, skullLocID, wound.None))
+	check.Equal(t, fxp.Three, WoundingMultiplier(wound.Piercing, vitalsLocID, wound.None))
+	check.Equal(t, fxp.Two, WoundingMultiplier(wound.Cutting, neckLocID, wound.None))
+	check.Equal(t, fxp.One, WoundingMultiplier(wound.Impaling, "arm", wound.None), "limbs cap impaling")
+	check.Equal(t, fxp.Fifth, WoundingMultiplier(wound.SmallPiercing, TorsoID, wound.Unliving))
+	check.Equal(t, fxp.One, WoundingMultiplier(wound.Impaling, TorsoID, wound.Unliving))
+	check.Equal(t, fxp.Half, WoundingMultiplier(wound.Impaling, skullLocID, wound.Homogeneous),
+		"homogeneous has no vulnerable spots")
+	check.Equal(t, fxp.Tenth, WoundingMultiplier(wound.SmallPiercing, TorsoID, wound.Homogeneous))
+}
+
+func TestResolveDamage(t *testing.T) {
+	e := NewEntity()
+	d := e.NewDamageApplication()
+	d.Damage = 7
+	d.Type = wound.Cutting
+	r := e.ResolveDamage(d)
+	check.Equal(t, 0, r.DR)
+	check.Equal(t, 7, r.Penetrating)
+	check.Equal(t, 10, r.Injury)
+	check.Equal(t, HitPointsID, r.PoolID)
+
+	d.Tolerance = wound.Diffuse
+	check.Equal(t, 2, e.ResolveDamage(d).Injury, "diffuse caps injury")
+
+	d.Tolerance = wound.None
+	d.Type = wound.SmallPiercing
+	d.Damage = 1
+	check.Equal(t, 1, e.ResolveDamage(d).Injury, "any penetration inflicts at least 1 injury")
+
+	d.Damage = 0
+	check.Equal(t, 0, e.ResolveDamage(d).Injury)
+
+	d.Type = wound.Fatigue
+	check.Equal(t, FatiguePointsID, e.ResolveDamage(d).PoolID)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsel"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/spellcmp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/spellmatch"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Dependent holds information about an item that references another item through its prerequisites, defaults, or
+// features.
+type Dependent struct {
+	Item   nameable.Accesser
+	Name   string
+	Reason string
+}
+
+// CanDisable returns true if the dependent can be disabled. Only traits and equipment can be.
+func (d *Dependent) CanDisable() bool {
+	switch d.Item.(type) {
+	case *Trait, *Equipment:
+		return true
+	default:
+		return false
+	}
+}
+
+// Disable the dependent. Traits are disabled and equipment is unequipped. Has no effect on other types.
+func (d *Dependent) Disable() {
+	switch item := d.Item.(type) {
+	case *Trait:
+		item.Disabled = true
+	case *Equipment:
+		item.Equipped = false
+	}
+}
+
+// FindDependents returns the items within the entity that reference any of the items in the list, or their children.
+// Items that are themselves in the list, or are children of items in the list, are not reported.
+func FindDependents[T NodeTypes](entity *Entity, list []T) []*Dependent {
+	if entity == nil {
+		return nil
+	}
+	removing := make(map[any]bool)
+	var traits []*Trait
+	var skills []*Skill
+	var spells []*Spell
+	var equipment []*Equipment
+	Traverse(func(node T) bool {
+		removing[node] = true
+		switch item := any(node).(type) {
+		case *Trait:
+			traits = append(traits, item)
+		case *Skill:
+			if !item.Container() {
+				skills = append(skills, item)
+			}
+		case *Spell:
+			if !item.Container() {
+				spells = append(spells, item)
+			}
+		case *Equipment:
+			equipment = append(equipment, item)
+		}
+		return false
+	}, false, false, list...)
+	if len(traits) == 0 && len(skills) == 0 && len(spells) == 0 && len(equipment) == 0 {
+		return nil
+	}
+	f := &dependentFinder{
+		traits:    traits,
+		skills:    skills,
+		spells:    spells,
+		equipment: equipment,
+	}
+	Traverse(func(t *Trait) bool {
+		if !removing[t] {
+			f.check(t, t.String(), t.Prereq, t.Features, nil)
+		}
+		return false
+	}, false, false, entity.Traits...)
+	Traverse(func(s *Skill) bool {
+		if !removing[s] {
+			defaults := s.Defaults
+			if techDefs := s.TechniqueDefaults(); len(techDefs) != 0 {
+				defaults = append(techDefs, defaults...)
+			}
+			f.check(s, s.String(), s.Prereq, s.Features, defaults)
+		}
+		return false
+	}, false, false, entity.Skills...)
+	Traverse(func(s *Spell) bool {
+		if !removing[s] {
+			f.check(s, s.String(), s.Prereq, nil, nil)
+		}
+		return false
+	}, false, false, entity.Spells...)
+	eqpFunc := func(e *Equipment) bool {
+		if !removing[e] {
+			f.check(e, e.String(), e.Prereq, e.Features, nil)
+		}
+		return false
+	}
+	Traverse(eqpFunc, false, false, entity.CarriedEquipment...)
+	Traverse(eqpFunc, false, false, entity.OtherEquipment...)
+	return f.dependents
+}
+
+type dependentFinder struct {
+	traits     []*Trait
+	skills     []*Skill
+	spells     []*Spell
+	equipment  []*Equipment
+	dependents []*Dependent
+}
+
+func (f *dependentFinder) check(owner nameable.Accesser, name string, prereqs *PrereqList, features Features, defaults []*SkillDefault) {
+	replacements := owner.NameableReplacements()
+	var reasons []string
+	for _, def := range defaults {
+		if def == nil || !DefaultTypeIsSkillBased(def.DefaultType) {
+			continue
+		}
+		defName := def.NameWithReplacements(replacements)
+		defSpecialization := def.SpecializationWithReplacements(replacements)
+		for _, sk := range f.skills {
+			if strings.EqualFold(sk.NameWithReplacements(), defName) &&
+				(defSpecialization == "" || strings.EqualFold(sk.SpecializationWithReplacements(), defSpecialization)) {
+				reasons = append(reasons, fmt.Sprintf(i18n.Text("defaults to %s"), sk.String()))
+			}
+		}
+	}
+	if prereqs != nil {
+		reasons = f.checkPrereqs(replacements, prereqs, reasons)
+	}
+	for _, feature := range features {
+		switch one := feature.(type) {
+		case *SkillBonus:
+			if one.SelectionType != skillsel.Name {
+				continue
+			}
+			for _, sk := range f.skills {
+				if one.NameCriteria.Matches(replacements, sk.NameWithReplacements()) &&
+					one.SpecializationCriteria.Matches(replacements, sk.SpecializationWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("has a skill bonus for %s"), sk.String()))
+				}
+			}
+		case *SpellBonus:
+			if one.SpellMatchType != spellmatch.Name {
+				continue
+			}
+			for _, sp := range f.spells {
+				if one.NameCriteria.Matches(replacements, sp.NameWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("has a spell bonus for %s"), sp.String()))
+				}
+			}
+		}
+	}
+	for _, reason := range reasons {
+		f.dependents = append(f.dependents, &Dependent{
+			Item:   owner,
+			Name:   name,
+			Reason: reason,
+		})
+	}
+}
+
+func (f *dependentFinder) checkPrereqs(replacements map[string]string, list *PrereqList, reasons []string) []string {
+	for _, one := range list.Prereqs {
+		switch p := one.(type) {
+		case *PrereqList:
+			reasons = f.checkPrereqs(replacements, p, reasons)
+		case *TraitPrereq:
+			if !p.Has {
+				continue
+			}
+			for _, t := range f.traits {
+				if p.NameCriteria.Matches(replacements, t.NameWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("requires %s"), t.String()))
+				}
+			}
+		case *SkillPrereq:
+			if !p.Has {
+				continue
+			}
+			for _, sk := range f.skills {
+				if p.NameCriteria.Matches(replacements, sk.NameWithReplacements()) &&
+					p.SpecializationCriteria.Matches(replacements, sk.SpecializationWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("requires %s"), sk.String()))
+				}
+			}
+		case *SpellPrereq:
+			if !p.Has || p.SubType != spellcmp.Name {
+				continue
+			}
+			for _, sp := range f.spells {
+				if p.QualifierCriteria.Matches(replacements, sp.NameWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("requires %s"), sp.String()))
+				}
+			}
+		case *EquippedEquipmentPrereq:
+			for _, e := range f.equipment {
+				if p.NameCriteria.Matches(replacements, e.NameWithReplacements()) {
+					reasons = append(reasons, fmt.Sprintf(i18n.Text("requires %s to be equipped"), e.String()))
+				}
+			}
+		}
+	}
+	return reasons
+}
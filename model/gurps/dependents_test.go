@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFindDependents(t *testing.T) {
+	e := NewEntity()
+	skill := NewSkill(e, nil, false)
+	skill.Name = "Broadsword"
+	technique := NewTechnique(e, nil, "Broadsword")
+	other := NewSkill(e, nil, false)
+	other.Name = "Shortsword"
+	trait := NewTrait(e, nil, false)
+	trait.Prereq = NewPrereqList()
+	skillPrereq := NewSkillPrereq()
+	skillPrereq.NameCriteria.Qualifier = "Broadsword"
+	trait.Prereq.Prereqs = append(trait.Prereq.Prereqs, skillPrereq)
+	e.Skills = []*Skill{skill, technique, other}
+	e.Traits = []*Trait{trait}
+
+	dependents := FindDependents(e, []*Skill{skill})
+	check.Equal(t, 2, len(dependents), "technique and trait depend on the skill")
+	check.Equal(t, true, dependents[0].CanDisable(), "traits can be disabled")
+	check.Equal(t, false, dependents[1].CanDisable(), "techniques can't be disabled")
+	dependents[0].Disable()
+	check.Equal(t, true, trait.Disabled, "trait disabled")
+
+	check.Equal(t, 0, len(FindDependents(e, []*Skill{other})), "nothing depends on the other skill")
+	check.Equal(t, 1, len(FindDependents(e, []*Skill{skill, technique})), "technique is also being removed")
+}
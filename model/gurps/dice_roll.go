@@ -0,0 +1,104 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+// MaxRollHistory is the maximum number of entries kept in a RollHistory.
+const MaxRollHistory = 200
+
+// DiceRoll holds the outcome of rolling the dice found in some text, such as a weapon's damage.
+type DiceRoll struct {
+	Text   string
+	Dice   *dice.Dice
+	Result string
+	Total  int
+}
+
+// RollDiceIn rolls the first dice specification found in the text. The remainder of the text is retained in the result,
+// so that rolling "2d+1 cut" might produce "9 cut". Per the rules for basic damage, a roll of damage that would be less
+// than 1 becomes 1, or 0 for crushing damage. Returns false if no dice specification could be found.
+func RollDiceIn(text string) (DiceRoll, bool) {
+	return rollDiceIn(text, nil)
+}
+
+func rollDiceIn(text string, rnd rand.Randomizer) (DiceRoll, bool) {
+	start, end := dice.ExtractDicePosition(text)
+	if start == -1 {
+		return DiceRoll{}, false
+	}
+	spec := text[start:end]
+	if _, err := strconv.Atoi(strings.TrimSpace(spec)); err == nil {
+		// A plain number isn't something that can be rolled
+		return DiceRoll{}, false
+	}
+	d := dice.New(spec)
+	total := d.RollWithRandomizer(rnd, false)
+	remainder := strings.TrimSpace(text[end:])
+	if total < 1 && strings.TrimSpace(text[:start]) == "" && remainder != "" {
+		if strings.HasPrefix(remainder, "cr") {
+			total = max(total, 0)
+		} else {
+			total = 1
+		}
+	}
+	return DiceRoll{
+		Text:   text,
+		Dice:   d,
+		Result: strings.TrimSpace(text[:start] + strconv.Itoa(total) + " " + remainder),
+		Total:  total,
+	}, true
+}
+
+// String implements fmt.Stringer.
+func (r DiceRoll) String() string {
+	return fmt.Sprintf(i18n.Text("Rolled %s: %s"), r.Text, r.Result)
+}
+
+// RollHistoryEntry holds a single roll in a RollHistory.
+type RollHistoryEntry struct {
+	When   time.Time
+	Title  string
+	Detail string
+}
+
+// RollHistory holds the most recent rolls, newest first.
+type RollHistory struct {
+	Entries []*RollHistoryEntry
+}
+
+// Add a roll to the history, discarding the oldest entries if the history is full.
+func (h *RollHistory) Add(title, detail string) *RollHistoryEntry {
+	entry := &RollHistoryEntry{
+		When:   time.Now(),
+		Title:  title,
+		Detail: detail,
+	}
+	h.Entries = slices.Insert(h.Entries, 0, entry)
+	if len(h.Entries) > MaxRollHistory {
+		h.Entries = h.Entries[:MaxRollHistory]
+	}
+	return entry
+}
+
+// Clear removes all entries from the history.
+func (h *RollHistory) Clear() {
+	h.Entries = nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+// fixedRandomizer always rolls the same face on every die.
+type fixedRandomizer int
+
+func (r fixedRandomizer) Intn(_ int) int {
+	return int(r)
+}
+
+func TestRollDiceIn(t *testing.T) {
+	r, ok := rollDiceIn("2d+1 cut", fixedRandomizer(3))
+	check.True(t, ok)
+	check.Equal(t, 9, r.Total)
+	check.Equal(t, "9 cut", r.Result)
+	check.Equal(t, "Rolled 2d+1 cut: 9 cut", r.String())
+
+	r, ok = rollDiceIn("1d-4 cr", fixedRandomizer(0))
+	check.True(t, ok)
+	check.Equal(t, 0, r.Total, "crushing damage may be zero")
+	r, ok = rollDiceIn("1d-4 imp", fixedRandomizer(0))
+	check.True(t, ok)
+	check.Equal(t, 1, r.Total, "other damage is at least one")
+	r, ok = rollDiceIn("1d-4", fixedRandomizer(0))
+	check.True(t, ok)
+	check.Equal(t, -3, r.Total, "plain dice are not adjusted")
+
+	_, ok = rollDiceIn("12", fixedRandomizer(0))
+	check.False(t, ok)
+	_, ok = rollDiceIn("none", fixedRandomizer(0))
+	check.False(t, ok)
+}
+
+func TestRollHistory(t *testing.T) {
+	var h RollHistory
+	for i := range MaxRollHistory + 5 {
+		h.Add(fmt.Sprintf("Roll %d", i), "")
+	}
+	check.Equal(t, MaxRollHistory, len(h.Entries))
+	check.Equal(t, fmt.Sprintf("Roll %d", MaxRollHistory+4), h.Entries[0].Title)
+	check.Equal(t, "Roll 5", h.Entries[MaxRollHistory-1].Title)
+	h.Clear()
+	check.Equal(t, 0, len(h.Entries))
+}
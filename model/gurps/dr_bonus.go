@@ -29,6 +29,8 @@ type DRBonusData struct {
 	Type           feature.Type `json:"type"`
 	Locations      []string     `json:"locations,omitempty"`
 	Specialization string       `json:"specialization,omitempty"`
+	Flexible       bool         `json:"flexible,omitempty"`
+	ToughSkin      bool         `json:"tough_skin,omitempty"`
 	LeveledAmount
 }
 
@@ -98,7 +100,31 @@ func (d *DRBonus) AddToTooltip(buffer *xio.ByteBuffer) {
 		buffer.WriteString(d.LeveledAmount.Format(false))
 		buffer.WriteString(i18n.Text(" against "))
 		buffer.WriteString(d.Specialization)
-		buffer.WriteString(i18n.Text(" attacks]"))
+		buffer.WriteString(i18n.Text(" attacks"))
+		if kind := d.KindText(); kind != "" {
+			buffer.WriteString(", ")
+			buffer.WriteString(kind)
+		}
+		buffer.WriteByte(']')
+	}
+}
+
+// KindText returns a description of the kind of DR this bonus provides, i.e. whether it is flexible or tough skin, or
+// an empty string if it is neither.
+func (d *DRBonus) KindText() string {
+	return drKindText(d.Flexible, d.ToughSkin)
+}
+
+func drKindText(flexible, toughSkin bool) string {
+	switch {
+	case flexible && toughSkin:
+		return i18n.Text("flexible, tough skin")
+	case flexible:
+		return i18n.Text("flexible")
+	case toughSkin:
+		return i18n.Text("tough skin")
+	default:
+		return ""
 	}
 }
 
@@ -140,5 +166,7 @@ func (d *DRBonus) Hash(h hash.Hash) {
 		_, _ = h.Write([]byte(loc))
 	}
 	_, _ = h.Write([]byte(d.Specialization))
+	_ = binary.Write(h, binary.LittleEndian, d.Flexible)
+	_ = binary.Write(h, binary.LittleEndian, d.ToughSkin)
 	d.LeveledAmount.Hash(h)
 }
@@ -0,0 +1,151 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// DRContribution holds the DR a single source provides at a hit location, keyed by the type of attack it applies to.
+// A source that provides both flexible and rigid DR, for example, has a separate contribution for each.
+type DRContribution struct {
+	Source    string
+	DR        map[string]int
+	Flexible  bool
+	ToughSkin bool
+}
+
+// DRContributions returns the DR provided at this HitLocation by each source, in the order they were found. This
+// includes the DR bonus built into the location itself, as well as any DR covering the location that owns the table
+// this location is in.
+func (h *HitLocation) DRContributions(entity *Entity) []*DRContribution {
+	type key struct {
+		source    string
+		flexible  bool
+		toughSkin bool
+	}
+	var list []*DRContribution
+	m := make(map[key]*DRContribution)
+	add := func(k key, specialization string, amount int) {
+		c, exists := m[k]
+		if !exists {
+			c = &DRContribution{
+				Source:    k.source,
+				DR:        make(map[string]int),
+				Flexible:  k.flexible,
+				ToughSkin: k.toughSkin,
+			}
+			m[k] = c
+			list = append(list, c)
+		}
+		c.DR[strings.ToLower(specialization)] += amount
+	}
+	for loc := h; loc != nil; {
+		if loc.DRBonus != 0 {
+			add(key{source: loc.ChoiceName}, AllID, loc.DRBonus)
+		}
+		for _, bonus := range entity.drBonusesFor(loc.LocID) {
+			add(key{
+				source:    bonus.parentName(),
+				flexible:  bonus.Flexible,
+				toughSkin: bonus.ToughSkin,
+			}, bonus.Specialization, fxp.As[int](bonus.AdjustedAmount()))
+		}
+		if loc.owningTable == nil {
+			break
+		}
+		loc = loc.owningTable.owningLocation
+	}
+	return list
+}
+
+// Kind returns a description of the kind of DR this contribution provides, i.e. whether it is flexible or tough skin,
+// or an empty string if it is neither.
+func (c *DRContribution) Kind() string {
+	return drKindText(c.Flexible, c.ToughSkin)
+}
+
+// String returns a description of the DR provided, e.g. "+3 against all attacks, +2 against crushing attacks".
+func (c *DRContribution) String() string {
+	keys := make([]string, 0, len(c.DR))
+	for k := range c.DR {
+		if k != AllID {
+			keys = append(keys, k)
+		}
+	}
+	txt.SortStringsNaturalAscending(keys)
+	if _, exists := c.DR[AllID]; exists {
+		keys = append([]string{AllID}, keys...)
+	}
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf(i18n.Text("%+d against %s attacks"), c.DR[k], k))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DRTooltip returns a description of the DR covering this HitLocation, suitable for use as a tooltip. The total DR
+// against each type of attack is listed first, followed by what each source contributes and, when any of it is
+// flexible or tough skin, the portion of the DR that is.
+func (h *HitLocation) DRTooltip(entity *Entity) string {
+	var buffer strings.Builder
+	drMap := h.DR(entity, nil, nil)
+	keys := make([]string, 0, len(drMap))
+	for k := range drMap {
+		keys = append(keys, k)
+	}
+	txt.SortStringsNaturalAscending(keys)
+	base := drMap[AllID]
+	for _, k := range keys {
+		value := drMap[k]
+		if k != AllID {
+			value += base
+		}
+		fmt.Fprintf(&buffer, i18n.Text("\n%d against %s attacks"), value, k)
+	}
+	contributions := h.DRContributions(entity)
+	if len(contributions) == 0 {
+		return buffer.String()
+	}
+	buffer.WriteByte('\n')
+	flexible := make(map[string]int)
+	toughSkin := make(map[string]int)
+	for _, c := range contributions {
+		fmt.Fprintf(&buffer, "\n%s [%s", c.Source, c.String())
+		if kind := c.Kind(); kind != "" {
+			buffer.WriteString(", ")
+			buffer.WriteString(kind)
+		}
+		buffer.WriteByte(']')
+		for k, v := range c.DR {
+			if c.Flexible {
+				flexible[k] += v
+			}
+			if c.ToughSkin {
+				toughSkin[k] += v
+			}
+		}
+	}
+	if len(flexible) != 0 || len(toughSkin) != 0 {
+		buffer.WriteByte('\n')
+		if len(flexible) != 0 {
+			fmt.Fprintf(&buffer, i18n.Text("\nFlexible: %s"), FormatDR(flexible))
+		}
+		if len(toughSkin) != 0 {
+			fmt.Fprintf(&buffer, i18n.Text("\nTough skin: %s"), FormatDR(toughSkin))
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestDRContributions(t *testing.T) {
+	e := NewEntity()
+	jacket := NewEquipment(e, nil, false)
+	jacket.Name = "Leather Jacket"
+	jacketDR := NewDRBonus()
+	jacketDR.Amount = fxp.Two
+	jacketDR.Flexible = true
+	jacket.Features = Features{jacketDR}
+	e.CarriedEquipment = []*Equipment{jacket}
+	hide := NewTrait(e, nil, false)
+	hide.Name = "Thick Hide"
+	hideDR := NewDRBonus()
+	hideDR.Amount = fxp.Three
+	hideDR.Locations = []string{AllID}
+	hideDR.ToughSkin = true
+	hide.Features = Features{hideDR}
+	e.SetTraitList([]*Trait{hide})
+	e.Recalculate()
+
+	torso := e.SheetSettings.BodyType.LookupLocationByID(e, TorsoID)
+	check.NotNil(t, torso)
+	contributions := torso.DRContributions(e)
+	check.Equal(t, 2, len(contributions))
+	var flexible, toughSkin *DRContribution
+	for _, c := range contributions {
+		if c.Flexible {
+			flexible = c
+		}
+		if c.ToughSkin {
+			toughSkin = c
+		}
+	}
+	check.NotNil(t, flexible)
+	check.Equal(t, "Leather Jacket", flexible.Source)
+	check.Equal(t, 2, flexible.DR[AllID])
+	check.Equal(t, "flexible", flexible.Kind())
+	check.NotNil(t, toughSkin)
+	check.Equal(t, 3, toughSkin.DR[AllID])
+	check.Equal(t, "+3 against all attacks", toughSkin.String())
+
+	tooltip := torso.DRTooltip(e)
+	check.True(t, strings.Contains(tooltip, "5 against all attacks"))
+	check.True(t, strings.Contains(tooltip, "Flexible: 2"))
+	check.True(t, strings.Contains(tooltip, "Tough skin: 3"))
+	check.Equal(t, "5", torso.DisplayDR(e, nil))
+}
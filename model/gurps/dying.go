@@ -0,0 +1,211 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+const (
+	maxDeathCheckMultiples = 4
+	autoDeathMultiple      = 5
+	destroyedMultiple      = 10
+)
+
+// DyingStatus records the outcome of the death checks made as a character's HP fell below zero.
+type DyingStatus struct {
+	// ChecksMade is the number of multiples of -HP for which a death check has been made.
+	ChecksMade      int  `json:"checks_made,omitempty"`
+	MortallyWounded bool `json:"mortally_wounded,omitempty"`
+	Dead            bool `json:"dead,omitempty"`
+}
+
+// DyingState holds a snapshot of the data that may be altered by death checks.
+type DyingState struct {
+	dying     *DyingStatus
+	changeLog []*ChangeLogEntry
+}
+
+// DyingState returns a snapshot of the data that may be altered by death checks.
+func (e *Entity) DyingState() *DyingState {
+	s := &DyingState{changeLog: slices.Clone(e.ChangeLog)}
+	if e.Dying != nil {
+		dying := *e.Dying
+		s.dying = &dying
+	}
+	return s
+}
+
+// ApplyDyingState restores a snapshot previously obtained from DyingState.
+func (e *Entity) ApplyDyingState(s *DyingState) {
+	e.Dying = nil
+	if s.dying != nil {
+		dying := *s.dying
+		e.Dying = &dying
+	}
+	e.ChangeLog = slices.Clone(s.changeLog)
+}
+
+// NegativeHPMultiples returns the number of whole multiples of -HP the character's current HP has reached, or 0 if HP
+// is above -HP. Returns -1 if the character has no hit points pool.
+func (e *Entity) NegativeHPMultiples() int {
+	attr, ok := e.Attributes.Set[HitPointsID]
+	if !ok {
+		return -1
+	}
+	maximum := attr.Maximum()
+	current := attr.Current()
+	if maximum <= 0 || current > -maximum {
+		return 0
+	}
+	return fxp.As[int]((-current).Div(maximum).Trunc())
+}
+
+// PendingDeathChecks returns the number of death checks the character must still make for the HP lost so far.
+func (e *Entity) PendingDeathChecks() int {
+	if e.Dying != nil && e.Dying.Dead {
+		return 0
+	}
+	multiples := e.NegativeHPMultiples()
+	if multiples >= autoDeathMultiple {
+		return 0
+	}
+	made := 0
+	if e.Dying != nil {
+		made = e.Dying.ChecksMade
+	}
+	return max(min(multiples, maxDeathCheckMultiples)-made, 0)
+}
+
+// UpdateDyingStatus brings the dying status in line with the character's current HP: death checks that no longer apply
+// because of healing are forgotten and a character reduced to -5×HP or less dies automatically. Returns true if
+// anything changed.
+func (e *Entity) UpdateDyingStatus() bool {
+	multiples := e.NegativeHPMultiples()
+	if multiples < 0 {
+		return false
+	}
+	changed := false
+	if multiples >= autoDeathMultiple && (e.Dying == nil || !e.Dying.Dead) {
+		e.ensureDyingStatus().Dead = true
+		description := i18n.Text("Died on reaching -5×HP")
+		if multiples >= destroyedMultiple {
+			description = i18n.Text("Died on reaching -10×HP; the body is destroyed")
+		}
+		e.addDyingLogEntry(description)
+		changed = true
+	}
+	if e.Dying != nil && !e.Dying.Dead && e.Dying.ChecksMade > multiples {
+		e.Dying.ChecksMade = multiples
+		changed = true
+	}
+	if e.Dying != nil && *e.Dying == (DyingStatus{}) {
+		e.Dying = nil
+	}
+	return changed
+}
+
+// MakeDeathCheck makes the next pending death check, rolling against HT. Failing by 1 or 2 leaves the character
+// mortally wounded rather than dead. The outcome is added to the change log and returned.
+func (e *Entity) MakeDeathCheck(roll func(target int) SuccessRoll) string {
+	if e.PendingDeathChecks() == 0 {
+		return ""
+	}
+	dying := e.ensureDyingStatus()
+	dying.ChecksMade++
+	r := roll(fxp.As[int](e.Attributes.Current(HealthID).Trunc()))
+	var result string
+	switch {
+	case r.Success:
+		result = i18n.Text("survived")
+	case r.Margin() >= -2 && !r.Critical:
+		dying.MortallyWounded = true
+		result = i18n.Text("mortally wounded")
+	default:
+		dying.Dead = true
+		result = i18n.Text("died")
+	}
+	description := fmt.Sprintf(i18n.Text("Death check at -%d×HP: %s; %s"), dying.ChecksMade, r, result)
+	e.addDyingLogEntry(description)
+	return description
+}
+
+// MakeMortalWoundCheck makes the roll against HT required every half hour while mortally wounded. A failure is fatal
+// and a critical success ends the mortal wound. The outcome is added to the change log and returned.
+func (e *Entity) MakeMortalWoundCheck(roll func(target int) SuccessRoll) string {
+	if e.Dying == nil || !e.Dying.MortallyWounded || e.Dying.Dead {
+		return ""
+	}
+	r := roll(fxp.As[int](e.Attributes.Current(HealthID).Trunc()))
+	var result string
+	switch {
+	case r.Success && r.Critical:
+		e.Dying.MortallyWounded = false
+		result = i18n.Text("no longer mortally wounded")
+	case r.Success:
+		result = i18n.Text("still mortally wounded")
+	default:
+		e.Dying.Dead = true
+		result = i18n.Text("died")
+	}
+	description := fmt.Sprintf(i18n.Text("Mortal wound check: %s; %s"), r, result)
+	e.addDyingLogEntry(description)
+	return description
+}
+
+// ClearDyingStatus forgets the death checks made and their outcomes, such as when a dead character is revived.
+func (e *Entity) ClearDyingStatus() {
+	if e.Dying != nil {
+		e.Dying = nil
+		e.addDyingLogEntry(i18n.Text("Cleared the death and dying status"))
+	}
+}
+
+// DyingConditions returns the conditions that currently apply to the character because of HP loss, along with the
+// rolls they require.
+func (e *Entity) DyingConditions() []string {
+	if e.Dying != nil && e.Dying.Dead {
+		return []string{i18n.Text("Dead")}
+	}
+	var list []string
+	multiples := e.NegativeHPMultiples()
+	if multiples > 0 {
+		list = append(list, fmt.Sprintf(i18n.Text("Dying (at -%d×HP)"), multiples))
+	}
+	if pending := e.PendingDeathChecks(); pending > 0 {
+		list = append(list, fmt.Sprintf(i18n.Text("%d death check(s) required: roll vs HT or die"), pending))
+	}
+	if e.Dying != nil && e.Dying.MortallyWounded {
+		list = append(list, i18n.Text("Mortally wounded: roll vs HT every half hour or die"))
+	}
+	if attr, ok := e.Attributes.Set[HitPointsID]; ok && attr.Current() <= 0 {
+		list = append(list, i18n.Text("At 0 HP or less: roll vs HT each turn to remain conscious"))
+	}
+	return list
+}
+
+func (e *Entity) ensureDyingStatus() *DyingStatus {
+	if e.Dying == nil {
+		e.Dying = &DyingStatus{}
+	}
+	return e.Dying
+}
+
+func (e *Entity) addDyingLogEntry(description string) {
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: description,
+	})
+}
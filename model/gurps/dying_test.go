@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestDeathChecks(t *testing.T) {
+	e := NewEntity()
+	hp := e.Attributes.Set[HitPointsID]
+	setHP := func(value int) {
+		hp.Damage = hp.Maximum() - fxp.From(value)
+		e.UpdateDyingStatus()
+	}
+	rollOf := func(roll int) func(int) SuccessRoll {
+		return func(target int) SuccessRoll { return NewSuccessRoll(target, roll) }
+	}
+
+	setHP(-5)
+	check.Equal(t, 0, e.PendingDeathChecks(), "no checks until -1×HP")
+	check.Equal(t, 1, len(e.DyingConditions()), "only the consciousness roll")
+
+	setHP(-25)
+	check.Equal(t, 2, e.NegativeHPMultiples())
+	check.Equal(t, 2, e.PendingDeathChecks())
+	e.MakeDeathCheck(rollOf(10))
+	check.Equal(t, 1, e.PendingDeathChecks())
+	check.False(t, e.Dying.MortallyWounded)
+	e.MakeDeathCheck(rollOf(12))
+	check.Equal(t, 0, e.PendingDeathChecks())
+	check.True(t, e.Dying.MortallyWounded, "failing by 2 is a mortal wound")
+	check.False(t, e.Dying.Dead)
+	check.Equal(t, 2, len(e.ChangeLog))
+
+	e.MakeMortalWoundCheck(rollOf(4))
+	check.False(t, e.Dying.MortallyWounded, "a critical success ends the mortal wound")
+
+	setHP(-15)
+	check.Equal(t, 1, e.Dying.ChecksMade, "healing forgets checks that no longer apply")
+	setHP(-20)
+	check.Equal(t, 1, e.PendingDeathChecks())
+	e.MakeDeathCheck(rollOf(13))
+	check.True(t, e.Dying.Dead)
+	check.Equal(t, []string{"Dead"}, e.DyingConditions())
+
+	e = NewEntity()
+	hp = e.Attributes.Set[HitPointsID]
+	setHP(-50)
+	check.True(t, e.Dying.Dead, "-5×HP is automatic death")
+	check.Equal(t, 0, e.PendingDeathChecks())
+}
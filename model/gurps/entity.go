@@ -23,11 +23,13 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/criteria"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/condition"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/progression"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsel"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stlimit"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/threshold"
@@ -71,22 +73,46 @@ func (pb *PointsBreakdown) Total() fxp.Int {
 
 // EntityData holds the Entity data that is written to disk.
 type EntityData struct {
-	Version          int             `json:"version"`
-	ID               tid.TID         `json:"id"`
-	TotalPoints      fxp.Int         `json:"total_points"`
-	PointsRecord     []*PointsRecord `json:"points_record,omitempty"`
-	Profile          Profile         `json:"profile"`
-	SheetSettings    *SheetSettings  `json:"settings,omitempty"`
-	Attributes       *Attributes     `json:"attributes,omitempty"`
-	Traits           []*Trait        `json:"traits,alt=advantages,omitempty"`
-	Skills           []*Skill        `json:"skills,omitempty"`
-	Spells           []*Spell        `json:"spells,omitempty"`
-	CarriedEquipment []*Equipment    `json:"equipment,omitempty"`
-	OtherEquipment   []*Equipment    `json:"other_equipment,omitempty"`
-	Notes            []*Note         `json:"notes,omitempty"`
-	CreatedOn        jio.Time        `json:"created_date"`
-	ModifiedOn       jio.Time        `json:"modified_date"`
-	ThirdParty       map[string]any  `json:"third_party,omitempty"`
+	Version               int                    `json:"version"`
+	ID                    tid.TID                `json:"id"`
+	TotalPoints           fxp.Int                `json:"total_points"`
+	PointsRecord          []*PointsRecord        `json:"points_record,omitempty"`
+	PointsExpenditures    []*PointsExpenditure   `json:"points_expenditures,omitempty"`
+	PointsEarmarks        []*PointsEarmark       `json:"points_earmarks,omitempty"`
+	Mode                  sheetmode.Mode         `json:"mode"`
+	ChangeLog             []*ChangeLogEntry      `json:"change_log,omitempty"`
+	Profile               Profile                `json:"profile"`
+	SheetSettings         *SheetSettings         `json:"settings,omitempty"`
+	Attributes            *Attributes            `json:"attributes,omitempty"`
+	Traits                []*Trait               `json:"traits,alt=advantages,omitempty"`
+	Skills                []*Skill               `json:"skills,omitempty"`
+	Spells                []*Spell               `json:"spells,omitempty"`
+	CarriedEquipment      []*Equipment           `json:"equipment,omitempty"`
+	OtherEquipment        []*Equipment           `json:"other_equipment,omitempty"`
+	Loadouts              []*Loadout             `json:"loadouts,omitempty"`
+	Snapshots             []*CharacterSnapshot   `json:"snapshots,omitempty"`
+	Notes                 []*Note                `json:"notes,omitempty"`
+	Variables             []*EntityVariable      `json:"variables,omitempty"`
+	Languages             []*Language            `json:"languages,omitempty"`
+	CulturalFamiliarities []*CulturalFamiliarity `json:"cultural_familiarities,omitempty"`
+	Associates            []*Associate           `json:"associates,omitempty"`
+	Companions            []*Companion           `json:"companions,omitempty"`
+	Reputations           []*Reputation          `json:"reputations,omitempty"`
+	Timeline              *Timeline              `json:"timeline,omitempty"`
+	SessionMinutes        int                    `json:"session_minutes,omitempty"`
+	ThresholdTally        fxp.Int                `json:"threshold_tally,omitempty"`
+	Grimoire              []*Ritual              `json:"grimoire,omitempty"`
+	SuppressedPowers      []string               `json:"suppressed_power_sources,omitempty"`
+	CreatedOn             jio.Time               `json:"created_date"`
+	ModifiedOn            jio.Time               `json:"modified_date"`
+	ThirdParty            map[string]any         `json:"third_party,omitempty"`
+	PinnedRows            []tid.TID              `json:"pinned_rows,omitempty"`
+	OrderLockedLists      []string               `json:"order_locked_lists,omitempty"`
+	Dying                 *DyingStatus           `json:"dying,omitempty"`
+	Conditions            []condition.Type       `json:"conditions,omitempty"`
+	ShockPenalty          int                    `json:"shock_penalty,omitempty"`
+	AppliedTemplates      []*AppliedTemplate     `json:"applied_templates,omitempty"`
+	RollModifiers         []*RollModifier        `json:"roll_modifiers,omitempty"`
 }
 
 type features struct {
@@ -94,6 +120,7 @@ type features struct {
 	costReductions    []*CostReduction
 	drBonuses         []*DRBonus
 	skillBonuses      []*SkillBonus
+	skillGrants       []*SkillGrant
 	skillPointBonuses []*SkillPointBonus
 	spellBonuses      []*SpellBonus
 	spellPointBonuses []*SpellPointBonus
@@ -112,6 +139,7 @@ type Entity struct {
 	BlockBonus                      fxp.Int
 	BlockBonusTooltip               string
 	features                        features
+	grantedSkills                   []*Skill
 	variableResolverExclusions      map[string]bool
 	skillResolverExclusions         map[string]bool
 	cachedBasicLift                 fxp.Weight
@@ -119,6 +147,7 @@ type Entity struct {
 	cachedEncumbranceLevelForSkills encumbrance.Level
 	cachedVariables                 map[string]string
 	srcMatcher                      *SrcMatcher
+	savedPoints                     *PointsSnapshot
 }
 
 // NewEntityFromFile loads an Entity from a file.
@@ -139,6 +168,7 @@ func NewEntity() *Entity {
 	var e Entity
 	e.ID = tid.MustNewTID(kinds.Entity)
 	e.TotalPoints = settings.InitialPoints
+	e.Mode = sheetmode.Creation
 	e.PointsRecord = append(e.PointsRecord, &PointsRecord{
 		When:   jio.Now(),
 		Points: settings.InitialPoints,
@@ -153,6 +183,7 @@ func NewEntity() *Entity {
 	if settings.AutoAddNaturalAttacks {
 		e.Traits = append(e.Traits, NewNaturalAttacks(&e, nil))
 	}
+	settings.NewCharacter.Apply(&e)
 	e.ModifiedOn = e.CreatedOn
 	e.Recalculate()
 	return &e
@@ -238,6 +269,7 @@ func (e *Entity) UnmarshalJSON(data []byte) error {
 	if !tid.IsKindAndValid(e.ID, kinds.Entity) {
 		e.ID = tid.MustNewTID(kinds.Entity)
 	}
+	e.Mode = e.Mode.EnsureValid()
 	if e.SheetSettings == nil {
 		e.SheetSettings = GlobalSettings().SheetSettings().Clone(e)
 	}
@@ -344,6 +376,7 @@ func (e *Entity) processFeatures() {
 		}, true, true, a.Modifiers...)
 		return false
 	}, true, false, e.Traits...)
+	e.processPowerTalentBonuses()
 	Traverse(func(s *Skill) bool {
 		for _, f := range s.Features {
 			e.processFeature(s, nil, f, s.LevelData.Level)
@@ -365,6 +398,12 @@ func (e *Entity) processFeatures() {
 		}, true, true, eqp.Modifiers...)
 		return false
 	}, false, false, e.CarriedEquipment...)
+	for _, c := range e.Conditions {
+		for _, f := range e.ConditionFeatures(c) {
+			e.processFeature(c, nil, f, 0)
+		}
+	}
+	e.updateGrantedSkills()
 	e.LiftingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.LiftingOnly, nil).Trunc()
 	e.StrikingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.StrikingOnly, nil).Trunc()
 	e.ThrowingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.ThrowingOnly, nil).Trunc()
@@ -395,6 +434,9 @@ func (e *Entity) processFeatures() {
 }
 
 func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels fxp.Int) {
+	if r, ok := f.(amountExpressionResolver); ok {
+		r.ResolveExpression(e)
+	}
 	if bonus, ok := f.(Bonus); ok {
 		bonus.SetOwner(owner)
 		bonus.SetSubOwner(subOwner)
@@ -405,6 +447,11 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 		e.features.attributeBonuses = append(e.features.attributeBonuses, actual)
 	case *CostReduction:
 		e.features.costReductions = append(e.features.costReductions, actual)
+	case *SkillGrant:
+		if _, ok := owner.(*Equipment); ok {
+			actual.owner = owner
+			e.features.skillGrants = append(e.features.skillGrants, actual)
+		}
 	case *DRBonus:
 		if len(actual.Locations) == 0 { // "this armor"
 			if eqp, ok := owner.(*Equipment); ok {
@@ -424,6 +471,8 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 									Type:           feature.DRBonus,
 									Locations:      slices.Clone(drBonus.Locations),
 									Specialization: actual.Specialization,
+									Flexible:       actual.Flexible || drBonus.Flexible,
+									ToughSkin:      actual.ToughSkin || drBonus.ToughSkin,
 									LeveledAmount:  actual.LeveledAmount,
 								},
 							}
@@ -445,6 +494,8 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 							Type:           feature.DRBonus,
 							Locations:      locations,
 							Specialization: actual.Specialization,
+							Flexible:       actual.Flexible,
+							ToughSkin:      actual.ToughSkin,
 							LeveledAmount:  actual.LeveledAmount,
 						},
 					}
@@ -513,6 +564,9 @@ func (e *Entity) processPrereqs() {
 			if satisfied && s.IsTechnique() {
 				satisfied = s.TechniqueSatisfied(&tooltip, prefix)
 			}
+			if satisfied {
+				satisfied = s.WildcardPointsSatisfied(&tooltip, prefix)
+			}
 			if !satisfied {
 				s.UnsatisfiedReason = notMetPrefix + tooltip.String()
 			}
@@ -542,6 +596,9 @@ func (e *Entity) processPrereqs() {
 			if satisfied && s.IsRitualMagic() {
 				satisfied = s.RitualMagicSatisfied(&tooltip, prefix)
 			}
+			if satisfied {
+				satisfied = s.MagerySatisfied(&tooltip, prefix)
+			}
 			if !satisfied {
 				s.UnsatisfiedReason = notMetPrefix + tooltip.String()
 			}
@@ -563,6 +620,27 @@ func (e *Entity) processPrereqs() {
 	Traverse(equipmentFunc, false, false, e.OtherEquipment...)
 }
 
+// UnmetPrereqsKey returns a key identifying which traits and equipment currently have unmet prerequisites. Comparing
+// the key before and after a change reveals whether the prerequisite state of those lists was affected by it.
+func (e *Entity) UnmetPrereqsKey() string {
+	var buffer strings.Builder
+	Traverse(func(t *Trait) bool {
+		if t.UnsatisfiedReason != "" {
+			buffer.WriteString(string(t.ID()))
+		}
+		return false
+	}, false, false, e.Traits...)
+	equipmentFunc := func(eqp *Equipment) bool {
+		if eqp.UnsatisfiedReason != "" {
+			buffer.WriteString(string(eqp.ID()))
+		}
+		return false
+	}
+	Traverse(equipmentFunc, false, false, e.CarriedEquipment...)
+	Traverse(equipmentFunc, false, false, e.OtherEquipment...)
+	return buffer.String()
+}
+
 // UpdateSkills updates the levels of all skills.
 func (e *Entity) UpdateSkills() bool {
 	changed := false
@@ -587,16 +665,31 @@ func (e *Entity) UpdateSpells() bool {
 	return changed
 }
 
-// UnspentPoints returns the number of unspent points.
+// UnspentPoints returns the number of unspent points. This is derived from the points awarded in the points record and
+// the points spent on the character, so it can't be set directly; add a points record entry instead.
 func (e *Entity) UnspentPoints() fxp.Int {
 	return e.TotalPoints - e.PointsBreakdown().Total()
 }
 
-// SetUnspentPoints sets the number of unspent points.
-func (e *Entity) SetUnspentPoints(unspent fxp.Int) {
-	if unspent != e.UnspentPoints() {
-		e.TotalPoints = unspent + e.PointsBreakdown().Total()
+// EarmarkedPoints returns the number of unspent points that have been earmarked for a particular purpose.
+func (e *Entity) EarmarkedPoints() fxp.Int {
+	var total fxp.Int
+	for _, one := range e.PointsEarmarks {
+		if one.Points > 0 {
+			total += one.Points
+		}
 	}
+	return total
+}
+
+// AvailablePoints returns the number of unspent points that have not been earmarked.
+func (e *Entity) AvailablePoints() fxp.Int {
+	return e.UnspentPoints() - e.EarmarkedPoints()
+}
+
+// SpendingIntoEarmarks returns true if the unspent points are no longer sufficient to cover the earmarked points.
+func (e *Entity) SpendingIntoEarmarks() bool {
+	return e.AvailablePoints() < 0
 }
 
 // PointsBreakdown returns the point breakdown for spent points.
@@ -620,12 +713,16 @@ func (e *Entity) PointsBreakdown() *PointsBreakdown {
 }
 
 func calculateSingleTraitPoints(t *Trait, pb *PointsBreakdown) {
+	if t.IsAlternateForm() {
+		pb.Advantages += t.AdjustedPoints()
+		return
+	}
 	if t.Disabled {
 		return
 	}
 	if t.Container() {
 		switch t.ContainerType {
-		case container.Group:
+		case container.Group, container.Power:
 			for _, child := range t.Children {
 				calculateSingleTraitPoints(child, pb)
 			}
@@ -780,6 +877,15 @@ func (e *Entity) AddDRBonusesFor(locationID string, tooltip *xio.ByteBuffer, drM
 	if drMap == nil {
 		drMap = make(map[string]int)
 	}
+	for _, one := range e.drBonusesFor(locationID) {
+		drMap[strings.ToLower(one.Specialization)] += fxp.As[int](one.AdjustedAmount())
+		one.AddToTooltip(tooltip)
+	}
+	return drMap
+}
+
+// drBonusesFor returns the active DR bonuses that apply to the location.
+func (e *Entity) drBonusesFor(locationID string) []*DRBonus {
 	isTopLevel := false
 	for _, one := range e.SheetSettings.BodyType.Locations {
 		if one.LocID == locationID {
@@ -787,16 +893,16 @@ func (e *Entity) AddDRBonusesFor(locationID string, tooltip *xio.ByteBuffer, drM
 			break
 		}
 	}
+	var list []*DRBonus
 	for _, one := range e.features.drBonuses {
 		for _, loc := range one.Locations {
 			if (loc == AllID && isTopLevel) || strings.EqualFold(loc, locationID) {
-				drMap[strings.ToLower(one.Specialization)] += fxp.As[int](one.AdjustedAmount())
-				one.AddToTooltip(tooltip)
+				list = append(list, one)
 				break
 			}
 		}
 	}
-	return drMap
+	return list
 }
 
 // SkillBonusFor returns the total bonus for the matching skill bonuses.
@@ -970,9 +1076,13 @@ func (e *Entity) Move(enc encumbrance.Level) int {
 	move := initialMove.Mul(fxp.Ten + fxp.Two.Mul(enc.Penalty())).Div(fxp.Ten).Trunc()
 	if move < fxp.One {
 		if initialMove > 0 {
-			return 1
+			move = fxp.One
+		} else {
+			move = 0
 		}
-		return 0
+	}
+	if limit, ok := e.ConditionMoveLimit(); ok {
+		move = move.Min(fxp.From(limit))
 	}
 	return fxp.As[int](move)
 }
@@ -981,7 +1091,7 @@ func (e *Entity) Move(enc encumbrance.Level) int {
 func (e *Entity) BestSkillNamed(name, specialization string, requirePoints bool, excludes map[string]bool) *Skill {
 	var best *Skill
 	level := fxp.Min
-	for _, sk := range e.SkillNamed(name, specialization, requirePoints, excludes) {
+	for _, sk := range e.skillsNamedOrCovering(name, specialization, requirePoints, excludes) {
 		skillLevel := sk.CalculateLevel(excludes).Level
 		if best == nil || level < skillLevel {
 			best = sk
@@ -991,6 +1101,12 @@ func (e *Entity) BestSkillNamed(name, specialization string, requirePoints bool,
 	return best
 }
 
+// skillsNamedOrCovering returns the skills that match, along with any wildcard skills that cover them.
+func (e *Entity) skillsNamedOrCovering(name, specialization string, requirePoints bool, excludes map[string]bool) []*Skill {
+	return append(e.SkillNamed(name, specialization, requirePoints, excludes),
+		e.WildcardSkillsCovering(name, requirePoints, excludes)...)
+}
+
 // SkillNamed returns a list of skills that match.
 func (e *Entity) SkillNamed(name, specialization string, requirePoints bool, excludes map[string]bool) []*Skill {
 	var list []*Skill
@@ -1006,6 +1122,12 @@ func (e *Entity) SkillNamed(name, specialization string, requirePoints bool, exc
 		}
 		return false
 	}, false, true, e.Skills...)
+	for _, sk := range e.grantedSkills {
+		if !excludes[sk.String()] && strings.EqualFold(sk.Name, name) &&
+			(specialization == "" || strings.EqualFold(sk.Specialization, specialization)) {
+			list = append(list, sk)
+		}
+	}
 	return list
 }
 
@@ -1196,6 +1318,11 @@ func (e *Entity) ResolveVariable(variableName string) string {
 	parts := strings.SplitN(variableName, ".", 2)
 	attr := e.Attributes.Set[parts[0]]
 	if attr == nil {
+		if v := e.Variable(parts[0]); v != nil && len(parts) == 1 {
+			result := fxp.EvaluateToNumber(v.Expression, e).String()
+			e.cachedVariables[variableName] = result
+			return result
+		}
 		if fxp.DebugVariableResolver {
 			errs.Log(errs.New("no such variable"), "name", "$"+variableName)
 		}
@@ -1378,6 +1505,7 @@ func (e *Entity) Reactions() []*ConditionalModifier {
 		e.reactionsFromFeatureList(i18n.Text("from skill ")+sk.String(), sk.Features, m)
 		return false
 	}, false, true, e.Skills...)
+	e.reactionsFromReputations(m)
 	list := make([]*ConditionalModifier, 0, len(m))
 	for _, v := range m {
 		list = append(list, v)
@@ -1436,6 +1564,7 @@ func (e *Entity) ConditionalModifiers() []*ConditionalModifier {
 		e.conditionalModifiersFromFeatureList(i18n.Text("from skill ")+sk.String(), sk.Features, m)
 		return false
 	}, false, true, e.Skills...)
+	e.reactionsFromReputations(m)
 	list := make([]*ConditionalModifier, 0, len(m))
 	for _, v := range m {
 		list = append(list, v)
@@ -1566,6 +1695,11 @@ func (e *Entity) SetPointsRecord(record []*PointsRecord) {
 	}
 }
 
+// SetPointsEarmarks sets a new points earmark list.
+func (e *Entity) SetPointsEarmarks(earmarks []*PointsEarmark) {
+	e.PointsEarmarks = ClonePointsEarmarkList(earmarks)
+}
+
 // EvalEmbeddedRegex is aa regex for extracting embedded expressions.
 var EvalEmbeddedRegex = regexp.MustCompile(`\|\|[^|]+\|\|`)
 
@@ -1585,40 +1719,55 @@ func (e *Entity) EmbeddedEval(s string) string {
 
 // SyncWithLibrarySources syncs the entity with the library sources.
 func (e *Entity) SyncWithLibrarySources() {
+	e.syncWithSources(nil)
+}
+
+type sourceSyncer interface {
+	GetSource() Source
+	SyncWithSource()
+}
+
+// syncWithSources syncs the entity with the library sources that the filter accepts. A nil filter accepts all sources.
+func (e *Entity) syncWithSources(filter func(src Source) bool) {
+	syncOne := func(one sourceSyncer) {
+		if filter == nil || filter(one.GetSource()) {
+			one.SyncWithSource()
+		}
+	}
 	Traverse(func(trait *Trait) bool {
-		trait.SyncWithSource()
+		syncOne(trait)
 		Traverse(func(traitModifier *TraitModifier) bool {
-			traitModifier.SyncWithSource()
+			syncOne(traitModifier)
 			return false
 		}, false, false, trait.Modifiers...)
 		return false
 	}, false, false, e.Traits...)
 	Traverse(func(skill *Skill) bool {
-		skill.SyncWithSource()
+		syncOne(skill)
 		return false
 	}, false, false, e.Skills...)
 	Traverse(func(spell *Spell) bool {
-		spell.SyncWithSource()
+		syncOne(spell)
 		return false
 	}, false, false, e.Spells...)
 	Traverse(func(equipment *Equipment) bool {
-		equipment.SyncWithSource()
+		syncOne(equipment)
 		Traverse(func(equipmentModifier *EquipmentModifier) bool {
-			equipmentModifier.SyncWithSource()
+			syncOne(equipmentModifier)
 			return false
 		}, false, false, equipment.Modifiers...)
 		return false
 	}, false, false, e.CarriedEquipment...)
 	Traverse(func(equipment *Equipment) bool {
-		equipment.SyncWithSource()
+		syncOne(equipment)
 		Traverse(func(equipmentModifier *EquipmentModifier) bool {
-			equipmentModifier.SyncWithSource()
+			syncOne(equipmentModifier)
 			return false
 		}, false, false, equipment.Modifiers...)
 		return false
 	}, false, false, e.OtherEquipment...)
 	Traverse(func(note *Note) bool {
-		note.SyncWithSource()
+		syncOne(note)
 		return false
 	}, false, false, e.Notes...)
 }
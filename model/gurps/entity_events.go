@@ -0,0 +1,225 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xmath/crc"
+)
+
+// EntityEventKind identifies the kind of change reported by an EntityEvent.
+type EntityEventKind string
+
+// Possible values for EntityEventKind.
+const (
+	ItemAddedEntityEvent     EntityEventKind = "item_added"
+	ItemRemovedEntityEvent   EntityEventKind = "item_removed"
+	ItemChangedEntityEvent   EntityEventKind = "item_changed"
+	PointsChangedEntityEvent EntityEventKind = "points_changed"
+	FileSavedEntityEvent     EntityEventKind = "file_saved"
+)
+
+// EntityEvent describes a change made to an entity. The item fields are only set for the item events, the points
+// fields only for the points event. List holds the key of the list the item is in, which is the same key used for the
+// sheet's block layout.
+type EntityEvent struct {
+	Kind        EntityEventKind `json:"kind"`
+	EntityID    tid.TID         `json:"entity_id"`
+	Path        string          `json:"path,omitempty"`
+	List        string          `json:"list,omitempty"`
+	ItemID      tid.TID         `json:"item_id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	TotalPoints fxp.Int         `json:"total_points,omitempty"`
+	SpentPoints fxp.Int         `json:"spent_points,omitempty"`
+	When        jio.Time        `json:"when"`
+}
+
+// EntityEventSubscription is returned when subscribing to entity events and may be used to stop receiving them.
+type EntityEventSubscription struct {
+	handler func(*EntityEvent)
+}
+
+var (
+	entityEventLock          sync.RWMutex
+	entityEventSubscriptions []*EntityEventSubscription
+)
+
+// SubscribeToEntityEvents arranges for the handler to be called for each entity event that is published. The handler
+// is called on the goroutine that published the event, so it should hand off any lengthy work.
+func SubscribeToEntityEvents(handler func(*EntityEvent)) *EntityEventSubscription {
+	sub := &EntityEventSubscription{handler: handler}
+	entityEventLock.Lock()
+	entityEventSubscriptions = append(entityEventSubscriptions, sub)
+	entityEventLock.Unlock()
+	return sub
+}
+
+// Unsubscribe stops the delivery of entity events to this subscription.
+func (s *EntityEventSubscription) Unsubscribe() {
+	entityEventLock.Lock()
+	if i := slices.Index(entityEventSubscriptions, s); i != -1 {
+		entityEventSubscriptions = slices.Delete(entityEventSubscriptions, i, i+1)
+	}
+	entityEventLock.Unlock()
+}
+
+// HasEntityEventSubscribers returns true if anything is currently subscribed to entity events.
+func HasEntityEventSubscribers() bool {
+	entityEventLock.RLock()
+	defer entityEventLock.RUnlock()
+	return len(entityEventSubscriptions) != 0
+}
+
+// PublishEntityEvents sends the events to all current subscribers.
+func PublishEntityEvents(events ...*EntityEvent) {
+	if len(events) == 0 {
+		return
+	}
+	entityEventLock.RLock()
+	subs := slices.Clone(entityEventSubscriptions)
+	entityEventLock.RUnlock()
+	for _, sub := range subs {
+		for _, event := range events {
+			sub.handler(event)
+		}
+	}
+}
+
+// NewFileSavedEntityEvent returns a new event for the entity having been saved to the given path.
+func NewFileSavedEntityEvent(entity *Entity, filePath string) *EntityEvent {
+	return &EntityEvent{
+		Kind:     FileSavedEntityEvent,
+		EntityID: entity.ID,
+		Path:     filePath,
+		When:     jio.Now(),
+	}
+}
+
+type trackedEntityItem struct {
+	list string
+	name string
+	crc  uint64
+}
+
+// EntityEventTracker remembers enough about the state of an entity to produce the events describing the changes made
+// to it since it was last updated. Rather than having every place that alters an entity publish its own events, the
+// code that already responds to those alterations, such as a sheet rebuilding itself, updates the tracker.
+type EntityEventTracker struct {
+	items       map[tid.TID]trackedEntityItem
+	order       []tid.TID
+	totalPoints fxp.Int
+	spentPoints fxp.Int
+}
+
+// Update compares the entity with the state recorded by the last call and returns the events describing the
+// differences, then records the current state. The first call only records the state and returns no events. Note that
+// a container is reported as changed when its contents change. When nothing is subscribed to entity events, the
+// recorded state is discarded instead, avoiding the cost of tracking it.
+func (t *EntityEventTracker) Update(entity *Entity, filePath string) []*EntityEvent {
+	if !HasEntityEventSubscribers() {
+		t.items = nil
+		t.order = nil
+		return nil
+	}
+	items, order := trackEntityItems(entity)
+	total := entity.TotalPoints
+	spent := entity.PointsBreakdown().Total()
+	var events []*EntityEvent
+	if t.items != nil {
+		when := jio.Now()
+		newEvent := func(kind EntityEventKind, id tid.TID, item trackedEntityItem) *EntityEvent {
+			return &EntityEvent{
+				Kind:     kind,
+				EntityID: entity.ID,
+				Path:     filePath,
+				List:     item.list,
+				ItemID:   id,
+				Name:     item.name,
+				When:     when,
+			}
+		}
+		for _, id := range t.order {
+			if _, exists := items[id]; !exists {
+				events = append(events, newEvent(ItemRemovedEntityEvent, id, t.items[id]))
+			}
+		}
+		for _, id := range order {
+			item := items[id]
+			if prior, exists := t.items[id]; !exists {
+				events = append(events, newEvent(ItemAddedEntityEvent, id, item))
+			} else if prior.crc != item.crc {
+				events = append(events, newEvent(ItemChangedEntityEvent, id, item))
+			}
+		}
+		if total != t.totalPoints || spent != t.spentPoints {
+			events = append(events, &EntityEvent{
+				Kind:        PointsChangedEntityEvent,
+				EntityID:    entity.ID,
+				Path:        filePath,
+				TotalPoints: total,
+				SpentPoints: spent,
+				When:        when,
+			})
+		}
+	}
+	t.items = items
+	t.order = order
+	t.totalPoints = total
+	t.spentPoints = spent
+	return events
+}
+
+func trackEntityItems(entity *Entity) (items map[tid.TID]trackedEntityItem, order []tid.TID) {
+	items = make(map[tid.TID]trackedEntityItem)
+	track := func(list string, id tid.TID, item interface{ String() string }) {
+		data, err := json.Marshal(item)
+		if err != nil {
+			errs.Log(err, "id", id)
+		}
+		items[id] = trackedEntityItem{
+			list: list,
+			name: item.String(),
+			crc:  crc.Bytes(0, data),
+		}
+		order = append(order, id)
+	}
+	Traverse(func(t *Trait) bool {
+		track(BlockLayoutTraitsKey, t.ID(), t)
+		return false
+	}, false, false, entity.Traits...)
+	Traverse(func(s *Skill) bool {
+		track(BlockLayoutSkillsKey, s.ID(), s)
+		return false
+	}, false, false, entity.Skills...)
+	Traverse(func(s *Spell) bool {
+		track(BlockLayoutSpellsKey, s.ID(), s)
+		return false
+	}, false, false, entity.Spells...)
+	Traverse(func(e *Equipment) bool {
+		track(BlockLayoutEquipmentKey, e.ID(), e)
+		return false
+	}, false, false, entity.CarriedEquipment...)
+	Traverse(func(e *Equipment) bool {
+		track(BlockLayoutOtherEquipmentKey, e.ID(), e)
+		return false
+	}, false, false, entity.OtherEquipment...)
+	Traverse(func(n *Note) bool {
+		track(BlockLayoutNotesKey, n.ID(), n)
+		return false
+	}, false, false, entity.Notes...)
+	return items, order
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestEntityEventTracker(t *testing.T) {
+	var received []*EntityEvent
+	sub := SubscribeToEntityEvents(func(event *EntityEvent) { received = append(received, event) })
+	defer sub.Unsubscribe()
+	check.True(t, HasEntityEventSubscribers())
+
+	e := NewEntity()
+	var tracker EntityEventTracker
+	check.Equal(t, 0, len(tracker.Update(e, "a.gcs")), "first update only records the state")
+
+	trait := NewTrait(e, nil, false)
+	trait.Name = "Luck"
+	trait.BasePoints = fxp.Fifteen
+	e.SetTraitList([]*Trait{trait})
+	events := tracker.Update(e, "a.gcs")
+	check.Equal(t, 2, len(events))
+	check.Equal(t, ItemAddedEntityEvent, events[0].Kind)
+	check.Equal(t, BlockLayoutTraitsKey, events[0].List)
+	check.Equal(t, trait.ID(), events[0].ItemID)
+	check.Equal(t, "Luck", events[0].Name)
+	check.Equal(t, "a.gcs", events[0].Path)
+	check.Equal(t, PointsChangedEntityEvent, events[1].Kind)
+	check.Equal(t, fxp.Fifteen, events[1].SpentPoints)
+
+	check.Equal(t, 0, len(tracker.Update(e, "a.gcs")), "nothing changed")
+
+	trait.Name = "Extraordinary Luck"
+	events = tracker.Update(e, "a.gcs")
+	check.Equal(t, 1, len(events))
+	check.Equal(t, ItemChangedEntityEvent, events[0].Kind)
+	check.Equal(t, "Extraordinary Luck", events[0].Name)
+
+	e.SetTraitList(nil)
+	events = tracker.Update(e, "a.gcs")
+	check.Equal(t, 2, len(events))
+	check.Equal(t, ItemRemovedEntityEvent, events[0].Kind)
+	check.Equal(t, trait.ID(), events[0].ItemID)
+
+	PublishEntityEvents(events...)
+	PublishEntityEvents(NewFileSavedEntityEvent(e, "a.gcs"))
+	check.Equal(t, 3, len(received))
+	check.Equal(t, FileSavedEntityEvent, received[2].Kind)
+	check.Equal(t, e.ID, received[2].EntityID)
+
+	sub.Unsubscribe()
+	check.False(t, HasEntityEventSubscribers())
+	check.Equal(t, 0, len(tracker.Update(e, "a.gcs")))
+	PublishEntityEvents(NewFileSavedEntityEvent(e, "a.gcs"))
+	check.Equal(t, 3, len(received), "unsubscribed handlers receive nothing")
+}
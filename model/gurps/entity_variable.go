@@ -0,0 +1,94 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/toolbox/eval"
+)
+
+// EntityVariable holds a named value that can be referenced as $name from expressions, feature amounts, and prereq
+// qualifiers on the same sheet.
+type EntityVariable struct {
+	Name       string `json:"name"`
+	Expression string `json:"expr"`
+}
+
+type amountExpressionResolver interface {
+	ResolveExpression(resolver eval.VariableResolver)
+}
+
+// CloneEntityVariableList creates a clone of the provided EntityVariable list.
+func CloneEntityVariableList(list []*EntityVariable) []*EntityVariable {
+	clone := make([]*EntityVariable, len(list))
+	for i := 0; i < len(list); i++ {
+		v := *list[i]
+		clone[i] = &v
+	}
+	return clone
+}
+
+// IsValidEntityVariableName returns true if the name can be used for an entity variable.
+func IsValidEntityVariableName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, ch := range name {
+		if (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') && (ch < '0' || ch > '9') && ch != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Variable returns the entity variable with the given name, or nil.
+func (e *Entity) Variable(name string) *EntityVariable {
+	for _, v := range e.Variables {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// SetVariables sets a new variable list.
+func (e *Entity) SetVariables(list []*EntityVariable) {
+	e.Variables = CloneEntityVariableList(list)
+	e.DiscardCaches()
+}
+
+// VariableValue returns the current value of the named variable.
+func (e *Entity) VariableValue(name string) fxp.Int {
+	if v := e.Variable(name); v != nil {
+		return fxp.EvaluateToNumber(v.Expression, e)
+	}
+	return 0
+}
+
+// prereqReplacements returns the nameable replacements to use when checking the qualifiers of a prereq, which
+// includes the entity variables, keyed by their $name.
+func (e *Entity) prereqReplacements(exclude any) map[string]string {
+	var replacements map[string]string
+	if na, ok := exclude.(nameable.Accesser); ok {
+		replacements = na.NameableReplacements()
+	}
+	if e == nil || len(e.Variables) == 0 {
+		return replacements
+	}
+	m := make(map[string]string, len(replacements)+len(e.Variables))
+	for k, v := range replacements {
+		m[k] = v
+	}
+	for _, v := range e.Variables {
+		m[nameable.VariablePrefix+v.Name] = e.ResolveVariable(v.Name)
+	}
+	return m
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestEntityVariables(t *testing.T) {
+	e := NewEntity()
+	e.SetVariables([]*EntityVariable{
+		{Name: "campaign_tl", Expression: "8"},
+		{Name: "campaign", Expression: "$campaign_tl - 2"},
+	})
+	check.Equal(t, fxp.From(8), e.VariableValue("campaign_tl"))
+	check.Equal(t, fxp.From(6), e.VariableValue("campaign"))
+	check.Equal(t, fxp.From(14), fxp.EvaluateToNumber("$campaign_tl + $campaign", e))
+
+	replacements := e.prereqReplacements(nil)
+	check.Equal(t, "TL8 and 6", nameable.Apply("TL$campaign_tl and $campaign", replacements))
+
+	check.Equal(t, true, IsValidEntityVariableName("mana_level"))
+	check.Equal(t, false, IsValidEntityVariableName("2nd"))
+	check.Equal(t, false, IsValidEntityVariableName("a-b"))
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package assoc
+
+import "github.com/richardwilkes/gcs/v5/model/fxp"
+
+// Target returns the number that must be rolled on 3d6 for the NPC to appear, or 0 if no roll is needed.
+func (enum Frequency) Target() int {
+	switch enum.EnsureValid() {
+	case Fifteen:
+		return 15
+	case Twelve:
+		return 12
+	case Nine:
+		return 9
+	case Six:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// Multiplier returns the amount to multiply the base cost by.
+func (enum Frequency) Multiplier() fxp.Int {
+	switch enum.EnsureValid() {
+	case Constantly:
+		return fxp.Four
+	case Fifteen:
+		return fxp.Three
+	case Twelve:
+		return fxp.Two
+	case Nine:
+		return fxp.One
+	default:
+		return fxp.Half
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package assoc
+
+import "github.com/richardwilkes/gcs/v5/model/fxp"
+
+// Multiplier returns the amount to multiply the base cost of a contact by.
+func (enum Reliability) Multiplier() fxp.Int {
+	switch enum.EnsureValid() {
+	case Completely:
+		return fxp.Three
+	case Usually:
+		return fxp.Two
+	case Somewhat:
+		return fxp.One
+	default:
+		return fxp.Half
+	}
+}
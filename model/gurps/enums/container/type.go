@@ -0,0 +1,40 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package container
+
+import "github.com/richardwilkes/toolbox/i18n"
+
+// AlternativeCost returns true if the children of a container of this type are alternative abilities, where only the
+// most expensive is paid for in full.
+func (enum Type) AlternativeCost() bool {
+	return enum == AlternativeAbilities || enum == Sorcery
+}
+
+// CostRule returns a description of how the cost of a container of this type is computed from its children.
+func (enum Type) CostRule() string {
+	switch enum {
+	case AlternativeAbilities:
+		return i18n.Text("The most expensive trait is paid for in full. Each of the others costs 1/5 of its cost, after modifiers.")
+	case Ancestry:
+		return i18n.Text("Racial package: the cost is the sum of the traits and is counted as the cost of the character's ancestry.")
+	case Attributes:
+		return i18n.Text("The cost is the sum of the traits and is counted as attribute points.")
+	case MetaTrait:
+		return i18n.Text("The cost is the sum of the traits, which are treated as a single trait. Modifiers on the meta-trait apply to each of them.")
+	case AlternateForm:
+		return i18n.Text("The form costs 90% of the cost of the traits it adds, with a minimum of 15 points, whether or not it is active.")
+	case Power:
+		return i18n.Text("The cost is the sum of the traits. Modifiers on the power apply to each of them, except for the power's Talent.")
+	case Sorcery:
+		return i18n.Text("Spells are alternative abilities: the most expensive is paid for in full and each of the others costs 1/5 of its cost, after modifiers. Sorcerous Empowerment is paid for in full and must be at or above the level each spell requires.")
+	default:
+		return i18n.Text("The cost is the sum of the traits, each of which is counted separately. Modifiers on the group apply to each of them.")
+	}
+}
@@ -25,10 +25,13 @@ const (
 	Ancestry
 	Attributes
 	MetaTrait
+	AlternateForm
+	Power
+	Sorcery
 )
 
 // LastType is the last valid value.
-const LastType Type = MetaTrait
+const LastType Type = Sorcery
 
 // Types holds all possible values.
 var Types = []Type{
@@ -37,6 +40,9 @@ var Types = []Type{
 	Ancestry,
 	Attributes,
 	MetaTrait,
+	AlternateForm,
+	Power,
+	Sorcery,
 }
 
 // Type holds the type of a trait container.
@@ -44,7 +50,7 @@ type Type byte
 
 // EnsureValid ensures this is of a known value.
 func (enum Type) EnsureValid() Type {
-	if enum <= MetaTrait {
+	if enum <= Sorcery {
 		return enum
 	}
 	return 0
@@ -63,6 +69,12 @@ func (enum Type) Key() string {
 		return "attributes"
 	case MetaTrait:
 		return "meta_trait"
+	case AlternateForm:
+		return "alternate_form"
+	case Power:
+		return "power"
+	case Sorcery:
+		return "sorcery"
 	default:
 		return Type(0).Key()
 	}
@@ -80,6 +92,12 @@ func (enum Type) oldKeys() []string {
 		return nil
 	case MetaTrait:
 		return nil
+	case AlternateForm:
+		return nil
+	case Power:
+		return nil
+	case Sorcery:
+		return nil
 	default:
 		return Type(0).oldKeys()
 	}
@@ -98,6 +116,12 @@ func (enum Type) String() string {
 		return i18n.Text("Attributes")
 	case MetaTrait:
 		return i18n.Text("Meta-Trait")
+	case AlternateForm:
+		return i18n.Text("Alternate Form")
+	case Power:
+		return i18n.Text("Power")
+	case Sorcery:
+		return i18n.Text("Sorcery")
 	default:
 		return Type(0).String()
 	}
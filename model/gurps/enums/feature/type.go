@@ -12,11 +12,18 @@ package feature
 // TypesWithoutContainedWeightReduction holds the possible Type values, minus the ContainedWeightReduction.
 var TypesWithoutContainedWeightReduction []Type
 
+// TypesWithoutEquipmentOnly holds the possible Type values, minus those that only apply to equipment.
+var TypesWithoutEquipmentOnly []Type
+
 func init() {
 	TypesWithoutContainedWeightReduction = make([]Type, 0, len(Types)-1)
+	TypesWithoutEquipmentOnly = make([]Type, 0, len(Types)-2)
 	for _, one := range Types {
 		if one != ContainedWeightReduction {
 			TypesWithoutContainedWeightReduction = append(TypesWithoutContainedWeightReduction, one)
+			if one != SkillGrant {
+				TypesWithoutEquipmentOnly = append(TypesWithoutEquipmentOnly, one)
+			}
 		}
 	}
 }
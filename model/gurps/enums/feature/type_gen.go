@@ -53,10 +53,11 @@ const (
 	WeaponSwitch
 	CostReduction
 	ContainedWeightReduction
+	SkillGrant
 )
 
 // LastType is the last valid value.
-const LastType Type = ContainedWeightReduction
+const LastType Type = SkillGrant
 
 // Types holds all possible values.
 var Types = []Type{
@@ -94,6 +95,7 @@ var Types = []Type{
 	WeaponSwitch,
 	CostReduction,
 	ContainedWeightReduction,
+	SkillGrant,
 }
 
 // Type holds the type of a Feature.
@@ -101,7 +103,7 @@ type Type byte
 
 // EnsureValid ensures this is of a known value.
 func (enum Type) EnsureValid() Type {
-	if enum <= ContainedWeightReduction {
+	if enum <= SkillGrant {
 		return enum
 	}
 	return 0
@@ -178,6 +180,8 @@ func (enum Type) Key() string {
 		return "cost_reduction"
 	case ContainedWeightReduction:
 		return "contained_weight_reduction"
+	case SkillGrant:
+		return "skill_grant"
 	default:
 		return Type(0).Key()
 	}
@@ -254,6 +258,8 @@ func (enum Type) String() string {
 		return i18n.Text("Reduces the attribute cost of")
 	case ContainedWeightReduction:
 		return i18n.Text("Reduces the contained weight by")
+	case SkillGrant:
+		return i18n.Text("Grants the skill")
 	default:
 		return Type(0).String()
 	}
@@ -24,10 +24,11 @@ const (
 	MarkovLetter
 	MarkovRun
 	Compound
+	Syllable
 )
 
 // LastType is the last valid value.
-const LastType Type = Compound
+const LastType Type = Syllable
 
 // Types holds all possible values.
 var Types = []Type{
@@ -35,6 +36,7 @@ var Types = []Type{
 	MarkovLetter,
 	MarkovRun,
 	Compound,
+	Syllable,
 }
 
 // Type holds a name generation type.
@@ -42,7 +44,7 @@ type Type byte
 
 // EnsureValid ensures this is of a known value.
 func (enum Type) EnsureValid() Type {
-	if enum <= Compound {
+	if enum <= Syllable {
 		return enum
 	}
 	return 0
@@ -59,6 +61,8 @@ func (enum Type) Key() string {
 		return "markov_run"
 	case Compound:
 		return "compound"
+	case Syllable:
+		return "syllable"
 	default:
 		return Type(0).Key()
 	}
@@ -74,6 +78,8 @@ func (enum Type) oldKeys() []string {
 		return nil
 	case Compound:
 		return nil
+	case Syllable:
+		return nil
 	default:
 		return Type(0).oldKeys()
 	}
@@ -90,6 +96,8 @@ func (enum Type) String() string {
 		return i18n.Text("Markov Run")
 	case Compound:
 		return i18n.Text("Compound")
+	case Syllable:
+		return i18n.Text("Syllable")
 	default:
 		return Type(0).String()
 	}
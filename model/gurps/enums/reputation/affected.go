@@ -0,0 +1,24 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package reputation
+
+// Fraction returns the numerator and denominator of the fraction to multiply the base cost of a reputation by.
+func (enum Affected) Fraction() (numerator, denominator int) {
+	switch enum.EnsureValid() {
+	case AllButOneClass:
+		return 2, 3
+	case LargeClass:
+		return 1, 2
+	case SmallClass:
+		return 1, 3
+	default:
+		return 1, 1
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package rpm
+
+// BaseEnergy returns the energy the effect adds to the base cost of a ritual.
+func (enum Effect) BaseEnergy() int {
+	switch enum {
+	case Sense:
+		return 2
+	case Strengthen:
+		return 3
+	case Restore:
+		return 4
+	case Control, Destroy:
+		return 5
+	case Create:
+		return 6
+	case Transform:
+		return 8
+	default:
+		return Sense.BaseEnergy()
+	}
+}
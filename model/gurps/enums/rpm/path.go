@@ -0,0 +1,19 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package rpm
+
+import "strings"
+
+// SkillName returns the name of the skill used to work with the path, e.g. "Path of Body". The name is not localized,
+// since it has to match the name of the skill as it appears in the libraries.
+func (enum Path) SkillName() string {
+	key := enum.EnsureValid().Key()
+	return "Path of " + strings.ToUpper(key[:1]) + key[1:]
+}
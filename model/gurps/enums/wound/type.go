@@ -0,0 +1,30 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package wound
+
+// IsImpalingOrPiercing returns true if the damage type is impaling or one of the piercing types.
+func (enum Type) IsImpalingOrPiercing() bool {
+	switch enum {
+	case Impaling, SmallPiercing, Piercing, LargePiercing, HugePiercing:
+		return true
+	default:
+		return false
+	}
+}
+
+// DRSpecialization returns the specialization used by DR bonuses that only protect against this type of damage.
+func (enum Type) DRSpecialization() string {
+	switch enum {
+	case SmallPiercing, Piercing, LargePiercing, HugePiercing:
+		return "piercing"
+	default:
+		return enum.EnsureValid().Key()
+	}
+}
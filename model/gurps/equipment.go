@@ -23,6 +23,8 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/ectype"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/srcstate"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/model/kinds"
@@ -87,25 +89,30 @@ type EquipmentEditData struct {
 	Quantity     fxp.Int              `json:"quantity,omitempty"`
 	Level        fxp.Int              `json:"level,omitempty"`
 	Uses         int                  `json:"uses,omitempty"`
+	RemoveEmpty  bool                 `json:"remove_when_empty,omitempty"` // Only for consumables
 	Equipped     bool                 `json:"equipped,omitempty"`
+	Kit          bool                 `json:"kit,omitempty"` // Only for containers
 }
 
 // EquipmentSyncData holds the equipment sync data that is common to both containers and non-containers.
 type EquipmentSyncData struct {
-	Name                   string      `json:"description,omitempty"`
-	PageRef                string      `json:"reference,omitempty"`
-	PageRefHighlight       string      `json:"reference_highlight,omitempty"`
-	LocalNotes             string      `json:"notes,omitempty"`
-	TechLevel              string      `json:"tech_level,omitempty"`
-	LegalityClass          string      `json:"legality_class,omitempty"`
-	Tags                   []string    `json:"tags,omitempty"`
-	Value                  fxp.Int     `json:"value,omitempty"`
-	Weight                 fxp.Weight  `json:"weight,omitempty"`
-	MaxUses                int         `json:"max_uses,omitempty"`
-	Prereq                 *PrereqList `json:"prereqs,omitempty"`
-	Weapons                []*Weapon   `json:"weapons,omitempty"`
-	Features               Features    `json:"features,omitempty"`
-	WeightIgnoredForSkills bool        `json:"ignore_weight_for_skills,omitempty"`
+	Name                   string        `json:"description,omitempty"`
+	PageRef                string        `json:"reference,omitempty"`
+	PageRefHighlight       string        `json:"reference_highlight,omitempty"`
+	LocalNotes             string        `json:"notes,omitempty"`
+	TechLevel              string        `json:"tech_level,omitempty"`
+	LegalityClass          string        `json:"legality_class,omitempty"`
+	Tags                   []string      `json:"tags,omitempty"`
+	Value                  fxp.Int       `json:"value,omitempty"`
+	Weight                 fxp.Weight    `json:"weight,omitempty"`
+	MaxUses                int           `json:"max_uses,omitempty"`
+	Recharge               recharge.Type `json:"recharge,omitempty"`
+	Prereq                 *PrereqList   `json:"prereqs,omitempty"`
+	Weapons                []*Weapon     `json:"weapons,omitempty"`
+	Features               Features      `json:"features,omitempty"`
+	WeightIgnoredForSkills bool          `json:"ignore_weight_for_skills,omitempty"`
+	WeightRules            []*WeightRule `json:"weight_rules,omitempty"`
+	ContainerType          ectype.Type   `json:"container_type,omitempty"` // Only for containers
 }
 
 type equipmentListData struct {
@@ -307,9 +314,15 @@ func EquipmentHeaderData(columnID int, entity *Entity, carried, forPage bool) He
 		data.Title = i18n.Text("Equipment")
 		if forPage && entity != nil {
 			if carried {
-				data.Title = fmt.Sprintf(i18n.Text("Carried Equipment (%s; $%s)"),
-					entity.SheetSettings.DefaultWeightUnits.Format(entity.WeightCarried(false)),
-					entity.WealthCarried().Comma())
+				units := entity.SheetSettings.DefaultWeightUnits
+				if capacity := entity.PayloadCapacity(); capacity > 0 {
+					data.Title = fmt.Sprintf(i18n.Text("Carried Equipment (%s; $%s; Payload %s of %s)"),
+						units.Format(entity.WeightCarried(false)), entity.WealthCarried().Comma(),
+						units.Format(entity.PayloadWeight()), units.Format(capacity))
+				} else {
+					data.Title = fmt.Sprintf(i18n.Text("Carried Equipment (%s; $%s)"),
+						units.Format(entity.WeightCarried(false)), entity.WealthCarried().Comma())
+				}
 			} else {
 				data.Title = fmt.Sprintf(i18n.Text("Other Equipment ($%s)"), entity.WealthNotCarried().Comma())
 			}
@@ -379,10 +392,13 @@ func (e *Equipment) CellData(columnID int, data *CellData) {
 		data.Tooltip = e.SecondaryText(func(option display.Option) bool { return option.Tooltip() })
 	case EquipmentUsesColumn:
 		if e.MaxUses > 0 {
-			data.Type = cell.Text
+			data.Type = cell.Stepper
 			data.Primary = strconv.Itoa(e.Uses)
 			data.Alignment = align.End
 			data.Tooltip = fmt.Sprintf(i18n.Text("Maximum Uses: %d"), e.MaxUses)
+			if e.Recharge != recharge.None {
+				data.Tooltip += "\n" + e.Recharge.String()
+			}
 		}
 	case EquipmentTLColumn:
 		data.Type = cell.Text
@@ -490,7 +506,7 @@ func (e *Equipment) Description() string {
 func (e *Equipment) SecondaryText(optionChecker func(display.Option) bool) string {
 	var buffer strings.Builder
 	settings := SheetSettingsFor(EntityFromNode(e))
-	if optionChecker(settings.ModifiersDisplay) {
+	if optionChecker(settings.EffectiveModifiersDisplay()) {
 		AppendStringOntoNewLine(&buffer, e.ModifierNotes())
 	}
 	if optionChecker(settings.NotesDisplay) {
@@ -569,22 +585,23 @@ func (e *Equipment) AdjustedWeight(forSkills bool, defUnits fxp.WeightUnit) fxp.
 	if forSkills && e.WeightIgnoredForSkills && e.Equipped {
 		return 0
 	}
-	return WeightAdjustedForModifiers(e, e.Weight, e.Modifiers, defUnits)
+	return ApplyWeightRules(e, WeightAdjustedForModifiers(e, e.Weight, e.Modifiers, defUnits), e.WeightRules)
 }
 
 // ExtendedWeight returns the extended weight.
 func (e *Equipment) ExtendedWeight(forSkills bool, defUnits fxp.WeightUnit) fxp.Weight {
-	return ExtendedWeightAdjustedForModifiers(e, defUnits, e.Quantity, e.Weight, e.Modifiers, e.Features, e.Children, forSkills, e.WeightIgnoredForSkills && e.Equipped)
+	return ExtendedWeightAdjustedForModifiers(e, defUnits, e.Quantity, e.Weight, e.Modifiers, e.Features, e.WeightRules, e.Children, forSkills, e.WeightIgnoredForSkills && e.Equipped)
 }
 
 // ExtendedWeightAdjustedForModifiers calculates the extended weight.
-func ExtendedWeightAdjustedForModifiers(equipment *Equipment, defUnits fxp.WeightUnit, qty fxp.Int, baseWeight fxp.Weight, modifiers []*EquipmentModifier, features Features, children []*Equipment, forSkills, weightIgnoredForSkills bool) fxp.Weight {
+func ExtendedWeightAdjustedForModifiers(equipment *Equipment, defUnits fxp.WeightUnit, qty fxp.Int, baseWeight fxp.Weight, modifiers []*EquipmentModifier, features Features, weightRules []*WeightRule, children []*Equipment, forSkills, weightIgnoredForSkills bool) fxp.Weight {
 	if qty <= 0 {
 		return 0
 	}
 	var base fxp.Int
 	if !forSkills || !weightIgnoredForSkills {
-		base = fxp.Int(WeightAdjustedForModifiers(equipment, baseWeight, modifiers, defUnits))
+		base = fxp.Int(ApplyWeightRules(equipment, WeightAdjustedForModifiers(equipment, baseWeight, modifiers, defUnits),
+			weightRules))
 	}
 	if len(children) != 0 {
 		var contained fxp.Int
@@ -619,6 +636,9 @@ func ExtendedWeightAdjustedForModifiers(equipment *Equipment, defUnits fxp.Weigh
 		} else if percentage > 0 {
 			contained -= contained.Mul(percentage).Div(fxp.Hundred)
 		}
+		if equipment != nil && equipment.ContentsAreWeightless() {
+			contained = 0
+		}
 		base += (contained - reduction).Max(0)
 	}
 	return fxp.Weight(base.Mul(qty))
@@ -771,6 +791,8 @@ func (e *Equipment) Kind() string {
 func (e *Equipment) ClearUnusedFieldsForType() {
 	if !e.Container() {
 		e.Children = nil
+		e.Kit = false
+		e.ContainerType = ectype.Normal
 	}
 }
 
@@ -794,6 +816,7 @@ func (e *Equipment) SyncWithSource() {
 				e.Prereq = other.Prereq.CloneResolvingEmpty(false, true)
 				e.Weapons = CloneWeapons(other.Weapons, false)
 				e.Features = other.Features.Clone()
+				e.WeightRules = CloneWeightRules(other.WeightRules)
 			}
 		}
 	}
@@ -818,6 +841,7 @@ func (e *EquipmentSyncData) hash(h hash.Hash) {
 	_ = binary.Write(h, binary.LittleEndian, e.Value)
 	_ = binary.Write(h, binary.LittleEndian, e.Weight)
 	_ = binary.Write(h, binary.LittleEndian, int64(e.MaxUses))
+	_ = binary.Write(h, binary.LittleEndian, e.Recharge)
 	e.Prereq.Hash(h)
 	for _, weapon := range e.Weapons {
 		weapon.Hash(h)
@@ -826,6 +850,10 @@ func (e *EquipmentSyncData) hash(h hash.Hash) {
 		feature.Hash(h)
 	}
 	_ = binary.Write(h, binary.LittleEndian, e.WeightIgnoredForSkills)
+	for _, rule := range e.WeightRules {
+		rule.Hash(h)
+	}
+	_ = binary.Write(h, binary.LittleEndian, e.ContainerType)
 }
 
 // CopyFrom implements node.EditorData.
@@ -852,4 +880,5 @@ func (e *EquipmentEditData) copyFrom(owner DataOwner, other *EquipmentEditData,
 	e.Prereq = e.Prereq.CloneResolvingEmpty(false, isApply)
 	e.Weapons = CloneWeapons(other.Weapons, isApply)
 	e.Features = other.Features.Clone()
+	e.WeightRules = CloneWeightRules(other.WeightRules)
 }
@@ -0,0 +1,84 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/ectype"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// PayloadTraitName is the name of the trait that provides Payload capacity.
+const PayloadTraitName = "Payload"
+
+// ContentsAreWeightless returns true if the contents of this container do not count toward its weight.
+func (e *Equipment) ContentsAreWeightless() bool {
+	return e.Container() && e.ContainerType != ectype.Normal
+}
+
+// PayloadCapacity returns the amount of weight that may be carried in Payload containers. Each level of the Payload
+// trait provides one-tenth of Basic Lift.
+func (e *Entity) PayloadCapacity() fxp.Weight {
+	var levels fxp.Int
+	Traverse(func(t *Trait) bool {
+		if strings.EqualFold(t.NameWithReplacements(), PayloadTraitName) {
+			if t.IsLeveled() {
+				levels += t.CurrentLevel()
+			} else {
+				levels += fxp.One
+			}
+		}
+		return false
+	}, true, false, e.Traits...)
+	return fxp.Weight(fxp.Int(e.BasicLift()).Mul(levels).Div(fxp.Ten))
+}
+
+// PayloadWeight returns the weight of the contents of the carried Payload containers.
+func (e *Entity) PayloadWeight() fxp.Weight {
+	return payloadWeight(e.CarriedEquipment, e.SheetSettings.DefaultWeightUnits)
+}
+
+func payloadWeight(list []*Equipment, defUnits fxp.WeightUnit) fxp.Weight {
+	var total fxp.Weight
+	for _, one := range list {
+		if one.Quantity <= 0 || !one.Container() {
+			continue
+		}
+		if one.ContainerType == ectype.Payload {
+			var contained fxp.Weight
+			for _, child := range one.Children {
+				contained += child.ExtendedWeight(false, defUnits)
+			}
+			total += fxp.Weight(fxp.Int(contained).Mul(one.Quantity))
+		} else {
+			total += fxp.Weight(fxp.Int(payloadWeight(one.Children, defUnits)).Mul(one.Quantity))
+		}
+	}
+	return total
+}
+
+func (e *Entity) checkPayload(issues []*ValidationIssue) []*ValidationIssue {
+	if weight := e.PayloadWeight(); weight > 0 {
+		if capacity := e.PayloadCapacity(); weight > capacity {
+			units := e.SheetSettings.DefaultWeightUnits
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Payload containers hold %s, exceeding the Payload capacity of %s"),
+					units.Format(weight), units.Format(capacity)),
+			})
+		}
+	}
+	return issues
+}
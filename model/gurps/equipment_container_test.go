@@ -0,0 +1,41 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/ectype"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestContainerTypes(t *testing.T) {
+	e := NewEntity()
+	bag := NewEquipment(e, nil, true)
+	bag.Weight = fxp.Weight(fxp.One)
+	rock := NewEquipment(e, bag, false)
+	rock.Weight = fxp.Weight(fxp.Ten)
+	bag.Children = []*Equipment{rock}
+	e.CarriedEquipment = []*Equipment{bag}
+	units := fxp.Pound
+
+	check.Equal(t, fxp.Weight(fxp.Eleven), bag.ExtendedWeight(false, units))
+	check.Equal(t, fxp.Weight(0), e.PayloadWeight())
+
+	bag.ContainerType = ectype.ExtraDimensional
+	check.Equal(t, fxp.Weight(fxp.One), bag.ExtendedWeight(false, units), "contents are weightless")
+	check.Equal(t, fxp.Weight(0), e.PayloadWeight())
+
+	bag.ContainerType = ectype.Payload
+	check.Equal(t, fxp.Weight(fxp.One), bag.ExtendedWeight(false, units), "contents don't count toward encumbrance")
+	check.Equal(t, fxp.Weight(fxp.Ten), e.PayloadWeight(), "contents count against payload")
+	check.Equal(t, 1, len(e.checkPayload(nil)), "no Payload trait, so no capacity")
+}
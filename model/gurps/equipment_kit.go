@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+// NewKit creates a new kit container holding copies of the equipment in the list. The kit is not attached to any
+// owner, so that it may be saved to a library.
+func NewKit(name string, list []*Equipment) *Equipment {
+	kit := NewEquipment(nil, nil, true)
+	kit.Name = name
+	kit.Kit = true
+	kit.Children = make([]*Equipment, 0, len(list))
+	for _, one := range list {
+		kit.Children = append(kit.Children, one.Clone(LibraryFile{}, nil, kit, false))
+	}
+	return kit
+}
+
+// ExpandKits replaces any kits within the list with their contents, placing them within the parent that held the kit.
+// Returns the resulting list and true if any kits were expanded.
+func ExpandKits(list []*Equipment, parent *Equipment) ([]*Equipment, bool) {
+	expanded := false
+	result := make([]*Equipment, 0, len(list))
+	for _, one := range list {
+		if one.Kit && one.Container() {
+			expanded = true
+			children, _ := ExpandKits(one.Children, parent)
+			for _, child := range children {
+				child.parent = parent
+			}
+			result = append(result, children...)
+			continue
+		}
+		if one.Container() {
+			var changed bool
+			if one.Children, changed = ExpandKits(one.Children, one); changed {
+				expanded = true
+			}
+		}
+		result = append(result, one)
+	}
+	return result, expanded
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestEquipmentKits(t *testing.T) {
+	e := NewEntity()
+	rope := NewEquipment(e, nil, false)
+	rope.Name = "Rope"
+	rope.Quantity = fxp.Two
+	pack := NewEquipment(e, nil, true)
+	pack.Name = "Backpack"
+	torch := NewEquipment(e, pack, false)
+	torch.Name = "Torch"
+	torch.Quantity = fxp.Five
+	pack.Children = []*Equipment{torch}
+
+	kit := NewKit("Explorer's Kit", []*Equipment{rope, pack})
+	check.True(t, kit.Kit)
+	check.True(t, kit.Container())
+	check.Equal(t, "Explorer's Kit", kit.Name)
+	check.Nil(t, kit.DataOwner(), "kits are not attached to an owner")
+	check.Equal(t, 2, len(kit.Children))
+	check.Equal(t, "Rope", kit.Children[0].Name)
+	check.Equal(t, fxp.Two, kit.Children[0].Quantity)
+	check.NotEqual(t, rope.TID, kit.Children[0].TID, "the kit holds copies with their own IDs")
+	check.Equal(t, kit, kit.Children[0].Parent())
+	check.Equal(t, 1, len(kit.Children[1].Children))
+	check.NotEqual(t, torch.TID, kit.Children[1].Children[0].TID)
+
+	lantern := NewEquipment(e, nil, false)
+	lantern.Name = "Lantern"
+	sack := NewEquipment(e, nil, true)
+	sack.Name = "Sack"
+	nested := NewKit("Light Kit", []*Equipment{lantern})
+	nested.parent = sack
+	sack.Children = []*Equipment{nested}
+	list, expanded := ExpandKits([]*Equipment{kit, sack}, nil)
+	check.True(t, expanded)
+	check.Equal(t, 3, len(list))
+	check.Equal(t, kit.Children[0], list[0], "the kit's contents replace the kit")
+	check.Nil(t, list[0].Parent())
+	check.Equal(t, "Backpack", list[1].Name)
+	check.Equal(t, 1, len(list[1].Children))
+	check.Equal(t, "Torch", list[1].Children[0].Name)
+	check.Equal(t, fxp.Five, list[1].Children[0].Quantity)
+	check.Equal(t, list[1], list[1].Children[0].Parent())
+	check.Equal(t, sack, list[2])
+	check.Equal(t, 1, len(sack.Children), "kits within containers are expanded in place")
+	check.Equal(t, "Lantern", sack.Children[0].Name)
+	check.Equal(t, sack, sack.Children[0].Parent())
+
+	_, expanded = ExpandKits(list, nil)
+	check.False(t, expanded)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// CanAdjustUses returns true if the remaining uses can be changed by the amount.
+func (e *Equipment) CanAdjustUses(amount int) bool {
+	if e.MaxUses <= 0 || amount == 0 {
+		return false
+	}
+	total := e.Uses + amount
+	return total >= 0 && total <= e.MaxUses
+}
+
+// UsedUpBy returns true if adjusting the remaining uses by the amount would use up a consumable entirely.
+func (e *Equipment) UsedUpBy(amount int) bool {
+	return e.Recharge == recharge.Consumable && e.CanAdjustUses(amount) && e.Uses+amount == 0 && e.Quantity <= fxp.One
+}
+
+// AdjustUses changes the remaining uses by the amount, if possible. When a consumable in a stack is used up, the
+// quantity is reduced and the next one in the stack starts out with its full uses. Returns true if a consumable was
+// used up entirely.
+func (e *Equipment) AdjustUses(amount int) bool {
+	if !e.CanAdjustUses(amount) {
+		return false
+	}
+	usedUp := e.UsedUpBy(amount)
+	e.Uses += amount
+	if e.Recharge == recharge.Consumable && e.Uses == 0 && e.Quantity > fxp.One {
+		e.Quantity -= fxp.One
+		e.Uses = e.MaxUses
+	}
+	return usedUp
+}
+
+// EquipmentToRecharge returns the equipment that recharges in the given way and isn't already at its maximum uses.
+func (e *Entity) EquipmentToRecharge(kind recharge.Type) []*Equipment {
+	var list []*Equipment
+	f := func(eqp *Equipment) bool {
+		if eqp.Recharge == kind && eqp.MaxUses > 0 && eqp.Uses != eqp.MaxUses {
+			list = append(list, eqp)
+		}
+		return false
+	}
+	Traverse(f, false, false, e.CarriedEquipment...)
+	Traverse(f, false, false, e.OtherEquipment...)
+	return list
+}
+
+// RechargeEquipment restores the uses of all equipment that recharges in the given way, returning the affected
+// equipment.
+func (e *Entity) RechargeEquipment(kind recharge.Type) []*Equipment {
+	list := e.EquipmentToRecharge(kind)
+	for _, eqp := range list {
+		eqp.Uses = eqp.MaxUses
+	}
+	return list
+}
+
+// RemoveEquipment removes the equipment from the entity. Returns false if it could not be found.
+func (e *Entity) RemoveEquipment(target *Equipment) bool {
+	if target.parent != nil {
+		if i := slices.Index(target.parent.Children, target); i != -1 {
+			target.parent.Children = slices.Delete(target.parent.Children, i, i+1)
+			return true
+		}
+		return false
+	}
+	if i := slices.Index(e.CarriedEquipment, target); i != -1 {
+		e.CarriedEquipment = slices.Delete(e.CarriedEquipment, i, i+1)
+		return true
+	}
+	if i := slices.Index(e.OtherEquipment, target); i != -1 {
+		e.OtherEquipment = slices.Delete(e.OtherEquipment, i, i+1)
+		return true
+	}
+	return false
+}
+
+func (e *Entity) checkConsumables(issues []*ValidationIssue) []*ValidationIssue {
+	f := func(eqp *Equipment) bool {
+		if eqp.Recharge == recharge.Consumable && eqp.MaxUses > 0 && eqp.Uses == 0 {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Equipment,
+				Subject: eqp.String(),
+				Message: fmt.Sprintf(i18n.Text("%s has been used up"), eqp.String()),
+			})
+		}
+		return false
+	}
+	Traverse(f, false, false, e.CarriedEquipment...)
+	Traverse(f, false, false, e.OtherEquipment...)
+	return issues
+}
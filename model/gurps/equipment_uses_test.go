@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestConsumableUses(t *testing.T) {
+	e := NewEntity()
+	potion := NewEquipment(e, nil, false)
+	potion.Quantity = fxp.Two
+	potion.MaxUses = 1
+	potion.Uses = 1
+	potion.Recharge = recharge.Consumable
+	e.CarriedEquipment = []*Equipment{potion}
+
+	check.False(t, potion.CanAdjustUses(1))
+	check.False(t, potion.AdjustUses(-1), "another potion remains in the stack")
+	check.Equal(t, fxp.One, potion.Quantity)
+	check.Equal(t, 1, potion.Uses)
+	check.Equal(t, 0, len(e.checkConsumables(nil)))
+
+	check.True(t, potion.UsedUpBy(-1))
+	check.True(t, potion.AdjustUses(-1))
+	check.Equal(t, 0, potion.Uses)
+	check.Equal(t, 1, len(e.checkConsumables(nil)))
+	check.True(t, e.RemoveEquipment(potion))
+	check.Equal(t, 0, len(e.CarriedEquipment))
+}
+
+func TestRechargeEquipment(t *testing.T) {
+	e := NewEntity()
+	wand := NewEquipment(e, nil, false)
+	wand.MaxUses = 3
+	wand.Recharge = recharge.PerDay
+	ring := NewEquipment(e, nil, false)
+	ring.MaxUses = 2
+	ring.Recharge = recharge.PerSession
+	e.CarriedEquipment = []*Equipment{wand, ring}
+
+	check.Equal(t, 1, len(e.RechargeEquipment(recharge.PerDay)))
+	check.Equal(t, 3, wand.Uses)
+	check.Equal(t, 0, ring.Uses)
+	check.Equal(t, 0, len(e.RechargeEquipment(recharge.PerDay)))
+}
@@ -65,10 +65,7 @@ func (p *EquippedEquipmentPrereq) FillWithNameableKeys(m, existing map[string]st
 
 // Satisfied implements Prereq.
 func (p *EquippedEquipmentPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, hasEquipmentPenalty *bool) bool {
-	var replacements map[string]string
-	if na, ok := exclude.(nameable.Accesser); ok {
-		replacements = na.NameableReplacements()
-	}
+	replacements := entity.prereqReplacements(exclude)
 	satisfied := false
 	Traverse(func(eqp *Equipment) bool {
 		satisfied = exclude != eqp && eqp.Equipped && eqp.Quantity > 0 &&
@@ -135,6 +135,7 @@ func evalSkillLevel(ev *eval.Evaluator, arguments string) (any, error) {
 	e.registerSkillLevelResolutionExclusion(name, specialization)
 	defer e.unregisterSkillLevelResolutionExclusion(name, specialization)
 	var level fxp.Int
+	found := false
 	Traverse(func(s *Skill) bool {
 		if strings.EqualFold(s.NameWithReplacements(), name) &&
 			strings.EqualFold(s.SpecializationWithReplacements(), specialization) {
@@ -144,10 +145,23 @@ func evalSkillLevel(ev *eval.Evaluator, arguments string) (any, error) {
 			} else {
 				level = s.LevelData.Level
 			}
+			found = true
 			return true
 		}
 		return false
 	}, true, true, e.Skills...)
+	if !found {
+		for _, s := range e.grantedSkills {
+			if strings.EqualFold(s.Name, name) && strings.EqualFold(s.Specialization, specialization) {
+				if relative {
+					level = s.LevelData.RelativeLevel
+				} else {
+					level = s.LevelData.Level
+				}
+				break
+			}
+		}
+	}
 	return level, nil
 }
 
@@ -19,7 +19,6 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
-	"strings"
 	texttmpl "text/template"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
@@ -36,6 +35,7 @@ type exportedMeleeWeapon struct {
 	Usage         string
 	Level         fxp.Int
 	Damage        string
+	DamageParts   *DamageBreakdown
 	Parry         string
 	ParryParts    WeaponParry
 	Block         string
@@ -56,6 +56,7 @@ type exportedRangedWeapon struct {
 	Range           string
 	RangeParts      WeaponRange
 	Damage          string
+	DamageParts     *DamageBreakdown
 	RateOfFire      string
 	RateOfFireParts WeaponRoF
 	Shots           string
@@ -70,6 +71,7 @@ type exportedRangedWeapon struct {
 
 type exportedHitLocation struct {
 	RollRange string
+	Chance    string
 	Where     string
 	Penalty   int
 	DR        string
@@ -79,6 +81,7 @@ type exportedHitLocation struct {
 
 type exportedBodyType struct {
 	Name      string
+	Roll      string
 	Locations []*exportedHitLocation
 }
 
@@ -291,6 +294,7 @@ type exportedEntity struct {
 	Notes                   []*exportedNote
 	MeleeWeapons            []*exportedMeleeWeapon
 	RangedWeapons           []*exportedRangedWeapon
+	Companions              []*Companion
 	GridTemplate            htmltmpl.CSS
 	Page                    exportedPage
 }
@@ -317,6 +321,12 @@ func ExportSheets(templatePath string, fileList []string) error {
 
 // Export an Entity to exportPath using the template found at templatePath.
 func Export(entity *Entity, templatePath, exportPath string) error {
+	return ExportWithOptions(entity, templatePath, exportPath, HTMLExportOptions{})
+}
+
+// ExportWithOptions exports an Entity to exportPath using the template found at templatePath. The options are only
+// applied when the template is an HTML template.
+func ExportWithOptions(entity *Entity, templatePath, exportPath string, options HTMLExportOptions) error {
 	tmpl, err := os.ReadFile(templatePath)
 	if err != nil {
 		return errs.Wrap(err)
@@ -333,6 +343,13 @@ func Export(entity *Entity, templatePath, exportPath string) error {
 		if t, err = htmltmpl.New("").Funcs(createTemplateFuncs()).Parse(string(tmpl[advance:])); err != nil {
 			return errs.Wrap(err)
 		}
+		if options.ThemePath != "" || options.SelfContained {
+			return export(entity, &themedHTMLExporter{
+				tmpl:    t,
+				baseDir: filepath.Dir(templatePath),
+				options: options,
+			}, exportPath)
+		}
 		return export(entity, t, exportPath)
 	case "GCS Text Template v1":
 		var t *texttmpl.Template
@@ -345,30 +362,6 @@ func Export(entity *Entity, templatePath, exportPath string) error {
 	}
 }
 
-func createTemplateFuncs() texttmpl.FuncMap {
-	return texttmpl.FuncMap{
-		"caselessEqual": strings.EqualFold,
-		"contains":      strings.Contains,
-		"hasPrefix":     strings.HasPrefix,
-		"hasSuffix":     strings.HasSuffix,
-		"index":         strings.Index,
-		"join":          strings.Join,
-		"lastIndex":     strings.LastIndex,
-		"lower":         strings.ToLower,
-		"numberFrom":    numberFrom,
-		"numberToFloat": fxp.As[float64],
-		"numberToInt":   fxp.As[int],
-		"repeat":        strings.Repeat,
-		"replace":       strings.ReplaceAll,
-		"split":         strings.Split,
-		"splitN":        strings.SplitN,
-		"trim":          strings.TrimSpace,
-		"trimPrefix":    strings.TrimPrefix,
-		"trimSuffix":    strings.TrimSuffix,
-		"upper":         strings.ToUpper,
-	}
-}
-
 func numberFrom(value any) (fxp.Int, error) {
 	switch v := value.(type) {
 	case int:
@@ -439,6 +432,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 		},
 		BodyType: exportedBodyType{
 			Name:      entity.SheetSettings.BodyType.Name,
+			Roll:      entity.SheetSettings.BodyType.TableRoll().String(),
 			Locations: addToHitLocations(entity, nil, 0, entity.SheetSettings.BodyType.Locations),
 		},
 		Reactions:            newExportedConditionalModifiers(entity.Reactions()),
@@ -450,6 +444,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 			Other:         newExportedEquipment(entity, entity.OtherEquipment, false),
 			OtherValue:    entity.WealthNotCarried(),
 		},
+		Companions:   entity.CompanionsForExport(),
 		GridTemplate: htmltmpl.CSS(entity.SheetSettings.BlockLayout.HTMLGridTemplate()), //nolint:gosec // This is safe
 		Page:         newExportedPage(entity.SheetSettings.Page),
 	}
@@ -601,6 +596,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 			Block:         block.String(),
 			BlockParts:    block,
 			Damage:        w.Damage.ResolvedDamage(nil),
+			DamageParts:   w.Damage.Breakdown(),
 			Reach:         reach.String(),
 			ReachParts:    reach,
 			Strength:      weaponST.String(),
@@ -625,6 +621,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 			Range:           weaponRange.String(true),
 			RangeParts:      weaponRange,
 			Damage:          w.Damage.ResolvedDamage(nil),
+			DamageParts:     w.Damage.Breakdown(),
 			RateOfFire:      rof.String(),
 			RateOfFireParts: rof,
 			Shots:           shots.String(),
@@ -735,6 +732,7 @@ func addToHitLocations(entity *Entity, locations []*exportedHitLocation, depth i
 	for _, location := range hitLocations {
 		loc := &exportedHitLocation{
 			RollRange: location.RollRange,
+			Chance:    location.RollChanceText(),
 			Where:     location.TableName,
 			Penalty:   location.HitPenalty,
 			Depth:     depth,
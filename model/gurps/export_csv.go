@@ -0,0 +1,128 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// CSVExt is the extension used for CSV exports.
+const CSVExt = ".csv"
+
+// PlainTitle returns a textual title for the header, substituting a name for those headers that use an image.
+func (h HeaderData) PlainTitle() string {
+	if !h.TitleIsImageKey {
+		return h.Title
+	}
+	switch h.Title {
+	case HeaderCheckmark:
+		return i18n.Text("Enabled")
+	case HeaderCoins:
+		return i18n.Text("Value")
+	case HeaderStackedCoins:
+		return i18n.Text("Extended Value")
+	case HeaderWeight:
+		return i18n.Text("Weight")
+	case HeaderStackedWeight:
+		return i18n.Text("Extended Weight")
+	case HeaderBookmark:
+		return i18n.Text("Reference")
+	case HeaderDatabase:
+		return i18n.Text("Library")
+	default:
+		return h.Title
+	}
+}
+
+// ExportCSV writes the rows and all of their descendants to filePath as comma-separated values. Each column holds the
+// text the column would display in a table, so computed values such as adjusted points, levels and extended weights
+// are included. The notes shown beneath the primary column are placed in a column of their own, and a final column
+// marks which rows are containers, since a container's values typically include those of its children.
+func ExportCSV[T NodeTypes](filePath string, columnIDs []int, headerData func(columnID int) HeaderData, rows []T) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if err = WriteCSV(f, columnIDs, headerData, rows); err != nil {
+		errs.Log(f.Close())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// WriteCSV writes the rows and all of their descendants to w as comma-separated values. See ExportCSV for details.
+func WriteCSV[T NodeTypes](w io.Writer, columnIDs []int, headerData func(columnID int) HeaderData, rows []T) error {
+	out := csv.NewWriter(w)
+	primary := make([]bool, len(columnIDs))
+	record := make([]string, 0, len(columnIDs)+2)
+	for i, id := range columnIDs {
+		header := headerData(id)
+		record = append(record, header.PlainTitle())
+		if header.Primary {
+			primary[i] = true
+			record = append(record, i18n.Text("Notes"))
+		}
+	}
+	record = append(record, i18n.Text("Container"))
+	if err := out.Write(record); err != nil {
+		return errs.Wrap(err)
+	}
+	var err error
+	Traverse(func(row T) bool {
+		node := AsNode(row)
+		record = record[:0]
+		for i, id := range columnIDs {
+			var data CellData
+			node.CellData(id, &data)
+			record = append(record, csvCellText(&data))
+			if primary[i] {
+				record = append(record, data.Secondary)
+			}
+		}
+		var container string
+		if node.Container() {
+			container = i18n.Text("Yes")
+		}
+		record = append(record, container)
+		if err = out.Write(record); err != nil {
+			err = errs.Wrap(err)
+			return true
+		}
+		return false
+	}, false, false, rows...)
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return errs.Wrap(out.Error())
+}
+
+func csvCellText(data *CellData) string {
+	switch data.Type {
+	case cell.Toggle:
+		if data.Checked {
+			return i18n.Text("Yes")
+		}
+		return ""
+	case cell.Text, cell.PageRef, cell.Tags, cell.Markdown, cell.Stepper:
+		return data.Primary
+	default:
+		return ""
+	}
+}
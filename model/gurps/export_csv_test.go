@@ -0,0 +1,40 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWriteCSV(t *testing.T) {
+	e := NewEntity()
+	container := NewTrait(e, nil, true)
+	container.Name = "Racial, Traits"
+	child := NewTrait(e, container, false)
+	child.Name = "Fit"
+	container.Children = []*Trait{child}
+	e.Traits = []*Trait{container}
+	e.Recalculate()
+
+	var buffer strings.Builder
+	check.NoError(t, WriteCSV(&buffer, []int{TraitDescriptionColumn, TraitReferenceColumn}, TraitsHeaderData, e.Traits))
+	records, err := csv.NewReader(strings.NewReader(buffer.String())).ReadAll()
+	check.NoError(t, err)
+	check.Equal(t, 3, len(records))
+	check.Equal(t, []string{"Trait", "Notes", "Reference", "Container"}, records[0])
+	check.Equal(t, "Racial, Traits", records[1][0], "commas must survive the round trip")
+	check.Equal(t, "Yes", records[1][3])
+	check.Equal(t, "Fit", records[2][0])
+	check.Equal(t, "", records[2][3])
+}
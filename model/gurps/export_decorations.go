@@ -0,0 +1,110 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholders that may be used within export headers and footers.
+const (
+	ExportPagePlaceholder      = "{page}"
+	ExportPageCountPlaceholder = "{pages}"
+	ExportNamePlaceholder      = "{name}"
+	ExportTitlePlaceholder     = "{title}"
+	ExportPlayerPlaceholder    = "{player}"
+	ExportDatePlaceholder      = "{date}"
+)
+
+// Watermark opacity limits, as a percentage.
+const (
+	WatermarkOpacityMin = 1
+	WatermarkOpacityMax = 100
+	WatermarkOpacityDef = 15
+)
+
+// ExportDecorations holds the additional header, footer and watermark drawn on each page when a sheet is exported to
+// PDF or an image. The watermark image is referenced rather than embedded, so that it isn't copied into every sheet.
+type ExportDecorations struct {
+	Header           string       `json:"header,omitempty"`
+	Footer           string       `json:"footer,omitempty"`
+	WatermarkText    string       `json:"watermark_text,omitempty"`
+	WatermarkImage   *LibraryFile `json:"watermark_image_file,omitempty"`
+	WatermarkOpacity int          `json:"watermark_opacity"`
+}
+
+// NewExportDecorations returns new export decorations with factory defaults.
+func NewExportDecorations() *ExportDecorations {
+	return &ExportDecorations{WatermarkOpacity: WatermarkOpacityDef}
+}
+
+// EnsureValidity checks the current settings for validity and if they aren't valid, makes them so.
+func (d *ExportDecorations) EnsureValidity() {
+	if d.WatermarkOpacity < WatermarkOpacityMin || d.WatermarkOpacity > WatermarkOpacityMax {
+		d.WatermarkOpacity = WatermarkOpacityDef
+	}
+}
+
+// Clone creates a copy of this.
+func (d *ExportDecorations) Clone() *ExportDecorations {
+	if d == nil {
+		return nil
+	}
+	clone := *d
+	if d.WatermarkImage != nil {
+		libFile := *d.WatermarkImage
+		clone.WatermarkImage = &libFile
+	}
+	return &clone
+}
+
+// HasWatermark returns true if a watermark should be drawn.
+func (d *ExportDecorations) HasWatermark() bool {
+	return strings.TrimSpace(d.WatermarkText) != "" || d.WatermarkImage != nil
+}
+
+// WatermarkImagePathOnDisk returns the path to the watermark image file, or an empty string if there is no watermark
+// image or the library it was in is no longer configured.
+func (d *ExportDecorations) WatermarkImagePathOnDisk() string {
+	if d.WatermarkImage == nil {
+		return ""
+	}
+	if d.WatermarkImage.Library == "" {
+		return d.WatermarkImage.Path
+	}
+	if lib, ok := GlobalSettings().Libraries()[d.WatermarkImage.Library]; ok {
+		return filepath.Join(lib.PathOnDisk, d.WatermarkImage.Path)
+	}
+	return ""
+}
+
+// Expand returns the text with its placeholders replaced by the values for the given page of the entity's export.
+func (d *ExportDecorations) Expand(text string, entity *Entity, pageNumber, pageCount int, when time.Time) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	var name, title, player string
+	if entity != nil {
+		name = entity.Profile.Name
+		title = entity.Profile.Title
+		player = entity.Profile.PlayerName
+	}
+	return strings.NewReplacer(
+		ExportPagePlaceholder, strconv.Itoa(pageNumber),
+		ExportPageCountPlaceholder, strconv.Itoa(pageCount),
+		ExportNamePlaceholder, name,
+		ExportTitlePlaceholder, title,
+		ExportPlayerPlaceholder, player,
+		ExportDatePlaceholder, when.Format(time.DateOnly),
+	).Replace(text)
+}
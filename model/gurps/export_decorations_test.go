@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestExportDecorations(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Dai Blackthorn"
+	e.Profile.Title = "Thief"
+	e.Profile.PlayerName = "Pat"
+	d := e.SheetSettings.ExportDecorations
+	check.NotNil(t, d)
+	check.Equal(t, WatermarkOpacityDef, d.WatermarkOpacity)
+	check.False(t, d.HasWatermark())
+
+	when := time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC)
+	check.Equal(t, "Dai Blackthorn (Thief) for Pat, 2024-03-09, page 2 of 5",
+		d.Expand("{name} ({title}) for {player}, {date}, page {page} of {pages}", e, 2, 5, when))
+	check.Equal(t, "Convention Handout", d.Expand("Convention Handout", e, 1, 1, when))
+
+	d.WatermarkText = "DRAFT"
+	d.WatermarkImage = &LibraryFile{Library: "richardwilkes/gcs_user_library", Path: "Images/draft.png"}
+	d.WatermarkOpacity = 500
+	d.EnsureValidity()
+	check.True(t, d.HasWatermark())
+	check.Equal(t, WatermarkOpacityDef, d.WatermarkOpacity)
+
+	clone := e.SheetSettings.Clone(e).ExportDecorations
+	check.Equal(t, d, clone)
+	clone.WatermarkImage.Path = "Images/final.png"
+	check.Equal(t, "Images/draft.png", d.WatermarkImage.Path, "the watermark image reference is copied, not shared")
+}
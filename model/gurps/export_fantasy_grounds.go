@@ -0,0 +1,270 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// FantasyGroundsExt is the extension used for Fantasy Grounds exports.
+const FantasyGroundsExt = ".xml"
+
+// fantasyGroundsAttributeNames maps attribute IDs to the names the Fantasy Grounds Unity GURPS ruleset uses for them.
+// Attributes not listed here are not exported, as the ruleset has no place to put them.
+var fantasyGroundsAttributeNames = map[string]string{
+	StrengthID:   "strength",
+	DexterityID:  "dexterity",
+	"iq":         "intelligence",
+	"ht":         "health",
+	"will":       "will",
+	"per":        "perception",
+	BasicSpeedID: "basicspeed",
+	BasicMoveID:  "basicmove",
+}
+
+// FantasyGroundsNode is an element of a Fantasy Grounds XML document. Fantasy Grounds stores lists as elements named
+// "id-NNNNN" and marks each value with its type, so the document is built as a generic tree rather than from fixed
+// structures.
+type FantasyGroundsNode struct {
+	XMLName  xml.Name
+	Version  string                `xml:"version,attr,omitempty"`
+	Type     string                `xml:"type,attr,omitempty"`
+	Value    string                `xml:",chardata"`
+	Children []*FantasyGroundsNode `xml:",any"`
+}
+
+// ExportFantasyGrounds writes the entity to filePath as a Fantasy Grounds Unity character for the GURPS ruleset.
+func ExportFantasyGrounds(e *Entity, filePath string) error {
+	data, err := xml.MarshalIndent(NewFantasyGroundsCharacter(e), "", "\t")
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if err = os.WriteFile(filePath, append([]byte(xml.Header), append(data, '\n')...), 0o640); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// NewFantasyGroundsCharacter creates the root node of a Fantasy Grounds character document from the entity.
+func NewFantasyGroundsCharacter(e *Entity) *FantasyGroundsNode {
+	e.Recalculate()
+	enc := e.EncumbranceLevel(false)
+	root := &FantasyGroundsNode{XMLName: xml.Name{Local: "root"}, Version: "4.4"}
+	c := root.node("character")
+	c.str("name", e.Profile.Name)
+	c.str("title", e.Profile.Title)
+	c.str("player", e.Profile.PlayerName)
+	c.str("gender", e.Profile.Gender)
+	c.str("age", e.Profile.Age)
+	c.str("height", e.SheetSettings.DefaultLengthUnits.Format(e.Profile.Height))
+	c.str("weight", e.SheetSettings.DefaultWeightUnits.Format(e.Profile.Weight))
+	c.str("techlevel", e.Profile.TechLevel)
+	c.num("sizemodifier", strconv.Itoa(e.Profile.AdjustedSizeModifier()))
+	c.num("totalpoints", e.TotalPoints.String())
+	c.num("unspentpoints", e.UnspentPoints().String())
+
+	attrs := c.node("attributes")
+	for _, attr := range e.Attributes.List() {
+		def := attr.AttributeDef()
+		if def == nil {
+			continue
+		}
+		if name, ok := fantasyGroundsAttributeNames[def.DefID]; ok {
+			attrs.num(name, attr.Maximum().String())
+			attrs.num(name+"_points", attr.PointCost().String())
+		}
+	}
+	fantasyGroundsPool(e, attrs, "hp", "hitpoints", "hps")
+	fantasyGroundsPool(e, attrs, "fp", "fatiguepoints", "fps")
+	attrs.num("move", strconv.Itoa(e.Move(enc)))
+	attrs.str("basiclift", e.SheetSettings.DefaultWeightUnits.Format(e.BasicLift()))
+	attrs.str("thrust", e.Thrust().String())
+	attrs.str("swing", e.Swing().String())
+
+	traits := c.node("traits")
+	ads := traits.node("adslist")
+	disads := traits.node("disadslist")
+	Traverse(func(t *Trait) bool {
+		list := ads
+		if t.AdjustedPoints() < 0 {
+			list = disads
+		}
+		item := list.item()
+		item.str("name", t.String())
+		item.num("points", t.AdjustedPoints().String())
+		item.str("text", joinNotes(t.ModifierNotes(), t.Notes()))
+		item.str("page", t.PageRef)
+		return false
+	}, true, true, e.Traits...)
+
+	abilities := c.node("abilities")
+	skills := abilities.node("skilllist")
+	Traverse(func(s *Skill) bool {
+		item := skills.item()
+		item.str("name", s.String())
+		item.str("type", s.Difficulty.Description(e))
+		item.num("level", strconv.Itoa(foundryLevel(s.CalculateLevel(nil).Level)))
+		item.str("relativelevel", s.RelativeLevel())
+		item.num("points", s.AdjustedPoints(nil).String())
+		item.str("text", joinNotes(s.ModifierNotes(), s.Notes()))
+		item.str("page", s.PageRef)
+		return false
+	}, true, true, e.Skills...)
+	spells := abilities.node("spelllist")
+	Traverse(func(s *Spell) bool {
+		item := spells.item()
+		item.str("name", s.String())
+		item.str("class", s.ClassWithReplacements())
+		item.str("college", strings.Join(s.CollegeWithReplacements(), ", "))
+		item.str("costmaintain", joinCost(s.CastingCostWithReplacements(), s.MaintenanceCostWithReplacements()))
+		item.str("time", s.CastingTimeWithReplacements())
+		item.str("duration", s.DurationWithReplacements())
+		item.str("resist", s.ResistWithReplacements())
+		item.str("type", s.Difficulty.Description(e))
+		item.num("level", strconv.Itoa(foundryLevel(s.CalculateLevel().Level)))
+		item.str("relativelevel", s.RelativeLevel())
+		item.num("points", s.AdjustedPoints(nil).String())
+		item.str("text", s.Notes())
+		item.str("page", s.PageRef)
+		return false
+	}, true, true, e.Spells...)
+
+	combat := c.node("combat")
+	combat.num("dodge", strconv.Itoa(e.Dodge(enc)))
+	melee := combat.node("meleecombatlist")
+	for _, w := range e.EquippedWeapons(true) {
+		item := melee.item()
+		item.str("name", w.String())
+		item.str("st", w.Strength.Resolve(w, nil).String())
+		item.str("text", w.Notes())
+		mode := item.node("meleemodelist").item()
+		mode.str("name", w.UsageWithReplacements())
+		mode.num("level", strconv.Itoa(foundryLevel(w.SkillLevel(nil))))
+		mode.str("damage", w.Damage.ResolvedDamage(nil))
+		mode.str("reach", w.Reach.Resolve(w, nil).String())
+		mode.str("parry", w.Parry.Resolve(w, nil).String())
+		mode.str("block", w.Block.Resolve(w, nil).String())
+	}
+	ranged := combat.node("rangedcombatlist")
+	for _, w := range e.EquippedWeapons(false) {
+		item := ranged.item()
+		item.str("name", w.String())
+		item.str("st", w.Strength.Resolve(w, nil).String())
+		item.str("bulk", w.Bulk.Resolve(w, nil).String())
+		item.str("text", w.Notes())
+		mode := item.node("rangedmodelist").item()
+		mode.str("name", w.UsageWithReplacements())
+		mode.num("level", strconv.Itoa(foundryLevel(w.SkillLevel(nil))))
+		mode.str("damage", w.Damage.ResolvedDamage(nil))
+		mode.str("acc", w.Accuracy.Resolve(w, nil).String())
+		mode.str("range", w.Range.Resolve(w, nil).String(true))
+		mode.str("rof", w.RateOfFire.Resolve(w, nil).String())
+		mode.str("shots", w.Shots.Resolve(w, nil).String())
+		mode.str("rcl", w.Recoil.Resolve(w, nil).String())
+	}
+	locations := combat.node("hitlocations")
+	if body := e.SheetSettings.BodyType; body != nil {
+		for _, loc := range body.Locations {
+			item := locations.item()
+			item.str("location", loc.TableName)
+			item.str("roll", loc.RollRange)
+			item.num("penalty", strconv.Itoa(loc.HitPenalty))
+			item.str("dr", loc.DisplayDR(e, nil))
+			item.str("text", loc.Description)
+		}
+	}
+
+	inventory := c.node("inventorylist")
+	fantasyGroundsEquipment(inventory, e.CarriedEquipment, true)
+	fantasyGroundsEquipment(inventory, e.OtherEquipment, false)
+
+	notes := c.node("notelist")
+	Traverse(func(n *Note) bool {
+		item := notes.item()
+		item.str("text", n.String())
+		item.str("page", n.PageRef)
+		return false
+	}, true, true, e.Notes...)
+	return root
+}
+
+func fantasyGroundsPool(e *Entity, attrs *FantasyGroundsNode, attrID, name, prefix string) {
+	attr := e.ResolveAttribute(attrID)
+	if attr == nil {
+		return
+	}
+	attrs.num(name, attr.Maximum().String())
+	attrs.num(name+"_points", attr.PointCost().String())
+	attrs.num(prefix, attr.Current().String())
+}
+
+func fantasyGroundsEquipment(inventory *FantasyGroundsNode, list []*Equipment, carried bool) {
+	Traverse(func(eqp *Equipment) bool {
+		item := inventory.item()
+		item.str("name", eqp.String())
+		item.num("count", eqp.Quantity.String())
+		item.num("cost", eqp.AdjustedValue().String())
+		item.num("weight", fxp.Int(eqp.AdjustedWeight(false, fxp.Pound)).String())
+		item.str("techlevel", eqp.TechLevel)
+		var location string
+		switch {
+		case !carried:
+			location = "Other"
+		case eqp.Equipped:
+			location = "Equipped"
+		default:
+			location = "Carried"
+		}
+		item.str("location", location)
+		item.str("text", joinNotes(eqp.ModifierNotes(), eqp.Notes()))
+		item.str("page", eqp.PageRef)
+		return false
+	}, false, false, list...)
+}
+
+// joinCost joins a casting cost and maintenance cost into the single field Fantasy Grounds uses for them.
+func joinCost(cost, maintain string) string {
+	if maintain == "" {
+		return cost
+	}
+	return cost + "/" + maintain
+}
+
+func (n *FantasyGroundsNode) node(name string) *FantasyGroundsNode {
+	child := &FantasyGroundsNode{XMLName: xml.Name{Local: name}}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// item adds a new entry to a list node.
+func (n *FantasyGroundsNode) item() *FantasyGroundsNode {
+	return n.node(fmt.Sprintf("id-%05d", len(n.Children)+1))
+}
+
+func (n *FantasyGroundsNode) str(name, value string) {
+	if value != "" {
+		child := n.node(name)
+		child.Type = "string"
+		child.Value = value
+	}
+}
+
+func (n *FantasyGroundsNode) num(name, value string) {
+	child := n.node(name)
+	child.Type = "number"
+	child.Value = value
+}
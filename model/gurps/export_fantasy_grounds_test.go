@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFantasyGroundsCharacter(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Test Subject"
+	sk := NewSkill(e, nil, false)
+	sk.Name = "Stealth"
+	e.Skills = []*Skill{sk}
+
+	data, err := xml.Marshal(NewFantasyGroundsCharacter(e))
+	check.NoError(t, err)
+	var root FantasyGroundsNode
+	check.NoError(t, xml.Unmarshal(data, &root))
+	c := fantasyGroundsChild(&root, "character")
+	check.Equal(t, "Test Subject", fantasyGroundsChild(c, "name").Value)
+	strength := fantasyGroundsChild(fantasyGroundsChild(c, "attributes"), "strength")
+	check.Equal(t, "number", strength.Type)
+	check.Equal(t, "10", strength.Value)
+	skill := fantasyGroundsChild(fantasyGroundsChild(fantasyGroundsChild(c, "abilities"), "skilllist"), "id-00001")
+	check.Equal(t, "Stealth", fantasyGroundsChild(skill, "name").Value)
+	locations := fantasyGroundsChild(fantasyGroundsChild(c, "combat"), "hitlocations")
+	check.Equal(t, len(e.SheetSettings.BodyType.Locations), len(locations.Children))
+	check.NotNil(t, fantasyGroundsChild(locations.Children[0], "dr"))
+}
+
+func fantasyGroundsChild(n *FantasyGroundsNode, name string) *FantasyGroundsNode {
+	for _, child := range n.Children {
+		if child.XMLName.Local == name {
+			return child
+		}
+	}
+	return nil
+}
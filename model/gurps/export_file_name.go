@@ -0,0 +1,68 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// DefaultFileNamePattern is the file name pattern that reproduces the name of the source file.
+const DefaultFileNamePattern = "{file}"
+
+// FileNamePatternHelp describes the substitutions available in file name patterns.
+const FileNamePatternHelp = "{file}, {name}, {player}, {title}, {points}, {tl} and {date}"
+
+// ExpandFileNamePattern returns a file name, without extension, built from the pattern. The placeholders described by
+// FileNamePatternHelp are replaced with values from the entity, the base name of sourcePath and the date of when, and
+// characters that aren't permitted in file names are replaced. An empty pattern is treated as DefaultFileNamePattern.
+func ExpandFileNamePattern(pattern string, e *Entity, sourcePath string, when time.Time) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = DefaultFileNamePattern
+	}
+	file := fs.BaseName(sourcePath)
+	var name, player, title, points, tl string
+	if e != nil {
+		name = e.Profile.Name
+		player = e.Profile.PlayerName
+		title = e.Profile.Title
+		points = e.TotalPoints.String()
+		tl = e.Profile.TechLevel
+	}
+	if name == "" {
+		name = file
+	}
+	result := strings.NewReplacer(
+		"{file}", file,
+		"{name}", name,
+		"{player}", player,
+		"{title}", title,
+		"{points}", points,
+		"{tl}", tl,
+		"{date}", when.Format(time.DateOnly),
+	).Replace(pattern)
+	result = strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		default:
+			if r < ' ' {
+				return '_'
+			}
+			return r
+		}
+	}, result))
+	if result == "" || strings.Trim(result, ".") == "" {
+		return file
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestExpandFileNamePattern(t *testing.T) {
+	when := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	e := NewEntity()
+	e.Profile.Name = "Aria: the Bold"
+	e.Profile.PlayerName = "GM"
+	e.Profile.TechLevel = "3"
+	e.TotalPoints = fxp.From(150)
+	check.Equal(t, "aria", ExpandFileNamePattern("", e, "/tmp/aria.gcs", when))
+	check.Equal(t, "Aria_ the Bold - 150pts - 2024-03-05",
+		ExpandFileNamePattern("{name} - {points}pts - {date}", e, "/tmp/aria.gcs", when))
+	check.Equal(t, "GM aria TL3", ExpandFileNamePattern("{player} {file} TL{tl}", e, "/tmp/aria.gcs", when))
+	check.Equal(t, "Knight", ExpandFileNamePattern("{name}", nil, "Knight", when), "no entity uses the file name")
+	check.Equal(t, "Knight", ExpandFileNamePattern(" {title} ", nil, "Knight", when), "empty results fall back")
+	e.Profile.Name = ""
+	check.Equal(t, "aria-sheet", ExpandFileNamePattern("{name}-sheet", e, "/tmp/aria.gcs", when))
+}
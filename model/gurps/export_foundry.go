@@ -0,0 +1,413 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+)
+
+// FoundryActorExt is the extension used for Foundry VTT actor exports.
+const FoundryActorExt = ".json"
+
+// FoundryActor holds a character in the actor format used by the GURPS Game Aid system for Foundry VTT. Lists are
+// stored the way that system stores them: as maps keyed by a zero-padded index, with children held in "contains".
+type FoundryActor struct {
+	Name   string             `json:"name"`
+	Type   string             `json:"type"`
+	System FoundryActorSystem `json:"system"`
+}
+
+// FoundryActorSystem holds the system-specific data of a FoundryActor.
+type FoundryActorSystem struct {
+	Attributes   map[string]*FoundryAttribute `json:"attributes"`
+	HP           FoundryPool                  `json:"HP"`
+	FP           FoundryPool                  `json:"FP"`
+	BasicSpeed   FoundryValue                 `json:"basicspeed"`
+	BasicMove    FoundryValue                 `json:"basicmove"`
+	CurrentMove  int                          `json:"currentmove"`
+	CurrentDodge int                          `json:"currentdodge"`
+	Traits       FoundryProfile               `json:"traits"`
+	Ads          map[string]*FoundryTrait     `json:"ads"`
+	Skills       map[string]*FoundrySkill     `json:"skills"`
+	Spells       map[string]*FoundrySpell     `json:"spells"`
+	Equipment    FoundryEquipmentLists        `json:"equipment"`
+	Melee        map[string]*FoundryMelee     `json:"melee"`
+	Ranged       map[string]*FoundryRanged    `json:"ranged"`
+	Notes        map[string]*FoundryNote      `json:"notes"`
+}
+
+// FoundryAttribute holds a primary attribute of a FoundryActor.
+type FoundryAttribute struct {
+	Value  int     `json:"value"`
+	Import int     `json:"import"`
+	Points fxp.Int `json:"points"`
+}
+
+// FoundryPool holds a pool, such as HP or FP, of a FoundryActor.
+type FoundryPool struct {
+	Value  int     `json:"value"`
+	Max    int     `json:"max"`
+	Points fxp.Int `json:"points"`
+}
+
+// FoundryValue holds a simple value of a FoundryActor.
+type FoundryValue struct {
+	Value  fxp.Int `json:"value"`
+	Points fxp.Int `json:"points"`
+}
+
+// FoundryProfile holds the descriptive details of a FoundryActor.
+type FoundryProfile struct {
+	Title      string `json:"title,omitempty"`
+	Player     string `json:"player,omitempty"`
+	Gender     string `json:"gender,omitempty"`
+	Age        string `json:"age,omitempty"`
+	Birthday   string `json:"birthday,omitempty"`
+	Religion   string `json:"religion,omitempty"`
+	Height     string `json:"height,omitempty"`
+	Weight     string `json:"weight,omitempty"`
+	SizeMod    int    `json:"sizemod"`
+	Hair       string `json:"hair,omitempty"`
+	Eyes       string `json:"eyes,omitempty"`
+	Skin       string `json:"skin,omitempty"`
+	Hand       string `json:"hand,omitempty"`
+	TechLevel  string `json:"techlevel,omitempty"`
+	TotalPts   string `json:"totalpoints"`
+	UnspentPts string `json:"unspentpoints"`
+}
+
+// FoundryTrait holds a trait of a FoundryActor.
+type FoundryTrait struct {
+	Name     string                   `json:"name"`
+	Points   fxp.Int                  `json:"points"`
+	Notes    string                   `json:"notes,omitempty"`
+	PageRef  string                   `json:"pageref,omitempty"`
+	Contains map[string]*FoundryTrait `json:"contains,omitempty"`
+}
+
+// FoundrySkill holds a skill or technique of a FoundryActor.
+type FoundrySkill struct {
+	Name          string                   `json:"name"`
+	Type          string                   `json:"type,omitempty"`
+	Level         int                      `json:"level"`
+	RelativeLevel string                   `json:"relativelevel,omitempty"`
+	Points        fxp.Int                  `json:"points"`
+	Notes         string                   `json:"notes,omitempty"`
+	PageRef       string                   `json:"pageref,omitempty"`
+	Contains      map[string]*FoundrySkill `json:"contains,omitempty"`
+}
+
+// FoundrySpell holds a spell of a FoundryActor.
+type FoundrySpell struct {
+	Name          string                   `json:"name"`
+	Class         string                   `json:"class,omitempty"`
+	College       string                   `json:"college,omitempty"`
+	Cost          string                   `json:"cost,omitempty"`
+	Maintain      string                   `json:"maintain,omitempty"`
+	Duration      string                   `json:"duration,omitempty"`
+	CastTime      string                   `json:"casttime,omitempty"`
+	Resist        string                   `json:"resist,omitempty"`
+	Difficulty    string                   `json:"difficulty,omitempty"`
+	Level         int                      `json:"level"`
+	RelativeLevel string                   `json:"relativelevel,omitempty"`
+	Points        fxp.Int                  `json:"points"`
+	Notes         string                   `json:"notes,omitempty"`
+	PageRef       string                   `json:"pageref,omitempty"`
+	Contains      map[string]*FoundrySpell `json:"contains,omitempty"`
+}
+
+// FoundryEquipmentLists holds the equipment lists of a FoundryActor.
+type FoundryEquipmentLists struct {
+	Carried map[string]*FoundryEquipment `json:"carried"`
+	Other   map[string]*FoundryEquipment `json:"other"`
+}
+
+// FoundryEquipment holds a piece of equipment of a FoundryActor. Weights are in pounds.
+type FoundryEquipment struct {
+	Name      string                       `json:"name"`
+	Count     fxp.Int                      `json:"count"`
+	Cost      fxp.Int                      `json:"cost"`
+	Weight    fxp.Int                      `json:"weight"`
+	CostSum   fxp.Int                      `json:"costsum"`
+	WeightSum fxp.Int                      `json:"weightsum"`
+	TechLevel string                       `json:"techlevel,omitempty"`
+	Equipped  bool                         `json:"equipped"`
+	Carried   bool                         `json:"carried"`
+	Uses      int                          `json:"uses"`
+	MaxUses   int                          `json:"maxuses"`
+	Notes     string                       `json:"notes,omitempty"`
+	PageRef   string                       `json:"pageref,omitempty"`
+	Contains  map[string]*FoundryEquipment `json:"contains,omitempty"`
+}
+
+// FoundryMelee holds a melee weapon usage of a FoundryActor.
+type FoundryMelee struct {
+	Name   string `json:"name"`
+	Mode   string `json:"mode,omitempty"`
+	Level  int    `json:"level"`
+	Damage string `json:"damage"`
+	Reach  string `json:"reach,omitempty"`
+	Parry  string `json:"parry,omitempty"`
+	Block  string `json:"block,omitempty"`
+	ST     string `json:"st,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// FoundryRanged holds a ranged weapon usage of a FoundryActor.
+type FoundryRanged struct {
+	Name   string `json:"name"`
+	Mode   string `json:"mode,omitempty"`
+	Level  int    `json:"level"`
+	Damage string `json:"damage"`
+	Acc    string `json:"acc,omitempty"`
+	Range  string `json:"range,omitempty"`
+	RoF    string `json:"rof,omitempty"`
+	Shots  string `json:"shots,omitempty"`
+	Bulk   string `json:"bulk,omitempty"`
+	Rcl    string `json:"rcl,omitempty"`
+	ST     string `json:"st,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// FoundryNote holds a note of a FoundryActor.
+type FoundryNote struct {
+	Notes    string                  `json:"notes"`
+	PageRef  string                  `json:"pageref,omitempty"`
+	Contains map[string]*FoundryNote `json:"contains,omitempty"`
+}
+
+// ExportFoundryActor writes the entity to filePath as a Foundry VTT actor.
+func ExportFoundryActor(e *Entity, filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, NewFoundryActor(e))
+}
+
+// NewFoundryActor creates a new FoundryActor from the entity.
+func NewFoundryActor(e *Entity) *FoundryActor {
+	e.Recalculate()
+	enc := e.EncumbranceLevel(false)
+	a := &FoundryActor{
+		Name: e.Profile.Name,
+		Type: "character",
+		System: FoundryActorSystem{
+			Attributes:   make(map[string]*FoundryAttribute),
+			HP:           foundryPool(e, "hp"),
+			FP:           foundryPool(e, "fp"),
+			BasicSpeed:   foundryValue(e, BasicSpeedID),
+			BasicMove:    foundryValue(e, BasicMoveID),
+			CurrentMove:  e.Move(enc),
+			CurrentDodge: e.Dodge(enc),
+			Traits: FoundryProfile{
+				Title:      e.Profile.Title,
+				Player:     e.Profile.PlayerName,
+				Gender:     e.Profile.Gender,
+				Age:        e.Profile.Age,
+				Birthday:   e.Profile.Birthday,
+				Religion:   e.Profile.Religion,
+				Height:     e.SheetSettings.DefaultLengthUnits.Format(e.Profile.Height),
+				Weight:     e.SheetSettings.DefaultWeightUnits.Format(e.Profile.Weight),
+				SizeMod:    e.Profile.AdjustedSizeModifier(),
+				Hair:       e.Profile.Hair,
+				Eyes:       e.Profile.Eyes,
+				Skin:       e.Profile.Skin,
+				Hand:       e.Profile.Handedness,
+				TechLevel:  e.Profile.TechLevel,
+				TotalPts:   e.TotalPoints.String(),
+				UnspentPts: e.UnspentPoints().String(),
+			},
+			Ads: foundryMap(e.Traits, true, func(t *Trait, contains map[string]*FoundryTrait) *FoundryTrait {
+				return &FoundryTrait{
+					Name:     t.String(),
+					Points:   t.AdjustedPoints(),
+					Notes:    joinNotes(t.ModifierNotes(), t.Notes()),
+					PageRef:  t.PageRef,
+					Contains: contains,
+				}
+			}),
+			Skills: foundryMap(e.Skills, true, func(s *Skill, contains map[string]*FoundrySkill) *FoundrySkill {
+				skill := &FoundrySkill{
+					Name:     s.String(),
+					Points:   s.AdjustedPoints(nil),
+					Notes:    joinNotes(s.ModifierNotes(), s.Notes()),
+					PageRef:  s.PageRef,
+					Contains: contains,
+				}
+				if !s.Container() {
+					skill.Type = s.Difficulty.Description(e)
+					skill.Level = foundryLevel(s.CalculateLevel(nil).Level)
+					skill.RelativeLevel = s.RelativeLevel()
+				}
+				return skill
+			}),
+			Spells: foundryMap(e.Spells, true, func(s *Spell, contains map[string]*FoundrySpell) *FoundrySpell {
+				spell := &FoundrySpell{
+					Name:     s.String(),
+					Points:   s.AdjustedPoints(nil),
+					Notes:    s.Notes(),
+					PageRef:  s.PageRef,
+					Contains: contains,
+				}
+				if !s.Container() {
+					spell.Class = s.ClassWithReplacements()
+					spell.College = strings.Join(s.CollegeWithReplacements(), ", ")
+					spell.Cost = s.CastingCostWithReplacements()
+					spell.Maintain = s.MaintenanceCostWithReplacements()
+					spell.Duration = s.DurationWithReplacements()
+					spell.CastTime = s.CastingTimeWithReplacements()
+					spell.Resist = s.ResistWithReplacements()
+					spell.Difficulty = s.Difficulty.Description(e)
+					spell.Level = foundryLevel(s.CalculateLevel().Level)
+					spell.RelativeLevel = s.RelativeLevel()
+				}
+				return spell
+			}),
+			Equipment: FoundryEquipmentLists{
+				Carried: foundryEquipment(e.CarriedEquipment, true),
+				Other:   foundryEquipment(e.OtherEquipment, false),
+			},
+			Melee:  make(map[string]*FoundryMelee),
+			Ranged: make(map[string]*FoundryRanged),
+			Notes: foundryMap(e.Notes, true, func(n *Note, contains map[string]*FoundryNote) *FoundryNote {
+				return &FoundryNote{
+					Notes:    n.String(),
+					PageRef:  n.PageRef,
+					Contains: contains,
+				}
+			}),
+		},
+	}
+	for _, attr := range e.Attributes.List() {
+		if def := attr.AttributeDef(); def != nil && def.Primary() {
+			value := fxp.As[int](attr.Maximum())
+			a.System.Attributes[strings.ToUpper(def.DefID)] = &FoundryAttribute{
+				Value:  value,
+				Import: value,
+				Points: attr.PointCost(),
+			}
+		}
+	}
+	for _, w := range e.EquippedWeapons(true) {
+		a.System.Melee[foundryKey(len(a.System.Melee))] = &FoundryMelee{
+			Name:   w.String(),
+			Mode:   w.UsageWithReplacements(),
+			Level:  foundryLevel(w.SkillLevel(nil)),
+			Damage: w.Damage.ResolvedDamage(nil),
+			Reach:  w.Reach.Resolve(w, nil).String(),
+			Parry:  w.Parry.Resolve(w, nil).String(),
+			Block:  w.Block.Resolve(w, nil).String(),
+			ST:     w.Strength.Resolve(w, nil).String(),
+			Notes:  w.Notes(),
+		}
+	}
+	for _, w := range e.EquippedWeapons(false) {
+		a.System.Ranged[foundryKey(len(a.System.Ranged))] = &FoundryRanged{
+			Name:   w.String(),
+			Mode:   w.UsageWithReplacements(),
+			Level:  foundryLevel(w.SkillLevel(nil)),
+			Damage: w.Damage.ResolvedDamage(nil),
+			Acc:    w.Accuracy.Resolve(w, nil).String(),
+			Range:  w.Range.Resolve(w, nil).String(true),
+			RoF:    w.RateOfFire.Resolve(w, nil).String(),
+			Shots:  w.Shots.Resolve(w, nil).String(),
+			Bulk:   w.Bulk.Resolve(w, nil).String(),
+			Rcl:    w.Recoil.Resolve(w, nil).String(),
+			ST:     w.Strength.Resolve(w, nil).String(),
+			Notes:  w.Notes(),
+		}
+	}
+	return a
+}
+
+func foundryEquipment(list []*Equipment, carried bool) map[string]*FoundryEquipment {
+	return foundryMap(list, false, func(eqp *Equipment, contains map[string]*FoundryEquipment) *FoundryEquipment {
+		return &FoundryEquipment{
+			Name:      eqp.String(),
+			Count:     eqp.Quantity,
+			Cost:      eqp.AdjustedValue(),
+			Weight:    fxp.Int(eqp.AdjustedWeight(false, fxp.Pound)),
+			CostSum:   eqp.ExtendedValue(),
+			WeightSum: fxp.Int(eqp.ExtendedWeight(false, fxp.Pound)),
+			TechLevel: eqp.TechLevel,
+			Equipped:  carried && eqp.Equipped,
+			Carried:   carried,
+			Uses:      eqp.Uses,
+			MaxUses:   eqp.MaxUses,
+			Notes:     joinNotes(eqp.ModifierNotes(), eqp.Notes()),
+			PageRef:   eqp.PageRef,
+			Contains:  contains,
+		}
+	})
+}
+
+// foundryMap converts a list of nodes, and their children, into the keyed maps the Foundry VTT format uses.
+func foundryMap[T NodeTypes, R any](list []T, onlyEnabled bool, f func(node T, contains map[string]R) R) map[string]R {
+	m := make(map[string]R, len(list))
+	for _, one := range list {
+		node := AsNode(one)
+		if onlyEnabled && !node.Enabled() {
+			continue
+		}
+		var contains map[string]R
+		if node.HasChildren() {
+			contains = foundryMap(node.NodeChildren(), onlyEnabled, f)
+		}
+		m[foundryKey(len(m))] = f(one, contains)
+	}
+	return m
+}
+
+func foundryKey(index int) string {
+	return fmt.Sprintf("%05d", index)
+}
+
+func foundryPool(e *Entity, attrID string) FoundryPool {
+	attr := e.ResolveAttribute(attrID)
+	if attr == nil {
+		return FoundryPool{}
+	}
+	return FoundryPool{
+		Value:  fxp.As[int](attr.Current()),
+		Max:    fxp.As[int](attr.Maximum()),
+		Points: attr.PointCost(),
+	}
+}
+
+func foundryValue(e *Entity, attrID string) FoundryValue {
+	attr := e.ResolveAttribute(attrID)
+	if attr == nil {
+		return FoundryValue{}
+	}
+	return FoundryValue{
+		Value:  attr.Maximum(),
+		Points: attr.PointCost(),
+	}
+}
+
+func foundryLevel(level fxp.Int) int {
+	return max(fxp.As[int](level), 0)
+}
+
+// joinNotes joins the non-empty parts with semicolons.
+func joinNotes(parts ...string) string {
+	var buffer strings.Builder
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			if buffer.Len() != 0 {
+				buffer.WriteString("; ")
+			}
+			buffer.WriteString(part)
+		}
+	}
+	return buffer.String()
+}
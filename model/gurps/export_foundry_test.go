@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFoundryActor(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Test Subject"
+	group := NewTrait(e, nil, true)
+	group.Name = "Group"
+	child := NewTrait(e, group, false)
+	child.Name = "Child"
+	group.Children = []*Trait{child}
+	disabled := NewTrait(e, nil, false)
+	disabled.Name = "Disabled"
+	disabled.Disabled = true
+	e.Traits = []*Trait{group, disabled}
+	sk := NewSkill(e, nil, false)
+	sk.Name = "Stealth"
+	e.Skills = []*Skill{sk}
+
+	a := NewFoundryActor(e)
+	check.Equal(t, "Test Subject", a.Name)
+	check.Equal(t, "character", a.Type)
+	check.Equal(t, 1, len(a.System.Ads))
+	check.Equal(t, "Group", a.System.Ads["00000"].Name)
+	check.Equal(t, "Child", a.System.Ads["00000"].Contains["00000"].Name)
+	check.Equal(t, "Stealth", a.System.Skills["00000"].Name)
+	check.Equal(t, foundryLevel(sk.CalculateLevel(nil).Level), a.System.Skills["00000"].Level)
+	check.Equal(t, 10, a.System.Attributes["ST"].Value)
+	check.Equal(t, a.System.HP.Max, a.System.HP.Value)
+}
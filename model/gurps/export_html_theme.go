@@ -0,0 +1,198 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/txt"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// OutputThemesDirName is the name of the directory within a library that holds the CSS themes that may be applied to
+// HTML exports.
+const OutputThemesDirName = "Output Themes"
+
+// OutputThemeExt is the extension used by CSS themes.
+const OutputThemeExt = ".css"
+
+var (
+	styleSheetLinkRegex = regexp.MustCompile(`(?i)<link\b[^>]*\brel\s*=\s*["']?stylesheet["']?[^>]*>`)
+	hrefRegex           = regexp.MustCompile(`(?i)\bhref\s*=\s*["']([^"']+)["']`)
+	cssURLRegex         = regexp.MustCompile(`url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+	imgSrcRegex         = regexp.MustCompile(`(?i)(<img\b[^>]*\bsrc\s*=\s*)["']([^"']+)["']`)
+	headCloseRegex      = regexp.MustCompile(`(?i)</head\s*>`)
+)
+
+// HTMLExportOptions holds the options that apply when exporting with an HTML template.
+type HTMLExportOptions struct {
+	// ThemePath is the path to a CSS file whose rules are added to the output. May be empty.
+	ThemePath string
+	// SelfContained causes local style sheets, fonts and images referenced by the output to be embedded within it, so
+	// that the result is a single file that can be moved or shared on its own.
+	SelfContained bool
+}
+
+// OutputThemes returns the paths to the CSS themes found in the libraries, sorted by name.
+func OutputThemes() []string {
+	var list []string
+	for _, lib := range GlobalSettings().Libraries().List() {
+		dir := filepath.Join(lib.Path(), OutputThemesDirName)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs.Log(err, "dir", dir)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && !strings.HasPrefix(name, ".") && strings.EqualFold(filepath.Ext(name), OutputThemeExt) {
+				list = append(list, filepath.Join(dir, name))
+			}
+		}
+	}
+	txt.SortStringsNaturalAscending(list)
+	return list
+}
+
+// themedHTMLExporter wraps an HTML template, applying the HTMLExportOptions to its output.
+type themedHTMLExporter struct {
+	tmpl    exporter
+	baseDir string
+	options HTMLExportOptions
+}
+
+func (e *themedHTMLExporter) Execute(w io.Writer, data any) error {
+	var buffer bytes.Buffer
+	if err := e.tmpl.Execute(&buffer, data); err != nil {
+		return err
+	}
+	out := buffer.String()
+	if e.options.SelfContained {
+		out = styleSheetLinkRegex.ReplaceAllStringFunc(out, func(link string) string {
+			m := hrefRegex.FindStringSubmatch(link)
+			if m == nil || !isLocalReference(m[1]) {
+				return link
+			}
+			cssPath := resolveReference(e.baseDir, m[1])
+			css, err := os.ReadFile(cssPath)
+			if err != nil {
+				errs.Log(errs.Wrap(err), "path", cssPath)
+				return link
+			}
+			return "<style>\n" + embedCSSURLs(string(css), filepath.Dir(cssPath)) + "\n</style>"
+		})
+		out = imgSrcRegex.ReplaceAllStringFunc(out, func(img string) string {
+			m := imgSrcRegex.FindStringSubmatch(img)
+			if !isLocalReference(m[2]) {
+				return img
+			}
+			p := resolveReference(e.baseDir, m[2])
+			data, err := os.ReadFile(p)
+			if err != nil {
+				errs.Log(errs.Wrap(err), "path", p)
+				return img
+			}
+			return m[1] + `"` + dataURL(p, data) + `"`
+		})
+		out = embedCSSURLs(out, e.baseDir)
+	}
+	if e.options.ThemePath != "" {
+		css, err := os.ReadFile(e.options.ThemePath)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		theme := string(css)
+		if e.options.SelfContained {
+			theme = embedCSSURLs(theme, filepath.Dir(e.options.ThemePath))
+		}
+		style := "<style>\n" + theme + "\n</style>\n"
+		// The theme is placed at the end of the head so that its rules take precedence over the template's own.
+		if loc := headCloseRegex.FindStringIndex(out); loc != nil {
+			out = out[:loc[0]] + style + out[loc[0]:]
+		} else {
+			out = style + out
+		}
+	}
+	_, err := io.WriteString(w, out)
+	return errs.Wrap(err)
+}
+
+// embedCSSURLs replaces the url() references to local files within the CSS with data URLs holding their contents.
+// References that can't be read are left alone.
+func embedCSSURLs(css, baseDir string) string {
+	return cssURLRegex.ReplaceAllStringFunc(css, func(ref string) string {
+		m := cssURLRegex.FindStringSubmatch(ref)
+		if !isLocalReference(m[1]) {
+			return ref
+		}
+		p := resolveReference(baseDir, m[1])
+		data, err := os.ReadFile(p)
+		if err != nil {
+			errs.Log(errs.Wrap(err), "path", p)
+			return ref
+		}
+		return `url("` + dataURL(p, data) + `")`
+	})
+}
+
+func isLocalReference(ref string) bool {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	return ref != "" && !strings.HasPrefix(ref, "data:") && !strings.HasPrefix(ref, "#") && !strings.Contains(ref, "://") &&
+		!strings.HasPrefix(ref, "//")
+}
+
+func resolveReference(baseDir, ref string) string {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "file:")
+	if i := strings.IndexAny(ref, "?#"); i != -1 {
+		ref = ref[:i]
+	}
+	ref = filepath.FromSlash(ref)
+	if filepath.IsAbs(ref) {
+		return ref
+	}
+	return filepath.Join(baseDir, ref)
+}
+
+func dataURL(p string, data []byte) string {
+	contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(p)))
+	if contentType == "" {
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".woff":
+			contentType = "font/woff"
+		case ".woff2":
+			contentType = "font/woff2"
+		case ".ttf":
+			contentType = "font/ttf"
+		case ".otf":
+			contentType = "font/otf"
+		default:
+			contentType = http.DetectContentType(data)
+		}
+	}
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// OutputThemeName returns the name to show for the theme at the given path.
+func OutputThemeName(themePath string) string {
+	return xfs.BaseName(themePath)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/base64"
+	htmltmpl "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestThemedHTMLExporter(t *testing.T) {
+	dir := t.TempDir()
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "base.css"), []byte(`body { background: url("paper.png"); }`), 0o600))
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "paper.png"), []byte("PNG"), 0o600))
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "theme.css"), []byte(`h1 { color: red; }`), 0o600))
+	tmpl, err := htmltmpl.New("").Parse(`<html><head><link rel="stylesheet" href="base.css"></head><body><h1>{{.}}</h1></body></html>`)
+	check.NoError(t, err)
+
+	var buffer strings.Builder
+	e := &themedHTMLExporter{
+		tmpl:    tmpl,
+		baseDir: dir,
+		options: HTMLExportOptions{ThemePath: filepath.Join(dir, "theme.css")},
+	}
+	check.NoError(t, e.Execute(&buffer, "Name"))
+	check.Equal(t, `<html><head><link rel="stylesheet" href="base.css"><style>
+h1 { color: red; }
+</style>
+</head><body><h1>Name</h1></body></html>`, buffer.String())
+
+	buffer.Reset()
+	e.options.SelfContained = true
+	check.NoError(t, e.Execute(&buffer, "Name"))
+	out := buffer.String()
+	check.False(t, strings.Contains(out, "<link"))
+	check.True(t, strings.Contains(out, `url("data:image/png;base64,`+base64.StdEncoding.EncodeToString([]byte("PNG"))+`")`))
+	check.True(t, strings.Index(out, "background") < strings.Index(out, "color: red"))
+}
+
+func TestEmbedCSSURLsSkipsRemote(t *testing.T) {
+	css := `a { background: url(https://example.com/x.png); } b { background: url(data:image/png;base64,AA==); }`
+	check.Equal(t, css, embedCSSURLs(css, t.TempDir()))
+}
@@ -361,6 +361,8 @@ func (ex *legacyExporter) emitKey(key string) error {
 		ex.writeEncodedText(ex.entity.Ancestry().Name)
 	case "BODY_TYPE":
 		ex.writeEncodedText(ex.entity.SheetSettings.BodyType.Name)
+	case "BODY_TYPE_ROLL":
+		ex.writeEncodedText(ex.entity.SheetSettings.BodyType.TableRoll().String())
 	case "ENCUMBRANCE_LOOP_COUNT":
 		ex.writeEncodedText(strconv.Itoa(len(encumbrance.Levels)))
 	case "ENCUMBRANCE_LOOP_START":
@@ -510,6 +512,10 @@ func (ex *legacyExporter) emitKey(key string) error {
 		ex.writeEncodedText(strconv.Itoa(count))
 	case "POINT_POOL_LOOP_START":
 		ex.processPointPoolLoop(ex.extractUpToMarker("POINT_POOL_LOOP_END"))
+	case "VARIABLES_LOOP_COUNT":
+		ex.writeEncodedText(strconv.Itoa(len(ex.entity.Variables)))
+	case "VARIABLES_LOOP_START":
+		ex.processVariablesLoop(ex.extractUpToMarker("VARIABLES_LOOP_END"))
 	case "CONTINUE_ID", "CAMPAIGN", "OPTIONS_CODE":
 		// No-op
 	default:
@@ -745,6 +751,8 @@ func (ex *legacyExporter) processHitLocationLoop(buffer []byte) {
 				ex.writeEncodedText(strconv.Itoa(i))
 			case "ROLL":
 				ex.writeEncodedText(location.RollRange)
+			case "CHANCE":
+				ex.writeEncodedText(location.RollChanceText())
 			case "WHERE":
 				ex.writeEncodedText(location.TableName)
 			case "PENALTY":
@@ -1207,6 +1215,26 @@ func (ex *legacyExporter) processAttributesLoop(buffer []byte, primary bool) {
 	}
 }
 
+func (ex *legacyExporter) processVariablesLoop(buffer []byte) {
+	for i, one := range ex.entity.Variables {
+		ex.processBuffer(buffer, func(key string, _ []byte, index int) int {
+			switch key {
+			case idExportKey:
+				ex.writeEncodedText(strconv.Itoa(i))
+			case nameExportKey:
+				ex.writeEncodedText(one.Name)
+			case "EXPRESSION":
+				ex.writeEncodedText(one.Expression)
+			case "VALUE":
+				ex.writeEncodedText(ex.entity.VariableValue(one.Name).String())
+			default:
+				ex.unidentifiedKey(key)
+			}
+			return index
+		})
+	}
+}
+
 func (ex *legacyExporter) processPointPoolLoop(buffer []byte) {
 	for _, def := range ex.entity.SheetSettings.Attributes.List(true) {
 		if def.Type == attribute.Pool || def.Type == attribute.PoolRef {
@@ -1366,6 +1394,10 @@ func (ex *legacyExporter) processWeaponKeys(key string, currentID int, w *Weapon
 		ex.writeEncodedText(w.Damage.ResolvedDamage(nil))
 	case "UNMODIFIED_DAMAGE":
 		ex.writeEncodedText(w.Damage.String())
+	case "DAMAGE_BREAKDOWN":
+		if breakdown := w.Damage.Breakdown(); breakdown != nil {
+			ex.writeEncodedText(strings.ReplaceAll(breakdown.String(), "\n", "; "))
+		}
 	case "STRENGTH":
 		ex.writeEncodedText(w.Strength.Resolve(w, nil).String())
 	case "WEAPON_STRENGTH":
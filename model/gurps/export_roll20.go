@@ -0,0 +1,199 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/collection/dict"
+)
+
+// Roll20CharacterExt is the extension used for Roll20 character exports.
+const Roll20CharacterExt = ".json"
+
+// roll20AttributeNames maps attribute IDs to the names the Roll20 GURPS character sheet uses for them. Attributes not
+// listed here are exported using their ID.
+var roll20AttributeNames = map[string]string{
+	StrengthID:   "strength",
+	DexterityID:  "dexterity",
+	"iq":         "intelligence",
+	"ht":         "health",
+	"will":       "willpower",
+	"per":        "perception",
+	"hp":         "hit_points",
+	"fp":         "fatigue_points",
+	BasicSpeedID: "basic_speed",
+	BasicMoveID:  "basic_move",
+}
+
+// Roll20Character holds a character in the form accepted by the Roll20 API for creating a character with the GURPS
+// character sheet. List data is stored in the sheet's repeating sections, using attribute names of the form
+// repeating_<section>_<row id>_<field>.
+type Roll20Character struct {
+	Name       string            `json:"name"`
+	Bio        string            `json:"bio,omitempty"`
+	Attributes []Roll20Attribute `json:"attributes"`
+}
+
+// Roll20Attribute holds a single attribute of a Roll20Character.
+type Roll20Attribute struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Max     string `json:"max,omitempty"`
+}
+
+// ExportRoll20Character writes the entity to filePath as a Roll20 character.
+func ExportRoll20Character(e *Entity, filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, NewRoll20Character(e))
+}
+
+// NewRoll20Character creates a new Roll20Character from the entity.
+func NewRoll20Character(e *Entity) *Roll20Character {
+	e.Recalculate()
+	c := &Roll20Character{
+		Name: e.Profile.Name,
+		Bio:  e.Profile.Title,
+	}
+	for _, attr := range e.Attributes.List() {
+		def := attr.AttributeDef()
+		if def == nil || def.IsSeparator() {
+			continue
+		}
+		name, ok := roll20AttributeNames[def.DefID]
+		if !ok {
+			name = def.DefID
+		}
+		if def.Pool() {
+			c.add(name, attr.Current().String(), attr.Maximum().String())
+		} else {
+			c.add(name, attr.Maximum().String(), "")
+		}
+		c.add(name+"_points", attr.PointCost().String(), "")
+	}
+	enc := e.EncumbranceLevel(false)
+	c.add("dodge", strconv.Itoa(e.Dodge(enc)), "")
+	c.add("move", strconv.Itoa(e.Move(enc)), "")
+	c.add("size_modifier", strconv.Itoa(e.Profile.AdjustedSizeModifier()), "")
+	c.add("player_name", e.Profile.PlayerName, "")
+	c.add("total_points", e.TotalPoints.String(), "")
+	c.add("unspent_points", e.UnspentPoints().String(), "")
+	row := 0
+	Traverse(func(t *Trait) bool {
+		c.addRow("traits", row, map[string]string{
+			"name":   t.String(),
+			"points": t.AdjustedPoints().String(),
+			"notes":  joinNotes(t.ModifierNotes(), t.Notes()),
+			"ref":    t.PageRef,
+		})
+		row++
+		return false
+	}, true, true, e.Traits...)
+	row = 0
+	Traverse(func(s *Skill) bool {
+		c.addRow("skills", row, map[string]string{
+			"name":       s.String(),
+			"difficulty": s.Difficulty.Description(e),
+			"level":      roll20Level(s.CalculateLevel(nil).Level),
+			"relative":   s.RelativeLevel(),
+			"points":     s.AdjustedPoints(nil).String(),
+			"notes":      joinNotes(s.ModifierNotes(), s.Notes()),
+			"ref":        s.PageRef,
+		})
+		row++
+		return false
+	}, true, true, e.Skills...)
+	row = 0
+	Traverse(func(s *Spell) bool {
+		c.addRow("spells", row, map[string]string{
+			"name":       s.String(),
+			"college":    strings.Join(s.CollegeWithReplacements(), ", "),
+			"class":      s.ClassWithReplacements(),
+			"difficulty": s.Difficulty.Description(e),
+			"level":      roll20Level(s.CalculateLevel().Level),
+			"relative":   s.RelativeLevel(),
+			"points":     s.AdjustedPoints(nil).String(),
+			"cost":       s.CastingCostWithReplacements(),
+			"maintain":   s.MaintenanceCostWithReplacements(),
+			"time":       s.CastingTimeWithReplacements(),
+			"duration":   s.DurationWithReplacements(),
+			"resist":     s.ResistWithReplacements(),
+			"notes":      s.Notes(),
+			"ref":        s.PageRef,
+		})
+		row++
+		return false
+	}, true, true, e.Spells...)
+	for i, w := range e.EquippedWeapons(true) {
+		c.addRow("melee", i, map[string]string{
+			"name":   w.String(),
+			"usage":  w.UsageWithReplacements(),
+			"level":  roll20Level(w.SkillLevel(nil)),
+			"damage": w.Damage.ResolvedDamage(nil),
+			"reach":  w.Reach.Resolve(w, nil).String(),
+			"parry":  w.Parry.Resolve(w, nil).String(),
+			"block":  w.Block.Resolve(w, nil).String(),
+			"st":     w.Strength.Resolve(w, nil).String(),
+			"notes":  w.Notes(),
+		})
+	}
+	for i, w := range e.EquippedWeapons(false) {
+		c.addRow("ranged", i, map[string]string{
+			"name":   w.String(),
+			"usage":  w.UsageWithReplacements(),
+			"level":  roll20Level(w.SkillLevel(nil)),
+			"damage": w.Damage.ResolvedDamage(nil),
+			"acc":    w.Accuracy.Resolve(w, nil).String(),
+			"range":  w.Range.Resolve(w, nil).String(true),
+			"rof":    w.RateOfFire.Resolve(w, nil).String(),
+			"shots":  w.Shots.Resolve(w, nil).String(),
+			"bulk":   w.Bulk.Resolve(w, nil).String(),
+			"rcl":    w.Recoil.Resolve(w, nil).String(),
+			"st":     w.Strength.Resolve(w, nil).String(),
+			"notes":  w.Notes(),
+		})
+	}
+	return c
+}
+
+func (c *Roll20Character) add(name, current, maximum string) {
+	c.Attributes = append(c.Attributes, Roll20Attribute{
+		Name:    name,
+		Current: current,
+		Max:     maximum,
+	})
+}
+
+// addRow adds a row to a repeating section. The fields are added in sorted order so that the output is stable.
+func (c *Roll20Character) addRow(section string, row int, fields map[string]string) {
+	prefix := fmt.Sprintf("repeating_%s_%s_", section, roll20RowID(row))
+	keys := dict.Keys(fields)
+	slices.Sort(keys)
+	for _, k := range keys {
+		if v := fields[k]; v != "" {
+			c.add(prefix+k, v, "")
+		}
+	}
+}
+
+// roll20RowID returns a row ID for a repeating section. Roll20 only requires that these be unique within the section
+// and start with a dash.
+func roll20RowID(row int) string {
+	return fmt.Sprintf("-gcs%016d", row)
+}
+
+func roll20Level(level fxp.Int) string {
+	return strconv.Itoa(max(fxp.As[int](level), 0))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRoll20Character(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Test Subject"
+	sk := NewSkill(e, nil, false)
+	sk.Name = "Stealth"
+	e.Skills = []*Skill{sk}
+
+	c := NewRoll20Character(e)
+	check.Equal(t, "Test Subject", c.Name)
+	attrs := make(map[string]Roll20Attribute, len(c.Attributes))
+	for _, one := range c.Attributes {
+		attrs[one.Name] = one
+	}
+	check.Equal(t, "10", attrs["strength"].Current)
+	check.Equal(t, attrs["hit_points"].Max, attrs["hit_points"].Current)
+	prefix := "repeating_skills_" + roll20RowID(0) + "_"
+	check.Equal(t, "Stealth", attrs[prefix+"name"].Current)
+	check.Equal(t, roll20Level(sk.CalculateLevel(nil).Level), attrs[prefix+"level"].Current)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+	texttmpl "text/template"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// ExportTemplate is a user-supplied template that is offered in the export menu alongside those found in the libraries.
+type ExportTemplate struct {
+	Name      string `json:"name,omitempty"`
+	Path      string `json:"path"`
+	Extension string `json:"extension,omitempty"`
+	MimeType  string `json:"mime_type,omitempty"`
+}
+
+// ExportTemplateFunction describes a function that templates may call, in addition to those built into Go's template
+// package.
+type ExportTemplateFunction struct {
+	Name        string
+	Usage       string
+	Description string
+	function    any
+}
+
+// Clone creates a copy of the export template.
+func (t *ExportTemplate) Clone() *ExportTemplate {
+	other := *t
+	return &other
+}
+
+// Title returns the name to show for the template, falling back to the template file's name.
+func (t *ExportTemplate) Title() string {
+	if name := strings.TrimSpace(t.Name); name != "" {
+		return name
+	}
+	base := filepath.Base(t.Path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// OutputExtension returns the extension, including the leading period, to give files exported with the template. If
+// none was set, the template file's own extension is used.
+func (t *ExportTemplate) OutputExtension() string {
+	ext := strings.TrimSpace(t.Extension)
+	if ext == "" {
+		return filepath.Ext(t.Path)
+	}
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// OutputMimeType returns the mime type of files exported with the template. If none was set, the mime type normally
+// associated with the output extension is used.
+func (t *ExportTemplate) OutputMimeType() string {
+	if mimeType := strings.TrimSpace(t.MimeType); mimeType != "" {
+		return mimeType
+	}
+	return mime.TypeByExtension(strings.ToLower(t.OutputExtension()))
+}
+
+// CloneExportTemplates creates a copy of the export templates.
+func CloneExportTemplates(list []*ExportTemplate) []*ExportTemplate {
+	if list == nil {
+		return nil
+	}
+	result := make([]*ExportTemplate, len(list))
+	for i, one := range list {
+		result[i] = one.Clone()
+	}
+	return result
+}
+
+// ExportTemplateFunctions returns the functions available to "GCS HTML Template v1" and "GCS Text Template v1"
+// templates, sorted by name.
+func ExportTemplateFunctions() []*ExportTemplateFunction {
+	return []*ExportTemplateFunction{
+		{
+			Name:        "caselessEqual",
+			Usage:       "caselessEqual a b",
+			Description: i18n.Text("Returns true if the two strings are equal, ignoring case."),
+			function:    strings.EqualFold,
+		},
+		{
+			Name:        "comma",
+			Usage:       "comma number",
+			Description: i18n.Text("Returns the number with commas separating the thousands."),
+			function:    func(value fxp.Int) string { return value.Comma() },
+		},
+		{
+			Name:        "contains",
+			Usage:       "contains s substr",
+			Description: i18n.Text("Returns true if substr is within s."),
+			function:    strings.Contains,
+		},
+		{
+			Name:        "fallback",
+			Usage:       "fallback s alternate",
+			Description: i18n.Text("Returns s, or alternate if s is empty or only whitespace."),
+			function:    fallbackString,
+		},
+		{
+			Name:        "hasPrefix",
+			Usage:       "hasPrefix s prefix",
+			Description: i18n.Text("Returns true if s begins with prefix."),
+			function:    strings.HasPrefix,
+		},
+		{
+			Name:        "hasSuffix",
+			Usage:       "hasSuffix s suffix",
+			Description: i18n.Text("Returns true if s ends with suffix."),
+			function:    strings.HasSuffix,
+		},
+		{
+			Name:        "index",
+			Usage:       "index s substr",
+			Description: i18n.Text("Returns the position of the first occurrence of substr in s, or -1 if it isn't present."),
+			function:    strings.Index,
+		},
+		{
+			Name:        "join",
+			Usage:       "join list separator",
+			Description: i18n.Text("Returns the strings in the list joined together with the separator between them."),
+			function:    strings.Join,
+		},
+		{
+			Name:        "lastIndex",
+			Usage:       "lastIndex s substr",
+			Description: i18n.Text("Returns the position of the last occurrence of substr in s, or -1 if it isn't present."),
+			function:    strings.LastIndex,
+		},
+		{
+			Name:        "lower",
+			Usage:       "lower s",
+			Description: i18n.Text("Returns s in lowercase."),
+			function:    strings.ToLower,
+		},
+		{
+			Name:        "numberFrom",
+			Usage:       "numberFrom value",
+			Description: i18n.Text("Returns a number created from an integer, floating point value or string."),
+			function:    numberFrom,
+		},
+		{
+			Name:        "numberToFloat",
+			Usage:       "numberToFloat number",
+			Description: i18n.Text("Returns the number as a floating point value."),
+			function:    fxp.As[float64],
+		},
+		{
+			Name:        "numberToInt",
+			Usage:       "numberToInt number",
+			Description: i18n.Text("Returns the number as an integer, discarding any fraction."),
+			function:    fxp.As[int],
+		},
+		{
+			Name:        "repeat",
+			Usage:       "repeat s count",
+			Description: i18n.Text("Returns s repeated count times."),
+			function:    strings.Repeat,
+		},
+		{
+			Name:        "replace",
+			Usage:       "replace s old new",
+			Description: i18n.Text("Returns s with every occurrence of old replaced by new."),
+			function:    strings.ReplaceAll,
+		},
+		{
+			Name:        "signed",
+			Usage:       "signed number",
+			Description: i18n.Text("Returns the number with a leading + or - sign, as used for modifiers."),
+			function:    func(value fxp.Int) string { return value.StringWithSign() },
+		},
+		{
+			Name:        "split",
+			Usage:       "split s separator",
+			Description: i18n.Text("Returns the list of strings found between occurrences of the separator in s."),
+			function:    strings.Split,
+		},
+		{
+			Name:        "splitN",
+			Usage:       "splitN s separator count",
+			Description: i18n.Text("Like split, but returns at most count strings, the last holding the remainder of s."),
+			function:    strings.SplitN,
+		},
+		{
+			Name:        "trim",
+			Usage:       "trim s",
+			Description: i18n.Text("Returns s with leading and trailing whitespace removed."),
+			function:    strings.TrimSpace,
+		},
+		{
+			Name:        "trimPrefix",
+			Usage:       "trimPrefix s prefix",
+			Description: i18n.Text("Returns s without the leading prefix, if present."),
+			function:    strings.TrimPrefix,
+		},
+		{
+			Name:        "trimSuffix",
+			Usage:       "trimSuffix s suffix",
+			Description: i18n.Text("Returns s without the trailing suffix, if present."),
+			function:    strings.TrimSuffix,
+		},
+		{
+			Name:        "upper",
+			Usage:       "upper s",
+			Description: i18n.Text("Returns s in uppercase."),
+			function:    strings.ToUpper,
+		},
+	}
+}
+
+func createTemplateFuncs() texttmpl.FuncMap {
+	list := ExportTemplateFunctions()
+	m := make(texttmpl.FuncMap, len(list))
+	for _, one := range list {
+		m[one.Name] = one.function
+	}
+	return m
+}
+
+func fallbackString(s, alternate string) string {
+	if strings.TrimSpace(s) == "" {
+		return alternate
+	}
+	return s
+}
@@ -10,6 +10,7 @@
 package gurps
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"text/template"
@@ -62,3 +63,35 @@ func TestTemplateFuncs(t *testing.T) {
 	check.NoError(t, err)
 	check.Error(t, tmpl.Execute(&buffer, nil))
 }
+
+func TestExportTemplateFuncs(t *testing.T) {
+	tmpl, err := template.New("").Funcs(createTemplateFuncs()).Parse(
+		`{{signed .Value}}|{{comma .Big}}|{{fallback .Empty "none"}}|{{fallback "x" "none"}}`)
+	check.NoError(t, err)
+	var buffer strings.Builder
+	input := struct {
+		Value fxp.Int
+		Big   fxp.Int
+		Empty string
+	}{
+		Value: fxp.Two,
+		Big:   fxp.From(12345),
+	}
+	check.NoError(t, tmpl.Execute(&buffer, input))
+	check.Equal(t, "+2|12,345|none|x", buffer.String())
+	for _, one := range ExportTemplateFunctions() {
+		check.NotEqual(t, "", one.Description, one.Name)
+	}
+}
+
+func TestExportTemplateOutput(t *testing.T) {
+	tmpl := &ExportTemplate{Path: filepath.Join("templates", "Statblock.txt")}
+	check.Equal(t, "Statblock", tmpl.Title())
+	check.Equal(t, ".txt", tmpl.OutputExtension())
+	tmpl.Name = "Forum Post"
+	tmpl.Extension = "bbcode"
+	tmpl.MimeType = "text/x-bbcode"
+	check.Equal(t, "Forum Post", tmpl.Title())
+	check.Equal(t, ".bbcode", tmpl.OutputExtension())
+	check.Equal(t, "text/x-bbcode", tmpl.OutputMimeType())
+}
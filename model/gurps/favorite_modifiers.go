@@ -0,0 +1,83 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FavoriteModifiers holds copies of the trait and equipment modifiers that have been marked as favorites, so that they
+// can be quickly applied. Favorites are identified by their name, so only one modifier with a given name may be a
+// favorite at a time.
+type FavoriteModifiers struct {
+	Traits    []*TraitModifier     `json:"traits,omitempty"`
+	Equipment []*EquipmentModifier `json:"equipment,omitempty"`
+}
+
+type favoriteModifier interface {
+	*TraitModifier | *EquipmentModifier
+	fmt.Stringer
+}
+
+func favoriteIndex[T favoriteModifier](list []T, mod T) int {
+	name := mod.String()
+	return slices.IndexFunc(list, func(one T) bool { return one.String() == name })
+}
+
+// IsFavoriteTraitModifier returns true if the modifier is a favorite.
+func (f *FavoriteModifiers) IsFavoriteTraitModifier(mod *TraitModifier) bool {
+	return favoriteIndex(f.Traits, mod) != -1
+}
+
+// ToggleFavoriteTraitModifier adds a copy of the modifier to the favorites, or removes it if it is already a favorite.
+// Containers can't be favorites.
+func (f *FavoriteModifiers) ToggleFavoriteTraitModifier(mod *TraitModifier) {
+	if i := favoriteIndex(f.Traits, mod); i != -1 {
+		f.Traits = slices.Delete(f.Traits, i, i+1)
+	} else if !mod.Container() {
+		f.Traits = append(f.Traits, mod.Clone(LibraryFile{}, nil, nil, false))
+	}
+}
+
+// IsFavoriteEquipmentModifier returns true if the modifier is a favorite.
+func (f *FavoriteModifiers) IsFavoriteEquipmentModifier(mod *EquipmentModifier) bool {
+	return favoriteIndex(f.Equipment, mod) != -1
+}
+
+// ToggleFavoriteEquipmentModifier adds a copy of the modifier to the favorites, or removes it if it is already a
+// favorite. Containers can't be favorites.
+func (f *FavoriteModifiers) ToggleFavoriteEquipmentModifier(mod *EquipmentModifier) {
+	if i := favoriteIndex(f.Equipment, mod); i != -1 {
+		f.Equipment = slices.Delete(f.Equipment, i, i+1)
+	} else if !mod.Container() {
+		f.Equipment = append(f.Equipment, mod.Clone(LibraryFile{}, nil, nil, false))
+	}
+}
+
+// ApplyModifier adds a copy of the modifier to the trait. Returns false if the trait already has a modifier with the
+// same name, in which case nothing is added.
+func (t *Trait) ApplyModifier(mod *TraitModifier) bool {
+	if favoriteIndex(t.Modifiers, mod) != -1 {
+		return false
+	}
+	t.Modifiers = append(t.Modifiers, mod.Clone(LibraryFile{}, t.DataOwner(), nil, false))
+	return true
+}
+
+// ApplyModifier adds a copy of the modifier to the equipment. Returns false if the equipment already has a modifier
+// with the same name, in which case nothing is added.
+func (e *Equipment) ApplyModifier(mod *EquipmentModifier) bool {
+	if favoriteIndex(e.Modifiers, mod) != -1 {
+		return false
+	}
+	e.Modifiers = append(e.Modifiers, mod.Clone(LibraryFile{}, e.DataOwner(), nil, false))
+	return true
+}
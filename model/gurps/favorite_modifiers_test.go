@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFavoriteModifiers(t *testing.T) {
+	var f FavoriteModifiers
+	mod := NewEquipmentModifier(nil, nil, false)
+	mod.Name = "Fine"
+	check.False(t, f.IsFavoriteEquipmentModifier(mod))
+	f.ToggleFavoriteEquipmentModifier(mod)
+	check.True(t, f.IsFavoriteEquipmentModifier(mod))
+	check.Equal(t, 1, len(f.Equipment))
+	check.NotEqual(t, mod, f.Equipment[0])
+
+	container := NewEquipmentModifier(nil, nil, true)
+	f.ToggleFavoriteEquipmentModifier(container)
+	check.Equal(t, 1, len(f.Equipment))
+
+	e := NewEntity()
+	eqp := NewEquipment(e, nil, false)
+	check.True(t, eqp.ApplyModifier(f.Equipment[0]))
+	check.False(t, eqp.ApplyModifier(f.Equipment[0]))
+	check.Equal(t, 1, len(eqp.Modifiers))
+	check.Equal(t, "Fine", eqp.Modifiers[0].Name)
+
+	f.ToggleFavoriteEquipmentModifier(mod)
+	check.False(t, f.IsFavoriteEquipmentModifier(mod))
+	check.Equal(t, 0, len(f.Equipment))
+}
@@ -61,6 +61,8 @@ func (f *Features) UnmarshalJSON(data []byte) error {
 			feat = &ReactionBonus{}
 		case feature.SkillBonus:
 			feat = &SkillBonus{}
+		case feature.SkillGrant:
+			feat = &SkillGrant{}
 		case feature.SkillPointBonus:
 			feat = &SkillPointBonus{}
 		case feature.SpellBonus:
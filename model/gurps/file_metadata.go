@@ -0,0 +1,87 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// FileMetadata holds summary information about a GCS data file, suitable for previewing it without fully loading it.
+type FileMetadata struct {
+	Name        string
+	PlayerName  string
+	Title       string
+	Portrait    []byte
+	TotalPoints fxp.Int
+	Modified    jio.Time
+	Revision    int
+	Traits      int
+	Skills      int
+	Spells      int
+	Equipment   int
+	Notes       int
+	Rows        int
+}
+
+// fileMetadataData only decodes the top-level fields needed for a FileMetadata. The lists are captured as raw JSON so
+// that their elements are counted, but never unmarshaled into full objects.
+type fileMetadataData struct {
+	Version        int               `json:"version"`
+	TotalPoints    fxp.Int           `json:"total_points"`
+	Profile        fileMetadataOwner `json:"profile"`
+	ModifiedOn     jio.Time          `json:"modified_date"`
+	Revision       int               `json:"revision"`
+	Traits         []json.RawMessage `json:"traits,alt=advantages"`
+	Skills         []json.RawMessage `json:"skills"`
+	Spells         []json.RawMessage `json:"spells"`
+	Equipment      []json.RawMessage `json:"equipment"`
+	OtherEquipment []json.RawMessage `json:"other_equipment"`
+	Notes          []json.RawMessage `json:"notes"`
+	Rows           []json.RawMessage `json:"rows"`
+}
+
+type fileMetadataOwner struct {
+	Name         string `json:"name"`
+	PlayerName   string `json:"player_name"`
+	Title        string `json:"title"`
+	PortraitData []byte `json:"portrait"`
+}
+
+// LoadFileMetadata loads the metadata for a GCS sheet, template or library file.
+func LoadFileMetadata(fileSystem fs.FS, filePath string) (*FileMetadata, error) {
+	var data fileMetadataData
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &data); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(data.Version); err != nil {
+		return nil, err
+	}
+	return &FileMetadata{
+		Name:        data.Profile.Name,
+		PlayerName:  data.Profile.PlayerName,
+		Title:       data.Profile.Title,
+		Portrait:    data.Profile.PortraitData,
+		TotalPoints: data.TotalPoints,
+		Modified:    data.ModifiedOn,
+		Revision:    data.Revision,
+		Traits:      len(data.Traits),
+		Skills:      len(data.Skills),
+		Spells:      len(data.Spells),
+		Equipment:   len(data.Equipment) + len(data.OtherEquipment),
+		Notes:       len(data.Notes),
+		Rows:        len(data.Rows),
+	}, nil
+}
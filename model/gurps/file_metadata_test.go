@@ -0,0 +1,55 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLoadFileMetadata(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Hero.gcs": {Data: []byte(`{
+	"version": 5,
+	"total_points": 150,
+	"profile": {"name": "Hero", "player_name": "Pat", "portrait": "AQID"},
+	"traits": [{"name": "A"}, {"name": "B"}],
+	"equipment": [{"description": "Sword"}],
+	"other_equipment": [{"description": "Tent"}]
+}`)},
+		"Soldier.gct": {Data: []byte(`{"version": 5, "revision": 3, "skills": [{"name": "Guns"}], "notes": [{}]}`)},
+		"Basic.skl":   {Data: []byte(`{"version": 5, "rows": [{}, {}, {}]}`)},
+		"Future.gcs":  {Data: []byte(`{"version": 9999}`)},
+	}
+	m, err := LoadFileMetadata(fsys, "Hero.gcs")
+	check.NoError(t, err)
+	check.Equal(t, "Hero", m.Name)
+	check.Equal(t, "Pat", m.PlayerName)
+	check.Equal(t, []byte{1, 2, 3}, m.Portrait)
+	check.Equal(t, fxp.From(150), m.TotalPoints)
+	check.Equal(t, 2, m.Traits)
+	check.Equal(t, 2, m.Equipment)
+
+	m, err = LoadFileMetadata(fsys, "Soldier.gct")
+	check.NoError(t, err)
+	check.Equal(t, 3, m.Revision)
+	check.Equal(t, 1, m.Skills)
+	check.Equal(t, 1, m.Notes)
+
+	m, err = LoadFileMetadata(fsys, "Basic.skl")
+	check.NoError(t, err)
+	check.Equal(t, 3, m.Rows)
+
+	_, err = LoadFileMetadata(fsys, "Future.gcs")
+	check.Error(t, err)
+}
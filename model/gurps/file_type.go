@@ -27,6 +27,7 @@ const (
 // Primary GCS file extensions.
 const (
 	CampaignExt           = ".campaign"
+	CombatExt             = ".combat"
 	EquipmentExt          = ".eqp"
 	EquipmentModifiersExt = ".eqm"
 	NotesExt              = ".not"
@@ -54,6 +55,8 @@ const (
 	KeySettingsExt     = ".keys"
 	NamesExt           = ".names"
 	PageRefSettingsExt = ".refs"
+	QualityPresetsExt  = ".qualities"
+	QuickReferenceExt  = ".quickref"
 	SheetSettingsExt   = ".sheet"
 	WebSettingsExt     = ".web"
 )
@@ -134,7 +137,7 @@ func GCSExtensions() []string {
 	return list
 }
 
-// GCSSecondaryExtensions returns the file extensions that are owned by GCS but are not directly openable file types.
+// GCSSecondaryExtensions returns the file extensions that are owned by GCS but are not primary document types.
 func GCSSecondaryExtensions() []string {
 	return []string{
 		AncestryExt,
@@ -150,6 +153,8 @@ func GCSSecondaryExtensions() []string {
 		KeySettingsExt,
 		NamesExt,
 		PageRefSettingsExt,
+		QualityPresetsExt,
+		QuickReferenceExt,
 		SheetSettingsExt,
 		WebSettingsExt,
 	}
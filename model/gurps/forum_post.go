@@ -0,0 +1,241 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Extensions used for forum post exports.
+const (
+	ForumPostBBCodeExt   = ".txt"
+	ForumPostMarkdownExt = ".md"
+)
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "#", `\#`,
+	"|", `\|`, "~", `\~`, "^", `\^`, ">", `\>`)
+
+// ForumPostOptions holds the options for a forum post export.
+type ForumPostOptions struct {
+	// Markdown selects reddit-flavored markdown rather than BBCode.
+	Markdown bool
+	// OmitZeroPointTraits leaves out traits, such as features and quirk-free perks, that cost no points.
+	OmitZeroPointTraits bool
+	// CollapseEquipmentContainers lists equipment containers on a single line, without their contents.
+	CollapseEquipmentContainers bool
+}
+
+// Ext returns the file extension to use for the options' format.
+func (o ForumPostOptions) Ext() string {
+	if o.Markdown {
+		return ForumPostMarkdownExt
+	}
+	return ForumPostBBCodeExt
+}
+
+// ExportForumPost writes the entity to filePath formatted for posting on a play-by-post forum.
+func ExportForumPost(e *Entity, filePath string, options ForumPostOptions) error {
+	if err := os.WriteFile(filePath, []byte(NewForumPost(e, options)), 0o640); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+type forumPostItem struct {
+	text     string
+	children []forumPostItem
+}
+
+type forumPostWriter struct {
+	buffer   strings.Builder
+	markdown bool
+}
+
+// NewForumPost returns the entity formatted as BBCode or markdown, suitable for pasting into a play-by-post forum.
+func NewForumPost(e *Entity, options ForumPostOptions) string {
+	e.Recalculate()
+	w := &forumPostWriter{markdown: options.Markdown}
+	name := e.Profile.Name
+	if name == "" {
+		name = i18n.Text("Unnamed")
+	}
+	w.heading(name)
+	var details []string
+	if e.Profile.PlayerName != "" {
+		details = append(details, fmt.Sprintf(i18n.Text("Player: %s"), w.escape(e.Profile.PlayerName)))
+	}
+	details = append(details, fmt.Sprintf(i18n.Text("%s points (%s unspent)"), e.TotalPoints.Comma(),
+		e.UnspentPoints().Comma()))
+	w.buffer.WriteString(strings.Join(details, "; "))
+	w.buffer.WriteString("\n\n")
+
+	var attrs []string
+	for _, attr := range e.Attributes.List() {
+		def := attr.AttributeDef()
+		if def == nil || def.IsSeparator() {
+			continue
+		}
+		var value string
+		if def.Type == attribute.Pool || def.Type == attribute.PoolRef {
+			value = attr.Current().String() + "/" + attr.Maximum().String()
+		} else {
+			value = attributeText(attr.Maximum())
+		}
+		attrs = append(attrs, w.bold(w.escape(def.Name))+" "+value+" "+w.escape(forumPostPoints(attr.PointCost())))
+	}
+	w.buffer.WriteString(strings.Join(attrs, "; "))
+	w.buffer.WriteString("\n")
+
+	w.section(i18n.Text("Traits"), forumPostItems(e.Traits, func(t *Trait) (string, bool) {
+		points := t.AdjustedPoints()
+		return w.escape(t.String()) + " " + w.escape(forumPostPoints(points)), !options.OmitZeroPointTraits || points != 0
+	}, nil))
+	w.section(i18n.Text("Skills"), forumPostItems(e.Skills, func(s *Skill) (string, bool) {
+		if s.Container() {
+			return w.escape(s.String()), true
+		}
+		text := w.escape(s.String())
+		if level := s.CalculateLevel(nil).Level; level != fxp.Min {
+			text += "-" + level.Trunc().String()
+			if rsl := s.RelativeLevel(); rsl != "" {
+				text += " (" + w.escape(rsl) + ")"
+			}
+		}
+		return text + " " + w.escape(forumPostPoints(s.AdjustedPoints(nil))), true
+	}, nil))
+	w.section(i18n.Text("Spells"), forumPostItems(e.Spells, func(s *Spell) (string, bool) {
+		if s.Container() {
+			return w.escape(s.String()), true
+		}
+		text := w.escape(s.String())
+		if level := s.CalculateLevel().Level; level != fxp.Min {
+			text += "-" + level.Trunc().String()
+			if rsl := s.RelativeLevel(); rsl != "" {
+				text += " (" + w.escape(rsl) + ")"
+			}
+		}
+		return text + " " + w.escape(forumPostPoints(s.AdjustedPoints(nil))), true
+	}, nil))
+	var collapse func(*Equipment) bool
+	if options.CollapseEquipmentContainers {
+		collapse = func(eqp *Equipment) bool { return eqp.Container() }
+	}
+	units := e.SheetSettings.DefaultWeightUnits
+	equipmentText := func(eqp *Equipment) (string, bool) {
+		text := w.escape(eqp.String())
+		if eqp.Quantity != fxp.One {
+			text += " ×" + eqp.Quantity.Comma()
+		}
+		if weight := eqp.ExtendedWeight(false, units); weight != 0 {
+			text += " (" + units.Format(weight) + ")"
+		}
+		if options.CollapseEquipmentContainers && eqp.Container() {
+			var count int
+			Traverse(func(_ *Equipment) bool {
+				count++
+				return false
+			}, false, false, eqp.Children...)
+			if count != 0 {
+				text += " " + w.escape(fmt.Sprintf(i18n.Text("[contains %d items]"), count))
+			}
+		}
+		return text, true
+	}
+	w.section(i18n.Text("Equipment"), forumPostItems(e.CarriedEquipment, equipmentText, collapse))
+	w.section(i18n.Text("Other Equipment"), forumPostItems(e.OtherEquipment, equipmentText, collapse))
+	return w.buffer.String()
+}
+
+// forumPostItems builds the list items for the rows. text returns the text for a row and whether it should be
+// included; a container is still included if any of its children are. Rows for which collapse returns true do not have
+// their children listed.
+func forumPostItems[T NodeTypes](rows []T, text func(T) (string, bool), collapse func(T) bool) []forumPostItem {
+	items := make([]forumPostItem, 0, len(rows))
+	for _, row := range rows {
+		node := AsNode(row)
+		if !node.Enabled() {
+			continue
+		}
+		var children []forumPostItem
+		if node.HasChildren() && (collapse == nil || !collapse(row)) {
+			children = forumPostItems(node.NodeChildren(), text, collapse)
+		}
+		s, include := text(row)
+		if include || len(children) != 0 {
+			items = append(items, forumPostItem{text: s, children: children})
+		}
+	}
+	return items
+}
+
+func (w *forumPostWriter) escape(text string) string {
+	if w.markdown {
+		return markdownEscaper.Replace(text)
+	}
+	return text
+}
+
+func (w *forumPostWriter) bold(text string) string {
+	if w.markdown {
+		return "**" + text + "**"
+	}
+	return "[b]" + text + "[/b]"
+}
+
+func (w *forumPostWriter) heading(text string) {
+	if w.markdown {
+		w.buffer.WriteString("## " + w.escape(text) + "\n\n")
+	} else {
+		w.buffer.WriteString("[size=150][b]" + text + "[/b][/size]\n\n")
+	}
+}
+
+func (w *forumPostWriter) section(title string, items []forumPostItem) {
+	if len(items) == 0 {
+		return
+	}
+	w.buffer.WriteByte('\n')
+	if w.markdown {
+		w.buffer.WriteString(w.bold(w.escape(title)) + "\n\n")
+	} else {
+		w.buffer.WriteString(w.bold(title) + "\n")
+	}
+	w.list(items, 0)
+}
+
+func (w *forumPostWriter) list(items []forumPostItem, depth int) {
+	if !w.markdown {
+		w.buffer.WriteString("[list]\n")
+	}
+	for _, item := range items {
+		if w.markdown {
+			w.buffer.WriteString(strings.Repeat("    ", depth) + "- " + item.text + "\n")
+		} else {
+			w.buffer.WriteString("[*]" + item.text + "\n")
+		}
+		if len(item.children) != 0 {
+			w.list(item.children, depth+1)
+		}
+	}
+	if !w.markdown {
+		w.buffer.WriteString("[/list]\n")
+	}
+}
+
+func forumPostPoints(points fxp.Int) string {
+	return "[" + points.Comma() + "]"
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestForumPost(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Aria"
+	fit := NewTrait(e, nil, false)
+	fit.Name = "Fit"
+	fit.BasePoints = fxp.Five
+	feature := NewTrait(e, nil, false)
+	feature.Name = "Pointy_Ears"
+	e.Traits = []*Trait{fit, feature}
+	pack := NewEquipment(e, nil, true)
+	pack.Name = "Backpack"
+	rope := NewEquipment(e, pack, false)
+	rope.Name = "Rope"
+	pack.Children = []*Equipment{rope}
+	e.CarriedEquipment = []*Equipment{pack}
+
+	post := NewForumPost(e, ForumPostOptions{})
+	check.Contains(t, post, "[size=150][b]Aria[/b][/size]")
+	check.Contains(t, post, "[*]Fit [5]")
+	check.Contains(t, post, "[*]Pointy_Ears [0]")
+	check.Contains(t, post, "[list]\n[*]Rope")
+
+	post = NewForumPost(e, ForumPostOptions{
+		Markdown:                    true,
+		OmitZeroPointTraits:         true,
+		CollapseEquipmentContainers: true,
+	})
+	check.Contains(t, post, "## Aria")
+	check.Contains(t, post, `- Fit \[5\]`)
+	check.NotContains(t, post, "Pointy")
+	check.NotContains(t, post, "- Rope")
+	check.Contains(t, post, `\[contains 1 items\]`)
+}
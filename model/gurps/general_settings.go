@@ -51,6 +51,7 @@ const (
 	InitialListUIScaleDef      = 100
 	InitialEditorUIScaleDef    = 100
 	InitialSheetUIScaleDef     = 150
+	DefaultSheetUIScaleDef     = 100
 	InitialPDFUIScaleDef       = 100
 	InitialMarkdownUIScaleDef  = 100
 	InitialImageUIScaleDef     = 100
@@ -58,33 +59,42 @@ const (
 	AutoColWidthMin            = 50
 	AutoColWidthMax            = 9999
 	MaximumAutoColWidthDef     = 800
+	AutoBackupRetentionMin     = 1
+	AutoBackupRetentionMax     = 999
+	AutoBackupRetentionDef     = 10
 )
 
 // GeneralSettings holds general settings for a sheet.
 type GeneralSettings struct {
-	DefaultPlayerName           string           `json:"default_player_name,omitempty"`
-	DefaultTechLevel            string           `json:"default_tech_level,omitempty"`
-	CalendarName                string           `json:"calendar_ref,omitempty"`
-	ExternalPDFCmdLine          string           `json:"external_pdf_cmd_line,omitempty"`
-	InitialPoints               fxp.Int          `json:"initial_points"`
-	TooltipDelay                fxp.Int          `json:"tooltip_delay"`
-	TooltipDismissal            fxp.Int          `json:"tooltip_dismissal"`
-	ScrollWheelMultiplier       fxp.Int          `json:"scroll_wheel_multiplier"`
-	NavigatorUIScale            int              `json:"navigator_scale"`
-	InitialListUIScale          int              `json:"initial_list_scale"`
-	InitialEditorUIScale        int              `json:"initial_editor_scale"`
-	InitialSheetUIScale         int              `json:"initial_sheet_scale"`
-	InitialPDFUIScale           int              `json:"initial_pdf_scale"`
-	InitialMarkdownUIScale      int              `json:"initial_md_scale"`
-	InitialImageUIScale         int              `json:"initial_img_scale"`
-	MaximumAutoColWidth         int              `json:"maximum_auto_col_width"`
-	ImageResolution             int              `json:"image_resolution"`
-	MonitorResolution           int              `json:"monitor_resolution,omitempty"`
-	PDFAutoScaling              autoscale.Option `json:"pdf_auto_scaling,omitempty"`
-	AutoFillProfile             bool             `json:"auto_fill_profile"`
-	AutoAddNaturalAttacks       bool             `json:"add_natural_attacks"`
-	GroupContainersOnSort       bool             `json:"group_containers_on_sort"`
-	InitialFieldClickSelectsAll bool             `json:"initial_field_click_selects_all"`
+	DefaultPlayerName           string                `json:"default_player_name,omitempty"`
+	DefaultTechLevel            string                `json:"default_tech_level,omitempty"`
+	CalendarName                string                `json:"calendar_ref,omitempty"`
+	ExternalPDFCmdLine          string                `json:"external_pdf_cmd_line,omitempty"`
+	FileNamePattern             string                `json:"file_name_pattern,omitempty"`
+	InitialPoints               fxp.Int               `json:"initial_points"`
+	TooltipDelay                fxp.Int               `json:"tooltip_delay"`
+	TooltipDismissal            fxp.Int               `json:"tooltip_dismissal"`
+	ScrollWheelMultiplier       fxp.Int               `json:"scroll_wheel_multiplier"`
+	NavigatorUIScale            int                   `json:"navigator_scale"`
+	InitialListUIScale          int                   `json:"initial_list_scale"`
+	InitialEditorUIScale        int                   `json:"initial_editor_scale"`
+	InitialSheetUIScale         int                   `json:"initial_sheet_scale"`
+	DefaultSheetUIScale         int                   `json:"default_sheet_scale"`
+	InitialPDFUIScale           int                   `json:"initial_pdf_scale"`
+	InitialMarkdownUIScale      int                   `json:"initial_md_scale"`
+	InitialImageUIScale         int                   `json:"initial_img_scale"`
+	MaximumAutoColWidth         int                   `json:"maximum_auto_col_width"`
+	ImageResolution             int                   `json:"image_resolution"`
+	MonitorResolution           int                   `json:"monitor_resolution,omitempty"`
+	AutoBackupRetention         int                   `json:"auto_backup_retention"`
+	PDFAutoScaling              autoscale.Option      `json:"pdf_auto_scaling,omitempty"`
+	AutoFillProfile             bool                  `json:"auto_fill_profile"`
+	AutoAddNaturalAttacks       bool                  `json:"add_natural_attacks"`
+	GroupContainersOnSort       bool                  `json:"group_containers_on_sort"`
+	InitialFieldClickSelectsAll bool                  `json:"initial_field_click_selects_all"`
+	DisableAutoBackup           bool                  `json:"disable_auto_backup,omitempty"`
+	CombineImagePages           bool                  `json:"combine_image_pages,omitempty"`
+	NewCharacter                *NewCharacterDefaults `json:"new_character,omitempty"`
 }
 
 // NewGeneralSettings creates settings with factory defaults.
@@ -100,11 +110,13 @@ func NewGeneralSettings() *GeneralSettings {
 		InitialListUIScale:     InitialListUIScaleDef,
 		InitialEditorUIScale:   InitialEditorUIScaleDef,
 		InitialSheetUIScale:    InitialSheetUIScaleDef,
+		DefaultSheetUIScale:    DefaultSheetUIScaleDef,
 		InitialPDFUIScale:      InitialPDFUIScaleDef,
 		InitialMarkdownUIScale: InitialMarkdownUIScaleDef,
 		InitialImageUIScale:    InitialImageUIScaleDef,
 		MaximumAutoColWidth:    MaximumAutoColWidthDef,
 		ImageResolution:        ImageResolutionDef,
+		AutoBackupRetention:    AutoBackupRetentionDef,
 		PDFAutoScaling:         InitialPDFAutoScaling,
 		AutoFillProfile:        true,
 		AutoAddNaturalAttacks:  true,
@@ -167,10 +179,12 @@ func (s *GeneralSettings) EnsureValidity() {
 	s.InitialListUIScale = fxp.ResetIfOutOfRange(s.InitialListUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialListUIScaleDef)
 	s.InitialEditorUIScale = fxp.ResetIfOutOfRange(s.InitialEditorUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialEditorUIScaleDef)
 	s.InitialSheetUIScale = fxp.ResetIfOutOfRange(s.InitialSheetUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialSheetUIScaleDef)
+	s.DefaultSheetUIScale = fxp.ResetIfOutOfRange(s.DefaultSheetUIScale, InitialUIScaleMin, InitialUIScaleMax, DefaultSheetUIScaleDef)
 	s.InitialPDFUIScale = fxp.ResetIfOutOfRange(s.InitialPDFUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialPDFUIScaleDef)
 	s.InitialMarkdownUIScale = fxp.ResetIfOutOfRange(s.InitialMarkdownUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialMarkdownUIScaleDef)
 	s.InitialImageUIScale = fxp.ResetIfOutOfRange(s.InitialImageUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialImageUIScaleDef)
 	s.MaximumAutoColWidth = fxp.ResetIfOutOfRange(s.MaximumAutoColWidth, AutoColWidthMin, AutoColWidthMax, MaximumAutoColWidthDef)
+	s.AutoBackupRetention = fxp.ResetIfOutOfRange(s.AutoBackupRetention, AutoBackupRetentionMin, AutoBackupRetentionMax, AutoBackupRetentionDef)
 	s.PDFAutoScaling = s.PDFAutoScaling.EnsureValid()
 	s.UpdateToolTipTiming()
 }
@@ -0,0 +1,259 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/combat"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Keys for the built-in GM screen tables.
+const (
+	GMScreenSizeSpeedRangeKey = "size_speed_range"
+	GMScreenHitLocationsKey   = "hit_locations"
+	GMScreenPosturesKey       = "postures"
+	GMScreenCriticalHitKey    = "critical_hit"
+	GMScreenCriticalMissKey   = "critical_miss"
+)
+
+// Limits for the number of columns on the GM screen.
+const (
+	GMScreenColumnsMin     = 1
+	GMScreenColumnsDefault = 3
+	GMScreenColumnsMax     = 6
+)
+
+// GMScreenSettings holds the configuration of the GM screen: which built-in tables are hidden, which markdown files
+// have been pinned as additional panels and how many columns the panels are arranged in.
+type GMScreenSettings struct {
+	Hidden  []string `json:"hidden,omitempty"`
+	Pinned  []string `json:"pinned,omitempty"`
+	Columns int      `json:"columns,omitempty"`
+}
+
+// GMScreenTable holds one of the built-in reference tables shown on the GM screen.
+type GMScreenTable struct {
+	Key      string
+	Title    string
+	Markdown string
+}
+
+// NewGMScreenSettings returns new GM screen settings with factory defaults.
+func NewGMScreenSettings() *GMScreenSettings {
+	return &GMScreenSettings{Columns: GMScreenColumnsDefault}
+}
+
+// EnsureValidity checks the current settings for validity and if they aren't valid, makes them so.
+func (s *GMScreenSettings) EnsureValidity() {
+	if s.Columns == 0 {
+		s.Columns = GMScreenColumnsDefault
+	}
+	s.Columns = min(max(s.Columns, GMScreenColumnsMin), GMScreenColumnsMax)
+	pinned := make([]string, 0, len(s.Pinned))
+	for _, one := range s.Pinned {
+		if one = filepath.Clean(one); !slices.Contains(pinned, one) {
+			pinned = append(pinned, one)
+		}
+	}
+	s.Pinned = pinned
+}
+
+// IsHidden returns true if the built-in table with the given key has been hidden.
+func (s *GMScreenSettings) IsHidden(key string) bool {
+	return slices.Contains(s.Hidden, key)
+}
+
+// SetHidden sets whether the built-in table with the given key is hidden.
+func (s *GMScreenSettings) SetHidden(key string, hidden bool) {
+	i := slices.Index(s.Hidden, key)
+	switch {
+	case hidden && i == -1:
+		s.Hidden = append(s.Hidden, key)
+	case !hidden && i != -1:
+		s.Hidden = slices.Delete(s.Hidden, i, i+1)
+	default:
+	}
+}
+
+// Pin adds the markdown file to the GM screen. Returns false if it was already pinned.
+func (s *GMScreenSettings) Pin(filePath string) bool {
+	filePath = filepath.Clean(filePath)
+	if slices.Contains(s.Pinned, filePath) {
+		return false
+	}
+	s.Pinned = append(s.Pinned, filePath)
+	return true
+}
+
+// Unpin removes the markdown file from the GM screen.
+func (s *GMScreenSettings) Unpin(filePath string) {
+	filePath = filepath.Clean(filePath)
+	if i := slices.Index(s.Pinned, filePath); i != -1 {
+		s.Pinned = slices.Delete(s.Pinned, i, i+1)
+	}
+}
+
+// GMScreenTables returns the built-in reference tables for the GM screen. The hit location table is built from the
+// given body type.
+func GMScreenTables(body *Body) []*GMScreenTable {
+	return []*GMScreenTable{
+		{
+			Key:      GMScreenSizeSpeedRangeKey,
+			Title:    i18n.Text("Size and Speed/Range"),
+			Markdown: sizeSpeedRangeMarkdown(),
+		},
+		{
+			Key:      GMScreenHitLocationsKey,
+			Title:    i18n.Text("Hit Locations"),
+			Markdown: hitLocationsMarkdown(body),
+		},
+		{
+			Key:      GMScreenPosturesKey,
+			Title:    i18n.Text("Postures"),
+			Markdown: posturesMarkdown(),
+		},
+		{
+			Key:      GMScreenCriticalHitKey,
+			Title:    i18n.Text("Critical Hits"),
+			Markdown: criticalHitMarkdown(),
+		},
+		{
+			Key:      GMScreenCriticalMissKey,
+			Title:    i18n.Text("Critical Misses"),
+			Markdown: criticalMissMarkdown(),
+		},
+	}
+}
+
+func writeMarkdownTableRow(buffer *strings.Builder, cells ...string) {
+	buffer.WriteString("| ")
+	buffer.WriteString(strings.Join(cells, " | "))
+	buffer.WriteString(" |\n")
+}
+
+func writeMarkdownTableHeader(buffer *strings.Builder, cells ...string) {
+	writeMarkdownTableRow(buffer, cells...)
+	separators := make([]string, len(cells))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownTableRow(buffer, separators...)
+}
+
+func sizeSpeedRangeMarkdown() string {
+	small := []string{
+		i18n.Text("1.5 in"),
+		i18n.Text("2 in"),
+		i18n.Text("3 in"),
+		i18n.Text("5 in"),
+		i18n.Text("8 in"),
+		i18n.Text("1 ft"),
+		i18n.Text("1.5 ft"),
+		i18n.Text("2 ft"),
+	}
+	var buffer strings.Builder
+	writeMarkdownTableHeader(&buffer, i18n.Text("Speed/Range"), i18n.Text("Size"), i18n.Text("Measurement"))
+	for value := -len(small) - 2; value <= 18; value++ {
+		var measure string
+		if i := value + len(small) + 2; i < len(small) {
+			measure = small[i]
+		} else {
+			measure = fmt.Sprintf(i18n.Text("%s yd"), valueToYards(value).Comma())
+		}
+		writeMarkdownTableRow(&buffer, fmt.Sprintf("%+d", -value), fmt.Sprintf("%+d", value), measure)
+	}
+	return buffer.String()
+}
+
+func hitLocationsMarkdown(body *Body) string {
+	var buffer strings.Builder
+	if body == nil {
+		return buffer.String()
+	}
+	if body.Name != "" {
+		buffer.WriteString(body.Name)
+		buffer.WriteString("\n\n")
+	}
+	writeMarkdownTableHeader(&buffer, i18n.Text("Roll"), i18n.Text("Location"), i18n.Text("Penalty"),
+		i18n.Text("DR"))
+	for _, one := range body.Locations {
+		var dr string
+		if one.DRBonus != 0 {
+			dr = fmt.Sprintf("%+d", one.DRBonus)
+		}
+		writeMarkdownTableRow(&buffer, one.RollRange, one.TableName, fmt.Sprintf("%+d", one.HitPenalty), dr)
+	}
+	return buffer.String()
+}
+
+func posturesMarkdown() string {
+	type postureRow struct {
+		name     string
+		attack   string
+		defense  string
+		target   string
+		movement string
+	}
+	normal := i18n.Text("Normal")
+	rows := []postureRow{
+		{combat.Standing.String(), normal, normal, normal, i18n.Text("Normal; may sprint")},
+		{combat.Crouching.String(), "-2", normal, "-2", i18n.Text("2/3 Move")},
+		{combat.Kneeling.String(), "-2", "-2", "-2", i18n.Text("1/3 Move")},
+		{combat.Crawling.String(), "-4", "-3", "-2", i18n.Text("1/3 Move")},
+		{combat.Sitting.String(), "-2", "-2", "-2", i18n.Text("None")},
+		{i18n.Text("Lying Down"), "-4", "-3", "-2", i18n.Text("1 yard/turn")},
+	}
+	var buffer strings.Builder
+	writeMarkdownTableHeader(&buffer, i18n.Text("Posture"), i18n.Text("Attack"), i18n.Text("Defense"),
+		i18n.Text("Target"), i18n.Text("Movement"))
+	for _, one := range rows {
+		writeMarkdownTableRow(&buffer, one.name, one.attack, one.defense, one.target, one.movement)
+	}
+	buffer.WriteString("\n")
+	buffer.WriteString(i18n.Text("Attack applies to melee attacks. Target applies to ranged attacks against someone in that posture."))
+	buffer.WriteString("\n")
+	return buffer.String()
+}
+
+func criticalHitMarkdown() string {
+	var buffer strings.Builder
+	writeMarkdownTableHeader(&buffer, i18n.Text("3d"), i18n.Text("Result"))
+	writeMarkdownTableRow(&buffer, "3, 18", i18n.Text("Triple damage."))
+	writeMarkdownTableRow(&buffer, "4, 17", i18n.Text("Target's DR protects at half value."))
+	writeMarkdownTableRow(&buffer, "5, 16", i18n.Text("Double damage."))
+	writeMarkdownTableRow(&buffer, "6, 15", i18n.Text("Maximum normal damage."))
+	writeMarkdownTableRow(&buffer, "7, 13", i18n.Text("Any penetrating injury counts as a major wound."))
+	writeMarkdownTableRow(&buffer, "8, 14",
+		i18n.Text("Any penetrating injury causes double shock; a limb or extremity hit is briefly crippled."))
+	writeMarkdownTableRow(&buffer, "9–11", i18n.Text("Normal damage only."))
+	writeMarkdownTableRow(&buffer, "12", i18n.Text("Normal damage, and the target drops anything held."))
+	return buffer.String()
+}
+
+func criticalMissMarkdown() string {
+	var buffer strings.Builder
+	writeMarkdownTableHeader(&buffer, i18n.Text("3d"), i18n.Text("Result"))
+	writeMarkdownTableRow(&buffer, "3, 4, 17, 18", i18n.Text("The weapon breaks."))
+	writeMarkdownTableRow(&buffer, "5", i18n.Text("You hit yourself in the arm or leg for normal damage."))
+	writeMarkdownTableRow(&buffer, "6", i18n.Text("You hit yourself in the arm or leg for half damage."))
+	writeMarkdownTableRow(&buffer, "7, 13",
+		i18n.Text("You lose your balance; no other action until next turn and -2 to active defenses."))
+	writeMarkdownTableRow(&buffer, "8, 12", i18n.Text("The weapon turns in your hand; take a Ready maneuver."))
+	writeMarkdownTableRow(&buffer, "9–11", i18n.Text("You drop the weapon."))
+	writeMarkdownTableRow(&buffer, "14", i18n.Text("A swung weapon flies 1d yards away; otherwise, you drop it."))
+	writeMarkdownTableRow(&buffer, "15", i18n.Text("You strain your shoulder; the weapon arm is crippled for 30 minutes."))
+	writeMarkdownTableRow(&buffer, "16", i18n.Text("You fall down."))
+	return buffer.String()
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestGMScreenSettings(t *testing.T) {
+	s := &GMScreenSettings{Columns: 99, Pinned: []string{"a/b.md", "a/./b.md", "c.md"}}
+	s.EnsureValidity()
+	check.Equal(t, GMScreenColumnsMax, s.Columns)
+	check.Equal(t, []string{"a/b.md", "c.md"}, s.Pinned)
+
+	check.False(t, s.Pin("c.md"), "already pinned")
+	check.True(t, s.Pin("d.md"))
+	s.Unpin("a/b.md")
+	check.Equal(t, []string{"c.md", "d.md"}, s.Pinned)
+
+	check.False(t, s.IsHidden(GMScreenPosturesKey))
+	s.SetHidden(GMScreenPosturesKey, true)
+	s.SetHidden(GMScreenPosturesKey, true)
+	check.True(t, s.IsHidden(GMScreenPosturesKey))
+	check.Equal(t, 1, len(s.Hidden))
+	s.SetHidden(GMScreenPosturesKey, false)
+	check.False(t, s.IsHidden(GMScreenPosturesKey))
+
+	s = &GMScreenSettings{}
+	s.EnsureValidity()
+	check.Equal(t, GMScreenColumnsDefault, s.Columns)
+}
+
+func TestGMScreenTables(t *testing.T) {
+	tables := GMScreenTables(FactoryBody())
+	check.Equal(t, 5, len(tables))
+	check.Equal(t, GMScreenSizeSpeedRangeKey, tables[0].Key)
+	check.Contains(t, tables[0].Markdown, "| -4 | +4 | 10 yd |")
+	check.Contains(t, tables[0].Markdown, "| +10 | -10 | 1.5 in |")
+	check.Contains(t, tables[1].Markdown, "Torso")
+	for _, one := range tables {
+		check.NotEqual(t, "", one.Markdown, one.Key)
+	}
+}
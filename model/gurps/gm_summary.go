@@ -0,0 +1,161 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// GMSummaryKeySkillCount is the maximum number of key skills included in a GMSummary.
+const GMSummaryKeySkillCount = 6
+
+// senseTraitPrefixes holds the lowercased name prefixes of traits that are considered senses when building a GMSummary.
+var senseTraitPrefixes = []string{
+	"360° vision",
+	"acute ",
+	"dark vision",
+	"danger sense",
+	"detect",
+	"discriminatory ",
+	"hyperspectral vision",
+	"infravision",
+	"microscopic vision",
+	"night vision",
+	"parabolic hearing",
+	"penetrating vision",
+	"peripheral vision",
+	"sensitive touch",
+	"telescopic vision",
+	"ultrahearing",
+	"ultravision",
+	"vibration sense",
+}
+
+// GMSummary holds a condensed view of a character, suitable for a GM screen insert.
+type GMSummary struct {
+	Name       string
+	Player     string
+	HP         string
+	FP         string
+	Dodge      string
+	Parry      string
+	Block      string
+	DR         string
+	Perception string
+	Will       string
+	Move       string
+	Senses     []string
+	KeySkills  []string
+	Languages  []string
+}
+
+// NewGMSummary creates a new GMSummary for the entity.
+func NewGMSummary(e *Entity) *GMSummary {
+	s := &GMSummary{
+		Name:       e.Profile.Name,
+		Player:     e.Profile.PlayerName,
+		HP:         currentOfMaximum(e, HitPointsID),
+		FP:         currentOfMaximum(e, FatiguePointsID),
+		Dodge:      strconv.Itoa(e.Dodge(e.EncumbranceLevel(false))),
+		Perception: attributeText(e.Attributes.Current(PerceptionID)),
+		Will:       attributeText(e.Attributes.Current(WillID)),
+		Move:       strconv.Itoa(e.Move(e.EncumbranceLevel(false))),
+		Parry:      "–",
+		Block:      "–",
+		DR:         "–",
+	}
+	var bestParry, bestBlock *Weapon
+	var parry WeaponParry
+	var block WeaponBlock
+	for _, w := range e.EquippedWeapons(true) {
+		if p := w.Parry.Resolve(w, nil); p.CanParry && (bestParry == nil || p.Modifier > parry.Modifier) {
+			bestParry = w
+			parry = p
+		}
+		if b := w.Block.Resolve(w, nil); b.CanBlock && (bestBlock == nil || b.Modifier > block.Modifier) {
+			bestBlock = w
+			block = b
+		}
+	}
+	if bestParry != nil {
+		s.Parry = parry.String() + " (" + bestParry.String() + ")"
+	}
+	if bestBlock != nil {
+		s.Block = block.String() + " (" + bestBlock.String() + ")"
+	}
+	if loc := e.SheetSettings.BodyType.LookupLocationByID(e, TorsoID); loc != nil {
+		s.DR = loc.DisplayDR(e, nil)
+	}
+	Traverse(func(t *Trait) bool {
+		switch {
+		case HasTag("Language", t.Tags):
+			s.Languages = append(s.Languages, t.String())
+		case isSenseTrait(t):
+			s.Senses = append(s.Senses, t.String())
+		}
+		return false
+	}, true, true, e.Traits...)
+	var skills []*Skill
+	Traverse(func(sk *Skill) bool {
+		if !sk.IsTechnique() && sk.LevelData.Level != fxp.Min {
+			skills = append(skills, sk)
+		}
+		return false
+	}, false, true, e.Skills...)
+	slices.SortFunc(skills, func(a, b *Skill) int {
+		if result := cmp.Compare(b.LevelData.Level, a.LevelData.Level); result != 0 {
+			return result
+		}
+		return txt.NaturalCmp(a.String(), b.String(), true)
+	})
+	for i, sk := range skills {
+		if i == GMSummaryKeySkillCount {
+			break
+		}
+		s.KeySkills = append(s.KeySkills, fmt.Sprintf("%s-%s", sk.String(), sk.LevelData.Level.Trunc().String()))
+	}
+	return s
+}
+
+func isSenseTrait(t *Trait) bool {
+	if HasTag("Senses", t.Tags) {
+		return true
+	}
+	name := strings.ToLower(t.NameWithReplacements())
+	for _, prefix := range senseTraitPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func currentOfMaximum(e *Entity, attrID string) string {
+	current := e.Attributes.Current(attrID)
+	maximum := e.Attributes.Maximum(attrID)
+	if current == fxp.Min || maximum == fxp.Min {
+		return "–"
+	}
+	return current.String() + "/" + maximum.String()
+}
+
+func attributeText(value fxp.Int) string {
+	if value == fxp.Min {
+		return "–"
+	}
+	return value.String()
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestGMSummary(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Aria"
+	lang := NewTrait(e, nil, false)
+	lang.Name = "Elvish"
+	lang.Tags = []string{"Language"}
+	sense := NewTrait(e, nil, false)
+	sense.Name = "Night Vision"
+	other := NewTrait(e, nil, false)
+	other.Name = "Fit"
+	e.Traits = []*Trait{lang, sense, other}
+	e.Recalculate()
+
+	s := NewGMSummary(e)
+	check.Equal(t, "Aria", s.Name)
+	check.Equal(t, []string{"Elvish"}, s.Languages)
+	check.Equal(t, []string{"Night Vision"}, s.Senses)
+	check.Equal(t, "–", s.Parry, "no weapons means no parry")
+	check.Equal(t, "5", s.Move)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// grapplingSkillNames holds the skills that may be used in place of DX for grappling.
+var grapplingSkillNames = []string{"Brawling", "Judo", "Sumo Wrestling", "Wrestling"}
+
+// Possible values for GrapplingTechnique.Base.
+const (
+	// GrapplingBaseSkill rolls against the grappling level.
+	GrapplingBaseSkill = iota
+	// GrapplingBaseBest rolls against the best of ST, DX and the grappling level.
+	GrapplingBaseBest
+	// GrapplingBaseST rolls against ST.
+	GrapplingBaseST
+)
+
+// GrapplingTechnique holds a common grappling technique.
+type GrapplingTechnique struct {
+	Name    string
+	PageRef string
+	// Base is one of the GrapplingBase constants.
+	Base     int
+	Modifier int
+	// Contest describes what the opponent rolls against in a Quick Contest. Empty if the opponent defends normally
+	// instead.
+	Contest string
+}
+
+// GrapplingTechniques returns the common grappling techniques.
+func GrapplingTechniques() []*GrapplingTechnique {
+	return []*GrapplingTechnique{
+		{
+			Name:    i18n.Text("Grab"),
+			PageRef: "B370",
+			Base:    GrapplingBaseSkill,
+		},
+		{
+			Name:    i18n.Text("Takedown"),
+			PageRef: "B370",
+			Base:    GrapplingBaseBest,
+			Contest: i18n.Text("the best of their ST, DX or grappling skill"),
+		},
+		{
+			Name:    i18n.Text("Pin"),
+			PageRef: "B370",
+			Base:    GrapplingBaseBest,
+			Contest: i18n.Text("the best of their ST, DX or grappling skill"),
+		},
+		{
+			Name:    i18n.Text("Choke or Strangle"),
+			PageRef: "B370",
+			Base:    GrapplingBaseST,
+			Contest: i18n.Text("the higher of their ST or HT"),
+		},
+		{
+			Name:    i18n.Text("Break Free"),
+			PageRef: "B371",
+			Base:    GrapplingBaseBest,
+			Contest: i18n.Text("the best of their ST, DX or grappling skill"),
+		},
+		{
+			Name:    i18n.Text("Arm Lock"),
+			PageRef: "B403",
+			Base:    GrapplingBaseSkill,
+		},
+		{
+			Name:     i18n.Text("Neck Snap"),
+			PageRef:  "B404",
+			Base:     GrapplingBaseST,
+			Modifier: -4,
+			Contest:  i18n.Text("the higher of their ST or HT"),
+		},
+	}
+}
+
+// String implements fmt.Stringer.
+func (t *GrapplingTechnique) String() string {
+	return t.Name
+}
+
+// GrapplingLevel returns the best level the entity can grapple at, along with the name of the skill that provides it,
+// which is "DX" if no grappling skill is better than DX.
+func (e *Entity) GrapplingLevel() (level int, source string) {
+	level = fxp.As[int](e.ResolveAttributeCurrent(DexterityID))
+	source = i18n.Text("DX")
+	for _, name := range grapplingSkillNames {
+		if sk := e.BestSkillNamed(name, "", false, nil); sk != nil {
+			if skillLevel := fxp.As[int](sk.CalculateLevel(nil).Level); skillLevel > level {
+				level = skillLevel
+				source = name
+			}
+		}
+	}
+	return level, source
+}
+
+// GrapplingTechniqueLevel returns the level the entity rolls against for the technique, before any situational
+// modifiers.
+func (e *Entity) GrapplingTechniqueLevel(t *GrapplingTechnique) int {
+	st := fxp.As[int](e.ResolveAttributeCurrent(StrengthID))
+	level, _ := e.GrapplingLevel()
+	switch t.Base {
+	case GrapplingBaseBest:
+		level = max(level, st)
+	case GrapplingBaseST:
+		level = st
+	}
+	return level + t.Modifier
+}
+
+// ControlPoints returns the dice rolled to determine the control points inflicted by a successful grapple when using
+// the optional control point rules, which is a thrust damage roll for the entity's ST.
+func (e *Entity) ControlPoints() *dice.Dice {
+	return e.ThrustFor(fxp.As[int](e.ResolveAttributeCurrent(StrengthID)))
+}
+
+// QuickContest holds the outcome of a Quick Contest (B348).
+type QuickContest struct {
+	Attacker SuccessRoll
+	Defender SuccessRoll
+}
+
+// RollQuickContest rolls a Quick Contest between the two levels.
+func RollQuickContest(attacker, defender int) QuickContest {
+	return QuickContest{
+		Attacker: RollAgainst(attacker),
+		Defender: RollAgainst(defender),
+	}
+}
+
+// Winner returns 1 if the attacker won, -1 if the defender won, or 0 if neither did. If only one side succeeded, that
+// side wins. Otherwise, the side with the better margin wins.
+func (q QuickContest) Winner() int {
+	switch {
+	case q.Attacker.Success && !q.Defender.Success:
+		return 1
+	case !q.Attacker.Success && q.Defender.Success:
+		return -1
+	case q.Attacker.Margin() > q.Defender.Margin():
+		return 1
+	case q.Attacker.Margin() < q.Defender.Margin():
+		return -1
+	default:
+		return 0
+	}
+}
+
+// MarginOfVictory returns the difference between the margins of the two sides.
+func (q QuickContest) MarginOfVictory() int {
+	margin := q.Attacker.Margin() - q.Defender.Margin()
+	if margin < 0 {
+		return -margin
+	}
+	return margin
+}
+
+// String implements fmt.Stringer.
+func (q QuickContest) String() string {
+	var outcome string
+	switch q.Winner() {
+	case 1:
+		outcome = fmt.Sprintf(i18n.Text("You win by %d."), q.MarginOfVictory())
+	case -1:
+		outcome = fmt.Sprintf(i18n.Text("Your opponent wins by %d."), q.MarginOfVictory())
+	default:
+		outcome = i18n.Text("A tie; neither side wins.")
+	}
+	return fmt.Sprintf(i18n.Text("You: %s\nOpponent: %s\n%s"), q.Attacker, q.Defender, outcome)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestGrapplingTechniqueLevel(t *testing.T) {
+	e := NewEntity()
+	e.Attributes.Set[StrengthID].SetMaximum(fxp.From(13))
+	e.Recalculate()
+	level, source := e.GrapplingLevel()
+	check.Equal(t, 10, level)
+	check.Equal(t, "DX", source)
+	for _, one := range GrapplingTechniques() {
+		switch one.Name {
+		case "Grab":
+			check.Equal(t, 10, e.GrapplingTechniqueLevel(one))
+		case "Pin":
+			check.Equal(t, 13, e.GrapplingTechniqueLevel(one))
+		case "Neck Snap":
+			check.Equal(t, 9, e.GrapplingTechniqueLevel(one))
+		}
+	}
+}
+
+func TestQuickContest(t *testing.T) {
+	q := QuickContest{Attacker: NewSuccessRoll(12, 10), Defender: NewSuccessRoll(10, 12)}
+	check.Equal(t, 1, q.Winner())
+	check.Equal(t, 4, q.MarginOfVictory())
+	q = QuickContest{Attacker: NewSuccessRoll(12, 10), Defender: NewSuccessRoll(14, 11)}
+	check.Equal(t, -1, q.Winner())
+	check.Equal(t, 1, q.MarginOfVictory())
+	q = QuickContest{Attacker: NewSuccessRoll(10, 12), Defender: NewSuccessRoll(9, 11)}
+	check.Equal(t, 0, q.Winner())
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package gurpstest provides utilities for writing regression tests against character sheets. Sheets are loaded and
+// recalculated entirely within the model, so no windows or other user interface are needed, allowing data file
+// maintainers to verify computed values such as skill levels, encumbrance and extended costs with "go test".
+package gurpstest
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
+)
+
+// Sheet wraps a loaded character sheet for making assertions against its computed values.
+type Sheet struct {
+	t      testing.TB
+	Entity *gurps.Entity
+}
+
+// LoadFile loads the character sheet at the given path on disk. The test is stopped if the file cannot be loaded.
+func LoadFile(t testing.TB, path string) *Sheet {
+	t.Helper()
+	return Load(t, os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+// Load loads the character sheet at the given path within the file system. The test is stopped if the file cannot be
+// loaded.
+func Load(t testing.TB, fileSystem fs.FS, path string) *Sheet {
+	t.Helper()
+	e, err := gurps.NewEntityFromFile(fileSystem, path)
+	if err != nil {
+		t.Fatalf("unable to load %s: %v", path, err)
+	}
+	return Wrap(t, e)
+}
+
+// Wrap wraps an existing entity, recalculating it first.
+func Wrap(t testing.TB, e *gurps.Entity) *Sheet {
+	e.Recalculate()
+	return &Sheet{t: t, Entity: e}
+}
+
+// Skill returns the first skill matching the name and specialization, ignoring case. An empty specialization only
+// matches skills without one. The test is stopped if no such skill exists.
+func (s *Sheet) Skill(name, specialization string) *gurps.Skill {
+	s.t.Helper()
+	var found *gurps.Skill
+	gurps.Traverse(func(skill *gurps.Skill) bool {
+		if strings.EqualFold(skill.NameWithReplacements(), name) &&
+			strings.EqualFold(skill.SpecializationWithReplacements(), specialization) {
+			found = skill
+			return true
+		}
+		return false
+	}, false, true, s.Entity.Skills...)
+	if found == nil {
+		s.t.Fatalf("no skill named %s", describe(name, specialization))
+	}
+	return found
+}
+
+// Spell returns the first spell matching the name, ignoring case. The test is stopped if no such spell exists.
+func (s *Sheet) Spell(name string) *gurps.Spell {
+	s.t.Helper()
+	var found *gurps.Spell
+	gurps.Traverse(func(spell *gurps.Spell) bool {
+		if strings.EqualFold(spell.NameWithReplacements(), name) {
+			found = spell
+			return true
+		}
+		return false
+	}, false, true, s.Entity.Spells...)
+	if found == nil {
+		s.t.Fatalf("no spell named %s", name)
+	}
+	return found
+}
+
+// Equipment returns the first piece of carried or other equipment matching the name, ignoring case. The test is
+// stopped if no such equipment exists.
+func (s *Sheet) Equipment(name string) *gurps.Equipment {
+	s.t.Helper()
+	var found *gurps.Equipment
+	f := func(eqp *gurps.Equipment) bool {
+		if strings.EqualFold(eqp.NameWithReplacements(), name) {
+			found = eqp
+			return true
+		}
+		return false
+	}
+	gurps.Traverse(f, false, false, s.Entity.CarriedEquipment...)
+	if found == nil {
+		gurps.Traverse(f, false, false, s.Entity.OtherEquipment...)
+	}
+	if found == nil {
+		s.t.Fatalf("no equipment named %s", name)
+	}
+	return found
+}
+
+// AssertAttribute checks the current value of an attribute, e.g. "st" or "per".
+func (s *Sheet) AssertAttribute(attrID string, expected fxp.Int) {
+	s.t.Helper()
+	if actual := s.Entity.ResolveAttributeCurrent(attrID); actual != expected {
+		s.t.Errorf("attribute %s: expected %s, got %s", attrID, expected.String(), actual.String())
+	}
+}
+
+// AssertSkillLevel checks the computed level of a skill.
+func (s *Sheet) AssertSkillLevel(name, specialization string, expected fxp.Int) {
+	s.t.Helper()
+	if actual := s.Skill(name, specialization).LevelData.Level; actual != expected {
+		s.t.Errorf("skill %s: expected level %s, got %s", describe(name, specialization), expected.String(),
+			actual.String())
+	}
+}
+
+// AssertSpellLevel checks the computed level of a spell.
+func (s *Sheet) AssertSpellLevel(name string, expected fxp.Int) {
+	s.t.Helper()
+	if actual := s.Spell(name).LevelData.Level; actual != expected {
+		s.t.Errorf("spell %s: expected level %s, got %s", name, expected.String(), actual.String())
+	}
+}
+
+// AssertEncumbrance checks the encumbrance level.
+func (s *Sheet) AssertEncumbrance(expected encumbrance.Level) {
+	s.t.Helper()
+	if actual := s.Entity.EncumbranceLevel(false); actual != expected {
+		s.t.Errorf("encumbrance: expected %s, got %s", expected.String(), actual.String())
+	}
+}
+
+// AssertExtendedValue checks the extended value (cost) of a piece of equipment, which includes its quantity and
+// contents.
+func (s *Sheet) AssertExtendedValue(name string, expected fxp.Int) {
+	s.t.Helper()
+	if actual := s.Equipment(name).ExtendedValue(); actual != expected {
+		s.t.Errorf("equipment %s: expected extended value %s, got %s", name, expected.String(), actual.String())
+	}
+}
+
+// AssertExtendedWeight checks the extended weight of a piece of equipment, which includes its quantity and contents.
+func (s *Sheet) AssertExtendedWeight(name string, expected fxp.Weight) {
+	s.t.Helper()
+	units := s.Entity.SheetSettings.DefaultWeightUnits
+	if actual := s.Equipment(name).ExtendedWeight(false, units); actual != expected {
+		s.t.Errorf("equipment %s: expected extended weight %s, got %s", name, units.Format(expected),
+			units.Format(actual))
+	}
+}
+
+// AssertUnspentPoints checks the number of unspent character points.
+func (s *Sheet) AssertUnspentPoints(expected fxp.Int) {
+	s.t.Helper()
+	if actual := s.Entity.UnspentPoints(); actual != expected {
+		s.t.Errorf("unspent points: expected %s, got %s", expected.String(), actual.String())
+	}
+}
+
+func describe(name, specialization string) string {
+	if specialization == "" {
+		return name
+	}
+	return name + " (" + specialization + ")"
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurpstest_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
+	"github.com/richardwilkes/gcs/v5/model/gurps/gurpstest"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLoadAndAssert(t *testing.T) {
+	e := gurps.NewEntity()
+	rope := gurps.NewEquipment(e, nil, false)
+	rope.Name = "Rope"
+	rope.Quantity = fxp.Two
+	rope.Value = fxp.Five
+	rope.Weight = fxp.Weight(fxp.Three)
+	e.CarriedEquipment = []*gurps.Equipment{rope}
+	path := filepath.Join(t.TempDir(), "test.gcs")
+	check.NoError(t, e.Save(path))
+
+	s := gurpstest.LoadFile(t, path)
+	s.AssertAttribute("st", fxp.Ten)
+	s.AssertEncumbrance(encumbrance.None)
+	s.AssertExtendedValue("rope", fxp.Ten)
+	s.AssertExtendedWeight("Rope", fxp.Weight(fxp.From(6)))
+}
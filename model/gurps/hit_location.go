@@ -42,6 +42,7 @@ type HitLocation struct {
 	RollRange   string
 	KeyPrefix   string
 	owningTable *Body
+	rollStart   int
 }
 
 // NewHitLocation creates a new hit location.
@@ -160,12 +161,14 @@ func (h *HitLocation) DR(entity *Entity, tooltip *xio.ByteBuffer, drMap map[stri
 
 // DisplayDR returns the DR for this location, formatted as a string.
 func (h *HitLocation) DisplayDR(entity *Entity, tooltip *xio.ByteBuffer) string {
-	drMap := h.DR(entity, tooltip, nil)
-	all, exists := drMap[AllID]
-	if !exists {
-		drMap[AllID] = 0
-	}
-	keys := make([]string, 0, len(drMap))
+	return FormatDR(h.DR(entity, tooltip, nil))
+}
+
+// FormatDR returns the DR in the map, formatted as a string. The DR against all attacks comes first, followed by the DR
+// against each specific type of attack, separated by slashes.
+func FormatDR(drMap map[string]int) string {
+	all := drMap[AllID]
+	keys := make([]string, 0, len(drMap)+1)
 	keys = append(keys, AllID)
 	for k := range drMap {
 		if k != AllID {
@@ -206,6 +209,7 @@ func (h *HitLocation) populateMap(entity *Entity, m map[string]*HitLocation) {
 }
 
 func (h *HitLocation) updateRollRange(start int) int {
+	h.rollStart = start
 	switch h.Slots {
 	case 0:
 		h.RollRange = "-"
@@ -0,0 +1,162 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// maxDistributionOutcomes limits the size of the dice for which chances will be calculated.
+const maxDistributionOutcomes = 10000
+
+// HitLocationRollStep holds the result of rolling on a single table while determining a random hit location.
+type HitLocationRollStep struct {
+	Dice     string
+	Value    int
+	Location *HitLocation
+}
+
+// TotalWeight returns the sum of the slots of the locations in the table.
+func (b *Body) TotalWeight() int {
+	total := 0
+	for _, loc := range b.Locations {
+		total += max(loc.Slots, 0)
+	}
+	return total
+}
+
+// TableRoll returns the dice used to roll on the table.
+func (b *Body) TableRoll() *dice.Dice {
+	if b.Weighted {
+		if total := b.TotalWeight(); total > 0 {
+			return &dice.Dice{Count: 1, Sides: total, Multiplier: 1}
+		}
+	}
+	if b.Roll == nil {
+		return dice.New("3d")
+	}
+	return b.Roll
+}
+
+// LocationForRoll returns the location in this table whose roll range includes the value, or nil.
+func (b *Body) LocationForRoll(value int) *HitLocation {
+	for _, loc := range b.Locations {
+		if loc.Slots > 0 && value >= loc.rollStart && value < loc.rollStart+loc.Slots {
+			return loc
+		}
+	}
+	return nil
+}
+
+// RollHitLocation determines a random hit location, rolling on sub-tables as needed. The roll function is called
+// with the dice for each table and must return the result of rolling them. One step is returned for each table rolled
+// on; the location of the last step is the one that was hit. The location of a step may be nil if the roll did not
+// land on any location.
+func (b *Body) RollHitLocation(roll func(d *dice.Dice) int) []HitLocationRollStep {
+	b.updateRollRanges()
+	var steps []HitLocationRollStep
+	for table := b; table != nil; {
+		d := table.TableRoll()
+		step := HitLocationRollStep{Dice: d.String(), Value: roll(d)}
+		step.Location = table.LocationForRoll(step.Value)
+		steps = append(steps, step)
+		if step.Location == nil {
+			break
+		}
+		table = step.Location.SubTable
+	}
+	return steps
+}
+
+// DescribeHitLocationRoll returns a description of the steps returned by RollHitLocation.
+func DescribeHitLocationRoll(steps []HitLocationRollStep) string {
+	var buffer strings.Builder
+	for _, step := range steps {
+		if buffer.Len() != 0 {
+			buffer.WriteString("\n")
+		}
+		name := i18n.Text("no location")
+		if step.Location != nil {
+			name = step.Location.TableName
+		}
+		fmt.Fprintf(&buffer, i18n.Text("Rolled %d on %s: %s"), step.Value, step.Dice, name)
+	}
+	return buffer.String()
+}
+
+// RollChance returns the chance, as a percentage, of a roll on the owning table landing on this location. Returns a
+// negative value if the chance can't be determined.
+func (h *HitLocation) RollChance() float64 {
+	if h.owningTable == nil || h.Slots <= 0 {
+		return 0
+	}
+	distribution := rollDistribution(h.owningTable.TableRoll())
+	if distribution == nil {
+		return -1
+	}
+	var chance float64
+	for i := h.rollStart; i < h.rollStart+h.Slots; i++ {
+		chance += distribution[i]
+	}
+	return chance * 100
+}
+
+// RollChanceText returns the result of RollChance formatted for display, or an empty string if it can't be determined.
+func (h *HitLocation) RollChanceText() string {
+	chance := h.RollChance()
+	if chance < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", chance)
+}
+
+// rollDistribution returns the probability of each result of rolling the dice, or nil if there are too many possible
+// outcomes to compute.
+func rollDistribution(d *dice.Dice) map[int]float64 {
+	multiplier := d.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	if d.Sides <= 1 || d.Count <= 0 {
+		// Mirrors dice.Dice.Roll(), which ignores the modifier for single-sided dice.
+		value := d.Modifier
+		if d.Sides == 1 {
+			value = d.Count
+		}
+		return map[int]float64{value * multiplier: 1}
+	}
+	if d.Count*d.Sides > maxDistributionOutcomes {
+		return nil
+	}
+	sums := []float64{1}
+	for range d.Count {
+		next := make([]float64, len(sums)+d.Sides)
+		for total, p := range sums {
+			if p == 0 {
+				continue
+			}
+			for face := 1; face <= d.Sides; face++ {
+				next[total+face] += p / float64(d.Sides)
+			}
+		}
+		sums = next
+	}
+	result := make(map[int]float64, len(sums))
+	for total, p := range sums {
+		if p != 0 {
+			result[(total+d.Modifier)*multiplier] += p
+		}
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"math"
+	"testing"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeightedHitLocationTable(t *testing.T) {
+	body := &Body{BodyData: BodyData{Roll: dice.New("3d"), Weighted: true}}
+	body.AddLocation(&HitLocation{HitLocationData: HitLocationData{LocID: "body", TableName: "Body", Slots: 7}})
+	body.AddLocation(&HitLocation{HitLocationData: HitLocationData{LocID: "limb", TableName: "Limb", Slots: 2}})
+	body.AddLocation(&HitLocation{HitLocationData: HitLocationData{LocID: "head", TableName: "Head", Slots: 1}})
+	body.Update(nil)
+	check.Equal(t, dice.Dice{Count: 1, Sides: 10, Multiplier: 1}, *body.TableRoll())
+	check.Equal(t, "1-7", body.Locations[0].RollRange)
+	check.Equal(t, "8-9", body.Locations[1].RollRange)
+	check.Equal(t, "10", body.Locations[2].RollRange)
+	check.Equal(t, "70.0%", body.Locations[0].RollChanceText())
+	check.Equal(t, "10.0%", body.Locations[2].RollChanceText())
+	check.Equal(t, body.Locations[1], body.LocationForRoll(9))
+	check.Nil(t, body.LocationForRoll(11))
+}
+
+func TestRollHitLocationWithSubTable(t *testing.T) {
+	body := FactoryBody()
+	var rolled []string
+	steps := body.RollHitLocation(func(d *dice.Dice) int {
+		rolled = append(rolled, d.String())
+		return d.Minimum(false)
+	})
+	check.NotEqual(t, 0, len(steps))
+	check.Equal(t, len(steps), len(rolled))
+	check.NotNil(t, steps[0].Location)
+	check.Equal(t, 3, steps[0].Value)
+	last := steps[len(steps)-1]
+	check.NotNil(t, last.Location)
+	check.Nil(t, last.Location.SubTable)
+}
+
+func TestRollDistribution(t *testing.T) {
+	distribution := rollDistribution(dice.New("3d"))
+	check.Equal(t, 16, len(distribution))
+	check.True(t, math.Abs(distribution[10]-27.0/216) < 1e-9)
+	var total float64
+	for _, p := range distribution {
+		total += p
+	}
+	check.True(t, math.Abs(total-1) < 1e-9)
+}
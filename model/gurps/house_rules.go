@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// LibraryFileForPath returns the LibraryFile for the given path on disk. Returns false if the path is not within one of
+// the libraries.
+func LibraryFileForPath(filePathOnDisk string) (LibraryFile, bool) {
+	for _, lib := range GlobalSettings().Libraries() {
+		libPathOnDisk := lib.PathOnDisk + string(filepath.Separator)
+		if strings.HasPrefix(filePathOnDisk, libPathOnDisk) {
+			return LibraryFile{
+				Library: lib.Key(),
+				Path:    strings.TrimPrefix(filePathOnDisk, libPathOnDisk),
+			}, true
+		}
+	}
+	return LibraryFile{}, false
+}
+
+// IsHouseRulesFile returns true if the library file is one of the shared house rules files.
+func (s *SheetSettings) IsHouseRulesFile(libFile LibraryFile) bool {
+	return slices.Contains(s.HouseRules, libFile)
+}
+
+// SyncWithHouseRules re-resolves the rows that came from one of the shared house rules files against the current contents
+// of that file. Sheets reference house rules files through SheetSettings.HouseRules and each row added from one keeps a
+// Source pointing back at it, so the file, not the sheet, is authoritative: the rows are refreshed when the sheet is
+// loaded, when its settings change and when the file changes on disk. The copy stored in the sheet only serves as a
+// fallback so the sheet can still be opened when the file isn't available.
+func (e *Entity) SyncWithHouseRules() {
+	if len(e.SheetSettings.HouseRules) == 0 {
+		return
+	}
+	e.SourceMatcher().PrepareHashes(e)
+	e.syncWithSources(func(src Source) bool { return e.SheetSettings.IsHouseRulesFile(src.LibraryFile) })
+}
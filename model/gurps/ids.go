@@ -23,9 +23,15 @@ const (
 	BlockID            = "block"
 	DexterityID        = "dx"
 	DodgeID            = "dodge"
+	EnergyReserveID    = "er"
+	FatiguePointsID    = "fp"
+	HealthID           = "ht"
+	HitPointsID        = "hp"
+	IntelligenceID     = "iq"
 	LiftingStrengthID  = "lifting_st"
 	MoveID             = "move"
 	ParryID            = "parry"
+	PerceptionID       = "per"
 	RitualMagicSpellID = "ritual_magic_spell"
 	SizeModifierID     = "sm"
 	SkillID            = "skill"
@@ -35,6 +41,7 @@ const (
 	TechniqueID        = "technique"
 	ThrowingStrengthID = "throwing_st"
 	TorsoID            = "torso"
+	WillID             = "will"
 )
 
 // SanitizeID ensures the ID is not empty and consists of only lowercase alphanumeric characters. If permitLeadingDigits
@@ -0,0 +1,281 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// foundryActorFile accepts both the current actor layout and the older one, which stored the system-specific data
+// under "data" rather than "system".
+type foundryActorFile struct {
+	FoundryActor
+	Data *FoundryActorSystem `json:"data"`
+}
+
+// ImportFoundryActor loads a Foundry VTT actor from the file and converts it into a new Entity.
+func ImportFoundryActor(fileSystem fs.FS, filePath string) (*Entity, error) {
+	var a foundryActorFile
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &a); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if a.Data != nil && len(a.System.Attributes) == 0 {
+		a.System = *a.Data
+	}
+	if len(a.System.Attributes) == 0 {
+		return nil, errs.New(i18n.Text("file does not contain a Foundry VTT GURPS actor"))
+	}
+	return NewEntityFromFoundryActor(&a.FoundryActor), nil
+}
+
+// NewEntityFromFoundryActor creates a new Entity from a Foundry VTT actor. Attributes, pools, the profile, traits,
+// skills, spells, equipment and notes are carried over, along with the points spent on each. Weapon usages are not
+// imported, since the actor format does not tie them to the items that provide them.
+func NewEntityFromFoundryActor(a *FoundryActor) *Entity {
+	e := NewEntity()
+	e.Traits = nil
+	e.Profile.Name = a.Name
+	p := &a.System.Traits
+	e.Profile.Title = p.Title
+	e.Profile.PlayerName = p.Player
+	e.Profile.Gender = p.Gender
+	e.Profile.Age = p.Age
+	e.Profile.Birthday = p.Birthday
+	e.Profile.Religion = p.Religion
+	e.Profile.Hair = p.Hair
+	e.Profile.Eyes = p.Eyes
+	e.Profile.Skin = p.Skin
+	e.Profile.Handedness = p.Hand
+	e.Profile.TechLevel = p.TechLevel
+	e.Profile.SizeModifier = p.SizeMod
+	if height, err := fxp.LengthFromString(p.Height, e.SheetSettings.DefaultLengthUnits); err == nil {
+		e.Profile.Height = height
+	}
+	if weight, err := fxp.WeightFromString(p.Weight, e.SheetSettings.DefaultWeightUnits); err == nil {
+		e.Profile.Weight = weight
+	}
+	if total, err := fxp.FromString(strings.TrimSpace(p.TotalPts)); err == nil {
+		e.TotalPoints = total
+		e.PointsRecord = []*PointsRecord{{
+			When:   jio.Now(),
+			Points: total,
+			Reason: i18n.Text("Imported from Foundry VTT"),
+		}}
+	}
+	importFoundryAttributes(e, &a.System)
+	e.Traits = foundryTraits(e, nil, a.System.Ads)
+	e.Skills = foundrySkills(e, nil, a.System.Skills)
+	e.Spells = foundrySpells(e, nil, a.System.Spells)
+	e.CarriedEquipment = foundryEquipmentList(e, nil, a.System.Equipment.Carried)
+	e.OtherEquipment = foundryEquipmentList(e, nil, a.System.Equipment.Other)
+	e.Notes = foundryNotes(e, nil, a.System.Notes)
+	e.Recalculate()
+	return e
+}
+
+func importFoundryAttributes(e *Entity, sys *FoundryActorSystem) {
+	e.Recalculate()
+	for key, one := range sys.Attributes {
+		if one == nil {
+			continue
+		}
+		if attr, ok := e.Attributes.Set[strings.ToLower(key)]; ok {
+			if def := attr.AttributeDef(); def != nil && def.Primary() {
+				attr.SetMaximum(fxp.From(one.Value))
+			}
+		}
+	}
+	// Secondary attributes and pools are derived from the primary ones, so they can only be matched once those are set.
+	e.Recalculate()
+	setFoundryMaximum(e, BasicSpeedID, sys.BasicSpeed.Value)
+	setFoundryMaximum(e, BasicMoveID, sys.BasicMove.Value)
+	setFoundryMaximum(e, HitPointsID, fxp.From(sys.HP.Max))
+	setFoundryMaximum(e, "fp", fxp.From(sys.FP.Max))
+	e.Recalculate()
+	setFoundryDamage(e, HitPointsID, sys.HP)
+	setFoundryDamage(e, "fp", sys.FP)
+}
+
+func setFoundryMaximum(e *Entity, attrID string, value fxp.Int) {
+	if value <= 0 {
+		return
+	}
+	if attr, ok := e.Attributes.Set[attrID]; ok {
+		attr.SetMaximum(value)
+	}
+}
+
+func setFoundryDamage(e *Entity, attrID string, pool FoundryPool) {
+	if attr, ok := e.Attributes.Set[attrID]; ok && pool.Max > 0 {
+		attr.Damage = max(attr.Maximum()-fxp.From(pool.Value), 0)
+	}
+}
+
+func foundryTraits(e *Entity, parent *Trait, m map[string]*FoundryTrait) []*Trait {
+	var list []*Trait
+	for _, key := range foundrySortedKeys(m) {
+		one := m[key]
+		t := NewTrait(e, parent, len(one.Contains) != 0)
+		t.Name = one.Name
+		t.PageRef = one.PageRef
+		t.LocalNotes = one.Notes
+		if t.Container() {
+			t.Children = foundryTraits(e, t, one.Contains)
+		} else {
+			t.BasePoints = one.Points
+		}
+		list = append(list, t)
+	}
+	return list
+}
+
+func foundrySkills(e *Entity, parent *Skill, m map[string]*FoundrySkill) []*Skill {
+	var list []*Skill
+	for _, key := range foundrySortedKeys(m) {
+		one := m[key]
+		s := NewSkill(e, parent, len(one.Contains) != 0)
+		s.PageRef = one.PageRef
+		s.LocalNotes = one.Notes
+		if s.Container() {
+			s.Name = one.Name
+			s.Children = foundrySkills(e, s, one.Contains)
+		} else {
+			s.Name, s.TechLevel, s.Specialization = splitFoundrySkillName(one.Name)
+			setFoundryDifficulty(e, &s.Difficulty, one.Type)
+			s.Points = one.Points
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func foundrySpells(e *Entity, parent *Spell, m map[string]*FoundrySpell) []*Spell {
+	var list []*Spell
+	for _, key := range foundrySortedKeys(m) {
+		one := m[key]
+		s := NewSpell(e, parent, len(one.Contains) != 0)
+		s.PageRef = one.PageRef
+		s.LocalNotes = one.Notes
+		if s.Container() {
+			s.Name = one.Name
+			s.Children = foundrySpells(e, s, one.Contains)
+		} else {
+			s.Name, s.TechLevel, _ = splitFoundrySkillName(one.Name)
+			setFoundryDifficulty(e, &s.Difficulty, one.Difficulty)
+			s.Class = one.Class
+			s.College = nil
+			for _, college := range strings.Split(one.College, ",") {
+				if college = strings.TrimSpace(college); college != "" {
+					s.College = append(s.College, college)
+				}
+			}
+			s.CastingCost = one.Cost
+			s.MaintenanceCost = one.Maintain
+			s.Duration = one.Duration
+			s.CastingTime = one.CastTime
+			s.Resist = one.Resist
+			s.Points = one.Points
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func foundryEquipmentList(e *Entity, parent *Equipment, m map[string]*FoundryEquipment) []*Equipment {
+	var list []*Equipment
+	for _, key := range foundrySortedKeys(m) {
+		one := m[key]
+		eqp := NewEquipment(e, parent, len(one.Contains) != 0)
+		eqp.Name = one.Name
+		eqp.PageRef = one.PageRef
+		eqp.LocalNotes = one.Notes
+		eqp.TechLevel = one.TechLevel
+		eqp.Quantity = one.Count
+		eqp.Value = one.Cost
+		eqp.Weight = fxp.Weight(one.Weight)
+		eqp.Equipped = one.Equipped
+		eqp.Uses = one.Uses
+		eqp.MaxUses = one.MaxUses
+		if eqp.Container() {
+			eqp.Children = foundryEquipmentList(e, eqp, one.Contains)
+		}
+		list = append(list, eqp)
+	}
+	return list
+}
+
+func foundryNotes(e *Entity, parent *Note, m map[string]*FoundryNote) []*Note {
+	var list []*Note
+	for _, key := range foundrySortedKeys(m) {
+		one := m[key]
+		n := NewNote(e, parent, len(one.Contains) != 0)
+		n.Text = one.Notes
+		n.PageRef = one.PageRef
+		if n.Container() {
+			n.Children = foundryNotes(e, n, one.Contains)
+		}
+		list = append(list, n)
+	}
+	return list
+}
+
+// foundrySortedKeys returns the keys of the map, skipping those with nil values, in the order Foundry VTT displays
+// them.
+func foundrySortedKeys[V any](m map[string]*V) []string {
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		if v != nil {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// splitFoundrySkillName splits a name of the form "Name/TL8 (Specialization)" into its parts.
+func splitFoundrySkillName(text string) (name string, techLevel *string, specialization string) {
+	name = strings.TrimSpace(text)
+	if strings.HasSuffix(name, ")") {
+		if i := strings.LastIndex(name, " ("); i != -1 {
+			specialization = name[i+2 : len(name)-1]
+			name = name[:i]
+		}
+	}
+	if i := strings.LastIndex(name, "/TL"); i != -1 {
+		tl := name[i+3:]
+		techLevel = &tl
+		name = name[:i]
+	}
+	return name, techLevel, specialization
+}
+
+// setFoundryDifficulty parses a difficulty of the form "DX/A", or just "A" for techniques, leaving the existing value
+// alone if the text is empty.
+func setFoundryDifficulty(e *Entity, ad *AttributeDifficulty, text string) {
+	attr, diff, found := strings.Cut(strings.TrimSpace(text), "/")
+	if !found {
+		if attr != "" {
+			ad.Difficulty = difficulty.ExtractLevel(attr)
+		}
+		return
+	}
+	ad.Attribute = strings.TrimSpace(attr)
+	ad.Difficulty = difficulty.ExtractLevel(strings.TrimSpace(diff))
+	ad.Normalize(e)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFoundryActorRoundTrip(t *testing.T) {
+	e := NewEntity()
+	e.Traits = nil
+	e.Profile.Name = "Aria"
+	e.Profile.Title = "Scout"
+	e.Attributes.Set["st"].Adjustment = fxp.Two
+	e.Recalculate()
+	e.Attributes.Set[HitPointsID].Damage = fxp.Three
+	fit := NewTrait(e, nil, false)
+	fit.Name = "Fit"
+	fit.BasePoints = fxp.Five
+	e.Traits = []*Trait{fit}
+	guns := NewSkill(e, nil, false)
+	guns.Name = "Guns"
+	tl := "8"
+	guns.TechLevel = &tl
+	guns.Specialization = "Pistol"
+	guns.Difficulty.Difficulty = difficulty.Easy
+	guns.Points = fxp.Two
+	e.Skills = []*Skill{guns}
+	pack := NewEquipment(e, nil, true)
+	pack.Name = "Backpack"
+	pack.Equipped = true
+	rope := NewEquipment(e, pack, false)
+	rope.Name = "Rope"
+	rope.Quantity = fxp.One
+	rope.Weight = fxp.Weight(fxp.Ten)
+	pack.Children = []*Equipment{rope}
+	e.CarriedEquipment = []*Equipment{pack}
+	e.Recalculate()
+
+	imported := NewEntityFromFoundryActor(NewFoundryActor(e))
+	check.Equal(t, "Aria", imported.Profile.Name)
+	check.Equal(t, "Scout", imported.Profile.Title)
+	check.Equal(t, e.Attributes.Set["st"].Maximum(), imported.Attributes.Set["st"].Maximum())
+	check.Equal(t, e.Attributes.Set[HitPointsID].Current(), imported.Attributes.Set[HitPointsID].Current())
+	check.Equal(t, 1, len(imported.Traits))
+	check.Equal(t, "Fit", imported.Traits[0].Name)
+	check.Equal(t, fxp.Five, imported.Traits[0].BasePoints)
+	check.Equal(t, 1, len(imported.Skills))
+	skill := imported.Skills[0]
+	check.Equal(t, "Guns", skill.Name)
+	check.Equal(t, "Pistol", skill.Specialization)
+	check.NotNil(t, skill.TechLevel)
+	check.Equal(t, "8", *skill.TechLevel)
+	check.Equal(t, difficulty.Easy, skill.Difficulty.Difficulty)
+	check.Equal(t, fxp.Two, skill.Points)
+	check.Equal(t, 1, len(imported.CarriedEquipment))
+	check.True(t, imported.CarriedEquipment[0].Container())
+	check.Equal(t, 1, len(imported.CarriedEquipment[0].Children))
+	check.Equal(t, fxp.Weight(fxp.Ten), imported.CarriedEquipment[0].Children[0].Weight)
+	check.Equal(t, e.TotalPoints, imported.TotalPoints)
+}
+
+func TestSplitFoundrySkillName(t *testing.T) {
+	name, tl, spec := splitFoundrySkillName("Stealth")
+	check.Equal(t, "Stealth", name)
+	check.Nil(t, tl)
+	check.Equal(t, "", spec)
+	name, tl, spec = splitFoundrySkillName("Electronics Operation/TL8 (Sensors)")
+	check.Equal(t, "Electronics Operation", name)
+	check.NotNil(t, tl)
+	check.Equal(t, "8", *tl)
+	check.Equal(t, "Sensors", spec)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"html"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// ItemCardField holds a labeled value shown on an ItemCard.
+type ItemCardField struct {
+	Label string
+	Value string
+}
+
+// ItemCard holds the information needed to share a single trait, spell or piece of equipment, such as in a chat or as
+// a handout in a virtual tabletop.
+type ItemCard struct {
+	Kind      string
+	Title     string
+	Fields    []ItemCardField
+	Modifiers []string
+	Notes     string
+	Reference string
+}
+
+// NewItemCard creates a new ItemCard for the data, which must be a *Trait, *Spell or *Equipment. Returns nil for
+// anything else.
+func NewItemCard(data any) *ItemCard {
+	var c *ItemCard
+	switch item := data.(type) {
+	case *Trait:
+		c = newTraitItemCard(item)
+	case *Spell:
+		c = newSpellItemCard(item)
+	case *Equipment:
+		c = newEquipmentItemCard(item)
+	default:
+		return nil
+	}
+	c.Notes = strings.TrimSpace(c.Notes)
+	return c
+}
+
+func newTraitItemCard(t *Trait) *ItemCard {
+	c := &ItemCard{
+		Kind:      i18n.Text("Trait"),
+		Title:     t.String(),
+		Notes:     t.Notes(),
+		Reference: t.PageRef,
+	}
+	c.addField(i18n.Text("Points"), t.AdjustedPoints().Comma())
+	if t.CR != selfctrl.NoCR {
+		value := t.CR.String()
+		if t.CRAdj != selfctrl.NoCRAdj {
+			value += ", " + t.CRAdj.Description(t.CR)
+		}
+		c.addField(i18n.Text("Self-Control"), value)
+	}
+	Traverse(func(mod *TraitModifier) bool {
+		c.Modifiers = append(c.Modifiers, mod.FullDescription())
+		return false
+	}, true, true, t.Modifiers...)
+	return c
+}
+
+func newSpellItemCard(s *Spell) *ItemCard {
+	c := &ItemCard{
+		Kind:      i18n.Text("Spell"),
+		Title:     s.String(),
+		Notes:     s.Notes(),
+		Reference: s.PageRef,
+	}
+	if s.Container() {
+		return c
+	}
+	entity := EntityFromNode(s)
+	if entity != nil {
+		if level := s.CalculateLevel().Level; level != fxp.Min {
+			value := level.Trunc().String()
+			if rsl := s.RelativeLevel(); rsl != "" {
+				value += " (" + rsl + ")"
+			}
+			c.addField(i18n.Text("Level"), value)
+		}
+	}
+	c.addField(i18n.Text("Difficulty"), s.Difficulty.Description(entity))
+	c.addField(i18n.Text("College"), strings.Join(s.CollegeWithReplacements(), ", "))
+	c.addField(i18n.Text("Class"), s.ClassWithReplacements())
+	c.addField(i18n.Text("Power Source"), s.PowerSourceWithReplacements())
+	c.addField(i18n.Text("Resist"), s.ResistWithReplacements())
+	c.addField(i18n.Text("Casting Cost"), s.CastingCostWithReplacements())
+	c.addField(i18n.Text("Maintenance Cost"), s.MaintenanceCostWithReplacements())
+	c.addField(i18n.Text("Casting Time"), s.CastingTimeWithReplacements())
+	c.addField(i18n.Text("Duration"), s.DurationWithReplacements())
+	if entity != nil {
+		c.addField(i18n.Text("Points"), s.AdjustedPoints(nil).Comma())
+	}
+	return c
+}
+
+func newEquipmentItemCard(e *Equipment) *ItemCard {
+	c := &ItemCard{
+		Kind:      i18n.Text("Equipment"),
+		Title:     e.String(),
+		Notes:     e.Notes(),
+		Reference: e.PageRef,
+	}
+	if e.Quantity != fxp.One {
+		c.addField(i18n.Text("Quantity"), e.Quantity.Comma())
+	}
+	c.addField(i18n.Text("TL"), e.TechLevel)
+	c.addField(i18n.Text("LC"), e.LegalityClass)
+	c.addField(i18n.Text("Value"), e.AdjustedValue().Comma())
+	units := SheetSettingsFor(EntityFromNode(e)).DefaultWeightUnits
+	c.addField(i18n.Text("Weight"), units.Format(e.AdjustedWeight(false, units)))
+	Traverse(func(mod *EquipmentModifier) bool {
+		c.Modifiers = append(c.Modifiers, mod.FullDescription())
+		return false
+	}, true, true, e.Modifiers...)
+	return c
+}
+
+// addField adds the field if it has a value.
+func (c *ItemCard) addField(label, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		c.Fields = append(c.Fields, ItemCardField{Label: label, Value: value})
+	}
+}
+
+// Markdown returns the card formatted as markdown, suitable for pasting into a chat.
+func (c *ItemCard) Markdown() string {
+	var buffer strings.Builder
+	buffer.WriteString("### " + markdownEscaper.Replace(c.Title) + "\n")
+	buffer.WriteString("_" + markdownEscaper.Replace(c.Kind) + "_\n")
+	if len(c.Fields) != 0 {
+		buffer.WriteByte('\n')
+		for i, field := range c.Fields {
+			buffer.WriteString("**" + markdownEscaper.Replace(field.Label) + ":** " + markdownEscaper.Replace(field.Value))
+			if i < len(c.Fields)-1 {
+				// Two trailing spaces produce a line break without starting a new paragraph.
+				buffer.WriteString("  ")
+			}
+			buffer.WriteByte('\n')
+		}
+	}
+	if len(c.Modifiers) != 0 {
+		buffer.WriteString("\n**" + i18n.Text("Modifiers") + ":**\n")
+		for _, mod := range c.Modifiers {
+			buffer.WriteString("- " + markdownEscaper.Replace(mod) + "\n")
+		}
+	}
+	if c.Notes != "" {
+		buffer.WriteByte('\n')
+		for _, line := range strings.Split(c.Notes, "\n") {
+			buffer.WriteString("> " + markdownEscaper.Replace(line) + "\n")
+		}
+	}
+	if c.Reference != "" {
+		buffer.WriteString("\n_" + i18n.Text("Reference") + ": " + markdownEscaper.Replace(c.Reference) + "_\n")
+	}
+	return buffer.String()
+}
+
+// HTML returns the card formatted as an HTML fragment with inline styles, suitable for a virtual tabletop handout. If
+// standalone is true, the fragment is wrapped in a complete HTML document.
+func (c *ItemCard) HTML(standalone bool) string {
+	var buffer strings.Builder
+	if standalone {
+		buffer.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+		buffer.WriteString(html.EscapeString(c.Title))
+		buffer.WriteString("</title>\n</head>\n<body>\n")
+	}
+	buffer.WriteString(`<div style="border:1px solid #888;border-radius:6px;padding:8px 12px;max-width:480px;font-family:sans-serif">`)
+	buffer.WriteString("\n<h3 style=\"margin:0\">" + html.EscapeString(c.Title) + "</h3>\n")
+	buffer.WriteString("<div style=\"font-style:italic;color:#666\">" + html.EscapeString(c.Kind) + "</div>\n")
+	if len(c.Fields) != 0 {
+		buffer.WriteString("<p>")
+		for i, field := range c.Fields {
+			if i != 0 {
+				buffer.WriteString("<br>")
+			}
+			buffer.WriteString("<b>" + html.EscapeString(field.Label) + ":</b> " + html.EscapeString(field.Value))
+		}
+		buffer.WriteString("</p>\n")
+	}
+	if len(c.Modifiers) != 0 {
+		buffer.WriteString("<p><b>" + html.EscapeString(i18n.Text("Modifiers")) + ":</b></p>\n<ul>\n")
+		for _, mod := range c.Modifiers {
+			buffer.WriteString("<li>" + html.EscapeString(mod) + "</li>\n")
+		}
+		buffer.WriteString("</ul>\n")
+	}
+	if c.Notes != "" {
+		buffer.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(c.Notes), "\n", "<br>") + "</p>\n")
+	}
+	if c.Reference != "" {
+		buffer.WriteString("<div style=\"font-size:smaller;color:#666\">" + html.EscapeString(i18n.Text("Reference")) +
+			": " + html.EscapeString(c.Reference) + "</div>\n")
+	}
+	buffer.WriteString("</div>\n")
+	if standalone {
+		buffer.WriteString("</body>\n</html>\n")
+	}
+	return buffer.String()
+}
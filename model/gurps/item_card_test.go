@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestItemCard(t *testing.T) {
+	check.Nil(t, NewItemCard(NewNote(nil, nil, false)), "notes aren't supported")
+
+	trait := NewTrait(nil, nil, false)
+	trait.Name = "Fearlessness"
+	trait.BasePoints = fxp.Two
+	trait.LocalNotes = " Add to <Will> for fright checks \n"
+	trait.PageRef = "B55"
+	mod := NewTraitModifier(nil, nil, false)
+	mod.Name = "Unshakable"
+	disabled := NewTraitModifier(nil, nil, false)
+	disabled.Name = "Ignored"
+	disabled.Disabled = true
+	trait.Modifiers = []*TraitModifier{mod, disabled}
+
+	c := NewItemCard(trait)
+	check.Equal(t, "Fearlessness", c.Title)
+	check.Equal(t, []string{mod.FullDescription()}, c.Modifiers, "disabled modifiers are left out")
+	check.Equal(t, "Add to <Will> for fright checks", c.Notes)
+
+	md := c.Markdown()
+	check.True(t, strings.HasPrefix(md, "### Fearlessness\n_Trait_\n"))
+	check.Contains(t, md, "- "+markdownEscaper.Replace(mod.FullDescription())+"\n")
+	check.Contains(t, md, "> Add to <Will> for fright checks\n")
+	check.Contains(t, md, "_Reference: B55_")
+
+	fragment := c.HTML(false)
+	check.Contains(t, fragment, "Add to &lt;Will&gt; for fright checks")
+	check.False(t, strings.Contains(fragment, "<html>"))
+	check.Contains(t, c.HTML(true), "<title>Fearlessness</title>")
+}
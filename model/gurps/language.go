@@ -0,0 +1,159 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/fluency"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+const culturalFamiliarityPrefix = "cultural familiarity ("
+
+// Language holds the spoken and written comprehension levels of a language known by a character.
+type Language struct {
+	Name    string        `json:"name"`
+	Spoken  fluency.Level `json:"spoken"`
+	Written fluency.Level `json:"written"`
+	Native  bool          `json:"native,omitempty"`
+}
+
+// CulturalFamiliarity holds a culture the character is familiar with.
+type CulturalFamiliarity struct {
+	Name   string `json:"name"`
+	Native bool   `json:"native,omitempty"`
+}
+
+// NewLanguage creates a new Language.
+func NewLanguage() *Language {
+	return &Language{
+		Spoken:  fluency.Native,
+		Written: fluency.Native,
+	}
+}
+
+// Cost returns the number of points this language should cost. A native language is free at full comprehension and
+// returns points for any reduction from that.
+func (l *Language) Cost() fxp.Int {
+	cost := int(l.Spoken.EnsureValid()) + int(l.Written.EnsureValid())
+	if l.Native {
+		cost -= 2 * int(fluency.Native)
+	}
+	return fxp.From(cost)
+}
+
+// Cost returns the number of points this cultural familiarity should cost.
+func (c *CulturalFamiliarity) Cost() fxp.Int {
+	if c.Native {
+		return 0
+	}
+	return fxp.One
+}
+
+// CloneLanguageList creates a clone of the provided Language list.
+func CloneLanguageList(list []*Language) []*Language {
+	clone := make([]*Language, len(list))
+	for i := 0; i < len(list); i++ {
+		l := *list[i]
+		clone[i] = &l
+	}
+	return clone
+}
+
+// CloneCulturalFamiliarityList creates a clone of the provided CulturalFamiliarity list.
+func CloneCulturalFamiliarityList(list []*CulturalFamiliarity) []*CulturalFamiliarity {
+	clone := make([]*CulturalFamiliarity, len(list))
+	for i := 0; i < len(list); i++ {
+		c := *list[i]
+		clone[i] = &c
+	}
+	return clone
+}
+
+// SetLanguages sets new language and cultural familiarity lists.
+func (e *Entity) SetLanguages(languages []*Language, familiarities []*CulturalFamiliarity) {
+	e.Languages = CloneLanguageList(languages)
+	e.CulturalFamiliarities = CloneCulturalFamiliarityList(familiarities)
+}
+
+// LanguageTraitPoints returns the points spent on enabled traits tagged as a Language whose name contains the language
+// name.
+func (e *Entity) LanguageTraitPoints(name string) fxp.Int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return 0
+	}
+	var points fxp.Int
+	Traverse(func(t *Trait) bool {
+		if HasTag("Language", t.Tags) && strings.Contains(strings.ToLower(t.NameWithReplacements()), name) {
+			points += t.AdjustedPoints()
+		}
+		return false
+	}, true, true, e.Traits...)
+	return points
+}
+
+// CulturalFamiliarityTraitPoints returns the points spent on enabled "Cultural Familiarity (name)" traits.
+func (e *Entity) CulturalFamiliarityTraitPoints(name string) fxp.Int {
+	target := culturalFamiliarityPrefix + strings.ToLower(strings.TrimSpace(name)) + ")"
+	var points fxp.Int
+	Traverse(func(t *Trait) bool {
+		if strings.ToLower(strings.TrimSpace(t.NameWithReplacements())) == target {
+			points += t.AdjustedPoints()
+		}
+		return false
+	}, true, true, e.Traits...)
+	return points
+}
+
+func (e *Entity) checkLanguages(issues []*ValidationIssue) []*ValidationIssue {
+	natives := 0
+	for _, one := range e.Languages {
+		if strings.TrimSpace(one.Name) == "" {
+			continue
+		}
+		if one.Native {
+			natives++
+		}
+		if cost, spent := one.Cost(), e.LanguageTraitPoints(one.Name); cost != spent {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Language %s (spoken %s, written %s) should cost %s points, but its traits total %s"),
+					one.Name, one.Spoken.String(), one.Written.String(), cost.String(), spent.String()),
+			})
+		}
+	}
+	if natives > 1 {
+		issues = append(issues, &ValidationIssue{
+			Target:  vtarget.Character,
+			Subject: e.Profile.Name,
+			Message: fmt.Sprintf(i18n.Text("%d languages are marked as native, but only one may be"), natives),
+		})
+	}
+	for _, one := range e.CulturalFamiliarities {
+		if strings.TrimSpace(one.Name) == "" {
+			continue
+		}
+		if cost, spent := one.Cost(), e.CulturalFamiliarityTraitPoints(one.Name); cost != spent {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Cultural Familiarity (%s) should cost %s points, but its traits total %s"),
+					one.Name, cost.String(), spent.String()),
+			})
+		}
+	}
+	return issues
+}
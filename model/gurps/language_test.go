@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/fluency"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLanguageCost(t *testing.T) {
+	check.Equal(t, 0, fxp.As[int]((&Language{Spoken: fluency.Native, Written: fluency.Native, Native: true}).Cost()))
+	check.Equal(t, -3, fxp.As[int]((&Language{Spoken: fluency.Native, Native: true}).Cost()))
+	check.Equal(t, 3, fxp.As[int]((&Language{Spoken: fluency.Accented, Written: fluency.Broken}).Cost()))
+	check.Equal(t, 0, fxp.As[int]((&CulturalFamiliarity{Native: true}).Cost()))
+	check.Equal(t, 1, fxp.As[int]((&CulturalFamiliarity{}).Cost()))
+}
+
+func TestLanguageValidation(t *testing.T) {
+	e := NewEntity()
+	trait := NewTrait(e, nil, false)
+	trait.Name = "Language: Spanish"
+	trait.Tags = []string{"Language"}
+	trait.BasePoints = fxp.Two
+	e.Traits = []*Trait{trait}
+	e.Languages = []*Language{{Name: "Spanish", Spoken: fluency.Accented}}
+	e.Recalculate()
+	check.Equal(t, 0, len(e.checkLanguages(nil)))
+
+	e.Languages[0].Written = fluency.Broken
+	check.Equal(t, 1, len(e.checkLanguages(nil)), "cost no longer matches the trait")
+
+	e.CulturalFamiliarities = []*CulturalFamiliarity{{Name: "Latin America"}}
+	check.Equal(t, 2, len(e.checkLanguages(nil)), "missing cultural familiarity trait")
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+func init() {
+	jio.RegisterMigration(&jio.Migration{
+		Version:     jio.FirstGoDataVersion,
+		Description: i18n.Text("renamed advantages to traits and hit locations to body type"),
+		Apply:       migrateToFirstGoDataVersion,
+	})
+}
+
+// migrateToFirstGoDataVersion renames the keys that were changed when the data moved to the first Go release of GCS.
+// Older data using these keys can still be loaded directly, but rewriting them here lets a dry-run report the upgrade.
+func migrateToFirstGoDataVersion(data map[string]any) error {
+	renameJSONKey(data, "advantages", "traits")
+	for _, key := range []string{"settings", "sheet_settings"} {
+		if settings, ok := data[key].(map[string]any); ok {
+			renameLegacySheetSettingsKeys(settings)
+		}
+	}
+	if _, ok := data["block_layout"]; ok {
+		// A standalone sheet settings file
+		renameLegacySheetSettingsKeys(data)
+	}
+	return nil
+}
+
+func renameLegacySheetSettingsKeys(settings map[string]any) {
+	renameJSONKey(settings, "hit_locations", "body_type")
+	renameJSONKey(settings, "show_advantage_modifier_adj", "show_trait_modifier_adj")
+}
+
+// renameJSONKey moves the value stored under the old key to the new key, unless the new key is already present.
+func renameJSONKey(data map[string]any, oldKey, newKey string) {
+	if value, ok := data[oldKey]; ok {
+		if _, exists := data[newKey]; !exists {
+			data[newKey] = value
+		}
+		delete(data, oldKey)
+	}
+}
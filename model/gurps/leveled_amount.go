@@ -13,16 +13,19 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/eval"
 	"github.com/richardwilkes/toolbox/i18n"
 )
 
 // LeveledAmount holds an amount that can be either a fixed amount, or an amount per level.
 type LeveledAmount struct {
-	Level    fxp.Int `json:"-"`
-	Amount   fxp.Int `json:"amount"`
-	PerLevel bool    `json:"per_level,omitempty"`
+	Level      fxp.Int `json:"-"`
+	Amount     fxp.Int `json:"amount"`
+	Expression string  `json:"expr,omitempty"`
+	PerLevel   bool    `json:"per_level,omitempty"`
 }
 
 // AdjustedAmount returns the amount, adjusted for level, if requested.
@@ -36,6 +39,13 @@ func (l *LeveledAmount) AdjustedAmount() fxp.Int {
 	return l.Amount
 }
 
+// ResolveExpression updates the amount from its expression, if it has one.
+func (l *LeveledAmount) ResolveExpression(resolver eval.VariableResolver) {
+	if expr := strings.TrimSpace(l.Expression); expr != "" {
+		l.Amount = fxp.EvaluateToNumber(expr, resolver)
+	}
+}
+
 // Format the value.
 func (l *LeveledAmount) Format(asPercentage bool) string {
 	amt := l.Amount.StringWithSign()
@@ -58,5 +68,6 @@ func (l *LeveledAmount) Hash(h hash.Hash) {
 		return
 	}
 	_ = binary.Write(h, binary.LittleEndian, l.Amount)
+	_, _ = h.Write([]byte(l.Expression))
 	_ = binary.Write(h, binary.LittleEndian, l.PerLevel)
 }
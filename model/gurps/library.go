@@ -130,6 +130,11 @@ func (l *Library) CleanupFavorites() {
 	l.Favorites = favs
 }
 
+// ScanForFiles scans the entire directory tree of this library for files with any of the given extensions.
+func (l *Library) ScanForFiles(extensions ...string) []*NamedFileRef {
+	return scanForNamedFileSets(os.DirFS(l.Path()), ".", extensions, false, make(map[string]bool))
+}
+
 // Watch for changes in the directory tree of this library.
 func (l *Library) Watch(callback func(lib *Library, fullPath string, what notify.Event), callbackOnUIThread bool) *MonitorToken {
 	return l.monitor.newWatch(callback, callbackOnUIThread)
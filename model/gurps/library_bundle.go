@@ -0,0 +1,372 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// LibraryBundleExt is the extension used for library bundles, which are ordinary zip archives.
+const LibraryBundleExt = ".zip"
+
+const libraryBundleManifestName = "bundle.json"
+
+// LibraryBundle describes the contents of a library bundle.
+type LibraryBundle struct {
+	Version   int                   `json:"version"`
+	Created   jio.Time              `json:"created"`
+	Libraries []*LibraryBundleEntry `json:"libraries"`
+}
+
+// LibraryBundleEntry describes one library within a library bundle.
+type LibraryBundleEntry struct {
+	Key     string `json:"key"`
+	Title   string `json:"title"`
+	Release string `json:"release,omitempty"`
+	// Dir is the directory within the archive holding the library's files.
+	Dir string `json:"dir"`
+	// Complete is true if the whole library was bundled, rather than just the files some data depended upon.
+	Complete  bool `json:"complete,omitempty"`
+	FileCount int  `json:"file_count"`
+}
+
+// ExportLibraryBundle writes the complete contents of the libraries into a bundle at filePath. Hidden files and
+// directories, such as those used by version control, are skipped.
+func ExportLibraryBundle(filePath string, libs []*Library) error {
+	files := make(map[*Library][]string, len(libs))
+	for _, lib := range libs {
+		root := lib.Path()
+		var list []string
+		if err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p == root {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.Type().IsRegular() {
+				var rel string
+				if rel, err = filepath.Rel(root, p); err != nil {
+					return err
+				}
+				list = append(list, rel)
+			}
+			return nil
+		}); err != nil {
+			return errs.NewWithCause(i18n.Text("unable to read library ")+lib.Title, err)
+		}
+		files[lib] = list
+	}
+	return writeLibraryBundle(filePath, libs, files, true)
+}
+
+// ExportLibraryDependencyBundle writes the library files into a bundle at filePath. Files that can't be found, because
+// their library isn't configured or they no longer exist, are returned in missing rather than causing a failure.
+func ExportLibraryDependencyBundle(filePath string, dependencies []LibraryFile) (missing []LibraryFile, err error) {
+	libs := GlobalSettings().Libraries()
+	files := make(map[*Library][]string)
+	var order []*Library
+	for _, one := range dependencies {
+		lib, ok := libs[one.Library]
+		if !ok || !xfs.FileIsReadable(filepath.Join(lib.PathOnDisk, one.Path)) {
+			missing = append(missing, one)
+			continue
+		}
+		if _, exists := files[lib]; !exists {
+			order = append(order, lib)
+		}
+		files[lib] = append(files[lib], one.Path)
+	}
+	if len(order) == 0 {
+		return missing, errs.New(i18n.Text("none of the library files could be found"))
+	}
+	return missing, writeLibraryBundle(filePath, order, files, false)
+}
+
+func writeLibraryBundle(filePath string, libs []*Library, files map[*Library][]string, complete bool) (err error) {
+	var f *os.File
+	if f, err = os.Create(filePath); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(filePath) //nolint:errcheck // The original error is more useful
+		}
+	}()
+	zw := zip.NewWriter(f)
+	bundle := LibraryBundle{
+		Version: jio.CurrentDataVersion,
+		Created: jio.Now(),
+	}
+	for i, lib := range libs {
+		entry := &LibraryBundleEntry{
+			Key:       lib.Key(),
+			Title:     lib.Title,
+			Release:   lib.VersionOnDisk(),
+			Dir:       fmt.Sprintf("%d-%s", i+1, xfs.SanitizeName(lib.Title)),
+			Complete:  complete,
+			FileCount: len(files[lib]),
+		}
+		bundle.Libraries = append(bundle.Libraries, entry)
+		for _, rel := range files[lib] {
+			if err = addFileToLibraryBundle(zw, filepath.Join(lib.PathOnDisk, rel),
+				path.Join(entry.Dir, filepath.ToSlash(rel))); err != nil {
+				return err
+			}
+		}
+	}
+	var w io.Writer
+	if w, err = zw.CreateHeader(&zip.FileHeader{
+		Name:     libraryBundleManifestName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	}); err != nil {
+		return errs.Wrap(err)
+	}
+	if err = jio.Save(context.Background(), w, &bundle); err != nil {
+		return err
+	}
+	return errs.Wrap(zw.Close())
+}
+
+func addFileToLibraryBundle(zw *zip.Writer, srcPath, name string) error {
+	fi, err := os.Stat(srcPath)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	var hdr *zip.FileHeader
+	if hdr, err = zip.FileInfoHeader(fi); err != nil {
+		return errs.Wrap(err)
+	}
+	hdr.Name = name
+	hdr.Method = zip.Deflate
+	hdr.SetMode(0o640)
+	var w io.Writer
+	if w, err = zw.CreateHeader(hdr); err != nil {
+		return errs.Wrap(err)
+	}
+	var r *os.File
+	if r, err = os.Open(srcPath); err != nil {
+		return errs.Wrap(err)
+	}
+	defer xio.CloseIgnoringErrors(r)
+	_, err = io.Copy(w, r)
+	return errs.Wrap(err)
+}
+
+// ReadLibraryBundle returns the description of the contents of the bundle at filePath.
+func ReadLibraryBundle(filePath string) (*LibraryBundle, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	defer xio.CloseIgnoringErrors(zr)
+	return readLibraryBundleManifest(&zr.Reader)
+}
+
+func readLibraryBundleManifest(zr *zip.Reader) (*LibraryBundle, error) {
+	var bundle LibraryBundle
+	if err := jio.LoadFromFS(context.Background(), zr, libraryBundleManifestName, &bundle); err != nil {
+		return nil, errs.NewWithCause(i18n.Text("not a library bundle"), err)
+	}
+	if err := jio.CheckVersion(bundle.Version); err != nil {
+		return nil, err
+	}
+	for _, entry := range bundle.Libraries {
+		if !fs.ValidPath(entry.Dir) || !strings.Contains(entry.Key, "/") {
+			return nil, errs.New(InvalidFileData())
+		}
+	}
+	return &bundle, nil
+}
+
+// ImportLibraryBundle extracts the bundle at filePath into the libraries. Files are written into the configured
+// library with the same key, replacing any existing files of the same name. Libraries that aren't configured are
+// created in the default library location. Returns the libraries that received files.
+func ImportLibraryBundle(filePath string) ([]*Library, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	defer xio.CloseIgnoringErrors(zr)
+	var bundle *LibraryBundle
+	if bundle, err = readLibraryBundleManifest(&zr.Reader); err != nil {
+		return nil, err
+	}
+	libs := GlobalSettings().LibrarySet
+	imported := make([]*Library, 0, len(bundle.Libraries))
+	for _, entry := range bundle.Libraries {
+		lib, exists := libs[entry.Key]
+		if !exists {
+			lib = &Library{Title: entry.Title}
+			lib.ConfigureForKey(entry.Key)
+			if err = lib.SetPath(uniqueLibraryPath(entry.Title)); err != nil {
+				return imported, err
+			}
+			libs[lib.Key()] = lib
+		}
+		if err = extractLibraryBundleEntry(&zr.Reader, entry, lib); err != nil {
+			return imported, err
+		}
+		current := lib.VersionOnDisk()
+		lib.lock.Lock()
+		lib.current = current
+		lib.lock.Unlock()
+		imported = append(imported, lib)
+	}
+	if NotifyOfLibraryChangeFunc != nil {
+		NotifyOfLibraryChangeFunc()
+	}
+	return imported, nil
+}
+
+func extractLibraryBundleEntry(zr *zip.Reader, entry *LibraryBundleEntry, lib *Library) error {
+	root := filepath.Clean(lib.Path())
+	rootWithTrailingSep := root
+	if !strings.HasSuffix(rootWithTrailingSep, string(filepath.Separator)) {
+		rootWithTrailingSep += string(filepath.Separator)
+	}
+	prefix := entry.Dir + "/"
+	for _, f := range zr.File {
+		if f.FileInfo().Mode()&os.ModeType != 0 || !strings.HasPrefix(f.Name, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(f.Name, prefix)
+		// A partial bundle must not change the release the library reports, since most of its files weren't included.
+		if !entry.Complete && rel == releaseFile {
+			continue
+		}
+		fullPath := filepath.Join(root, filepath.FromSlash(rel))
+		if !strings.HasPrefix(fullPath, rootWithTrailingSep) {
+			return errs.Newf("path outside of root is not permitted: %s", fullPath)
+		}
+		parent := filepath.Dir(fullPath)
+		if err := os.MkdirAll(parent, 0o750); err != nil {
+			return errs.NewWithCause("unable to create "+parent, err)
+		}
+		if err := lib.extractFile(f, fullPath); err != nil {
+			return errs.NewWithCause("unable to create "+fullPath, err)
+		}
+	}
+	return nil
+}
+
+// uniqueLibraryPath returns a path within the default library location, based on the title, that isn't in use.
+func uniqueLibraryPath(title string) string {
+	base := filepath.Join(DefaultRootLibraryPath(), xfs.SanitizeName(title))
+	p := base
+	for i := 2; xfs.FileExists(p) || xfs.IsDir(p); i++ {
+		p = fmt.Sprintf("%s %d", base, i)
+	}
+	return p
+}
+
+// LibraryDependencies returns the library files the data in the given sheets, templates and campaigns was copied from,
+// along with any house rules files and templates they refer to.
+func LibraryDependencies(paths ...string) ([]LibraryFile, error) {
+	m := make(map[LibraryFile]struct{})
+	for _, p := range paths {
+		dir := os.DirFS(filepath.Dir(p))
+		file := filepath.Base(p)
+		switch strings.ToLower(filepath.Ext(p)) {
+		case SheetExt:
+			e, err := NewEntityFromFile(dir, file)
+			if err != nil {
+				return nil, errs.NewWithCause(p, err)
+			}
+			e.collectLibraryDependencies(m)
+		case TemplatesExt:
+			t, err := NewTemplateFromFile(dir, file)
+			if err != nil {
+				return nil, errs.NewWithCause(p, err)
+			}
+			collectSourceLibraryFiles(m, t.Traits, t.Skills, t.Spells, t.Notes, t.Equipment)
+		case CampaignExt:
+			c, err := NewCampaignFromFile(dir, file)
+			if err != nil {
+				return nil, errs.NewWithCause(p, err)
+			}
+			c.collectLibraryDependencies(m)
+		default:
+			return nil, errs.Newf(i18n.Text("unsupported file type: %s"), p)
+		}
+	}
+	list := make([]LibraryFile, 0, len(m))
+	for one := range m {
+		list = append(list, one)
+	}
+	slices.SortFunc(list, func(a, b LibraryFile) int {
+		if a.Library != b.Library {
+			return txt.NaturalCmp(a.Library, b.Library, true)
+		}
+		return txt.NaturalCmp(a.Path, b.Path, true)
+	})
+	return list, nil
+}
+
+func (e *Entity) collectLibraryDependencies(m map[LibraryFile]struct{}) {
+	collectSourceLibraryFiles(m, e.Traits, e.Skills, e.Spells, e.Notes, e.CarriedEquipment, e.OtherEquipment)
+	e.SheetSettings.collectLibraryDependencies(m)
+	for _, one := range e.AppliedTemplates {
+		if one.Library != "" {
+			m[one.LibraryFile] = struct{}{}
+		}
+	}
+}
+
+func (s *SheetSettings) collectLibraryDependencies(m map[LibraryFile]struct{}) {
+	if s == nil {
+		return
+	}
+	for _, one := range s.HouseRules {
+		m[one] = struct{}{}
+	}
+	if s.ExportDecorations != nil && s.ExportDecorations.WatermarkImage != nil &&
+		s.ExportDecorations.WatermarkImage.Library != "" {
+		m[*s.ExportDecorations.WatermarkImage] = struct{}{}
+	}
+}
+
+func (c *Campaign) collectLibraryDependencies(m map[LibraryFile]struct{}) {
+	collectSourceLibraryFiles(m, c.Traits, c.Skills, c.Spells, c.Notes, c.Equipment)
+	c.SheetSettings.collectLibraryDependencies(m)
+	for _, t := range c.Templates {
+		collectSourceLibraryFiles(m, t.Traits, t.Skills, t.Spells, t.Notes, t.Equipment)
+	}
+	for _, e := range c.Characters {
+		e.collectLibraryDependencies(m)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLibraryBundleRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	check.NoError(t, os.MkdirAll(filepath.Join(src, "Traits"), 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(src, "Traits", "Basic.adq"), []byte("traits"), 0o640))
+	check.NoError(t, os.WriteFile(filepath.Join(src, "Notes.not"), []byte("notes"), 0o640))
+	check.NoError(t, os.WriteFile(filepath.Join(src, releaseFile), []byte("1.2.3\n"), 0o640))
+	lib := &Library{Title: "Test Library", GitHubAccountName: "someone", RepoName: "test", PathOnDisk: src}
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle"+LibraryBundleExt)
+	files := map[*Library][]string{lib: {filepath.Join("Traits", "Basic.adq"), releaseFile}}
+	check.NoError(t, writeLibraryBundle(bundlePath, []*Library{lib}, files, false))
+
+	bundle, err := ReadLibraryBundle(bundlePath)
+	check.NoError(t, err)
+	check.Equal(t, 1, len(bundle.Libraries))
+	entry := bundle.Libraries[0]
+	check.Equal(t, "someone/test", entry.Key)
+	check.Equal(t, "Test Library", entry.Title)
+	check.Equal(t, "1.2.3", entry.Release)
+	check.Equal(t, 2, entry.FileCount)
+	check.False(t, entry.Complete)
+
+	zr, err := zip.OpenReader(bundlePath)
+	check.NoError(t, err)
+	defer func() { check.NoError(t, zr.Close()) }()
+	dst := &Library{Title: "Copy", GitHubAccountName: "someone", RepoName: "test", PathOnDisk: t.TempDir()}
+	check.NoError(t, extractLibraryBundleEntry(&zr.Reader, entry, dst))
+	data, err := os.ReadFile(filepath.Join(dst.PathOnDisk, "Traits", "Basic.adq"))
+	check.NoError(t, err)
+	check.Equal(t, "traits", string(data))
+	_, err = os.Stat(filepath.Join(dst.PathOnDisk, "Notes.not"))
+	check.True(t, os.IsNotExist(err))
+	// The release file of a partial bundle is not extracted
+	_, err = os.Stat(filepath.Join(dst.PathOnDisk, releaseFile))
+	check.True(t, os.IsNotExist(err))
+}
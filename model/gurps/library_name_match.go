@@ -0,0 +1,272 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// libraryNameRescanInterval is the minimum time between scans of the library directories when looking for items to
+// match typed names against. Files that have been seen before are only reloaded when their modification time changes.
+const libraryNameRescanInterval = 10 * time.Second
+
+// LibraryNameMatch holds a library item, plus the library modifiers whose names, taken together with the item's name,
+// make up a name that was typed in.
+type LibraryNameMatch[T, M NodeTypes] struct {
+	Item          T
+	ItemFile      LibraryFile
+	Modifiers     []M
+	ModifierFiles []LibraryFile
+}
+
+type libraryNameEntry[T NodeTypes] struct {
+	file LibraryFile
+	data T
+}
+
+type libraryNameCacheFile struct {
+	modTime time.Time
+	data    any
+}
+
+var libraryNameCache = struct {
+	lock    sync.Mutex
+	scanned map[string]time.Time
+	paths   map[string][]string
+	files   map[string]*libraryNameCacheFile
+}{
+	scanned: make(map[string]time.Time),
+	paths:   make(map[string][]string),
+	files:   make(map[string]*libraryNameCacheFile),
+}
+
+// MatchEquipmentName looks for an equipment item in the libraries whose name, combined with the names of one or more
+// equipment modifiers, makes up the given name. For example, "Fine Thrusting Broadsword" would match the "Thrusting
+// Broadsword" equipment with the "Fine" modifier. Returns nil if no such combination can be found.
+func MatchEquipmentName(name string) *LibraryNameMatch[*Equipment, *EquipmentModifier] {
+	return matchLibraryName(name,
+		libraryNameEntries(EquipmentExt, NewEquipmentFromFile),
+		libraryNameEntries(EquipmentModifiersExt, NewEquipmentModifiersFromFile),
+		func(e *Equipment) string { return e.Name },
+		func(m *EquipmentModifier) string { return m.Name })
+}
+
+// MatchTraitName looks for a trait in the libraries whose name, combined with the names of one or more trait
+// modifiers, makes up the given name. Returns nil if no such combination can be found.
+func MatchTraitName(name string) *LibraryNameMatch[*Trait, *TraitModifier] {
+	return matchLibraryName(name,
+		libraryNameEntries(TraitsExt, NewTraitsFromFile),
+		libraryNameEntries(TraitModifiersExt, NewTraitModifiersFromFile),
+		func(t *Trait) string { return t.Name },
+		func(m *TraitModifier) string { return m.Name })
+}
+
+// Equipment returns a new piece of equipment built from the matched library data.
+func (m *LibraryNameMatch[T, M]) Equipment(owner DataOwner) *Equipment {
+	item, ok := any(m.Item).(*Equipment)
+	if !ok {
+		return nil
+	}
+	eqp := item.Clone(m.ItemFile, owner, nil, false)
+	for i, one := range m.Modifiers {
+		if mod, isMod := any(one).(*EquipmentModifier); isMod {
+			eqp.Modifiers = append(eqp.Modifiers, mod.Clone(m.ModifierFiles[i], owner, nil, false))
+		}
+	}
+	return eqp
+}
+
+// Trait returns a new trait built from the matched library data.
+func (m *LibraryNameMatch[T, M]) Trait(owner DataOwner) *Trait {
+	item, ok := any(m.Item).(*Trait)
+	if !ok {
+		return nil
+	}
+	t := item.Clone(m.ItemFile, owner, nil, false)
+	for i, one := range m.Modifiers {
+		if mod, isMod := any(one).(*TraitModifier); isMod {
+			t.Modifiers = append(t.Modifiers, mod.Clone(m.ModifierFiles[i], owner, nil, false))
+		}
+	}
+	return t
+}
+
+func matchLibraryName[T, M NodeTypes](name string, items []libraryNameEntry[T], mods []libraryNameEntry[M], itemName func(T) string, modName func(M) string) *LibraryNameMatch[T, M] {
+	itemNames := make([]string, len(items))
+	for i, one := range items {
+		itemNames[i] = itemName(one.data)
+	}
+	modNames := make([]string, len(mods))
+	for i, one := range mods {
+		modNames[i] = modName(one.data)
+	}
+	itemIndex, modIndexes := matchNameWithModifiers(name, itemNames, modNames)
+	if itemIndex == -1 {
+		return nil
+	}
+	result := &LibraryNameMatch[T, M]{
+		Item:     items[itemIndex].data,
+		ItemFile: items[itemIndex].file,
+	}
+	for _, i := range modIndexes {
+		result.Modifiers = append(result.Modifiers, mods[i].data)
+		result.ModifierFiles = append(result.ModifierFiles, mods[i].file)
+	}
+	return result
+}
+
+// matchNameWithModifiers finds the item name that covers the most words of the given name, then attempts to account
+// for each of the remaining words with modifier names. Returns the index of the item and the indexes of the modifiers,
+// or -1 if the name can't be completely accounted for or no modifiers were needed.
+func matchNameWithModifiers(name string, itemNames, modNames []string) (item int, mods []int) {
+	words := nameMatchWords(name)
+	if len(words) < 2 {
+		return -1, nil
+	}
+	modWords := make([][]string, len(modNames))
+	for i, one := range modNames {
+		modWords[i] = nameMatchWords(one)
+	}
+	type candidate struct {
+		item  int
+		start int
+		count int
+	}
+	var candidates []candidate
+	for i, one := range itemNames {
+		itemWords := nameMatchWords(one)
+		if len(itemWords) == 0 || len(itemWords) >= len(words) {
+			continue
+		}
+		for start := 0; start+len(itemWords) <= len(words); start++ {
+			if slices.Equal(words[start:start+len(itemWords)], itemWords) {
+				candidates = append(candidates, candidate{item: i, start: start, count: len(itemWords)})
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int { return b.count - a.count })
+	for _, c := range candidates {
+		before, ok := matchModifierWords(words[:c.start], modWords)
+		if !ok {
+			continue
+		}
+		var after []int
+		if after, ok = matchModifierWords(words[c.start+c.count:], modWords); ok {
+			return c.item, append(before, after...)
+		}
+	}
+	return -1, nil
+}
+
+// matchModifierWords accounts for every word with modifier names, preferring the modifiers that cover the most words
+// at each step.
+func matchModifierWords(words []string, modWords [][]string) (mods []int, ok bool) {
+	for len(words) != 0 {
+		best := -1
+		for i, one := range modWords {
+			if len(one) != 0 && len(one) <= len(words) && slices.Equal(words[:len(one)], one) &&
+				(best == -1 || len(one) > len(modWords[best])) && !slices.Contains(mods, i) {
+				best = i
+			}
+		}
+		if best == -1 {
+			return nil, false
+		}
+		mods = append(mods, best)
+		words = words[len(modWords[best]):]
+	}
+	return mods, true
+}
+
+// nameMatchWords returns the lowercased words of a name, ignoring any parenthetical portions and punctuation, so that a
+// modifier named "Fine (Accuracy)" can be matched by just "fine".
+func nameMatchWords(name string) []string {
+	var buffer strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth > 0 {
+				depth--
+			}
+		case depth > 0:
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '\'':
+			buffer.WriteRune(r)
+		default:
+			buffer.WriteByte(' ')
+		}
+	}
+	return strings.Fields(buffer.String())
+}
+
+func libraryNameEntries[T NodeTypes](ext string, loader func(fs.FS, string) ([]T, error)) []libraryNameEntry[T] {
+	libraryNameCache.lock.Lock()
+	defer libraryNameCache.lock.Unlock()
+	libs := GlobalSettings().Libraries()
+	if time.Since(libraryNameCache.scanned[ext]) > libraryNameRescanInterval {
+		var paths []string
+		for _, lib := range libs.List() {
+			_ = filepath.WalkDir(lib.Path(), func(p string, d fs.DirEntry, err error) error { //nolint:errcheck // We want to continue on even if there was an error
+				if err != nil {
+					return nil
+				}
+				if strings.HasPrefix(d.Name(), ".") && p != lib.Path() {
+					if d.IsDir() {
+						return fs.SkipDir
+					}
+					return nil
+				}
+				if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ext) {
+					paths = append(paths, p)
+				}
+				return nil
+			})
+		}
+		libraryNameCache.paths[ext] = paths
+		libraryNameCache.scanned[ext] = time.Now()
+	}
+	var list []libraryNameEntry[T]
+	for _, p := range libraryNameCache.paths[ext] {
+		stat, err := os.Stat(p)
+		if err != nil {
+			delete(libraryNameCache.files, p)
+			continue
+		}
+		cached, exists := libraryNameCache.files[p]
+		if !exists || stat.ModTime().After(cached.modTime) {
+			var data []T
+			if data, err = loader(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+				delete(libraryNameCache.files, p)
+				continue
+			}
+			cached = &libraryNameCacheFile{modTime: stat.ModTime(), data: data}
+			libraryNameCache.files[p] = cached
+		}
+		data, ok := cached.data.([]T)
+		if !ok {
+			continue
+		}
+		libFile, _ := LibraryFileForPath(p)
+		Traverse(func(one T) bool {
+			list = append(list, libraryNameEntry[T]{file: libFile, data: one})
+			return false
+		}, false, true, data...)
+	}
+	return list
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestMatchNameWithModifiers(t *testing.T) {
+	items := []string{"Broadsword", "Thrusting Broadsword", "Large Knife"}
+	mods := []string{"Fine (Accuracy)", "Very Fine", "Balanced", "Cheap"}
+
+	item, list := matchNameWithModifiers("Fine Thrusting Broadsword", items, mods)
+	check.Equal(t, 1, item, "longest item name wins")
+	check.Equal(t, []int{0}, list)
+
+	item, list = matchNameWithModifiers("very fine, balanced broadsword", items, mods)
+	check.Equal(t, 0, item)
+	check.Equal(t, []int{1, 2}, list, "multi-word modifiers are preferred")
+
+	item, list = matchNameWithModifiers("Large Knife (Cheap)", items, mods)
+	check.Equal(t, -1, item, "parenthetical text in typed names isn't used")
+	check.Equal(t, 0, len(list))
+
+	item, _ = matchNameWithModifiers("Broadsword", items, mods)
+	check.Equal(t, -1, item, "no modifiers needed")
+
+	item, _ = matchNameWithModifiers("Fine Broadsword of Doom", items, mods)
+	check.Equal(t, -1, item, "every word must be accounted for")
+
+	item, _ = matchNameWithModifiers("Fine Fine Broadsword", items, mods)
+	check.Equal(t, -1, item, "a modifier may only be used once")
+}
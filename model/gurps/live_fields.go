@@ -0,0 +1,178 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+)
+
+// LiveFieldRegex is a regex for extracting live field references, such as {{ST}} or {{skill:Stealth}}.
+var LiveFieldRegex = regexp.MustCompile(`\{\{[^{}]+\}\}`)
+
+// Live field reference kinds. A reference without a kind is treated as an attribute or one of the derived values.
+const (
+	liveFieldAttributeKind = "attr"
+	liveFieldSkillKind     = "skill"
+	liveFieldSpellKind     = "spell"
+)
+
+// HasLiveFields returns true if the text contains any live field references.
+func HasLiveFields(s string) bool {
+	return LiveFieldRegex.MatchString(s)
+}
+
+// ResolveLiveFields replaces any live field references in the text with their current values.
+func (e *Entity) ResolveLiveFields(s string) string {
+	if e == nil || !strings.Contains(s, "{{") {
+		return s
+	}
+	return LiveFieldRegex.ReplaceAllStringFunc(s, e.LiveFieldValue)
+}
+
+// ResolveNoteText resolves both the live field references and the embedded expressions in note text.
+func (e *Entity) ResolveNoteText(s string) string {
+	return EvalEmbeddedRegex.ReplaceAllStringFunc(e.ResolveLiveFields(s), e.EmbeddedEval)
+}
+
+// LiveFieldValue resolves a single live field reference, including its surrounding braces, to its current value. The
+// reference may name an attribute by ID or name ({{ST}}, {{Basic Speed}}), a skill with an optional specialization
+// ({{skill:Stealth}}, {{skill:Guns (Pistol)}}), a spell ({{spell:Fireball}}), or one of dodge, move, basic lift,
+// encumbrance, points, unspent or name. References that can't be resolved are returned unchanged, so that mistakes
+// remain visible.
+func (e *Entity) LiveFieldValue(ref string) string {
+	if e == nil || len(ref) < 5 {
+		return ref
+	}
+	field := strings.TrimSpace(ref[2 : len(ref)-2])
+	kind := ""
+	if i := strings.IndexByte(field, ':'); i != -1 {
+		kind = strings.ToLower(strings.TrimSpace(field[:i]))
+		field = strings.TrimSpace(field[i+1:])
+	}
+	var result string
+	var ok bool
+	switch kind {
+	case "":
+		if result, ok = e.liveAttributeValue(field); !ok {
+			result, ok = e.liveDerivedValue(field)
+		}
+	case liveFieldAttributeKind:
+		result, ok = e.liveAttributeValue(field)
+	case liveFieldSkillKind:
+		result, ok = e.liveSkillValue(field)
+	case liveFieldSpellKind:
+		result, ok = e.liveSpellValue(field)
+	}
+	if !ok {
+		return ref
+	}
+	return result
+}
+
+func (e *Entity) liveAttributeValue(name string) (string, bool) {
+	attr := e.ResolveAttribute(strings.ToLower(name))
+	if attr == nil {
+		for _, one := range e.Attributes.List() {
+			if def := one.AttributeDef(); def != nil &&
+				(strings.EqualFold(def.Name, name) || strings.EqualFold(def.FullName, name)) {
+				attr = one
+				break
+			}
+		}
+		if attr == nil {
+			return "", false
+		}
+	}
+	def := attr.AttributeDef()
+	if def == nil {
+		return "", false
+	}
+	if def.Type == attribute.Pool || def.Type == attribute.PoolRef {
+		return attr.Current().String() + "/" + attr.Maximum().String(), true
+	}
+	return attr.Maximum().String(), true
+}
+
+func (e *Entity) liveDerivedValue(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "dodge":
+		return strconv.Itoa(e.Dodge(e.EncumbranceLevel(true))), true
+	case "move":
+		return strconv.Itoa(e.Move(e.EncumbranceLevel(true))), true
+	case "basic lift", "basic_lift":
+		return e.SheetSettings.DefaultWeightUnits.Format(e.BasicLift()), true
+	case "encumbrance":
+		return e.EncumbranceLevel(true).String(), true
+	case "points":
+		return e.TotalPoints.String(), true
+	case "unspent":
+		return e.UnspentPoints().String(), true
+	case "name":
+		return e.Profile.Name, true
+	default:
+		return "", false
+	}
+}
+
+func (e *Entity) liveSkillValue(field string) (string, bool) {
+	name := field
+	specialization := ""
+	if strings.HasSuffix(field, ")") {
+		if i := strings.LastIndexByte(field, '('); i > 0 {
+			name = strings.TrimSpace(field[:i])
+			specialization = strings.TrimSpace(field[i+1 : len(field)-1])
+		}
+	}
+	sk := e.BestSkillNamed(name, specialization, false, nil)
+	if sk == nil {
+		return "", false
+	}
+	return liveLevel(sk.CalculateLevel(nil).Level), true
+}
+
+func (e *Entity) liveSpellValue(name string) (string, bool) {
+	var best *Spell
+	level := fxp.Min
+	Traverse(func(sp *Spell) bool {
+		if strings.EqualFold(sp.NameWithReplacements(), name) {
+			if spellLevel := sp.CalculateLevel().Level; best == nil || level < spellLevel {
+				best = sp
+				level = spellLevel
+			}
+		}
+		return false
+	}, false, true, e.Spells...)
+	if best == nil {
+		return "", false
+	}
+	return liveLevel(level), true
+}
+
+func liveLevel(level fxp.Int) string {
+	if level <= 0 {
+		return "-"
+	}
+	return level.Trunc().String()
+}
+
+// NotesHaveLiveFields returns true if any of the entity's notes contain live field references.
+func (e *Entity) NotesHaveLiveFields() bool {
+	found := false
+	Traverse(func(n *Note) bool {
+		found = HasLiveFields(n.Text)
+		return found
+	}, false, false, e.Notes...)
+	return found
+}
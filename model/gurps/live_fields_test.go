@@ -0,0 +1,38 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLiveFields(t *testing.T) {
+	e := NewEntity()
+	sk := NewSkill(e, nil, false)
+	sk.Name = "Guns"
+	sk.Specialization = "Pistol"
+	e.Skills = []*Skill{sk}
+	note := NewNote(e, nil, false)
+	note.Text = "ST {{ST}}, Guns {{skill:Guns (Pistol)}}, {{skill:Stealth}}, {{bogus}}"
+	e.Notes = []*Note{note}
+	e.Recalculate()
+
+	st := e.Attributes.Maximum(StrengthID).String()
+	level := sk.CalculateLevel(nil).Level.Trunc().String()
+	check.Equal(t, "ST "+st+", Guns "+level+", {{skill:Stealth}}, {{bogus}}", note.String())
+	check.Equal(t, st, e.LiveFieldValue("{{ attr:st }}"))
+	check.Equal(t, e.Profile.Name, e.LiveFieldValue("{{name}}"))
+	check.True(t, e.NotesHaveLiveFields())
+
+	note.Text = "Nothing live here"
+	check.False(t, e.NotesHaveLiveFields())
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Loadout holds a named set of equipment to carry, such as "Town" or "Dungeon". Only top-level equipment is tracked for
+// placement, since the contents of a container go wherever it goes.
+type Loadout struct {
+	Name     string    `json:"name"`
+	Carried  []tid.TID `json:"carried,omitempty"`
+	Other    []tid.TID `json:"other,omitempty"`
+	Equipped []tid.TID `json:"equipped,omitempty"`
+}
+
+// CloneLoadoutList creates a clone of the provided Loadout list.
+func CloneLoadoutList(list []*Loadout) []*Loadout {
+	clone := make([]*Loadout, len(list))
+	for i, one := range list {
+		l := *one
+		l.Carried = slices.Clone(one.Carried)
+		l.Other = slices.Clone(one.Other)
+		l.Equipped = slices.Clone(one.Equipped)
+		clone[i] = &l
+	}
+	return clone
+}
+
+// String implements fmt.Stringer.
+func (l *Loadout) String() string {
+	return l.Name
+}
+
+// SetLoadouts sets a new loadout list.
+func (e *Entity) SetLoadouts(list []*Loadout) {
+	e.Loadouts = CloneLoadoutList(list)
+}
+
+// LoadoutByName returns the loadout with the given name, ignoring case, or nil.
+func (e *Entity) LoadoutByName(name string) *Loadout {
+	name = strings.TrimSpace(name)
+	for _, one := range e.Loadouts {
+		if strings.EqualFold(one.Name, name) {
+			return one
+		}
+	}
+	return nil
+}
+
+func (e *Entity) currentLoadout(name string) *Loadout {
+	l := &Loadout{Name: strings.TrimSpace(name)}
+	for _, eqp := range e.CarriedEquipment {
+		l.Carried = append(l.Carried, eqp.TID)
+	}
+	for _, eqp := range e.OtherEquipment {
+		l.Other = append(l.Other, eqp.TID)
+	}
+	Traverse(func(eqp *Equipment) bool {
+		if eqp.Equipped {
+			l.Equipped = append(l.Equipped, eqp.TID)
+		}
+		return false
+	}, false, false, e.CarriedEquipment...)
+	return l
+}
+
+// CaptureLoadout records the equipment currently carried and equipped as a loadout with the given name, replacing any
+// existing loadout with that name.
+func (e *Entity) CaptureLoadout(name string) *Loadout {
+	l := e.currentLoadout(name)
+	if existing := e.LoadoutByName(l.Name); existing != nil {
+		*existing = *l
+		return existing
+	}
+	e.Loadouts = append(e.Loadouts, l)
+	return l
+}
+
+// ApplyLoadout moves top-level equipment between the carried and other equipment lists to match the loadout, then sets
+// the equipped state of the carried equipment. Equipment acquired since the loadout was recorded is left as it is.
+func (e *Entity) ApplyLoadout(l *Loadout) {
+	carry := make(map[tid.TID]bool, len(l.Carried))
+	for _, id := range l.Carried {
+		carry[id] = true
+	}
+	leave := make(map[tid.TID]bool, len(l.Other))
+	for _, id := range l.Other {
+		leave[id] = true
+	}
+	var carried, other []*Equipment
+	for _, eqp := range e.CarriedEquipment {
+		if leave[eqp.TID] {
+			other = append(other, eqp)
+		} else {
+			carried = append(carried, eqp)
+		}
+	}
+	for _, eqp := range e.OtherEquipment {
+		if carry[eqp.TID] {
+			carried = append(carried, eqp)
+		} else {
+			other = append(other, eqp)
+		}
+	}
+	e.CarriedEquipment = carried
+	e.OtherEquipment = other
+	equipped := make(map[tid.TID]bool, len(l.Equipped))
+	for _, id := range l.Equipped {
+		equipped[id] = true
+	}
+	for _, top := range e.CarriedEquipment {
+		if carry[top.TID] || leave[top.TID] {
+			Traverse(func(eqp *Equipment) bool {
+				eqp.Equipped = equipped[eqp.TID]
+				return false
+			}, false, false, top)
+		}
+	}
+	e.Recalculate()
+}
+
+// RemoveLoadout removes the loadout from the entity.
+func (e *Entity) RemoveLoadout(l *Loadout) {
+	e.Loadouts = slices.DeleteFunc(e.Loadouts, func(one *Loadout) bool { return one == l })
+}
+
+// IsLoadoutActive returns true if the carried and equipped equipment currently matches the loadout.
+func (e *Entity) IsLoadoutActive(l *Loadout) bool {
+	current := e.currentLoadout(l.Name)
+	return sameTIDs(current.Carried, l.Carried) && sameTIDs(current.Equipped, l.Equipped)
+}
+
+func sameTIDs(a, b []tid.TID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
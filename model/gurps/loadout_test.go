@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLoadouts(t *testing.T) {
+	e := gurps.NewEntity()
+	sword := gurps.NewEquipment(e, nil, false)
+	sword.Name = "Sword"
+	armor := gurps.NewEquipment(e, nil, false)
+	armor.Name = "Armor"
+	clothes := gurps.NewEquipment(e, nil, false)
+	clothes.Name = "Fine Clothes"
+	e.CarriedEquipment = []*gurps.Equipment{sword, armor}
+	e.OtherEquipment = []*gurps.Equipment{clothes}
+
+	dungeon := e.CaptureLoadout(" Dungeon ")
+	check.Equal(t, "Dungeon", dungeon.Name)
+	check.True(t, e.IsLoadoutActive(dungeon))
+
+	armor.Equipped = false
+	e.CarriedEquipment = []*gurps.Equipment{sword, clothes}
+	e.OtherEquipment = []*gurps.Equipment{armor}
+	town := e.CaptureLoadout("Town")
+	check.Equal(t, 2, len(e.Loadouts))
+	check.False(t, e.IsLoadoutActive(dungeon))
+	check.True(t, e.IsLoadoutActive(town))
+
+	e.ApplyLoadout(dungeon)
+	check.True(t, e.IsLoadoutActive(dungeon))
+	check.Equal(t, []*gurps.Equipment{sword, armor}, e.CarriedEquipment)
+	check.Equal(t, []*gurps.Equipment{clothes}, e.OtherEquipment)
+	check.True(t, armor.Equipped)
+
+	check.Equal(t, town, e.LoadoutByName("town"))
+	check.Equal(t, town, e.CaptureLoadout("TOWN"), "same name replaces the existing loadout")
+	check.Equal(t, 2, len(e.Loadouts))
+	e.RemoveLoadout(town)
+	check.Equal(t, 1, len(e.Loadouts))
+	check.Nil(t, e.LoadoutByName("Town"))
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/merge"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// MergeOptions holds the options for merging data from one entity into another.
+type MergeOptions struct {
+	Traits           bool
+	Skills           bool
+	Spells           bool
+	CarriedEquipment bool
+	OtherEquipment   bool
+	Notes            bool
+	Resolution       merge.Resolution
+}
+
+// MergeResult holds the counts of what happened during a merge.
+type MergeResult struct {
+	Added    int
+	Replaced int
+	Skipped  int
+}
+
+// MergeFrom copies the top-level rows of the selected lists from the other entity into this one. An incoming row
+// conflicts with an existing top-level row if they share the same ID, or are of the same kind and have the same name.
+func (e *Entity) MergeFrom(other *Entity, options *MergeOptions) MergeResult {
+	var result MergeResult
+	if options.Traits {
+		e.Traits = mergeNodes(e, e.Traits, other.Traits, options.Resolution, &result)
+	}
+	if options.Skills {
+		e.Skills = mergeNodes(e, e.Skills, other.Skills, options.Resolution, &result)
+	}
+	if options.Spells {
+		e.Spells = mergeNodes(e, e.Spells, other.Spells, options.Resolution, &result)
+	}
+	if options.CarriedEquipment {
+		e.CarriedEquipment = mergeNodes(e, e.CarriedEquipment, other.CarriedEquipment, options.Resolution, &result)
+	}
+	if options.OtherEquipment {
+		e.OtherEquipment = mergeNodes(e, e.OtherEquipment, other.OtherEquipment, options.Resolution, &result)
+	}
+	if options.Notes {
+		e.Notes = mergeNodes(e, e.Notes, other.Notes, options.Resolution, &result)
+	}
+	return result
+}
+
+func mergeNodes[T NodeTypes](owner DataOwner, existing, incoming []T, resolution merge.Resolution, result *MergeResult) []T {
+	list := slices.Clone(existing)
+	ids := make(map[tid.TID]bool)
+	Traverse(func(node T) bool {
+		ids[AsNode(node).ID()] = true
+		return false
+	}, false, false, existing...)
+	var zero T
+	for _, one := range incoming {
+		node := AsNode(one)
+		index := slices.IndexFunc(list, func(t T) bool { return mergeConflicts(t, one) })
+		if index != -1 {
+			switch resolution {
+			case merge.Replace:
+				list[index] = node.Clone(LibraryFile{}, owner, zero,
+					AsNode(list[index]).ID() == node.ID() || !ids[node.ID()])
+				result.Replaced++
+				continue
+			case merge.Skip:
+				result.Skipped++
+				continue
+			default:
+			}
+		}
+		list = append(list, node.Clone(LibraryFile{}, owner, zero, !ids[node.ID()]))
+		result.Added++
+	}
+	return list
+}
+
+func mergeConflicts[T NodeTypes](existing, incoming T) bool {
+	a := AsNode(existing)
+	b := AsNode(incoming)
+	return a.ID() == b.ID() || (a.Kind() == b.Kind() && strings.EqualFold(a.String(), b.String()))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/merge"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestMergeFrom(t *testing.T) {
+	newEntityWithEquipment := func(names ...string) *Entity {
+		e := NewEntity()
+		e.CarriedEquipment = nil
+		for _, name := range names {
+			eqp := NewEquipment(e, nil, false)
+			eqp.Name = name
+			e.CarriedEquipment = append(e.CarriedEquipment, eqp)
+		}
+		return e
+	}
+	other := newEntityWithEquipment("Rope", "Torch")
+	other.CarriedEquipment[0].Quantity = 2
+
+	e := newEntityWithEquipment("rope")
+	result := e.MergeFrom(other, &MergeOptions{CarriedEquipment: true, Resolution: merge.Skip})
+	check.Equal(t, MergeResult{Added: 1, Skipped: 1}, result)
+	check.Equal(t, 2, len(e.CarriedEquipment))
+	check.Equal(t, "rope", e.CarriedEquipment[0].Name)
+
+	e = newEntityWithEquipment("rope")
+	result = e.MergeFrom(other, &MergeOptions{CarriedEquipment: true, Resolution: merge.Replace})
+	check.Equal(t, MergeResult{Added: 1, Replaced: 1}, result)
+	check.Equal(t, 2, len(e.CarriedEquipment))
+	check.Equal(t, "Rope", e.CarriedEquipment[0].Name)
+	check.Equal(t, DataOwner(e), e.CarriedEquipment[0].DataOwner())
+
+	e = newEntityWithEquipment("rope")
+	result = e.MergeFrom(other, &MergeOptions{CarriedEquipment: true, Resolution: merge.KeepBoth})
+	check.Equal(t, MergeResult{Added: 2}, result)
+	check.Equal(t, 3, len(e.CarriedEquipment))
+
+	e = newEntityWithEquipment("rope")
+	result = e.MergeFrom(other, &MergeOptions{Notes: true})
+	check.Equal(t, MergeResult{}, result)
+	check.Equal(t, 1, len(e.CarriedEquipment))
+}
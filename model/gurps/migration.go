@@ -0,0 +1,155 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+const maxMigrationChangesPerFile = 10
+
+// MigrationFile holds the planned migration of a single file.
+type MigrationFile struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Migrations  []*jio.Migration
+	Changes     []string
+	Err         error
+}
+
+// Changed returns true if migrating the file would alter its contents.
+func (m *MigrationFile) Changed() bool {
+	return len(m.Changes) != 0
+}
+
+// MigrationReport holds the result of a migration dry-run.
+type MigrationReport struct {
+	Files []*MigrationFile
+}
+
+// PlanMigration performs a dry-run of Convert on the GCS files found in the given paths, reporting what would change
+// without modifying any of them.
+func PlanMigration(paths ...string) (*MigrationReport, error) {
+	list, err := convertibleFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+	var tmpDir string
+	if tmpDir, err = os.MkdirTemp("", "gcs-migrate-"); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }() //nolint:errcheck // Nothing useful can be done about a failure here
+	report := &MigrationReport{Files: make([]*MigrationFile, 0, len(list))}
+	for i, p := range list {
+		report.Files = append(report.Files, planFileMigration(p, filepath.Join(tmpDir, strconv.Itoa(i)), tmpDir))
+	}
+	return report, nil
+}
+
+func planFileMigration(p, dst, tmpDir string) *MigrationFile {
+	f := &MigrationFile{Path: p}
+	before, err := os.ReadFile(p)
+	if err != nil {
+		f.Err = errs.Wrap(err)
+		return f
+	}
+	f.FromVersion = jio.DataVersion(before)
+	f.ToVersion = f.FromVersion
+	var handled bool
+	if f.Migrations, handled, err = migrateFile(p, dst, tmpDir); err != nil {
+		f.Err = err
+		return f
+	}
+	if !handled {
+		return f
+	}
+	var after []byte
+	if after, err = os.ReadFile(dst); err != nil {
+		f.Err = errs.Wrap(err)
+		return f
+	}
+	f.ToVersion = jio.DataVersion(after)
+	if !bytes.Equal(before, after) {
+		f.Changes = jio.DiffJSON(before, after, maxMigrationChangesPerFile)
+	}
+	return f
+}
+
+// String returns a human-readable summary of the report.
+func (r *MigrationReport) String() string {
+	type versionPair struct{ from, to int }
+	groups := make(map[versionPair][]*MigrationFile)
+	var unchanged int
+	var failed []*MigrationFile
+	for _, f := range r.Files {
+		switch {
+		case f.Err != nil:
+			failed = append(failed, f)
+		case f.Changed():
+			pair := versionPair{from: f.FromVersion, to: f.ToVersion}
+			groups[pair] = append(groups[pair], f)
+		default:
+			unchanged++
+		}
+	}
+	pairs := make([]versionPair, 0, len(groups))
+	for pair := range groups {
+		pairs = append(pairs, pair)
+	}
+	slices.SortFunc(pairs, func(a, b versionPair) int {
+		if a.from != b.from {
+			return a.from - b.from
+		}
+		return a.to - b.to
+	})
+	var buffer strings.Builder
+	for _, pair := range pairs {
+		files := groups[pair]
+		if pair.from == pair.to {
+			fmt.Fprintf(&buffer, i18n.Text("%d file(s) at v%d will be rewritten in the current format:\n"), len(files),
+				pair.from)
+		} else {
+			fmt.Fprintf(&buffer, i18n.Text("%d file(s) will be upgraded from v%d to v%d:\n"), len(files), pair.from,
+				pair.to)
+		}
+		for _, m := range jio.MigrationsFor(pair.from) {
+			if m.Version <= pair.to {
+				fmt.Fprintf(&buffer, "  v%d: %s\n", m.Version, m.Description)
+			}
+		}
+		for _, f := range files {
+			fmt.Fprintf(&buffer, "  %s\n", f.Path)
+			for _, change := range f.Changes {
+				fmt.Fprintf(&buffer, "    %s\n", change)
+			}
+		}
+	}
+	if unchanged != 0 {
+		fmt.Fprintf(&buffer, i18n.Text("%d file(s) are already current and will not be changed\n"), unchanged)
+	}
+	if len(failed) != 0 {
+		fmt.Fprintf(&buffer, i18n.Text("%d file(s) cannot be migrated:\n"), len(failed))
+		for _, f := range failed {
+			fmt.Fprintf(&buffer, "  %s: %v\n", f.Path, f.Err)
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+const legacyTemplate = `{
+	"version": 3,
+	"id": "1b2f6a2e-5f55-4f4e-9d84-6f1d0f2e6f3a",
+	"advantages": [
+		{
+			"id": "8c8f7b4a-3a5e-4a55-8a0b-92c9c2a1c3d4",
+			"type": "advantage",
+			"name": "Fit",
+			"base_points": 5
+		}
+	]
+}`
+
+func TestLegacyMigration(t *testing.T) {
+	migrated, applied, err := jio.ApplyMigrations([]byte(legacyTemplate))
+	check.NoError(t, err)
+	check.Equal(t, 1, len(applied))
+	check.Equal(t, jio.FirstGoDataVersion, applied[0].Version)
+	check.Equal(t, jio.FirstGoDataVersion, jio.DataVersion(migrated))
+	check.True(t, strings.Contains(string(migrated), `"traits"`))
+	check.False(t, strings.Contains(string(migrated), `"advantages"`))
+
+	current, applied, err := jio.ApplyMigrations([]byte(`{"version":5,"advantages":[]}`))
+	check.NoError(t, err)
+	check.Equal(t, 0, len(applied), "current data is left alone")
+	check.Equal(t, `{"version":5,"advantages":[]}`, string(current))
+}
+
+func TestPlanMigration(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Legacy"+TemplatesExt)
+	check.NoError(t, os.WriteFile(path, []byte(legacyTemplate), 0o640))
+
+	report, err := PlanMigration(dir)
+	check.NoError(t, err)
+	check.Equal(t, 1, len(report.Files))
+	f := report.Files[0]
+	check.NoError(t, f.Err)
+	check.Equal(t, 3, f.FromVersion)
+	check.Equal(t, jio.CurrentDataVersion, f.ToVersion)
+	check.Equal(t, 1, len(f.Migrations))
+	check.True(t, f.Changed())
+	text := report.String()
+	check.True(t, strings.Contains(text, "upgraded from v3 to v5"), text)
+	check.True(t, strings.Contains(text, "v4: renamed advantages to traits"), text)
+
+	data, err := os.ReadFile(path)
+	check.NoError(t, err)
+	check.Equal(t, legacyTemplate, string(data), "a dry-run doesn't modify the file")
+
+	tmpl, err := NewTemplateFromFile(os.DirFS(dir), filepath.Base(path))
+	check.NoError(t, err)
+	check.Equal(t, 1, len(tmpl.Traits))
+}
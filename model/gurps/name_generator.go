@@ -12,7 +12,12 @@ package gurps
 import (
 	"context"
 	"io/fs"
+	"path"
 	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/namegen"
 	"github.com/richardwilkes/gcs/v5/model/jio"
@@ -23,7 +28,10 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/rand"
 )
 
-var _ names.Namer = &NameGenerator{}
+var (
+	_ names.Namer = &NameGenerator{}
+	_ names.Namer = &syllableNamer{}
+)
 
 // NameGeneratorRef holds a reference to a NameGenerator.
 type NameGeneratorRef struct {
@@ -31,12 +39,15 @@ type NameGeneratorRef struct {
 	generator *NameGenerator
 }
 
-// TrainingData is only valid when Type is not namegen.Compound. Only one will be used, and they are checked
-// in the order listed here.
+// TrainingData is only valid when Type is not namegen.Compound or namegen.Syllable. Only one will be used, and they are
+// checked in the order listed here. DataFile is a path, relative to the name generator's file, of a plain text file with
+// one entry per line, optionally followed by its weight.
 type TrainingData struct {
 	BuiltIn    namegen.Builtin `json:"built_in_training_data,omitempty"`
 	Weighted   map[string]int  `json:"weighted_training_data,omitempty"`
 	Unweighted []string        `json:"training_data,omitempty"`
+	DataFile   string          `json:"training_data_file,omitempty"`
+	fileData   map[string]int
 }
 
 func (t *TrainingData) data() map[string]int {
@@ -57,14 +68,41 @@ func (t *TrainingData) data() map[string]int {
 		if len(t.Weighted) != 0 {
 			return t.Weighted
 		}
-		unweighted := make(map[string]int, len(t.Unweighted))
-		for _, k := range t.Unweighted {
-			unweighted[k] = 1
+		if len(t.Unweighted) != 0 {
+			unweighted := make(map[string]int, len(t.Unweighted))
+			for _, k := range t.Unweighted {
+				unweighted[k] = 1
+			}
+			return unweighted
 		}
-		return unweighted
+		return t.fileData
 	}
 }
 
+// ParseWeightedNameData parses plain text name data. Each non-blank line that doesn't start with '#' holds an entry,
+// optionally followed by a space, tab or comma and a positive weight. Entries without a weight have a weight of 1.
+// Entries that appear more than once have their weights combined.
+func ParseWeightedNameData(text string) map[string]int {
+	data := make(map[string]int)
+	for _, line := range strings.Split(txt.NormalizeLineEndings(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		weight := 1
+		if i := strings.LastIndexAny(line, " \t,"); i != -1 {
+			if w, err := strconv.Atoi(strings.TrimSpace(line[i+1:])); err == nil && w > 0 {
+				weight = w
+				line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line[:i]), ","))
+			}
+		}
+		if line != "" {
+			data[line] += weight
+		}
+	}
+	return data
+}
+
 func toUnweighted(data map[string]int) map[string]int {
 	unweighted := make(map[string]int, len(data))
 	for k := range data {
@@ -81,6 +119,7 @@ type NameGenerator struct {
 	Separator      string           `json:"separator,omitempty"` // Only valid for namegen.Compound
 	Depth          int              `json:"depth,omitempty"`     // Only valid for namegen.MarkovLetter
 	Compound       []*NameGenerator `json:"compound,omitempty"`  // Only valid for namegen.Compound
+	Syllables      []map[string]int `json:"syllables,omitempty"` // Only valid for namegen.Syllable
 	TrainingData
 	namer names.Namer
 }
@@ -110,6 +149,9 @@ func NewNameGeneratorFromFS(fileSystem fs.FS, filePath string) (*NameGenerator,
 	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &generator); err != nil {
 		return nil, err
 	}
+	if err := generator.loadDataFiles(fileSystem, path.Dir(filePath)); err != nil {
+		return nil, err
+	}
 	if err := generator.createNamer(); err != nil {
 		return nil, err
 	}
@@ -137,8 +179,31 @@ func (n *NameGenerator) GenerateNameWithRandomizer(rnd rand.Randomizer) string {
 	return n.namer.GenerateNameWithRandomizer(rnd)
 }
 
+func (n *NameGenerator) loadDataFiles(fileSystem fs.FS, dir string) error {
+	if n.DataFile != "" {
+		data, err := fs.ReadFile(fileSystem, path.Join(dir, n.DataFile))
+		if err != nil {
+			return errs.NewWithCause("unable to load name training data file: "+n.DataFile, err)
+		}
+		n.fileData = ParseWeightedNameData(string(data))
+	}
+	for _, one := range n.Compound {
+		if err := one.loadDataFiles(fileSystem, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (n *NameGenerator) createNamer() error {
 	n.namer = nil
+	if n.Type == namegen.Syllable {
+		if len(n.Syllables) == 0 {
+			return errs.New("no syllables specified for " + n.Type.String() + " generation type")
+		}
+		n.namer = newSyllableNamer(n.Syllables, !n.NoLowered, !n.NoFirstToUpper)
+		return nil
+	}
 	if n.Type == namegen.Compound {
 		if len(n.Compound) == 0 {
 			return errs.New("no name generators specified for " + n.Type.String() + " generation type")
@@ -177,3 +242,70 @@ func (n *NameGenerator) createNamer() error {
 		return errs.New("invalid name generator type")
 	}
 }
+
+// syllableNamer builds names by picking one syllable from each group in turn, using the weights within each group. A
+// group may hold an empty syllable to make that position optional.
+type syllableNamer struct {
+	groups       [][]weightedSyllable
+	lowered      bool
+	firstToUpper bool
+}
+
+type weightedSyllable struct {
+	syllable string
+	weight   int
+}
+
+func newSyllableNamer(groups []map[string]int, lowered, firstToUpper bool) *syllableNamer {
+	n := &syllableNamer{
+		groups:       make([][]weightedSyllable, 0, len(groups)),
+		lowered:      lowered,
+		firstToUpper: firstToUpper,
+	}
+	for _, group := range groups {
+		list := make([]weightedSyllable, 0, len(group))
+		for syllable, weight := range group {
+			if weight > 0 {
+				list = append(list, weightedSyllable{syllable: syllable, weight: weight})
+			}
+		}
+		if len(list) != 0 {
+			slices.SortFunc(list, func(a, b weightedSyllable) int { return strings.Compare(a.syllable, b.syllable) })
+			n.groups = append(n.groups, list)
+		}
+	}
+	return n
+}
+
+// GenerateName generates a new random name.
+func (n *syllableNamer) GenerateName() string {
+	return n.GenerateNameWithRandomizer(rand.NewCryptoRand())
+}
+
+// GenerateNameWithRandomizer generates a new random name using the specified randomizer.
+func (n *syllableNamer) GenerateNameWithRandomizer(rnd rand.Randomizer) string {
+	var buffer strings.Builder
+	for _, group := range n.groups {
+		total := 0
+		for _, one := range group {
+			total += one.weight
+		}
+		choice := rnd.Intn(total)
+		for _, one := range group {
+			if choice -= one.weight; choice < 0 {
+				buffer.WriteString(one.syllable)
+				break
+			}
+		}
+	}
+	name := buffer.String()
+	if n.lowered {
+		name = strings.ToLower(name)
+	}
+	if n.firstToUpper {
+		if r, size := utf8.DecodeRuneInString(name); r != utf8.RuneError {
+			name = string(unicode.ToUpper(r)) + name[size:]
+		}
+	}
+	return name
+}
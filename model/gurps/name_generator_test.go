@@ -0,0 +1,66 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestParseWeightedNameData(t *testing.T) {
+	data := ParseWeightedNameData("# comment\r\nAlda 3\nBren,2\n\nCora\nAlda\tx\nAlda 1\nSan Marco 4\n")
+	check.Equal(t, map[string]int{
+		"Alda":      5,
+		"Alda\tx":   1,
+		"Bren":      2,
+		"Cora":      1,
+		"San Marco": 4,
+	}, data)
+}
+
+func TestNameGeneratorFormats(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"names/Elf.names": &fstest.MapFile{Data: []byte(`{
+	"type": "syllable",
+	"syllables": [
+		{ "ae": 1 },
+		{ "lan": 1 },
+		{ "dor": 1 }
+	]
+}`)},
+		"names/Dwarf.names": &fstest.MapFile{Data: []byte(`{
+	"type": "simple",
+	"training_data_file": "data/dwarf.txt"
+}`)},
+		"names/data/dwarf.txt": &fstest.MapFile{Data: []byte("gimbur 5\n")},
+		"names/Bad.names":      &fstest.MapFile{Data: []byte(`{ "type": "simple", "training_data_file": "missing.txt" }`)},
+	}
+	generator, err := NewNameGeneratorFromFS(fileSystem, "names/Elf.names")
+	check.NoError(t, err)
+	check.Equal(t, "Aelandor", generator.GenerateName())
+
+	generator, err = NewNameGeneratorFromFS(fileSystem, "names/Dwarf.names")
+	check.NoError(t, err)
+	check.Equal(t, "Gimbur", generator.GenerateName())
+
+	_, err = NewNameGeneratorFromFS(fileSystem, "names/Bad.names")
+	check.Error(t, err)
+
+	namer := newSyllableNamer([]map[string]int{{"ka": 1, "": 1}, {"ri": 2}, {"": 0}}, true, true)
+	check.Equal(t, 2, len(namer.groups), "groups without positive weights are dropped")
+	for range 20 {
+		name := namer.GenerateName()
+		check.True(t, name == "Kari" || name == "Ri", name)
+		check.True(t, strings.HasSuffix(strings.ToLower(name), "ri"))
+	}
+}
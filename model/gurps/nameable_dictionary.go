@@ -0,0 +1,52 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+)
+
+// NameableSubstitution holds a campaign-wide value for a nameable key, such as the name of the local deity.
+type NameableSubstitution struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CloneNameableSubstitutions creates a copy of the substitutions.
+func CloneNameableSubstitutions(list []*NameableSubstitution) []*NameableSubstitution {
+	if len(list) == 0 {
+		return nil
+	}
+	clone := make([]*NameableSubstitution, len(list))
+	for i, one := range list {
+		s := *one
+		clone[i] = &s
+	}
+	return clone
+}
+
+// PrefillNameables sets the value of each key in m that has not been given a substitution yet to the one in the
+// dictionary, if any. Keys are matched without regard to case.
+func (s *SheetSettings) PrefillNameables(m map[string]string) {
+	if s == nil || len(s.Nameables) == 0 {
+		return
+	}
+	for k, v := range m {
+		if v != k {
+			continue
+		}
+		for _, one := range s.Nameables {
+			if one.Value != "" && strings.EqualFold(strings.TrimSpace(one.Key), k) {
+				m[k] = one.Value
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPrefillNameables(t *testing.T) {
+	s := FactorySheetSettings()
+	s.Nameables = []*NameableSubstitution{
+		{Key: "Deity", Value: "Ra"},
+		{Key: "Guild", Value: "Thieves' Guild"},
+		{Key: "Element", Value: ""},
+	}
+	m := map[string]string{
+		"deity":   "deity",
+		"Guild":   "Merchants",
+		"Element": "Element",
+		"Patron":  "Patron",
+	}
+	s.PrefillNameables(m)
+	check.Equal(t, "Ra", m["deity"], "keys match without regard to case")
+	check.Equal(t, "Merchants", m["Guild"], "existing substitutions are kept")
+	check.Equal(t, "Element", m["Element"], "empty values are ignored")
+	check.Equal(t, "Patron", m["Patron"])
+
+	clone := s.Clone(nil)
+	clone.Nameables[0].Value = "Set"
+	check.Equal(t, "Ra", s.Nameables[0].Value, "clones don't share substitutions")
+}
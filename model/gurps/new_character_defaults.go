@@ -0,0 +1,115 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/imgutil"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// NewCharacterDefaults holds the values applied to newly created characters. The sheet settings used by new characters
+// come from the global sheet settings or, when created within a campaign, the campaign's sheet settings.
+type NewCharacterDefaults struct {
+	// TemplatePath is the path to a template to apply to new characters. May be empty.
+	TemplatePath string `json:"template,omitempty"`
+	// PortraitPath is the path to an image to use as the placeholder portrait for new characters. May be empty.
+	PortraitPath string `json:"portrait,omitempty"`
+	// Attributes maps attribute IDs to the value the attribute should start at.
+	Attributes map[string]fxp.Int `json:"attributes,omitempty"`
+}
+
+// Empty returns true if no defaults have been set.
+func (d *NewCharacterDefaults) Empty() bool {
+	return d == nil || (d.TemplatePath == "" && d.PortraitPath == "" && len(d.Attributes) == 0)
+}
+
+// TemplatePathOrEmpty returns the template path, or an empty string if there are no defaults.
+func (d *NewCharacterDefaults) TemplatePathOrEmpty() string {
+	if d == nil {
+		return ""
+	}
+	return d.TemplatePath
+}
+
+// Apply the default attribute values and portrait to the entity. The template, if any, is not applied, as doing so may
+// require user interaction.
+func (d *NewCharacterDefaults) Apply(e *Entity) {
+	if d == nil {
+		return
+	}
+	if len(d.Attributes) != 0 {
+		e.Recalculate()
+		for attrID, value := range d.Attributes {
+			if attr, ok := e.Attributes.Set[attrID]; ok {
+				attr.Adjustment += value - attr.Maximum()
+			}
+		}
+	}
+	if d.PortraitPath != "" {
+		data, err := xio.RetrieveData(d.PortraitPath)
+		if err == nil {
+			data, err = imgutil.ConvertForPortraitUse(data)
+		}
+		if err != nil {
+			errs.Log(errs.NewWithCause("unable to load default portrait", err), "file", d.PortraitPath)
+		} else {
+			e.Profile.PortraitData = data
+		}
+	}
+}
+
+// AttributesText returns the default attribute values in the form accepted by ParseAttributesText.
+func (d *NewCharacterDefaults) AttributesText() string {
+	if d == nil || len(d.Attributes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(d.Attributes))
+	for k := range d.Attributes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+d.Attributes[k].String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseAttributesText parses a comma-separated list of attribute ID and value pairs, such as "st=11, dx=12", into the
+// default attribute values.
+func (d *NewCharacterDefaults) ParseAttributesText(text string) error {
+	attrs := make(map[string]fxp.Int)
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		attrID, value, found := strings.Cut(part, "=")
+		attrID = strings.ToLower(strings.TrimSpace(attrID))
+		if !found || attrID == "" {
+			return errs.Newf(i18n.Text("expected an attribute ID and value, such as st=11, but found: %s"), part)
+		}
+		v, err := fxp.FromString(strings.TrimSpace(value))
+		if err != nil {
+			return errs.NewWithCause(i18n.Text("invalid attribute value: ")+part, err)
+		}
+		attrs[attrID] = v
+	}
+	if len(attrs) == 0 {
+		attrs = nil
+	}
+	d.Attributes = attrs
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestNewCharacterDefaultsAttributes(t *testing.T) {
+	var d NewCharacterDefaults
+	check.NoError(t, d.ParseAttributesText(" DX = 12, st=11,"))
+	check.Equal(t, "dx=12, st=11", d.AttributesText())
+	check.Error(t, d.ParseAttributesText("st"))
+	check.Error(t, d.ParseAttributesText("st=x"))
+	check.Equal(t, "dx=12, st=11", d.AttributesText(), "failed parses leave the prior values")
+	check.NoError(t, d.ParseAttributesText(""))
+	check.True(t, d.Empty())
+
+	e := NewEntity()
+	d.Attributes = map[string]fxp.Int{StrengthID: fxp.From(13), "unknown": fxp.One}
+	d.Apply(e)
+	e.Recalculate()
+	check.Equal(t, fxp.From(13), e.Attributes.Current(StrengthID))
+	check.Equal(t, fxp.Three, e.Attributes.Set[StrengthID].Adjustment)
+}
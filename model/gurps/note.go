@@ -60,6 +60,7 @@ type NoteData struct {
 type NoteEditData struct {
 	NoteSyncData
 	Replacements map[string]string `json:"replacements,omitempty"`
+	Date         string            `json:"date,omitempty"`
 }
 
 // NoteSyncData holds the note sync data that is common to both containers and non-containers.
@@ -232,7 +233,7 @@ func (n *Note) String() string {
 }
 
 func (n *Note) resolveText() string {
-	return EvalEmbeddedRegex.ReplaceAllStringFunc(n.TextWithReplacements(), EntityFromNode(n).EmbeddedEval)
+	return EntityFromNode(n).ResolveNoteText(n.TextWithReplacements())
 }
 
 // NotesHeaderData returns the header data information for the given note column.
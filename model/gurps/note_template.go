@@ -0,0 +1,132 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// NoteDateFormat is the format used for the date of a note.
+const NoteDateFormat = time.DateOnly
+
+// Keys for the built-in note templates.
+const (
+	SessionLogNoteTemplateKey   = "session_log"
+	LocationNoteTemplateKey     = "location"
+	NPCEncounterNoteTemplateKey = "npc_encounter"
+)
+
+// NoteTemplate holds a reusable starting point for a note.
+type NoteTemplate struct {
+	Key      string
+	Title    string
+	Fields   []string
+	Sections []string
+}
+
+// NoteTemplates returns the built-in note templates.
+func NoteTemplates() []*NoteTemplate {
+	return []*NoteTemplate{
+		{
+			Key:   SessionLogNoteTemplateKey,
+			Title: i18n.Text("Session Log"),
+			Sections: []string{
+				i18n.Text("Summary"),
+				i18n.Text("Events"),
+				i18n.Text("Loot & Rewards"),
+				i18n.Text("Points Awarded"),
+			},
+		},
+		{
+			Key:    LocationNoteTemplateKey,
+			Title:  i18n.Text("Location"),
+			Fields: []string{i18n.Text("Name"), i18n.Text("Region")},
+			Sections: []string{
+				i18n.Text("Description"),
+				i18n.Text("Inhabitants"),
+				i18n.Text("Points of Interest"),
+				i18n.Text("Hooks"),
+			},
+		},
+		{
+			Key:    NPCEncounterNoteTemplateKey,
+			Title:  i18n.Text("NPC Encounter"),
+			Fields: []string{i18n.Text("Name"), i18n.Text("Reaction")},
+			Sections: []string{
+				i18n.Text("Description"),
+				i18n.Text("What Happened"),
+				i18n.Text("Follow-up"),
+			},
+		},
+	}
+}
+
+func (t *NoteTemplate) String() string {
+	return t.Title
+}
+
+// Text returns the text of a new note created from this template on the given date.
+func (t *NoteTemplate) Text(when time.Time) string {
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "# %s — %s\n", t.Title, when.Format(NoteDateFormat))
+	for _, one := range t.Fields {
+		fmt.Fprintf(&buffer, "\n**%s:** ", one)
+	}
+	if len(t.Fields) != 0 {
+		buffer.WriteByte('\n')
+	}
+	for _, one := range t.Sections {
+		fmt.Fprintf(&buffer, "\n## %s\n", one)
+	}
+	return buffer.String()
+}
+
+// NewNote creates a new note from this template, dated with the given time.
+func (t *NoteTemplate) NewNote(owner DataOwner, when time.Time) *Note {
+	n := NewNote(owner, nil, false)
+	n.Text = t.Text(when)
+	n.Date = when.Format(NoteDateFormat)
+	return n
+}
+
+// JournalDate returns the date of the note, if it has a valid one.
+func (n *Note) JournalDate() (time.Time, bool) {
+	if n.Date == "" {
+		return time.Time{}, false
+	}
+	when, err := time.ParseInLocation(NoteDateFormat, strings.TrimSpace(n.Date), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return when, true
+}
+
+// JournalNotes returns the notes within the list, including those inside containers, that have a valid date, ordered
+// from oldest to newest. Notes with the same date remain in the order they appear in the list.
+func JournalNotes(list []*Note) []*Note {
+	var notes []*Note
+	Traverse(func(n *Note) bool {
+		if _, ok := n.JournalDate(); ok {
+			notes = append(notes, n)
+		}
+		return false
+	}, false, false, list...)
+	slices.SortStableFunc(notes, func(a, b *Note) int {
+		aDate, _ := a.JournalDate()
+		bDate, _ := b.JournalDate()
+		return aDate.Compare(bDate)
+	})
+	return notes
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestNoteTemplates(t *testing.T) {
+	when := time.Date(2024, time.March, 5, 19, 30, 0, 0, time.Local)
+	templates := NoteTemplates()
+	check.Equal(t, 3, len(templates))
+	n := templates[0].NewNote(nil, when)
+	check.Equal(t, "2024-03-05", n.Date)
+	check.Contains(t, n.Text, "# Session Log — 2024-03-05\n")
+	check.Contains(t, n.Text, "\n## Summary\n")
+	n = templates[2].NewNote(nil, when)
+	check.Contains(t, n.Text, "**Name:** ")
+}
+
+func TestJournalNotes(t *testing.T) {
+	later := NewNote(nil, nil, false)
+	later.Date = "2024-05-01"
+	undated := NewNote(nil, nil, false)
+	bad := NewNote(nil, nil, false)
+	bad.Date = "someday"
+	container := NewNote(nil, nil, true)
+	earlier := NewNote(nil, container, false)
+	earlier.Date = "2024-01-15"
+	sameDay := NewNote(nil, nil, false)
+	sameDay.Date = "2024-05-01"
+	container.Children = []*Note{earlier}
+	journal := JournalNotes([]*Note{later, undated, bad, container, sameDay})
+	check.Equal(t, []*Note{earlier, later, sameDay}, journal)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// NPCCardAttackCount is the maximum number of attacks included in an NPCCard.
+const NPCCardAttackCount = 5
+
+// NPCCard holds the information shown on a printable, index-card-sized NPC reference card.
+type NPCCard struct {
+	Name       string
+	Portrait   []byte
+	Stats      []string
+	Defenses   []string
+	Attacks    []string
+	Skills     []string
+	AttackMore int
+}
+
+// NewNPCCard creates a new NPCCard for the entity.
+func NewNPCCard(e *Entity) *NPCCard {
+	e.Recalculate()
+	enc := e.EncumbranceLevel(false)
+	summary := NewGMSummary(e)
+	c := &NPCCard{
+		Name:     e.Profile.Name,
+		Portrait: e.Profile.PortraitData,
+		Stats: []string{
+			statBlockEntry("ST", e.Attributes.Current(StrengthID)),
+			statBlockEntry("DX", e.Attributes.Current(DexterityID)),
+			statBlockEntry("IQ", e.Attributes.Current(IntelligenceID)),
+			statBlockEntry("HT", e.Attributes.Current(HealthID)),
+			"HP " + summary.HP,
+			"FP " + summary.FP,
+			"Will " + summary.Will,
+			"Per " + summary.Perception,
+			"Speed " + strconv.FormatFloat(fxp.As[float64](e.Attributes.Current(BasicSpeedID)), 'f', 2, 64),
+			fmt.Sprintf("Move %d", e.Move(enc)),
+		},
+		Defenses: []string{fmt.Sprintf("Dodge %d", e.Dodge(enc))},
+		Skills:   summary.KeySkills,
+	}
+	if sm := e.Profile.AdjustedSizeModifier(); sm != 0 {
+		c.Stats = append(c.Stats, fmt.Sprintf("SM %+d", sm))
+	}
+	if summary.Parry != "–" {
+		c.Defenses = append(c.Defenses, "Parry "+summary.Parry)
+	}
+	if summary.Block != "–" {
+		c.Defenses = append(c.Defenses, "Block "+summary.Block)
+	}
+	c.Defenses = append(c.Defenses, "DR "+summary.DR)
+	for _, w := range e.EquippedWeapons(true) {
+		c.addAttack(w, "Reach", w.Reach.Resolve(w, nil).String())
+	}
+	for _, w := range e.EquippedWeapons(false) {
+		c.addAttack(w, "Acc", w.Accuracy.Resolve(w, nil).String(), "Range", w.Range.Resolve(w, nil).String(true))
+	}
+	return c
+}
+
+// addAttack adds a line for the weapon, using the same format as the stat block. Once NPCCardAttackCount attacks have
+// been added, further attacks are only counted.
+func (c *NPCCard) addAttack(w *Weapon, labelsAndValues ...string) {
+	if len(c.Attacks) == NPCCardAttackCount {
+		c.AttackMore++
+		return
+	}
+	var buffer strings.Builder
+	writeStatBlockWeapon(&buffer, w, labelsAndValues...)
+	c.Attacks = append(c.Attacks, strings.TrimSuffix(strings.TrimSuffix(buffer.String(), "\n"), "."))
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestNPCCard(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Guard"
+	c := NewNPCCard(e)
+	check.Equal(t, "Guard", c.Name)
+	check.Equal(t, "ST 10", c.Stats[0])
+	check.Equal(t, "Speed 5.00", c.Stats[8])
+	check.Equal(t, "Dodge 8", c.Defenses[0])
+	check.Equal(t, 0, len(c.Attacks))
+	check.Equal(t, 0, c.AttackMore)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// PointsEarmark holds a reservation of unspent points that are being saved for a particular purpose.
+type PointsEarmark struct {
+	Points fxp.Int `json:"points"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+// ClonePointsEarmarkList creates a clone of the provided PointsEarmark list.
+func ClonePointsEarmarkList(list []*PointsEarmark) []*PointsEarmark {
+	clone := make([]*PointsEarmark, len(list))
+	for i := 0; i < len(list); i++ {
+		earmark := *list[i]
+		clone[i] = &earmark
+	}
+	return clone
+}
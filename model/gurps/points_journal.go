@@ -0,0 +1,125 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// PointsExpenditure holds information about when and why points were spent on the character. Expenditures are
+// recorded from the change in points spent, so they don't alter the total points the character has been awarded.
+type PointsExpenditure struct {
+	When   jio.Time `json:"when"`
+	Points fxp.Int  `json:"points"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+// PointsJournalEntry is a single line of the points journal, which interleaves the awards found in the points record
+// with the expenditures.
+type PointsJournalEntry struct {
+	When    jio.Time
+	Reason  string
+	Awarded fxp.Int
+	Spent   fxp.Int
+	Unspent fxp.Int
+}
+
+// ClonePointsExpenditureList creates a clone of the provided PointsExpenditure list.
+func ClonePointsExpenditureList(list []*PointsExpenditure) []*PointsExpenditure {
+	clone := make([]*PointsExpenditure, len(list))
+	for i := 0; i < len(list); i++ {
+		expenditure := *list[i]
+		clone[i] = &expenditure
+	}
+	return clone
+}
+
+// JournaledSpentPoints returns the number of points the expenditures in the journal account for.
+func (e *Entity) JournaledSpentPoints() fxp.Int {
+	var total fxp.Int
+	for _, one := range e.PointsExpenditures {
+		total += one.Points
+	}
+	return total
+}
+
+// UnrecordedSpentPoints returns the number of points spent on the character that have not yet been recorded as an
+// expenditure in the journal. This will be negative if points have been refunded since the last expenditure was
+// recorded.
+func (e *Entity) UnrecordedSpentPoints() fxp.Int {
+	return e.PointsBreakdown().Total() - e.JournaledSpentPoints()
+}
+
+// RecordPointsExpenditure adds an expenditure to the journal covering any points spent since the last one was recorded.
+// Returns nil if there was nothing to record.
+func (e *Entity) RecordPointsExpenditure(reason string) *PointsExpenditure {
+	spent := e.UnrecordedSpentPoints()
+	if spent == 0 {
+		return nil
+	}
+	if reason == "" {
+		if len(e.PointsExpenditures) == 0 {
+			reason = i18n.Text("Initial character creation")
+		} else {
+			reason = i18n.Text("Character advancement")
+		}
+	}
+	expenditure := &PointsExpenditure{
+		When:   jio.Now(),
+		Points: spent,
+		Reason: reason,
+	}
+	e.PointsExpenditures = append(e.PointsExpenditures, expenditure)
+	return expenditure
+}
+
+// SetPointsExpenditures sets a new points expenditure list.
+func (e *Entity) SetPointsExpenditures(list []*PointsExpenditure) {
+	e.PointsExpenditures = ClonePointsExpenditureList(list)
+}
+
+// PointsJournal returns the awards and expenditures, oldest first, along with the running balance of unspent points
+// after each. Any spending not yet recorded as an expenditure is included as a final, pending entry, so that the last
+// balance always matches the character's unspent points.
+func (e *Entity) PointsJournal() []*PointsJournalEntry {
+	list := make([]*PointsJournalEntry, 0, len(e.PointsRecord)+len(e.PointsExpenditures)+1)
+	for _, one := range e.PointsRecord {
+		list = append(list, &PointsJournalEntry{
+			When:    one.When,
+			Reason:  one.Reason,
+			Awarded: one.Points,
+		})
+	}
+	for _, one := range e.PointsExpenditures {
+		list = append(list, &PointsJournalEntry{
+			When:   one.When,
+			Reason: one.Reason,
+			Spent:  one.Points,
+		})
+	}
+	slices.SortStableFunc(list, func(a, b *PointsJournalEntry) int { return a.When.Compare(b.When) })
+	if pending := e.UnrecordedSpentPoints(); pending != 0 {
+		list = append(list, &PointsJournalEntry{
+			When:   jio.Now(),
+			Reason: i18n.Text("Not yet recorded"),
+			Spent:  pending,
+		})
+	}
+	var balance fxp.Int
+	for _, one := range list {
+		balance += one.Awarded - one.Spent
+		one.Unspent = balance
+	}
+	return list
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPointsJournal(t *testing.T) {
+	e := NewEntity()
+	trait := NewTrait(e, nil, false)
+	trait.Name = "Luck"
+	trait.BasePoints = fxp.Fifteen
+	e.SetTraitList([]*Trait{trait})
+	spent := e.PointsBreakdown().Total()
+
+	journal := e.PointsJournal()
+	check.Equal(t, e.UnspentPoints(), journal[len(journal)-1].Unspent, "pending spending is included")
+	check.Equal(t, spent, e.UnrecordedSpentPoints())
+
+	check.NotNil(t, e.RecordPointsExpenditure(""))
+	check.Nil(t, e.RecordPointsExpenditure(""), "nothing has been spent since the last expenditure")
+	check.Equal(t, fxp.Int(0), e.UnrecordedSpentPoints())
+
+	e.SetPointsRecord(append(e.PointsRecord, &PointsRecord{
+		When:   e.PointsRecord[0].When,
+		Points: fxp.Ten,
+		Reason: "Session 1",
+	}))
+	trait.BasePoints = fxp.Twenty
+	check.Equal(t, fxp.Five, e.RecordPointsExpenditure("Improved Luck").Points)
+
+	journal = e.PointsJournal()
+	check.Equal(t, 4, len(journal))
+	check.Equal(t, e.UnspentPoints(), journal[len(journal)-1].Unspent, "the journal balance matches the sheet")
+	check.Equal(t, e.TotalPoints-spent-fxp.Five, journal[len(journal)-1].Unspent)
+}
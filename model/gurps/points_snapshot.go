@@ -0,0 +1,96 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// PointsSnapshot records the points spent on each part of a character at a moment in time, typically when it was last
+// saved, so that the changes made since then can be shown.
+type PointsSnapshot struct {
+	rows       map[tid.TID]fxp.Int
+	attributes map[string]fxp.Int
+	breakdown  PointsBreakdown
+}
+
+// MarkPointsSaved records the points currently spent on the character as the baseline for the "since save" deltas.
+func (e *Entity) MarkPointsSaved() {
+	e.savedPoints = e.TakePointsSnapshot()
+}
+
+// TakePointsSnapshot records the points currently spent on the character.
+func (e *Entity) TakePointsSnapshot() *PointsSnapshot {
+	s := &PointsSnapshot{
+		rows:       make(map[tid.TID]fxp.Int),
+		attributes: make(map[string]fxp.Int),
+		breakdown:  *e.PointsBreakdown(),
+	}
+	if e.Attributes != nil {
+		for _, attr := range e.Attributes.Set {
+			s.attributes[attr.AttrID] = attr.PointCost()
+		}
+	}
+	Traverse(func(t *Trait) bool {
+		s.rows[t.TID] = t.AdjustedPoints()
+		return false
+	}, false, false, e.Traits...)
+	Traverse(func(sk *Skill) bool {
+		s.rows[sk.TID] = sk.AdjustedPoints(nil)
+		return false
+	}, false, false, e.Skills...)
+	Traverse(func(sp *Spell) bool {
+		s.rows[sp.TID] = sp.AdjustedPoints(nil)
+		return false
+	}, false, false, e.Spells...)
+	return s
+}
+
+// RowDelta returns the change in points for the row with the given ID. Rows that didn't exist when the snapshot was
+// taken are treated as having had no points.
+func (s *PointsSnapshot) RowDelta(id tid.TID, points fxp.Int) fxp.Int {
+	if s == nil {
+		return 0
+	}
+	return points - s.rows[id]
+}
+
+// AttributeDelta returns the change in points for the attribute with the given ID.
+func (s *PointsSnapshot) AttributeDelta(attrID string, points fxp.Int) fxp.Int {
+	if s == nil {
+		return 0
+	}
+	return points - s.attributes[attrID]
+}
+
+// BreakdownDelta returns the change in points for each category of the breakdown.
+func (s *PointsSnapshot) BreakdownDelta(current *PointsBreakdown) PointsBreakdown {
+	if s == nil {
+		return PointsBreakdown{}
+	}
+	return PointsBreakdown{
+		Ancestry:      current.Ancestry - s.breakdown.Ancestry,
+		Attributes:    current.Attributes - s.breakdown.Attributes,
+		Advantages:    current.Advantages - s.breakdown.Advantages,
+		Disadvantages: current.Disadvantages - s.breakdown.Disadvantages,
+		Quirks:        current.Quirks - s.breakdown.Quirks,
+		Skills:        current.Skills - s.breakdown.Skills,
+		Spells:        current.Spells - s.breakdown.Spells,
+	}
+}
+
+// SavedPoints returns the snapshot of points taken when the character was last saved, or nil if none was taken.
+func (e *Entity) SavedPoints() *PointsSnapshot {
+	if e == nil {
+		return nil
+	}
+	return e.savedPoints
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPointsSnapshot(t *testing.T) {
+	e := NewEntity()
+	trait := NewTrait(e, nil, false)
+	trait.BasePoints = fxp.Ten
+	e.SetTraitList([]*Trait{trait})
+	check.Equal(t, fxp.Int(0), e.SavedPoints().RowDelta(trait.TID, fxp.Ten), "no snapshot means no deltas")
+
+	e.MarkPointsSaved()
+	check.Equal(t, fxp.Int(0), e.SavedPoints().RowDelta(trait.TID, trait.AdjustedPoints()))
+
+	trait.BasePoints = fxp.Fifteen
+	check.Equal(t, fxp.Five, e.SavedPoints().RowDelta(trait.TID, trait.AdjustedPoints()))
+	check.Equal(t, fxp.Five, e.SavedPoints().BreakdownDelta(e.PointsBreakdown()).Advantages)
+
+	added := NewTrait(e, nil, false)
+	added.BasePoints = -fxp.Five
+	check.Equal(t, -fxp.Five, e.SavedPoints().RowDelta(added.TID, added.AdjustedPoints()),
+		"new rows count from zero")
+
+	st := e.Attributes.Set[StrengthID]
+	st.Adjustment = fxp.One
+	check.Equal(t, fxp.Ten, e.SavedPoints().AttributeDelta(st.AttrID, st.PointCost()))
+
+	e.MarkPointsSaved()
+	check.Equal(t, fxp.Int(0), e.SavedPoints().RowDelta(trait.TID, trait.AdjustedPoints()))
+	check.Equal(t, PointsBreakdown{}, e.SavedPoints().BreakdownDelta(e.PointsBreakdown()))
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/criteria"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// Power holds the abilities that share a power source and power talent, such as those found in a power container, along
+// with the skills linked to its talent.
+type Power struct {
+	Name        string
+	Source      string
+	Talent      *Trait
+	TalentName  string
+	TalentLevel fxp.Int
+	Abilities   []*Trait
+	Modifiers   []*TraitModifier
+	Skills      []*Skill
+	Suppressed  bool
+}
+
+// Points returns the total points spent on the abilities of the power and its talent.
+func (p *Power) Points() fxp.Int {
+	var total fxp.Int
+	for _, one := range p.Abilities {
+		total += one.AdjustedPoints()
+	}
+	if p.Talent != nil {
+		total += p.Talent.AdjustedPoints()
+	}
+	return total
+}
+
+// EffectiveLevel returns the level of the ability, with the talent bonus for the power added. Abilities that aren't
+// leveled just receive the talent bonus.
+func (p *Power) EffectiveLevel(ability *Trait) fxp.Int {
+	if p.Suppressed {
+		return 0
+	}
+	return ability.CurrentLevel() + p.TalentLevel
+}
+
+// Powers returns the powers the entity's traits have been grouped into. Traits within a power container are grouped
+// together under its name; otherwise, they are grouped by their power talent or, if they have none, their power source.
+func (e *Entity) Powers() []*Power {
+	var list []*Power
+	var talents []*Trait
+	Traverse(func(t *Trait) bool {
+		if t.IsTalent() {
+			talents = append(talents, t)
+		}
+		source := t.EffectivePowerSource()
+		talent := t.EffectivePowerTalent()
+		if source == "" && talent == "" {
+			return false
+		}
+		name := source
+		if talent != "" {
+			name = talent
+		}
+		var powerContainer *Trait
+		for p := t.parent; p != nil; p = p.parent {
+			if p.ContainerType == container.Power {
+				powerContainer = p
+				name = p.NameWithReplacements()
+				break
+			}
+		}
+		i := slices.IndexFunc(list, func(p *Power) bool { return strings.EqualFold(p.Name, name) })
+		if i == -1 {
+			i = len(list)
+			list = append(list, &Power{
+				Name:       name,
+				Source:     source,
+				TalentName: talent,
+				Suppressed: source != "" && e.PowerSourceSuppressed(source),
+			})
+			if powerContainer != nil {
+				for _, mod := range powerContainer.Modifiers {
+					if mod.Enabled() {
+						list[i].Modifiers = append(list[i].Modifiers, mod)
+					}
+				}
+			}
+		}
+		if !t.IsTalent() {
+			list[i].Abilities = append(list[i].Abilities, t)
+		}
+		return false
+	}, false, true, e.Traits...)
+	Traverse(func(s *Skill) bool {
+		talent := s.EffectivePowerTalent()
+		if talent == "" {
+			return false
+		}
+		i := slices.IndexFunc(list, func(p *Power) bool { return strings.EqualFold(p.TalentName, talent) })
+		if i == -1 {
+			i = len(list)
+			list = append(list, &Power{
+				Name:       talent,
+				TalentName: talent,
+			})
+		}
+		list[i].Skills = append(list[i].Skills, s)
+		return false
+	}, false, true, e.Skills...)
+	for _, p := range list {
+		if p.TalentName == "" {
+			continue
+		}
+		for _, t := range talents {
+			if strings.EqualFold(t.NameWithReplacements(), p.TalentName) {
+				p.Talent = t
+				if t.IsLeveled() {
+					p.TalentLevel = t.CurrentLevel()
+				} else if t.Enabled() {
+					p.TalentLevel = fxp.One
+				}
+				break
+			}
+		}
+	}
+	return list
+}
+
+// EffectivePowerTalent returns the power talent of this Skill, or that of its nearest parent that has one.
+func (s *Skill) EffectivePowerTalent() string {
+	for p := s; p != nil; p = p.parent {
+		if p.PowerTalent != "" {
+			return p.PowerTalent
+		}
+	}
+	return ""
+}
+
+// processPowerTalentBonuses adds the level of each power's talent as a bonus to the skills linked to it.
+func (e *Entity) processPowerTalentBonuses() {
+	for _, p := range e.Powers() {
+		if p.Talent == nil || p.TalentLevel == 0 || p.Suppressed {
+			continue
+		}
+		for _, s := range p.Skills {
+			bonus := NewSkillBonus()
+			bonus.NameCriteria.Qualifier = s.NameWithReplacements()
+			if spec := s.SpecializationWithReplacements(); spec != "" {
+				bonus.SpecializationCriteria.Compare = criteria.IsText
+				bonus.SpecializationCriteria.Qualifier = spec
+			}
+			bonus.Amount = p.TalentLevel
+			e.processFeature(p.Talent, nil, bonus, 0)
+		}
+	}
+}
+
+// PowerSources returns the power sources used by the entity's traits, sorted by name.
+func (e *Entity) PowerSources() []string {
+	var list []string
+	Traverse(func(t *Trait) bool {
+		if t.PowerSource != "" && !slices.ContainsFunc(list, func(s string) bool {
+			return strings.EqualFold(s, t.PowerSource)
+		}) {
+			list = append(list, t.PowerSource)
+		}
+		return false
+	}, false, false, e.Traits...)
+	slices.SortFunc(list, func(a, b string) int { return txt.NaturalCmp(a, b, true) })
+	return list
+}
+
+// PowerSourceSuppressed returns true if the power source has been suppressed, such as by an anti-magic zone.
+func (e *Entity) PowerSourceSuppressed(source string) bool {
+	return slices.ContainsFunc(e.SuppressedPowers, func(s string) bool { return strings.EqualFold(s, source) })
+}
+
+// SetPowerSourceSuppressed sets whether the power source has been suppressed. The features of traits drawing on a
+// suppressed power source no longer apply, although their points are still counted.
+func (e *Entity) SetPowerSourceSuppressed(source string, suppressed bool) {
+	if e.PowerSourceSuppressed(source) == suppressed {
+		return
+	}
+	if suppressed {
+		e.SuppressedPowers = append(e.SuppressedPowers, source)
+	} else {
+		e.SuppressedPowers = slices.DeleteFunc(e.SuppressedPowers,
+			func(s string) bool { return strings.EqualFold(s, source) })
+	}
+	e.Recalculate()
+}
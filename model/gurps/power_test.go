@@ -0,0 +1,111 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPowers(t *testing.T) {
+	e := NewEntity()
+	pyrokinesis := NewTrait(e, nil, true)
+	pyrokinesis.Name = "Pyrokinesis"
+	pyrokinesis.ContainerType = container.Power
+	pyrokinesis.PowerSource = "Psionic"
+	pyrokinesis.PowerTalent = "Pyrokinesis Talent"
+	talent := NewTrait(e, pyrokinesis, false)
+	talent.Name = "Pyrokinesis Talent"
+	talent.Tags = []string{"Talent"}
+	talent.CanLevel = true
+	talent.Levels = fxp.Two
+	talent.BasePoints = fxp.From(5)
+	talent.PointsPerLevel = fxp.From(5)
+	resistance := NewTrait(e, pyrokinesis, false)
+	resistance.Name = "Temperature Tolerance"
+	resistance.CanLevel = true
+	resistance.Levels = fxp.Three
+	resistance.PointsPerLevel = fxp.One
+	resistance.Features = append(resistance.Features, NewAttributeBonus("ht"))
+	pyrokinesis.Children = []*Trait{talent, resistance}
+	ward := NewTrait(e, nil, false)
+	ward.Name = "Ward"
+	ward.PowerSource = "Magic"
+	ward.BasePoints = fxp.Ten
+	e.Traits = []*Trait{pyrokinesis, ward}
+	e.Recalculate()
+
+	check.Equal(t, []string{"Magic", "Psionic"}, e.PowerSources())
+	powers := e.Powers()
+	check.Equal(t, 2, len(powers))
+	check.Equal(t, "Pyrokinesis", powers[0].Name)
+	check.Equal(t, "Psionic", powers[0].Source)
+	check.Equal(t, talent, powers[0].Talent)
+	check.Equal(t, fxp.Two, powers[0].TalentLevel)
+	check.Equal(t, []*Trait{resistance}, powers[0].Abilities, "the talent isn't an ability")
+	check.Equal(t, fxp.From(5), powers[0].EffectiveLevel(resistance), "the talent bonus is added")
+	check.Equal(t, fxp.From(18), powers[0].Points())
+	check.Equal(t, "Magic", powers[1].Name)
+	check.Equal(t, fxp.Eleven, e.Attributes.Current("ht"))
+
+	e.SetPowerSourceSuppressed("psionic", true)
+	check.False(t, resistance.Enabled(), "suppressed traits are disabled")
+	check.True(t, ward.Enabled())
+	check.Equal(t, fxp.Ten, e.Attributes.Current("ht"), "the features of suppressed traits no longer apply")
+	check.Equal(t, fxp.From(28), e.PointsBreakdown().Advantages, "but their points are still counted")
+	powers = e.Powers()
+	check.True(t, powers[0].Suppressed)
+	check.Equal(t, fxp.Int(0), powers[0].EffectiveLevel(resistance))
+
+	e.SetPowerSourceSuppressed("Psionic", false)
+	check.Equal(t, 0, len(e.SuppressedPowers))
+	check.Equal(t, fxp.Eleven, e.Attributes.Current("ht"))
+}
+
+func TestPowerTalentSkills(t *testing.T) {
+	e := NewEntity()
+	telepathy := NewTrait(e, nil, true)
+	telepathy.Name = "Telepathy"
+	telepathy.ContainerType = container.Power
+	telepathy.PowerSource = "Psionic"
+	telepathy.PowerTalent = "Telepathy Talent"
+	mod := NewTraitModifier(e, nil, false)
+	mod.Name = "Psionic"
+	mod.Cost = fxp.From(-10)
+	telepathy.Modifiers = []*TraitModifier{mod}
+	talent := NewTrait(e, telepathy, false)
+	talent.Name = "Telepathy Talent"
+	talent.Tags = []string{"Talent"}
+	talent.CanLevel = true
+	talent.Levels = fxp.Two
+	talent.PointsPerLevel = fxp.Five
+	telepathy.Children = []*Trait{talent}
+	e.Traits = []*Trait{telepathy}
+	telesend := NewSkill(e, nil, false)
+	telesend.Name = "Telesend"
+	telesend.Points = fxp.Four
+	e.Skills = []*Skill{telesend}
+	e.Recalculate()
+	unlinked := telesend.LevelData.Level
+
+	telesend.PowerTalent = "telepathy talent"
+	e.Recalculate()
+	check.Equal(t, unlinked+fxp.Two, telesend.LevelData.Level, "the talent bonus is added to linked skills")
+	powers := e.Powers()
+	check.Equal(t, 1, len(powers))
+	check.Equal(t, []*Skill{telesend}, powers[0].Skills)
+	check.Equal(t, []*TraitModifier{mod}, powers[0].Modifiers)
+
+	e.SetPowerSourceSuppressed("Psionic", true)
+	check.Equal(t, unlinked, telesend.LevelData.Level, "a suppressed talent gives no bonus")
+}
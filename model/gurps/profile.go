@@ -10,14 +10,36 @@
 package gurps
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stlimit"
 	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 )
 
+// Keys for the randomizable profile fields.
+const (
+	ProfileNameKey       = "name"
+	ProfileGenderKey     = "gender"
+	ProfileAgeKey        = "age"
+	ProfileBirthdayKey   = "birthday"
+	ProfileHeightKey     = "height"
+	ProfileWeightKey     = "weight"
+	ProfileHairKey       = "hair"
+	ProfileEyesKey       = "eyes"
+	ProfileSkinKey       = "skin"
+	ProfileHandednessKey = "handedness"
+)
+
+// ProfileRandomField describes one of the randomizable profile fields.
+type ProfileRandomField struct {
+	Key   string
+	Title string
+}
+
 // ProfileRandom holds the portion of the profile that is affected by the randomizer.
 type ProfileRandom struct {
 	Name       string     `json:"name,omitempty"`
@@ -43,6 +65,7 @@ type Profile struct {
 	PortraitData      []byte        `json:"portrait,omitempty"`
 	PortraitImage     *unison.Image `json:"-"`
 	SizeModifier      int           `json:"SM,omitempty"`
+	LockedFields      []string      `json:"locked_fields,omitempty"`
 	SizeModifierBonus fxp.Int       `json:"-"`
 }
 
@@ -101,3 +124,107 @@ func (p *Profile) ApplyRandomizers(entity *Entity) {
 	p.Name = a.RandomName(AvailableNameGenerators(globalSettings.Libraries()), p.Gender)
 	p.Birthday = generalSettings.CalendarRef(globalSettings.Libraries()).RandomBirthday(p.Birthday)
 }
+
+// ProfileRandomFields returns the randomizable profile fields, in the order they are re-rolled.
+func ProfileRandomFields() []ProfileRandomField {
+	return []ProfileRandomField{
+		{Key: ProfileGenderKey, Title: i18n.Text("Gender")},
+		{Key: ProfileNameKey, Title: i18n.Text("Name")},
+		{Key: ProfileAgeKey, Title: i18n.Text("Age")},
+		{Key: ProfileBirthdayKey, Title: i18n.Text("Birthday")},
+		{Key: ProfileHeightKey, Title: i18n.Text("Height")},
+		{Key: ProfileWeightKey, Title: i18n.Text("Weight")},
+		{Key: ProfileHairKey, Title: i18n.Text("Hair")},
+		{Key: ProfileEyesKey, Title: i18n.Text("Eyes")},
+		{Key: ProfileSkinKey, Title: i18n.Text("Skin")},
+		{Key: ProfileHandednessKey, Title: i18n.Text("Handedness")},
+	}
+}
+
+// RandomFieldValue returns the current value of the randomizable field with the given key, formatted for display.
+func (p *Profile) RandomFieldValue(key string) string {
+	switch key {
+	case ProfileNameKey:
+		return p.Name
+	case ProfileGenderKey:
+		return p.Gender
+	case ProfileAgeKey:
+		return p.Age
+	case ProfileBirthdayKey:
+		return p.Birthday
+	case ProfileHeightKey:
+		return p.Height.String()
+	case ProfileWeightKey:
+		return p.Weight.String()
+	case ProfileHairKey:
+		return p.Hair
+	case ProfileEyesKey:
+		return p.Eyes
+	case ProfileSkinKey:
+		return p.Skin
+	case ProfileHandednessKey:
+		return p.Handedness
+	default:
+		return ""
+	}
+}
+
+// IsLocked returns true if the randomizable field with the given key has been locked against re-rolling.
+func (p *Profile) IsLocked(key string) bool {
+	return slices.Contains(p.LockedFields, key)
+}
+
+// SetLocked sets whether the randomizable field with the given key is locked against re-rolling.
+func (p *Profile) SetLocked(key string, locked bool) {
+	i := slices.Index(p.LockedFields, key)
+	switch {
+	case locked && i == -1:
+		p.LockedFields = append(p.LockedFields, key)
+	case !locked && i != -1:
+		p.LockedFields = slices.Delete(p.LockedFields, i, i+1)
+	default:
+	}
+}
+
+// RerollUnlocked re-rolls the randomizable fields that haven't been locked, trying to pick values that differ from the
+// current ones. Locked fields are left untouched, although a locked gender is still used to re-roll the others. The
+// gender and name are kept if the ancestry has nothing to offer for them.
+func (p *Profile) RerollUnlocked(entity *Entity) {
+	a := entity.Ancestry()
+	if !p.IsLocked(ProfileGenderKey) {
+		if gender := a.RandomGender(p.Gender); gender != "" {
+			p.Gender = gender
+		}
+	}
+	if !p.IsLocked(ProfileNameKey) {
+		if name := a.RandomName(AvailableNameGenerators(GlobalSettings().Libraries()), p.Gender); name != "" {
+			p.Name = name
+		}
+	}
+	if !p.IsLocked(ProfileAgeKey) {
+		age, _ := strconv.Atoi(p.Age) //nolint:errcheck // A default of 0 is ok here on error
+		p.Age = strconv.Itoa(a.RandomAge(entity, p.Gender, age))
+	}
+	if !p.IsLocked(ProfileBirthdayKey) {
+		globalSettings := GlobalSettings()
+		p.Birthday = globalSettings.GeneralSettings().CalendarRef(globalSettings.Libraries()).RandomBirthday(p.Birthday)
+	}
+	if !p.IsLocked(ProfileHeightKey) {
+		p.Height = a.RandomHeight(entity, p.Gender, p.Height)
+	}
+	if !p.IsLocked(ProfileWeightKey) {
+		p.Weight = a.RandomWeight(entity, p.Gender, p.Weight)
+	}
+	if !p.IsLocked(ProfileHairKey) {
+		p.Hair = a.RandomHair(p.Gender, p.Hair)
+	}
+	if !p.IsLocked(ProfileEyesKey) {
+		p.Eyes = a.RandomEyes(p.Gender, p.Eyes)
+	}
+	if !p.IsLocked(ProfileSkinKey) {
+		p.Skin = a.RandomSkin(p.Gender, p.Skin)
+	}
+	if !p.IsLocked(ProfileHandednessKey) {
+		p.Handedness = a.RandomHandedness(p.Gender, p.Handedness)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestProfileRerollUnlocked(t *testing.T) {
+	e := NewEntity()
+	p := &e.Profile
+	p.SetLocked(ProfileNameKey, true)
+	p.SetLocked(ProfileNameKey, true)
+	p.SetLocked(ProfileHairKey, true)
+	check.Equal(t, []string{ProfileNameKey, ProfileHairKey}, p.LockedFields)
+	p.SetLocked(ProfileHairKey, false)
+	check.False(t, p.IsLocked(ProfileHairKey))
+
+	p.Name = "Kept Name"
+	p.SetLocked(ProfileSkinKey, true)
+	p.Skin = "Kept Skin"
+	p.Hair = ""
+	p.RerollUnlocked(e)
+	check.Equal(t, "Kept Name", p.Name)
+	check.Equal(t, "Kept Skin", p.Skin)
+	check.NotEqual(t, "", p.Hair, "unlocked fields are re-rolled")
+	check.Equal(t, 10, len(ProfileRandomFields()))
+}
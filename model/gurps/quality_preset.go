@@ -0,0 +1,134 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/quality"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// QualityPreset holds a named set of equipment modifiers that can be applied to a piece of equipment in one step.
+// Presets within the same group are mutually exclusive, so applying one removes the modifiers of the others.
+type QualityPreset struct {
+	Group     string               `json:"group,omitempty"`
+	Name      string               `json:"name"`
+	Target    quality.Target       `json:"target,omitempty"`
+	Modifiers []*EquipmentModifier `json:"modifiers,omitempty"`
+}
+
+// QualityPresets holds a set of quality presets.
+type QualityPresets struct {
+	Presets []*QualityPreset `json:"presets,omitempty"`
+}
+
+type qualityPresetsData struct {
+	Version int `json:"version"`
+	QualityPresets
+}
+
+// NewQualityPresetsFromFile loads a set of quality presets from a file.
+func NewQualityPresetsFromFile(fileSystem fs.FS, filePath string) (*QualityPresets, error) {
+	var data qualityPresetsData
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &data); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(data.Version); err != nil {
+		return nil, err
+	}
+	return &data.QualityPresets, nil
+}
+
+// Save writes the QualityPresets to the file as JSON.
+func (q *QualityPresets) Save(filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, &qualityPresetsData{
+		Version:        jio.CurrentDataVersion,
+		QualityPresets: *q,
+	})
+}
+
+// AvailableQualityPresets scans the libraries and returns the available quality presets. A preset found in a library
+// replaces a built-in preset with the same group and name.
+func AvailableQualityPresets(libraries Libraries) []*QualityPreset {
+	var list []*QualityPreset
+	seen := make(map[string]bool)
+	for _, set := range ScanForNamedFileSets(embeddedFS, "embedded_data", false, libraries, QualityPresetsExt) {
+		for _, one := range set.List {
+			presets, err := NewQualityPresetsFromFile(one.FileSystem, one.FilePath)
+			if err != nil {
+				errs.Log(err, "path", one.FilePath)
+				continue
+			}
+			for _, preset := range presets.Presets {
+				key := strings.ToLower(preset.Group) + "\n" + strings.ToLower(preset.Name)
+				if !seen[key] {
+					seen[key] = true
+					list = append(list, preset)
+				}
+			}
+		}
+	}
+	return list
+}
+
+// String implements fmt.Stringer.
+func (p *QualityPreset) String() string {
+	if p.Group == "" {
+		return p.Name
+	}
+	return p.Group + ": " + p.Name
+}
+
+// AppliesTo returns true if the preset may be applied to the equipment.
+func (p *QualityPreset) AppliesTo(eqp *Equipment) bool {
+	switch p.Target {
+	case quality.MeleeWeapon, quality.RangedWeapon:
+		melee := p.Target == quality.MeleeWeapon
+		return slices.ContainsFunc(eqp.Weapons, func(w *Weapon) bool { return w.IsMelee() == melee })
+	case quality.Armor:
+		return slices.ContainsFunc(eqp.Features, func(f Feature) bool {
+			_, ok := f.(*DRBonus)
+			return ok
+		})
+	default:
+		return true
+	}
+}
+
+// ApplyQualityPreset removes any modifiers added by other presets in the same group, then adds copies of the preset's
+// modifiers that the equipment doesn't already have. Modifiers are matched by name. Returns true if the equipment was
+// changed.
+func (e *Equipment) ApplyQualityPreset(preset *QualityPreset, all []*QualityPreset) bool {
+	remove := make(map[string]bool)
+	for _, other := range all {
+		if other != preset && strings.EqualFold(other.Group, preset.Group) && preset.Group != "" {
+			for _, mod := range other.Modifiers {
+				remove[mod.String()] = true
+			}
+		}
+	}
+	for _, mod := range preset.Modifiers {
+		delete(remove, mod.String())
+	}
+	count := len(e.Modifiers)
+	e.Modifiers = slices.DeleteFunc(e.Modifiers, func(mod *EquipmentModifier) bool { return remove[mod.String()] })
+	changed := count != len(e.Modifiers)
+	for _, mod := range preset.Modifiers {
+		if e.ApplyModifier(mod) {
+			changed = true
+		}
+	}
+	return changed
+}
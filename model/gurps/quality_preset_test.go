@@ -0,0 +1,58 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/quality"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestQualityPresets(t *testing.T) {
+	presets, err := NewQualityPresetsFromFile(embeddedFS, "embedded_data/Standard.qualities")
+	check.NoError(t, err)
+	lookup := func(group, name string) *QualityPreset {
+		for _, one := range presets.Presets {
+			if one.Group == group && one.Name == name {
+				return one
+			}
+		}
+		t.Fatalf("missing preset %s: %s", group, name)
+		return nil
+	}
+	cheap := lookup("Melee Weapon Quality", "Cheap")
+	fine := lookup("Melee Weapon Quality", "Fine")
+	good := lookup("Melee Weapon Quality", "Good")
+	balanced := lookup("Balance", "Balanced")
+	armor := lookup("Armor Quality", "Fine")
+	check.Equal(t, quality.MeleeWeapon, fine.Target)
+
+	e := NewEntity()
+	sword := NewEquipment(e, nil, false)
+	sword.Name = "Broadsword"
+	sword.Weapons = []*Weapon{NewWeapon(sword, true)}
+	check.True(t, fine.AppliesTo(sword))
+	check.False(t, armor.AppliesTo(sword))
+	check.False(t, lookup("Ranged Weapon Quality", "Cheap").AppliesTo(sword))
+
+	check.True(t, sword.ApplyQualityPreset(cheap, presets.Presets))
+	check.True(t, sword.ApplyQualityPreset(balanced, presets.Presets))
+	check.True(t, sword.ApplyQualityPreset(fine, presets.Presets))
+	names := make([]string, 0, len(sword.Modifiers))
+	for _, mod := range sword.Modifiers {
+		names = append(names, mod.Name)
+	}
+	check.Equal(t, []string{"Balanced", "Fine"}, names, "presets in the same group replace each other")
+	check.False(t, sword.ApplyQualityPreset(fine, presets.Presets), "already applied")
+
+	check.True(t, sword.ApplyQualityPreset(good, presets.Presets))
+	check.Equal(t, 1, len(sword.Modifiers), "an empty preset removes the rest of its group")
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// QuickReferenceTable holds one of the tables shown in the quick reference panel.
+type QuickReferenceTable struct {
+	Name    string     `json:"name"`
+	Columns []string   `json:"columns,omitempty"`
+	Rows    [][]string `json:"rows,omitempty"`
+	Notes   string     `json:"notes,omitempty"`
+}
+
+// QuickReference holds a set of quick reference tables.
+type QuickReference struct {
+	Tables []*QuickReferenceTable `json:"tables,omitempty"`
+}
+
+type quickReferenceData struct {
+	Version int `json:"version"`
+	QuickReference
+}
+
+// NewQuickReferenceFromFile loads a set of quick reference tables from a file.
+func NewQuickReferenceFromFile(fileSystem fs.FS, filePath string) (*QuickReference, error) {
+	var data quickReferenceData
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &data); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(data.Version); err != nil {
+		return nil, err
+	}
+	return &data.QuickReference, nil
+}
+
+// Save writes the QuickReference to the file as JSON.
+func (q *QuickReference) Save(filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, &quickReferenceData{
+		Version:        jio.CurrentDataVersion,
+		QuickReference: *q,
+	})
+}
+
+// AvailableQuickReferenceTables scans the libraries and returns the available quick reference tables. A table found in
+// a library replaces a built-in table with the same name, allowing groups to substitute their own house rules.
+func AvailableQuickReferenceTables(libraries Libraries) []*QuickReferenceTable {
+	var list []*QuickReferenceTable
+	seen := make(map[string]bool)
+	for _, set := range ScanForNamedFileSets(embeddedFS, "embedded_data", false, libraries, QuickReferenceExt) {
+		for _, one := range set.List {
+			ref, err := NewQuickReferenceFromFile(one.FileSystem, one.FilePath)
+			if err != nil {
+				errs.Log(err, "path", one.FilePath)
+				continue
+			}
+			for _, table := range ref.Tables {
+				key := strings.ToLower(table.Name)
+				if !seen[key] {
+					seen[key] = true
+					list = append(list, table)
+				}
+			}
+		}
+	}
+	return list
+}
+
+// String implements fmt.Stringer.
+func (t *QuickReferenceTable) String() string {
+	return t.Name
+}
+
+// Markdown returns the table and its notes as markdown. Rows with fewer cells than there are columns are padded out.
+func (t *QuickReferenceTable) Markdown() string {
+	var buffer strings.Builder
+	if len(t.Columns) != 0 {
+		writeMarkdownTableHeader(&buffer, t.Columns...)
+		for _, row := range t.Rows {
+			cells := make([]string, len(t.Columns))
+			copy(cells, row)
+			for i, cell := range cells {
+				cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			writeMarkdownTableRow(&buffer, cells...)
+		}
+	}
+	if t.Notes != "" {
+		if buffer.Len() != 0 {
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(t.Notes)
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
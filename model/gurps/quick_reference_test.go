@@ -0,0 +1,43 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestQuickReference(t *testing.T) {
+	ref, err := NewQuickReferenceFromFile(embeddedFS, "embedded_data/Standard.quickref")
+	check.NoError(t, err)
+	names := make([]string, 0, len(ref.Tables))
+	for _, one := range ref.Tables {
+		names = append(names, one.Name)
+		for _, row := range one.Rows {
+			check.Equal(t, len(one.Columns), len(row), one.Name)
+		}
+	}
+	check.Equal(t, []string{"Size and Speed/Range", "Hit Locations", "Postures", "Task Difficulty Modifiers"}, names)
+
+	table := &QuickReferenceTable{
+		Name:    "Lighting",
+		Columns: []string{"Modifier", "Condition"},
+		Rows:    [][]string{{"-1", "Twilight|dusk"}, {"-10"}},
+		Notes:   "Night Vision offsets these.",
+	}
+	check.Equal(t, `| Modifier | Condition |
+| --- | --- |
+| -1 | Twilight\|dusk |
+| -10 |  |
+
+Night Vision offsets these.
+`, table.Markdown())
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Reputation points per level of reaction modifier.
+const reputationPointsPerLevel = 5
+
+// Reputation holds a reputation the character has with some group of people.
+type Reputation struct {
+	Name        string                 `json:"name"`
+	AffectedBy  string                 `json:"affected_by,omitempty"`
+	Modifier    int                    `json:"modifier"`
+	Affected    reputation.Affected    `json:"affected,omitempty"`
+	Recognition reputation.Recognition `json:"recognition,omitempty"`
+	TraitID     tid.TID                `json:"trait_id,omitempty"`
+}
+
+// NewReputation creates a new Reputation.
+func NewReputation() *Reputation {
+	return &Reputation{Modifier: 1}
+}
+
+// Cost returns the number of points this reputation should cost.
+func (r *Reputation) Cost() fxp.Int {
+	an, ad := r.Affected.Fraction()
+	rn, rd := r.Recognition.Fraction()
+	return fxp.From(reputationPointsPerLevel * r.Modifier * an * rn / (ad * rd))
+}
+
+// Situation returns the description of when the reputation applies to reactions.
+func (r *Reputation) Situation() string {
+	who := strings.TrimSpace(r.AffectedBy)
+	if who == "" {
+		who = r.Affected.String()
+	}
+	if target := r.Recognition.Target(); target != 0 {
+		return fmt.Sprintf(i18n.Text("from %s, if recognized (%d or less)"), who, target)
+	}
+	return fmt.Sprintf(i18n.Text("from %s"), who)
+}
+
+// CloneReputationList creates a clone of the provided Reputation list.
+func CloneReputationList(list []*Reputation) []*Reputation {
+	clone := make([]*Reputation, len(list))
+	for i := 0; i < len(list); i++ {
+		r := *list[i]
+		clone[i] = &r
+	}
+	return clone
+}
+
+// SetReputations sets a new reputation list.
+func (e *Entity) SetReputations(list []*Reputation) {
+	e.Reputations = CloneReputationList(list)
+}
+
+func (e *Entity) reactionsFromReputations(m map[string]*ConditionalModifier) {
+	for _, one := range e.Reputations {
+		if one.Modifier == 0 {
+			continue
+		}
+		source := i18n.Text("from reputation ") + one.Name
+		amt := fxp.From(one.Modifier)
+		situation := one.Situation()
+		if r, exists := m[situation]; exists {
+			r.Add(source, amt)
+		} else {
+			m[situation] = NewConditionalModifier(source, situation, amt)
+		}
+	}
+}
+
+func (e *Entity) checkReputations(issues []*ValidationIssue) []*ValidationIssue {
+	for _, one := range e.Reputations {
+		if one.Modifier < -4 || one.Modifier > 4 {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Reputation %s has a reaction modifier of %d, but it must be between -4 and +4"),
+					one.Name, one.Modifier),
+			})
+		}
+		if one.TraitID == "" {
+			continue
+		}
+		t := e.TraitByID(one.TraitID)
+		if t == nil {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Reputation %s is linked to a trait that no longer exists"), one.Name),
+			})
+			continue
+		}
+		if cost, spent := one.Cost(), t.AdjustedPoints(); cost != spent {
+			issues = append(issues, &ValidationIssue{
+				Target:  vtarget.Character,
+				Subject: e.Profile.Name,
+				Message: fmt.Sprintf(i18n.Text("Reputation %s should cost %s points, but the linked trait %s costs %s"),
+					one.Name, cost.String(), t.String(), spent.String()),
+			})
+		}
+	}
+	return issues
+}
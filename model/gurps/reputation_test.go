@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestReputationCost(t *testing.T) {
+	r := &Reputation{Modifier: 2}
+	check.Equal(t, fxp.Ten, r.Cost())
+
+	r.Affected = reputation.LargeClass
+	r.Recognition = reputation.Sometimes
+	check.Equal(t, fxp.Two, r.Cost(), "10 x 1/2 x 1/2, rounded toward zero")
+
+	r.Modifier = -3
+	r.Affected = reputation.SmallClass
+	r.Recognition = reputation.Always
+	check.Equal(t, -fxp.Five, r.Cost())
+}
+
+func TestReputationReactions(t *testing.T) {
+	e := NewEntity()
+	e.Reputations = []*Reputation{
+		{Name: "Hero of the Realm", AffectedBy: "townsfolk", Modifier: 2},
+		{Name: "Thief", AffectedBy: "townsfolk", Modifier: -1},
+	}
+	e.Recalculate()
+	var found *ConditionalModifier
+	for _, one := range e.Reactions() {
+		if one.From == e.Reputations[0].Situation() {
+			found = one
+		}
+	}
+	check.NotNil(t, found)
+	check.Equal(t, fxp.One, found.Total())
+	check.Equal(t, 2, len(found.Sources))
+}
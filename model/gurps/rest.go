@@ -0,0 +1,190 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rest"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// RestSettings holds the settings that control how much time each kind of rest takes and how quickly pools recover
+// while resting.
+type RestSettings struct {
+	ShortRestMinutes  int     `json:"short_rest_minutes"`
+	LongRestMinutes   int     `json:"long_rest_minutes"`
+	SleepMinutes      int     `json:"sleep_minutes"`
+	FPMinutesPerPoint int     `json:"fp_minutes_per_point"`
+	ERMinutesPerPoint int     `json:"er_minutes_per_point"`
+	HPPerSleep        fxp.Int `json:"hp_per_sleep"`
+}
+
+// RestState holds a snapshot of the data that may be altered by resting or casting spells with threshold-limited magic.
+type RestState struct {
+	sessionMinutes int
+	thresholdTally fxp.Int
+	damage         map[string]fxp.Int
+	changeLog      []*ChangeLogEntry
+}
+
+// NewRestSettings returns new rest settings with factory defaults. Fatigue and energy reserves recover 1 point per 10
+// minutes of rest and a night's sleep restores 1 HP.
+func NewRestSettings() *RestSettings {
+	return &RestSettings{
+		ShortRestMinutes:  10,
+		LongRestMinutes:   60,
+		SleepMinutes:      8 * 60,
+		FPMinutesPerPoint: 10,
+		ERMinutesPerPoint: 10,
+		HPPerSleep:        fxp.One,
+	}
+}
+
+// EnsureValidity checks the current settings for validity and if they aren't valid, makes them so.
+func (r *RestSettings) EnsureValidity() {
+	r.ShortRestMinutes = max(r.ShortRestMinutes, 1)
+	r.LongRestMinutes = max(r.LongRestMinutes, 1)
+	r.SleepMinutes = max(r.SleepMinutes, 1)
+	r.FPMinutesPerPoint = max(r.FPMinutesPerPoint, 0)
+	r.ERMinutesPerPoint = max(r.ERMinutesPerPoint, 0)
+	r.HPPerSleep = r.HPPerSleep.Max(0)
+}
+
+// Clone creates a copy of this.
+func (r *RestSettings) Clone() *RestSettings {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	return &clone
+}
+
+// Minutes returns the number of minutes the kind of rest takes.
+func (r *RestSettings) Minutes(kind rest.Kind) int {
+	switch kind {
+	case rest.Long:
+		return r.LongRestMinutes
+	case rest.Sleep:
+		return r.SleepMinutes
+	default:
+		return r.ShortRestMinutes
+	}
+}
+
+// FormatSessionTime returns the number of minutes formatted as hours and minutes.
+func FormatSessionTime(minutes int) string {
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// RestState returns a snapshot of the data that may be altered by resting or casting spells with threshold-limited
+// magic.
+func (e *Entity) RestState() *RestState {
+	s := &RestState{
+		sessionMinutes: e.SessionMinutes,
+		thresholdTally: e.ThresholdTally,
+		damage:         make(map[string]fxp.Int),
+		changeLog:      slices.Clone(e.ChangeLog),
+	}
+	for _, id := range []string{FatiguePointsID, EnergyReserveID, HitPointsID} {
+		if attr, ok := e.Attributes.Set[id]; ok {
+			s.damage[id] = attr.Damage
+		}
+	}
+	return s
+}
+
+// ApplyRestState restores a snapshot previously obtained from RestState.
+func (e *Entity) ApplyRestState(s *RestState) {
+	e.SessionMinutes = s.sessionMinutes
+	e.ThresholdTally = s.thresholdTally
+	for id, damage := range s.damage {
+		if attr, ok := e.Attributes.Set[id]; ok {
+			attr.Damage = damage
+		}
+	}
+	e.ChangeLog = slices.Clone(s.changeLog)
+	e.Recalculate()
+}
+
+// AdvanceSessionTime moves the session timer forward by the given number of minutes without any recovery.
+func (e *Entity) AdvanceSessionTime(minutes int) {
+	e.SessionMinutes += max(minutes, 0)
+}
+
+// ResetSessionTime starts the session timer over, such as at the start of a new session.
+func (e *Entity) ResetSessionTime() {
+	e.SessionMinutes = 0
+}
+
+// Rest advances the session timer by the duration of the kind of rest and restores FP, ER and, for sleep, HP according
+// to the sheet's rest settings. Sleep also reduces the threshold tally when threshold-limited magic is enabled. The recovery is added to the change log and a description of it is returned.
+func (e *Entity) Rest(kind rest.Kind) string {
+	settings := e.SheetSettings.Rest
+	if settings == nil {
+		settings = NewRestSettings()
+	}
+	minutes := settings.Minutes(kind)
+	e.AdvanceSessionTime(minutes)
+	var recovered []string
+	if amount := e.recoverPool(FatiguePointsID, recoveryForMinutes(minutes, settings.FPMinutesPerPoint)); amount != "" {
+		recovered = append(recovered, amount)
+	}
+	if amount := e.recoverPool(EnergyReserveID, recoveryForMinutes(minutes, settings.ERMinutesPerPoint)); amount != "" {
+		recovered = append(recovered, amount)
+	}
+	if kind == rest.Sleep {
+		if amount := e.recoverPool(HitPointsID, settings.HPPerSleep); amount != "" {
+			recovered = append(recovered, amount)
+		}
+		if amount := e.recoverThresholdTally(); amount != "" {
+			recovered = append(recovered, amount)
+		}
+	}
+	description := fmt.Sprintf(i18n.Text("%s for %s"), kind, FormatSessionTime(minutes))
+	if len(recovered) == 0 {
+		description += i18n.Text("; nothing recovered")
+	} else {
+		description += fmt.Sprintf(i18n.Text("; recovered %s"), strings.Join(recovered, ", "))
+	}
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: description,
+	})
+	e.Recalculate()
+	return description
+}
+
+// recoverPool reduces the damage to the pool by up to the given amount and returns a description of the amount
+// recovered, or an empty string if nothing was.
+func (e *Entity) recoverPool(attrID string, amount fxp.Int) string {
+	attr, ok := e.Attributes.Set[attrID]
+	if !ok || amount <= 0 || attr.Damage <= 0 {
+		return ""
+	}
+	amount = amount.Min(attr.Damage)
+	attr.Damage -= amount
+	name := strings.ToUpper(attrID)
+	if def := attr.AttributeDef(); def != nil {
+		name = def.Name
+	}
+	return fmt.Sprintf("%s %s", amount.Comma(), name)
+}
+
+func recoveryForMinutes(minutes, minutesPerPoint int) fxp.Int {
+	if minutesPerPoint <= 0 {
+		return 0
+	}
+	return fxp.From(minutes / minutesPerPoint)
+}
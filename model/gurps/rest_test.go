@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rest"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRest(t *testing.T) {
+	e := NewEntity()
+	e.SheetSettings.Rest = NewRestSettings()
+	fp := e.Attributes.Set[FatiguePointsID]
+	hp := e.Attributes.Set[HitPointsID]
+	fp.Damage = fxp.From(8)
+	hp.Damage = fxp.From(3)
+	before := e.RestState()
+
+	e.Rest(rest.Short)
+	check.Equal(t, 10, e.SessionMinutes)
+	check.Equal(t, fxp.From(7), fp.Damage)
+	check.Equal(t, fxp.From(3), hp.Damage, "only sleep restores HP")
+
+	e.Rest(rest.Long)
+	check.Equal(t, 70, e.SessionMinutes)
+	check.Equal(t, fxp.One, fp.Damage)
+
+	e.Rest(rest.Sleep)
+	check.Equal(t, 550, e.SessionMinutes)
+	check.Equal(t, fxp.Int(0), fp.Damage, "recovery stops at the maximum")
+	check.Equal(t, fxp.Two, hp.Damage)
+	check.Equal(t, 3, len(e.ChangeLog))
+	check.Equal(t, "9:10", FormatSessionTime(e.SessionMinutes))
+
+	e.ApplyRestState(before)
+	check.Equal(t, 0, e.SessionMinutes)
+	check.Equal(t, fxp.From(8), fp.Damage)
+	check.Equal(t, 0, len(e.ChangeLog))
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// RitualEffect holds one of the effects that make up a Ritual Path Magic ritual.
+type RitualEffect struct {
+	Effect  rpm.Effect `json:"effect"`
+	Path    rpm.Path   `json:"path"`
+	Greater bool       `json:"greater,omitempty"`
+}
+
+// RitualModifier holds one of the modifiers applied to a Ritual Path Magic ritual, such as its area, duration or range,
+// along with the energy it adds to the cost.
+type RitualModifier struct {
+	Name   string `json:"name"`
+	Energy int    `json:"energy"`
+}
+
+// Ritual holds a Ritual Path Magic ritual, along with the energy gathered so far for casting it.
+type Ritual struct {
+	Name      string            `json:"name"`
+	Effects   []*RitualEffect   `json:"effects,omitempty"`
+	Modifiers []*RitualModifier `json:"modifiers,omitempty"`
+	Notes     string            `json:"notes,omitempty"`
+	Gathered  int               `json:"gathered,omitempty"`
+}
+
+// GrimoireState holds a snapshot of the data that may be altered by changing the grimoire or gathering energy for its
+// rituals.
+type GrimoireState struct {
+	grimoire  []*Ritual
+	changeLog []*ChangeLogEntry
+}
+
+// NewRitual creates a new ritual with a single lesser Sense effect.
+func NewRitual() *Ritual {
+	return &Ritual{
+		Name:    i18n.Text("Ritual"),
+		Effects: []*RitualEffect{{}},
+	}
+}
+
+// Clone creates a copy of this.
+func (r *Ritual) Clone() *Ritual {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	clone.Effects = make([]*RitualEffect, len(r.Effects))
+	for i, one := range r.Effects {
+		effect := *one
+		clone.Effects[i] = &effect
+	}
+	clone.Modifiers = make([]*RitualModifier, len(r.Modifiers))
+	for i, one := range r.Modifiers {
+		modifier := *one
+		clone.Modifiers[i] = &modifier
+	}
+	return &clone
+}
+
+// CloneRituals creates a copy of the list of rituals.
+func CloneRituals(list []*Ritual) []*Ritual {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*Ritual, len(list))
+	for i, one := range list {
+		clone[i] = one.Clone()
+	}
+	return clone
+}
+
+func (r *Ritual) String() string {
+	return r.Name
+}
+
+// BaseEnergy returns the energy cost of the ritual's effects and modifiers, before the multiplier for greater effects is
+// applied.
+func (r *Ritual) BaseEnergy() int {
+	var total int
+	for _, one := range r.Effects {
+		total += one.Effect.BaseEnergy()
+	}
+	for _, one := range r.Modifiers {
+		total += one.Energy
+	}
+	return max(total, 0)
+}
+
+// GreaterEffects returns the number of greater effects in the ritual.
+func (r *Ritual) GreaterEffects() int {
+	var count int
+	for _, one := range r.Effects {
+		if one.Greater {
+			count++
+		}
+	}
+	return count
+}
+
+// EnergyMultiplier returns the multiplier applied to the base energy cost of the ritual, which is 1 plus 2 for each
+// greater effect.
+func (r *Ritual) EnergyMultiplier() int {
+	return 1 + 2*r.GreaterEffects()
+}
+
+// EnergyCost returns the total energy that must be gathered to cast the ritual.
+func (r *Ritual) EnergyCost() int {
+	return r.BaseEnergy() * r.EnergyMultiplier()
+}
+
+// Remaining returns the energy that still needs to be gathered before the ritual can be cast.
+func (r *Ritual) Remaining() int {
+	return max(r.EnergyCost()-r.Gathered, 0)
+}
+
+// Paths returns the paths used by the ritual's effects, in the order they first appear.
+func (r *Ritual) Paths() []rpm.Path {
+	var list []rpm.Path
+	for _, one := range r.Effects {
+		if !slices.Contains(list, one.Path) {
+			list = append(list, one.Path)
+		}
+	}
+	return list
+}
+
+// EffectsText returns a description of the ritual's effects, e.g. "Greater Create Matter + Lesser Sense Mind".
+func (r *Ritual) EffectsText() string {
+	parts := make([]string, 0, len(r.Effects))
+	for _, one := range r.Effects {
+		var format string
+		if one.Greater {
+			format = i18n.Text("Greater %s %s")
+		} else {
+			format = i18n.Text("Lesser %s %s")
+		}
+		parts = append(parts, fmt.Sprintf(format, one.Effect, one.Path))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// PathSkillLevel returns the entity's level with the skill for the path. Returns false if the entity doesn't have the
+// skill.
+func (e *Entity) PathSkillLevel(path rpm.Path) (fxp.Int, bool) {
+	if sk := e.BestSkillNamed(path.SkillName(), "", false, nil); sk != nil {
+		return sk.LevelData.Level, true
+	}
+	return 0, false
+}
+
+// RitualSkill returns the path whose skill is rolled against when gathering energy for and casting the ritual, which is
+// the lowest of the paths it uses, along with the entity's level with it. Returns false if the ritual has no effects or
+// the entity is missing the skill for any of its paths.
+func (e *Entity) RitualSkill(r *Ritual) (path rpm.Path, level fxp.Int, ok bool) {
+	for i, one := range r.Paths() {
+		pathLevel, has := e.PathSkillLevel(one)
+		if !has {
+			return one, 0, false
+		}
+		if i == 0 || pathLevel < level {
+			path = one
+			level = pathLevel
+		}
+	}
+	return path, level, len(r.Effects) != 0
+}
+
+// GrimoireState returns a snapshot of the data that may be altered by changing the grimoire or gathering energy for its
+// rituals.
+func (e *Entity) GrimoireState() *GrimoireState {
+	return &GrimoireState{
+		grimoire:  CloneRituals(e.Grimoire),
+		changeLog: slices.Clone(e.ChangeLog),
+	}
+}
+
+// ApplyGrimoireState restores a snapshot previously obtained from GrimoireState.
+func (e *Entity) ApplyGrimoireState(s *GrimoireState) {
+	e.Grimoire = CloneRituals(s.grimoire)
+	e.ChangeLog = slices.Clone(s.changeLog)
+}
+
+// GatherRitualEnergy adds the energy to that gathered for the ritual, up to its energy cost. The gathering is added to
+// the change log and a description of it is returned.
+func (e *Entity) GatherRitualEnergy(r *Ritual, amount int) string {
+	amount = min(max(amount, 0), r.Remaining())
+	r.Gathered += amount
+	description := fmt.Sprintf(i18n.Text("Gathered %d energy for %s; %d of %d gathered"), amount, r.Name, r.Gathered,
+		r.EnergyCost())
+	if r.Remaining() == 0 {
+		description += i18n.Text("; ready to cast")
+	}
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: description,
+	})
+	return description
+}
+
+// CastRitual spends the energy gathered for the ritual. The casting is added to the change log and a description of it
+// is returned.
+func (e *Entity) CastRitual(r *Ritual) string {
+	description := fmt.Sprintf(i18n.Text("Cast %s using %d energy"), r.Name, r.Gathered)
+	r.Gathered = 0
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: description,
+	})
+	return description
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRitualEnergyCost(t *testing.T) {
+	r := &Ritual{
+		Name: "Fire Ward",
+		Effects: []*RitualEffect{
+			{Effect: rpm.Create, Path: rpm.Energy, Greater: true},
+			{Effect: rpm.Sense, Path: rpm.Mind},
+		},
+		Modifiers: []*RitualModifier{
+			{Name: "Duration, 1 hour", Energy: 3},
+			{Name: "Area, 5 yards", Energy: 4},
+		},
+	}
+	check.Equal(t, 15, r.BaseEnergy())
+	check.Equal(t, 3, r.EnergyMultiplier())
+	check.Equal(t, 45, r.EnergyCost())
+	check.Equal(t, []rpm.Path{rpm.Energy, rpm.Mind}, r.Paths())
+	check.Equal(t, "Greater Create Energy + Lesser Sense Mind", r.EffectsText())
+
+	r.Effects[1].Greater = true
+	check.Equal(t, 75, r.EnergyCost(), "each greater effect adds 2 to the multiplier")
+
+	clone := r.Clone()
+	clone.Effects[0].Effect = rpm.Transform
+	clone.Modifiers[0].Energy = 0
+	check.Equal(t, rpm.Create, r.Effects[0].Effect, "clones don't share effects")
+	check.Equal(t, 3, r.Modifiers[0].Energy, "clones don't share modifiers")
+}
+
+func TestRitualEnergyGathering(t *testing.T) {
+	e := NewEntity()
+	body := NewSkill(e, nil, false)
+	body.Name = rpm.Body.SkillName()
+	body.Points = fxp.From(8)
+	mind := NewSkill(e, nil, false)
+	mind.Name = rpm.Mind.SkillName()
+	mind.Points = fxp.One
+	e.Skills = []*Skill{body, mind}
+	e.Recalculate()
+
+	r := &Ritual{
+		Name: "Soothe",
+		Effects: []*RitualEffect{
+			{Effect: rpm.Restore, Path: rpm.Body},
+			{Effect: rpm.Control, Path: rpm.Mind},
+		},
+	}
+	e.Grimoire = []*Ritual{r}
+	path, level, ok := e.RitualSkill(r)
+	check.True(t, ok)
+	check.Equal(t, rpm.Mind, path, "the lowest path is rolled against")
+	check.Equal(t, mind.LevelData.Level, level)
+	r.Effects = append(r.Effects, &RitualEffect{Effect: rpm.Sense, Path: rpm.Spirit})
+	_, _, ok = e.RitualSkill(r)
+	check.False(t, ok, "the Path of Spirit skill is missing")
+	r.Effects = r.Effects[:2]
+
+	before := e.GrimoireState()
+	check.Equal(t, "Gathered 5 energy for Soothe; 5 of 9 gathered", e.GatherRitualEnergy(r, 5))
+	check.Equal(t, "Gathered 4 energy for Soothe; 9 of 9 gathered; ready to cast", e.GatherRitualEnergy(r, 10))
+	check.Equal(t, 0, r.Remaining())
+	check.Equal(t, "Cast Soothe using 9 energy", e.CastRitual(r))
+	check.Equal(t, 0, r.Gathered)
+	check.Equal(t, 3, len(e.ChangeLog))
+
+	e.ApplyGrimoireState(before)
+	check.Equal(t, 0, len(e.ChangeLog))
+	check.Equal(t, 1, len(e.Grimoire))
+	check.Equal(t, 9, e.Grimoire[0].Remaining())
+}
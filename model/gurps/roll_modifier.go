@@ -0,0 +1,106 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// RollModifier holds a situational modifier, such as one waiting in a character's modifiers tray to be applied to their
+// next success roll.
+type RollModifier struct {
+	Description string `json:"description,omitempty"`
+	Modifier    int    `json:"modifier"`
+}
+
+// StandardRollModifiers returns the commonly used situational modifiers that are always offered by the modifiers tray.
+func StandardRollModifiers() []*RollModifier {
+	return []*RollModifier{
+		{Description: i18n.Text("Aim"), Modifier: 1},
+		{Description: i18n.Text("All-Out Attack (Determined)"), Modifier: 4},
+		{Description: i18n.Text("Telegraphic Attack"), Modifier: 4},
+		{Description: i18n.Text("Deceptive Attack"), Modifier: -2},
+		{Description: i18n.Text("Move and Attack"), Modifier: -4},
+		{Description: i18n.Text("Bad Footing"), Modifier: -2},
+		{Description: i18n.Text("Partial Darkness"), Modifier: -3},
+		{Description: i18n.Text("Off Hand"), Modifier: -4},
+		{Description: i18n.Text("Easy Task"), Modifier: 2},
+		{Description: i18n.Text("Hard Task"), Modifier: -2},
+	}
+}
+
+// CloneRollModifiers creates a copy of the list.
+func CloneRollModifiers(list []*RollModifier) []*RollModifier {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*RollModifier, len(list))
+	for i, one := range list {
+		m := *one
+		clone[i] = &m
+	}
+	return clone
+}
+
+// String implements fmt.Stringer.
+func (m *RollModifier) String() string {
+	if m.Description == "" {
+		return fmt.Sprintf("%+d", m.Modifier)
+	}
+	return fmt.Sprintf("%+d %s", m.Modifier, m.Description)
+}
+
+// QueueRollModifier adds a modifier to the character's modifiers tray, to be applied to their next success roll.
+// Modifiers of zero are ignored.
+func (e *Entity) QueueRollModifier(description string, modifier int) {
+	if modifier == 0 {
+		return
+	}
+	e.RollModifiers = append(e.RollModifiers, &RollModifier{
+		Description: strings.TrimSpace(description),
+		Modifier:    modifier,
+	})
+}
+
+// RemoveRollModifier removes the modifier from the character's modifiers tray.
+func (e *Entity) RemoveRollModifier(m *RollModifier) {
+	if i := slices.Index(e.RollModifiers, m); i != -1 {
+		e.RollModifiers = slices.Delete(e.RollModifiers, i, i+1)
+	}
+}
+
+// RollModifiersTotal returns the sum of the modifiers in the character's modifiers tray.
+func (e *Entity) RollModifiersTotal() int {
+	total := 0
+	for _, one := range e.RollModifiers {
+		total += one.Modifier
+	}
+	return total
+}
+
+// TakeRollModifiers empties the character's modifiers tray, returning the total of the modifiers that were in it along
+// with a description of them suitable for showing alongside the roll they were applied to. Returns an empty description
+// if the tray was empty.
+func (e *Entity) TakeRollModifiers() (total int, description string) {
+	if len(e.RollModifiers) == 0 {
+		return 0, ""
+	}
+	parts := make([]string, len(e.RollModifiers))
+	for i, one := range e.RollModifiers {
+		parts[i] = one.String()
+	}
+	total = e.RollModifiersTotal()
+	e.RollModifiers = nil
+	return total, fmt.Sprintf(i18n.Text("Modifiers applied: %s (total %+d)"), strings.Join(parts, ", "), total)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRollModifiersTray(t *testing.T) {
+	e := NewEntity()
+	total, desc := e.TakeRollModifiers()
+	check.Equal(t, 0, total)
+	check.Equal(t, "", desc)
+
+	e.QueueRollModifier(" Aim ", 1)
+	e.QueueRollModifier("Ignored", 0)
+	e.QueueRollModifier("Darkness", -3)
+	e.QueueRollModifier("", 2)
+	check.Equal(t, 3, len(e.RollModifiers))
+	check.Equal(t, "+1 Aim", e.RollModifiers[0].String())
+	check.Equal(t, "+2", e.RollModifiers[2].String())
+	check.Equal(t, 0, e.RollModifiersTotal())
+
+	e.RemoveRollModifier(e.RollModifiers[2])
+	check.Equal(t, -2, e.RollModifiersTotal())
+
+	saved := CloneRollModifiers(e.RollModifiers)
+	total, desc = e.TakeRollModifiers()
+	check.Equal(t, -2, total)
+	check.Contains(t, desc, "+1 Aim, -3 Darkness")
+	check.Equal(t, 0, len(e.RollModifiers), "taking the modifiers empties the tray")
+	check.Equal(t, 2, len(saved))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// maxWebhookContent is the maximum number of characters Discord accepts in a message's content.
+const maxWebhookContent = 2000
+
+// ValidateRollWebhookURL returns an error if the URL cannot be used as a webhook for forwarding rolls. An empty URL is
+// valid and disables forwarding.
+func ValidateRollWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return errs.NewWithCause("invalid webhook URL", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errs.New("webhook URL must use http or https")
+	}
+	if u.Host == "" {
+		return errs.New("webhook URL must include a host")
+	}
+	return nil
+}
+
+// FormatRollForWebhook returns the message content to post for a roll.
+func FormatRollForWebhook(title, detail string) string {
+	content := "**" + strings.TrimSpace(title) + "**"
+	if detail = strings.TrimSpace(detail); detail != "" {
+		content += "\n" + detail
+	}
+	if runes := []rune(content); len(runes) > maxWebhookContent {
+		content = string(runes[:maxWebhookContent-1]) + "…"
+	}
+	return content
+}
+
+// ForwardRoll posts the outcome of a roll to a Discord-compatible webhook.
+func ForwardRoll(ctx context.Context, client *http.Client, webhookURL, title, detail string) error {
+	if err := ValidateRollWebhookURL(webhookURL); err != nil {
+		return err
+	}
+	if webhookURL == "" {
+		return nil
+	}
+	data, err := json.Marshal(&struct {
+		Username string `json:"username"`
+		Content  string `json:"content"`
+	}{
+		Username: "GCS",
+		Content:  FormatRollForWebhook(title, detail),
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data)); err != nil {
+		return errs.NewWithCause("unable to create webhook request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var rsp *http.Response
+	if rsp, err = client.Do(req); err != nil {
+		return errs.NewWithCause("webhook request failed", err)
+	}
+	defer xio.DiscardAndCloseIgnoringErrors(rsp.Body)
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errs.New("unexpected response code from webhook -> " + rsp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestForwardRoll(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check.Equal(t, http.MethodPost, r.Method)
+		check.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		check.NoError(t, err)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	check.NoError(t, ForwardRoll(context.Background(), server.Client(), server.URL, "Bob: Broadsword Damage",
+		"Rolled 2d+1 cut: 9 cut"))
+	check.Equal(t, `{"username":"GCS","content":"**Bob: Broadsword Damage**\nRolled 2d+1 cut: 9 cut"}`,
+		strings.TrimSpace(body))
+
+	body = ""
+	check.NoError(t, ForwardRoll(context.Background(), server.Client(), "", "title", "detail"))
+	check.Equal(t, "", body, "an empty URL disables forwarding")
+
+	check.Error(t, ValidateRollWebhookURL("ftp://example.com/hook"))
+	check.Error(t, ValidateRollWebhookURL("https:///hook"))
+	check.NoError(t, ValidateRollWebhookURL("https://discord.com/api/webhooks/1/abc"))
+}
+
+func TestFormatRollForWebhook(t *testing.T) {
+	check.Equal(t, "**Roll**", FormatRollForWebhook(" Roll ", " "))
+	content := FormatRollForWebhook("Roll", strings.Repeat("x", 3000))
+	check.Equal(t, maxWebhookContent, len([]rune(content)))
+	check.True(t, strings.HasSuffix(content, "…"))
+}
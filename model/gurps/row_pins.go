@@ -0,0 +1,76 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// IsPinned returns true if the row with the given ID is pinned to the top of its list.
+func (e *Entity) IsPinned(id tid.TID) bool {
+	return slices.Contains(e.PinnedRows, id)
+}
+
+// SetPinned sets whether the row with the given ID is pinned to the top of its list. Pinned rows are moved to the top
+// of their list the next time ApplyPinnedRows is called.
+func (e *Entity) SetPinned(id tid.TID, pinned bool) {
+	if i := slices.Index(e.PinnedRows, id); i != -1 {
+		if !pinned {
+			e.PinnedRows = slices.Delete(e.PinnedRows, i, i+1)
+		}
+	} else if pinned {
+		e.PinnedRows = append(e.PinnedRows, id)
+	}
+}
+
+// IsOrderLocked returns true if manual reordering of the list with the given key has been locked.
+func (e *Entity) IsOrderLocked(listKey string) bool {
+	return slices.Contains(e.OrderLockedLists, listKey)
+}
+
+// SetOrderLocked sets whether manual reordering of the list with the given key is locked.
+func (e *Entity) SetOrderLocked(listKey string, locked bool) {
+	if i := slices.Index(e.OrderLockedLists, listKey); i != -1 {
+		if !locked {
+			e.OrderLockedLists = slices.Delete(e.OrderLockedLists, i, i+1)
+		}
+	} else if locked {
+		e.OrderLockedLists = append(e.OrderLockedLists, listKey)
+	}
+}
+
+// ApplyPinnedRows moves the pinned top-level rows of each list to the top of that list, preserving the relative order
+// of both the pinned and unpinned rows.
+func (e *Entity) ApplyPinnedRows() {
+	if len(e.PinnedRows) == 0 {
+		return
+	}
+	e.Traits = pinnedFirst(e, e.Traits)
+	e.Skills = pinnedFirst(e, e.Skills)
+	e.Spells = pinnedFirst(e, e.Spells)
+	e.CarriedEquipment = pinnedFirst(e, e.CarriedEquipment)
+	e.OtherEquipment = pinnedFirst(e, e.OtherEquipment)
+	e.Notes = pinnedFirst(e, e.Notes)
+}
+
+func pinnedFirst[T NodeTypes](e *Entity, list []T) []T {
+	pinned := make([]T, 0, len(list))
+	unpinned := make([]T, 0, len(list))
+	for _, one := range list {
+		if e.IsPinned(AsNode(one).ID()) {
+			pinned = append(pinned, one)
+		} else {
+			unpinned = append(unpinned, one)
+		}
+	}
+	return append(pinned, unpinned...)
+}
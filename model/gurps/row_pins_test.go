@@ -0,0 +1,41 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPinnedRows(t *testing.T) {
+	e := NewEntity()
+	a := NewSkill(e, nil, false)
+	b := NewSkill(e, nil, false)
+	c := NewSkill(e, nil, false)
+	e.Skills = []*Skill{a, b, c}
+
+	e.SetPinned(c.ID(), true)
+	e.SetPinned(b.ID(), true)
+	check.True(t, e.IsPinned(b.ID()))
+	e.ApplyPinnedRows()
+	check.Equal(t, []*Skill{b, c, a}, e.Skills, "pinned rows keep their relative order")
+
+	e.SetPinned(b.ID(), false)
+	check.False(t, e.IsPinned(b.ID()))
+	e.ApplyPinnedRows()
+	check.Equal(t, []*Skill{c, b, a}, e.Skills)
+
+	e.SetOrderLocked("skills", true)
+	check.True(t, e.IsOrderLocked("skills"))
+	check.False(t, e.IsOrderLocked("traits"))
+	e.SetOrderLocked("skills", false)
+	check.False(t, e.IsOrderLocked("skills"))
+}
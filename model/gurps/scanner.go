@@ -72,7 +72,7 @@ func scanForNamedFileSets(fileSystem fs.FS, dirPath string, extensions []string,
 			return nil
 		}
 		name := d.Name()
-		if strings.HasPrefix(name, ".") {
+		if strings.HasPrefix(name, ".") && p != dirPath {
 			if d.IsDir() {
 				return fs.SkipDir
 			}
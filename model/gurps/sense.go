@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sense"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// SenseLevel holds the resolved level for a sense roll.
+type SenseLevel struct {
+	Sense       sense.Type
+	Level       fxp.Int
+	Unavailable bool
+	Tooltip     string
+}
+
+// SenseLevels returns the resolved levels for each sense.
+func (e *Entity) SenseLevels() []SenseLevel {
+	list := make([]SenseLevel, len(sense.Types))
+	for i, one := range sense.Types {
+		list[i] = e.SenseLevel(one)
+	}
+	return list
+}
+
+// SenseLevel returns the resolved level for a sense roll. The base is the sense's own attribute, which already includes
+// any adjustments made to it by traits, or Perception if the sheet doesn't define that attribute. Any skill bonuses
+// that target the sense by name are then added.
+func (e *Entity) SenseLevel(s sense.Type) SenseLevel {
+	result := SenseLevel{Sense: s}
+	var tooltip xio.ByteBuffer
+	if result.Level = e.Attributes.Current(s.Key()); result.Level != fxp.Min {
+		fmt.Fprintf(&tooltip, "%s [%s]", s.String(), result.Level.String())
+	} else {
+		if result.Level = e.Attributes.Current(PerceptionID); result.Level == fxp.Min {
+			result.Level = 0
+			result.Unavailable = true
+			return result
+		}
+		fmt.Fprintf(&tooltip, i18n.Text("Perception [%s]"), result.Level.String())
+	}
+	result.Level += e.SkillBonusFor(s.String(), "", nil, &tooltip)
+	result.Tooltip = tooltip.String()
+	return result
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sense"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSenseLevels(t *testing.T) {
+	e := NewEntity()
+	acute := NewTrait(e, nil, false)
+	acute.Name = "Acute Hearing"
+	acute.CanLevel = true
+	acute.PointsPerLevel = fxp.Two
+	acute.Levels = fxp.Three
+	bonus := NewAttributeBonus("hearing")
+	bonus.PerLevel = true
+	acute.Features = append(acute.Features, bonus)
+	e.Traits = []*Trait{acute}
+	e.Recalculate()
+	per := e.Attributes.Current("per")
+
+	check.Equal(t, per, e.SenseLevel(sense.Vision).Level)
+	check.Equal(t, per+fxp.Three, e.SenseLevel(sense.Hearing).Level, "traits adjust the sense attribute")
+	check.False(t, e.SenseLevel(sense.Hearing).Unavailable)
+
+	delete(e.Attributes.Set, "touch")
+	check.Equal(t, per, e.SenseLevel(sense.Touch).Level, "falls back to Perception without the attribute")
+
+	delete(e.Attributes.Set, "per")
+	check.True(t, e.SenseLevel(sense.Touch).Unavailable)
+}
+
+func TestSuccessRoll(t *testing.T) {
+	r := NewSuccessRoll(12, 12)
+	check.True(t, r.Success)
+	check.False(t, r.Critical)
+	check.Equal(t, 0, r.Margin())
+
+	r = NewSuccessRoll(3, 4)
+	check.True(t, r.Success, "3 or 4 always succeeds")
+	check.True(t, r.Critical)
+
+	r = NewSuccessRoll(16, 6)
+	check.True(t, r.Critical)
+	r = NewSuccessRoll(15, 6)
+	check.False(t, r.Critical)
+
+	r = NewSuccessRoll(16, 17)
+	check.False(t, r.Success)
+	check.False(t, r.Critical)
+	r = NewSuccessRoll(15, 17)
+	check.True(t, r.Critical)
+
+	r = NewSuccessRoll(5, 15)
+	check.False(t, r.Success)
+	check.True(t, r.Critical, "failure by 10 or more is critical")
+}
@@ -82,6 +82,7 @@ type Settings struct {
 	LastDirs           map[string]string          `json:"last_dirs,omitempty"`
 	ColumnSizing       map[string]map[int]float32 `json:"column_sizing,omitempty"`
 	PageRefs           PageRefs                   `json:"page_refs,omitempty"`
+	ExportTemplates    []*ExportTemplate          `json:"export_templates,omitempty"`
 	KeyBindings        KeyBindings                `json:"key_bindings,omitempty"`
 	WorkspaceFrame     *unison.Rect               `json:"workspace_frame,omitempty"`
 	Colors             colors.Colors              `json:"theme_colors"`
@@ -91,6 +92,9 @@ type Settings struct {
 	WebServer          *websettings.Settings      `json:"web,omitempty"` // Do not use "web_server" as the key, as an earlier release used that name and it will cause a failure to load the settings file.
 	OpenNodes          map[tid.TID]int64          `json:"open_nodes,omitempty"`
 	PDFs               map[string]*PDFInfo        `json:"pdfs,omitempty"`
+	FavoriteModifiers  *FavoriteModifiers         `json:"favorite_modifiers,omitempty"`
+	RollModifiers      []*RollModifier            `json:"roll_modifiers,omitempty"`
+	GMScreen           *GMScreenSettings          `json:"gm_screen,omitempty"`
 }
 
 // IDer defines the methods required of objects that have an ID.
@@ -199,6 +203,14 @@ func (s *Settings) EnsureValidity() {
 		s.Sheet.EnsureValidity()
 	}
 	s.OpenInWindow = SanitizeDockableGroups(s.OpenInWindow)
+	if s.FavoriteModifiers == nil {
+		s.FavoriteModifiers = &FavoriteModifiers{}
+	}
+	if s.GMScreen == nil {
+		s.GMScreen = NewGMScreenSettings()
+	} else {
+		s.GMScreen.EnsureValidity()
+	}
 	if s.WebServer == nil {
 		s.WebServer = websettings.Default()
 	} else {
@@ -0,0 +1,169 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// Sheet layout file locations.
+const (
+	SheetLayoutsDirName = "Sheet Layouts"
+	SheetLayoutExt      = ".layout"
+)
+
+// Valid sheet layout header keys
+const (
+	HeaderPortraitKey            = "portrait"
+	HeaderIdentityKey            = "identity"
+	HeaderMiscKey                = "misc"
+	HeaderPointsKey              = "points"
+	HeaderDescriptionKey         = "description"
+	HeaderPrimaryAttributesKey   = "primary_attributes"
+	HeaderSecondaryAttributesKey = "secondary_attributes"
+	HeaderPointPoolsKey          = "point_pools"
+	HeaderBodyKey                = "body"
+	HeaderEncumbranceKey         = "encumbrance"
+	HeaderLiftingKey             = "lifting"
+	HeaderSensesKey              = "senses"
+	HeaderDamageKey              = "damage"
+)
+
+var allHeaderKeys = []string{
+	HeaderPortraitKey,
+	HeaderIdentityKey,
+	HeaderMiscKey,
+	HeaderPointsKey,
+	HeaderDescriptionKey,
+	HeaderPrimaryAttributesKey,
+	HeaderSecondaryAttributesKey,
+	HeaderPointPoolsKey,
+	HeaderBodyKey,
+	HeaderEncumbranceKey,
+	HeaderLiftingKey,
+	HeaderSensesKey,
+	HeaderDamageKey,
+}
+
+// SheetLayout describes an alternate arrangement of a character sheet for PDF and image exports and printing. Anything
+// left unset falls back to the sheet's own settings.
+type SheetLayout struct {
+	Version int    `json:"version"`
+	Name    string `json:"name,omitempty"`
+	// Page replaces the sheet's page settings.
+	Page *PageSettings `json:"page,omitempty"`
+	// Header holds the rows of blocks to place at the top of the first page, each row being a space-separated list of
+	// header keys. Unlike the list blocks, header blocks may be omitted entirely. When empty, the standard header is
+	// used.
+	Header []string `json:"header,omitempty"`
+	// Blocks replaces the sheet's block layout for the lists that follow the header.
+	Blocks *BlockLayout `json:"blocks,omitempty"`
+}
+
+// HeaderKeys returns the valid header keys.
+func HeaderKeys() []string {
+	return append([]string(nil), allHeaderKeys...)
+}
+
+// NewSheetLayoutFromFile loads a SheetLayout from a file.
+func NewSheetLayoutFromFile(fileSystem fs.FS, filePath string) (*SheetLayout, error) {
+	var layout SheetLayout
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &layout); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(layout.Version); err != nil {
+		return nil, err
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	if layout.Name == "" {
+		layout.Name = xfs.BaseName(filePath)
+	}
+	if layout.Page != nil {
+		layout.Page.EnsureValidity()
+	}
+	return &layout, nil
+}
+
+// Save writes the SheetLayout to the file as JSON.
+func (l *SheetLayout) Save(filePath string) error {
+	l.Version = jio.CurrentDataVersion
+	return jio.SaveToFile(context.Background(), filePath, l)
+}
+
+// Validate returns an error if the header refers to unknown blocks or places a block more than once.
+func (l *SheetLayout) Validate() error {
+	valid := make(map[string]bool, len(allHeaderKeys))
+	for _, k := range allHeaderKeys {
+		valid[k] = true
+	}
+	seen := make(map[string]bool)
+	for _, line := range l.Header {
+		for _, key := range strings.Fields(strings.ToLower(line)) {
+			if !valid[key] {
+				return errs.Newf(i18n.Text("unknown header block: %s"), key)
+			}
+			if seen[key] {
+				return errs.Newf(i18n.Text("header block used more than once: %s"), key)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
+
+// HeaderRows breaks the header down into rows of keys, or returns nil if the standard header should be used.
+func (l *SheetLayout) HeaderRows() [][]string {
+	if l == nil {
+		return nil
+	}
+	var rows [][]string
+	for _, line := range l.Header {
+		if keys := strings.Fields(strings.ToLower(line)); len(keys) != 0 {
+			rows = append(rows, keys)
+		}
+	}
+	return rows
+}
+
+// SheetLayouts returns the paths to the sheet layouts found in the libraries, sorted by name.
+func SheetLayouts() []string {
+	var list []string
+	for _, lib := range GlobalSettings().Libraries().List() {
+		dir := filepath.Join(lib.Path(), SheetLayoutsDirName)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs.Log(err, "dir", dir)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && !strings.HasPrefix(name, ".") && strings.EqualFold(filepath.Ext(name), SheetLayoutExt) {
+				list = append(list, filepath.Join(dir, name))
+			}
+		}
+	}
+	txt.SortStringsNaturalAscending(list)
+	return list
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSheetLayoutFromFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Compact.layout": {Data: []byte(`{
+	"version": 5,
+	"header": ["identity  Points", "", "primary_attributes secondary_attributes body"],
+	"blocks": ["skills traits", "equipment"]
+}`)},
+		"Bad.layout":       {Data: []byte(`{"version": 5, "header": ["identity wings"]}`)},
+		"Duplicate.layout": {Data: []byte(`{"version": 5, "header": ["body", "misc body"]}`)},
+	}
+	layout, err := NewSheetLayoutFromFile(fsys, "Compact.layout")
+	check.NoError(t, err)
+	check.Equal(t, "Compact", layout.Name)
+	check.Nil(t, layout.Page)
+	check.Equal(t, [][]string{
+		{HeaderIdentityKey, HeaderPointsKey},
+		{HeaderPrimaryAttributesKey, HeaderSecondaryAttributesKey, HeaderBodyKey},
+	}, layout.HeaderRows())
+	check.NotNil(t, layout.Blocks)
+	check.Equal(t, []string{BlockLayoutSkillsKey + " " + BlockLayoutTraitsKey, BlockLayoutEquipmentKey},
+		layout.Blocks.Layout[:2])
+
+	_, err = NewSheetLayoutFromFile(fsys, "Bad.layout")
+	check.Error(t, err)
+	_, err = NewSheetLayoutFromFile(fsys, "Duplicate.layout")
+	check.Error(t, err)
+
+	var none *SheetLayout
+	check.Nil(t, none.HeaderRows())
+}
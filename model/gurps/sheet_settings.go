@@ -12,10 +12,12 @@ package gurps
 import (
 	"context"
 	"io/fs"
+	"slices"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/progression"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsort"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/json"
 )
@@ -30,26 +32,42 @@ type SheetSettingsResponder interface {
 
 // SheetSettingsData holds the SheetSettings data that is written to disk.
 type SheetSettingsData struct {
-	Page                          *PageSettings      `json:"page,omitempty"`
-	BlockLayout                   *BlockLayout       `json:"block_layout,omitempty"`
-	Attributes                    *AttributeDefs     `json:"attributes,omitempty"`
-	BodyType                      *Body              `json:"body_type,alt=hit_locations,omitempty"`
-	DamageProgression             progression.Option `json:"damage_progression"`
-	DefaultLengthUnits            fxp.LengthUnit     `json:"default_length_units"`
-	DefaultWeightUnits            fxp.WeightUnit     `json:"default_weight_units"`
-	UserDescriptionDisplay        display.Option     `json:"user_description_display"`
-	ModifiersDisplay              display.Option     `json:"modifiers_display"`
-	NotesDisplay                  display.Option     `json:"notes_display"`
-	SkillLevelAdjDisplay          display.Option     `json:"skill_level_adj_display"`
-	UseMultiplicativeModifiers    bool               `json:"use_multiplicative_modifiers,omitempty"`
-	UseModifyingDicePlusAdds      bool               `json:"use_modifying_dice_plus_adds,omitempty"`
-	UseHalfStatDefaults           bool               `json:"use_half_stat_defaults,omitempty"`
-	ShowTraitModifierAdj          bool               `json:"show_trait_modifier_adj,alt=show_advantage_modifier_adj,omitempty"`
-	ShowEquipmentModifierAdj      bool               `json:"show_equipment_modifier_adj,omitempty"`
-	ShowSpellAdj                  bool               `json:"show_spell_adj,omitempty"`
-	HideSourceMismatch            bool               `json:"hide_source_mismatch,omitempty"`
-	UseTitleInFooter              bool               `json:"use_title_in_footer,omitempty"`
-	ExcludeUnspentPointsFromTotal bool               `json:"exclude_unspent_points_from_total"`
+	Page                          *PageSettings           `json:"page,omitempty"`
+	BlockLayout                   *BlockLayout            `json:"block_layout,omitempty"`
+	Attributes                    *AttributeDefs          `json:"attributes,omitempty"`
+	BodyType                      *Body                   `json:"body_type,alt=hit_locations,omitempty"`
+	DamageProgression             progression.Option      `json:"damage_progression"`
+	DefaultLengthUnits            fxp.LengthUnit          `json:"default_length_units"`
+	DefaultWeightUnits            fxp.WeightUnit          `json:"default_weight_units"`
+	UserDescriptionDisplay        display.Option          `json:"user_description_display"`
+	ModifiersDisplay              display.Option          `json:"modifiers_display"`
+	NotesDisplay                  display.Option          `json:"notes_display"`
+	SkillLevelAdjDisplay          display.Option          `json:"skill_level_adj_display"`
+	SkillOrder                    skillsort.Order         `json:"skill_order,omitempty"`
+	SpellOrder                    skillsort.Order         `json:"spell_order,omitempty"`
+	UseMultiplicativeModifiers    bool                    `json:"use_multiplicative_modifiers,omitempty"`
+	UseModifyingDicePlusAdds      bool                    `json:"use_modifying_dice_plus_adds,omitempty"`
+	UseHalfStatDefaults           bool                    `json:"use_half_stat_defaults,omitempty"`
+	ShowTraitModifierAdj          bool                    `json:"show_trait_modifier_adj,alt=show_advantage_modifier_adj,omitempty"`
+	ShowEquipmentModifierAdj      bool                    `json:"show_equipment_modifier_adj,omitempty"`
+	ShowSpellAdj                  bool                    `json:"show_spell_adj,omitempty"`
+	SimplifiedDisplay             bool                    `json:"simplified_display,omitempty"`
+	HideSourceMismatch            bool                    `json:"hide_source_mismatch,omitempty"`
+	UseTitleInFooter              bool                    `json:"use_title_in_footer,omitempty"`
+	ExcludeUnspentPointsFromTotal bool                    `json:"exclude_unspent_points_from_total"`
+	IncludeCompanionsInExports    bool                    `json:"include_companions_in_exports,omitempty"`
+	ValidateOnSave                bool                    `json:"validate_on_save,omitempty"`
+	RecordSnapshotOnSave          bool                    `json:"record_snapshot_on_save,omitempty"`
+	CreationDisadvantageLimit     fxp.Int                 `json:"creation_disadvantage_limit,omitempty"`
+	CreationQuirkLimit            fxp.Int                 `json:"creation_quirk_limit,omitempty"`
+	SpellsRequireMagery           bool                    `json:"spells_require_magery,omitempty"`
+	BlockUnmetSpellsInCreation    bool                    `json:"block_unmet_spells_in_creation,omitempty"`
+	ValidationRules               []*ValidationRule       `json:"validation_rules,omitempty"`
+	HouseRules                    []LibraryFile           `json:"house_rules,omitempty"`
+	Nameables                     []*NameableSubstitution `json:"nameables,omitempty"`
+	Rest                          *RestSettings           `json:"rest,omitempty"`
+	ThresholdMagic                *ThresholdMagicSettings `json:"threshold_magic,omitempty"`
+	ExportDecorations             *ExportDecorations      `json:"export_decorations,omitempty"`
 }
 
 // SheetSettings holds sheet settings.
@@ -82,6 +100,9 @@ func FactorySheetSettings() *SheetSettings {
 			NotesDisplay:           display.Inline,
 			SkillLevelAdjDisplay:   display.Tooltip,
 			ShowSpellAdj:           true,
+			Rest:                   NewRestSettings(),
+			ThresholdMagic:         NewThresholdMagicSettings(),
+			ExportDecorations:      NewExportDecorations(),
 		},
 	}
 }
@@ -131,6 +152,28 @@ func (s *SheetSettings) EnsureValidity() {
 	s.ModifiersDisplay = s.ModifiersDisplay.EnsureValid()
 	s.NotesDisplay = s.NotesDisplay.EnsureValid()
 	s.SkillLevelAdjDisplay = s.SkillLevelAdjDisplay.EnsureValid()
+	s.SkillOrder = s.SkillOrder.EnsureValid()
+	s.SpellOrder = s.SpellOrder.EnsureValid()
+	s.CreationDisadvantageLimit = s.CreationDisadvantageLimit.Max(0)
+	s.CreationQuirkLimit = s.CreationQuirkLimit.Max(0)
+	for _, rule := range s.ValidationRules {
+		rule.Target = rule.Target.EnsureValid()
+	}
+	if s.Rest == nil {
+		s.Rest = NewRestSettings()
+	} else {
+		s.Rest.EnsureValidity()
+	}
+	if s.ThresholdMagic == nil {
+		s.ThresholdMagic = NewThresholdMagicSettings()
+	} else {
+		s.ThresholdMagic.EnsureValidity()
+	}
+	if s.ExportDecorations == nil {
+		s.ExportDecorations = NewExportDecorations()
+	} else {
+		s.ExportDecorations.EnsureValidity()
+	}
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -155,9 +198,38 @@ func (s *SheetSettings) Clone(entity *Entity) *SheetSettings {
 	clone.BlockLayout = s.BlockLayout.Clone()
 	clone.Attributes = s.Attributes.Clone()
 	clone.BodyType = s.BodyType.Clone(entity, nil)
+	clone.ValidationRules = CloneValidationRules(s.ValidationRules)
+	clone.HouseRules = slices.Clone(s.HouseRules)
+	clone.Nameables = CloneNameableSubstitutions(s.Nameables)
+	clone.Rest = s.Rest.Clone()
+	clone.ThresholdMagic = s.ThresholdMagic.Clone()
+	clone.ExportDecorations = s.ExportDecorations.Clone()
 	return &clone
 }
 
+// EffectiveModifiersDisplay returns the display option to use for modifiers. When the simplified display is enabled,
+// modifiers are only shown in tooltips.
+func (s *SheetSettings) EffectiveModifiersDisplay() display.Option {
+	if s.SimplifiedDisplay {
+		return display.Tooltip
+	}
+	return s.ModifiersDisplay
+}
+
+// EffectiveSkillLevelAdjDisplay returns the display option to use for skill level adjustments. When the simplified
+// display is enabled, skill level adjustments are only shown in tooltips.
+func (s *SheetSettings) EffectiveSkillLevelAdjDisplay() display.Option {
+	if s.SimplifiedDisplay {
+		return display.Tooltip
+	}
+	return s.SkillLevelAdjDisplay
+}
+
+// ShowLibrarySourceColumn returns true if the library source column should be shown.
+func (s *SheetSettings) ShowLibrarySourceColumn() bool {
+	return !s.HideSourceMismatch && !s.SimplifiedDisplay
+}
+
 // SetOwningEntity sets the owning entity and configures any sub-components as needed.
 func (s *SheetSettings) SetOwningEntity(entity *Entity) {
 	s.Entity = entity
@@ -62,6 +62,7 @@ const (
 type Skill struct {
 	SkillData
 	owner             DataOwner
+	grant             *SkillGrant
 	LevelData         Level
 	UnsatisfiedReason string
 }
@@ -101,6 +102,7 @@ type SkillSyncData struct {
 	PageRefHighlight string   `json:"reference_highlight,omitempty"`
 	LocalNotes       string   `json:"notes,omitempty"`
 	Tags             []string `json:"tags,omitempty"`
+	PowerTalent      string   `json:"power_talent,omitempty"`
 }
 
 // SkillNonContainerOnlySyncData holds the sskll sync data that is only applicable to traits that aren't containers.
@@ -110,7 +112,9 @@ type SkillNonContainerOnlySyncData struct {
 	EncumbrancePenaltyMultiplier fxp.Int             `json:"encumbrance_penalty_multiplier,omitempty"`
 	Defaults                     []*SkillDefault     `json:"defaults,omitempty"`
 	TechniqueDefault             *SkillDefault       `json:"default,omitempty"`
+	TechniqueAlternateDefaults   []*SkillDefault     `json:"alternate_defaults,omitempty"`
 	TechniqueLimitModifier       *fxp.Int            `json:"limit,omitempty"`
+	WildcardCovers               []string            `json:"wildcard_covers,omitempty"`
 	Prereq                       *PrereqList         `json:"prereqs,omitempty"`
 	Weapons                      []*Weapon           `json:"weapons,omitempty"`
 	Features                     Features            `json:"features,omitempty"`
@@ -252,6 +256,11 @@ func (s *Skill) IsTechnique() bool {
 	return tid.IsKind(s.TID, kinds.Technique)
 }
 
+// IsGranted returns true if this skill was granted by a piece of equipment rather than being learned.
+func (s *Skill) IsGranted() bool {
+	return s.grant != nil
+}
+
 // Clone implements Node.
 func (s *Skill) Clone(from LibraryFile, owner DataOwner, parent *Skill, preserveID bool) *Skill {
 	var other *Skill
@@ -401,6 +410,12 @@ func (s *Skill) CellData(columnID int, data *CellData) {
 		data.Secondary = s.SecondaryText(func(option display.Option) bool { return option.Inline() })
 		data.UnsatisfiedReason = s.UnsatisfiedReason
 		data.Tooltip = s.SecondaryText(func(option display.Option) bool { return option.Tooltip() })
+		if coverage := s.WildcardCoverageText(); coverage != "" {
+			if data.Tooltip != "" {
+				data.Tooltip += "\n"
+			}
+			data.Tooltip += coverage
+		}
 		data.TemplateInfo = s.TemplatePicker.Description()
 	case SkillDifficultyColumn:
 		if !s.Container() {
@@ -427,6 +442,9 @@ func (s *Skill) CellData(columnID int, data *CellData) {
 				data.Tooltip = IncludesModifiersFrom() + ":" + level.Tooltip
 			}
 			data.Alignment = align.End
+			if level.Level.Trunc() > 0 {
+				data.Roll = SuccessCellRoll
+			}
 		}
 	case SkillRelativeLevelColumn:
 		if !s.Container() {
@@ -440,8 +458,10 @@ func (s *Skill) CellData(columnID int, data *CellData) {
 	case SkillPointsColumn:
 		data.Type = cell.Text
 		var tooltip xio.ByteBuffer
-		data.Primary = s.AdjustedPoints(&tooltip).String()
+		points := s.AdjustedPoints(&tooltip)
+		data.Primary = points.String()
 		data.Alignment = align.End
+		data.PointsDelta = EntityFromNode(s).SavedPoints().RowDelta(s.TID, points)
 		if tooltip.Len() != 0 {
 			data.Tooltip = IncludesModifiersFrom() + ":" + tooltip.String()
 		}
@@ -522,7 +542,7 @@ func (s *Skill) DefaultSkill() *Skill {
 		return nil
 	}
 	if s.IsTechnique() {
-		return s.BaseSkill(e, s.TechniqueDefault, true)
+		return s.BaseSkill(e, s.BestTechniqueDefault(), true)
 	}
 	return s.BaseSkill(e, s.DefaultedFrom, true)
 }
@@ -564,7 +584,7 @@ func (s *Skill) Description() string {
 func (s *Skill) SecondaryText(optionChecker func(display.Option) bool) string {
 	var buffer strings.Builder
 	prefs := SheetSettingsFor(EntityFromNode(s))
-	if optionChecker(prefs.ModifiersDisplay) {
+	if optionChecker(prefs.EffectiveModifiersDisplay()) {
 		text := s.ModifierNotes()
 		if strings.TrimSpace(text) != "" {
 			buffer.WriteString(text)
@@ -579,7 +599,7 @@ func (s *Skill) SecondaryText(optionChecker func(display.Option) bool) string {
 }
 
 func addTooltipForSkillLevelAdj(optionChecker func(display.Option) bool, prefs *SheetSettings, level Level, to LineBuilder) {
-	if optionChecker(prefs.SkillLevelAdjDisplay) {
+	if optionChecker(prefs.EffectiveSkillLevelAdjDisplay()) {
 		if level.Tooltip != "" && level.Tooltip != NoAdditionalModifiers() {
 			levelTooltip := level.Tooltip
 			msg := IncludesModifiersFrom()
@@ -634,7 +654,10 @@ func (s *Skill) AdjustedRelativeLevel() fxp.Int {
 	}
 	if EntityFromNode(s) != nil && s.LevelData.Level > 0 {
 		if s.IsTechnique() {
-			return s.LevelData.RelativeLevel + s.TechniqueDefault.Modifier
+			if def := s.BestTechniqueDefault(); def != nil {
+				return s.LevelData.RelativeLevel + def.Modifier
+			}
+			return s.LevelData.RelativeLevel
 		}
 		return s.LevelData.RelativeLevel
 	}
@@ -727,11 +750,15 @@ func (s *Skill) DecrementSkillLevel() {
 
 // CalculateLevel returns the computed level without updating it.
 func (s *Skill) CalculateLevel(excludes map[string]bool) Level {
+	if s.grant != nil {
+		return s.grant.calculateLevel(EntityFromNode(s), s)
+	}
 	points := s.AdjustedPoints(nil)
 	if s.IsTechnique() {
-		return CalculateTechniqueLevel(EntityFromNode(s), s.Replacements, s.NameWithReplacements(),
-			s.SpecializationWithReplacements(), s.Tags, s.TechniqueDefault, s.Difficulty.Difficulty, points, true,
+		level, _ := CalculateBestTechniqueLevel(EntityFromNode(s), s.Replacements, s.NameWithReplacements(),
+			s.SpecializationWithReplacements(), s.Tags, s.TechniqueDefaults(), s.Difficulty.Difficulty, points, true,
 			s.TechniqueLimitModifier, excludes)
+		return level
 	}
 	return CalculateSkillLevel(EntityFromNode(s), s.NameWithReplacements(), s.SpecializationWithReplacements(), s.Tags,
 		s.DefaultedFrom, s.Difficulty, points, s.EncumbrancePenaltyMultiplier)
@@ -789,6 +816,26 @@ func CalculateSkillLevel(e *Entity, name, specialization string, tags []string,
 	}
 }
 
+// CalculateBestTechniqueLevel returns the calculated level for a technique using whichever of its defaults yields the
+// highest level, along with that default. Ties go to the earliest default in the list.
+func CalculateBestTechniqueLevel(e *Entity, replacements map[string]string, name, specialization string, tags []string, defs []*SkillDefault, diffLevel difficulty.Level, points fxp.Int, requirePoints bool, limitModifier *fxp.Int, excludes map[string]bool) (Level, *SkillDefault) {
+	best := Level{Level: fxp.Min}
+	var bestDef *SkillDefault
+	for _, def := range defs {
+		// Each default gets its own copy of the exclusions, since resolving one adds the skill it used to them.
+		excl := excludes
+		if len(defs) > 1 {
+			excl = maps.Clone(excludes)
+		}
+		if level := CalculateTechniqueLevel(e, replacements, name, specialization, tags, def, diffLevel, points,
+			requirePoints, limitModifier, excl); bestDef == nil || best.Level < level.Level {
+			best = level
+			bestDef = def
+		}
+	}
+	return best, bestDef
+}
+
 // CalculateTechniqueLevel returns the calculated level for a technique.
 func CalculateTechniqueLevel(e *Entity, replacements map[string]string, name, specialization string, tags []string, def *SkillDefault, diffLevel difficulty.Level, points fxp.Int, requirePoints bool, limitModifier *fxp.Int, excludes map[string]bool) Level {
 	var tooltip xio.ByteBuffer
@@ -798,7 +845,11 @@ func CalculateTechniqueLevel(e *Entity, replacements map[string]string, name, sp
 		if def.DefaultType == SkillID {
 			defName := def.NameWithReplacements(replacements)
 			defSpec := def.SpecializationWithReplacements(replacements)
-			if list := e.SkillNamed(defName, defSpec, requirePoints, excludes); len(list) > 0 {
+			list := e.SkillNamed(defName, defSpec, requirePoints, excludes)
+			if len(list) == 0 {
+				list = e.WildcardSkillsCovering(defName, requirePoints, excludes)
+			}
+			if len(list) > 0 {
 				sk := list[0]
 				var buf strings.Builder
 				buf.WriteString(defName)
@@ -812,9 +863,11 @@ func CalculateTechniqueLevel(e *Entity, replacements map[string]string, name, sp
 				}
 				excludes[buf.String()] = true
 				if sk.IsTechnique() {
-					if sk.TechniqueDefault != nil &&
-						(sk.TechniqueDefault.NameWithReplacements(replacements) != name ||
-							sk.TechniqueDefault.SpecializationWithReplacements(replacements) != specialization) {
+					if defs := sk.TechniqueDefaults(); len(defs) != 0 &&
+						!slices.ContainsFunc(defs, func(other *SkillDefault) bool {
+							return other.NameWithReplacements(replacements) == name &&
+								other.SpecializationWithReplacements(replacements) == specialization
+						}) {
 						level = sk.CalculateLevel(excludes).Level
 					}
 				} else {
@@ -951,37 +1004,88 @@ func (s *Skill) resolveToSpecificDefaults() []*SkillDefault {
 		if e == nil || def == nil || !def.SkillBased() {
 			result = append(result, def)
 		} else {
-			for _, one := range e.SkillNamed(def.NameWithReplacements(s.Replacements),
-				def.SpecializationWithReplacements(s.Replacements), true,
-				map[string]bool{s.String(): true}) {
+			defName := def.NameWithReplacements(s.Replacements)
+			excludes := map[string]bool{s.String(): true}
+			for _, one := range e.SkillNamed(defName, def.SpecializationWithReplacements(s.Replacements), true,
+				excludes) {
 				local := *def
 				local.Specialization = one.Specialization
 				result = append(result, &local)
 			}
+			if len(e.WildcardSkillsCovering(defName, true, excludes)) != 0 {
+				local := *def
+				result = append(result, &local)
+			}
 		}
 	}
 	return result
 }
 
-// TechniqueSatisfied returns true if the Technique is satisfied.
+// TechniqueDefaults returns the defaults a technique may be based on: its primary default followed by any alternates.
+func (s *SkillNonContainerOnlySyncData) TechniqueDefaults() []*SkillDefault {
+	defs := make([]*SkillDefault, 0, 1+len(s.TechniqueAlternateDefaults))
+	if s.TechniqueDefault != nil {
+		defs = append(defs, s.TechniqueDefault)
+	}
+	for _, def := range s.TechniqueAlternateDefaults {
+		if def != nil {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// BestTechniqueDefault returns the default that currently yields the highest level for this technique, or nil if this
+// isn't a technique.
+func (s *Skill) BestTechniqueDefault() *SkillDefault {
+	if !s.IsTechnique() {
+		return nil
+	}
+	if len(s.TechniqueAlternateDefaults) == 0 {
+		return s.TechniqueDefault
+	}
+	_, def := CalculateBestTechniqueLevel(EntityFromNode(s), s.Replacements, s.NameWithReplacements(),
+		s.SpecializationWithReplacements(), s.Tags, s.TechniqueDefaults(), s.Difficulty.Difficulty,
+		s.AdjustedPoints(nil), true, s.TechniqueLimitModifier, nil)
+	return def
+}
+
+// TechniqueSatisfied returns true if the Technique is satisfied. A technique with several defaults is satisfied if any
+// one of them is.
 func (s *Skill) TechniqueSatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
-	if !s.IsTechnique() || !s.TechniqueDefault.SkillBased() {
+	if !s.IsTechnique() {
 		return true
 	}
 	e := EntityFromNode(s)
-	sk := e.BestSkillNamed(s.TechniqueDefault.NameWithReplacements(s.Replacements),
-		s.TechniqueDefault.SpecializationWithReplacements(s.Replacements), false, nil)
-	satisfied := sk != nil && (sk.IsTechnique() || sk.Points > 0)
-	if !satisfied && tooltip != nil {
+	var names []string
+	found := false
+	for _, def := range s.TechniqueDefaults() {
+		if !def.SkillBased() {
+			return true
+		}
+		sk := e.BestSkillNamed(def.NameWithReplacements(s.Replacements),
+			def.SpecializationWithReplacements(s.Replacements), false, nil)
+		if sk != nil && (sk.IsTechnique() || sk.IsGranted() || sk.Points > 0) {
+			return true
+		}
+		if sk != nil {
+			found = true
+		}
+		names = append(names, def.FullName(e, s.Replacements))
+	}
+	if len(names) == 0 {
+		return true
+	}
+	if tooltip != nil {
 		tooltip.WriteString(prefix)
-		if sk == nil {
-			tooltip.WriteString(i18n.Text("Requires a skill named "))
-		} else {
+		if found {
 			tooltip.WriteString(i18n.Text("Requires at least 1 point in the skill named "))
+		} else {
+			tooltip.WriteString(i18n.Text("Requires a skill named "))
 		}
-		tooltip.WriteString(s.TechniqueDefault.FullName(e, s.Replacements))
+		tooltip.WriteString(strings.Join(names, i18n.Text(" or ")))
 	}
-	return satisfied
+	return false
 }
 
 // TL implements TechLevelProvider.
@@ -1032,8 +1136,10 @@ func (s *Skill) Notes() string {
 // ModifierNotes returns the notes due to modifiers.
 func (s *Skill) ModifierNotes() string {
 	if s.IsTechnique() {
-		return i18n.Text("Default: ") + s.TechniqueDefault.FullName(EntityFromNode(s), s.Replacements) +
-			s.TechniqueDefault.ModifierAsString()
+		if def := s.BestTechniqueDefault(); def != nil {
+			return i18n.Text("Default: ") + def.FullName(EntityFromNode(s), s.Replacements) + def.ModifierAsString()
+		}
+		return ""
 	}
 	if s.Difficulty.Difficulty != difficulty.Wildcard {
 		defSkill := s.DefaultSkill()
@@ -1067,8 +1173,8 @@ func (s *Skill) FillWithNameableKeys(m, existing map[string]string) {
 	if s.Prereq != nil {
 		s.Prereq.FillWithNameableKeys(m, existing)
 	}
-	if s.TechniqueDefault != nil {
-		s.TechniqueDefault.FillWithNameableKeys(m, existing)
+	for _, one := range s.TechniqueDefaults() {
+		one.FillWithNameableKeys(m, existing)
 	}
 	for _, one := range s.Defaults {
 		one.FillWithNameableKeys(m, existing)
@@ -1192,10 +1298,12 @@ func (s *Skill) SyncWithSource() {
 							s.TechniqueDefault.Specialization = ""
 						}
 					}
+					s.TechniqueAlternateDefaults = cloneTechniqueDefaults(other.TechniqueAlternateDefaults)
 					if other.TechniqueLimitModifier != nil {
 						mod := *other.TechniqueLimitModifier
 						s.TechniqueLimitModifier = &mod
 					}
+					s.WildcardCovers = slices.Clone(other.WildcardCovers)
 					s.Prereq = other.Prereq.CloneResolvingEmpty(false, true)
 					s.Weapons = CloneWeapons(other.Weapons, false)
 					s.Features = other.Features.Clone()
@@ -1224,6 +1332,7 @@ func (s *SkillSyncData) hash(h hash.Hash) {
 	for _, tag := range s.Tags {
 		_, _ = h.Write([]byte(tag))
 	}
+	_, _ = h.Write([]byte(s.PowerTalent))
 }
 
 func (s *SkillContainerOnlySyncData) hash(h hash.Hash) {
@@ -1237,12 +1346,15 @@ func (s *SkillNonContainerOnlySyncData) hash(h hash.Hash) {
 	for _, one := range s.Defaults {
 		one.Hash(h)
 	}
-	if s.TechniqueDefault != nil {
-		s.TechniqueDefault.Hash(h)
+	for _, one := range s.TechniqueDefaults() {
+		one.Hash(h)
 	}
 	if s.TechniqueLimitModifier != nil {
 		_ = binary.Write(h, binary.LittleEndian, s.TechniqueLimitModifier)
 	}
+	for _, one := range s.WildcardCovers {
+		_, _ = h.Write([]byte(one))
+	}
 	s.Prereq.Hash(h)
 	for _, weapon := range s.Weapons {
 		weapon.Hash(h)
@@ -1252,6 +1364,22 @@ func (s *SkillNonContainerOnlySyncData) hash(h hash.Hash) {
 	}
 }
 
+func cloneTechniqueDefaults(defs []*SkillDefault) []*SkillDefault {
+	if len(defs) == 0 {
+		return nil
+	}
+	list := make([]*SkillDefault, len(defs))
+	for i, def := range defs {
+		def2 := *def
+		if !DefaultTypeIsSkillBased(def2.DefaultType) {
+			def2.Name = ""
+			def2.Specialization = ""
+		}
+		list[i] = &def2
+	}
+	return list
+}
+
 // CopyFrom implements node.EditorData.
 func (s *SkillEditData) CopyFrom(other *Skill) {
 	s.copyFrom(&other.SkillEditData, other.Container(), false)
@@ -1290,10 +1418,12 @@ func (s *SkillEditData) copyFrom(other *SkillEditData, isContainer, isApply bool
 			s.TechniqueDefault.Specialization = ""
 		}
 	}
+	s.TechniqueAlternateDefaults = cloneTechniqueDefaults(other.TechniqueAlternateDefaults)
 	if other.TechniqueLimitModifier != nil {
 		mod := *other.TechniqueLimitModifier
 		s.TechniqueLimitModifier = &mod
 	}
+	s.WildcardCovers = txt.CloneStringSlice(other.WildcardCovers)
 	s.Prereq = s.Prereq.CloneResolvingEmpty(isContainer, isApply)
 	s.Weapons = CloneWeapons(other.Weapons, isApply)
 	s.Features = other.Features.Clone()
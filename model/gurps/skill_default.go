@@ -144,7 +144,7 @@ func (s *SkillDefault) SkillLevel(entity *Entity, replacements map[string]string
 
 func (s *SkillDefault) best(entity *Entity, replacements map[string]string, requirePoints bool, excludes map[string]bool) fxp.Int {
 	best := fxp.Min
-	for _, sk := range entity.SkillNamed(s.NameWithReplacements(replacements),
+	for _, sk := range entity.skillsNamedOrCovering(s.NameWithReplacements(replacements),
 		s.SpecializationWithReplacements(replacements), requirePoints, excludes) {
 		if best < sk.LevelData.Level {
 			level := sk.CalculateLevel(excludes).Level
@@ -193,7 +193,7 @@ func (s *SkillDefault) SkillLevelFast(entity *Entity, replacements map[string]st
 
 func (s *SkillDefault) bestFast(entity *Entity, replacements map[string]string, requirePoints bool, excludes map[string]bool) fxp.Int {
 	best := fxp.Min
-	for _, sk := range entity.SkillNamed(s.NameWithReplacements(replacements),
+	for _, sk := range entity.skillsNamedOrCovering(s.NameWithReplacements(replacements),
 		s.SpecializationWithReplacements(replacements), requirePoints, excludes) {
 		if best < sk.LevelData.Level {
 			best = sk.LevelData.Level
@@ -0,0 +1,112 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+var _ Feature = &SkillGrant{}
+
+// SkillGrant holds the data for a skill that is granted at a fixed level while the owning equipment is equipped, such
+// as one provided by a skill chip or a HUD.
+type SkillGrant struct {
+	Type           feature.Type `json:"type"`
+	Name           string       `json:"name,omitempty"`
+	Specialization string       `json:"specialization,omitempty"`
+	Level          fxp.Int      `json:"level"`
+	owner          fmt.Stringer
+}
+
+// NewSkillGrant creates a new SkillGrant.
+func NewSkillGrant() *SkillGrant {
+	return &SkillGrant{
+		Type:  feature.SkillGrant,
+		Level: fxp.Ten,
+	}
+}
+
+// FeatureType implements Feature.
+func (s *SkillGrant) FeatureType() feature.Type {
+	return s.Type
+}
+
+// Clone implements Feature.
+func (s *SkillGrant) Clone() Feature {
+	other := *s
+	return &other
+}
+
+// FillWithNameableKeys implements Feature.
+func (s *SkillGrant) FillWithNameableKeys(m, existing map[string]string) {
+	nameable.Extract(s.Name, m, existing)
+	nameable.Extract(s.Specialization, m, existing)
+}
+
+// Hash writes this object's contents into the hasher.
+func (s *SkillGrant) Hash(h hash.Hash) {
+	if s == nil {
+		return
+	}
+	_ = binary.Write(h, binary.LittleEndian, s.Type)
+	_, _ = h.Write([]byte(s.Name))
+	_, _ = h.Write([]byte(s.Specialization))
+	_ = binary.Write(h, binary.LittleEndian, s.Level)
+}
+
+func (s *SkillGrant) createSkill(e *Entity) *Skill {
+	var replacements map[string]string
+	if na, ok := s.owner.(nameable.Accesser); ok {
+		replacements = na.NameableReplacements()
+	}
+	sk := NewSkill(e, nil, false)
+	sk.Name = nameable.Apply(s.Name, replacements)
+	sk.Specialization = nameable.Apply(s.Specialization, replacements)
+	sk.Points = 0
+	sk.grant = s
+	sk.LevelData = sk.CalculateLevel(nil)
+	return sk
+}
+
+func (s *SkillGrant) calculateLevel(e *Entity, sk *Skill) Level {
+	var tooltip xio.ByteBuffer
+	if s.owner != nil {
+		fmt.Fprintf(&tooltip, i18n.Text("\nGranted by %s [%s]"), s.owner.String(), s.Level.String())
+	}
+	relativeLevel := e.SkillBonusFor(sk.Name, sk.Specialization, sk.Tags, &tooltip)
+	return Level{
+		Level:         s.Level + relativeLevel,
+		RelativeLevel: relativeLevel,
+		Tooltip:       tooltip.String(),
+	}
+}
+
+// GrantedSkills returns the skills currently granted by equipped equipment. These do not appear in the skill list, but
+// participate in skill lookups, such as those used by defaults and prerequisites.
+func (e *Entity) GrantedSkills() []*Skill {
+	return e.grantedSkills
+}
+
+func (e *Entity) updateGrantedSkills() {
+	e.grantedSkills = nil
+	for _, one := range e.features.skillGrants {
+		if one.Name != "" {
+			e.grantedSkills = append(e.grantedSkills, one.createSkill(e))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSkillGrant(t *testing.T) {
+	e := NewEntity()
+	chip := NewEquipment(e, nil, false)
+	grant := NewSkillGrant()
+	grant.Name = "Electronics Operation"
+	grant.Specialization = "Security"
+	grant.Level = fxp.From(14)
+	chip.Features = Features{grant}
+	e.CarriedEquipment = []*Equipment{chip}
+	sk := NewSkill(e, nil, false)
+	sk.Name = "Electronics Repair"
+	sk.Specialization = "Security"
+	sk.Points = 0
+	sk.Defaults = []*SkillDefault{{
+		DefaultType:    SkillID,
+		Name:           "Electronics Operation",
+		Specialization: "Security",
+		Modifier:       -fxp.Five,
+	}}
+	e.Skills = []*Skill{sk}
+	e.Recalculate()
+
+	best := e.BestSkillNamed("Electronics Operation", "Security", true, nil)
+	check.NotNil(t, best, "granted skill satisfies a lookup that requires points")
+	check.True(t, best.IsGranted())
+	check.Equal(t, fxp.From(14), best.LevelData.Level)
+	check.NotNil(t, sk.DefaultedFrom, "default chain uses the granted skill")
+	check.Equal(t, fxp.Nine, sk.DefaultedFrom.Level)
+
+	chip.Equipped = false
+	e.Recalculate()
+	check.Equal(t, 0, len(e.SkillNamed("Electronics Operation", "Security", false, nil)), "unequipped gear grants nothing")
+	check.Nil(t, sk.DefaultedFrom)
+}
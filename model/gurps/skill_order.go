@@ -0,0 +1,141 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsort"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+type skillOrderKey struct {
+	name      string
+	attribute string
+	level     fxp.Int
+	points    fxp.Int
+	container bool
+}
+
+// SortedSkills returns a copy of the skills, arranged in the given order. Only the list itself is sorted, not the
+// children of any containers within it. Containers keep their arranged order relative to each other and are listed
+// ahead of the skills.
+func SortedSkills(entity *Entity, list []*Skill, order skillsort.Order) []*Skill {
+	return sortedByOrder(entity, list, order, func(s *Skill) *skillOrderKey {
+		key := &skillOrderKey{
+			name:      s.String(),
+			attribute: s.Difficulty.Attribute,
+			level:     s.LevelData.Level,
+			container: s.Container(),
+		}
+		if !key.container {
+			key.points = s.AdjustedPoints(nil)
+			if s.IsTechnique() && s.TechniqueDefault != nil {
+				key.attribute = s.TechniqueDefault.DefaultType
+			}
+		}
+		return key
+	})
+}
+
+// SortedSpells returns a copy of the spells, arranged in the given order. Only the list itself is sorted, not the
+// children of any containers within it. Containers keep their arranged order relative to each other and are listed
+// ahead of the spells.
+func SortedSpells(entity *Entity, list []*Spell, order skillsort.Order) []*Spell {
+	return sortedByOrder(entity, list, order, func(s *Spell) *skillOrderKey {
+		key := &skillOrderKey{
+			name:      s.String(),
+			attribute: s.Difficulty.Attribute,
+			level:     s.LevelData.Level,
+			container: s.Container(),
+		}
+		if !key.container {
+			key.points = s.AdjustedPoints(nil)
+		}
+		return key
+	})
+}
+
+func sortedByOrder[T any](entity *Entity, list []T, order skillsort.Order, keyFor func(T) *skillOrderKey) []T {
+	if order == skillsort.Manual || len(list) < 2 {
+		return slices.Clone(list)
+	}
+	type entry struct {
+		key  *skillOrderKey
+		data T
+	}
+	entries := make([]entry, len(list))
+	for i, one := range list {
+		entries[i] = entry{key: keyFor(one), data: one}
+	}
+	var attributeRank map[string]int
+	if order == skillsort.Attribute {
+		defs := AttributeDefsFor(entity).List(true)
+		attributeRank = make(map[string]int, len(defs))
+		for i, def := range defs {
+			attributeRank[def.ID()] = i
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return a.key.compare(b.key, order, attributeRank)
+	})
+	result := make([]T, len(entries))
+	for i, one := range entries {
+		result[i] = one.data
+	}
+	return result
+}
+
+func (k *skillOrderKey) compare(other *skillOrderKey, order skillsort.Order, attributeRank map[string]int) int {
+	if k.container != other.container {
+		if k.container {
+			return -1
+		}
+		return 1
+	}
+	if k.container {
+		return 0
+	}
+	var result int
+	switch order {
+	case skillsort.EffectiveLevel:
+		result = cmp.Compare(other.level, k.level)
+	case skillsort.PointsPerLevel:
+		if result = cmp.Compare(k.pointsPerLevel(), other.pointsPerLevel()); result == 0 {
+			result = cmp.Compare(other.level, k.level)
+		}
+	case skillsort.Attribute:
+		result = cmp.Compare(k.attributeRank(attributeRank), other.attributeRank(attributeRank))
+	default:
+	}
+	if result == 0 {
+		result = txt.NaturalCmp(k.name, other.name, true)
+	}
+	return result
+}
+
+// pointsPerLevel returns the points spent for each level of skill. Skills without a usable level sort after those
+// with one.
+func (k *skillOrderKey) pointsPerLevel() fxp.Int {
+	if k.level <= 0 {
+		return fxp.Max
+	}
+	return k.points.Div(k.level)
+}
+
+func (k *skillOrderKey) attributeRank(ranks map[string]int) int {
+	if rank, ok := ranks[k.attribute]; ok {
+		return rank
+	}
+	return math.MaxInt
+}
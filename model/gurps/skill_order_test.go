@@ -0,0 +1,66 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsort"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSortedSkills(t *testing.T) {
+	e := NewEntity()
+	newSkill := func(name, attribute string, level, points int) *Skill {
+		sk := NewSkill(e, nil, false)
+		sk.Name = name
+		sk.Difficulty.Attribute = attribute
+		sk.Points = fxp.From(points)
+		sk.LevelData.Level = fxp.From(level)
+		return sk
+	}
+	stealth := newSkill("Stealth", "dx", 14, 8)
+	lockpicking := newSkill("Lockpicking", "iq", 12, 2)
+	climbing := newSkill("Climbing", "dx", 12, 4)
+	swimming := newSkill("Swimming", "ht", 0, 0)
+	container := NewSkill(e, nil, true)
+	container.Name = "Combat"
+	list := []*Skill{stealth, lockpicking, container, climbing, swimming}
+
+	names := func(order skillsort.Order) []string {
+		sorted := SortedSkills(e, list, order)
+		result := make([]string, len(sorted))
+		for i, one := range sorted {
+			result[i] = one.Name
+		}
+		return result
+	}
+	check.Equal(t, []string{"Stealth", "Lockpicking", "Combat", "Climbing", "Swimming"}, names(skillsort.Manual))
+	check.Equal(t, []string{"Combat", "Stealth", "Climbing", "Lockpicking", "Swimming"}, names(skillsort.EffectiveLevel))
+	check.Equal(t, []string{"Combat", "Lockpicking", "Climbing", "Stealth", "Swimming"}, names(skillsort.PointsPerLevel))
+	check.Equal(t, []string{"Combat", "Climbing", "Stealth", "Lockpicking", "Swimming"}, names(skillsort.Attribute))
+	check.Equal(t, "Stealth", list[0].Name, "the original list is left untouched")
+}
+
+func TestSkillOrderPersistence(t *testing.T) {
+	s := FactorySheetSettings()
+	s.SkillOrder = skillsort.PointsPerLevel
+	s.SpellOrder = skillsort.Attribute
+	data, err := s.MarshalJSON()
+	check.NoError(t, err)
+	check.Contains(t, string(data), `"skill_order":"points_per_level"`)
+	var loaded SheetSettings
+	check.NoError(t, loaded.UnmarshalJSON(data))
+	check.Equal(t, skillsort.PointsPerLevel, loaded.SkillOrder)
+	check.Equal(t, skillsort.Attribute, loaded.SpellOrder)
+	check.NoError(t, loaded.UnmarshalJSON([]byte(`{"skill_order":"bogus"}`)))
+	check.Equal(t, skillsort.Manual, loaded.SkillOrder)
+}
@@ -68,10 +68,7 @@ func (p *SkillPrereq) FillWithNameableKeys(m, existing map[string]string) {
 
 // Satisfied implements Prereq.
 func (p *SkillPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
-	var replacements map[string]string
-	if na, ok := exclude.(nameable.Accesser); ok {
-		replacements = na.NameableReplacements()
-	}
+	replacements := entity.prereqReplacements(exclude)
 	satisfied := false
 	var techLevel *string
 	if sk, ok := exclude.(*Skill); ok {
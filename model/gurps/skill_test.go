@@ -0,0 +1,63 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+func TestTechniqueAlternateDefaults(t *testing.T) {
+	e := NewEntity()
+	broadsword := NewSkill(e, nil, false)
+	broadsword.Name = "Broadsword"
+	broadsword.Points = fxp.Four
+	karate := NewSkill(e, nil, false)
+	karate.Name = "Karate"
+	karate.Difficulty.Difficulty = difficulty.Hard
+	karate.Points = fxp.Twelve
+	disarming := NewTechnique(e, nil, "Broadsword")
+	disarming.Name = "Disarming"
+	disarming.TechniqueDefault.Modifier = -fxp.Two
+	disarming.TechniqueAlternateDefaults = []*SkillDefault{{DefaultType: SkillID, Name: "Karate", Modifier: -fxp.One}}
+	e.Skills = []*Skill{broadsword, karate, disarming}
+	e.Recalculate()
+
+	check.Equal(t, fxp.From(11), broadsword.LevelData.Level)
+	check.Equal(t, fxp.Twelve, karate.LevelData.Level)
+	check.Equal(t, fxp.Twelve, disarming.LevelData.Level, "the best default is used")
+	check.Equal(t, disarming.TechniqueAlternateDefaults[0], disarming.BestTechniqueDefault())
+	check.Equal(t, karate, disarming.DefaultSkill())
+	check.Equal(t, fxp.Int(0), disarming.AdjustedRelativeLevel())
+	check.Equal(t, "Default: Karate-1", disarming.ModifierNotes())
+
+	karate.Points = 0
+	e.Recalculate()
+	check.Equal(t, fxp.Ten, disarming.LevelData.Level)
+	check.Equal(t, disarming.TechniqueDefault, disarming.BestTechniqueDefault())
+	check.Equal(t, "Default: Broadsword-2", disarming.ModifierNotes())
+	check.True(t, disarming.TechniqueSatisfied(nil, ""), "any one default is enough")
+
+	broadsword.Points = 0
+	e.Recalculate()
+	var tooltip xio.ByteBuffer
+	check.False(t, disarming.TechniqueSatisfied(&tooltip, ""))
+	check.Equal(t, "Requires at least 1 point in the skill named Broadsword or Karate", tooltip.String())
+
+	other := NewTechnique(e, nil, "")
+	other.CopyFrom(disarming)
+	check.Equal(t, 1, len(other.TechniqueAlternateDefaults))
+	check.False(t, other.TechniqueAlternateDefaults[0] == disarming.TechniqueAlternateDefaults[0],
+		"the alternate defaults are copied, not shared")
+}
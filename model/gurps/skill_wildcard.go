@@ -0,0 +1,84 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// IsWildcard returns true if this is a wildcard (bang!) skill.
+func (s *Skill) IsWildcard() bool {
+	return !s.Container() && !s.IsTechnique() && s.Difficulty.Difficulty == difficulty.Wildcard
+}
+
+// WildcardCoversWithReplacements returns the names of the regular skills this wildcard skill covers, with any
+// replacements applied.
+func (s *Skill) WildcardCoversWithReplacements() []string {
+	if !s.IsWildcard() || len(s.WildcardCovers) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(s.WildcardCovers))
+	for _, one := range s.WildcardCovers {
+		list = append(list, nameable.Apply(one, s.Replacements))
+	}
+	return list
+}
+
+// CoversSkill returns true if this wildcard skill covers the regular skill with the given name. A covered skill is
+// covered regardless of its specialization.
+func (s *Skill) CoversSkill(name string) bool {
+	for _, one := range s.WildcardCoversWithReplacements() {
+		if strings.EqualFold(one, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// WildcardSkillsCovering returns the wildcard skills that cover the regular skill with the given name.
+func (e *Entity) WildcardSkillsCovering(name string, requirePoints bool, excludes map[string]bool) []*Skill {
+	var list []*Skill
+	Traverse(func(sk *Skill) bool {
+		if !excludes[sk.String()] && sk.CoversSkill(name) && (!requirePoints || sk.AdjustedPoints(nil) > 0) {
+			list = append(list, sk)
+		}
+		return false
+	}, false, true, e.Skills...)
+	return list
+}
+
+// WildcardCoverageText returns a description of the regular skills this wildcard skill replaces, or an empty string if
+// it doesn't replace any.
+func (s *Skill) WildcardCoverageText() string {
+	covers := s.WildcardCoversWithReplacements()
+	if len(covers) == 0 {
+		return ""
+	}
+	return i18n.Text("Replaces: ") + strings.Join(covers, ", ")
+}
+
+// WildcardPointsSatisfied returns true if the points spent on this skill are valid for a wildcard skill. Wildcard skills
+// cost three times as much as a Very Hard skill, so their points must be a multiple of 3.
+func (s *Skill) WildcardPointsSatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
+	if !s.IsWildcard() || s.Points.Mod(fxp.Three) == 0 {
+		return true
+	}
+	if tooltip != nil {
+		tooltip.WriteString(prefix)
+		tooltip.WriteString(i18n.Text("Wildcard skills cost three times as much as Very Hard skills, so their points must be a multiple of 3"))
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWildcardSkills(t *testing.T) {
+	e := NewEntity()
+	sword := NewSkill(e, nil, false)
+	sword.Name = "Sword!"
+	sword.Difficulty.Difficulty = difficulty.Wildcard
+	sword.Points = fxp.Twelve
+	sword.WildcardCovers = []string{"Broadsword", "Shortsword"}
+	disarming := NewTechnique(e, nil, "Broadsword")
+	disarming.Name = "Disarming"
+	e.Skills = []*Skill{sword, disarming}
+	e.Recalculate()
+
+	check.True(t, sword.IsWildcard())
+	check.Equal(t, fxp.From(9), sword.LevelData.Level)
+	check.True(t, sword.CoversSkill("shortsword"))
+	check.False(t, sword.CoversSkill("Rapier"))
+	check.Equal(t, "Replaces: Broadsword, Shortsword", sword.WildcardCoverageText())
+
+	def := &SkillDefault{DefaultType: SkillID, Name: "Broadsword", Modifier: -fxp.Two}
+	check.Equal(t, fxp.From(7), def.SkillLevel(e, nil, true, nil, false), "the wildcard skill covers Broadsword")
+	check.Equal(t, sword, e.BestSkillNamed("Broadsword", "", true, nil))
+	check.True(t, disarming.TechniqueSatisfied(nil, ""))
+	check.Equal(t, sword, disarming.DefaultSkill())
+	check.NotEqual(t, fxp.Min, disarming.LevelData.Level)
+
+	check.Equal(t, "", sword.UnsatisfiedReason)
+	sword.Points = fxp.Ten
+	e.Recalculate()
+	check.False(t, sword.WildcardPointsSatisfied(nil, ""), "wildcard points must be a multiple of 3")
+	check.NotEqual(t, "", sword.UnsatisfiedReason)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// SorcerousEmpowermentName is the name of the trait that grants the ability to cast sorcery spells.
+const SorcerousEmpowermentName = "Sorcerous Empowerment"
+
+// IsSorcerousEmpowerment returns true if this is the Sorcerous Empowerment trait.
+func (t *Trait) IsSorcerousEmpowerment() bool {
+	return !t.Container() && strings.EqualFold(t.NameWithReplacements(), SorcerousEmpowermentName)
+}
+
+// IsSorcerySpell returns true if this is a spell within a sorcery container.
+func (t *Trait) IsSorcerySpell() bool {
+	if t.Container() || t.IsSorcerousEmpowerment() {
+		return false
+	}
+	for p := t.parent; p != nil; p = p.parent {
+		if p.ContainerType == container.Sorcery {
+			return true
+		}
+	}
+	return false
+}
+
+// SorcerousEmpowerment returns the level of the entity's Sorcerous Empowerment. Returns false if the entity doesn't
+// have it.
+func (e *Entity) SorcerousEmpowerment() (level fxp.Int, has bool) {
+	Traverse(func(t *Trait) bool {
+		if t.IsSorcerousEmpowerment() {
+			level = t.CurrentLevel()
+			has = true
+		}
+		return has
+	}, true, true, e.Traits...)
+	return level, has
+}
+
+func (e *Entity) checkSorcery(issues []*ValidationIssue) []*ValidationIssue {
+	level, has := e.SorcerousEmpowerment()
+	Traverse(func(t *Trait) bool {
+		if !t.IsSorcerySpell() {
+			return false
+		}
+		var msg string
+		switch {
+		case !has:
+			msg = fmt.Sprintf(i18n.Text("%s is a sorcery spell, but %s has not been taken"), t.String(),
+				SorcerousEmpowermentName)
+		case level < t.Empowerment:
+			msg = fmt.Sprintf(i18n.Text("%s requires %s %s, but it is only at level %s"), t.String(),
+				SorcerousEmpowermentName, t.Empowerment.String(), level.String())
+		default:
+			return false
+		}
+		issues = append(issues, &ValidationIssue{
+			Target:  vtarget.Traits,
+			ID:      t.TID,
+			Subject: t.String(),
+			Message: msg,
+		})
+		return false
+	}, true, true, e.Traits...)
+	return issues
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSorcery(t *testing.T) {
+	e := NewEntity()
+	sorcery := NewTrait(e, nil, true)
+	sorcery.Name = "Sorcery"
+	sorcery.ContainerType = container.Sorcery
+	empowerment := NewTrait(e, sorcery, false)
+	empowerment.Name = SorcerousEmpowermentName
+	empowerment.CanLevel = true
+	empowerment.Levels = fxp.Two
+	empowerment.BasePoints = fxp.From(15)
+	empowerment.PointsPerLevel = fxp.Ten
+	fireball := NewTrait(e, sorcery, false)
+	fireball.Name = "Fireball"
+	fireball.BasePoints = fxp.Twenty
+	fireball.Empowerment = fxp.One
+	teleport := NewTrait(e, sorcery, false)
+	teleport.Name = "Teleport"
+	teleport.BasePoints = fxp.Ten
+	teleport.Empowerment = fxp.Three
+	sorcery.Children = []*Trait{empowerment, fireball, teleport}
+	e.Traits = []*Trait{sorcery}
+	e.Recalculate()
+
+	check.False(t, empowerment.IsSorcerySpell())
+	check.True(t, fireball.IsSorcerySpell())
+	check.Equal(t, fxp.From(57), sorcery.AdjustedPoints(),
+		"empowerment is paid in full, the most expensive spell is too and the other costs 1/5")
+
+	issues := e.checkSorcery(nil)
+	check.Equal(t, 1, len(issues))
+	check.Equal(t, teleport.TID, issues[0].ID)
+	check.Equal(t, "Teleport requires Sorcerous Empowerment 3, but it is only at level 2", issues[0].Message)
+
+	empowerment.Levels = fxp.Three
+	check.Equal(t, 0, len(e.checkSorcery(nil)))
+
+	empowerment.Disabled = true
+	check.Equal(t, 2, len(e.checkSorcery(nil)), "every spell needs Sorcerous Empowerment")
+}
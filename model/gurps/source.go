@@ -64,49 +64,49 @@ func (s Source) String() string {
 	return s.LibraryFile.String() + "\n" + i18n.Text("ID: ") + string(s.TID)
 }
 
-func (l LibraryFile) String() string {
-	return i18n.Text("Library: ") + l.Library + "\n" + i18n.Text("Path: ") + l.Path
-}
-
-// PrepareHashes for the given ListProvider.
-func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
-	neededLibs := make(map[LibraryFile]struct{})
+// collectSourceLibraryFiles adds the library files that the rows in the lists, and their modifiers, were copied from.
+func collectSourceLibraryFiles(m map[LibraryFile]struct{}, traits []*Trait, skills []*Skill, spells []*Spell, notes []*Note, equipment ...[]*Equipment) {
 	Traverse(func(t *Trait) bool {
-		t.Source.collectInto(neededLibs)
+		t.Source.collectInto(m)
 		Traverse(func(mod *TraitModifier) bool {
-			mod.Source.collectInto(neededLibs)
+			mod.Source.collectInto(m)
 			return false
 		}, false, false, t.Modifiers...)
 		return false
-	}, false, false, provider.TraitList()...)
+	}, false, false, traits...)
 	Traverse(func(s *Skill) bool {
-		s.Source.collectInto(neededLibs)
+		s.Source.collectInto(m)
 		return false
-	}, false, false, provider.SkillList()...)
+	}, false, false, skills...)
 	Traverse(func(s *Spell) bool {
-		s.Source.collectInto(neededLibs)
+		s.Source.collectInto(m)
 		return false
-	}, false, false, provider.SpellList()...)
-	Traverse(func(e *Equipment) bool {
-		e.Source.collectInto(neededLibs)
-		Traverse(func(mod *EquipmentModifier) bool {
-			mod.Source.collectInto(neededLibs)
+	}, false, false, spells...)
+	for _, list := range equipment {
+		Traverse(func(e *Equipment) bool {
+			e.Source.collectInto(m)
+			Traverse(func(mod *EquipmentModifier) bool {
+				mod.Source.collectInto(m)
+				return false
+			}, false, false, e.Modifiers...)
 			return false
-		}, false, false, e.Modifiers...)
-		return false
-	}, false, false, provider.CarriedEquipmentList()...)
-	Traverse(func(e *Equipment) bool {
-		e.Source.collectInto(neededLibs)
-		Traverse(func(mod *EquipmentModifier) bool {
-			mod.Source.collectInto(neededLibs)
-			return false
-		}, false, false, e.Modifiers...)
-		return false
-	}, false, false, provider.OtherEquipmentList()...)
+		}, false, false, list...)
+	}
 	Traverse(func(n *Note) bool {
-		n.Source.collectInto(neededLibs)
+		n.Source.collectInto(m)
 		return false
-	}, false, false, provider.NoteList()...)
+	}, false, false, notes...)
+}
+
+func (l LibraryFile) String() string {
+	return i18n.Text("Library: ") + l.Library + "\n" + i18n.Text("Path: ") + l.Path
+}
+
+// PrepareHashes for the given ListProvider.
+func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
+	neededLibs := make(map[LibraryFile]struct{})
+	collectSourceLibraryFiles(neededLibs, provider.TraitList(), provider.SkillList(), provider.SpellList(),
+		provider.NoteList(), provider.CarriedEquipmentList(), provider.OtherEquipmentList())
 	libs := GlobalSettings().Libraries()
 	if sm.libHashes == nil {
 		sm.libHashes = make(map[LibraryFile]libSrcData)
@@ -124,7 +124,7 @@ func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
 		}
 		modTime := stat.ModTime()
 		if data, exists := sm.libHashes[libFile]; exists {
-			if modTime.Compare(data.timestamp) >= 0 {
+			if !modTime.After(data.timestamp) {
 				continue // We've already loaded this file and it hasn't changed.
 			}
 			delete(sm.libHashes, libFile)
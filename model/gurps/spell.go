@@ -420,6 +420,7 @@ func SpellsHeaderData(columnID int) HeaderData {
 
 // CellData returns the cell data information for the given column.
 func (s *Spell) CellData(columnID int, data *CellData) {
+	data.Dim = s.UnsatisfiedReason != ""
 	switch columnID {
 	case SpellDescriptionColumn:
 		data.Type = cell.Text
@@ -469,10 +470,10 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 			data.Primary = s.Difficulty.Description(EntityFromNode(s))
 		}
 	case SpellPrereqCountColumn:
-		if !s.Container() && s.IsRitualMagic() {
+		if !s.Container() {
 			data.Type = cell.Text
 			data.Alignment = align.End
-			data.Primary = strconv.Itoa(s.RitualPrereqCount)
+			data.Primary = strconv.Itoa(s.PrereqCount())
 		}
 	case SpellTagsColumn:
 		data.Type = cell.Tags
@@ -494,6 +495,9 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 				data.Tooltip = IncludesModifiersFrom() + ":" + level.Tooltip
 			}
 			data.Alignment = align.End
+			if level.Level.Trunc() > 0 {
+				data.Roll = SuccessCellRoll
+			}
 		}
 	case SpellRelativeLevelColumn:
 		if !s.Container() {
@@ -514,8 +518,10 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 	case SpellPointsColumn:
 		data.Type = cell.Text
 		var tooltip xio.ByteBuffer
-		data.Primary = s.AdjustedPoints(&tooltip).String()
+		points := s.AdjustedPoints(&tooltip)
+		data.Primary = points.String()
 		data.Alignment = align.End
+		data.PointsDelta = EntityFromNode(s).SavedPoints().RowDelta(s.TID, points)
 		if tooltip.Len() != 0 {
 			data.Tooltip = IncludesModifiersFrom() + ":" + tooltip.String()
 		}
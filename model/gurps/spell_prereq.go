@@ -72,10 +72,7 @@ func (p *SpellPrereq) FillWithNameableKeys(m, existing map[string]string) {
 
 // Satisfied implements Prereq.
 func (p *SpellPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
-	var replacements map[string]string
-	if na, ok := exclude.(nameable.Accesser); ok {
-		replacements = na.NameableReplacements()
-	}
+	replacements := entity.prereqReplacements(exclude)
 	var techLevel *string
 	if sp, ok := exclude.(*Spell); ok {
 		techLevel = sp.TechLevel
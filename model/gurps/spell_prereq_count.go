@@ -19,15 +19,18 @@ import (
 
 // CountPrereqsForSpell returns the number of prerequisites for the specified spell.
 func CountPrereqsForSpell(spell *Spell, availableSpells []*Spell, nonSpellsCountAs int, useHighestInOr bool) int {
-	return countPrereqsForList(spell.Prereq, availableSpells, nonSpellsCountAs, useHighestInOr)
+	return countPrereqsForList(spell.Prereq, availableSpells, nonSpellsCountAs, useHighestInOr,
+		map[*Spell]bool{spell: true})
 }
 
-func countPrereqsForList(list *PrereqList, availableSpells []*Spell, nonSpellsCountAs int, useHighestInOr bool) int {
+// countPrereqsForList counts the prerequisites in the list. Spells already being counted are tracked so that a chain of
+// prerequisites that loops back on itself doesn't recurse forever.
+func countPrereqsForList(list *PrereqList, availableSpells []*Spell, nonSpellsCountAs int, useHighestInOr bool, counting map[*Spell]bool) int {
 	counts := make([]int, len(list.Prereqs))
 	for i, prereq := range list.Prereqs {
 		switch p := prereq.(type) {
 		case *PrereqList:
-			counts[i] = countPrereqsForList(p, availableSpells, nonSpellsCountAs, useHighestInOr)
+			counts[i] = countPrereqsForList(p, availableSpells, nonSpellsCountAs, useHighestInOr, counting)
 		case *TraitPrereq:
 			if p.Has {
 				switch p.LevelCriteria.Compare {
@@ -64,7 +67,12 @@ func countPrereqsForList(list *PrereqList, availableSpells []*Spell, nonSpellsCo
 				if counts[i] == 1 && p.SubType == spellcmp.Name && p.QualifierCriteria.Compare == criteria.IsText {
 					Traverse(func(s *Spell) bool {
 						if strings.EqualFold(s.NameWithReplacements(), p.QualifierCriteria.Qualifier) {
-							counts[i] = 1 + countPrereqsForList(s.Prereq, availableSpells, nonSpellsCountAs, useHighestInOr)
+							if !counting[s] && s.Prereq != nil {
+								counting[s] = true
+								counts[i] = 1 + countPrereqsForList(s.Prereq, availableSpells, nonSpellsCountAs,
+									useHighestInOr, counting)
+								delete(counting, s)
+							}
 							return true
 						}
 						return false
@@ -0,0 +1,131 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// MageryName is the name of the trait that allows spells to be cast in normal mana. Variants such as "Magery 0" and
+// "Magery (Fire)" are also recognized.
+const MageryName = "Magery"
+
+// IsMagery returns true if this is a Magery trait.
+func (t *Trait) IsMagery() bool {
+	if t.Container() {
+		return false
+	}
+	name := strings.ToLower(t.NameWithReplacements())
+	magery := strings.ToLower(MageryName)
+	return name == magery || strings.HasPrefix(name, magery+" ") || strings.HasPrefix(name, magery+"(")
+}
+
+// HasMagery returns true if the entity has an enabled Magery trait.
+func (e *Entity) HasMagery() bool {
+	has := false
+	Traverse(func(t *Trait) bool {
+		has = t.IsMagery()
+		return has
+	}, true, true, e.Traits...)
+	return has
+}
+
+// MagerySatisfied returns true if the spell's Magery requirement is met. Only applies when the sheet settings require
+// Magery for spells; ritual magic spells never require it.
+func (s *Spell) MagerySatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
+	e := EntityFromNode(s)
+	if e == nil || s.Container() || s.IsRitualMagic() || !e.SheetSettings.SpellsRequireMagery || e.HasMagery() {
+		return true
+	}
+	if tooltip != nil {
+		tooltip.WriteString(prefix)
+		tooltip.WriteString(i18n.Text("Requires Magery"))
+	}
+	return false
+}
+
+// SpellRequirementsMet returns true if the entity meets the prerequisites and Magery requirement of the spell.
+func (e *Entity) SpellRequirementsMet(s *Spell) bool {
+	if s.Container() {
+		return true
+	}
+	if s.Prereq != nil {
+		var eqpPenalty bool
+		if !s.Prereq.Satisfied(e, s, nil, "", &eqpPenalty) {
+			return false
+		}
+	}
+	return s.RitualMagicSatisfied(nil, "") && s.MagerySatisfied(nil, "")
+}
+
+// RemoveSpellsWithUnmetRequirements removes any of the candidate spells whose requirements the entity doesn't meet
+// from the list, including those nested within containers. Returns the resulting list and the names of the spells that
+// were removed.
+func (e *Entity) RemoveSpellsWithUnmetRequirements(list, candidates []*Spell) (result []*Spell, removed []string) {
+	unmet := make(map[*Spell]bool)
+	Traverse(func(s *Spell) bool {
+		if !e.SpellRequirementsMet(s) {
+			unmet[s] = true
+			removed = append(removed, s.String())
+		}
+		return false
+	}, false, true, candidates...)
+	if len(unmet) == 0 {
+		return list, nil
+	}
+	return removeSpells(list, unmet), removed
+}
+
+// RemoveSpellsBlockedInCreation removes any of the candidate spells whose requirements the entity doesn't meet from the
+// list, but only while the entity is in creation mode and its sheet settings block such spells. The entity is
+// recalculated first, so that anything added alongside the candidates is taken into account. Returns the resulting list
+// and the names of the spells that were removed.
+func (e *Entity) RemoveSpellsBlockedInCreation(list, candidates []*Spell) (result []*Spell, removed []string) {
+	if e.Mode != sheetmode.Creation || !e.SheetSettings.BlockUnmetSpellsInCreation {
+		return list, nil
+	}
+	e.Recalculate()
+	return e.RemoveSpellsWithUnmetRequirements(list, candidates)
+}
+
+func removeSpells(list []*Spell, remove map[*Spell]bool) []*Spell {
+	result := make([]*Spell, 0, len(list))
+	for _, one := range list {
+		if remove[one] {
+			continue
+		}
+		if one.Container() {
+			one.Children = removeSpells(one.Children, remove)
+		}
+		result = append(result, one)
+	}
+	return result
+}
+
+// PrereqCount returns the number of spells that must be learned before this one. Ritual magic spells use the count
+// they were given; for other spells, it is derived from their prerequisites, following the chain through the other
+// spells the entity has and taking the shortest path through alternatives.
+func (s *Spell) PrereqCount() int {
+	if s.IsRitualMagic() {
+		return s.RitualPrereqCount
+	}
+	if s.Container() || s.Prereq == nil {
+		return 0
+	}
+	var available []*Spell
+	if e := EntityFromNode(s); e != nil {
+		available = e.Spells
+	}
+	return CountPrereqsForSpell(s, available, 0, false)
+}
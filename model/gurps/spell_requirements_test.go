@@ -0,0 +1,119 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+func newTestSpell(e *Entity, name string, requires ...string) *Spell {
+	s := NewSpell(e, nil, false)
+	s.Name = name
+	if len(requires) != 0 {
+		s.Prereq = NewPrereqList()
+		for _, one := range requires {
+			p := NewSpellPrereq()
+			p.QualifierCriteria.Qualifier = one
+			p.Parent = s.Prereq
+			s.Prereq.Prereqs = append(s.Prereq.Prereqs, p)
+		}
+	}
+	return s
+}
+
+func TestSpellPrereqCount(t *testing.T) {
+	e := NewEntity()
+	light := newTestSpell(e, "Light")
+	continual := newTestSpell(e, "Continual Light", "Light")
+	sunlight := newTestSpell(e, "Sunlight", "Continual Light")
+	e.Spells = []*Spell{light, continual, sunlight}
+	e.Recalculate()
+	check.Equal(t, 0, light.PrereqCount())
+	check.Equal(t, 1, continual.PrereqCount())
+	check.Equal(t, 2, sunlight.PrereqCount(), "the chain is followed through the other spells")
+
+	light.Prereq = NewPrereqList()
+	p := NewSpellPrereq()
+	p.QualifierCriteria.Qualifier = "Sunlight"
+	light.Prereq.Prereqs = append(light.Prereq.Prereqs, p)
+	check.Equal(t, 3, sunlight.PrereqCount(), "a looping chain stops when it comes back around")
+
+	ritual := NewRitualMagicSpell(e, nil, false)
+	ritual.RitualPrereqCount = 4
+	check.Equal(t, 4, ritual.PrereqCount())
+}
+
+func TestSpellsRequireMagery(t *testing.T) {
+	e := NewEntity()
+	light := newTestSpell(e, "Light")
+	e.Spells = []*Spell{light}
+	e.Recalculate()
+	check.True(t, light.MagerySatisfied(nil, ""), "not required unless the setting is enabled")
+
+	e.SheetSettings.SpellsRequireMagery = true
+	e.Recalculate()
+	var tooltip xio.ByteBuffer
+	check.False(t, light.MagerySatisfied(&tooltip, ""))
+	check.Equal(t, "Requires Magery", tooltip.String())
+	check.NotEqual(t, "", light.UnsatisfiedReason)
+	check.True(t, NewRitualMagicSpell(e, nil, false).MagerySatisfied(nil, ""), "ritual magic never requires it")
+
+	magery := NewTrait(e, nil, false)
+	magery.Name = "Magery 0"
+	e.Traits = []*Trait{magery}
+	e.Recalculate()
+	check.True(t, light.MagerySatisfied(nil, ""))
+	check.Equal(t, "", light.UnsatisfiedReason)
+}
+
+func TestRemoveSpellsWithUnmetRequirements(t *testing.T) {
+	e := NewEntity()
+	light := newTestSpell(e, "Light")
+	continual := newTestSpell(e, "Continual Light", "Light")
+	sunlight := newTestSpell(e, "Sunlight", "Continual Light")
+	e.Spells = []*Spell{light, sunlight}
+	e.Recalculate()
+
+	list, removed := e.RemoveSpellsWithUnmetRequirements(e.Spells, []*Spell{sunlight})
+	check.Equal(t, []*Spell{light}, list)
+	check.Equal(t, []string{"Sunlight"}, removed)
+
+	e.Spells = []*Spell{light, continual}
+	list, removed = e.RemoveSpellsWithUnmetRequirements(e.Spells, []*Spell{continual})
+	check.Equal(t, []*Spell{light, continual}, list)
+	check.Equal(t, 0, len(removed))
+}
+
+func TestRemoveSpellsBlockedInCreation(t *testing.T) {
+	e := NewEntity()
+	light := newTestSpell(e, "Light")
+	sunlight := newTestSpell(e, "Sunlight", "Continual Light")
+	e.Spells = []*Spell{light, sunlight}
+	e.Mode = sheetmode.Creation
+	e.SheetSettings.BlockUnmetSpellsInCreation = false
+	list, removed := e.RemoveSpellsBlockedInCreation(e.Spells, []*Spell{sunlight})
+	check.Equal(t, []*Spell{light, sunlight}, list, "not blocked unless the sheet settings ask for it")
+	check.Equal(t, 0, len(removed))
+
+	e.SheetSettings.BlockUnmetSpellsInCreation = true
+	e.Mode = sheetmode.Play
+	list, removed = e.RemoveSpellsBlockedInCreation(e.Spells, []*Spell{sunlight})
+	check.Equal(t, []*Spell{light, sunlight}, list, "not blocked in play mode")
+	check.Equal(t, 0, len(removed))
+
+	e.Mode = sheetmode.Creation
+	list, removed = e.RemoveSpellsBlockedInCreation(e.Spells, []*Spell{sunlight})
+	check.Equal(t, []*Spell{light}, list)
+	check.Equal(t, []string{"Sunlight"}, removed)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// StatBlockExt is the extension used for stat block exports.
+const StatBlockExt = ".txt"
+
+// ExportStatBlock writes the entity to filePath as a plain-text stat block.
+func ExportStatBlock(e *Entity, filePath string) error {
+	if err := os.WriteFile(filePath, []byte(NewStatBlock(e)), 0o640); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// NewStatBlock returns a compact, plain-text stat block for the entity, in the style used for characters in published
+// GURPS adventures: the attributes and secondary characteristics in three columns, followed by the defenses, one line
+// per weapon and then the traits, skills, spells and equipped gear run together inline.
+func NewStatBlock(e *Entity) string {
+	e.Recalculate()
+	enc := e.EncumbranceLevel(false)
+	var buffer strings.Builder
+	if e.Profile.Name != "" {
+		buffer.WriteString(e.Profile.Name)
+		buffer.WriteByte('\n')
+	}
+	speed := strconv.FormatFloat(fxp.As[float64](e.Attributes.Current(BasicSpeedID)), 'f', 2, 64)
+	rows := [][]string{
+		{statBlockEntry("ST", e.Attributes.Current(StrengthID)), statBlockEntry("HP", e.Attributes.Maximum(HitPointsID)), "Speed " + speed},
+		{statBlockEntry("DX", e.Attributes.Current(DexterityID)), statBlockEntry("Will", e.Attributes.Current(WillID)), fmt.Sprintf("Move %d", e.Move(enc))},
+		{statBlockEntry("IQ", e.Attributes.Current(IntelligenceID)), statBlockEntry("Per", e.Attributes.Current(PerceptionID))},
+		{statBlockEntry("HT", e.Attributes.Current(HealthID)), statBlockEntry("FP", e.Attributes.Maximum(FatiguePointsID)), fmt.Sprintf("SM %+d", e.Profile.AdjustedSizeModifier())},
+	}
+	widths := make([]int, 2)
+	for _, row := range rows {
+		for i := range widths {
+			widths[i] = max(widths[i], len(row[i])+2)
+		}
+	}
+	for _, row := range rows {
+		for i, col := range row {
+			if i < len(widths) && i < len(row)-1 {
+				fmt.Fprintf(&buffer, "%-*s", widths[i], col+";")
+			} else {
+				buffer.WriteString(col + ".")
+			}
+		}
+		buffer.WriteByte('\n')
+	}
+	buffer.WriteByte('\n')
+
+	summary := NewGMSummary(e)
+	defenses := []string{fmt.Sprintf("Dodge %d", e.Dodge(enc))}
+	if summary.Parry != "–" {
+		defenses = append(defenses, "Parry "+summary.Parry)
+	}
+	if summary.Block != "–" {
+		defenses = append(defenses, "Block "+summary.Block)
+	}
+	defenses = append(defenses, "DR "+summary.DR)
+	buffer.WriteString(strings.Join(defenses, "; "))
+	buffer.WriteString(".\n")
+	for _, w := range e.EquippedWeapons(true) {
+		writeStatBlockWeapon(&buffer, w, "Reach", w.Reach.Resolve(w, nil).String())
+	}
+	for _, w := range e.EquippedWeapons(false) {
+		writeStatBlockWeapon(&buffer, w, "Acc", w.Accuracy.Resolve(w, nil).String(), "Range",
+			w.Range.Resolve(w, nil).String(true))
+	}
+
+	var traits, skills, spells, gear []string
+	Traverse(func(t *Trait) bool {
+		name := t.String()
+		if t.CR != selfctrl.NoCR {
+			name += " (" + strconv.Itoa(int(t.CR)) + ")"
+		}
+		traits = append(traits, name)
+		return false
+	}, true, true, e.Traits...)
+	Traverse(func(s *Skill) bool {
+		if level := s.CalculateLevel(nil).Level; level != fxp.Min {
+			skills = append(skills, s.String()+"-"+level.Trunc().String())
+		}
+		return false
+	}, true, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		if level := s.CalculateLevel().Level; level != fxp.Min {
+			spells = append(spells, s.String()+"-"+level.Trunc().String())
+		}
+		return false
+	}, true, true, e.Spells...)
+	Traverse(func(eqp *Equipment) bool {
+		if eqp.Equipped {
+			name := eqp.String()
+			if eqp.Quantity != fxp.One {
+				name += " ×" + eqp.Quantity.String()
+			}
+			gear = append(gear, name)
+		}
+		return false
+	}, false, true, e.CarriedEquipment...)
+	writeStatBlockList(&buffer, i18n.Text("Traits"), traits)
+	writeStatBlockList(&buffer, i18n.Text("Skills"), skills)
+	writeStatBlockList(&buffer, i18n.Text("Spells"), spells)
+	writeStatBlockList(&buffer, i18n.Text("Equipment"), gear)
+	return buffer.String()
+}
+
+func statBlockEntry(name string, value fxp.Int) string {
+	return name + " " + attributeText(value)
+}
+
+// writeStatBlockWeapon writes a line for the weapon. labelsAndValues holds pairs of labels and values for the
+// additional statistics to include; those with an empty value are omitted.
+func writeStatBlockWeapon(buffer *strings.Builder, w *Weapon, labelsAndValues ...string) {
+	fmt.Fprintf(buffer, "%s (%s): %s", w.String(), w.SkillLevel(nil).Trunc().String(), w.Damage.ResolvedDamage(nil))
+	for i := 0; i+1 < len(labelsAndValues); i += 2 {
+		if labelsAndValues[i+1] != "" {
+			fmt.Fprintf(buffer, ", %s %s", labelsAndValues[i], labelsAndValues[i+1])
+		}
+	}
+	buffer.WriteString(".\n")
+}
+
+func writeStatBlockList(buffer *strings.Builder, title string, list []string) {
+	if len(list) == 0 {
+		return
+	}
+	buffer.WriteByte('\n')
+	buffer.WriteString(title)
+	buffer.WriteString(": ")
+	buffer.WriteString(strings.Join(list, "; "))
+	buffer.WriteString(".\n")
+}
@@ -0,0 +1,434 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+type statBlockSection byte
+
+const (
+	statBlockNone statBlockSection = iota
+	statBlockTraits
+	statBlockLanguages
+	statBlockSkills
+	statBlockSpells
+	statBlockEquipment
+	statBlockNotes
+)
+
+// statBlockLabels maps the lowercased labels that introduce a list in a stat block to the section they start.
+var statBlockLabels = map[string]statBlockSection{
+	"advantages":               statBlockTraits,
+	"advantages/disadvantages": statBlockTraits,
+	"disadvantages":            statBlockTraits,
+	"features":                 statBlockTraits,
+	"perks":                    statBlockTraits,
+	"quirks":                   statBlockTraits,
+	"traits":                   statBlockTraits,
+	"languages":                statBlockLanguages,
+	"skills":                   statBlockSkills,
+	"techniques":               statBlockSkills,
+	"spells":                   statBlockSpells,
+	"equipment":                statBlockEquipment,
+	"gear":                     statBlockEquipment,
+	"possessions":              statBlockEquipment,
+	"notes":                    statBlockNotes,
+}
+
+// statBlockPrimaryAttributes holds the stat block abbreviations for the attributes the others are derived from.
+var statBlockPrimaryAttributes = map[string]string{
+	"st": StrengthID,
+	"dx": DexterityID,
+	"iq": IntelligenceID,
+	"ht": HealthID,
+}
+
+// statBlockSecondaryAttributes holds the stat block abbreviations for the attributes that are derived from the primary
+// ones.
+var statBlockSecondaryAttributes = map[string]string{
+	"hp":          HitPointsID,
+	"fp":          FatiguePointsID,
+	"will":        WillID,
+	"per":         PerceptionID,
+	"speed":       BasicSpeedID,
+	"basic speed": BasicSpeedID,
+	"move":        BasicMoveID,
+	"basic move":  BasicMoveID,
+}
+
+var (
+	statBlockAttributeRegex = regexp.MustCompile(`(?i)\b(ST|DX|IQ|HT|HP|FP|Will|Per|Basic Speed|Speed|Basic Move|Move|SM)\s*:?\s*([+-]?\d+(?:\.\d+)?)`)
+	statBlockLabelRegex     = regexp.MustCompile(`^([A-Za-z][A-Za-z/ ]*):\s*(.*)$`)
+	statBlockPointsRegex    = regexp.MustCompile(`\s*\[([+-]?\d+(?:\.\d+)?)\]\s*$`)
+	statBlockLevelRegex     = regexp.MustCompile(`^(.*\S)\s*-\s*(\d+)$`)
+	statBlockCRRegex        = regexp.MustCompile(`^(.*\S)\s*\((6|9|12|15)\)$`)
+	statBlockTraitLevel     = regexp.MustCompile(`^(.*\D)\s+(\d+)$`)
+	statBlockQuantityRegex  = regexp.MustCompile(`^(.*\S)\s+\(?[×x]\s*(\d+)\)?$`)
+	statBlockTotalRegex     = regexp.MustCompile(`(?i)\s*\(\s*(\d+)\s*(?:points|pts)\.?\s*\)\s*$`)
+	statBlockLeftoverRegex  = regexp.MustCompile(`^[\s;,.]*$`)
+)
+
+type statBlockParser struct {
+	entity   *Entity
+	unparsed []string
+	primary  map[string]fxp.Int
+	derived  map[string]fxp.Int
+	levels   map[any]fxp.Int
+}
+
+// ParseStatBlock creates a new Entity from a plain-text stat block in the standard published format, such as those
+// found in GURPS adventures or produced by NewStatBlock. The result is a best effort: the name, attributes, traits,
+// languages, skills, spells, equipment and notes are picked out, while anything that couldn't be understood, such as
+// defenses and weapon lines, is kept in a note container so that nothing pasted is lost. Skills and spells are given
+// the points needed to reach the listed level with their default attribute and difficulty; when that isn't possible
+// the listed level is added to their notes.
+func ParseStatBlock(text string) *Entity {
+	p := &statBlockParser{
+		entity:  NewEntity(),
+		primary: make(map[string]fxp.Int),
+		derived: make(map[string]fxp.Int),
+		levels:  make(map[any]fxp.Int),
+	}
+	p.entity.Traits = nil
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "–", "-", "—", "-", "−", "-", "’", "'").Replace(text)
+	section := statBlockNone
+	var body strings.Builder
+	flush := func() {
+		p.parseSection(section, body.String())
+		body.Reset()
+	}
+	first := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flush()
+			section = statBlockNone
+			continue
+		}
+		if first {
+			first = false
+			if !strings.Contains(line, ":") && !statBlockAttributeRegex.MatchString(line) {
+				p.parseName(line)
+				continue
+			}
+		}
+		if parts := statBlockLabelRegex.FindStringSubmatch(line); parts != nil {
+			if next, ok := statBlockLabels[strings.ToLower(strings.TrimSpace(parts[1]))]; ok {
+				flush()
+				section = next
+				line = parts[2]
+			}
+		}
+		if section == statBlockNone {
+			p.parseHeaderLine(line)
+			continue
+		}
+		if body.Len() != 0 {
+			body.WriteByte(' ')
+		}
+		body.WriteString(line)
+	}
+	flush()
+	p.applyAttributes()
+	p.entity.Recalculate()
+	p.fitLevels()
+	if len(p.unparsed) != 0 {
+		container := NewNote(p.entity, nil, true)
+		container.Text = i18n.Text("Not recognized in the stat block")
+		for _, one := range p.unparsed {
+			n := NewNote(p.entity, container, false)
+			n.Text = one
+			container.Children = append(container.Children, n)
+		}
+		p.entity.Notes = append(p.entity.Notes, container)
+	}
+	p.entity.Recalculate()
+	return p.entity
+}
+
+func (p *statBlockParser) parseName(line string) {
+	if parts := statBlockTotalRegex.FindStringSubmatchIndex(line); parts != nil {
+		if total, err := fxp.FromString(line[parts[2]:parts[3]]); err == nil {
+			p.entity.TotalPoints = total
+			p.entity.PointsRecord = []*PointsRecord{{
+				When:   jio.Now(),
+				Points: total,
+				Reason: i18n.Text("Imported from a stat block"),
+			}}
+		}
+		line = line[:parts[0]]
+	}
+	p.entity.Profile.Name = strings.TrimSpace(line)
+}
+
+// parseHeaderLine picks the attributes out of a line that isn't part of a list. Whatever remains is flagged as
+// unparsed.
+func (p *statBlockParser) parseHeaderLine(line string) {
+	leftover := statBlockAttributeRegex.ReplaceAllStringFunc(line, func(match string) string {
+		parts := statBlockAttributeRegex.FindStringSubmatch(match)
+		key := strings.ToLower(parts[1])
+		value, err := fxp.FromString(parts[2])
+		if err != nil {
+			return match
+		}
+		switch {
+		case key == "sm":
+			p.entity.Profile.SizeModifier = fxp.As[int](value)
+		case statBlockPrimaryAttributes[key] != "":
+			p.primary[statBlockPrimaryAttributes[key]] = value
+		default:
+			p.derived[statBlockSecondaryAttributes[key]] = value
+		}
+		return ""
+	})
+	if !statBlockLeftoverRegex.MatchString(leftover) {
+		if leftover == line {
+			p.unparsed = append(p.unparsed, line)
+		} else {
+			p.unparsed = append(p.unparsed, strings.Trim(leftover, " ;,."))
+		}
+	}
+}
+
+// applyAttributes sets the attributes found in the stat block. The primary attributes are set first, since the others
+// are derived from them.
+func (p *statBlockParser) applyAttributes() {
+	p.entity.Recalculate()
+	for _, values := range []map[string]fxp.Int{p.primary, p.derived} {
+		for id, value := range values {
+			if attr, ok := p.entity.Attributes.Set[id]; ok && value > 0 {
+				attr.SetMaximum(value)
+			}
+		}
+		p.entity.Recalculate()
+	}
+}
+
+func (p *statBlockParser) parseSection(section statBlockSection, text string) {
+	if section == statBlockNone || strings.TrimSpace(text) == "" {
+		return
+	}
+	if section == statBlockNotes {
+		n := NewNote(p.entity, nil, false)
+		n.Text = strings.TrimSpace(text)
+		p.entity.Notes = append(p.entity.Notes, n)
+		return
+	}
+	for _, item := range splitStatBlockList(text) {
+		switch section {
+		case statBlockTraits:
+			p.entity.Traits = append(p.entity.Traits, p.parseTrait(item))
+		case statBlockLanguages:
+			t := p.parseTrait(item)
+			t.Tags = append(t.Tags, "Language")
+			p.entity.Traits = append(p.entity.Traits, t)
+		case statBlockSkills:
+			p.entity.Skills = append(p.entity.Skills, p.parseSkill(item))
+		case statBlockSpells:
+			p.entity.Spells = append(p.entity.Spells, p.parseSpell(item))
+		case statBlockEquipment:
+			p.entity.CarriedEquipment = append(p.entity.CarriedEquipment, p.parseEquipment(item))
+		default:
+		}
+	}
+}
+
+// splitStatBlockList splits the text at the semicolons that aren't within parentheses or brackets. If there are no
+// such semicolons, commas are used instead.
+func splitStatBlockList(text string) []string {
+	text = strings.TrimSuffix(strings.TrimSpace(text), ".")
+	list := splitStatBlockListOn(text, ';')
+	if len(list) < 2 {
+		list = splitStatBlockListOn(text, ',')
+	}
+	return list
+}
+
+func splitStatBlockListOn(text string, separator rune) []string {
+	var list []string
+	depth := 0
+	start := 0
+	for i, ch := range text {
+		switch ch {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth = max(depth-1, 0)
+		case separator:
+			if depth == 0 {
+				list = appendStatBlockItem(list, text[start:i])
+				start = i + 1
+			}
+		default:
+		}
+	}
+	return appendStatBlockItem(list, text[start:])
+}
+
+func appendStatBlockItem(list []string, item string) []string {
+	if item = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(item), ".")); item != "" {
+		list = append(list, item)
+	}
+	return list
+}
+
+// extractStatBlockPoints removes a trailing point cost in square brackets from the item.
+func extractStatBlockPoints(item string) (remainder string, points fxp.Int, found bool) {
+	if parts := statBlockPointsRegex.FindStringSubmatchIndex(item); parts != nil {
+		if value, err := fxp.FromString(item[parts[2]:parts[3]]); err == nil {
+			return item[:parts[0]], value, true
+		}
+	}
+	return item, 0, false
+}
+
+func (p *statBlockParser) parseTrait(item string) *Trait {
+	t := NewTrait(p.entity, nil, false)
+	item, points, hasPoints := extractStatBlockPoints(item)
+	if parts := statBlockCRRegex.FindStringSubmatch(item); parts != nil {
+		cr, _ := strconv.Atoi(parts[2]) //nolint:errcheck // The regex only matches digits
+		t.CR = selfctrl.Roll(cr)
+		item = parts[1]
+	}
+	if parts := statBlockTraitLevel.FindStringSubmatch(item); parts != nil {
+		if levels, err := fxp.FromString(parts[2]); err == nil && levels > 0 {
+			t.CanLevel = true
+			t.Levels = levels
+			if hasPoints {
+				t.PointsPerLevel = points.Div(levels)
+				hasPoints = false
+			}
+			item = parts[1]
+		}
+	}
+	if hasPoints {
+		t.BasePoints = points
+	}
+	t.Name, t.LocalNotes = splitStatBlockName(item)
+	return t
+}
+
+// splitStatBlockName splits a trailing parenthetical from the name, returning it separately as notes. Only used for
+// traits, where the parenthetical usually describes the particular version taken.
+func splitStatBlockName(item string) (name, notes string) {
+	item = strings.TrimSpace(item)
+	if strings.HasSuffix(item, ")") {
+		if i := strings.Index(item, " ("); i != -1 {
+			return item[:i], item[i+2 : len(item)-1]
+		}
+	}
+	return item, ""
+}
+
+func (p *statBlockParser) parseSkill(item string) *Skill {
+	s := NewSkill(p.entity, nil, false)
+	item, points, hasPoints := extractStatBlockPoints(item)
+	if parts := statBlockLevelRegex.FindStringSubmatch(item); parts != nil {
+		if level, err := fxp.FromString(parts[2]); err == nil {
+			item = parts[1]
+			if !hasPoints {
+				p.levels[s] = level
+			}
+		}
+	}
+	if hasPoints {
+		s.Points = points
+	}
+	s.Name, s.TechLevel, s.Specialization = splitFoundrySkillName(item)
+	return s
+}
+
+func (p *statBlockParser) parseSpell(item string) *Spell {
+	s := NewSpell(p.entity, nil, false)
+	item, points, hasPoints := extractStatBlockPoints(item)
+	if parts := statBlockLevelRegex.FindStringSubmatch(item); parts != nil {
+		if level, err := fxp.FromString(parts[2]); err == nil {
+			item = parts[1]
+			if !hasPoints {
+				p.levels[s] = level
+			}
+		}
+	}
+	if hasPoints {
+		s.Points = points
+	}
+	s.Name, s.TechLevel, _ = splitFoundrySkillName(item)
+	return s
+}
+
+func (p *statBlockParser) parseEquipment(item string) *Equipment {
+	eqp := NewEquipment(p.entity, nil, false)
+	if parts := statBlockQuantityRegex.FindStringSubmatch(item); parts != nil {
+		if quantity, err := fxp.FromString(parts[2]); err == nil && quantity > 0 {
+			eqp.Quantity = quantity
+			item = parts[1]
+		}
+	}
+	eqp.Name = strings.TrimSpace(item)
+	return eqp
+}
+
+// fitLevels gives each skill and spell that was listed with a level, but not a point cost, the points needed to reach
+// that level.
+func (p *statBlockParser) fitLevels() {
+	for _, s := range p.entity.Skills {
+		if target, ok := p.levels[s]; ok {
+			points, exact := fitStatBlockPoints(target, func(points fxp.Int) fxp.Int {
+				s.Points = points
+				return s.CalculateLevel(nil).Level
+			})
+			s.Points = points
+			if !exact {
+				s.LocalNotes = statBlockLevelNote(target)
+			}
+		}
+	}
+	for _, s := range p.entity.Spells {
+		if target, ok := p.levels[s]; ok {
+			points, exact := fitStatBlockPoints(target, func(points fxp.Int) fxp.Int {
+				s.Points = points
+				return s.CalculateLevel().Level
+			})
+			s.Points = points
+			if !exact {
+				s.LocalNotes = statBlockLevelNote(target)
+			}
+		}
+	}
+}
+
+// fitStatBlockPoints returns the fewest points, following the usual 1, 2, 4, 8, 12… progression, that reach the target
+// level, along with whether the level reached matched the target exactly. If the target can't be reached, a single
+// point is used.
+func fitStatBlockPoints(target fxp.Int, levelFor func(points fxp.Int) fxp.Int) (points fxp.Int, exact bool) {
+	candidates := []fxp.Int{fxp.One, fxp.Two, fxp.Four}
+	for pts := 8; pts <= 80; pts += 4 {
+		candidates = append(candidates, fxp.From(pts))
+	}
+	for _, candidate := range candidates {
+		if level := levelFor(candidate); level >= target {
+			return candidate, level == target
+		}
+	}
+	return fxp.One, false
+}
+
+func statBlockLevelNote(level fxp.Int) string {
+	return i18n.Text("Listed in the stat block at level ") + level.String()
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+const testStatBlock = `Orc Raider (75 points)
+ST 13; HP 13; Speed 6.00.
+DX 12; Will 10; Move 6.
+IQ 9; Per 10.
+HT 12; FP 12; SM +0.
+Dodge 9; Parry 10 (Broadsword); DR 2 (leather).
+Broadsword (14): 2d+1 cut.
+Advantages: Acute Hearing 2 [4]; Night Vision 3 [3];
+Wealth (Comfortable) [10].
+Disadvantages: Bad Temper (12) [-10]; Bloodlust (9) [-10].
+Languages: Orcish.
+Skills: Brawling–14; Broadsword-14; Stealth/TL3-11 [1]; Knot-Tying-40.
+Equipment: Broadsword; Torch ×3.
+`
+
+func TestParseStatBlock(t *testing.T) {
+	e := ParseStatBlock(testStatBlock)
+	check.Equal(t, "Orc Raider", e.Profile.Name)
+	check.Equal(t, fxp.From(75), e.TotalPoints)
+	check.Equal(t, fxp.From(13), e.Attributes.Current(StrengthID))
+	check.Equal(t, fxp.From(12), e.Attributes.Current("ht"))
+	check.Equal(t, fxp.From(13), e.Attributes.Maximum(HitPointsID))
+
+	check.Equal(t, 6, len(e.Traits))
+	check.Equal(t, "Acute Hearing", e.Traits[0].Name)
+	check.Equal(t, fxp.Two, e.Traits[0].Levels)
+	check.Equal(t, fxp.From(4), e.Traits[0].AdjustedPoints())
+	check.Equal(t, "Wealth", e.Traits[2].Name)
+	check.Equal(t, "Comfortable", e.Traits[2].LocalNotes)
+	check.Equal(t, selfctrl.CR12, e.Traits[3].CR)
+	check.Equal(t, fxp.From(-10), e.Traits[3].BasePoints)
+	check.Equal(t, "Orcish", e.Traits[5].Name)
+	check.True(t, HasTag("Language", e.Traits[5].Tags))
+
+	check.Equal(t, 4, len(e.Skills))
+	check.Equal(t, "Brawling", e.Skills[0].Name)
+	check.Equal(t, fxp.From(8), e.Skills[0].Points, "points fitted to reach the listed level")
+	check.Equal(t, fxp.From(14), e.Skills[0].CalculateLevel(nil).Level)
+	check.Equal(t, "", e.Skills[0].LocalNotes)
+	check.Equal(t, "Stealth", e.Skills[2].Name)
+	check.NotNil(t, e.Skills[2].TechLevel)
+	check.Equal(t, "3", *e.Skills[2].TechLevel)
+	check.Equal(t, fxp.One, e.Skills[2].Points)
+	check.Equal(t, "Knot-Tying", e.Skills[3].Name)
+	check.Contains(t, e.Skills[3].LocalNotes, "40", "unreachable levels are kept in the notes")
+
+	check.Equal(t, 2, len(e.CarriedEquipment))
+	check.Equal(t, "Torch", e.CarriedEquipment[1].Name)
+	check.Equal(t, fxp.From(3), e.CarriedEquipment[1].Quantity)
+
+	check.Equal(t, 1, len(e.Notes))
+	unparsed := e.Notes[0]
+	check.True(t, unparsed.Container())
+	check.Equal(t, 2, len(unparsed.Children))
+	check.True(t, strings.HasPrefix(unparsed.Children[0].Text, "Dodge 9"))
+	check.True(t, strings.HasPrefix(unparsed.Children[1].Text, "Broadsword (14)"))
+}
+
+func TestParseStatBlockRoundTrip(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Aria"
+	e.Attributes.Set[DexterityID].SetMaximum(fxp.From(13))
+	temper := NewTrait(e, nil, false)
+	temper.Name = "Bad Temper"
+	temper.CR = selfctrl.CR12
+	e.Traits = []*Trait{temper}
+	e.Recalculate()
+
+	parsed := ParseStatBlock(NewStatBlock(e))
+	check.Equal(t, "Aria", parsed.Profile.Name)
+	check.Equal(t, fxp.From(13), parsed.Attributes.Current(DexterityID))
+	check.Equal(t, 1, len(parsed.Traits))
+	check.Equal(t, "Bad Temper", parsed.Traits[0].Name)
+	check.Equal(t, selfctrl.CR12, parsed.Traits[0].CR)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/selfctrl"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestStatBlock(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Name = "Aria"
+	fit := NewTrait(e, nil, false)
+	fit.Name = "Fit"
+	temper := NewTrait(e, nil, false)
+	temper.Name = "Bad Temper"
+	temper.CR = selfctrl.CR12
+	e.Traits = []*Trait{fit, temper}
+
+	block := NewStatBlock(e)
+	lines := strings.Split(block, "\n")
+	check.Equal(t, "Aria", lines[0])
+	check.True(t, strings.HasPrefix(lines[1], "ST 10;"))
+	check.True(t, strings.HasSuffix(lines[1], "Speed 5.00."))
+	check.True(t, strings.HasPrefix(lines[3], "IQ 10;"))
+	check.True(t, strings.HasSuffix(lines[3], "Per 10."))
+	check.Contains(t, block, "Traits: Fit; Bad Temper (12).")
+	check.NotContains(t, block, "Skills:", "no skills means no skills line")
+}
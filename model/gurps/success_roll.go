@@ -0,0 +1,74 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// SuccessRoll holds the outcome of a 3d6 roll made against a target number.
+type SuccessRoll struct {
+	Target   int
+	Roll     int
+	Success  bool
+	Critical bool
+}
+
+// RollAgainst makes a 3d6 success roll against the target number.
+func RollAgainst(target int) SuccessRoll {
+	return NewSuccessRoll(target, dice.New("3d").Roll(false))
+}
+
+// NewSuccessRoll determines the outcome of a success roll, using the rules for critical success and failure.
+func NewSuccessRoll(target, roll int) SuccessRoll {
+	r := SuccessRoll{
+		Target: target,
+		Roll:   roll,
+	}
+	switch {
+	case roll <= 4:
+		r.Success = true
+		r.Critical = true
+	case roll == 18:
+		r.Critical = true
+	case roll == 17:
+		r.Critical = target <= 15
+	case roll >= target+10:
+		r.Critical = true
+	default:
+		r.Success = roll <= target
+		r.Critical = r.Success && ((roll == 5 && target >= 15) || (roll == 6 && target >= 16))
+	}
+	return r
+}
+
+// Margin returns the margin of success (positive) or failure (negative).
+func (r SuccessRoll) Margin() int {
+	return r.Target - r.Roll
+}
+
+// String implements fmt.Stringer.
+func (r SuccessRoll) String() string {
+	var format string
+	switch {
+	case r.Success && r.Critical:
+		format = i18n.Text("Rolled %d vs %d: critical success (margin %d)")
+	case r.Success:
+		format = i18n.Text("Rolled %d vs %d: success (margin %d)")
+	case r.Critical:
+		format = i18n.Text("Rolled %d vs %d: critical failure (margin %d)")
+	default:
+		format = i18n.Text("Rolled %d vs %d: failure (margin %d)")
+	}
+	return fmt.Sprintf(format, r.Roll, r.Target, r.Margin())
+}
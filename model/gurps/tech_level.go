@@ -108,3 +108,14 @@ func AdjustTechLevel(str string, delta fxp.Int) (s string, changed bool) {
 	}
 	return ReplaceTechLevel(str, newTL), true
 }
+
+// StartingWealthForTechLevel returns the standard starting wealth for a character at the tech level found in the
+// string (as found by a call to ExtractTechLevel). If no tech level can be found, TL3 is assumed.
+func StartingWealthForTechLevel(str string) fxp.Int {
+	wealth := []int{250, 500, 750, 1000, 2000, 5000, 10000, 15000, 20000, 30000, 50000, 75000, 100000}
+	tl := 3
+	if level, start, _ := ExtractTechLevel(str); start != -1 {
+		tl = fxp.As[int](level.Trunc())
+	}
+	return fxp.From(wealth[tl])
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestStartingWealthForTechLevel(t *testing.T) {
+	check.Equal(t, fxp.From(250), StartingWealthForTechLevel("0"))
+	check.Equal(t, fxp.From(1000), StartingWealthForTechLevel(""))
+	check.Equal(t, fxp.From(20000), StartingWealthForTechLevel("TL8"))
+	check.Equal(t, fxp.From(5000), StartingWealthForTechLevel("5.5"))
+	check.Equal(t, fxp.From(100000), StartingWealthForTechLevel("99"))
+}
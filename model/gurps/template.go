@@ -37,6 +37,7 @@ type Template struct {
 type TemplateData struct {
 	Version   int          `json:"version"`
 	ID        tid.TID      `json:"id"`
+	Revision  int          `json:"revision,omitempty"`
 	Traits    []*Trait     `json:"traits,alt=advantages,omitempty"`
 	Skills    []*Skill     `json:"skills,omitempty"`
 	Spells    []*Spell     `json:"spells,omitempty"`
@@ -223,3 +224,34 @@ func (t *Template) SyncWithLibrarySources() {
 		return false
 	}, false, false, t.Notes...)
 }
+
+// ApplyTo appends a copy of the template's content to the entity, without any of the interactive choices the user
+// interface offers. If the template has an ancestry, any ancestry the entity already had is disabled. Pickers and
+// nameable keys are left unresolved. The application is recorded on the entity so that later revisions of the template
+// can be detected.
+func (t *Template) ApplyTo(e *Entity, from LibraryFile) {
+	if len(ActiveAncestries(t.Traits)) != 0 {
+		for _, one := range ActiveAncestryTraits(e.Traits) {
+			one.Disabled = true
+		}
+	}
+	for _, one := range t.Traits {
+		e.Traits = append(e.Traits, one.Clone(from, e, nil, false))
+	}
+	for _, one := range t.Skills {
+		e.Skills = append(e.Skills, one.Clone(from, e, nil, false))
+	}
+	for _, one := range t.Spells {
+		e.Spells = append(e.Spells, one.Clone(from, e, nil, false))
+	}
+	for _, one := range t.Equipment {
+		e.CarriedEquipment = append(e.CarriedEquipment, one.Clone(from, e, nil, false))
+	}
+	for _, one := range t.Notes {
+		e.Notes = append(e.Notes, one.Clone(from, e, nil, false))
+	}
+	if from.Path != "" {
+		e.RecordAppliedTemplate(&AppliedTemplate{LibraryFile: from, ID: t.ID, Revision: t.Revision, When: jio.Now()})
+	}
+	e.Recalculate()
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestTemplateApplyTo(t *testing.T) {
+	tmpl := NewTemplate()
+	sk := NewSkill(tmpl, nil, false)
+	sk.Name = "Stealth"
+	tmpl.Skills = []*Skill{sk}
+	note := NewNote(tmpl, nil, false)
+	note.Text = "From the template"
+	tmpl.Notes = []*Note{note}
+
+	e := NewEntity()
+	from := LibraryFile{Library: "test/lib", Path: "Thief.gct"}
+	tmpl.ApplyTo(e, from)
+	check.Equal(t, 1, len(e.Skills))
+	check.Equal(t, "Stealth", e.Skills[0].Name)
+	check.Equal(t, e, EntityFromNode(e.Skills[0]))
+	check.NotEqual(t, sk.TID, e.Skills[0].TID)
+	check.Equal(t, 1, len(e.Notes))
+
+	tmpl.ApplyTo(e, from)
+	check.Equal(t, 2, len(e.Skills))
+	check.Equal(t, 1, len(tmpl.Skills))
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// AppliedTemplate records a template that was applied to a sheet. When the template lives within a library, the
+// library and the path relative to it are recorded, otherwise Library is empty and Path holds the full path on disk.
+type AppliedTemplate struct {
+	LibraryFile
+	ID       tid.TID  `json:"id"`
+	Revision int      `json:"revision,omitempty"`
+	When     jio.Time `json:"when"`
+}
+
+// TemplateUpdate describes an applied template that has a newer revision available.
+type TemplateUpdate struct {
+	Applied  *AppliedTemplate
+	Template *Template
+}
+
+// TemplateDiff holds the names of the top-level rows a template would add to a sheet, or change on it, if re-applied.
+type TemplateDiff struct {
+	Added   []string
+	Changed []string
+}
+
+// NewAppliedTemplate creates a record of the template loaded from filePath being applied now.
+func NewAppliedTemplate(filePath string, t *Template) *AppliedTemplate {
+	libFile, ok := LibraryFileForPath(filePath)
+	if !ok {
+		libFile = LibraryFile{Path: filePath}
+	}
+	return &AppliedTemplate{
+		LibraryFile: libFile,
+		ID:          t.ID,
+		Revision:    t.Revision,
+		When:        jio.Now(),
+	}
+}
+
+// Title returns the name of the template file, without extension.
+func (a *AppliedTemplate) Title() string {
+	return fs.BaseName(a.Path)
+}
+
+// PathOnDisk returns the path to the template file, or an empty string if the library it was in is no longer
+// configured.
+func (a *AppliedTemplate) PathOnDisk() string {
+	if a.Library == "" {
+		return a.Path
+	}
+	if lib, ok := GlobalSettings().Libraries()[a.Library]; ok {
+		return filepath.Join(lib.PathOnDisk, a.Path)
+	}
+	return ""
+}
+
+// Load the template this record refers to.
+func (a *AppliedTemplate) Load() (*Template, error) {
+	p := a.PathOnDisk()
+	if p == "" {
+		return nil, errs.Newf(i18n.Text("library %s is not available"), a.Library)
+	}
+	return NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+}
+
+// RecordAppliedTemplate notes that the template was applied, replacing any earlier record for the same template.
+func (e *Entity) RecordAppliedTemplate(rec *AppliedTemplate) {
+	if i := slices.IndexFunc(e.AppliedTemplates, func(one *AppliedTemplate) bool {
+		return one.ID == rec.ID || one.LibraryFile == rec.LibraryFile
+	}); i != -1 {
+		e.AppliedTemplates[i] = rec
+		return
+	}
+	e.AppliedTemplates = append(e.AppliedTemplates, rec)
+}
+
+// TemplateUpdates returns the applied templates that now have a newer revision than the one that was applied. A
+// description of each template that could not be checked is returned in problems.
+func (e *Entity) TemplateUpdates() (updates []*TemplateUpdate, problems []string) {
+	for _, one := range e.AppliedTemplates {
+		t, err := one.Load()
+		if err != nil {
+			problems = append(problems, one.Title()+": "+err.Error())
+			continue
+		}
+		if t.Revision > one.Revision {
+			updates = append(updates, &TemplateUpdate{Applied: one, Template: t})
+		}
+	}
+	return updates, problems
+}
+
+// DiffTemplate returns the top-level rows the template would add or change if re-applied to this entity.
+func (e *Entity) DiffTemplate(t *Template) TemplateDiff {
+	var diff TemplateDiff
+	collect := func(name string, index int, changed bool) {
+		if index == -1 {
+			diff.Added = append(diff.Added, name)
+		} else if changed {
+			diff.Changed = append(diff.Changed, name)
+		}
+	}
+	diffTemplateNodes(e.Traits, t.Traits, func(one *Trait, index int, changed bool) { collect(one.String(), index, changed) })
+	diffTemplateNodes(e.Skills, t.Skills, func(one *Skill, index int, changed bool) { collect(one.String(), index, changed) })
+	diffTemplateNodes(e.Spells, t.Spells, func(one *Spell, index int, changed bool) { collect(one.String(), index, changed) })
+	diffTemplateNodes(e.CarriedEquipment, t.Equipment, func(one *Equipment, index int, changed bool) {
+		collect(one.String(), index, changed)
+	})
+	diffTemplateNodes(e.Notes, t.Notes, func(one *Note, index int, changed bool) { collect(one.String(), index, changed) })
+	return diff
+}
+
+// ReapplyTemplate adds the template's rows that are missing from this entity and, if replaceChanged is true, replaces
+// those whose library data differs from the template's. Rows that are unchanged are left alone, so any points spent
+// on them are kept. As with ApplyTo, pickers and nameable keys in the added rows are left unresolved. The record of the
+// template being applied is updated.
+func (e *Entity) ReapplyTemplate(rec *AppliedTemplate, t *Template, replaceChanged bool) MergeResult {
+	var result MergeResult
+	e.Traits = reapplyTemplateNodes(e, e.Traits, t.Traits, replaceChanged, &result)
+	e.Skills = reapplyTemplateNodes(e, e.Skills, t.Skills, replaceChanged, &result)
+	e.Spells = reapplyTemplateNodes(e, e.Spells, t.Spells, replaceChanged, &result)
+	e.CarriedEquipment = reapplyTemplateNodes(e, e.CarriedEquipment, t.Equipment, replaceChanged, &result)
+	e.Notes = reapplyTemplateNodes(e, e.Notes, t.Notes, replaceChanged, &result)
+	updated := *rec
+	updated.ID = t.ID
+	updated.Revision = t.Revision
+	updated.When = jio.Now()
+	e.RecordAppliedTemplate(&updated)
+	return result
+}
+
+// diffTemplateNodes calls f for each incoming node with the index of the existing node it corresponds to, or -1 if
+// there isn't one, and whether the two differ.
+func diffTemplateNodes[T NodeTypes](existing, incoming []T, f func(one T, index int, changed bool)) {
+	for _, one := range incoming {
+		index := slices.IndexFunc(existing, func(t T) bool { return mergeConflicts(t, one) })
+		f(one, index, index != -1 && templateTreeHash(existing[index]) != templateTreeHash(one))
+	}
+}
+
+func reapplyTemplateNodes[T NodeTypes](owner DataOwner, existing, incoming []T, replaceChanged bool, result *MergeResult) []T {
+	list := slices.Clone(existing)
+	ids := make(map[tid.TID]bool)
+	Traverse(func(node T) bool {
+		ids[AsNode(node).ID()] = true
+		return false
+	}, false, false, existing...)
+	var zero T
+	diffTemplateNodes(existing, incoming, func(one T, index int, changed bool) {
+		node := AsNode(one)
+		switch {
+		case index == -1:
+			list = append(list, node.Clone(LibraryFile{}, owner, zero, !ids[node.ID()]))
+			result.Added++
+		case changed && replaceChanged:
+			list[index] = node.Clone(LibraryFile{}, owner, zero, AsNode(list[index]).ID() == node.ID() || !ids[node.ID()])
+			result.Replaced++
+		default:
+			result.Skipped++
+		}
+	})
+	return list
+}
+
+// templateTreeHash returns a hash of the library data of the node and all of its descendants.
+func templateTreeHash[T NodeTypes](node T) uint64 {
+	h := fnv.New64()
+	Traverse(func(one T) bool {
+		AsNode(one).Hash(h)
+		return false
+	}, false, false, node)
+	return h.Sum64()
+}
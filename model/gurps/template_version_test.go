@@ -0,0 +1,70 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestReapplyTemplate(t *testing.T) {
+	tmpl := NewTemplate()
+	tmpl.Revision = 1
+	sk := NewSkill(tmpl, nil, false)
+	sk.Name = "Stealth"
+	sk.Difficulty.Difficulty = difficulty.Average
+	tmpl.Skills = []*Skill{sk}
+	note := NewNote(tmpl, nil, false)
+	note.Text = "From the template"
+	tmpl.Notes = []*Note{note}
+
+	e := NewEntity()
+	e.Traits = nil
+	from := LibraryFile{Library: "test/lib", Path: "Thief.gct"}
+	tmpl.ApplyTo(e, from)
+	tmpl.ApplyTo(e, from)
+	check.Equal(t, 1, len(e.AppliedTemplates))
+	applied := e.AppliedTemplates[0]
+	check.Equal(t, from, applied.LibraryFile)
+	check.Equal(t, 1, applied.Revision)
+	check.Equal(t, "Thief", applied.Title())
+	e.Skills = e.Skills[:1]
+	e.Notes = e.Notes[:1]
+	e.Skills[0].Points = fxp.Four
+
+	diff := e.DiffTemplate(tmpl)
+	check.Equal(t, 0, len(diff.Added))
+	check.Equal(t, 0, len(diff.Changed))
+
+	tmpl.Revision = 2
+	sk.Difficulty.Difficulty = difficulty.Hard
+	fit := NewTrait(tmpl, nil, false)
+	fit.Name = "Fit"
+	tmpl.Traits = []*Trait{fit}
+	diff = e.DiffTemplate(tmpl)
+	check.Equal(t, []string{"Fit"}, diff.Added)
+	check.Equal(t, []string{"Stealth"}, diff.Changed)
+
+	result := e.ReapplyTemplate(applied, tmpl, false)
+	check.Equal(t, MergeResult{Added: 1, Skipped: 2}, result)
+	check.Equal(t, 1, len(e.Traits))
+	check.Equal(t, difficulty.Average, e.Skills[0].Difficulty.Difficulty)
+	check.Equal(t, fxp.Four, e.Skills[0].Points)
+	check.Equal(t, 1, len(e.AppliedTemplates))
+	check.Equal(t, 2, e.AppliedTemplates[0].Revision)
+
+	result = e.ReapplyTemplate(e.AppliedTemplates[0], tmpl, true)
+	check.Equal(t, MergeResult{Replaced: 1, Skipped: 2}, result)
+	check.Equal(t, 1, len(e.Skills))
+	check.Equal(t, difficulty.Hard, e.Skills[0].Difficulty.Difficulty)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// ThresholdMagicSettings holds the settings for threshold-limited magic, where the energy of each
+// spell cast is added to a tally instead of being paid from FP, and a calamity check is required whenever the tally
+// exceeds the threshold.
+type ThresholdMagicSettings struct {
+	Enabled      bool    `json:"enabled,omitempty"`
+	Threshold    fxp.Int `json:"threshold"`
+	RecoveryRate fxp.Int `json:"recovery_rate"`
+}
+
+// NewThresholdMagicSettings returns new threshold-limited magic settings with factory defaults. Threshold-limited magic
+// is off, the threshold is 30 and the tally recovers 8 points per night of sleep.
+func NewThresholdMagicSettings() *ThresholdMagicSettings {
+	return &ThresholdMagicSettings{
+		Threshold:    fxp.From(30),
+		RecoveryRate: fxp.From(8),
+	}
+}
+
+// EnsureValidity checks the current settings for validity and if they aren't valid, makes them so.
+func (t *ThresholdMagicSettings) EnsureValidity() {
+	t.Threshold = t.Threshold.Max(fxp.One)
+	t.RecoveryRate = t.RecoveryRate.Max(0)
+}
+
+// Clone creates a copy of this.
+func (t *ThresholdMagicSettings) Clone() *ThresholdMagicSettings {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
+
+// ActiveThresholdMagic returns the threshold-limited magic settings for the entity, or nil if threshold-limited magic
+// is not enabled.
+func (e *Entity) ActiveThresholdMagic() *ThresholdMagicSettings {
+	if settings := e.SheetSettings.ThresholdMagic; settings != nil && settings.Enabled {
+		return settings
+	}
+	return nil
+}
+
+// OverThreshold returns the amount the threshold tally exceeds the threshold by, or 0 if it doesn't or threshold-limited
+// magic is not enabled.
+func (e *Entity) OverThreshold() fxp.Int {
+	if settings := e.ActiveThresholdMagic(); settings != nil {
+		return (e.ThresholdTally - settings.Threshold).Max(0)
+	}
+	return 0
+}
+
+// ThresholdCost returns the energy casting the spell adds to the threshold tally, taken from the leading value of its
+// casting cost. Returns false if the spell is a container or its casting cost has no leading value, such as when it
+// varies.
+func (s *Spell) ThresholdCost() (fxp.Int, bool) {
+	if s.Container() {
+		return 0, false
+	}
+	text := strings.TrimSpace(s.CastingCostWithReplacements())
+	cost, remainder := fxp.Extract(text)
+	if remainder == text || cost < 0 {
+		return 0, false
+	}
+	return cost, true
+}
+
+// CastSpell adds the energy required to cast the spell to the threshold tally. The casting is added to the change log,
+// noting when a calamity check is required because the tally now exceeds the threshold, and a description of it is
+// returned. Does nothing and returns an empty string if threshold-limited magic is not enabled or the spell has no
+// fixed casting cost.
+func (e *Entity) CastSpell(s *Spell) string {
+	settings := e.ActiveThresholdMagic()
+	if settings == nil {
+		return ""
+	}
+	cost, ok := s.ThresholdCost()
+	if !ok {
+		return ""
+	}
+	e.ThresholdTally += cost
+	description := fmt.Sprintf(i18n.Text("Cast %s for %s energy; threshold tally is %s of %s"), s.String(),
+		cost.Comma(), e.ThresholdTally.Comma(), settings.Threshold.Comma())
+	if over := e.OverThreshold(); over > 0 {
+		description += fmt.Sprintf(i18n.Text("; %s over threshold, roll for calamity"), over.Comma())
+	}
+	e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+		When:        jio.Now(),
+		Description: description,
+	})
+	return description
+}
+
+// recoverThresholdTally reduces the threshold tally by the recovery rate and returns a description of the amount
+// recovered, or an empty string if nothing was.
+func (e *Entity) recoverThresholdTally() string {
+	settings := e.ActiveThresholdMagic()
+	if settings == nil || settings.RecoveryRate <= 0 || e.ThresholdTally <= 0 {
+		return ""
+	}
+	amount := settings.RecoveryRate.Min(e.ThresholdTally)
+	e.ThresholdTally -= amount
+	return fmt.Sprintf(i18n.Text("%s threshold tally"), amount.Comma())
+}
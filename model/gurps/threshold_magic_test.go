@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rest"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestThresholdMagic(t *testing.T) {
+	e := NewEntity()
+	e.SheetSettings.ThresholdMagic = NewThresholdMagicSettings()
+	s := NewSpell(e, nil, false)
+	s.Name = "Fireball"
+	s.CastingCost = "12"
+
+	check.Equal(t, "", e.CastSpell(s), "nothing happens while threshold-limited magic is disabled")
+	check.Equal(t, fxp.Int(0), e.ThresholdTally)
+
+	e.SheetSettings.ThresholdMagic.Enabled = true
+	before := e.RestState()
+	check.Equal(t, "Cast Fireball for 12 energy; threshold tally is 12 of 30", e.CastSpell(s))
+	e.CastSpell(s)
+	check.Equal(t, fxp.Int(0), e.OverThreshold())
+	check.Equal(t, "Cast Fireball for 12 energy; threshold tally is 36 of 30; 6 over threshold, roll for calamity",
+		e.CastSpell(s))
+	check.Equal(t, fxp.From(6), e.OverThreshold())
+	check.Equal(t, 3, len(e.ChangeLog))
+
+	e.Rest(rest.Long)
+	check.Equal(t, fxp.From(36), e.ThresholdTally, "only sleep reduces the tally")
+	e.Rest(rest.Sleep)
+	check.Equal(t, fxp.From(28), e.ThresholdTally)
+
+	e.ApplyRestState(before)
+	check.Equal(t, fxp.Int(0), e.ThresholdTally)
+	check.Equal(t, 0, len(e.ChangeLog))
+
+	s.CastingCost = "Varies"
+	_, ok := s.ThresholdCost()
+	check.False(t, ok)
+	check.Equal(t, "", e.CastSpell(s))
+	s.CastingCost = "3 to maintain"
+	cost, ok := s.ThresholdCost()
+	check.True(t, ok)
+	check.Equal(t, fxp.From(3), cost)
+}
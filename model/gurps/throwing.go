@@ -0,0 +1,193 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stdmg"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Throw holds the distance and damage for an object thrown by an entity.
+type Throw struct {
+	// Inches is the distance the object can be thrown.
+	Inches fxp.Int
+	// Damage is the damage the object does when it hits.
+	Damage *dice.Dice
+}
+
+// Yards returns the distance the object can be thrown, in whole yards.
+func (t *Throw) Yards() fxp.Int {
+	return t.Inches.Div(fxp.ThirtySix).Trunc()
+}
+
+// Throw returns the distance and damage for throwing an object of the given weight (B355), taking the entity's Throwing
+// and Throwing Art skills into account. extraEffortPenalty is the (zero or negative) penalty taken for extra effort.
+// Returns nil if the object is too heavy for the entity to throw.
+func (e *Entity) Throw(weight fxp.Weight, extraEffortPenalty int) *Throw {
+	if weight <= 0 {
+		return nil
+	}
+
+	// Determine bonuses for skills
+	var distanceBonus, damageBonus int
+	Traverse(func(s *Skill) bool {
+		switch strings.ToLower(s.NameWithReplacements()) {
+		case "throwing art":
+			s.UpdateLevel()
+			if s.LevelData.RelativeLevel >= fxp.One {
+				distanceBonus = max(distanceBonus, 2)
+				damageBonus = max(damageBonus, 2)
+			} else if s.LevelData.RelativeLevel >= 0 {
+				distanceBonus = max(distanceBonus, 1)
+				damageBonus = max(damageBonus, 1)
+			}
+		case "throwing":
+			s.UpdateLevel()
+			if s.LevelData.RelativeLevel >= fxp.Two {
+				distanceBonus = max(distanceBonus, 2)
+			} else if s.LevelData.RelativeLevel >= fxp.One {
+				distanceBonus = max(distanceBonus, 1)
+			}
+		}
+		return false
+	}, true, true, e.Skills...)
+
+	// Determine distance modifier based on weight ratio
+	st := e.LiftingStrength() - e.LiftingStrengthBonus
+	if extraEffortPenalty < 0 {
+		st = st.Mul(fxp.From(-5*extraEffortPenalty).Div(fxp.Hundred) + fxp.One).Trunc()
+	}
+	st += fxp.From(distanceBonus)
+	basicLift := e.BasicLiftForST(st)
+	var weightRatio fxp.Int
+	if basicLift > 0 {
+		weightRatio = fxp.Int(weight).Div(fxp.Int(basicLift))
+	}
+	var modifier fxp.Int
+	switch {
+	case weightRatio <= fxp.Twentieth:
+		modifier = fxp.ThreeAndAHalf
+	case weightRatio <= fxp.Tenth:
+		modifier = fxp.TwoAndAHalf
+	case weightRatio <= fxp.PointOneFive:
+		modifier = fxp.Two
+	case weightRatio <= fxp.Fifth:
+		modifier = fxp.OneAndAHalf
+	case weightRatio <= fxp.Quarter:
+		modifier = fxp.OnePointTwo
+	case weightRatio <= fxp.ThreeTenths:
+		modifier = fxp.OnePointOne
+	case weightRatio <= fxp.TwoFifths:
+		modifier = fxp.One
+	case weightRatio <= fxp.Half:
+		modifier = fxp.FourFifths
+	case weightRatio <= fxp.ThreeQuarters:
+		modifier = fxp.SevenTenths
+	case weightRatio <= fxp.One:
+		modifier = fxp.ThreeFifths
+	case weightRatio <= fxp.OneAndAHalf:
+		modifier = fxp.TwoFifths
+	case weightRatio <= fxp.Two:
+		modifier = fxp.ThreeTenths
+	case weightRatio <= fxp.TwoAndAHalf:
+		modifier = fxp.Quarter
+	case weightRatio <= fxp.Three:
+		modifier = fxp.Fifth
+	case weightRatio <= fxp.Four:
+		modifier = fxp.PointOneFive
+	case weightRatio <= fxp.Five:
+		modifier = fxp.PointOneTwo
+	case weightRatio <= fxp.Six:
+		modifier = fxp.Tenth
+	case weightRatio <= fxp.Seven:
+		modifier = fxp.PointZeroNine
+	case weightRatio <= fxp.Eight:
+		modifier = fxp.PointZeroEight
+	case weightRatio <= fxp.Nine:
+		modifier = fxp.PointZeroSeven
+	case weightRatio <= fxp.Ten:
+		modifier = fxp.PointZeroSix
+	case weightRatio <= fxp.Twelve:
+		modifier = fxp.Twentieth
+	}
+	inches := st.Mul(modifier).Mul(fxp.ThirtySix).Trunc()
+	if inches <= fxp.One {
+		return nil
+	}
+
+	// Determine damage based on weight ratio
+	thrust := e.Thrust()
+	thrust.Modifier += thrust.Count * damageBonus
+	basicLift = e.BasicLiftForST(st - fxp.From(distanceBonus))
+	if basicLift > 0 {
+		weightRatio = fxp.Int(weight).Div(fxp.Int(basicLift))
+	} else {
+		weightRatio = 0
+	}
+	switch {
+	case weightRatio <= fxp.Eighth:
+		thrust.Modifier -= thrust.Count * 2
+	case weightRatio <= fxp.Quarter:
+		thrust.Modifier -= thrust.Count
+	case weightRatio <= fxp.Half:
+	case weightRatio <= fxp.One:
+		thrust.Modifier += thrust.Count
+	case weightRatio <= fxp.Two:
+	case weightRatio <= fxp.Four:
+		thrust.Modifier -= thrust.Count / 2
+	default:
+		thrust.Modifier -= thrust.Count
+	}
+	return &Throw{
+		Inches: inches,
+		Damage: thrust,
+	}
+}
+
+// NewThrownObject creates a new piece of equipment for an object of the given weight, with a ranged weapon whose range
+// and damage are those the entity currently gets when throwing it. Returns nil if the object is too heavy for the
+// entity to throw.
+func NewThrownObject(entity *Entity, parent *Equipment, weight fxp.Weight, extraEffortPenalty int) *Equipment {
+	t := entity.Throw(weight, extraEffortPenalty)
+	if t == nil {
+		return nil
+	}
+	obj := NewEquipment(entity, parent, false)
+	obj.Name = fmt.Sprintf(i18n.Text("Thrown Object (%s)"), entity.SheetSettings.DefaultWeightUnits.Format(weight))
+	obj.PageRef = "B355"
+	obj.Weight = weight
+	w := NewWeapon(obj, false)
+	w.Usage = i18n.Text("Thrown")
+	w.Defaults = []*SkillDefault{
+		{
+			DefaultType: DexterityID,
+			Modifier:    -fxp.Three,
+		},
+		{
+			DefaultType: SkillID,
+			Name:        "Throwing",
+		},
+	}
+	w.Damage.Type = "cr"
+	w.Damage.StrengthType = stdmg.None
+	w.Damage.Base = t.Damage
+	w.Damage.Owner = w
+	w.Range.Max = t.Yards()
+	if extraEffortPenalty < 0 {
+		w.UsageNotes = fmt.Sprintf(i18n.Text("Includes extra effort (%d)"), extraEffortPenalty)
+	}
+	obj.Weapons = []*Weapon{w}
+	return obj
+}
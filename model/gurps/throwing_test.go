@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestThrow(t *testing.T) {
+	e := NewEntity()
+	e.Recalculate()
+
+	th := e.Throw(fxp.WeightFromInteger(1, fxp.Pound), 0)
+	check.NotNil(t, th)
+	check.Equal(t, fxp.From(35), th.Yards())
+	check.Equal(t, "1d-4", th.Damage.String())
+
+	th = e.Throw(fxp.WeightFromInteger(20, fxp.Pound), 0)
+	check.NotNil(t, th)
+	check.Equal(t, fxp.Six, th.Yards())
+	check.Equal(t, "1d-1", th.Damage.String())
+
+	check.Nil(t, e.Throw(fxp.WeightFromInteger(300, fxp.Pound), 0))
+	check.Nil(t, e.Throw(0, 0))
+	check.Nil(t, NewThrownObject(e, nil, fxp.WeightFromInteger(300, fxp.Pound), 0))
+
+	obj := NewThrownObject(e, nil, fxp.WeightFromInteger(20, fxp.Pound), 0)
+	check.NotNil(t, obj)
+	check.Equal(t, 1, len(obj.Weapons))
+	w := obj.Weapons[0]
+	check.True(t, w.IsRanged())
+	check.Equal(t, fxp.Six, w.Range.Max)
+	check.Equal(t, "1d-1", w.Damage.Base.String())
+}
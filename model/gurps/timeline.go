@@ -0,0 +1,233 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// TimelineDateLayout is the layout used for dates within a Timeline.
+const TimelineDateLayout = "2006-01-02"
+
+// agingAttributes holds the attributes that may be reduced by a failed aging roll.
+var agingAttributes = []string{StrengthID, DexterityID, IntelligenceID, HealthID}
+
+// Timeline holds the in-game calendar for a character, used to track birthdays and aging rolls.
+type Timeline struct {
+	Date          string `json:"date"`
+	Birthday      string `json:"birthday,omitempty"`
+	LastAgingRoll string `json:"last_aging_roll,omitempty"`
+	AgingModifier int    `json:"aging_modifier,omitempty"`
+}
+
+// TimelineState holds the portions of an entity that may be altered by advancing its timeline.
+type TimelineState struct {
+	timeline    *Timeline
+	age         string
+	adjustments map[string]fxp.Int
+	changeLog   []*ChangeLogEntry
+}
+
+// NewTimeline creates a new Timeline starting on the given date. The character's most recent birthday is assumed to
+// be that date.
+func NewTimeline(date time.Time) *Timeline {
+	d := date.Format(TimelineDateLayout)
+	return &Timeline{
+		Date:          d,
+		Birthday:      d,
+		LastAgingRoll: d,
+	}
+}
+
+// Clone creates a copy of the Timeline.
+func (t *Timeline) Clone() *Timeline {
+	if t == nil {
+		return nil
+	}
+	other := *t
+	return &other
+}
+
+// TimelineState returns a snapshot of the data that may be altered by advancing the timeline.
+func (e *Entity) TimelineState() *TimelineState {
+	s := &TimelineState{
+		timeline:    e.Timeline.Clone(),
+		age:         e.Profile.Age,
+		adjustments: make(map[string]fxp.Int, len(agingAttributes)),
+		changeLog:   slices.Clone(e.ChangeLog),
+	}
+	for _, id := range agingAttributes {
+		if attr, ok := e.Attributes.Set[id]; ok {
+			s.adjustments[id] = attr.Adjustment
+		}
+	}
+	return s
+}
+
+// ApplyTimelineState restores a snapshot previously obtained from TimelineState.
+func (e *Entity) ApplyTimelineState(s *TimelineState) {
+	e.Timeline = s.timeline.Clone()
+	e.Profile.Age = s.age
+	for id, adj := range s.adjustments {
+		if attr, ok := e.Attributes.Set[id]; ok {
+			attr.Adjustment = adj
+		}
+	}
+	e.ChangeLog = slices.Clone(s.changeLog)
+	e.Recalculate()
+}
+
+// AdvanceTimeline moves the campaign date forward to the given date, updating the age on each birthday and making any
+// aging rolls that come due along the way. The results are added to the change log and returned.
+func (e *Entity) AdvanceTimeline(to time.Time) []string {
+	return e.advanceTimeline(to, RollAgainst, rand.IntN)
+}
+
+func (e *Entity) advanceTimeline(to time.Time, roll func(target int) SuccessRoll, pick func(n int) int) []string {
+	if e.Timeline == nil {
+		e.Timeline = NewTimeline(to)
+		return nil
+	}
+	current, err := time.Parse(TimelineDateLayout, e.Timeline.Date)
+	if err != nil {
+		current = to
+	}
+	birthday := parseTimelineDate(e.Timeline.Birthday, current)
+	lastRoll := parseTimelineDate(e.Timeline.LastAgingRoll, current)
+	age, ageErr := strconv.Atoi(strings.TrimSpace(e.Profile.Age))
+	var results []string
+	for d := current.AddDate(0, 0, 1); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if ageErr == nil && !d.Before(birthday.AddDate(1, 0, 0)) {
+			birthday = birthday.AddDate(1, 0, 0)
+			age++
+			e.Profile.Age = strconv.Itoa(age)
+			results = append(results, fmt.Sprintf(i18n.Text("[%s] Turned %d"), d.Format(TimelineDateLayout), age))
+		}
+		if years, months, days := e.agingInterval(age, ageErr == nil); years+months+days != 0 &&
+			!d.Before(lastRoll.AddDate(years, months, days)) {
+			lastRoll = d
+			results = append(results, fmt.Sprintf("[%s] %s", d.Format(TimelineDateLayout), e.makeAgingRoll(roll, pick)))
+		}
+	}
+	e.Timeline.Date = to.Format(TimelineDateLayout)
+	e.Timeline.Birthday = birthday.Format(TimelineDateLayout)
+	e.Timeline.LastAgingRoll = lastRoll.Format(TimelineDateLayout)
+	when := jio.Now()
+	for _, one := range results {
+		e.ChangeLog = append(e.ChangeLog, &ChangeLogEntry{
+			When:        when,
+			Description: one,
+		})
+	}
+	if len(results) != 0 {
+		e.Recalculate()
+	}
+	return results
+}
+
+// agingInterval returns the time between aging rolls for the given age, or all zeroes if no aging rolls are needed.
+// Unaging prevents aging rolls, Self-Destruct requires them daily, and each level of Extended Lifespan doubles the ages
+// at which they begin and become more frequent.
+func (e *Entity) agingInterval(age int, ageKnown bool) (years, months, days int) {
+	var unaging, selfDestruct bool
+	var extended int
+	Traverse(func(t *Trait) bool {
+		switch strings.ToLower(strings.TrimSpace(t.NameWithReplacements())) {
+		case "unaging":
+			unaging = true
+		case "self-destruct":
+			selfDestruct = true
+		case "extended lifespan":
+			extended += max(fxp.As[int](t.CurrentLevel()), 1)
+		}
+		return false
+	}, true, true, e.Traits...)
+	switch {
+	case unaging:
+		return 0, 0, 0
+	case selfDestruct:
+		return 0, 0, 1
+	case !ageKnown:
+		return 0, 0, 0
+	}
+	multiplier := 1 << min(extended, 10)
+	switch {
+	case age >= 90*multiplier:
+		return 0, 3, 0
+	case age >= 70*multiplier:
+		return 0, 6, 0
+	case age >= 50*multiplier:
+		return 1, 0, 0
+	default:
+		return 0, 0, 0
+	}
+}
+
+func (e *Entity) makeAgingRoll(roll func(target int) SuccessRoll, pick func(n int) int) string {
+	target := fxp.As[int](e.Attributes.Current(HealthID).Trunc()) + e.Timeline.AgingModifier
+	r := roll(target)
+	if e.hasTraitNamed("longevity") {
+		// With Longevity, aging rolls fail only on a 17 or 18.
+		r.Success = r.Roll < 17
+		r.Critical = r.Roll == 18 || (r.Success && r.Critical)
+	}
+	if r.Success {
+		return fmt.Sprintf(i18n.Text("Aging roll: %s"), r.String())
+	}
+	loss := fxp.One
+	if r.Critical {
+		loss = fxp.Two
+	}
+	var available []string
+	for _, id := range agingAttributes {
+		if _, ok := e.Attributes.Set[id]; ok {
+			available = append(available, id)
+		}
+	}
+	if len(available) == 0 {
+		return fmt.Sprintf(i18n.Text("Aging roll: %s"), r.String())
+	}
+	id := available[pick(len(available))]
+	attr := e.Attributes.Set[id]
+	attr.Adjustment -= loss
+	name := strings.ToUpper(id)
+	if def := attr.AttributeDef(); def != nil {
+		name = def.Name
+	}
+	return fmt.Sprintf(i18n.Text("Aging roll: %s; lost %s %s"), r.String(), loss.String(), name)
+}
+
+func (e *Entity) hasTraitNamed(name string) bool {
+	found := false
+	Traverse(func(t *Trait) bool {
+		if strings.EqualFold(strings.TrimSpace(t.NameWithReplacements()), name) {
+			found = true
+			return true
+		}
+		return false
+	}, true, true, e.Traits...)
+	return found
+}
+
+func parseTimelineDate(date string, fallback time.Time) time.Time {
+	if t, err := time.Parse(TimelineDateLayout, date); err == nil {
+		return t
+	}
+	return fallback
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAdvanceTimeline(t *testing.T) {
+	e := NewEntity()
+	e.Profile.Age = "49"
+	start := time.Date(1000, time.March, 1, 0, 0, 0, 0, time.UTC)
+	e.Timeline = NewTimeline(start)
+	e.Recalculate()
+	st := e.Attributes.Current("st")
+	var rolls int
+	failing := func(target int) SuccessRoll {
+		rolls++
+		return NewSuccessRoll(target, 16)
+	}
+	first := func(int) int { return 0 }
+
+	results := e.advanceTimeline(start.AddDate(0, 11, 0), failing, first)
+	check.Equal(t, 0, len(results), "no birthday or aging roll yet")
+	check.Equal(t, "49", e.Profile.Age)
+
+	results = e.advanceTimeline(start.AddDate(2, 0, 0), failing, first)
+	check.Equal(t, "51", e.Profile.Age)
+	check.Equal(t, 2, rolls, "one aging roll per year from age 50")
+	check.Equal(t, 4, len(results))
+	check.Equal(t, st-fxp.Two, e.Attributes.Current("st"))
+	check.Equal(t, 4, len(e.ChangeLog))
+
+	longevity := NewTrait(e, nil, false)
+	longevity.Name = "Longevity"
+	e.Traits = []*Trait{longevity}
+	e.Recalculate()
+	e.advanceTimeline(start.AddDate(3, 0, 0), failing, first)
+	check.Equal(t, 3, rolls)
+	check.Equal(t, st-fxp.Two, e.Attributes.Current("st"), "Longevity only fails on 17 or 18")
+
+	longevity.Name = "Unaging"
+	e.Recalculate()
+	e.advanceTimeline(start.AddDate(5, 0, 0), failing, first)
+	check.Equal(t, 3, rolls, "Unaging prevents aging rolls")
+	check.Equal(t, "54", e.Profile.Age)
+}
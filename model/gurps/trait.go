@@ -102,6 +102,8 @@ type TraitSyncData struct {
 	Tags             []string            `json:"tags,omitempty"`
 	Prereq           *PrereqList         `json:"prereqs,omitempty"`
 	CRAdj            selfctrl.Adjustment `json:"cr_adj,omitempty"`
+	PowerSource      string              `json:"power_source,omitempty"`
+	PowerTalent      string              `json:"power_talent,omitempty"`
 }
 
 // TraitNonContainerSyncData holds the Trait sync data that is only applicable to traits that aren't containers.
@@ -112,6 +114,7 @@ type TraitNonContainerSyncData struct {
 	Features       Features  `json:"features,omitempty"`
 	RoundCostDown  bool      `json:"round_down,omitempty"`
 	CanLevel       bool      `json:"can_level,omitempty"`
+	Empowerment    fxp.Int   `json:"empowerment,omitempty"`
 }
 
 // TraitContainerSyncData holds the Trait sync data that is only applicable to traits that are containers.
@@ -372,13 +375,21 @@ func (t *Trait) CellData(columnID int, data *CellData) {
 				data.InlineTag = i18n.Text("Attribute")
 			case container.MetaTrait:
 				data.InlineTag = i18n.Text("Meta")
+			case container.AlternateForm:
+				data.InlineTag = i18n.Text("Form")
+			case container.Power:
+				data.InlineTag = i18n.Text("Power")
+			case container.Sorcery:
+				data.InlineTag = i18n.Text("Sorcery")
 			default:
 			}
 		}
 	case TraitPointsColumn:
 		data.Type = cell.Text
-		data.Primary = t.AdjustedPoints().String()
+		points := t.AdjustedPoints()
+		data.Primary = points.String()
 		data.Alignment = align.End
+		data.PointsDelta = EntityFromNode(t).SavedPoints().RowDelta(t.TID, points)
 	case TraitTagsColumn:
 		data.Type = cell.Tags
 		data.Primary = CombineTags(t.Tags)
@@ -453,20 +464,40 @@ func (t *Trait) CurrentLevel() fxp.Int {
 
 // AdjustedPoints returns the total points, taking levels and modifiers into account.
 func (t *Trait) AdjustedPoints() fxp.Int {
+	if t.IsAlternateForm() {
+		// Alternate forms are paid for whether or not they are the active form.
+		if p := t.Parent(); p != nil && p.EffectivelyDisabled() {
+			return 0
+		}
+		return t.alternateFormCost()
+	}
 	if t.EffectivelyDisabled() {
 		return 0
 	}
+	return t.pointsWith((*Trait).AdjustedPoints)
+}
+
+// pointsWith returns the adjusted points of the trait without regard to whether it is enabled, using childPoints to
+// determine the points of each child of a container.
+func (t *Trait) pointsWith(childPoints func(*Trait) fxp.Int) fxp.Int {
 	if !t.Container() {
 		return AdjustedPoints(EntityFromNode(t), t, t.CanLevel, t.BasePoints, t.Levels, t.PointsPerLevel, t.CR,
 			t.AllModifiers(), t.RoundCostDown)
 	}
 	var points fxp.Int
-	if t.ContainerType == container.AlternativeAbilities {
-		values := make([]fxp.Int, len(t.Children))
-		for i, one := range t.Children {
-			values[i] = one.AdjustedPoints()
-			if values[i] > points {
-				points = values[i]
+	if t.ContainerType.AlternativeCost() {
+		var full fxp.Int
+		values := make([]fxp.Int, 0, len(t.Children))
+		for _, one := range t.Children {
+			v := childPoints(one)
+			if t.ContainerType == container.Sorcery && one.IsSorcerousEmpowerment() {
+				// Sorcerous Empowerment isn't one of the alternatives, so is always paid for in full.
+				full += v
+				continue
+			}
+			values = append(values, v)
+			if v > points {
+				points = v
 			}
 		}
 		maximum := points
@@ -478,27 +509,45 @@ func (t *Trait) AdjustedPoints() fxp.Int {
 				points += fxp.ApplyRounding(calculateModifierPoints(v, fxp.Twenty), t.RoundCostDown)
 			}
 		}
+		points += full
 	} else {
 		for _, one := range t.Children {
-			points += one.AdjustedPoints()
+			points += childPoints(one)
 		}
 	}
 	return points
 }
 
-// AllModifiers returns the modifiers plus any inherited from parents.
+// AllModifiers returns the modifiers plus any inherited from parents. A power's Talent does not inherit the modifiers of
+// the power.
 func (t *Trait) AllModifiers() []*TraitModifier {
 	all := make([]*TraitModifier, len(t.Modifiers))
 	copy(all, t.Modifiers)
+	talent := t.IsTalent()
 	p := t.parent
 	for p != nil {
-		all = append(all, p.Modifiers...)
+		if !talent || p.ContainerType != container.Power {
+			all = append(all, p.Modifiers...)
+		}
 		p = p.parent
 	}
 	return all
 }
 
-// Enabled returns true if this Trait and all of its parents are enabled.
+// IsTalent returns true if this is a Talent, i.e. a trait that isn't a container and is either tagged "Talent" or is
+// named "Talent" or "Talent (...)".
+func (t *Trait) IsTalent() bool {
+	if t.Container() {
+		return false
+	}
+	if HasTag("Talent", t.Tags) {
+		return true
+	}
+	name := strings.ToLower(t.NameWithReplacements())
+	return name == "talent" || strings.HasPrefix(name, "talent (")
+}
+
+// Enabled returns true if this Trait and all of its parents are enabled and its power source isn't suppressed.
 func (t *Trait) Enabled() bool {
 	if t.Disabled {
 		return false
@@ -510,7 +559,39 @@ func (t *Trait) Enabled() bool {
 		}
 		p = p.parent
 	}
-	return true
+	return !t.PowerSuppressed()
+}
+
+// EffectivePowerSource returns the power source of this Trait, or that of its nearest parent that has one.
+func (t *Trait) EffectivePowerSource() string {
+	for p := t; p != nil; p = p.parent {
+		if p.PowerSource != "" {
+			return p.PowerSource
+		}
+	}
+	return ""
+}
+
+// EffectivePowerTalent returns the power talent of this Trait, or that of its nearest parent that has one.
+func (t *Trait) EffectivePowerTalent() string {
+	for p := t; p != nil; p = p.parent {
+		if p.PowerTalent != "" {
+			return p.PowerTalent
+		}
+	}
+	return ""
+}
+
+// PowerSuppressed returns true if the power source of this Trait has been suppressed, such as by an anti-magic zone.
+func (t *Trait) PowerSuppressed() bool {
+	source := t.EffectivePowerSource()
+	if source == "" {
+		return false
+	}
+	if entity := EntityFromNode(t); entity != nil {
+		return entity.PowerSourceSuppressed(source)
+	}
+	return false
 }
 
 // NameWithReplacements returns the name with any replacements applied.
@@ -655,7 +736,7 @@ func (t *Trait) SecondaryText(optionChecker func(display.Option) bool) string {
 	if userDesc != "" && optionChecker(settings.UserDescriptionDisplay) {
 		buffer.WriteString(userDesc)
 	}
-	if optionChecker(settings.ModifiersDisplay) {
+	if optionChecker(settings.EffectiveModifiersDisplay()) {
 		AppendStringOntoNewLine(&buffer, t.ModifierNotes())
 	}
 	if optionChecker(settings.NotesDisplay) {
@@ -853,6 +934,8 @@ func (t *TraitSyncData) hash(h hash.Hash) {
 		_, _ = h.Write([]byte(tag))
 	}
 	_ = binary.Write(h, binary.LittleEndian, t.CRAdj)
+	_, _ = h.Write([]byte(t.PowerSource))
+	_, _ = h.Write([]byte(t.PowerTalent))
 	t.Prereq.Hash(h)
 }
 
@@ -867,6 +950,7 @@ func (t *TraitNonContainerSyncData) hash(h hash.Hash) {
 	}
 	_ = binary.Write(h, binary.LittleEndian, t.RoundCostDown)
 	_ = binary.Write(h, binary.LittleEndian, t.CanLevel)
+	_ = binary.Write(h, binary.LittleEndian, t.Empowerment)
 }
 
 func (t *TraitContainerSyncData) hash(h hash.Hash) {
@@ -0,0 +1,65 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestTraitContainerCostRules(t *testing.T) {
+	e := NewEntity()
+	newContainer := func(containerType container.Type, points ...int) *Trait {
+		c := NewTrait(e, nil, true)
+		c.ContainerType = containerType
+		for _, one := range points {
+			child := NewTrait(e, c, false)
+			child.BasePoints = fxp.From(one)
+			c.Children = append(c.Children, child)
+		}
+		return c
+	}
+
+	alternatives := newContainer(container.AlternativeAbilities, 20, 40)
+	check.Equal(t, fxp.From(44), alternatives.AdjustedPoints(), "the others cost 1/5")
+
+	power := newContainer(container.Power, 40, 10)
+	power.Children[1].Name = "Talent (Fire)"
+	mod := NewTraitModifier(e, nil, false)
+	mod.Cost = fxp.From(-10)
+	power.Modifiers = []*TraitModifier{mod}
+	check.False(t, power.Children[0].IsTalent())
+	check.True(t, power.Children[1].IsTalent())
+	power.Children[0].Name = "Untalented (Singing)"
+	check.False(t, power.Children[0].IsTalent(), "only a Talent tag or a name of Talent or Talent (...) marks a Talent")
+	power.Children[0].Tags = []string{"Talent"}
+	check.True(t, power.Children[0].IsTalent())
+	power.Children[0].Tags = nil
+	check.Equal(t, fxp.From(36), power.Children[0].AdjustedPoints(), "the power modifier applies")
+	check.Equal(t, fxp.From(10), power.Children[1].AdjustedPoints(), "but not to the Talent")
+	check.Equal(t, fxp.From(46), power.AdjustedPoints())
+
+	meta := newContainer(container.MetaTrait, 10, -15)
+	group := newContainer(container.Group, 10, -15)
+	e.Traits = []*Trait{alternatives, power, meta}
+	e.Recalculate()
+	pb := e.PointsBreakdown()
+	check.Equal(t, fxp.From(90), pb.Advantages)
+	check.Equal(t, fxp.From(-5), pb.Disadvantages, "a meta-trait counts as a single trait")
+
+	e.Traits = []*Trait{group}
+	e.Recalculate()
+	pb = e.PointsBreakdown()
+	check.Equal(t, fxp.From(10), pb.Advantages, "the traits in a group count separately")
+	check.Equal(t, fxp.From(-15), pb.Disadvantages)
+}
@@ -69,10 +69,7 @@ func (p *TraitPrereq) FillWithNameableKeys(m, existing map[string]string) {
 
 // Satisfied implements Prereq.
 func (p *TraitPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
-	var replacements map[string]string
-	if na, ok := exclude.(nameable.Accesser); ok {
-		replacements = na.NameableReplacements()
-	}
+	replacements := entity.prereqReplacements(exclude)
 	satisfied := false
 	Traverse(func(t *Trait) bool {
 		if exclude == t || !p.NameCriteria.Matches(replacements, t.NameWithReplacements()) {
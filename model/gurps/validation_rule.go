@@ -0,0 +1,234 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
+	"github.com/richardwilkes/toolbox/eval"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+var _ eval.VariableResolver = &validationResolver{}
+
+// ValidationRule holds a rule that a character can be checked against. The expression is evaluated once for the
+// character or once for each item of the target type and must resolve to true for the check to pass. In addition to
+// the normal variables, the following are available, depending upon the target:
+//
+//	Attributes: $value, $points
+//	Traits:     $levels, $points
+//	Skills:     $level, $relative_level, $points, $attribute
+//	Spells:     $level, $relative_level, $points, $attribute
+//	Equipment:  $quantity, $value, $weight, $tl, $level
+//
+// Rules marked as CreationOnly are only checked while the character is in creation mode.
+type ValidationRule struct {
+	Name         string       `json:"name,omitempty"`
+	Target       vtarget.Type `json:"target"`
+	Expression   string       `json:"expression"`
+	Message      string       `json:"message,omitempty"`
+	Disabled     bool         `json:"disabled,omitempty"`
+	CreationOnly bool         `json:"creation_only,omitempty"`
+}
+
+// ValidationIssue holds information about a validation rule that failed. Rule will be nil for issues arising from the
+// creation limits.
+type ValidationIssue struct {
+	Rule    *ValidationRule
+	Target  vtarget.Type
+	ID      tid.TID
+	Subject string
+	Message string
+}
+
+type validationResolver struct {
+	entity *Entity
+	vars   map[string]string
+}
+
+// CloneValidationRules creates a clone of the provided ValidationRule list.
+func CloneValidationRules(list []*ValidationRule) []*ValidationRule {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*ValidationRule, len(list))
+	for i := 0; i < len(list); i++ {
+		rule := *list[i]
+		clone[i] = &rule
+	}
+	return clone
+}
+
+// String implements fmt.Stringer.
+func (r *ValidationRule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Expression
+}
+
+// Validate checks the entity against the validation rules in its sheet settings and returns any issues found. While in
+// creation mode, the creation limits are also checked. Payload capacity is always checked.
+func (e *Entity) Validate() []*ValidationIssue {
+	var issues []*ValidationIssue
+	creation := e.Mode == sheetmode.Creation
+	if creation {
+		issues = e.checkCreationLimits(issues)
+	}
+	issues = e.checkPayload(issues)
+	issues = e.checkLanguages(issues)
+	issues = e.checkAssociates(issues)
+	issues = e.checkReputations(issues)
+	issues = e.checkConsumables(issues)
+	issues = e.checkSorcery(issues)
+	for _, rule := range e.SheetSettings.ValidationRules {
+		if !rule.Disabled && (creation || !rule.CreationOnly) && strings.TrimSpace(rule.Expression) != "" {
+			issues = rule.check(e, issues)
+		}
+	}
+	return issues
+}
+
+func (e *Entity) checkCreationLimits(issues []*ValidationIssue) []*ValidationIssue {
+	if unspent := e.UnspentPoints(); unspent < 0 {
+		issues = e.creationIssue(issues, fmt.Sprintf(i18n.Text("%s more points have been spent than are available"),
+			(-unspent).String()))
+	}
+	pb := e.PointsBreakdown()
+	if limit := e.SheetSettings.CreationDisadvantageLimit; limit > 0 && -pb.Disadvantages > limit {
+		issues = e.creationIssue(issues, fmt.Sprintf(i18n.Text("Disadvantages total %s points, exceeding the limit of %s"),
+			(-pb.Disadvantages).String(), limit.String()))
+	}
+	if limit := e.SheetSettings.CreationQuirkLimit; limit > 0 && -pb.Quirks > limit {
+		issues = e.creationIssue(issues, fmt.Sprintf(i18n.Text("Quirks total %s points, exceeding the limit of %s"),
+			(-pb.Quirks).String(), limit.String()))
+	}
+	return issues
+}
+
+func (e *Entity) creationIssue(issues []*ValidationIssue, msg string) []*ValidationIssue {
+	return append(issues, &ValidationIssue{
+		Target:  vtarget.Character,
+		Subject: e.Profile.Name,
+		Message: msg,
+	})
+}
+
+func (r *ValidationRule) check(e *Entity, issues []*ValidationIssue) []*ValidationIssue {
+	switch r.Target {
+	case vtarget.Attributes:
+		for _, attr := range e.Attributes.List() {
+			def := attr.AttributeDef()
+			if def == nil {
+				continue
+			}
+			issues = r.checkOne(e, issues, "", def.Name, map[string]string{
+				"value":  attr.Current().String(),
+				"points": attr.PointCost().String(),
+			})
+		}
+	case vtarget.Traits:
+		Traverse(func(t *Trait) bool {
+			issues = r.checkOne(e, issues, t.TID, t.String(), map[string]string{
+				"levels": t.CurrentLevel().String(),
+				"points": t.AdjustedPoints().String(),
+			})
+			return false
+		}, true, true, e.Traits...)
+	case vtarget.Skills:
+		Traverse(func(s *Skill) bool {
+			issues = r.checkOne(e, issues, s.TID, s.String(), map[string]string{
+				"level":          s.LevelData.Level.String(),
+				"relative_level": s.LevelData.RelativeLevel.String(),
+				"points":         s.Points.String(),
+				"attribute":      e.ResolveAttributeCurrent(s.Difficulty.Attribute).Max(0).String(),
+			})
+			return false
+		}, false, true, e.Skills...)
+	case vtarget.Spells:
+		Traverse(func(s *Spell) bool {
+			issues = r.checkOne(e, issues, s.TID, s.String(), map[string]string{
+				"level":          s.LevelData.Level.String(),
+				"relative_level": s.LevelData.RelativeLevel.String(),
+				"points":         s.Points.String(),
+				"attribute":      e.ResolveAttributeCurrent(s.Difficulty.Attribute).Max(0).String(),
+			})
+			return false
+		}, false, true, e.Spells...)
+	case vtarget.Equipment:
+		f := func(eqp *Equipment) bool {
+			issues = r.checkOne(e, issues, eqp.TID, eqp.String(), map[string]string{
+				"quantity": eqp.Quantity.String(),
+				"value":    eqp.Value.String(),
+				"weight":   fxp.Int(eqp.Weight).String(),
+				"tl":       techLevelForValidation(eqp.TechLevel),
+				"level":    eqp.Level.String(),
+			})
+			return false
+		}
+		Traverse(f, false, false, e.CarriedEquipment...)
+		Traverse(f, false, false, e.OtherEquipment...)
+	default:
+		issues = r.checkOne(e, issues, "", e.Profile.Name, map[string]string{
+			"tl": techLevelForValidation(e.Profile.TechLevel),
+		})
+	}
+	return issues
+}
+
+func (r *ValidationRule) checkOne(e *Entity, issues []*ValidationIssue, id tid.TID, subject string, vars map[string]string) []*ValidationIssue {
+	pass, err := evalToBool(fxp.NewEvaluator(&validationResolver{
+		entity: e,
+		vars:   vars,
+	}), r.Expression)
+	if err == nil && pass {
+		return issues
+	}
+	msg := r.Message
+	if err != nil {
+		msg = fmt.Sprintf(i18n.Text("Unable to evaluate rule: %v"), err)
+	} else if msg == "" {
+		msg = fmt.Sprintf(i18n.Text("Failed rule: %s"), r.String())
+	}
+	return append(issues, &ValidationIssue{
+		Rule:    r,
+		Target:  r.Target,
+		ID:      id,
+		Subject: subject,
+		Message: msg,
+	})
+}
+
+func techLevelForValidation(tl string) string {
+	var buffer strings.Builder
+	for _, ch := range tl {
+		if ch < '0' || ch > '9' {
+			break
+		}
+		buffer.WriteRune(ch)
+	}
+	if buffer.Len() == 0 {
+		return "0"
+	}
+	return buffer.String()
+}
+
+// ResolveVariable implements eval.VariableResolver.
+func (r *validationResolver) ResolveVariable(variableName string) string {
+	if v, ok := r.vars[variableName]; ok {
+		return v
+	}
+	return r.entity.ResolveVariable(variableName)
+}
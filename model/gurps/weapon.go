@@ -88,6 +88,8 @@ type WeaponData struct {
 	Bulk       WeaponBulk      `json:"bulk,omitempty"`
 	Recoil     WeaponRecoil    `json:"recoil,omitempty"`
 	Defaults   []*SkillDefault `json:"defaults,omitempty"`
+	AmmoID     tid.TID         `json:"ammo_id,omitempty"`
+	Loaded     fxp.Int         `json:"loaded,omitempty"`
 }
 
 // Weapon holds the stats for a weapon.
@@ -446,12 +448,7 @@ func (w *Weapon) usesCrossbowSkill() bool {
 
 func (w *Weapon) skillLevelBaseAdjustment(e *Entity, tooltip *xio.ByteBuffer) fxp.Int {
 	var adj fxp.Int
-	minST := w.Strength.Resolve(w, nil).Min
-	if !w.IsRanged() || (w.Range.MusclePowered && !w.usesCrossbowSkill()) {
-		minST -= e.StrikingStrength()
-	} else {
-		minST -= e.LiftingStrength()
-	}
+	minST := w.Strength.Resolve(w, nil).Min - w.userStrength(e)
 	if minST > 0 {
 		adj -= minST
 		if tooltip != nil {
@@ -790,10 +787,18 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 	case WeaponDescriptionColumn:
 		data.Primary = w.String()
 		data.Secondary = w.Notes()
+		if req, ok := w.STRequirement(); ok && req.Insufficient() {
+			data.Warning = i18n.Text("Insufficient ST")
+			data.Tooltip = req.Warning()
+		}
 	case WeaponUsageColumn:
 		data.Primary = w.UsageWithReplacements()
 	case WeaponSLColumn:
-		data.Primary = w.SkillLevel(&buffer).String()
+		level := w.SkillLevel(&buffer)
+		data.Primary = level.String()
+		if level > 0 {
+			data.Roll = SuccessCellRoll
+		}
 	case WeaponParryColumn:
 		parry := w.Parry.Resolve(w, &buffer)
 		data.Primary = parry.String()
@@ -801,7 +806,13 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 	case WeaponBlockColumn:
 		data.Primary = w.Block.Resolve(w, &buffer).String()
 	case WeaponDamageColumn:
-		data.Primary = w.Damage.ResolvedDamage(&buffer)
+		if breakdown := w.Damage.Breakdown(); breakdown != nil {
+			data.Primary = breakdown.Total
+			data.Tooltip = breakdown.String()
+		} else {
+			data.Primary = w.Damage.ResolvedDamage(&buffer)
+		}
+		data.Roll = DamageCellRoll
 	case WeaponReachColumn:
 		reach := w.Reach.Resolve(w, &buffer)
 		data.Primary = reach.String()
@@ -810,6 +821,16 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 		weaponST := w.Strength.Resolve(w, &buffer)
 		data.Primary = weaponST.String()
 		data.Tooltip = weaponST.Tooltip(w)
+		if req, ok := w.STRequirement(); ok {
+			for _, text := range []string{req.Warning(), req.Handling()} {
+				if text != "" {
+					if data.Tooltip != "" {
+						data.Tooltip += "\n\n"
+					}
+					data.Tooltip += text
+				}
+			}
+		}
 	case WeaponAccColumn:
 		data.Primary = w.Accuracy.Resolve(w, &buffer).String()
 	case WeaponRangeColumn:
@@ -822,6 +843,13 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 		shots := w.Shots.Resolve(w, &buffer)
 		data.Primary = shots.String()
 		data.Tooltip = shots.Tooltip()
+		if remaining, ok := w.RemainingShots(); ok {
+			data.Primary = remaining.String() + "/" + data.Primary
+			if data.Tooltip != "" {
+				data.Tooltip += "\n\n"
+			}
+			data.Tooltip += w.AmmoTooltip()
+		}
 	case WeaponBulkColumn:
 		bulk := w.Bulk.Resolve(w, &buffer)
 		data.Primary = bulk.String()
@@ -864,6 +892,8 @@ func (w *Weapon) Validate() {
 		w.Shots = WeaponShots{}
 		w.Bulk = WeaponBulk{}
 		w.Recoil = WeaponRecoil{}
+		w.AmmoID = ""
+		w.Loaded = 0
 	} else {
 		if w.Accuracy.Jet || w.RateOfFire.Jet {
 			w.Accuracy.Jet = true
@@ -875,6 +905,7 @@ func (w *Weapon) Validate() {
 		w.Shots.Validate()
 		w.Bulk.Validate()
 		w.Recoil.Validate()
+		w.Loaded = w.Loaded.Max(0)
 		w.Parry = WeaponParry{}
 		w.Block = WeaponBlock{}
 		w.Reach = WeaponReach{}
@@ -0,0 +1,141 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// EquipmentByID returns the carried or other equipment with the given ID, or nil.
+func (e *Entity) EquipmentByID(id tid.TID) *Equipment {
+	var found *Equipment
+	f := func(eqp *Equipment) bool {
+		if eqp.TID == id {
+			found = eqp
+			return true
+		}
+		return false
+	}
+	Traverse(f, false, false, e.CarriedEquipment...)
+	if found == nil {
+		Traverse(f, false, false, e.OtherEquipment...)
+	}
+	return found
+}
+
+// AmmoCandidates returns the non-container equipment that could be used as ammunition for a weapon.
+func (e *Entity) AmmoCandidates() []*Equipment {
+	var list []*Equipment
+	f := func(eqp *Equipment) bool {
+		list = append(list, eqp)
+		return false
+	}
+	Traverse(f, false, true, e.CarriedEquipment...)
+	Traverse(f, false, true, e.OtherEquipment...)
+	return list
+}
+
+// Ammo returns the equipment this weapon uses as ammunition, or nil if it has none.
+func (w *Weapon) Ammo() *Equipment {
+	if !w.IsRanged() || w.AmmoID == "" {
+		return nil
+	}
+	if e := w.Entity(); e != nil {
+		return e.EquipmentByID(w.AmmoID)
+	}
+	return nil
+}
+
+// Capacity returns the number of shots the weapon holds when fully loaded, including any in the chamber.
+func (w *Weapon) Capacity() fxp.Int {
+	shots := w.Shots.Resolve(w, nil)
+	if shots.Thrown {
+		return 0
+	}
+	return (shots.Count + shots.InChamber).Max(0)
+}
+
+// ShotsPerAttack returns the number of shots a single attack with the weapon's primary rate of fire expends.
+func (w *Weapon) ShotsPerAttack() fxp.Int {
+	return w.RateOfFire.Resolve(w, nil).Mode1.ShotsPerAttack.Max(fxp.One)
+}
+
+// RemainingShots returns the number of shots that may still be fired. For thrown weapons, this is the quantity of
+// ammunition on hand; for all others, it is the number of shots currently loaded. Returns false if the weapon isn't
+// linked to ammunition.
+func (w *Weapon) RemainingShots() (fxp.Int, bool) {
+	ammo := w.Ammo()
+	if ammo == nil {
+		return 0, false
+	}
+	if w.Shots.Resolve(w, nil).Thrown {
+		return ammo.Quantity, true
+	}
+	return w.Loaded, true
+}
+
+// CanFire returns true if the weapon is linked to ammunition and has shots remaining.
+func (w *Weapon) CanFire() bool {
+	remaining, ok := w.RemainingShots()
+	return ok && remaining > 0
+}
+
+// Fire expends the shots for one attack, limited to what remains, and returns the number of shots fired. Thrown
+// weapons consume their ammunition directly; all others consume loaded shots.
+func (w *Weapon) Fire() fxp.Int {
+	remaining, ok := w.RemainingShots()
+	if !ok || remaining <= 0 {
+		return 0
+	}
+	shots := w.ShotsPerAttack().Min(remaining)
+	if w.Shots.Resolve(w, nil).Thrown {
+		ammo := w.Ammo()
+		ammo.Quantity -= shots
+	} else {
+		w.Loaded -= shots
+	}
+	return shots
+}
+
+// CanReload returns true if the weapon has room for more shots and ammunition to fill it with.
+func (w *Weapon) CanReload() bool {
+	ammo := w.Ammo()
+	return ammo != nil && ammo.Quantity > 0 && w.Loaded < w.Capacity()
+}
+
+// Reload moves as much ammunition as will fit from the linked equipment into the weapon and returns the number of
+// shots loaded.
+func (w *Weapon) Reload() fxp.Int {
+	if !w.CanReload() {
+		return 0
+	}
+	ammo := w.Ammo()
+	amount := (w.Capacity() - w.Loaded).Min(ammo.Quantity).Trunc()
+	ammo.Quantity -= amount
+	w.Loaded += amount
+	return amount
+}
+
+// AmmoTooltip returns a description of the weapon's ammunition state, or an empty string if it isn't linked to any.
+func (w *Weapon) AmmoTooltip() string {
+	ammo := w.Ammo()
+	if ammo == nil {
+		return ""
+	}
+	if w.Shots.Resolve(w, nil).Thrown {
+		return fmt.Sprintf(i18n.Text("%s remaining of %s"), ammo.Quantity.Comma(), ammo.String())
+	}
+	return fmt.Sprintf(i18n.Text("%s of %s shots loaded\n%s remaining of %s"), w.Loaded.Comma(),
+		w.Capacity().Comma(), ammo.Quantity.Comma(), ammo.String())
+}
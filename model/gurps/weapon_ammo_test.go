@@ -0,0 +1,61 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeaponAmmo(t *testing.T) {
+	e := gurps.NewEntity()
+	ammo := gurps.NewEquipment(e, nil, false)
+	ammo.Name = "Bullets"
+	ammo.Quantity = fxp.From(12)
+	gun := gurps.NewEquipment(e, nil, false)
+	gun.Name = "Pistol"
+	w := gurps.NewWeapon(gun, false)
+	w.Shots = gurps.ParseWeaponShots("7+1(3)")
+	w.RateOfFire = gurps.ParseWeaponRoF("3")
+	gun.Weapons = []*gurps.Weapon{w}
+	e.CarriedEquipment = []*gurps.Equipment{gun, ammo}
+
+	_, ok := w.RemainingShots()
+	check.False(t, ok, "not linked to ammo")
+	check.False(t, w.CanFire())
+
+	w.AmmoID = ammo.TID
+	check.Equal(t, fxp.From(8), w.Capacity())
+	check.False(t, w.CanFire(), "nothing loaded yet")
+	check.True(t, w.CanReload())
+	check.Equal(t, fxp.From(8), w.Reload())
+	check.Equal(t, fxp.From(4), ammo.Quantity)
+	check.False(t, w.CanReload(), "already full")
+
+	check.Equal(t, fxp.From(3), w.Fire())
+	check.Equal(t, fxp.From(3), w.Fire())
+	check.Equal(t, fxp.From(2), w.Fire(), "limited to what remains")
+	check.False(t, w.CanFire())
+
+	check.Equal(t, fxp.From(4), w.Reload(), "limited to the ammo on hand")
+	check.Equal(t, fxp.Int(0), ammo.Quantity)
+	check.False(t, w.CanReload())
+
+	w.Shots = gurps.ParseWeaponShots("T(1)")
+	w.RateOfFire = gurps.ParseWeaponRoF("1")
+	ammo.Quantity = fxp.Two
+	remaining, _ := w.RemainingShots()
+	check.Equal(t, fxp.Two, remaining, "thrown weapons use the ammo quantity")
+	check.Equal(t, fxp.One, w.Fire())
+	check.Equal(t, fxp.One, ammo.Quantity)
+}
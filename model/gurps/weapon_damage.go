@@ -185,6 +185,9 @@ func (w *WeaponDamage) String() string {
 
 // DamageTooltip returns a formatted tooltip for the damage.
 func (w *WeaponDamage) DamageTooltip() string {
+	if breakdown := w.Breakdown(); breakdown != nil {
+		return breakdown.String()
+	}
 	var tooltip xio.ByteBuffer
 	w.ResolvedDamage(&tooltip)
 	if tooltip.Len() == 0 {
@@ -195,15 +198,22 @@ func (w *WeaponDamage) DamageTooltip() string {
 
 // BaseDamageDice returns the base damage dice for this weapon (i.e. the dice before any bonuses are applied).
 func (w *WeaponDamage) BaseDamageDice() *dice.Dice {
+	base, _, _, _ := w.baseDamageParts()
+	return base
+}
+
+// baseDamageParts returns the base damage dice for this weapon, along with the parts it was built from: the weapon's
+// own dice, the effective ST used and the dice that ST contributed. The ST and its dice are only returned when the
+// damage is ST-based.
+func (w *WeaponDamage) baseDamageParts() (base, weaponDice *dice.Dice, st fxp.Int, stDamage *dice.Dice) {
 	if w.Owner == nil {
-		return &dice.Dice{Sides: 6, Multiplier: 1}
+		return &dice.Dice{Sides: 6, Multiplier: 1}, nil, 0, nil
 	}
 	entity := w.Owner.Entity()
 	if entity == nil {
-		return &dice.Dice{Sides: 6, Multiplier: 1}
+		return &dice.Dice{Sides: 6, Multiplier: 1}, nil, 0, nil
 	}
 	maxST := w.Owner.Strength.Resolve(w.Owner, nil).Min.Mul(fxp.Three)
-	var st fxp.Int
 	if w.Owner.Owner != nil {
 		st = w.Owner.Owner.RatedStrength()
 	}
@@ -240,7 +250,7 @@ func (w *WeaponDamage) BaseDamageDice() *dice.Dice {
 	if w.StrengthMultiplier > 0 { // Just in case it somehow got set to 0
 		st = st.Mul(w.StrengthMultiplier)
 	}
-	base := &dice.Dice{
+	base = &dice.Dice{
 		Sides:      6,
 		Multiplier: 1,
 	}
@@ -252,20 +262,18 @@ func (w *WeaponDamage) BaseDamageDice() *dice.Dice {
 		multiplyDice(fxp.As[int](t.Levels), base)
 	}
 	intST := fxp.As[int](st)
-	var stDamage *dice.Dice
 	switch w.StrengthType {
 	case stdmg.Thrust, stdmg.LiftingThrust, stdmg.TelekineticThrust:
 		stDamage = entity.ThrustFor(intST)
 	case stdmg.Swing, stdmg.LiftingSwing, stdmg.TelekineticSwing:
 		stDamage = entity.SwingFor(intST)
 	default:
-		return base
+		return base, base, 0, nil
 	}
 	if w.Leveled && t.IsLeveled() {
 		multiplyDice(fxp.As[int](t.Levels), stDamage)
 	}
-	base = addDice(base, stDamage)
-	return base
+	return addDice(base, stDamage), base, st, stDamage
 }
 
 // ResolvedDamage returns the damage, fully resolved for the user's sw or thr, if possible.
@@ -0,0 +1,114 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stdmg"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// DamageBreakdown holds the parts that make up a weapon's resolved damage, so that the total can be checked by hand.
+type DamageBreakdown struct {
+	WeaponDice     string
+	StrengthType   stdmg.Option
+	Strength       fxp.Int
+	StrengthDice   string
+	Base           string
+	ModifierPerDie fxp.Int
+	Bonuses        []*DamageBreakdownBonus
+	Total          string
+}
+
+// DamageBreakdownBonus holds a single bonus that contributes to a weapon's damage.
+type DamageBreakdownBonus struct {
+	Source string
+	Amount string
+	Target string
+}
+
+// Breakdown returns the parts that make up the resolved damage. Returns nil if the weapon has no owning entity or its
+// damage has no dice to resolve.
+func (w *WeaponDamage) Breakdown() *DamageBreakdown {
+	base, weaponDice, st, stDamage := w.baseDamageParts()
+	if weaponDice == nil || (base.Count == 0 && base.Modifier == 0) {
+		return nil
+	}
+	extra := w.Owner.Entity().SheetSettings.UseModifyingDicePlusAdds
+	b := &DamageBreakdown{
+		Base:           base.StringExtra(extra),
+		ModifierPerDie: w.ModifierPerDie,
+		Total:          w.ResolvedDamage(nil),
+	}
+	if stDamage != nil {
+		b.StrengthType = w.StrengthType
+		b.Strength = st
+		b.StrengthDice = stDamage.StringExtra(extra)
+		if weaponDice.Count != 0 || weaponDice.Modifier != 0 {
+			b.WeaponDice = weaponDice.StringExtra(extra)
+		}
+	}
+	for _, bonus := range w.Owner.collectWeaponBonuses(base.Count, nil, feature.WeaponBonus,
+		feature.WeaponDRDivisorBonus, feature.WeaponEffectiveSTBonus) {
+		var target string
+		switch bonus.Type {
+		case feature.WeaponBonus:
+			target = i18n.Text("damage")
+		case feature.WeaponDRDivisorBonus:
+			target = i18n.Text("DR divisor")
+		case feature.WeaponEffectiveSTBonus:
+			target = i18n.Text("effective ST")
+		default:
+			continue
+		}
+		savedLevel := bonus.WeaponLeveledAmount.Level
+		bonus.WeaponLeveledAmount.Level = bonus.DerivedLevel()
+		bonus.AdjustedAmountForWeapon(w.Owner)
+		b.Bonuses = append(b.Bonuses, &DamageBreakdownBonus{
+			Source: bonus.parentName(),
+			Amount: bonus.WeaponLeveledAmount.Format(bonus.Percent),
+			Target: target,
+		})
+		bonus.WeaponLeveledAmount.Level = savedLevel
+	}
+	slices.SortStableFunc(b.Bonuses, func(x, y *DamageBreakdownBonus) int {
+		if result := cmp.Compare(x.Target, y.Target); result != 0 {
+			return result
+		}
+		return cmp.Compare(x.Source, y.Source)
+	})
+	return b
+}
+
+// String returns a multi-line description of the breakdown, suitable for use as a tooltip.
+func (b *DamageBreakdown) String() string {
+	var buffer strings.Builder
+	if b.WeaponDice != "" {
+		fmt.Fprintf(&buffer, i18n.Text("Weapon: %s\n"), b.WeaponDice)
+	}
+	if b.StrengthDice != "" {
+		fmt.Fprintf(&buffer, i18n.Text("ST %s %s: %s\n"), b.Strength.String(), b.StrengthType.String(), b.StrengthDice)
+	}
+	fmt.Fprintf(&buffer, i18n.Text("Base: %s\n"), b.Base)
+	if b.ModifierPerDie != 0 {
+		fmt.Fprintf(&buffer, i18n.Text("Weapon modifier: %s per die\n"), b.ModifierPerDie.StringWithSign())
+	}
+	for _, bonus := range b.Bonuses {
+		fmt.Fprintf(&buffer, i18n.Text("%s: %s to %s\n"), bonus.Source, bonus.Amount, bonus.Target)
+	}
+	fmt.Fprintf(&buffer, i18n.Text("Total: %s"), b.Total)
+	return buffer.String()
+}
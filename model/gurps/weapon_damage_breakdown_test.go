@@ -0,0 +1,59 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stdmg"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wsel"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeaponDamageBreakdown(t *testing.T) {
+	e := NewEntity()
+	eqp := NewEquipment(e, nil, false)
+	eqp.Name = "Sword"
+	eqp.Equipped = true
+	bonus := NewWeaponDamageBonus()
+	bonus.SelectionType = wsel.ThisWeapon
+	bonus.WeaponLeveledAmount.PerDie = true
+	eqp.Features = Features{bonus}
+	w := NewWeapon(eqp, true)
+	w.Damage.Type = "cut"
+	w.Damage.StrengthType = stdmg.Swing
+	w.Damage.Base = &dice.Dice{Sides: 6, Modifier: 1, Multiplier: 1}
+	eqp.Weapons = []*Weapon{w}
+	e.CarriedEquipment = []*Equipment{eqp}
+	e.Recalculate()
+
+	b := w.Damage.Breakdown()
+	check.NotNil(t, b)
+	check.Equal(t, fxp.Ten, b.Strength)
+	check.Equal(t, stdmg.Swing, b.StrengthType)
+	check.Equal(t, "1d", b.StrengthDice)
+	check.Equal(t, "1d+1", b.Base)
+	check.Equal(t, 1, len(b.Bonuses))
+	check.Equal(t, "Sword", b.Bonuses[0].Source)
+	check.Equal(t, "+1 (+1 per die)", b.Bonuses[0].Amount)
+	check.Equal(t, "1d+2 cut", b.Total)
+	check.Equal(t, w.Damage.ResolvedDamage(nil), b.Total)
+	check.True(t, strings.HasSuffix(b.String(), "1d+2 cut"))
+
+	var data CellData
+	w.CellData(WeaponDamageColumn, &data)
+	check.Equal(t, b.Total, data.Primary)
+	check.Equal(t, b.String(), data.Tooltip)
+
+	check.Nil(t, NewWeapon(NewEquipment(nil, nil, false), true).Damage.Breakdown(), "no entity, no breakdown")
+}
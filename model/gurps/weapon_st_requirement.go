@@ -0,0 +1,124 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// WeaponSTRequirement holds the result of comparing a weapon's minimum ST against the ST of its user.
+type WeaponSTRequirement struct {
+	Strength WeaponStrength
+	UserST   fxp.Int
+	// Shortfall is the number of points of ST the user lacks, which is also the penalty applied to weapon skill.
+	Shortfall fxp.Int
+	// OneHanded is true if the weapon can be used with one hand.
+	OneHanded bool
+	// Unready is true if the weapon becomes unready after each attack when used the way OneHanded indicates.
+	Unready bool
+	// NextST is the ST at which the way the weapon can be handled improves, or 0 if it can't.
+	NextST fxp.Int
+}
+
+// STRequirement returns the result of comparing the weapon's minimum ST against its user's ST. Returns false if the
+// weapon has no user or no minimum ST.
+func (w *Weapon) STRequirement() (WeaponSTRequirement, bool) {
+	entity := w.Entity()
+	if entity == nil {
+		return WeaponSTRequirement{}, false
+	}
+	ws := w.Strength.Resolve(w, nil)
+	if ws.Min <= 0 {
+		return WeaponSTRequirement{}, false
+	}
+	req := WeaponSTRequirement{
+		Strength:  ws,
+		UserST:    w.userStrength(entity),
+		OneHanded: true,
+	}
+	req.Shortfall = (ws.Min - req.UserST).Max(0)
+	switch {
+	case ws.TwoHandedUnready:
+		req.OneHanded, req.Unready = false, true
+		twoHandedReady := ws.Min.Mul(fxp.OneAndAHalf).Ceil()
+		oneHanded := ws.Min.Mul(fxp.Three).Ceil()
+		switch {
+		case req.UserST >= oneHanded:
+			req.OneHanded, req.Unready = true, false
+		case req.UserST >= twoHandedReady:
+			req.Unready = false
+			req.NextST = oneHanded
+		default:
+			req.NextST = twoHandedReady
+		}
+	case ws.TwoHanded:
+		req.OneHanded = false
+		oneHandedUnready := ws.Min.Mul(fxp.OneAndAHalf).Ceil()
+		oneHanded := ws.Min.Mul(fxp.Two).Ceil()
+		switch {
+		case req.UserST >= oneHanded:
+			req.OneHanded = true
+		case req.UserST >= oneHandedUnready:
+			req.OneHanded, req.Unready = true, true
+			req.NextST = oneHanded
+		default:
+			req.NextST = oneHandedUnready
+		}
+	}
+	return req, true
+}
+
+// userStrength returns the ST of the user that is compared against the weapon's minimum ST.
+func (w *Weapon) userStrength(e *Entity) fxp.Int {
+	if !w.IsRanged() || (w.Range.MusclePowered && !w.usesCrossbowSkill()) {
+		return e.StrikingStrength()
+	}
+	return e.LiftingStrength()
+}
+
+// Insufficient returns true if the user lacks the ST the weapon requires.
+func (r WeaponSTRequirement) Insufficient() bool {
+	return r.Shortfall > 0
+}
+
+// Warning returns a description of the penalties for insufficient ST, or an empty string if there are none.
+func (r WeaponSTRequirement) Warning() string {
+	if !r.Insufficient() {
+		return ""
+	}
+	return fmt.Sprintf(i18n.Text("Your ST of %v is %v less than the minimum ST of %v, giving -%v to weapon skill and costing 1 extra FP at the end of any fight long enough to cost FP."),
+		r.UserST, r.Shortfall, r.Strength.Min, r.Shortfall)
+}
+
+// Handling returns a description of how the weapon can be held at the user's ST, or an empty string if it has no
+// two-handed requirement.
+func (r WeaponSTRequirement) Handling() string {
+	if !r.Strength.TwoHanded && !r.Strength.TwoHandedUnready {
+		return ""
+	}
+	var text string
+	switch {
+	case r.OneHanded && !r.Unready:
+		text = fmt.Sprintf(i18n.Text("At ST %v, you can use it one-handed with no readiness penalty."), r.UserST)
+	case r.OneHanded:
+		text = fmt.Sprintf(i18n.Text("At ST %v, you can use it one-handed, but it becomes unready after you attack with it."), r.UserST)
+	case r.Unready:
+		text = fmt.Sprintf(i18n.Text("At ST %v, it requires two hands and becomes unready after you attack with it."), r.UserST)
+	default:
+		text = fmt.Sprintf(i18n.Text("At ST %v, it requires two hands."), r.UserST)
+	}
+	if r.NextST > 0 {
+		text += " " + fmt.Sprintf(i18n.Text("ST %v improves this."), r.NextST)
+	}
+	return text
+}
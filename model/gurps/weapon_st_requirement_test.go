@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeaponSTRequirement(t *testing.T) {
+	e := NewEntity()
+	eqp := NewEquipment(e, nil, false)
+	eqp.Equipped = true
+	w := NewWeapon(eqp, true)
+	w.Strength = ParseWeaponStrength("12†")
+	eqp.Weapons = []*Weapon{w}
+	e.CarriedEquipment = []*Equipment{eqp}
+	e.Recalculate()
+
+	req, ok := w.STRequirement()
+	check.True(t, ok)
+	check.True(t, req.Insufficient())
+	check.Equal(t, fxp.Two, req.Shortfall)
+	check.False(t, req.OneHanded)
+	check.Equal(t, fxp.From(18), req.NextST)
+	var data CellData
+	w.CellData(WeaponDescriptionColumn, &data)
+	check.NotEqual(t, "", data.Warning)
+
+	e.Attributes.Set[StrengthID].SetMaximum(fxp.From(24))
+	e.Recalculate()
+	req, ok = w.STRequirement()
+	check.True(t, ok)
+	check.False(t, req.Insufficient())
+	check.True(t, req.OneHanded)
+	check.False(t, req.Unready)
+	check.Equal(t, "", req.Warning())
+	data = CellData{}
+	w.CellData(WeaponDescriptionColumn, &data)
+	check.Equal(t, "", data.Warning)
+}
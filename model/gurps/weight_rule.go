@@ -0,0 +1,76 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wtcond"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// WeightRule adjusts how much of a piece of equipment's own weight counts, based on how it is being carried.
+type WeightRule struct {
+	Condition  wtcond.Condition `json:"condition"`
+	Percentage fxp.Int          `json:"percentage"`
+}
+
+// CloneWeightRules creates a clone of the provided WeightRule list.
+func CloneWeightRules(list []*WeightRule) []*WeightRule {
+	if len(list) == 0 {
+		return nil
+	}
+	clone := make([]*WeightRule, len(list))
+	for i, one := range list {
+		rule := *one
+		clone[i] = &rule
+	}
+	return clone
+}
+
+// Applies returns true if this rule applies to the equipment.
+func (w *WeightRule) Applies(equipment *Equipment) bool {
+	if equipment == nil {
+		return false
+	}
+	switch w.Condition {
+	case wtcond.Equipped:
+		return equipment.Equipped
+	case wtcond.NotEquipped:
+		return !equipment.Equipped
+	case wtcond.Contained:
+		return equipment.parent != nil
+	default:
+		return false
+	}
+}
+
+func (w *WeightRule) String() string {
+	return fmt.Sprintf(i18n.Text("Weight counts at %s%% %s"), w.Percentage.String(), w.Condition.String())
+}
+
+// Hash writes this object's contents into the hasher.
+func (w *WeightRule) Hash(h hash.Hash) {
+	_ = binary.Write(h, binary.LittleEndian, w.Condition)
+	_ = binary.Write(h, binary.LittleEndian, w.Percentage)
+}
+
+// ApplyWeightRules returns the weight after applying the rules which apply to the equipment.
+func ApplyWeightRules(equipment *Equipment, weight fxp.Weight, rules []*WeightRule) fxp.Weight {
+	for _, rule := range rules {
+		if rule.Applies(equipment) {
+			weight = fxp.Weight(fxp.Int(weight).Mul(rule.Percentage.Max(0)).Div(fxp.Hundred))
+		}
+	}
+	return weight
+}
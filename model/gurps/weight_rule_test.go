@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wtcond"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeightRules(t *testing.T) {
+	e := NewEntity()
+	pack := NewEquipment(e, nil, true)
+	pack.Weight = fxp.Weight(fxp.Ten)
+	armor := NewEquipment(e, nil, false)
+	armor.Weight = fxp.Weight(fxp.Twenty)
+	armor.WeightRules = []*WeightRule{{Condition: wtcond.Equipped, Percentage: fxp.Fifty}}
+	units := fxp.Pound
+
+	armor.Equipped = true
+	check.Equal(t, fxp.Weight(fxp.Ten), armor.ExtendedWeight(false, units), "worn armor counts at half")
+	armor.Equipped = false
+	check.Equal(t, fxp.Weight(fxp.Twenty), armor.ExtendedWeight(false, units), "carried armor counts in full")
+
+	armor.WeightRules = []*WeightRule{{Condition: wtcond.Contained, Percentage: 0}}
+	check.Equal(t, fxp.Weight(fxp.Twenty), armor.ExtendedWeight(false, units), "not yet inside a container")
+	pack.Children = []*Equipment{armor}
+	armor.SetParent(pack)
+	check.Equal(t, fxp.Weight(fxp.Ten), pack.ExtendedWeight(false, units), "contents are negligible once stowed")
+}
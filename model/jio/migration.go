@@ -0,0 +1,182 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package jio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// Migration upgrades the raw JSON of a data file to a particular version. Migrations are applied in version order,
+// before the data is loaded into its Go types, so they can handle structural changes that the types themselves can no
+// longer decode.
+type Migration struct {
+	// Version is the data version this migration upgrades to.
+	Version int
+	// Description is a short, human-readable summary of the changes made.
+	Description string
+	// Apply performs the migration on the decoded top-level JSON object.
+	Apply func(data map[string]any) error
+}
+
+var (
+	migrationLock sync.RWMutex
+	migrations    []*Migration
+)
+
+// RegisterMigration registers a migration. Migrations for versions newer than CurrentDataVersion are ignored.
+func RegisterMigration(m *Migration) {
+	migrationLock.Lock()
+	defer migrationLock.Unlock()
+	migrations = append(migrations, m)
+	slices.SortStableFunc(migrations, func(a, b *Migration) int { return a.Version - b.Version })
+}
+
+// MigrationsFor returns the registered migrations that apply when upgrading from the given version to
+// CurrentDataVersion.
+func MigrationsFor(fromVersion int) []*Migration {
+	migrationLock.RLock()
+	defer migrationLock.RUnlock()
+	var list []*Migration
+	for _, m := range migrations {
+		if m.Version > fromVersion && m.Version <= CurrentDataVersion {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+// DataVersion returns the value of the top-level "version" field in the JSON data, or 0 if there isn't one.
+func DataVersion(data []byte) int {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0
+	}
+	return header.Version
+}
+
+// ApplyMigrations applies any registered migrations that are needed to bring the JSON data up to CurrentDataVersion.
+// If none are needed, or the data is older than MinimumDataVersion, the original data is returned.
+func ApplyMigrations(data []byte) (result []byte, applied []*Migration, err error) {
+	version := DataVersion(data)
+	if version < MinimumDataVersion {
+		// Too old (or not versioned at all), so leave it to the loader to report
+		return data, nil, nil
+	}
+	applicable := MigrationsFor(version)
+	if len(applicable) == 0 {
+		return data, nil, nil
+	}
+	var m map[string]any
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, nil, errs.NewWithCause("unable to decode data for migration", err)
+	}
+	for _, one := range applicable {
+		if err = one.Apply(m); err != nil {
+			return nil, nil, errs.NewWithCause(fmt.Sprintf("migration to version %d failed", one.Version), err)
+		}
+		m["version"] = one.Version
+	}
+	var buffer bytes.Buffer
+	if err = Save(context.Background(), &buffer, m); err != nil {
+		return nil, nil, err
+	}
+	return buffer.Bytes(), applicable, nil
+}
+
+// DiffJSON compares two JSON documents and returns the paths of the fields that were added, removed or changed, e.g.
+// "rows[].calc.points". Array indexes are collapsed so that the same change across many rows is reported once. At most
+// limit entries are returned; if there were more, a final entry noting how many were omitted is added.
+func DiffJSON(before, after []byte, limit int) []string {
+	var a, b any
+	if err := json.Unmarshal(before, &a); err != nil {
+		return []string{"unreadable original data"}
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return []string{"unreadable migrated data"}
+	}
+	changes := make(map[string]string)
+	diffJSON("", a, b, changes)
+	keys := make([]string, 0, len(changes))
+	for k := range changes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]string, 0, min(len(keys), limit)+1)
+	for i, k := range keys {
+		if i == limit {
+			list = append(list, fmt.Sprintf("…and %d more", len(keys)-limit))
+			break
+		}
+		list = append(list, changes[k]+" "+k)
+	}
+	return list
+}
+
+func diffJSON(path string, a, b any, changes map[string]string) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			for k, v := range av {
+				if other, exists := bv[k]; exists {
+					diffJSON(joinJSONPath(path, k), v, other, changes)
+				} else {
+					recordJSONChange(joinJSONPath(path, k), "removed", changes)
+				}
+			}
+			for k := range bv {
+				if _, exists := av[k]; !exists {
+					recordJSONChange(joinJSONPath(path, k), "added", changes)
+				}
+			}
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			for i := 0; i < min(len(av), len(bv)); i++ {
+				diffJSON(path+"[]", av[i], bv[i], changes)
+			}
+			if len(av) != len(bv) {
+				recordJSONChange(path, "resized", changes)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		recordJSONChange(path, "changed", changes)
+	}
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func recordJSONChange(path, kind string, changes map[string]string) {
+	if path == "" {
+		path = "(root)"
+	}
+	if existing, ok := changes[path]; ok && existing != kind {
+		kind = "changed"
+	}
+	changes[path] = kind
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package jio
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestDiffJSON(t *testing.T) {
+	before := []byte(`{"version":4,"rows":[{"name":"a","calc":{"points":1}},{"name":"b","calc":{"points":2}}],"old":true}`)
+	after := []byte(`{"version":5,"rows":[{"name":"a","calc":{"points":3}},{"name":"b","calc":{"points":4}}],"new":1}`)
+	check.Equal(t, []string{
+		"added new",
+		"removed old",
+		"changed rows[].calc.points",
+		"changed version",
+	}, DiffJSON(before, after, 10))
+	check.Equal(t, []string{"added new", "…and 3 more"}, DiffJSON(before, after, 1))
+	check.Equal(t, 0, len(DiffJSON(before, before, 10)))
+}
+
+func TestDataVersion(t *testing.T) {
+	check.Equal(t, 4, DataVersion([]byte(`{"version":4,"rows":[]}`)))
+	check.Equal(t, 0, DataVersion([]byte(`[]`)))
+}
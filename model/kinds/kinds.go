@@ -12,6 +12,8 @@ package kinds
 // The various kinds of nodes
 const (
 	Campaign                   = 'C'
+	Combat                     = 'K'
+	Combatant                  = 'k'
 	ConditionalModifier        = 'c'
 	Entity                     = 'A'
 	Equipment                  = 'e'
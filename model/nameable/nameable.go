@@ -9,7 +9,14 @@
 
 package nameable
 
-import "strings"
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// VariablePrefix is the prefix of keys that are replaced as-is, rather than only when surrounded by '@'.
+const VariablePrefix = "$"
 
 // Filler defines the method for filling the nameable key map.
 type Filler interface {
@@ -52,7 +59,22 @@ func Extract(str string, m, existing map[string]string) {
 func Apply(str string, m map[string]string) string {
 	if strings.Count(str, "@") > 1 {
 		for k, v := range m {
-			str = strings.ReplaceAll(str, "@"+k+"@", v)
+			if !strings.HasPrefix(k, VariablePrefix) {
+				str = strings.ReplaceAll(str, "@"+k+"@", v)
+			}
+		}
+	}
+	if strings.Contains(str, VariablePrefix) {
+		var keys []string
+		for k := range m {
+			if strings.HasPrefix(k, VariablePrefix) {
+				keys = append(keys, k)
+			}
+		}
+		// Replace the longest keys first, so that $a doesn't clobber $ab.
+		slices.SortFunc(keys, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+		for _, k := range keys {
+			str = strings.ReplaceAll(str, k, m[k])
 		}
 	}
 	return str
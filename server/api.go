@@ -0,0 +1,264 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio/network/xhttp"
+)
+
+// LibraryInfo describes a library available through the API.
+type LibraryInfo struct {
+	Key     string `json:"key"`
+	Title   string `json:"title"`
+	Version string `json:"version,omitempty"`
+}
+
+// ExportTemplateInfo describes a user-registered export template available through the API.
+type ExportTemplateInfo struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	MimeType  string `json:"mime_type,omitempty"`
+}
+
+type templateRequest struct {
+	Library string `json:"library"`
+	Path    string `json:"path"`
+}
+
+// installAPIHandlers installs the handlers intended for use by external tools rather than the web front end. Sheets
+// are addressed the same way as they are for the front end, i.e. by access list key followed by the path within it.
+func (s *Server) installAPIHandlers() {
+	s.mux.HandleFunc("GET /api/v1/entity/{path...}", s.entityHandler)
+	s.mux.HandleFunc("POST /api/v1/recalculate/{path...}", s.recalculateHandler)
+	s.mux.HandleFunc("POST /api/v1/apply-template/{path...}", s.applyTemplateHandler)
+	s.mux.HandleFunc("GET /api/v1/libraries", s.librariesHandler)
+	s.mux.HandleFunc("GET /api/v1/library/{account}/{repo}/{path...}", s.libraryFileHandler)
+	s.mux.HandleFunc("GET /api/v1/export-templates", s.exportTemplatesHandler)
+	s.mux.HandleFunc("GET /api/v1/export/{template}/{path...}", s.exportHandler)
+	s.mux.HandleFunc("GET /api/v1/events", s.eventsHandler)
+}
+
+func (s *Server) entityHandler(w http.ResponseWriter, r *http.Request) {
+	entity, _, ok := s.loadSheet(w, r)
+	if !ok {
+		return
+	}
+	JSONResponse(w, http.StatusOK, entity.Entity)
+}
+
+func (s *Server) recalculateHandler(w http.ResponseWriter, r *http.Request) {
+	entity, access, ok := s.loadSheet(w, r)
+	if !ok {
+		return
+	}
+	if access.ReadOnly {
+		xhttp.ErrorStatus(w, http.StatusForbidden)
+		return
+	}
+	entity.Entity.Recalculate()
+	s.storeEntity(&entity)
+	JSONResponse(w, http.StatusOK, entity.Entity)
+}
+
+func (s *Server) applyTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	entity, access, ok := s.loadSheet(w, r)
+	if !ok {
+		return
+	}
+	if access.ReadOnly {
+		xhttp.ErrorStatus(w, http.StatusForbidden)
+		return
+	}
+	var req templateRequest
+	if err := JSONFromRequest(r, &req); err != nil {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	lib := gurps.GlobalSettings().Libraries()[req.Library]
+	if lib == nil || !fs.ValidPath(req.Path) || !strings.EqualFold(path.Ext(req.Path), gurps.TemplatesExt) {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	tmpl, err := gurps.NewTemplateFromFile(os.DirFS(lib.PathOnDisk), req.Path)
+	if err != nil {
+		slog.Error("error loading template", "library", req.Library, "path", req.Path, "error", err)
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	tmpl.ApplyTo(entity.Entity, gurps.LibraryFile{Library: req.Library, Path: req.Path})
+	entity.Entity.ModifiedOn = jio.Now()
+	s.storeEntity(&entity)
+	slog.Info("applied template", "path", entity.ClientPath, "library", req.Library, "template", req.Path)
+	JSONResponse(w, http.StatusOK, entity.Entity)
+}
+
+func (s *Server) librariesHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := sessionFromRequest(r); !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	libs := gurps.GlobalSettings().Libraries().List()
+	rsp := make([]LibraryInfo, 0, len(libs))
+	for _, lib := range libs {
+		rsp = append(rsp, LibraryInfo{
+			Key:     lib.Key(),
+			Title:   lib.Title,
+			Version: lib.VersionOnDisk(),
+		})
+	}
+	JSONResponse(w, http.StatusOK, rsp)
+}
+
+// libraryFileHandler returns the raw contents of a library file, or a listing of the directory's immediate contents
+// if the path refers to a directory.
+func (s *Server) libraryFileHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := sessionFromRequest(r); !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	lib := gurps.GlobalSettings().Libraries()[r.PathValue("account")+"/"+r.PathValue("repo")]
+	if lib == nil {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	filePath := strings.TrimSuffix(r.PathValue("path"), "/")
+	if filePath == "" {
+		filePath = "."
+	}
+	if !fs.ValidPath(filePath) {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	fileSystem := os.DirFS(lib.PathOnDisk)
+	fi, err := fs.Stat(fileSystem, filePath)
+	if err != nil {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	if !fi.IsDir() {
+		http.ServeFileFS(w, r, fileSystem, filePath)
+		return
+	}
+	entries, err := fs.ReadDir(fileSystem, filePath)
+	if err != nil {
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	dir := &Dir{Name: path.Base(filePath)}
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case strings.HasPrefix(name, "."):
+		case entry.IsDir():
+			dir.Dirs = append(dir.Dirs, &Dir{Name: name})
+		default:
+			dir.Files = append(dir.Files, name)
+		}
+	}
+	slices.SortFunc(dir.Files, func(a, b string) int { return txt.NaturalCmp(a, b, true) })
+	JSONResponse(w, http.StatusOK, dir)
+}
+
+func (s *Server) exportTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := sessionFromRequest(r); !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	templates := gurps.GlobalSettings().ExportTemplates
+	rsp := make([]ExportTemplateInfo, 0, len(templates))
+	for i, one := range templates {
+		rsp = append(rsp, ExportTemplateInfo{
+			Index:     i,
+			Name:      one.Title(),
+			Extension: one.OutputExtension(),
+			MimeType:  one.OutputMimeType(),
+		})
+	}
+	JSONResponse(w, http.StatusOK, rsp)
+}
+
+// exportHandler returns the sheet as exported by one of the user-registered export templates, identified by its index
+// in the list returned by exportTemplatesHandler.
+func (s *Server) exportHandler(w http.ResponseWriter, r *http.Request) {
+	entity, _, ok := s.loadSheet(w, r)
+	if !ok {
+		return
+	}
+	templates := gurps.GlobalSettings().ExportTemplates
+	index, err := strconv.Atoi(r.PathValue("template"))
+	if err != nil || index < 0 || index >= len(templates) {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	tmpl := templates[index]
+	var f *os.File
+	if f, err = os.CreateTemp("", "export-*"+tmpl.OutputExtension()); err != nil {
+		slog.Error("unable to create temporary export file", "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	tmpPath := f.Name()
+	defer func() {
+		if removeErr := os.Remove(tmpPath); removeErr != nil {
+			slog.Error("unable to remove temporary export file", "path", tmpPath, "error", removeErr)
+		}
+	}()
+	if err = f.Close(); err != nil {
+		slog.Error("unable to close temporary export file", "path", tmpPath, "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	if err = gurps.ExportWithOptions(entity.Entity, tmpl.Path, tmpPath, gurps.HTMLExportOptions{}); err != nil {
+		slog.Error("export failed", "template", tmpl.Path, "path", entity.ClientPath, "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	var data []byte
+	if data, err = os.ReadFile(tmpPath); err != nil {
+		slog.Error("unable to read exported file", "path", tmpPath, "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	if mimeType := tmpl.OutputMimeType(); mimeType != "" {
+		w.Header().Set("Content-Type", mimeType)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": strings.TrimSuffix(path.Base(filepath.ToSlash(entity.ClientPath)), gurps.SheetExt) +
+			tmpl.OutputExtension(),
+	}))
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(data); err != nil {
+		slog.Error("unable to write export response", "error", err)
+	}
+}
+
+func (s *Server) storeEntity(entity *webEntity) {
+	entity.CurrentCRC64 = entity.Entity.CRC64()
+	s.sheetsLock.Lock()
+	s.entitiesByPath[entity.ClientPath] = *entity
+	events := entity.Events.Update(entity.Entity, entity.ClientPath)
+	s.sheetsLock.Unlock()
+	gurps.PublishEntityEvents(events...)
+}
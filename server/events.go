@@ -0,0 +1,107 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/xio/network/xhttp"
+)
+
+const (
+	eventStreamBufferSize = 64
+	eventStreamKeepAlive  = 30 * time.Second
+)
+
+// eventsHandler streams entity events to the client as server-sent events. Only events for sheets within the
+// directories the user has been granted access to are sent, and their paths are rewritten to match the way sheets are
+// addressed by the rest of the API.
+func (s *Server) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	_, userName, ok := sessionFromRequest(r)
+	if !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Error("unable to clear write deadline for event stream", "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	events := make(chan *gurps.EntityEvent, eventStreamBufferSize)
+	sub := gurps.SubscribeToEntityEvents(func(event *gurps.EntityEvent) {
+		select {
+		case events <- event:
+		default:
+			slog.Warn("dropped entity event for slow event stream", "user", userName, "kind", event.Kind)
+		}
+	})
+	defer sub.Unsubscribe()
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	ticker := time.NewTicker(eventStreamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			clientPath, visible := clientPathForUser(userName, event.Path)
+			if !visible {
+				continue
+			}
+			other := *event
+			other.Path = clientPath
+			data, err := json.Marshal(&other)
+			if err != nil {
+				slog.Error("unable to encode entity event", "error", err)
+				continue
+			}
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", other.Kind, data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// clientPathForUser returns the path by which the user addresses the sheet at the given path on disk, i.e. the access
+// list key followed by the path within it. Returns false if the user doesn't have access to it.
+func clientPathForUser(userName, filePath string) (string, bool) {
+	if filePath == "" {
+		return "", false
+	}
+	for key, access := range gurps.GlobalSettings().WebServer.AccessList(userName) {
+		rel, err := filepath.Rel(access.Dir, filePath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return key + "/" + filepath.ToSlash(rel), true
+	}
+	return "", false
+}
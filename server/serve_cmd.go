@@ -0,0 +1,52 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/atexit"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+var _ cmdline.Cmd = &ServeCmd{}
+
+// ServeCmd is the "serve" command-line sub-command, which runs the web server, including the API used by external
+// tools, without bringing up the user interface. Unlike --web-server-only, it runs even if the web server hasn't been
+// enabled in the settings, since asking for it is explicit.
+type ServeCmd struct{}
+
+// Name implements cmdline.Cmd.
+func (c *ServeCmd) Name() string {
+	return "serve"
+}
+
+// Usage implements cmdline.Cmd.
+func (c *ServeCmd) Usage() string {
+	return i18n.Text("Runs the web server and its API for external tools without bringing up the user interface")
+}
+
+// Run implements cmdline.Cmd.
+func (c *ServeCmd) Run(cl *cmdline.CmdLine, args []string) error {
+	cl.Description = c.Usage()
+	if remaining := cl.Parse(args); len(remaining) != 0 {
+		return errs.Newf(i18n.Text("Unexpected arguments: %v"), remaining)
+	}
+	Start(func(err error) {
+		fmt.Fprintln(os.Stderr, err)
+		atexit.Exit(1)
+	})
+	fmt.Printf(i18n.Text("Serving on %s\n"), gurps.GlobalSettings().WebServer.Address)
+	select {}
+}
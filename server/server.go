@@ -77,6 +77,7 @@ func Start(errorCallback func(error)) {
 	s.installPageRefHandlers()
 	s.installSessionHandlers()
 	s.installSheetHandlers()
+	s.installAPIHandlers()
 	s.mux.Handle("GET /", statigz.FileServer(siteFS, statigz.FSPrefix("frontend/dist"), statigz.EncodeOnInit))
 	s.mux.Handle("GET /pdf/", statigz.FileServer(pdfFS, statigz.EncodeOnInit))
 
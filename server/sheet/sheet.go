@@ -21,7 +21,6 @@ import (
 	"github.com/richardwilkes/gcs/v5/ux"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
-	"github.com/richardwilkes/toolbox/xio"
 )
 
 // Identity holds the data needed by the frontend to display the identity block.
@@ -262,16 +261,14 @@ func createHitLocations(entity *gurps.Entity, locations *gurps.Body, startID int
 		if rollRange == "-" {
 			rollRange = ""
 		}
-		var detail xio.ByteBuffer
-		dr := loc.DisplayDR(entity, &detail)
 		one := HitLocation{
 			ID:             startID,
 			Roll:           rollRange,
 			Location:       loc.TableName,
 			LocationDetail: loc.Description,
 			HitPenalty:     strconv.Itoa(loc.HitPenalty),
-			DR:             dr,
-			DRDetail:       fmt.Sprintf(i18n.Text("The DR covering the %s hit location%s"), loc.TableName, detail.String()),
+			DR:             loc.DisplayDR(entity, nil),
+			DRDetail:       fmt.Sprintf(i18n.Text("The DR covering the %s hit location%s"), loc.TableName, loc.DRTooltip(entity)),
 			Notes:          loc.Notes,
 		}
 		startID++
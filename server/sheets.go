@@ -40,6 +40,7 @@ type webEntity struct {
 	Entity        *gurps.Entity
 	OriginalCRC64 uint64
 	CurrentCRC64  uint64
+	Events        *gurps.EntityEventTracker
 }
 
 // Dir is a directory listing.
@@ -178,6 +179,7 @@ func (s *Server) saveSheetHandler(w http.ResponseWriter, r *http.Request) {
 		s.sheetsLock.Lock()
 		s.entitiesByPath[entity.ClientPath] = entity
 		s.sheetsLock.Unlock()
+		gurps.PublishEntityEvents(gurps.NewFileSavedEntityEvent(entity.Entity, entity.ClientPath))
 	}
 	response := sheet.NewSheetFromEntity(entity.Entity, entity.OriginalCRC64 != entity.CurrentCRC64, access.ReadOnly)
 	CompressedJSONResponse(w, http.StatusOK, response)
@@ -342,10 +344,7 @@ func (s *Server) updateFieldText(entity *webEntity, update *sheetUpdate) error {
 		}
 	}
 	entity.Entity.ModifiedOn = jio.Now()
-	entity.CurrentCRC64 = entity.Entity.CRC64()
-	s.sheetsLock.Lock()
-	s.entitiesByPath[entity.ClientPath] = *entity
-	s.sheetsLock.Unlock()
+	s.storeEntity(entity)
 	slog.Info("updated sheet", "path", entity.ClientPath, "field", update.Key, "text", update.Data)
 	return nil
 }
@@ -370,10 +369,7 @@ func (s *Server) updateFieldBinary(entity *webEntity, update *sheetUpdate) error
 		return errs.Newf("unknown field key: %q", update.Key)
 	}
 	entity.Entity.ModifiedOn = jio.Now()
-	entity.CurrentCRC64 = entity.Entity.CRC64()
-	s.sheetsLock.Lock()
-	s.entitiesByPath[entity.ClientPath] = *entity
-	s.sheetsLock.Unlock()
+	s.storeEntity(entity)
 	slog.Info("updated sheet", "path", entity.ClientPath, "field", update.Key)
 	return nil
 }
@@ -385,7 +381,7 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 		return entity, access, false
 	}
 	accessList := gurps.GlobalSettings().WebServer.AccessList(userName)
-	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/sheet/"), "/", 2)
+	parts := strings.SplitN(r.PathValue("path"), "/", 2)
 	if len(parts) != 2 {
 		xhttp.ErrorStatus(w, http.StatusBadRequest)
 		return entity, access, false
@@ -417,6 +413,8 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 			entity.Entity = loadedEntity
 			entity.OriginalCRC64 = loadedEntity.CRC64()
 			entity.CurrentCRC64 = entity.OriginalCRC64
+			entity.Events = &gurps.EntityEventTracker{}
+			entity.Events.Update(loadedEntity, entityPath)
 			s.entitiesByPath[entityPath] = entity
 		}
 		s.sheetsLock.Unlock()
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
 	"github.com/richardwilkes/toolbox/cmdline"
 	"github.com/richardwilkes/toolbox/desktop"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -29,47 +30,77 @@ var licenseMarkdownContent string
 
 // These actions are registered for key bindings.
 var (
-	addNaturalAttacksAction        *unison.Action
-	applyTemplateAction            *unison.Action
-	clearPortraitAction            *unison.Action
-	clearSourceAction              *unison.Action
-	closeTabAction                 *unison.Action
-	colorSettingsAction            *unison.Action
-	convertToContainerAction       *unison.Action
-	convertToNonContainerAction    *unison.Action
-	copyToSheetAction              *unison.Action
-	copyToTemplateAction           *unison.Action
-	decreaseEquipmentLevelAction   *unison.Action
-	decreaseSkillLevelAction       *unison.Action
-	decreaseTechLevelAction        *unison.Action
-	decreaseUsesAction             *unison.Action
-	decrementAction                *unison.Action
-	defaultAttributeSettingsAction *unison.Action
-	defaultBodyTypeSettingsAction  *unison.Action
-	defaultSheetSettingsAction     *unison.Action
-	dockUnDockAction               *unison.Action
-	duplicateAction                *unison.Action
-	exportAsJPEGAction             *unison.Action
-	exportAsPDFAction              *unison.Action
-	exportAsPNGAction              *unison.Action
-	exportAsWEBPAction             *unison.Action
-	fontSettingsAction             *unison.Action
-	generalSettingsAction          *unison.Action
-	increaseEquipmentLevelAction   *unison.Action
-	increaseSkillLevelAction       *unison.Action
-	increaseTechLevelAction        *unison.Action
-	increaseUsesAction             *unison.Action
-	incrementAction                *unison.Action
-	jumpToSearchFilterAction       *unison.Action
-	menuKeySettingsAction          *unison.Action
-	moveToCarriedEquipmentAction   *unison.Action
-	moveToOtherEquipmentAction     *unison.Action
-	// TODO: Re-enable Campaign files
-	// newCampaignAction                   *unison.Action
+	addNaturalAttacksAction             *unison.Action
+	applyFavoriteModifierAction         *unison.Action
+	applyQualityPresetAction            *unison.Action
+	applyTemplateAction                 *unison.Action
+	bundleIntoKitAction                 *unison.Action
+	castSpellAction                     *unison.Action
+	checkTemplateUpdatesAction          *unison.Action
+	clearPortraitAction                 *unison.Action
+	clearSourceAction                   *unison.Action
+	closeTabAction                      *unison.Action
+	colorSettingsAction                 *unison.Action
+	compareSideBySideAction             *unison.Action
+	convertToContainerAction            *unison.Action
+	convertToNonContainerAction         *unison.Action
+	copyListToSheetAction               *unison.Action
+	copyToSheetAction                   *unison.Action
+	copyToTemplateAction                *unison.Action
+	decreaseEquipmentLevelAction        *unison.Action
+	decreaseSkillLevelAction            *unison.Action
+	decreaseTechLevelAction             *unison.Action
+	decreaseUsesAction                  *unison.Action
+	decrementAction                     *unison.Action
+	defaultAttributeSettingsAction      *unison.Action
+	defaultBodyTypeSettingsAction       *unison.Action
+	defaultSheetSettingsAction          *unison.Action
+	diceRollerAction                    *unison.Action
+	dockUnDockAction                    *unison.Action
+	duplicateAction                     *unison.Action
+	exportAllOpenSheetsAsPDFAction      *unison.Action
+	exportAsCSVAction                   *unison.Action
+	exportItemCardAction                *unison.Action
+	exportAsFantasyGroundsAction        *unison.Action
+	exportAsForumPostAction             *unison.Action
+	exportAsFoundryAction               *unison.Action
+	exportAsJPEGAction                  *unison.Action
+	exportAsPDFAction                   *unison.Action
+	exportAsPDFWithLayoutAction         *unison.Action
+	exportAsPNGAction                   *unison.Action
+	exportAsRoll20Action                *unison.Action
+	exportAsStatBlockAction             *unison.Action
+	exportAsWEBPAction                  *unison.Action
+	exportFolderAsPDFAction             *unison.Action
+	exportGMSummaryAction               *unison.Action
+	exportLibraryBundleAction           *unison.Action
+	exportDependencyBundleAction        *unison.Action
+	exportNPCCardsAction                *unison.Action
+	exportTemplatesAction               *unison.Action
+	fireWeaponAction                    *unison.Action
+	fontSettingsAction                  *unison.Action
+	generalSettingsAction               *unison.Action
+	gmScreenAction                      *unison.Action
+	increaseEquipmentLevelAction        *unison.Action
+	increaseSkillLevelAction            *unison.Action
+	increaseTechLevelAction             *unison.Action
+	increaseUsesAction                  *unison.Action
+	importFoundryActorAction            *unison.Action
+	importLibraryBundleAction           *unison.Action
+	incrementAction                     *unison.Action
+	jumpToSearchFilterAction            *unison.Action
+	menuKeySettingsAction               *unison.Action
+	mergeFromFileAction                 *unison.Action
+	moveToCarriedEquipmentAction        *unison.Action
+	moveToOtherEquipmentAction          *unison.Action
+	nameGeneratorAction                 *unison.Action
+	newCampaignAction                   *unison.Action
 	newCarriedEquipmentAction           *unison.Action
 	newCarriedEquipmentContainerAction  *unison.Action
 	newCharacterSheetAction             *unison.Action
 	newCharacterTemplateAction          *unison.Action
+	newCombatTrackerAction              *unison.Action
+	newCharacterWizardAction            *unison.Action
 	newEquipmentContainerModifierAction *unison.Action
 	newEquipmentLibraryAction           *unison.Action
 	newEquipmentModifierAction          *unison.Action
@@ -78,6 +109,7 @@ var (
 	newMeleeWeaponAction                *unison.Action
 	newNoteAction                       *unison.Action
 	newNoteContainerAction              *unison.Action
+	newNoteFromTemplateAction           *unison.Action
 	newNotesLibraryAction               *unison.Action
 	newOtherEquipmentAction             *unison.Action
 	newOtherEquipmentContainerAction    *unison.Action
@@ -102,13 +134,39 @@ var (
 	openEditorAction                    *unison.Action
 	openOnePageReferenceAction          *unison.Action
 	pageRefMappingsAction               *unison.Action
+	pasteStatBlockAction                *unison.Action
+	perSheetAlternateFormAction         *unison.Action
+	perSheetApplyDamageAction           *unison.Action
+	perSheetArmorTableAction            *unison.Action
+	perSheetAssociatesAction            *unison.Action
 	perSheetAttributeSettingsAction     *unison.Action
+	perSheetCompanionsAction            *unison.Action
 	perSheetBodyTypeSettingsAction      *unison.Action
+	perSheetDeathAndDyingAction         *unison.Action
+	perSheetGrimoireAction              *unison.Action
+	perSheetHistoryAction               *unison.Action
+	perSheetLanguagesAction             *unison.Action
+	perSheetNotesJournalAction          *unison.Action
+	perSheetPointsJournalAction         *unison.Action
+	perSheetPowersAction                *unison.Action
+	perSheetRollModifiersAction         *unison.Action
+	perSheetReputationsAction           *unison.Action
+	perSheetSessionTimerAction          *unison.Action
 	perSheetSettingsAction              *unison.Action
+	perSheetTimelineAction              *unison.Action
+	perSheetVariablesAction             *unison.Action
 	printAction                         *unison.Action
+	quickOpenAction                     *unison.Action
+	quickReferenceAction                *unison.Action
+	rechargeDailyUsesAction             *unison.Action
+	rechargeSessionUsesAction           *unison.Action
 	redoAction                          *unison.Action
+	referenceSearchAction               *unison.Action
+	reloadWeaponAction                  *unison.Action
+	rerollAncestryAction                *unison.Action
 	saveAction                          *unison.Action
 	saveAsAction                        *unison.Action
+	saveLoadoutAction                   *unison.Action
 	scale100Action                      *unison.Action
 	scale200Action                      *unison.Action
 	scale25Action                       *unison.Action
@@ -123,6 +181,11 @@ var (
 	scaleUpAction                       *unison.Action
 	syncWithSourceAction                *unison.Action
 	swapDefaultsAction                  *unison.Action
+	syncScrollingAction                 *unison.Action
+	switchLoadoutAction                 *unison.Action
+	toggleFavoriteModifierAction        *unison.Action
+	toggleOrderLockAction               *unison.Action
+	togglePinnedAction                  *unison.Action
 	toggleStateAction                   *unison.Action
 	undoAction                          *unison.Action
 	webSettingsAction                   *unison.Action
@@ -170,6 +233,26 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	checkTemplateUpdatesAction = registerKeyBindableAction("check.template.updates", &unison.Action{
+		ID:              CheckTemplateUpdatesItemID,
+		Title:           i18n.Text("Check for Template Updates…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if sheet := ActiveSheet(); sheet != nil {
+				sheet.checkForTemplateUpdates()
+			}
+		},
+	})
+	rerollAncestryAction = registerKeyBindableAction("reroll.ancestry", &unison.Action{
+		ID:              RerollAncestryItemID,
+		Title:           i18n.Text("Re-roll Ancestry…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if sheet := ActiveSheet(); sheet != nil {
+				sheet.rerollAncestry()
+			}
+		},
+	})
 	clearPortraitAction = registerKeyBindableAction("clear.portrait", &unison.Action{
 		ID:              ClearPortraitItemID,
 		Title:           i18n.Text("Clear Portrait"),
@@ -229,6 +312,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	compareSideBySideAction = registerKeyBindableAction("view.side_by_side", &unison.Action{
+		ID:              CompareSideBySideItemID,
+		Title:           i18n.Text("Compare Side by Side With…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	copyListToSheetAction = registerKeyBindableAction("copy.list_to_sheet", &unison.Action{
+		ID:              CopyListToSheetItemID,
+		Title:           i18n.Text("Copy Entire List to Character Sheet…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	copyToSheetAction = registerKeyBindableAction("copy.to_sheet", &unison.Action{
 		ID:              CopyToSheetItemID,
 		Title:           i18n.Text("Copy to Character Sheet"),
@@ -300,6 +395,21 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	diceRollerAction = registerKeyBindableAction("view.dice_roller", &unison.Action{
+		ID:              DiceRollerItemID,
+		Title:           i18n.Text("Dice Roller"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowDiceRoller() },
+	})
+	gmScreenAction = registerKeyBindableAction("view.gm_screen", &unison.Action{
+		ID:              GMScreenItemID,
+		Title:           i18n.Text("GM Screen"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowGMScreen() },
+	})
+	nameGeneratorAction = registerKeyBindableAction("view.name_generator", &unison.Action{
+		ID:              NameGeneratorItemID,
+		Title:           i18n.Text("Name Generator"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowNameGenerator() },
+	})
 	duplicateAction = registerKeyBindableAction("duplicate", &unison.Action{
 		ID:              DuplicateItemID,
 		Title:           i18n.Text("Duplicate"),
@@ -307,6 +417,42 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	exportAllOpenSheetsAsPDFAction = registerKeyBindableAction("export.pdf.all_open", &unison.Action{
+		ID:              ExportAllOpenSheetsAsPDFItemID,
+		Title:           i18n.Text("All Open Sheets as PDF…"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return len(openSheets()) != 0 },
+		ExecuteCallback: func(_ *unison.Action, _ any) { exportAllOpenSheetsAsPDF() },
+	})
+	exportAsCSVAction = registerKeyBindableAction("export.csv", &unison.Action{
+		ID:              ExportAsCSVItemID,
+		Title:           i18n.Text("Export List as CSV…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportItemCardAction = registerKeyBindableAction("export.item_card", &unison.Action{
+		ID:              ExportItemCardItemID,
+		Title:           i18n.Text("Share Item as Card…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsFantasyGroundsAction = registerKeyBindableAction("export.fantasy_grounds", &unison.Action{
+		ID:              ExportAsFantasyGroundsItemID,
+		Title:           i18n.Text("Fantasy Grounds Character"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsForumPostAction = registerKeyBindableAction("export.forum_post", &unison.Action{
+		ID:              ExportAsForumPostItemID,
+		Title:           i18n.Text("Forum Post (BBCode/Markdown)…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsFoundryAction = registerKeyBindableAction("export.foundry", &unison.Action{
+		ID:              ExportAsFoundryItemID,
+		Title:           i18n.Text("Foundry VTT Actor"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsJPEGAction = registerKeyBindableAction("export.jpeg", &unison.Action{
 		ID:              ExportAsJPEGItemID,
 		Title:           i18n.Text("JPEG"),
@@ -320,18 +466,61 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	exportAsPDFWithLayoutAction = registerKeyBindableAction("export.pdf.layout", &unison.Action{
+		ID:              ExportAsPDFWithLayoutItemID,
+		Title:           i18n.Text("PDF with Sheet Layout…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsPNGAction = registerKeyBindableAction("export.png", &unison.Action{
 		ID:              ExportAsPNGItemID,
 		Title:           i18n.Text("PNG"),
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	exportAsRoll20Action = registerKeyBindableAction("export.roll20", &unison.Action{
+		ID:              ExportAsRoll20ItemID,
+		Title:           i18n.Text("Roll20 Character"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsStatBlockAction = registerKeyBindableAction("export.stat_block", &unison.Action{
+		ID:              ExportAsStatBlockItemID,
+		Title:           i18n.Text("GM Stat Block (Text)"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsWEBPAction = registerKeyBindableAction("export.webp", &unison.Action{
 		ID:              ExportAsWEBPItemID,
 		Title:           i18n.Text("WEBP"),
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	exportFolderAsPDFAction = registerKeyBindableAction("export.pdf.folder", &unison.Action{
+		ID:              ExportFolderAsPDFItemID,
+		Title:           i18n.Text("Folder of Sheets as PDF…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { exportFolderAsPDF() },
+	})
+	exportGMSummaryAction = registerKeyBindableAction("export.gm_summary", &unison.Action{
+		ID:              ExportGMSummaryItemID,
+		Title:           i18n.Text("GM Screen Summary (PDF)…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ExportGMSummary() },
+	})
+	exportNPCCardsAction = registerKeyBindableAction("export.npc_cards", &unison.Action{
+		ID:              ExportNPCCardsItemID,
+		Title:           i18n.Text("NPC Reference Cards (PDF)…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ExportNPCCards() },
+	})
+	exportLibraryBundleAction = registerKeyBindableAction("export.library_bundle", &unison.Action{
+		ID:              ExportLibraryBundleItemID,
+		Title:           i18n.Text("Library Bundle…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { exportLibraryBundle() },
+	})
+	exportDependencyBundleAction = registerKeyBindableAction("export.library_dependency_bundle", &unison.Action{
+		ID:              ExportDependencyBundleItemID,
+		Title:           i18n.Text("Library Dependencies Bundle…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { exportLibraryDependencyBundle() },
+	})
 	jumpToSearchFilterAction = registerKeyBindableAction("jump-to-search", &unison.Action{
 		ID:              JumpToSearchFilterItemID,
 		Title:           i18n.Text("Jump to Search/Filter Field"),
@@ -339,6 +528,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	castSpellAction = registerKeyBindableAction("spell.cast", &unison.Action{
+		ID:              CastSpellItemID,
+		Title:           i18n.Text("Cast Spell"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	fireWeaponAction = registerKeyBindableAction("weapon.fire", &unison.Action{
+		ID:              FireWeaponItemID,
+		Title:           i18n.Text("Fire Weapon"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	fontSettingsAction = registerKeyBindableAction("settings.fonts", &unison.Action{
 		ID:              FontSettingsItemID,
 		Title:           i18n.Text("Fonts…"),
@@ -381,6 +582,21 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	importFoundryActorAction = registerKeyBindableAction("import.foundry", &unison.Action{
+		ID:              ImportFoundryActorItemID,
+		Title:           i18n.Text("Import Foundry VTT Actor…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { importFoundryActor() },
+	})
+	pasteStatBlockAction = registerKeyBindableAction("import.stat_block", &unison.Action{
+		ID:              PasteStatBlockItemID,
+		Title:           i18n.Text("Paste Stat Block…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { pasteStatBlock() },
+	})
+	importLibraryBundleAction = registerKeyBindableAction("import.library_bundle", &unison.Action{
+		ID:              ImportLibraryBundleItemID,
+		Title:           i18n.Text("Import Library Bundle…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { importLibraryBundle() },
+	})
 	incrementAction = registerKeyBindableAction("inc", &unison.Action{
 		ID:              IncrementItemID,
 		Title:           i18n.Text("Increment"),
@@ -393,6 +609,16 @@ func registerActions() {
 		Title:           i18n.Text("Menu Keys…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowMenuKeySettings() },
 	})
+	mergeFromFileAction = registerKeyBindableAction("merge.from.file", &unison.Action{
+		ID:              MergeFromFileItemID,
+		Title:           i18n.Text("Merge From File…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if sheet := ActiveSheet(); sheet != nil {
+				sheet.mergeFromFile()
+			}
+		},
+	})
 	moveToCarriedEquipmentAction = registerKeyBindableAction("move.to.carried", &unison.Action{
 		ID:              MoveToCarriedEquipmentItemID,
 		Title:           i18n.Text("Move to Carried Equipment"),
@@ -405,6 +631,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	bundleIntoKitAction = registerKeyBindableAction("bundle.kit", &unison.Action{
+		ID:              BundleIntoKitItemID,
+		Title:           i18n.Text("Bundle into Kit…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newCarriedEquipmentAction = registerKeyBindableAction("new.eqp", &unison.Action{
 		ID:              NewCarriedEquipmentItemID,
 		Title:           i18n.Text("New Carried Equipment"),
@@ -420,13 +652,15 @@ func registerActions() {
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
 	newCharacterSheetAction = registerKeyBindableAction("new.char.sheet", &unison.Action{
-		ID:         NewSheetItemID,
-		Title:      i18n.Text("New Character Sheet"),
-		KeyBinding: unison.KeyBinding{KeyCode: unison.KeyN, Modifiers: unison.OSMenuCmdModifier()},
-		ExecuteCallback: func(_ *unison.Action, _ any) {
-			e := gurps.NewEntity()
-			DisplayNewDockable(NewSheet(e.Profile.Name+gurps.SheetExt, e))
-		},
+		ID:              NewSheetItemID,
+		Title:           i18n.Text("New Character Sheet"),
+		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyN, Modifiers: unison.OSMenuCmdModifier()},
+		ExecuteCallback: func(_ *unison.Action, _ any) { newCharacterSheet() },
+	})
+	newCharacterWizardAction = registerKeyBindableAction("new.char.wizard", &unison.Action{
+		ID:              NewSheetWizardItemID,
+		Title:           i18n.Text("New Character Sheet Wizard…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowNewCharacterWizard() },
 	})
 	newCharacterTemplateAction = registerKeyBindableAction("new.char.template", &unison.Action{
 		ID:    NewTemplateItemID,
@@ -435,14 +669,20 @@ func registerActions() {
 			DisplayNewDockable(NewTemplate("untitled"+gurps.TemplatesExt, gurps.NewTemplate()))
 		},
 	})
-	// TODO: Re-enable Campaign files
-	// newCampaignAction = registerKeyBindableAction("new.campaign", &unison.Action{
-	// 	ID:    NewCampaignItemID,
-	// 	Title: i18n.Text("New Campaign"),
-	// 	ExecuteCallback: func(_ *unison.Action, _ any) {
-	// 		DisplayNewDockable(NewCampaign("untitled"+gurps.CampaignExt, gurps.NewCampaign()))
-	// 	},
-	// })
+	newCombatTrackerAction = registerKeyBindableAction("new.combat", &unison.Action{
+		ID:    NewCombatTrackerItemID,
+		Title: i18n.Text("New Combat Tracker"),
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			DisplayNewDockable(NewCombatTracker("untitled"+gurps.CombatExt, gurps.NewCombat()))
+		},
+	})
+	newCampaignAction = registerKeyBindableAction("new.campaign", &unison.Action{
+		ID:    NewCampaignItemID,
+		Title: i18n.Text("New Campaign"),
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			DisplayNewDockable(NewCampaign("untitled"+gurps.CampaignExt, gurps.NewCampaign()))
+		},
+	})
 	newEquipmentContainerModifierAction = registerKeyBindableAction("new.eqm.container", &unison.Action{
 		ID:              NewEquipmentContainerModifierItemID,
 		Title:           i18n.Text("New Equipment Modifier Container"),
@@ -504,6 +744,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	newNoteFromTemplateAction = registerKeyBindableAction("new.not.template", &unison.Action{
+		ID:              NewNoteFromTemplateItemID,
+		Title:           i18n.Text("New Note from Template…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newNotesLibraryAction = registerKeyBindableAction("new.not.lib", &unison.Action{
 		ID:    NewNotesLibraryItemID,
 		Title: i18n.Text("New Notes Library"),
@@ -650,6 +896,17 @@ func registerActions() {
 			}
 		},
 	})
+	quickOpenAction = registerKeyBindableAction("open.quick", &unison.Action{
+		ID:              QuickOpenItemID,
+		Title:           i18n.Text("Quick Open…"),
+		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyO, Modifiers: unison.ShiftModifier | unison.OSMenuCmdModifier()},
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowQuickOpen() },
+	})
+	quickReferenceAction = registerKeyBindableAction("view.quick_reference", &unison.Action{
+		ID:              QuickReferenceItemID,
+		Title:           i18n.Text("Quick Reference"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowQuickReference() },
+	})
 	openEachPageReferenceAction = registerKeyBindableAction("pageref.open.all", &unison.Action{
 		ID:              OpenEachPageReferenceItemID,
 		Title:           i18n.Text("Open Each Page Reference"),
@@ -676,6 +933,31 @@ func registerActions() {
 		Title:           i18n.Text("Page Reference Mappings…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowPageRefMappings() },
 	})
+	exportTemplatesAction = registerKeyBindableAction("settings.export_templates", &unison.Action{
+		ID:              ExportTemplatesItemID,
+		Title:           i18n.Text("Export Templates…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowExportTemplates() },
+	})
+	perSheetAssociatesAction = registerKeyBindableAction("settings.associates.per_sheet", &unison.Action{
+		ID:              PerSheetAssociatesItemID,
+		Title:           i18n.Text("Contacts, Patrons, Allies & Enemies…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				displayAssociatesEditor(s, s.entity)
+			}
+		},
+	})
+	perSheetCompanionsAction = registerKeyBindableAction("settings.companions.per_sheet", &unison.Action{
+		ID:              PerSheetCompanionsItemID,
+		Title:           i18n.Text("Companions & Mounts…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				displayCompanionsEditor(s, s.entity)
+			}
+		},
+	})
 	perSheetAttributeSettingsAction = registerKeyBindableAction("settings.attributes.per_sheet", &unison.Action{
 		ID:              PerSheetAttributeSettingsItemID,
 		Title:           i18n.Text("Attributes…"),
@@ -696,6 +978,136 @@ func registerActions() {
 			}
 		},
 	})
+	perSheetDeathAndDyingAction = registerKeyBindableAction("settings.dying.per_sheet", &unison.Action{
+		ID:              PerSheetDeathAndDyingItemID,
+		Title:           i18n.Text("Death & Dying…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				ShowDeathAndDying(s)
+			}
+		},
+	})
+	perSheetAlternateFormAction = registerKeyBindableAction("settings.form.per_sheet", &unison.Action{
+		ID:              PerSheetAlternateFormItemID,
+		Title:           i18n.Text("Switch Alternate Form…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				ShowAlternateFormSwitcher(s)
+			}
+		},
+	})
+	perSheetHistoryAction = registerKeyBindableAction("settings.history.per_sheet", &unison.Action{
+		ID:              PerSheetHistoryItemID,
+		Title:           i18n.Text("Character History…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplaySnapshotHistory(s)
+			}
+		},
+	})
+	perSheetArmorTableAction = registerKeyBindableAction("settings.armor_table.per_sheet", &unison.Action{
+		ID:              PerSheetArmorTableItemID,
+		Title:           i18n.Text("Armor Table…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayArmorTable(s)
+			}
+		},
+	})
+	perSheetGrimoireAction = registerKeyBindableAction("settings.grimoire.per_sheet", &unison.Action{
+		ID:              PerSheetGrimoireItemID,
+		Title:           i18n.Text("Grimoire…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayGrimoire(s)
+			}
+		},
+	})
+	perSheetPowersAction = registerKeyBindableAction("settings.powers.per_sheet", &unison.Action{
+		ID:              PerSheetPowersItemID,
+		Title:           i18n.Text("Powers…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayPowers(s)
+			}
+		},
+	})
+	perSheetNotesJournalAction = registerKeyBindableAction("settings.notes_journal.per_sheet", &unison.Action{
+		ID:              PerSheetNotesJournalItemID,
+		Title:           i18n.Text("Notes Journal…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayNotesJournal(s)
+			}
+		},
+	})
+	perSheetPointsJournalAction = registerKeyBindableAction("settings.points_journal.per_sheet", &unison.Action{
+		ID:              PerSheetPointsJournalItemID,
+		Title:           i18n.Text("Points Journal…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayPointsJournal(s)
+			}
+		},
+	})
+	perSheetRollModifiersAction = registerKeyBindableAction("settings.roll_modifiers.per_sheet", &unison.Action{
+		ID:              PerSheetRollModifiersItemID,
+		Title:           i18n.Text("Modifiers Tray…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				DisplayRollModifiersTray(s)
+			}
+		},
+	})
+	perSheetApplyDamageAction = registerKeyBindableAction("settings.damage.per_sheet", &unison.Action{
+		ID:              PerSheetApplyDamageItemID,
+		Title:           i18n.Text("Apply Damage…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				ShowApplyDamage(s)
+			}
+		},
+	})
+	perSheetLanguagesAction = registerKeyBindableAction("settings.languages.per_sheet", &unison.Action{
+		ID:              PerSheetLanguagesItemID,
+		Title:           i18n.Text("Languages & Cultural Familiarities…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				displayLanguagesEditor(s, s.entity)
+			}
+		},
+	})
+	perSheetReputationsAction = registerKeyBindableAction("settings.reputations.per_sheet", &unison.Action{
+		ID:              PerSheetReputationsItemID,
+		Title:           i18n.Text("Reputations…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				displayReputationsEditor(s, s.entity)
+			}
+		},
+	})
+	perSheetSessionTimerAction = registerKeyBindableAction("settings.rest.per_sheet", &unison.Action{
+		ID:              PerSheetSessionTimerItemID,
+		Title:           i18n.Text("Session Timer & Rest…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				ShowSessionTimer(s)
+			}
+		},
+	})
 	perSheetSettingsAction = registerKeyBindableAction("settings.sheet.per_sheet", &unison.Action{
 		ID:              PerSheetSettingsItemID,
 		Title:           i18n.Text("Sheet Settings…"),
@@ -707,6 +1119,26 @@ func registerActions() {
 			}
 		},
 	})
+	perSheetTimelineAction = registerKeyBindableAction("settings.timeline.per_sheet", &unison.Action{
+		ID:              PerSheetTimelineItemID,
+		Title:           i18n.Text("Timeline & Aging…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				ShowTimeline(s)
+			}
+		},
+	})
+	perSheetVariablesAction = registerKeyBindableAction("settings.variables.per_sheet", &unison.Action{
+		ID:              PerSheetVariablesItemID,
+		Title:           i18n.Text("Variables…"),
+		EnabledCallback: actionEnabledForSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if s := ActiveSheet(); s != nil {
+				displayVariablesEditor(s, s.entity)
+			}
+		},
+	})
 	printAction = registerKeyBindableAction("print", &unison.Action{
 		ID:              PrintItemID,
 		Title:           i18n.Text("Print…"),
@@ -714,6 +1146,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	rechargeDailyUsesAction = registerKeyBindableAction("recharge.uses.daily", &unison.Action{
+		ID:              RechargeDailyUsesItemID,
+		Title:           i18n.Text("Recharge Daily Uses"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return canRechargeUses(recharge.PerDay) },
+		ExecuteCallback: func(_ *unison.Action, _ any) { rechargeUses(recharge.PerDay) },
+	})
+	rechargeSessionUsesAction = registerKeyBindableAction("recharge.uses.session", &unison.Action{
+		ID:              RechargeSessionUsesItemID,
+		Title:           i18n.Text("Recharge Per-Session Uses"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return canRechargeUses(recharge.PerSession) },
+		ExecuteCallback: func(_ *unison.Action, _ any) { rechargeUses(recharge.PerSession) },
+	})
 	redoAction = registerKeyBindableAction("redo", &unison.Action{
 		ID:         RedoItemID,
 		Title:      unison.CannotRedoTitle(),
@@ -736,6 +1180,17 @@ func registerActions() {
 			}
 		},
 	})
+	referenceSearchAction = registerKeyBindableAction("view.reference_search", &unison.Action{
+		ID:              ReferenceSearchItemID,
+		Title:           i18n.Text("Reference Search"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowReferenceSearch() },
+	})
+	reloadWeaponAction = registerKeyBindableAction("weapon.reload", &unison.Action{
+		ID:              ReloadWeaponItemID,
+		Title:           i18n.Text("Reload Weapon"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	saveAction = registerKeyBindableAction("save", &unison.Action{
 		ID:              SaveItemID,
 		Title:           i18n.Text("Save"),
@@ -750,6 +1205,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	saveLoadoutAction = registerKeyBindableAction("equipment.loadout.save", &unison.Action{
+		ID:              SaveLoadoutItemID,
+		Title:           i18n.Text("Save Equipment Loadout…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	scale25Action = registerKeyBindableAction("scale.25", &unison.Action{
 		ID:              Scale25ItemID,
 		Title:           i18n.Text("25% Scale"),
@@ -847,6 +1308,48 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	syncScrollingAction = registerKeyBindableAction("view.sync_scrolling", &unison.Action{
+		ID:              SyncScrollingItemID,
+		Title:           i18n.Text("Toggle Synchronized Scrolling"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	switchLoadoutAction = registerKeyBindableAction("equipment.loadout.switch", &unison.Action{
+		ID:              SwitchLoadoutItemID,
+		Title:           i18n.Text("Switch Equipment Loadout…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	toggleFavoriteModifierAction = registerKeyBindableAction("toggle.favorite", &unison.Action{
+		ID:              ToggleFavoriteModifierItemID,
+		Title:           i18n.Text("Toggle Favorite Modifier"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	applyFavoriteModifierAction = registerKeyBindableAction("apply.favorite", &unison.Action{
+		ID:              ApplyFavoriteModifierItemID,
+		Title:           i18n.Text("Apply Favorite Modifier to Selection…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	applyQualityPresetAction = registerKeyBindableAction("apply.quality", &unison.Action{
+		ID:              ApplyQualityPresetItemID,
+		Title:           i18n.Text("Apply Quality Preset to Selection…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	toggleOrderLockAction = registerKeyBindableAction("toggle.order_lock", &unison.Action{
+		ID:              ToggleOrderLockItemID,
+		Title:           i18n.Text("Toggle Ordering Lock"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	togglePinnedAction = registerKeyBindableAction("toggle.pinned", &unison.Action{
+		ID:              TogglePinnedItemID,
+		Title:           i18n.Text("Toggle Pinned to Top"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	toggleStateAction = registerKeyBindableAction("toggle", &unison.Action{
 		ID:              ToggleStateItemID,
 		Title:           i18n.Text("Toggle State"),
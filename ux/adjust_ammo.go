@@ -0,0 +1,107 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+type adjustAmmoListUndoEdit = *unison.UndoEdit[*adjustAmmoList]
+
+type adjustAmmoList struct {
+	Owner Rebuildable
+	List  []*ammoAdjuster
+}
+
+func (a *adjustAmmoList) Apply() {
+	for _, one := range a.List {
+		one.Apply()
+	}
+	MarkModified(a.Owner)
+}
+
+type ammoAdjuster struct {
+	Target   *gurps.Weapon
+	Loaded   fxp.Int
+	Ammo     *gurps.Equipment
+	Quantity fxp.Int
+}
+
+func newAmmoAdjuster(target *gurps.Weapon) *ammoAdjuster {
+	a := &ammoAdjuster{
+		Target: target,
+		Loaded: target.Loaded,
+		Ammo:   target.Ammo(),
+	}
+	if a.Ammo != nil {
+		a.Quantity = a.Ammo.Quantity
+	}
+	return a
+}
+
+func (a *ammoAdjuster) Apply() {
+	a.Target.Loaded = a.Loaded
+	if a.Ammo != nil {
+		a.Ammo.Quantity = a.Quantity
+	}
+}
+
+func canAdjustAmmo(table *unison.Table[*Node[*gurps.Weapon]], fire bool) bool {
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && canAdjustAmmoOf(w, fire) {
+			return true
+		}
+	}
+	return false
+}
+
+func canAdjustAmmoOf(w *gurps.Weapon, fire bool) bool {
+	if fire {
+		return w.CanFire()
+	}
+	return w.CanReload()
+}
+
+func adjustAmmo(owner Rebuildable, table *unison.Table[*Node[*gurps.Weapon]], fire bool) {
+	var name string
+	if fire {
+		name = fireWeaponAction.Title
+	} else {
+		name = reloadWeaponAction.Title
+	}
+	before := &adjustAmmoList{Owner: owner}
+	after := &adjustAmmoList{Owner: owner}
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && canAdjustAmmoOf(w, fire) {
+			before.List = append(before.List, newAmmoAdjuster(w))
+			if fire {
+				w.Fire()
+			} else {
+				w.Reload()
+			}
+			after.List = append(after.List, newAmmoAdjuster(w))
+		}
+	}
+	if len(before.List) > 0 {
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			mgr.Add(&unison.UndoEdit[*adjustAmmoList]{
+				ID:         unison.NextUndoID(),
+				EditName:   name,
+				UndoFunc:   func(edit adjustAmmoListUndoEdit) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit adjustAmmoListUndoEdit) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		MarkModified(before.Owner)
+	}
+}
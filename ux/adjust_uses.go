@@ -10,7 +10,10 @@
 package ux
 
 import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
+	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 )
 
@@ -29,54 +32,67 @@ func (a *adjustUsesList) Apply() {
 }
 
 type usesAdjuster struct {
-	Target *gurps.Equipment
-	Uses   int
+	Target   *gurps.Equipment
+	Uses     int
+	Quantity fxp.Int
 }
 
 func newUsesAdjuster(target *gurps.Equipment) *usesAdjuster {
 	return &usesAdjuster{
-		Target: target,
-		Uses:   target.Uses,
+		Target:   target,
+		Uses:     target.Uses,
+		Quantity: target.Quantity,
 	}
 }
 
 func (a *usesAdjuster) Apply() {
 	a.Target.Uses = a.Uses
+	a.Target.Quantity = a.Quantity
 }
 
 func canAdjustUses(table *unison.Table[*Node[*gurps.Equipment]], amount int) bool {
 	for _, row := range table.SelectedRows(false) {
-		if eqp := row.Data(); eqp != nil {
-			total := eqp.Uses + amount
-			if total >= 0 && total <= eqp.MaxUses {
-				return true
-			}
+		if eqp := row.Data(); eqp != nil && eqp.CanAdjustUses(amount) {
+			return true
 		}
 	}
 	return false
 }
 
 func adjustUses(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]], amount int) {
-	before := &adjustUsesList{Owner: owner}
-	after := &adjustUsesList{Owner: owner}
+	var list []*gurps.Equipment
 	for _, row := range table.SelectedRows(false) {
 		if eqp := row.Data(); eqp != nil {
-			total := eqp.Uses + amount
-			if total >= 0 && total <= eqp.MaxUses {
-				before.List = append(before.List, newUsesAdjuster(eqp))
-				eqp.Uses = total
-				after.List = append(after.List, newUsesAdjuster(eqp))
-			}
+			list = append(list, eqp)
+		}
+	}
+	adjustUsesOf(owner, table, list, amount)
+}
+
+func adjustUsesOf(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]], list []*gurps.Equipment, amount int) {
+	var name string
+	if amount < 0 {
+		name = decreaseUsesAction.Title
+	} else {
+		name = increaseUsesAction.Title
+	}
+	for _, eqp := range list {
+		if eqp.RemoveEmpty && eqp.UsedUpBy(amount) && gurps.EntityFromNode(eqp) != nil {
+			adjustUsesWithRemoval(owner, table, list, amount, name)
+			return
+		}
+	}
+	before := &adjustUsesList{Owner: owner}
+	after := &adjustUsesList{Owner: owner}
+	for _, eqp := range list {
+		if eqp.CanAdjustUses(amount) {
+			before.List = append(before.List, newUsesAdjuster(eqp))
+			eqp.AdjustUses(amount)
+			after.List = append(after.List, newUsesAdjuster(eqp))
 		}
 	}
 	if len(before.List) > 0 {
 		if mgr := unison.UndoManagerFor(table); mgr != nil {
-			var name string
-			if amount < 0 {
-				name = decreaseUsesAction.Title
-			} else {
-				name = increaseUsesAction.Title
-			}
 			mgr.Add(&unison.UndoEdit[*adjustUsesList]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
@@ -89,3 +105,68 @@ func adjustUses(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]],
 		MarkModified(before.Owner)
 	}
 }
+
+// adjustUsesWithRemoval handles an adjustment that uses up consumables that are set to be removed once empty. Since
+// the structure of the table changes, the undo state is a snapshot of the whole table.
+func adjustUsesWithRemoval(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]], list []*gurps.Equipment, amount int, name string) {
+	var undo *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]
+	mgr := unison.UndoManagerFor(table)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]{
+			ID:         unison.NextUndoID(),
+			EditName:   name,
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]], _ unison.Undoable) bool { return false },
+			BeforeData: NewTableUndoEditData(table),
+		}
+	}
+	for _, eqp := range list {
+		if eqp.AdjustUses(amount) && eqp.RemoveEmpty {
+			if entity := gurps.EntityFromNode(eqp); entity != nil {
+				entity.RemoveEquipment(eqp)
+			}
+		}
+	}
+	table.SyncToModel()
+	if mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		mgr.Add(undo)
+	}
+	MarkModified(table)
+	owner.Rebuild(true)
+}
+
+func canRechargeUses(kind recharge.Type) bool {
+	s := ActiveSheet()
+	return s != nil && len(s.entity.EquipmentToRecharge(kind)) != 0
+}
+
+func rechargeUses(kind recharge.Type) {
+	s := ActiveSheet()
+	if s == nil {
+		return
+	}
+	list := s.entity.EquipmentToRecharge(kind)
+	if len(list) == 0 {
+		return
+	}
+	before := &adjustUsesList{Owner: s}
+	after := &adjustUsesList{Owner: s}
+	for _, eqp := range list {
+		before.List = append(before.List, newUsesAdjuster(eqp))
+	}
+	s.entity.RechargeEquipment(kind)
+	for _, eqp := range list {
+		after.List = append(after.List, newUsesAdjuster(eqp))
+	}
+	s.undoMgr.Add(&unison.UndoEdit[*adjustUsesList]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Recharge Uses"),
+		UndoFunc:   func(edit adjustUsesListUndoEdit) { edit.BeforeData.Apply() },
+		RedoFunc:   func(edit adjustUsesListUndoEdit) { edit.AfterData.Apply() },
+		BeforeData: before,
+		AfterData:  after,
+	})
+	MarkModified(s)
+}
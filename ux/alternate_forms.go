@@ -0,0 +1,122 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+type alternateFormChoice struct {
+	form *gurps.Trait
+}
+
+func (c *alternateFormChoice) String() string {
+	if c.form == nil {
+		return i18n.Text("Native Form")
+	}
+	return c.form.String()
+}
+
+// alternateFormState holds enough of an entity's state to undo switching between alternate forms.
+type alternateFormState struct {
+	disabled  map[*gurps.Trait]bool
+	changeLog []*gurps.ChangeLogEntry
+}
+
+func newAlternateFormState(entity *gurps.Entity) *alternateFormState {
+	state := &alternateFormState{
+		disabled:  make(map[*gurps.Trait]bool),
+		changeLog: slices.Clone(entity.ChangeLog),
+	}
+	for _, form := range entity.AlternateForms() {
+		state.disabled[form] = form.Disabled
+	}
+	return state
+}
+
+func (s *alternateFormState) apply(sheet *Sheet) {
+	entity := sheet.Entity()
+	for form, disabled := range s.disabled {
+		form.Disabled = disabled
+	}
+	entity.ChangeLog = slices.Clone(s.changeLog)
+	entity.Recalculate()
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+// ShowAlternateFormSwitcher asks which of the character's alternate forms to switch to, then enables the traits of that
+// form and disables those of all others.
+func ShowAlternateFormSwitcher(sheet *Sheet) {
+	entity := sheet.Entity()
+	forms := entity.AlternateForms()
+	if len(forms) == 0 {
+		unison.WarningDialogWithMessage(i18n.Text("The character has no alternate forms."),
+			fmt.Sprintf(i18n.Text("Add a trait container with a type of %s to hold the traits of each form."),
+				container.AlternateForm.String()))
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Form"), false))
+	popup := unison.NewPopupMenu[*alternateFormChoice]()
+	active := entity.ActiveAlternateForm()
+	popup.AddItem(&alternateFormChoice{})
+	for i, form := range forms {
+		popup.AddItem(&alternateFormChoice{form: form})
+		if form == active {
+			popup.SelectIndex(i + 1)
+		}
+	}
+	if active == nil {
+		popup.SelectIndex(0)
+	}
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Switch")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	choice, ok := popup.Selected()
+	if !ok {
+		return
+	}
+	before := newAlternateFormState(entity)
+	if !entity.SwitchAlternateForm(choice.form) {
+		return
+	}
+	sheet.undoMgr.Add(&unison.UndoEdit[*alternateFormState]{
+		ID:         unison.NextUndoID(),
+		EditName:   fmt.Sprintf(i18n.Text("Switch to %s"), choice.String()),
+		UndoFunc:   func(edit *unison.UndoEdit[*alternateFormState]) { edit.BeforeData.apply(sheet) },
+		RedoFunc:   func(edit *unison.UndoEdit[*alternateFormState]) { edit.AfterData.apply(sheet) },
+		BeforeData: before,
+		AfterData:  newAlternateFormState(entity),
+	})
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
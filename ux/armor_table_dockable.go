@@ -0,0 +1,263 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const armorTableColumns = 5
+
+var (
+	_ unison.Dockable            = &ArmorTableDockable{}
+	_ unison.UndoManagerProvider = &ArmorTableDockable{}
+	_ GroupedCloser              = &ArmorTableDockable{}
+)
+
+// ArmorTableDockable displays the equipped armor of a character, grouped by the hit locations it covers.
+type ArmorTableDockable struct {
+	unison.Panel
+	sheet        *Sheet
+	undoMgr      *unison.UndoManager
+	loadoutPopup *unison.PopupMenu[*gurps.Loadout]
+	donButton    *unison.Button
+	doffButton   *unison.Button
+	content      *unison.Panel
+	scroll       *unison.ScrollPanel
+	scale        int
+}
+
+// DisplayArmorTable displays the armor table for the given Sheet.
+func DisplayArmorTable(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if a, ok := d.AsPanel().Self.(*ArmorTableDockable); ok {
+			return a.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateArmorTable(sheet)
+		return
+	}
+	a := &ArmorTableDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	a.Self = a
+	a.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	a.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	a.content = unison.NewPanel()
+	a.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	a.content.SetLayout(&unison.FlexLayout{
+		Columns:  armorTableColumns,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	a.scroll = unison.NewScrollPanel()
+	a.scroll.SetContent(a.content, behavior.HintedFill, behavior.Unmodified)
+	a.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	a.AddChild(a.createToolbar())
+	a.AddChild(a.scroll)
+	a.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	a.refresh()
+	PlaceInDock(a, dgroup.Editors, false)
+}
+
+// UpdateArmorTable refreshes the armor table for the given Sheet, if it is being displayed.
+func UpdateArmorTable(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if a, ok := other.(*ArmorTableDockable); ok && a.sheet == sheet {
+			a.refresh()
+			break
+		}
+	}
+}
+
+func (a *ArmorTableDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return a.scale },
+			func(scale int) { a.scale = scale },
+			nil,
+			false,
+			a.scroll,
+		),
+	)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("Loadout"), false))
+	a.loadoutPopup = unison.NewPopupMenu[*gurps.Loadout]()
+	toolbar.AddChild(a.loadoutPopup)
+	a.donButton = unison.NewButton()
+	a.donButton.SetTitle(i18n.Text("Don Set"))
+	a.donButton.Tooltip = newWrappedTooltip(i18n.Text("Switch to the selected equipment loadout"))
+	a.donButton.ClickCallback = a.donSet
+	toolbar.AddChild(a.donButton)
+	a.doffButton = unison.NewButton()
+	a.doffButton.SetTitle(i18n.Text("Doff Armor"))
+	a.doffButton.Tooltip = newWrappedTooltip(i18n.Text("Unequip all of the equipped armor"))
+	a.doffButton.ClickCallback = a.doffArmor
+	toolbar.AddChild(a.doffButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (a *ArmorTableDockable) donSet() {
+	if l, ok := a.loadoutPopup.Selected(); ok && l != nil {
+		changeLoadouts(a.sheet, fmt.Sprintf(i18n.Text("Switch to Loadout %s"), l.Name), func() {
+			a.sheet.Entity().ApplyLoadout(l)
+		})
+	}
+}
+
+func (a *ArmorTableDockable) doffArmor() {
+	changeLoadouts(a.sheet, i18n.Text("Doff Armor"), a.sheet.Entity().DoffArmor)
+}
+
+func (a *ArmorTableDockable) refresh() {
+	entity := a.sheet.Entity()
+	a.loadoutPopup.RemoveAllItems()
+	a.loadoutPopup.AddItem(entity.Loadouts...)
+	if len(entity.Loadouts) != 0 {
+		a.loadoutPopup.SelectIndex(0)
+		for i, one := range entity.Loadouts {
+			if entity.IsLoadoutActive(one) {
+				a.loadoutPopup.SelectIndex(i)
+				break
+			}
+		}
+	}
+	a.loadoutPopup.SetEnabled(len(entity.Loadouts) != 0)
+	a.donButton.SetEnabled(len(entity.Loadouts) != 0)
+
+	a.content.RemoveAllChildren()
+	table := entity.ArmorTable()
+	a.doffButton.SetEnabled(len(table) != 0)
+	if len(table) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No armor is equipped"))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: armorTableColumns})
+		a.content.AddChild(label)
+	} else {
+		units := entity.SheetSettings.DefaultWeightUnits
+		a.addRow(unison.EmphasizedSystemFont, i18n.Text("Location"), i18n.Text("Armor"), i18n.Text("DR"),
+			i18n.Text("Weight"), i18n.Text("Cost"))
+		for _, loc := range table {
+			a.addRow(unison.EmphasizedSystemFont, loc.Location.ChoiceName, "", gurps.FormatDR(loc.DR),
+				units.Format(loc.Weight), loc.Cost.Comma())
+			for _, piece := range loc.Pieces {
+				name := piece.Equipment.String()
+				if piece.Flexible {
+					name = fmt.Sprintf(i18n.Text("%s (flexible)"), name)
+				}
+				a.addRow(unison.SystemFont, "", name, gurps.FormatDR(piece.DR),
+					units.Format(piece.Equipment.ExtendedWeight(false, units)), piece.Equipment.ExtendedValue().Comma())
+			}
+		}
+		weight, cost := gurps.ArmorTotals(table, units)
+		a.addRow(unison.EmphasizedSystemFont, i18n.Text("Total"), "", "", units.Format(weight), cost.Comma())
+	}
+	a.content.MarkForLayoutRecursively()
+	a.MarkForRedraw()
+}
+
+func (a *ArmorTableDockable) addRow(font unison.Font, location, armor, dr, weight, cost string) {
+	for i, text := range []string{location, armor, dr, weight, cost} {
+		label := unison.NewLabel()
+		label.Font = font
+		label.SetTitle(text)
+		hAlign := align.End
+		if i < 2 {
+			hAlign = align.Start
+		}
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: hAlign,
+			HGrab:  i == 1,
+		})
+		a.content.AddChild(label)
+	}
+}
+
+// TitleIcon implements unison.Dockable
+func (a *ArmorTableDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSEquipment,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (a *ArmorTableDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Armor for %s"), a.sheet.String())
+}
+
+func (a *ArmorTableDockable) String() string {
+	return a.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (a *ArmorTableDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (a *ArmorTableDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (a *ArmorTableDockable) CloseWithGroup(other unison.Paneler) bool {
+	return a.sheet != nil && a.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (a *ArmorTableDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(a)
+}
+
+// AttemptClose implements GroupedCloser
+func (a *ArmorTableDockable) AttemptClose() bool {
+	if !CloseGroup(a) {
+		return false
+	}
+	return AttemptCloseForDockable(a)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (a *ArmorTableDockable) UndoManager() *unison.UndoManager {
+	return a.undoMgr
+}
@@ -0,0 +1,413 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/assoc"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const associatesEditorColumns = 9
+
+var (
+	_ unison.Dockable            = &associatesEditor{}
+	_ unison.TabCloser           = &associatesEditor{}
+	_ ModifiableRoot             = &associatesEditor{}
+	_ unison.UndoManagerProvider = &associatesEditor{}
+	_ GroupedCloser              = &associatesEditor{}
+	_ Rebuildable                = &associatesEditor{}
+)
+
+type associatesEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.Associate
+	current          []*gurps.Associate
+	promptForSave    bool
+}
+
+func displayAssociatesEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*associatesEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &associatesEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.CloneAssociateList(entity.Associates),
+		current: gurps.CloneAssociateList(entity.Associates),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  associatesEditorColumns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+}
+
+func (e *associatesEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Contact, Patron, Ally, or Enemy"))
+	addButton.ClickCallback = func() {
+		e.current = slices.Insert(e.current, 0, gurps.NewAssociate(assoc.Contact))
+		e.rebuildContent()
+	}
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *associatesEditor) rebuildContent() {
+	e.content.RemoveAllChildren()
+	e.initContent()
+	e.content.Pack()
+	MarkForLayoutWithinDockable(e.content)
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+}
+
+func (e *associatesEditor) initContent() {
+	choices := linkedTraitChoices(e.entity)
+	for _, one := range e.current {
+		e.createRow(one, choices)
+	}
+}
+
+func (e *associatesEditor) createRow(a *gurps.Associate, choices []linkedTraitChoice) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current, a); i != -1 {
+			e.current = slices.Delete(e.current, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	e.content.AddChild(deleteButton)
+
+	cost := NewFieldTrailingLabel("", false)
+	updateCost := func() {
+		text := fmt.Sprintf(i18n.Text("%s pts"), a.Cost().String())
+		cost.OnBackgroundInk = unison.ThemeOnSurface
+		if a.TraitID != "" {
+			if t := e.entity.TraitByID(a.TraitID); t == nil || t.AdjustedPoints() != a.Cost() {
+				cost.OnBackgroundInk = unison.ThemeError
+			}
+		}
+		cost.SetTitle(text)
+		cost.MarkForLayoutAndRedraw()
+	}
+
+	e.content.AddChild(NewPopup[assoc.Kind](nil, "", i18n.Text("Kind"),
+		func() assoc.Kind { return a.Kind },
+		func(kind assoc.Kind) {
+			if a.Kind != kind {
+				replacement := gurps.NewAssociate(kind)
+				replacement.Name = a.Name
+				replacement.Frequency = a.Frequency
+				replacement.TraitID = a.TraitID
+				replacement.Notes = a.Notes
+				*a = *replacement
+				e.rebuildContent()
+			}
+		}, assoc.Kinds...))
+
+	nameText := i18n.Text("Name")
+	name := NewStringField(nil, "", nameText,
+		func() string { return a.Name },
+		func(value string) {
+			a.Name = value
+			MarkModified(e.content)
+		})
+	name.Watermark = nameText
+	name.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChild(name)
+
+	if a.Kind == assoc.Contact {
+		skill := NewIntegerField(nil, "", i18n.Text("Effective Skill"),
+			func() int { return a.EffectiveSkill },
+			func(value int) {
+				a.EffectiveSkill = value
+				updateCost()
+				MarkModified(e.content)
+			}, 3, 30, false, false)
+		skill.Tooltip = newWrappedTooltip(i18n.Text("The effective skill of the contact"))
+		e.content.AddChild(skill)
+		e.content.AddChild(NewPopup[assoc.Reliability](nil, "", i18n.Text("Reliability"),
+			func() assoc.Reliability { return a.Reliability },
+			func(reliability assoc.Reliability) {
+				a.Reliability = reliability
+				updateCost()
+			}, assoc.Reliabilitys...))
+	} else {
+		base := NewDecimalField(nil, "", i18n.Text("Base Points"),
+			func() fxp.Int { return a.BasePoints },
+			func(value fxp.Int) {
+				a.BasePoints = value
+				updateCost()
+				MarkModified(e.content)
+			}, fxp.From(-999), fxp.From(999), true, false)
+		base.Tooltip = newWrappedTooltip(i18n.Text("The base cost before adjusting for the frequency of appearance"))
+		e.content.AddChild(base)
+		e.content.AddChild(unison.NewPanel())
+	}
+
+	e.content.AddChild(NewPopup[assoc.Frequency](nil, "", i18n.Text("Frequency of Appearance"),
+		func() assoc.Frequency { return a.Frequency },
+		func(frequency assoc.Frequency) {
+			a.Frequency = frequency
+			updateCost()
+		}, assoc.Frequencys...))
+
+	e.content.AddChild(newLinkedTraitPopup(choices, &a.TraitID, updateCost))
+
+	updateCost()
+	e.content.AddChild(cost)
+
+	rollButton := unison.NewSVGButton(svg.Randomize)
+	rollButton.Tooltip = newWrappedTooltip(i18n.Text("Roll for appearance"))
+	rollButton.ClickCallback = func() {
+		title := fmt.Sprintf(i18n.Text("Appearance Roll for %s"), a.Name)
+		if r, ok := a.RollAppearance(); ok {
+			showSuccessRoll(e.entity, title, r)
+		} else {
+			showSuccessRollMessage(title, i18n.Text("Always present; no roll is needed"))
+		}
+	}
+	e.content.AddChild(rollButton)
+
+	notesText := i18n.Text("Notes")
+	notes := NewMultiLineStringField(nil, "", notesText,
+		func() string { return a.Notes },
+		func(value string) {
+			a.Notes = value
+			MarkModified(e.content)
+		})
+	notes.Watermark = notesText
+	notes.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  associatesEditorColumns - 2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChild(unison.NewPanel())
+	e.content.AddChild(notes)
+	e.content.AddChild(unison.NewPanel())
+}
+
+func (e *associatesEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+func (e *associatesEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Contacts & Allies for %s"), e.owner.String())
+}
+
+func (e *associatesEditor) String() string {
+	return e.Title()
+}
+
+func (e *associatesEditor) Tooltip() string {
+	return ""
+}
+
+func (e *associatesEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *associatesEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *associatesEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *associatesEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *associatesEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *associatesEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *associatesEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *associatesEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[[]*gurps.Associate]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Contacts & Allies Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.Associate]) {
+				entity.SetAssociates(edit.BeforeData)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.Associate]) {
+				entity.SetAssociates(edit.AfterData)
+				owner.Rebuild(true)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetAssociates(e.current)
+	owner.Rebuild(true)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/fatal"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -47,6 +48,8 @@ type AttrPanel struct {
 	rowStarts   []int
 	kind        int
 	stateLabels map[string]*unison.Label
+	tallyState  *unison.Label
+	hasTally    bool
 }
 
 // NewPrimaryAttrPanel creates a new primary attributes panel.
@@ -195,10 +198,17 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 						i18n.Text("Point Pool Current"), func() fxp.Int {
 							if currentField != nil {
 								currentField.SetMinMax(currentField.Min(), attr.Maximum())
+								a.updatePoolCurrentEditable(currentField)
 							}
 							return attr.Current()
 						},
-						func(v fxp.Int) { attr.Damage = (attr.Maximum() - v).Max(0) }, fxp.Min, attr.Maximum(), true)
+						func(v fxp.Int) {
+							attr.Damage = (attr.Maximum() - v).Max(0)
+							if attr.AttrID == gurps.HitPointsID {
+								a.entity.UpdateDyingStatus()
+							}
+						}, fxp.Min, attr.Maximum(), true)
+					a.updatePoolCurrentEditable(currentField)
 					a.AddChild(currentField)
 
 					a.AddChild(NewPageLabel(i18n.Text("of")))
@@ -207,8 +217,8 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 						a.AddChild(NewDecimalPageField(a.targetMgr, a.prefix+attr.AttrID+":max",
 							i18n.Text("Point Pool Maximum"), func() fxp.Int { return attr.Maximum() },
 							func(v fxp.Int) {
-								attr.SetMaximum(v)
-								currentField.SetMinMax(currentField.Min(), v)
+								a.setMaximum(attr, v)
+								currentField.SetMinMax(currentField.Min(), attr.Maximum())
 								currentField.Sync()
 							}, fxp.Min, fxp.Max, true))
 					} else {
@@ -228,6 +238,9 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 						if threshold.Explanation != "" {
 							state.Tooltip = newWrappedTooltip(threshold.Explanation)
 						}
+						if def.ID() == gurps.HitPointsID {
+							a.installDyingStateHandling(state)
+						}
 						a.AddChild(state)
 						a.stateLabels[def.ID()] = state
 					} else {
@@ -249,18 +262,27 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 						if def.AllowsDecimal() {
 							a.AddChild(NewDecimalPageField(a.targetMgr, a.prefix+attr.AttrID, def.CombinedName(),
 								func() fxp.Int { return attr.Maximum() },
-								func(v fxp.Int) { attr.SetMaximum(v) }, fxp.Min, fxp.Max, true))
+								func(v fxp.Int) { a.setMaximum(attr, v) }, fxp.Min, fxp.Max, true))
 						} else {
 							a.AddChild(NewIntegerPageField(a.targetMgr, a.prefix+attr.AttrID, def.CombinedName(),
 								func() int { return fxp.As[int](attr.Maximum().Trunc()) },
-								func(v int) { attr.SetMaximum(fxp.From(v)) }, fxp.As[int](fxp.Min.Trunc()), fxp.As[int](fxp.Max.Trunc()), false, true))
+								func(v int) { a.setMaximum(attr, fxp.From(v)) }, fxp.As[int](fxp.Min.Trunc()), fxp.As[int](fxp.Max.Trunc()), false, true))
 						}
 					}
-					a.AddChild(NewPageLabel(def.CombinedName()))
+					label := NewPageLabel(def.CombinedName())
+					if def.Type == attribute.Integer && a.inSheet() {
+						a.makeAttributeRollable(label, def, attr)
+					}
+					a.AddChild(label)
 				}
 			}
 		}
 	}
+	a.tallyState = nil
+	a.hasTally = a.kind == poolAttrKind && a.entity.ActiveThresholdMagic() != nil
+	if a.hasTally {
+		a.addThresholdTally()
+	}
 	if a.targetMgr != nil {
 		if sheet := unison.Ancestor[*Sheet](a); sheet != nil {
 			a.targetMgr.ReacquireFocus(focusRefKey, sheet.toolbar, sheet.scroll.Content())
@@ -268,14 +290,84 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 	}
 }
 
+func (a *AttrPanel) addThresholdTally() {
+	a.rowStarts = append(a.rowStarts, len(a.Children()))
+	a.AddChild(unison.NewPanel())
+	a.AddChild(NewDecimalPageField(a.targetMgr, a.prefix+"threshold_tally", i18n.Text("Threshold Tally"),
+		func() fxp.Int { return a.entity.ThresholdTally },
+		func(v fxp.Int) { a.entity.ThresholdTally = v }, 0, fxp.Max, true))
+	a.AddChild(NewPageLabel(i18n.Text("of")))
+	a.AddChild(NewNonEditablePageFieldEnd(func(field *NonEditablePageField) {
+		if settings := a.entity.ActiveThresholdMagic(); settings != nil {
+			field.SetTitle(settings.Threshold.String())
+		}
+	}))
+	name := NewPageLabel(i18n.Text("Tally"))
+	name.Tooltip = newWrappedTooltip(i18n.Text("The threshold tally for threshold-limited magic"))
+	a.AddChild(name)
+	a.tallyState = NewPageLabel("")
+	a.updateThresholdTallyState()
+	a.AddChild(a.tallyState)
+}
+
+func (a *AttrPanel) updateThresholdTallyState() {
+	var text string
+	a.tallyState.Tooltip = nil
+	if over := a.entity.OverThreshold(); over > 0 {
+		text = "[" + i18n.Text("Calamity") + "]"
+		a.tallyState.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("The tally exceeds the threshold by %s; roll for calamity after each spell cast"), over.Comma()))
+	}
+	a.tallyState.Text = unison.NewSmallCapsText(text, &unison.TextDecoration{
+		Font:            fonts.PageLabelPrimary,
+		OnBackgroundInk: unison.ThemeOnSurface,
+	})
+}
+
+func (a *AttrPanel) updatePoolCurrentEditable(field *DecimalField) {
+	// Pools can only be depleted once the character is in play. Exports are left alone, so they never show the field as
+	// disabled.
+	if _, ok := a.targetMgr.root.Self.(*Sheet); ok {
+		field.SetEnabled(a.entity.Mode != sheetmode.Creation)
+	}
+}
+
+func (a *AttrPanel) inSheet() bool {
+	if a.targetMgr == nil {
+		return false
+	}
+	_, ok := a.targetMgr.root.Self.(*Sheet)
+	return ok
+}
+
+// setMaximum sets the attribute's maximum, unless doing so would exceed the sheet's creation limits.
+func (a *AttrPanel) setMaximum(attr *gurps.Attribute, value fxp.Int) {
+	var owner any
+	if a.targetMgr != nil {
+		owner = a.targetMgr.root.Self
+	}
+	adjustment := attr.Adjustment
+	applyWithinCreationLimits(owner, func() { attr.SetMaximum(value) }, func() { attr.Adjustment = adjustment })
+}
+
+func (a *AttrPanel) makeAttributeRollable(label *unison.Label, def *gurps.AttributeDef, attr *gurps.Attribute) {
+	label.Tooltip = newWrappedTooltip(i18n.Text("Click to roll"))
+	makeRollable(a.entity, label, func() string {
+		return rollTitle(a.entity, fmt.Sprintf(i18n.Text("%s Roll"), def.CombinedName()))
+	}, func() (int, bool) {
+		return fxp.As[int](attr.Maximum().Trunc()), true
+	})
+}
+
 func (a *AttrPanel) createPointsField(attr *gurps.Attribute) unison.Paneler {
 	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := "[" + attr.PointCost().String() + "]"; text != f.Text.String() {
+		points := attr.PointCost()
+		if text := "[" + points.String() + "]"; text != f.Text.String() {
 			f.SetTitle(text)
 			MarkForLayoutWithinDockable(f)
 		}
 		if def := attr.AttributeDef(); def != nil {
-			f.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Points spent on %s"), def.CombinedName()))
+			syncPointsDeltaField(f, a.entity.SavedPoints().AttributeDelta(attr.AttrID, points),
+				fmt.Sprintf(i18n.Text("Points spent on %s"), def.CombinedName()), dimmedPointsColor)
 		}
 	})
 	field.Font = fonts.PageFieldSecondary
@@ -290,10 +382,13 @@ func (a *AttrPanel) createPointsField(attr *gurps.Attribute) unison.Paneler {
 // Sync the panel to the current data.
 func (a *AttrPanel) Sync() {
 	attrs := gurps.SheetSettingsFor(a.entity).Attributes
-	if crc := attrs.CRC64(); crc != a.crc {
+	if crc := attrs.CRC64(); crc != a.crc || a.hasTally != (a.kind == poolAttrKind && a.entity.ActiveThresholdMagic() != nil) {
 		a.crc = crc
 		a.rebuild(attrs)
 	} else if a.kind == poolAttrKind {
+		if a.tallyState != nil {
+			a.updateThresholdTallyState()
+		}
 		for _, def := range attrs.List(false) {
 			if def.Pool() && def.Type != attribute.PoolSeparator {
 				id := def.ID()
@@ -315,6 +410,9 @@ func (a *AttrPanel) Sync() {
 							})
 							label.Tooltip = nil
 						}
+						if id == gurps.HitPointsID {
+							a.updateDyingStateTooltip(label)
+						}
 					}
 				}
 			}
@@ -322,3 +420,41 @@ func (a *AttrPanel) Sync() {
 	}
 	MarkForLayoutWithinDockable(a)
 }
+
+// installDyingStateHandling adds the conditions caused by HP loss to the state label's tooltip and makes clicking on the
+// label bring up the death and dying dialog.
+func (a *AttrPanel) installDyingStateHandling(label *unison.Label) {
+	a.updateDyingStateTooltip(label)
+	label.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		return true
+	}
+	label.MouseUpCallback = func(where unison.Point, _ int, _ unison.Modifiers) bool {
+		if where.In(label.ContentRect(false)) {
+			if sheet := unison.Ancestor[*Sheet](label); sheet != nil {
+				ShowDeathAndDying(sheet)
+			}
+		}
+		return true
+	}
+	label.UpdateCursorCallback = func(_ unison.Point) *unison.Cursor {
+		return unison.PointingCursor()
+	}
+}
+
+func (a *AttrPanel) updateDyingStateTooltip(label *unison.Label) {
+	conditions := a.entity.DyingConditions()
+	if len(conditions) == 0 {
+		return
+	}
+	var text string
+	if threshold := a.entity.Attributes.Set[gurps.HitPointsID].CurrentThreshold(); threshold != nil {
+		text = threshold.Explanation
+	}
+	for _, one := range conditions {
+		if text != "" {
+			text += "\n"
+		}
+		text += one
+	}
+	label.Tooltip = newWrappedTooltip(text)
+}
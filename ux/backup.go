@@ -0,0 +1,28 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// backupBeforeBulkOperation writes a backup of the current content of the file at filePath before a bulk operation
+// modifies it, unless automatic backups have been disabled. Unlike undo, these backups survive quitting the app.
+func backupBeforeBulkOperation(filePath string, data any, operation string) {
+	general := gurps.GlobalSettings().General
+	if general.DisableAutoBackup {
+		return
+	}
+	if _, err := gurps.WriteBackup(gurps.BackupDir(), filePath, operation, data,
+		general.AutoBackupRetention); err != nil {
+		errs.Log(err, "path", filePath)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+// lastBatchExportPattern holds the file name pattern used by the previous batch export, so that it may be offered
+// again.
+var lastBatchExportPattern = gurps.DefaultFileNamePattern
+
+// batchExportItem is a single sheet to be exported by a batch export. The entity is loaded on demand when nil.
+type batchExportItem struct {
+	entity     *gurps.Entity
+	sourcePath string
+}
+
+func openSheets() []*Sheet {
+	var sheets []*Sheet
+	for _, d := range AllDockables() {
+		if s, ok := d.(*Sheet); ok {
+			sheets = append(sheets, s)
+		}
+	}
+	return sheets
+}
+
+func exportAllOpenSheetsAsPDF() {
+	sheets := openSheets()
+	if len(sheets) == 0 {
+		return
+	}
+	pattern, _, ok := promptForBatchExportOptions(false)
+	if !ok {
+		return
+	}
+	outDir, ok := chooseBatchExportDir(filepath.Dir(sheets[0].BackingFilePath()))
+	if !ok {
+		return
+	}
+	items := make([]batchExportItem, 0, len(sheets))
+	for _, s := range sheets {
+		items = append(items, batchExportItem{entity: s.entity, sourcePath: s.BackingFilePath()})
+	}
+	reportBatchExport(runBatchPDFExport(items, outDir, pattern))
+}
+
+func exportFolderAsPDF() {
+	global := gurps.GlobalSettings()
+	srcDir, ok := chooseBatchExportDir(global.LastDir(gurps.DefaultLastDirKey))
+	if !ok {
+		return
+	}
+	pattern, recursive, ok := promptForBatchExportOptions(true)
+	if !ok {
+		return
+	}
+	outDir, ok := chooseBatchExportDir(srcDir)
+	if !ok {
+		return
+	}
+	paths, err := sheetFilesIn(srcDir, recursive)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to read folder"), err)
+		return
+	}
+	if len(paths) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("No character sheets were found in"), srcDir)
+		return
+	}
+	items := make([]batchExportItem, 0, len(paths))
+	for _, p := range paths {
+		items = append(items, batchExportItem{sourcePath: p})
+	}
+	reportBatchExport(runBatchPDFExport(items, outDir, pattern))
+}
+
+func promptForBatchExportOptions(forFolder bool) (pattern string, recursive, ok bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("File Name"), false))
+	field := unison.NewField()
+	field.SetText(lastBatchExportPattern)
+	field.MinimumTextWidth = 300
+	field.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Available substitutions: %s"),
+		gurps.FileNamePatternHelp))
+	field.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill, HGrab: true})
+	panel.AddChild(field)
+	var subfolders *unison.CheckBox
+	if forFolder {
+		panel.AddChild(unison.NewPanel())
+		subfolders = unison.NewCheckBox()
+		subfolders.SetTitle(i18n.Text("Include subfolders"))
+		panel.AddChild(subfolders)
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Export")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return "", false, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return "", false, false
+	}
+	pattern = strings.TrimSpace(field.Text())
+	if pattern == "" {
+		pattern = gurps.DefaultFileNamePattern
+	}
+	lastBatchExportPattern = pattern
+	return pattern, subfolders != nil && subfolders.State == check.On, true
+}
+
+func chooseBatchExportDir(initialDir string) (string, bool) {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetCanChooseDirectories(true)
+	dialog.SetCanChooseFiles(false)
+	dialog.SetInitialDirectory(initialDir)
+	if !dialog.RunModal() {
+		return "", false
+	}
+	dir := dialog.Path()
+	gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, dir)
+	return dir, true
+}
+
+// runBatchPDFExport exports each item as a PDF into outDir, naming the files with the pattern. Failures do not stop
+// the export; a description of each failure is returned once all items have been attempted.
+func runBatchPDFExport(items []batchExportItem, outDir, pattern string) (exported int, failures []string) {
+	used := make(map[string]bool)
+	for _, item := range items {
+		if err := exportBatchItemAsPDF(item, outDir, pattern, used); err != nil {
+			errs.Log(err, "file", item.sourcePath)
+			failures = append(failures, filepath.Base(item.sourcePath)+": "+err.Error())
+			continue
+		}
+		exported++
+	}
+	return exported, failures
+}
+
+func exportBatchItemAsPDF(item batchExportItem, outDir, pattern string, used map[string]bool) error {
+	entity := item.entity
+	if entity == nil {
+		var err error
+		if entity, err = gurps.NewEntityFromFile(os.DirFS(filepath.Dir(item.sourcePath)),
+			filepath.Base(item.sourcePath)); err != nil {
+			return err
+		}
+	}
+	return newPageExporter(entity).exportAsPDFFile(uniqueBatchExportPath(outDir,
+		gurps.ExpandFileNamePattern(pattern, entity, item.sourcePath, time.Now()), ".pdf", used))
+}
+
+// uniqueBatchExportPath returns a path within dir for the named file, adding a number to the name if an earlier file
+// in the same batch already claimed it.
+func uniqueBatchExportPath(dir, name, ext string, used map[string]bool) string {
+	p := filepath.Join(dir, name+ext)
+	for i := 2; used[p]; i++ {
+		p = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+	}
+	used[p] = true
+	return p
+}
+
+// sheetFilesIn returns the paths of the character sheets within dir, sorted.
+func sheetFilesIn(dir string, recursive bool) ([]string, error) {
+	var list []string
+	err := filepath.WalkDir(dir, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && (!recursive || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(p), gurps.SheetExt) {
+			list = append(list, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	slices.Sort(list)
+	return list, nil
+}
+
+func reportBatchExport(exported int, failures []string) {
+	if len(failures) != 0 {
+		unison.ErrorDialogWithMessage(fmt.Sprintf(i18n.Text("Exported %d PDF(s); %d failed"), exported, len(failures)),
+			strings.Join(failures, "\n"))
+		return
+	}
+	dialog, err := unison.NewDialog(nil, nil, unison.NewMessagePanel(i18n.Text("Export Complete"),
+		fmt.Sprintf(i18n.Text("Exported %d PDF(s)."), exported)), []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
@@ -16,8 +16,8 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/i18n"
-	"github.com/richardwilkes/toolbox/xio"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/paintstyle"
@@ -83,7 +83,7 @@ func NewBodyPanel(entity *gurps.Entity, targetMgr *TargetMgr) *BodyPanel {
 
 func (p *BodyPanel) addContent(locations *gurps.Body) {
 	p.RemoveAllChildren()
-	p.AddChild(NewPageHeader(i18n.Text("Roll"), 1))
+	p.AddChild(p.createRollHeader())
 	p.AddChild(unison.NewPanel())
 	p.AddChild(NewPageHeader(i18n.Text("Location"), 2))
 	p.AddChild(unison.NewPanel())
@@ -132,6 +132,10 @@ func (p *BodyPanel) addTable(bodyType *gurps.Body, depth int) {
 		if depth > 0 {
 			label.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: float32(10 * depth)}))
 		}
+		if chance := location.RollChanceText(); chance != "" && rollRange != "" {
+			label.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("%s chance of a roll of %s landing here"), chance,
+				bodyType.TableRoll().String()))
+		}
 		p.AddChild(label)
 
 		if i == 0 && depth == 0 {
@@ -172,6 +176,29 @@ func (p *BodyPanel) addTable(bodyType *gurps.Body, depth int) {
 	}
 }
 
+// createRollHeader creates the header for the roll column, which rolls a random hit location when clicked.
+func (p *BodyPanel) createRollHeader() *unison.Label {
+	header := NewPageHeader(i18n.Text("Roll"), 1)
+	header.Tooltip = newWrappedTooltip(i18n.Text("Click to roll a random hit location"))
+	header.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		return true
+	}
+	header.MouseUpCallback = func(where unison.Point, _ int, _ unison.Modifiers) bool {
+		if where.In(header.ContentRect(false)) {
+			steps := gurps.SheetSettingsFor(p.entity).BodyType.RollHitLocation(func(d *dice.Dice) int {
+				return d.Roll(false)
+			})
+			showRoll(p.entity, rollTitle(p.entity, i18n.Text("Random Hit Location")),
+				gurps.DescribeHitLocationRoll(steps))
+		}
+		return true
+	}
+	header.UpdateCursorCallback = func(_ unison.Point) *unison.Cursor {
+		return unison.PointingCursor()
+	}
+	return header
+}
+
 func (p *BodyPanel) createHitPenaltyField(location *gurps.HitLocation) unison.Paneler {
 	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		f.SetTitle(fmt.Sprintf("%+d", location.HitPenalty))
@@ -183,10 +210,9 @@ func (p *BodyPanel) createHitPenaltyField(location *gurps.HitLocation) unison.Pa
 
 func (p *BodyPanel) createDRField(location *gurps.HitLocation) unison.Paneler {
 	field := NewNonEditablePageFieldCenter(func(f *NonEditablePageField) {
-		var tooltip xio.ByteBuffer
-		f.SetTitle(location.DisplayDR(p.entity, &tooltip))
+		f.SetTitle(location.DisplayDR(p.entity, nil))
 		f.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("The DR covering the %s hit location%s"),
-			location.TableName, tooltip.String()))
+			location.TableName, location.DRTooltip(p.entity)))
 		MarkForLayoutWithinDockable(f)
 	})
 	field.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
@@ -12,21 +12,28 @@ package ux
 import (
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"slices"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 )
 
-var _ GroupedCloser = &bodySettingsDockable{}
+var (
+	_ GroupedCloser      = &bodySettingsDockable{}
+	_ FileBackedDockable = &bodySettingsDockable{}
+)
 
 type bodySettingsDockable struct {
 	SettingsDockable
 	owner          EntityPanel
+	filePath       string
 	targetMgr      *TargetMgr
 	undoMgr        *unison.UndoManager
 	body           *gurps.Body
@@ -45,7 +52,7 @@ type bodySettingsDockable struct {
 // ShowBodySettings the Body Settings. Pass in nil to edit the defaults or a sheet to edit the sheet's.
 func ShowBodySettings(owner EntityPanel) {
 	if Activate(func(d unison.Dockable) bool {
-		if s, ok := d.AsPanel().Self.(*bodySettingsDockable); ok && owner == s.owner {
+		if s, ok := d.AsPanel().Self.(*bodySettingsDockable); ok && owner == s.owner && s.filePath == "" {
 			return true
 		}
 		return false
@@ -66,6 +73,32 @@ func ShowBodySettings(owner EntityPanel) {
 		d.body = gurps.GlobalSettings().Sheet.BodyType.Clone(nil, nil)
 		d.TabTitle = i18n.Text("Default Body Type")
 	}
+	d.init()
+	d.Setup(d.addToStartToolbar, nil, d.initContent)
+}
+
+// NewBodyTypeDockableFromFile loads a body type from a file and creates an editor for it. Unlike the body type
+// settings, changes are saved back to the file rather than applied to a sheet or the defaults, making this suitable for
+// maintaining the hit location tables shared through libraries.
+func NewBodyTypeDockableFromFile(filePath string) (unison.Dockable, error) {
+	body, err := gurps.NewBodyFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		return nil, err
+	}
+	d := &bodySettingsDockable{
+		filePath:      filePath,
+		body:          body,
+		promptForSave: true,
+	}
+	d.Self = d
+	d.targetMgr = NewTargetMgr(d)
+	d.TabTitle = xfs.BaseName(filePath)
+	d.init()
+	d.SetupWithoutDisplay(d.addToStartToolbar, nil, d.initContent)
+	return d, nil
+}
+
+func (d *bodySettingsDockable) init() {
 	d.TabIcon = svg.BodyType
 	d.body.ResetTargetKeyPrefixes(d.targetMgr.NextPrefix)
 	d.originalCRC = d.body.CRC64()
@@ -76,7 +109,20 @@ func ShowBodySettings(owner EntityPanel) {
 	d.Resetter = d.reset
 	d.ModifiedCallback = d.modified
 	d.WillCloseCallback = d.willClose
-	d.Setup(d.addToStartToolbar, nil, d.initContent)
+}
+
+// BackingFilePath implements FileBackedDockable
+func (d *bodySettingsDockable) BackingFilePath() string {
+	return d.filePath
+}
+
+// SetBackingFilePath implements FileBackedDockable
+func (d *bodySettingsDockable) SetBackingFilePath(p string) {
+	if d.filePath != "" {
+		d.filePath = p
+		d.TabTitle = xfs.BaseName(p)
+		UpdateTitleForDockable(d)
+	}
 }
 
 func (d *bodySettingsDockable) UndoManager() *unison.UndoManager {
@@ -92,10 +138,16 @@ func (d *bodySettingsDockable) modified() bool {
 
 func (d *bodySettingsDockable) willClose() bool {
 	if d.promptForSave && d.originalCRC != d.body.CRC64() {
-		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Apply changes made to\n%s?"), d.Title()), "") {
+		prompt := i18n.Text("Apply changes made to\n%s?")
+		if d.filePath != "" {
+			prompt = i18n.Text("Save changes made to\n%s?")
+		}
+		switch unison.YesNoCancelDialog(fmt.Sprintf(prompt, d.Title()), "") {
 		case unison.ModalResponseDiscard:
 		case unison.ModalResponseOK:
-			d.apply()
+			if !d.apply() {
+				return false
+			}
 		case unison.ModalResponseCancel:
 			return false
 		}
@@ -116,12 +168,17 @@ func (d *bodySettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	toolbar.AddChild(helpButton)
 
 	d.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
-	d.applyButton.Tooltip = newWrappedTooltip(i18n.Text("Apply Changes"))
 	d.applyButton.SetEnabled(false)
-	d.applyButton.ClickCallback = func() {
-		d.apply()
-		d.promptForSave = false
-		d.AttemptClose()
+	if d.filePath != "" {
+		d.applyButton.Tooltip = newWrappedTooltip(i18n.Text("Save Changes"))
+		d.applyButton.ClickCallback = func() { d.apply() }
+	} else {
+		d.applyButton.Tooltip = newWrappedTooltip(i18n.Text("Apply Changes"))
+		d.applyButton.ClickCallback = func() {
+			d.apply()
+			d.promptForSave = false
+			d.AttemptClose()
+		}
 	}
 	toolbar.AddChild(d.applyButton)
 
@@ -217,11 +274,20 @@ func (d *bodySettingsDockable) save(filePath string) error {
 	return d.body.Save(filePath)
 }
 
-func (d *bodySettingsDockable) apply() {
+func (d *bodySettingsDockable) apply() bool {
 	d.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	if d.filePath != "" {
+		if err := d.body.Save(d.filePath); err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to save body type"), err)
+			return false
+		}
+		d.originalCRC = d.body.CRC64()
+		d.MarkModified(nil)
+		return true
+	}
 	if d.owner == nil {
 		gurps.GlobalSettings().Sheet.BodyType = d.body.Clone(nil, nil)
-		return
+		return true
 	}
 	entity := d.owner.Entity()
 	entity.SheetSettings.BodyType = d.body.Clone(entity, nil)
@@ -230,6 +296,7 @@ func (d *bodySettingsDockable) apply() {
 			s.SheetSettingsUpdated(entity, true)
 		}
 	}
+	return true
 }
 
 func (d *bodySettingsDockable) dataDragOver(where unison.Point, data map[string]any) bool {
@@ -16,6 +16,7 @@ import (
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
 )
 
 const hitLocationDragDataKey = "drag.body"
@@ -76,6 +77,17 @@ func (p *bodySettingsPanel) addHitLocation() {
 	}
 }
 
+func newWeightedTableCheckBox(d *bodySettingsDockable, body *gurps.Body) *CheckBox {
+	checkbox := NewCheckBox(d.targetMgr, body.KeyPrefix+"weighted", i18n.Text("Weighted"),
+		func() check.Enum { return check.FromBool(body.Weighted) },
+		func(state check.Enum) {
+			body.Weighted = state == check.On
+			body.Update(d.Entity())
+		})
+	checkbox.Tooltip = newWrappedTooltip(i18n.Text("When checked, each location's slots are a relative weight and the table is rolled with a single die having as many sides as the total of the slots, rather than with the roll above"))
+	return checkbox
+}
+
 func (p *bodySettingsPanel) createContent() *unison.Panel {
 	content := unison.NewPanel()
 	content.SetLayout(&unison.FlexLayout{
@@ -102,6 +114,8 @@ func (p *bodySettingsPanel) createContent() *unison.Panel {
 	field.SetMinimumTextWidthUsing("100d1000")
 	field.Tooltip = newWrappedTooltip(i18n.Text("The dice to roll on the table"))
 	content.AddChild(field)
+	content.AddChild(unison.NewPanel())
+	content.AddChild(newWeightedTableCheckBox(p.dockable, p.dockable.body))
 
 	wrapper := unison.NewPanel()
 	wrapper.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
@@ -94,19 +94,26 @@ type Calculator struct {
 	broadJumpResult            *unison.Label
 	throwingDistanceResult     *unison.Label
 	throwingDamageResult       *unison.Label
+	throwingAddButton          *unison.Button
 	hikingResult               *unison.Label
+	grapplingLevelResult       *unison.Label
+	grapplingTechniques        []*gurps.GrapplingTechnique
+	grapplingTechnique         *gurps.GrapplingTechnique
 	scale                      int
 	jumpingRunningStartYards   fxp.Int
 	throwingObjectWeight       fxp.Weight
 	jumpingExtraEffortPenalty  int
 	throwingExtraEffortPenalty int
 	hikingExtraEffortPenalty   int
+	grapplingModifier          int
+	grapplingOpponentLevel     int
 	terrainIndex               int
 	weatherIndex               int
 	usingSkis                  bool
 	usingSkates                bool
 	roadsAreCleared            bool
 	successfulHikingRoll       bool
+	grapplingControlPoints     bool
 }
 
 // DisplayCalculator displays the calculator for the given Sheet.
@@ -120,11 +127,13 @@ func DisplayCalculator(sheet *Sheet) {
 		return
 	}
 	c := &Calculator{
-		sheet:                sheet,
-		scale:                gurps.GlobalSettings().General.InitialEditorUIScale,
-		throwingObjectWeight: fxp.Weight(fxp.One),
-		terrainIndex:         slices.IndexFunc(terrain, func(t terrainModifier) bool { return t.Default }),
-		weatherIndex:         slices.IndexFunc(weather, func(t terrainModifier) bool { return t.Default }),
+		sheet:                  sheet,
+		scale:                  gurps.GlobalSettings().General.InitialEditorUIScale,
+		throwingObjectWeight:   fxp.Weight(fxp.One),
+		grapplingTechniques:    gurps.GrapplingTechniques(),
+		grapplingOpponentLevel: 10,
+		terrainIndex:           slices.IndexFunc(terrain, func(t terrainModifier) bool { return t.Default }),
+		weatherIndex:           slices.IndexFunc(weather, func(t terrainModifier) bool { return t.Default }),
 	}
 	c.Self = c
 
@@ -169,6 +178,7 @@ func UpdateCalculator(sheet *Sheet) {
 		c.updateJumpingResult()
 		c.updateThrowingResult()
 		c.updateHikingResult()
+		c.updateGrapplingResult()
 		c.content.MarkForLayoutRecursively()
 		c.content.MarkForRedraw()
 		break
@@ -217,6 +227,7 @@ func (c *Calculator) createContent() {
 	c.addJumpingSection()
 	c.addThrowingSection()
 	c.addHikingSection()
+	c.addGrapplingSection()
 }
 
 func (c *Calculator) addJumpingSection() {
@@ -356,6 +367,15 @@ func (c *Calculator) addThrowingSection() {
 	wrapper.AddChild(label)
 	c.throwingDamageResult = c.createResultLabel()
 	wrapper.AddChild(c.throwingDamageResult)
+	c.throwingAddButton = unison.NewButton()
+	c.throwingAddButton.SetTitle(i18n.Text("Add to Carried Equipment"))
+	c.throwingAddButton.Tooltip = newWrappedTooltip(i18n.Text("Adds the object to the carried equipment list, with a ranged weapon for throwing it at the distance and damage shown above. Remove it when it is no longer needed."))
+	c.throwingAddButton.ClickCallback = c.addThrownObject
+	c.throwingAddButton.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Start,
+	})
+	wrapper.AddChild(c.throwingAddButton)
 	c.updateThrowingResult()
 	c.content.AddChild(wrapper)
 }
@@ -504,6 +524,95 @@ func (c *Calculator) addHikingSection() {
 	c.content.AddChild(wrapper)
 }
 
+func (c *Calculator) addGrapplingSection() {
+	c.grapplingTechnique = c.grapplingTechniques[0]
+	c.content.AddChild(c.createHeader(i18n.Text("Grappling"), "B370", "Grappling", unison.StdVSpacing*3))
+
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("Technique:"))
+	wrapper.AddChild(label)
+	techniquePopup := unison.NewPopupMenu[*gurps.GrapplingTechnique]()
+	techniquePopup.AddItem(c.grapplingTechniques...)
+	techniquePopup.Select(c.grapplingTechnique)
+	techniquePopup.SelectionChangedCallback = func(popup *unison.PopupMenu[*gurps.GrapplingTechnique]) {
+		if item, ok := popup.Selected(); ok {
+			c.grapplingTechnique = item
+			c.updateGrapplingResult()
+		}
+	}
+	wrapper.AddChild(techniquePopup)
+
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("Modifier:"))
+	wrapper.AddChild(label)
+	wrapper.AddChild(NewIntegerField(nil, "", i18n.Text("Grappling Modifier"),
+		func() int { return c.grapplingModifier },
+		func(v int) {
+			c.grapplingModifier = v
+			c.updateGrapplingResult()
+		},
+		-99, 99, true, false))
+
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("Opponent's level:"))
+	wrapper.AddChild(label)
+	opponentField := NewIntegerField(nil, "", i18n.Text("Opponent's Level"),
+		func() int { return c.grapplingOpponentLevel },
+		func(v int) { c.grapplingOpponentLevel = v },
+		0, 99, false, false)
+	opponentField.Tooltip = newWrappedTooltip(i18n.Text("The level your opponent rolls against in a Quick Contest, including any modifiers"))
+	wrapper.AddChild(opponentField)
+	c.content.AddChild(wrapper)
+
+	controlPointsCheckbox := unison.NewCheckBox()
+	controlPointsCheckbox.SetTitle(i18n.Text("Use control points"))
+	controlPointsCheckbox.Tooltip = newWrappedTooltip(i18n.Text("Optional rule: a successful grapple also inflicts control points, rolled as thrust damage for your ST"))
+	controlPointsCheckbox.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	controlPointsCheckbox.ClickCallback = func() {
+		c.grapplingControlPoints = controlPointsCheckbox.State == check.On
+	}
+	c.content.AddChild(controlPointsCheckbox)
+
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	divider := unison.NewSeparator()
+	divider.SetBorder(unison.NewEmptyBorder(unison.NewVerticalInsets(unison.StdVSpacing * 2)))
+	divider.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	wrapper.AddChild(divider)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("Level:"))
+	wrapper.AddChild(label)
+	c.grapplingLevelResult = c.createResultLabel()
+	wrapper.AddChild(c.grapplingLevelResult)
+	rollButton := unison.NewButton()
+	rollButton.SetTitle(i18n.Text("Roll"))
+	rollButton.ClickCallback = c.rollGrappling
+	rollButton.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Start,
+	})
+	wrapper.AddChild(rollButton)
+	c.updateGrapplingResult()
+	c.content.AddChild(wrapper)
+}
+
 func (c *Calculator) createResultLabel() *unison.Label {
 	label := unison.NewLabel()
 	label.Font = &unison.DynamicFont{
@@ -713,142 +822,79 @@ func (c *Calculator) updateJumpingResult() {
 }
 
 func (c *Calculator) updateThrowingResult() {
-	if c.throwingObjectWeight <= 0 {
+	entity := c.sheet.Entity()
+	t := entity.Throw(c.throwingObjectWeight, c.throwingExtraEffortPenalty)
+	c.throwingAddButton.SetEnabled(t != nil)
+	switch {
+	case c.throwingObjectWeight <= 0:
 		c.throwingDistanceResult.SetTitle(i18n.Text("None"))
 		c.throwingDamageResult.SetTitle(i18n.Text("None"))
+	case t == nil:
+		c.throwingDistanceResult.SetTitle(i18n.Text("The object is too heavy for you to throw"))
+		c.throwingDamageResult.SetTitle(i18n.Text("None"))
+	default:
+		c.throwingDistanceResult.SetTitle(c.distanceToText(t.Inches))
+		c.throwingDamageResult.SetTitle(t.Damage.StringExtra(entity.SheetSettings.UseModifyingDicePlusAdds))
+	}
+	c.throwingDistanceResult.MarkForLayoutRecursivelyUpward()
+	c.throwingDamageResult.MarkForLayoutRecursivelyUpward()
+}
+
+// addThrownObject adds the object described by the throwing section to the sheet's carried equipment, with a ranged
+// weapon for throwing it.
+func (c *Calculator) addThrownObject() {
+	obj := gurps.NewThrownObject(c.sheet.Entity(), nil, c.throwingObjectWeight, c.throwingExtraEffortPenalty)
+	if obj == nil {
 		return
 	}
-	entity := c.sheet.Entity()
+	list := c.sheet.CarriedEquipment
+	InsertItems[*gurps.Equipment](c.sheet, list.Table, c.sheet.Entity().CarriedEquipmentList,
+		c.sheet.Entity().SetCarriedEquipmentList,
+		func(_ *unison.Table[*Node[*gurps.Equipment]]) []*Node[*gurps.Equipment] {
+			return list.provider.RootRows()
+		}, obj)
+}
 
-	// Determine bonuses for skills
-	var distanceBonus, damageBonus int
-	gurps.Traverse(func(s *gurps.Skill) bool {
-		switch strings.ToLower(s.NameWithReplacements()) {
-		case "throwing art":
-			s.UpdateLevel()
-			if s.LevelData.RelativeLevel >= fxp.One {
-				if distanceBonus < 2 {
-					distanceBonus = 2
-				}
-				if damageBonus < 2 {
-					damageBonus = 2
-				}
-			} else if s.LevelData.RelativeLevel >= 0 {
-				if distanceBonus < 1 {
-					distanceBonus = 1
-				}
-				if damageBonus < 1 {
-					damageBonus = 1
-				}
-			}
-		case "throwing":
-			s.UpdateLevel()
-			if s.LevelData.RelativeLevel >= fxp.Two {
-				if distanceBonus < 2 {
-					distanceBonus = 2
-				}
-			} else if s.LevelData.RelativeLevel >= fxp.One {
-				if distanceBonus < 1 {
-					distanceBonus = 1
-				}
-			}
-		}
-		return false
-	}, true, true, entity.Skills...)
+func (c *Calculator) grapplingLevel() int {
+	return c.sheet.Entity().GrapplingTechniqueLevel(c.grapplingTechnique) + c.grapplingModifier
+}
 
-	// Determine distance modifier based on weight ratio
-	st := entity.LiftingStrength() - entity.LiftingStrengthBonus
-	if c.throwingExtraEffortPenalty < 0 {
-		st = st.Mul(fxp.From(-5*c.throwingExtraEffortPenalty).Div(fxp.Hundred) + fxp.One).Trunc()
-	}
-	st += fxp.From(distanceBonus)
-	basicLift := entity.BasicLiftForST(st)
-	var weightRatio fxp.Int
-	if basicLift > 0 {
-		weightRatio = fxp.Int(c.throwingObjectWeight).Div(fxp.Int(basicLift))
+func (c *Calculator) updateGrapplingResult() {
+	_, source := c.sheet.Entity().GrapplingLevel()
+	if c.grapplingTechnique.Base == gurps.GrapplingBaseST {
+		source = i18n.Text("ST")
 	}
-	var modifier fxp.Int
-	switch {
-	case weightRatio <= fxp.Twentieth:
-		modifier = fxp.ThreeAndAHalf
-	case weightRatio <= fxp.Tenth:
-		modifier = fxp.TwoAndAHalf
-	case weightRatio <= fxp.PointOneFive:
-		modifier = fxp.Two
-	case weightRatio <= fxp.Fifth:
-		modifier = fxp.OneAndAHalf
-	case weightRatio <= fxp.Quarter:
-		modifier = fxp.OnePointTwo
-	case weightRatio <= fxp.ThreeTenths:
-		modifier = fxp.OnePointOne
-	case weightRatio <= fxp.TwoFifths:
-		modifier = fxp.One
-	case weightRatio <= fxp.Half:
-		modifier = fxp.FourFifths
-	case weightRatio <= fxp.ThreeQuarters:
-		modifier = fxp.SevenTenths
-	case weightRatio <= fxp.One:
-		modifier = fxp.ThreeFifths
-	case weightRatio <= fxp.OneAndAHalf:
-		modifier = fxp.TwoFifths
-	case weightRatio <= fxp.Two:
-		modifier = fxp.ThreeTenths
-	case weightRatio <= fxp.TwoAndAHalf:
-		modifier = fxp.Quarter
-	case weightRatio <= fxp.Three:
-		modifier = fxp.Fifth
-	case weightRatio <= fxp.Four:
-		modifier = fxp.PointOneFive
-	case weightRatio <= fxp.Five:
-		modifier = fxp.PointOneTwo
-	case weightRatio <= fxp.Six:
-		modifier = fxp.Tenth
-	case weightRatio <= fxp.Seven:
-		modifier = fxp.PointZeroNine
-	case weightRatio <= fxp.Eight:
-		modifier = fxp.PointZeroEight
-	case weightRatio <= fxp.Nine:
-		modifier = fxp.PointZeroSeven
-	case weightRatio <= fxp.Ten:
-		modifier = fxp.PointZeroSix
-	case weightRatio <= fxp.Twelve:
-		modifier = fxp.Twentieth
-	}
-	inches := st.Mul(modifier).Mul(fxp.ThirtySix).Trunc()
-	if inches <= fxp.One {
-		c.throwingDistanceResult.SetTitle(i18n.Text("The object is too heavy for you to throw"))
-		c.throwingDamageResult.SetTitle(i18n.Text("None"))
-		return
+	title := fmt.Sprintf(i18n.Text("%d (%s)"), c.grapplingLevel(), source)
+	if c.grapplingTechnique.Contest != "" {
+		title += fmt.Sprintf(i18n.Text(", contested by %s"), c.grapplingTechnique.Contest)
 	}
+	c.grapplingLevelResult.SetTitle(title)
+	c.grapplingLevelResult.MarkForLayoutRecursivelyUpward()
+}
 
-	// Determine damage based on weight ratio
-	thrust := entity.Thrust()
-	thrust.Modifier += thrust.Count * damageBonus
-	basicLift = entity.BasicLiftForST(st - fxp.From(distanceBonus))
-	if basicLift > 0 {
-		weightRatio = fxp.Int(c.throwingObjectWeight).Div(fxp.Int(basicLift))
+// rollGrappling rolls the selected technique, as a Quick Contest if it calls for one, and displays the outcome.
+func (c *Calculator) rollGrappling() {
+	t := c.grapplingTechnique
+	title := fmt.Sprintf(i18n.Text("%s (%s)"), t.Name, t.PageRef)
+	var detail string
+	var success bool
+	if t.Contest != "" {
+		contest := gurps.RollQuickContest(c.grapplingLevel(), c.grapplingOpponentLevel)
+		detail = contest.String()
+		success = contest.Winner() > 0
 	} else {
-		weightRatio = 0
+		r := gurps.RollAgainst(c.grapplingLevel())
+		detail = r.String()
+		if r.Success {
+			detail += "\n" + i18n.Text("Your opponent may defend normally.")
+		}
+		success = r.Success
 	}
-	switch {
-	case weightRatio <= fxp.Eighth:
-		thrust.Modifier -= thrust.Count * 2
-	case weightRatio <= fxp.Quarter:
-		thrust.Modifier -= thrust.Count
-	case weightRatio <= fxp.Half:
-	case weightRatio <= fxp.One:
-		thrust.Modifier += thrust.Count
-	case weightRatio <= fxp.Two:
-	case weightRatio <= fxp.Four:
-		thrust.Modifier -= thrust.Count / 2
-	default:
-		thrust.Modifier -= thrust.Count
+	if success && c.grapplingControlPoints {
+		cp := c.sheet.Entity().ControlPoints()
+		detail += "\n" + fmt.Sprintf(i18n.Text("Control points inflicted: %d (%s)"), max(cp.Roll(false), 1), cp)
 	}
-
-	c.throwingDistanceResult.SetTitle(c.distanceToText(inches))
-	c.throwingDamageResult.SetTitle(thrust.StringExtra(entity.SheetSettings.UseModifyingDicePlusAdds))
-	c.throwingDistanceResult.MarkForLayoutRecursivelyUpward()
-	c.throwingDamageResult.MarkForLayoutRecursivelyUpward()
+	showRoll(c.sheet.Entity(), rollTitle(c.sheet.Entity(), title), detail)
 }
 
 func (c *Calculator) updateHikingResult() {
@@ -13,34 +13,48 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
 )
 
 var (
-	_ FileBackedDockable = &Campaign{}
-	_ unison.TabCloser   = &Campaign{}
+	_ FileBackedDockable           = &Campaign{}
+	_ unison.UndoManagerProvider   = &Campaign{}
+	_ ModifiableRoot               = &Campaign{}
+	_ EntityPanel                  = &Campaign{}
+	_ gurps.SheetSettingsResponder = &Campaign{}
 )
 
-// Campaign holds the view for a GURPS campaign.
+// Campaign holds the view for a GURPS campaign, which groups the sheets, NPC libraries, templates and notes used in
+// it and holds the sheet settings its member sheets should use.
 type Campaign struct {
 	unison.Panel
 	path              string
+	undoMgr           *unison.UndoManager
 	toolbar           *unison.Panel
 	scroll            *unison.ScrollPanel
 	content           *unison.Panel
 	campaign          *gurps.Campaign
+	settingsEntity    *gurps.Entity
 	crc               uint64
 	scale             int
 	needsSaveAsPrompt bool
 }
 
+type campaignCategory struct {
+	title   string
+	members []*gurps.CampaignMember
+}
+
 // NewCampaignFromFile loads a GURPS campaign file and creates a new unison.Dockable for it.
 func NewCampaignFromFile(filePath string) (unison.Dockable, error) {
 	campaign, err := gurps.NewCampaignFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
@@ -54,8 +68,12 @@ func NewCampaignFromFile(filePath string) (unison.Dockable, error) {
 
 // NewCampaign creates a new unison.Dockable for GURPS campaign files.
 func NewCampaign(filePath string, campaign *gurps.Campaign) *Campaign {
+	if campaign.SheetSettings == nil {
+		campaign.SheetSettings = gurps.GlobalSettings().SheetSettings().Clone(nil)
+	}
 	c := &Campaign{
 		path:              filePath,
+		undoMgr:           unison.NewUndoManager(200, func(err error) { errs.Log(err) }),
 		scroll:            unison.NewScrollPanel(),
 		campaign:          campaign,
 		crc:               campaign.CRC64(),
@@ -63,6 +81,10 @@ func NewCampaign(filePath string, campaign *gurps.Campaign) *Campaign {
 		needsSaveAsPrompt: true,
 	}
 	c.Self = c
+	// The sheet settings editor works with an entity, so give it one that shares the campaign's settings.
+	c.settingsEntity = gurps.NewEntity()
+	c.settingsEntity.SheetSettings = campaign.SheetSettings
+	campaign.SheetSettings.SetOwningEntity(c.settingsEntity)
 	c.SetLayout(&unison.FlexLayout{
 		Columns: 1,
 		HAlign:  align.Fill,
@@ -72,14 +94,30 @@ func NewCampaign(filePath string, campaign *gurps.Campaign) *Campaign {
 		c.RequestFocus()
 		return false
 	}
-	c.scroll.SetContent(c.createContent(), behavior.Unmodified, behavior.Unmodified)
+	c.content = unison.NewPanel()
+	c.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	c.content.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	c.scroll.SetContent(c.content, behavior.HintedFill, behavior.Fill)
 	c.scroll.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
 		VAlign: align.Fill,
 		HGrab:  true,
 		VGrab:  true,
 	})
+	c.createToolbar()
+	c.AddChild(c.toolbar)
+	c.AddChild(c.scroll)
+	c.InstallCmdHandlers(SaveItemID, func(_ any) bool { return c.Modified() }, func(_ any) { c.save(false) })
+	c.InstallCmdHandlers(SaveAsItemID, unison.AlwaysEnabled, func(_ any) { c.save(true) })
+	c.rebuild()
+	return c
+}
 
+func (c *Campaign) createToolbar() {
 	helpButton := unison.NewSVGButton(svg.Help)
 	helpButton.Tooltip = newWrappedTooltip(i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Campaign") }
@@ -105,19 +143,410 @@ func NewCampaign(filePath string, campaign *gurps.Campaign) *Campaign {
 			c.scroll,
 		),
 	)
+
+	addSheetsButton := unison.NewSVGButton(svg.GCSSheet)
+	addSheetsButton.Tooltip = newWrappedTooltip(i18n.Text("Add all open sheets as player characters"))
+	addSheetsButton.ClickCallback = c.addOpenSheets
+	c.toolbar.AddChild(addSheetsButton)
+
+	addFilesButton := unison.NewSVGButton(svg.CircledAdd)
+	addFilesButton.Tooltip = newWrappedTooltip(i18n.Text("Add sheets, templates, libraries and notes"))
+	addFilesButton.ClickCallback = c.addFiles
+	c.toolbar.AddChild(addFilesButton)
+
+	addFolderButton := unison.NewSVGButton(svg.NewFolder)
+	addFolderButton.Tooltip = newWrappedTooltip(i18n.Text("Add a folder of NPC sheets"))
+	addFolderButton.ClickCallback = c.addFolder
+	c.toolbar.AddChild(addFolderButton)
+
+	settingsButton := unison.NewSVGButton(svg.Settings)
+	settingsButton.Tooltip = newWrappedTooltip(i18n.Text("Campaign sheet settings, which take precedence over the default sheet settings for member sheets"))
+	settingsButton.ClickCallback = func() { ShowSheetSettings(c) }
+	c.toolbar.AddChild(settingsButton)
+
+	webhookButton := unison.NewSVGButton(svg.Link)
+	webhookButton.Tooltip = newWrappedTooltip(i18n.Text("Set the Discord webhook that rolls made from member sheets are posted to"))
+	webhookButton.ClickCallback = c.editRollWebhook
+	c.toolbar.AddChild(webhookButton)
+
+	partyButton := unison.NewButton()
+	partyButton.SetTitle(i18n.Text("Party Overview"))
+	partyButton.Tooltip = newWrappedTooltip(i18n.Text("Show the player characters side by side"))
+	partyButton.ClickCallback = func() { DisplayPartyOverview(c) }
+	c.toolbar.AddChild(partyButton)
+
+	applyButton := unison.NewButton()
+	applyButton.SetTitle(i18n.Text("Apply Settings to Sheets"))
+	applyButton.Tooltip = newWrappedTooltip(i18n.Text("Replace the sheet settings of every member sheet with the campaign's sheet settings"))
+	applyButton.ClickCallback = c.applySettingsToMembers
+	c.toolbar.AddChild(applyButton)
+
 	c.toolbar.SetLayout(&unison.FlexLayout{
 		Columns:  len(c.toolbar.Children()),
 		HSpacing: unison.StdHSpacing,
+		VAlign:   align.Middle,
 	})
+}
 
-	c.AddChild(c.toolbar)
-	c.AddChild(c.scroll)
-	return c
+func (c *Campaign) categories() []*campaignCategory {
+	pcs := &campaignCategory{title: i18n.Text("Player Characters")}
+	npcs := &campaignCategory{title: i18n.Text("NPCs")}
+	templates := &campaignCategory{title: i18n.Text("Templates")}
+	libraries := &campaignCategory{title: i18n.Text("Libraries")}
+	notes := &campaignCategory{title: i18n.Text("Notes")}
+	for _, one := range c.campaign.Members {
+		var category *campaignCategory
+		switch strings.ToLower(filepath.Ext(one.Path)) {
+		case gurps.SheetExt:
+			if one.NPC {
+				category = npcs
+			} else {
+				category = pcs
+			}
+		case gurps.TemplatesExt:
+			category = templates
+		case gurps.NotesExt, gurps.MarkdownExt:
+			category = notes
+		default:
+			if one.NPC {
+				category = npcs
+			} else {
+				category = libraries
+			}
+		}
+		category.members = append(category.members, one)
+	}
+	return []*campaignCategory{pcs, npcs, templates, libraries, notes}
 }
 
-func (c *Campaign) createContent() unison.Paneler {
-	c.content = unison.NewPanel()
-	return c.content
+func (c *Campaign) rebuild() {
+	c.content.RemoveAllChildren()
+	if len(c.campaign.Members) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("Nothing has been added to the campaign yet"))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 4})
+		c.content.AddChild(label)
+	}
+	for _, category := range c.categories() {
+		if len(category.members) == 0 {
+			continue
+		}
+		header := unison.NewLabel()
+		header.Font = unison.EmphasizedSystemFont
+		header.SetTitle(category.title)
+		header.SetBorder(unison.NewEmptyBorder(unison.Insets{Top: unison.StdVSpacing * 2}))
+		header.SetLayoutData(&unison.FlexLayoutData{HSpan: 4})
+		c.content.AddChild(header)
+		for _, one := range category.members {
+			c.addMemberRow(one)
+		}
+	}
+	c.content.MarkForLayoutRecursively()
+	c.MarkForRedraw()
+	UpdateTitleForDockable(c)
+	UpdatePartyOverview(c)
+}
+
+func (c *Campaign) addMemberRow(member *gurps.CampaignMember) {
+	remove := unison.NewSVGButton(svg.Trash)
+	remove.Tooltip = newWrappedTooltip(i18n.Text("Remove from the campaign"))
+	remove.ClickCallback = func() {
+		c.applyChange(i18n.Text("Remove From Campaign"), func(campaign *gurps.Campaign) { campaign.RemoveMember(member.Path) })
+	}
+	c.content.AddChild(remove)
+
+	name := filepath.Base(member.Path)
+	if !isDir(member.Path) {
+		name = fs.BaseName(member.Path)
+	}
+	link := unison.NewLink(name, i18n.Text("Open"), "", unison.DefaultLinkTheme,
+		func(_ unison.Paneler, _ string) { c.openMember(member) })
+	c.content.AddChild(link)
+
+	if member.IsSheet() {
+		npc := unison.NewCheckBox()
+		npc.SetTitle(i18n.Text("NPC"))
+		npc.State = check.FromBool(member.NPC)
+		npc.ClickCallback = func() {
+			c.applyChange(i18n.Text("Toggle NPC"), func(campaign *gurps.Campaign) {
+				if m := campaign.Member(member.Path); m != nil {
+					m.NPC = !m.NPC
+				}
+			})
+		}
+		c.content.AddChild(npc)
+	} else {
+		c.content.AddChild(unison.NewPanel())
+	}
+
+	location := unison.NewLabel()
+	location.SetTitle(filepath.Dir(member.Path))
+	location.Tooltip = newWrappedTooltip(member.Path)
+	location.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	c.content.AddChild(location)
+}
+
+func isDir(filePath string) bool {
+	fi, err := os.Stat(filePath)
+	return err == nil && fi.IsDir()
+}
+
+// openMember opens the member. Sheets are given the campaign's sheet settings as they are opened, while folders of
+// NPCs prompt for the sheets within them to open.
+func (c *Campaign) openMember(member *gurps.CampaignMember) {
+	if isDir(member.Path) {
+		dialog := unison.NewOpenDialog()
+		dialog.SetAllowsMultipleSelection(true)
+		dialog.SetResolvesAliases(true)
+		dialog.SetAllowedExtensions(gurps.SheetExt)
+		dialog.SetCanChooseDirectories(false)
+		dialog.SetCanChooseFiles(true)
+		dialog.SetInitialDirectory(member.Path)
+		if dialog.RunModal() {
+			for _, p := range dialog.Paths() {
+				c.openFile(p)
+			}
+		}
+		return
+	}
+	c.openFile(member.Path)
+}
+
+func (c *Campaign) openFile(filePath string) {
+	dockable, _ := OpenFile(filePath, 0)
+	if sheet, ok := dockable.(*Sheet); ok {
+		c.applySettingsToSheet(sheet)
+	}
+}
+
+// campaignFor returns the open campaign that the entity's sheet is a member of, or nil. Rolls made without an entity,
+// such as those from the dice roller, belong to the open campaign only when it is the sole one.
+func campaignFor(entity *gurps.Entity) *Campaign {
+	var campaigns []*Campaign
+	var sheetPath string
+	for _, one := range AllDockables() {
+		switch d := one.(type) {
+		case *Campaign:
+			campaigns = append(campaigns, d)
+		case *Sheet:
+			if entity != nil && d.entity == entity {
+				sheetPath = filepath.Clean(d.BackingFilePath())
+			}
+		}
+	}
+	if entity == nil {
+		if len(campaigns) == 1 {
+			return campaigns[0]
+		}
+		return nil
+	}
+	if sheetPath == "" {
+		return nil
+	}
+	for _, one := range campaigns {
+		if one.campaign.Member(sheetPath) != nil {
+			return one
+		}
+	}
+	return nil
+}
+
+func (c *Campaign) editRollWebhook() {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	title := i18n.Text("Discord Webhook")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	webhookURL := c.campaign.RollWebhookURL
+	field := NewStringField(nil, "", title, func() string { return webhookURL },
+		func(s string) { webhookURL = strings.TrimSpace(s) })
+	field.ValidateCallback = func() bool { return gurps.ValidateRollWebhookURL(strings.TrimSpace(field.Text())) == nil }
+	field.Watermark = i18n.Text("None")
+	field.SetMinimumTextWidthUsing("https://discord.com/api/webhooks/000000000000000000/")
+	field.Tooltip = newWrappedTooltip(i18n.Text(`When set, rolls made from the campaign's sheets are also posted to this webhook, so that others at the table can see them.
+The webhook is kept in the campaign file and is never saved into sheet files.`))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Set")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK || webhookURL == c.campaign.RollWebhookURL ||
+		gurps.ValidateRollWebhookURL(webhookURL) != nil {
+		return
+	}
+	c.undoMgr.Add(&unison.UndoEdit[string]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Set Discord Webhook"),
+		UndoFunc:   func(edit *unison.UndoEdit[string]) { c.applyRollWebhook(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[string]) { c.applyRollWebhook(edit.AfterData) },
+		BeforeData: c.campaign.RollWebhookURL,
+		AfterData:  webhookURL,
+	})
+	c.applyRollWebhook(webhookURL)
+}
+
+func (c *Campaign) applyRollWebhook(webhookURL string) {
+	c.campaign.RollWebhookURL = webhookURL
+	UpdateTitleForDockable(c)
+}
+
+func (c *Campaign) applySettingsToSheet(sheet *Sheet) {
+	if c.campaign.ApplySheetSettingsTo(sheet.entity) {
+		sheet.SheetSettingsUpdated(sheet.entity, true)
+	}
+}
+
+// applySettingsToMembers gives every member sheet the campaign's sheet settings. Sheets that are open are updated in
+// place and left for the user to save, while the rest are updated on disk.
+func (c *Campaign) applySettingsToMembers() {
+	open := make(map[string]*Sheet)
+	for _, one := range AllDockables() {
+		if sheet, ok := one.(*Sheet); ok {
+			open[filepath.Clean(sheet.BackingFilePath())] = sheet
+		}
+	}
+	var failures []string
+	for _, member := range c.campaign.Members {
+		if !member.IsSheet() {
+			continue
+		}
+		if sheet, ok := open[member.Path]; ok {
+			c.applySettingsToSheet(sheet)
+			continue
+		}
+		entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(member.Path)), filepath.Base(member.Path))
+		if err == nil {
+			backupBeforeBulkOperation(member.Path, entity, i18n.Text("Apply Campaign Settings"))
+			if c.campaign.ApplySheetSettingsTo(entity) {
+				entity.SyncWithHouseRules()
+				entity.Recalculate()
+				err = entity.Save(member.Path)
+			}
+		}
+		if err != nil {
+			errs.Log(err, "path", member.Path)
+			failures = append(failures, member.Path)
+		}
+	}
+	if len(failures) != 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to apply the campaign settings to:"),
+			strings.Join(failures, "\n"))
+	}
+}
+
+func (c *Campaign) addOpenSheets() {
+	var paths []string
+	for _, one := range AllDockables() {
+		if sheet, ok := one.(*Sheet); ok && !sheet.needsSaveAsPrompt && c.campaign.Member(sheet.BackingFilePath()) == nil {
+			paths = append(paths, sheet.BackingFilePath())
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	c.applyChange(i18n.Text("Add to Campaign"), func(campaign *gurps.Campaign) {
+		for _, p := range paths {
+			campaign.AddMember(p, false)
+		}
+	})
+}
+
+func (c *Campaign) addFiles() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt, gurps.TemplatesExt, gurps.TraitsExt, gurps.TraitModifiersExt,
+		gurps.SkillsExt, gurps.SpellsExt, gurps.EquipmentExt, gurps.EquipmentModifiersExt, gurps.NotesExt,
+		gurps.MarkdownExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	paths := dialog.Paths()
+	if len(paths) == 0 {
+		return
+	}
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(paths[0]))
+	c.applyChange(i18n.Text("Add to Campaign"), func(campaign *gurps.Campaign) {
+		for _, p := range paths {
+			campaign.AddMember(p, false)
+		}
+	})
+}
+
+func (c *Campaign) addFolder() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetCanChooseDirectories(true)
+	dialog.SetCanChooseFiles(false)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	p := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(p))
+	c.applyChange(i18n.Text("Add to Campaign"), func(campaign *gurps.Campaign) { campaign.AddMember(p, true) })
+}
+
+// applyChange makes an undoable change to the campaign's members and rebuilds the view.
+func (c *Campaign) applyChange(title string, f func(campaign *gurps.Campaign)) {
+	before := gurps.CloneCampaignMembers(c.campaign.Members)
+	f(c.campaign)
+	c.undoMgr.Add(&unison.UndoEdit[[]*gurps.CampaignMember]{
+		ID:         unison.NextUndoID(),
+		EditName:   title,
+		UndoFunc:   func(edit *unison.UndoEdit[[]*gurps.CampaignMember]) { c.applyMembers(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[[]*gurps.CampaignMember]) { c.applyMembers(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  gurps.CloneCampaignMembers(c.campaign.Members),
+	})
+	c.rebuild()
+}
+
+func (c *Campaign) applyMembers(list []*gurps.CampaignMember) {
+	c.campaign.Members = gurps.CloneCampaignMembers(list)
+	c.rebuild()
+}
+
+// Entity implements EntityPanel. The entity exists only to carry the campaign's sheet settings to the sheet settings
+// editor.
+func (c *Campaign) Entity() *gurps.Entity {
+	c.settingsEntity.Profile.Name = c.Title()
+	return c.settingsEntity
+}
+
+// SheetSettingsUpdated implements gurps.SheetSettingsResponder.
+func (c *Campaign) SheetSettingsUpdated(entity *gurps.Entity, _ bool) {
+	if entity == c.settingsEntity {
+		// Resetting or loading the settings replaces them rather than altering them in place.
+		c.campaign.SheetSettings = entity.SheetSettings
+		UpdateTitleForDockable(c)
+	}
+}
+
+// MarkModified implements ModifiableRoot.
+func (c *Campaign) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(c)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (c *Campaign) UndoManager() *unison.UndoManager {
+	return c.undoMgr
 }
 
 // TitleIcon implements workspace.FileBackedDockable
@@ -0,0 +1,69 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+func canCastSpell(table *unison.Table[*Node[*gurps.Spell]]) bool {
+	for _, row := range table.SelectedRows(false) {
+		if s := row.Data(); s != nil {
+			if entity := gurps.EntityFromNode(s); entity != nil && entity.ActiveThresholdMagic() != nil {
+				if _, ok := s.ThresholdCost(); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func castSpell(owner Rebuildable, table *unison.Table[*Node[*gurps.Spell]]) {
+	var entity *gurps.Entity
+	var before *gurps.RestState
+	for _, row := range table.SelectedRows(false) {
+		if s := row.Data(); s != nil {
+			if e := gurps.EntityFromNode(s); e != nil {
+				if entity == nil {
+					entity = e
+					before = entity.RestState()
+				}
+				entity.CastSpell(s)
+			}
+		}
+	}
+	if entity == nil {
+		return
+	}
+	if mgr := unison.UndoManagerFor(table); mgr != nil {
+		mgr.Add(&unison.UndoEdit[*gurps.RestState]{
+			ID:       unison.NextUndoID(),
+			EditName: castSpellAction.Title,
+			UndoFunc: func(edit *unison.UndoEdit[*gurps.RestState]) {
+				applyCastSpellState(owner, entity, edit.BeforeData)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[*gurps.RestState]) {
+				applyCastSpellState(owner, entity, edit.AfterData)
+			},
+			BeforeData: before,
+			AfterData:  entity.RestState(),
+		})
+	}
+	MarkModified(owner)
+	owner.Rebuild(true)
+}
+
+func applyCastSpellState(owner Rebuildable, entity *gurps.Entity, state *gurps.RestState) {
+	entity.ApplyRestState(state)
+	MarkModified(owner)
+	owner.Rebuild(true)
+}
@@ -0,0 +1,481 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/combat"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ FileBackedDockable         = &CombatTracker{}
+	_ unison.UndoManagerProvider = &CombatTracker{}
+	_ ModifiableRoot             = &CombatTracker{}
+)
+
+// CombatTracker holds the view for tracking the participants of a combat.
+type CombatTracker struct {
+	unison.Panel
+	path              string
+	undoMgr           *unison.UndoManager
+	toolbar           *unison.Panel
+	roundLabel        *unison.Label
+	scroll            *unison.ScrollPanel
+	content           *unison.Panel
+	combat            *gurps.Combat
+	crc               uint64
+	scale             int
+	needsSaveAsPrompt bool
+}
+
+// NewCombatTrackerFromFile loads a combat file and creates a new unison.Dockable for it.
+func NewCombatTrackerFromFile(filePath string) (unison.Dockable, error) {
+	c, err := gurps.NewCombatFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		return nil, err
+	}
+	t := NewCombatTracker(filePath, c)
+	t.needsSaveAsPrompt = false
+	return t, nil
+}
+
+// NewCombatTracker creates a new unison.Dockable for combat files.
+func NewCombatTracker(filePath string, c *gurps.Combat) *CombatTracker {
+	t := &CombatTracker{
+		path:              filePath,
+		undoMgr:           unison.NewUndoManager(200, func(err error) { errs.Log(err) }),
+		scroll:            unison.NewScrollPanel(),
+		combat:            c,
+		crc:               c.CRC64(),
+		scale:             gurps.GlobalSettings().General.InitialEditorUIScale,
+		needsSaveAsPrompt: true,
+	}
+	t.Self = t
+	t.SetLayout(&unison.FlexLayout{
+		Columns: 1,
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+	})
+	t.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		t.RequestFocus()
+		return false
+	}
+	t.content = unison.NewPanel()
+	t.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	t.content.SetLayout(&unison.FlexLayout{
+		Columns:  11,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	t.scroll.SetContent(t.content, behavior.Unmodified, behavior.Unmodified)
+	t.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	t.createToolbar()
+	t.AddChild(t.toolbar)
+	t.AddChild(t.scroll)
+	t.InstallCmdHandlers(SaveItemID, func(_ any) bool { return t.Modified() }, func(_ any) { t.save(false) })
+	t.InstallCmdHandlers(SaveAsItemID, unison.AlwaysEnabled, func(_ any) { t.save(true) })
+	t.rebuild()
+	return t
+}
+
+func (t *CombatTracker) createToolbar() {
+	t.toolbar = unison.NewPanel()
+	t.toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	t.toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	t.toolbar.AddChild(NewDefaultInfoPop())
+	t.toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return t.scale },
+			func(scale int) { t.scale = scale },
+			nil,
+			false,
+			t.scroll,
+		),
+	)
+
+	addSheetsButton := unison.NewSVGButton(svg.GCSSheet)
+	addSheetsButton.Tooltip = newWrappedTooltip(i18n.Text("Add the characters from all open sheets"))
+	addSheetsButton.ClickCallback = t.addOpenSheets
+	t.toolbar.AddChild(addSheetsButton)
+
+	addFileButton := unison.NewSVGButton(svg.CircledAdd)
+	addFileButton.Tooltip = newWrappedTooltip(i18n.Text("Add characters from sheet files, such as those in a library of NPCs"))
+	addFileButton.ClickCallback = t.addFromFiles
+	t.toolbar.AddChild(addFileButton)
+
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Update names, initiative and maximum HP & FP from the sheets the combatants came from"))
+	refreshButton.ClickCallback = t.refreshFromSheets
+	t.toolbar.AddChild(refreshButton)
+
+	previousButton := unison.NewSVGButton(svg.Previous)
+	previousButton.Tooltip = newWrappedTooltip(i18n.Text("Previous turn"))
+	previousButton.ClickCallback = func() {
+		t.applyChange(i18n.Text("Previous Turn"), func(c *gurps.Combat) { c.PreviousTurn() })
+	}
+	t.toolbar.AddChild(previousButton)
+
+	nextButton := unison.NewSVGButton(svg.Next)
+	nextButton.Tooltip = newWrappedTooltip(i18n.Text("Next turn"))
+	nextButton.ClickCallback = func() {
+		t.applyChange(i18n.Text("Next Turn"), func(c *gurps.Combat) { c.NextTurn() })
+	}
+	t.toolbar.AddChild(nextButton)
+
+	endButton := unison.NewButton()
+	endButton.SetTitle(i18n.Text("End Combat"))
+	endButton.ClickCallback = func() {
+		t.applyChange(i18n.Text("End Combat"), func(c *gurps.Combat) { c.Reset() })
+	}
+	t.toolbar.AddChild(endButton)
+
+	t.roundLabel = unison.NewLabel()
+	t.toolbar.AddChild(t.roundLabel)
+
+	t.toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(t.toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VAlign:   align.Middle,
+	})
+}
+
+func (t *CombatTracker) rebuild() {
+	if t.combat.Started() {
+		t.roundLabel.SetTitle(fmt.Sprintf(i18n.Text("Round %d"), t.combat.Round))
+	} else {
+		t.roundLabel.SetTitle(i18n.Text("Not started"))
+	}
+	t.content.RemoveAllChildren()
+	if len(t.combat.Combatants) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No combatants have been added yet"))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 11})
+		t.content.AddChild(label)
+	} else {
+		for _, title := range []string{"", i18n.Text("Name"), i18n.Text("Speed"), i18n.Text("HP"), "",
+			i18n.Text("FP"), "", i18n.Text("Maneuver"), i18n.Text("Posture"), i18n.Text("Status"), ""} {
+			label := unison.NewLabel()
+			label.Font = unison.EmphasizedSystemFont
+			label.SetTitle(title)
+			t.content.AddChild(label)
+		}
+	}
+	current := t.combat.Current()
+	for _, one := range t.combat.Combatants {
+		t.addCombatantRow(one, one == current)
+	}
+	t.content.MarkForLayoutRecursively()
+	t.MarkForRedraw()
+	UpdateTitleForDockable(t)
+}
+
+func (t *CombatTracker) addCombatantRow(c *gurps.Combatant, current bool) {
+	marker := unison.NewLabel()
+	if current {
+		marker.SetTitle("▶")
+	}
+	t.content.AddChild(marker)
+
+	name := NewStringField(nil, "", i18n.Text("Name"), func() string { return c.Name },
+		func(s string) { c.Name = s })
+	name.SetMinimumTextWidthUsing("Sir Reginald the Bold")
+	if current {
+		name.Font = unison.EmphasizedFieldFont
+	}
+	if c.Source != "" {
+		name.Tooltip = newWrappedTooltip(c.Source)
+	}
+	t.content.AddChild(name)
+
+	speed := unison.NewLabel()
+	speed.HAlign = align.End
+	speed.SetTitle(c.Initiative.String())
+	speed.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Basic Speed %s, DX %s"), c.Initiative, c.Tiebreak))
+	t.content.AddChild(speed)
+
+	t.addPoolFields(&c.HP, c.MaxHP, i18n.Text("HP"))
+	t.addPoolFields(&c.FP, c.MaxFP, i18n.Text("FP"))
+
+	t.content.AddChild(NewPopup(nil, "", i18n.Text("Maneuver"), func() combat.Maneuver { return c.Maneuver },
+		func(m combat.Maneuver) { c.Maneuver = m }, combat.Maneuvers...))
+	t.content.AddChild(NewPopup(nil, "", i18n.Text("Posture"), func() combat.Posture { return c.Posture },
+		func(p combat.Posture) { c.Posture = p }, combat.Postures...))
+
+	status := unison.NewButton()
+	status.SetTitle(flagsText(c.Flags))
+	status.ClickCallback = func() { t.showFlagsMenu(status, c) }
+	t.content.AddChild(status)
+
+	remove := unison.NewSVGButton(svg.Trash)
+	remove.Tooltip = newWrappedTooltip(i18n.Text("Remove from the combat"))
+	remove.ClickCallback = func() {
+		t.applyChange(i18n.Text("Remove Combatant"), func(combat *gurps.Combat) { combat.Remove(c.ID) })
+	}
+	t.content.AddChild(remove)
+}
+
+func (t *CombatTracker) addPoolFields(value *fxp.Int, maximum fxp.Int, title string) {
+	field := NewDecimalField(nil, "", title, func() fxp.Int { return *value }, func(v fxp.Int) { *value = v },
+		fxp.Min, fxp.Max, false, false)
+	field.SetMinimumTextWidthUsing("-999")
+	t.content.AddChild(field)
+	label := unison.NewLabel()
+	label.SetTitle(fmt.Sprintf(i18n.Text("of %s"), maximum))
+	t.content.AddChild(label)
+}
+
+func flagsText(flags []combat.Flag) string {
+	if len(flags) == 0 {
+		return i18n.Text("None")
+	}
+	names := make([]string, len(flags))
+	for i, one := range flags {
+		names[i] = one.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (t *CombatTracker) showFlagsMenu(button *unison.Button, c *gurps.Combatant) {
+	f := unison.DefaultMenuFactory()
+	cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	for i, one := range combat.Flags {
+		flag := one
+		item := f.NewItem(unison.PopupMenuTemporaryBaseID+i+1, flag.String(), unison.KeyBinding{}, nil,
+			func(_ unison.MenuItem) {
+				t.applyChange(fmt.Sprintf(i18n.Text("Toggle %s"), flag), func(combat *gurps.Combat) {
+					for _, other := range combat.Combatants {
+						if other.ID == c.ID {
+							other.ToggleFlag(flag)
+						}
+					}
+				})
+			})
+		item.SetCheckState(check.FromBool(c.HasFlag(flag)))
+		cm.InsertItem(-1, item)
+	}
+	button.FlushDrawing()
+	cm.Popup(button.RectToRoot(button.ContentRect(true)), 0)
+	cm.Dispose()
+}
+
+// applyChange makes an undoable change to the combat and rebuilds the view.
+func (t *CombatTracker) applyChange(title string, f func(c *gurps.Combat)) {
+	before := t.combat.Clone()
+	f(t.combat)
+	t.undoMgr.Add(&unison.UndoEdit[*gurps.Combat]{
+		ID:         unison.NextUndoID(),
+		EditName:   title,
+		UndoFunc:   func(edit *unison.UndoEdit[*gurps.Combat]) { t.applyCombat(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[*gurps.Combat]) { t.applyCombat(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  t.combat.Clone(),
+	})
+	t.rebuild()
+}
+
+func (t *CombatTracker) applyCombat(c *gurps.Combat) {
+	t.combat.CombatData = c.Clone().CombatData
+	t.rebuild()
+}
+
+func (t *CombatTracker) hasSource(source string) bool {
+	for _, one := range t.combat.Combatants {
+		if source != "" && one.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *CombatTracker) addOpenSheets() {
+	var list []*gurps.Combatant
+	for _, one := range AllDockables() {
+		if s, ok := one.(*Sheet); ok && !t.hasSource(s.BackingFilePath()) {
+			list = append(list, gurps.NewCombatantFromEntity(s.Entity(), s.BackingFilePath()))
+		}
+	}
+	if len(list) != 0 {
+		t.applyChange(i18n.Text("Add Combatants"), func(c *gurps.Combat) { c.Add(list...) })
+	}
+}
+
+func (t *CombatTracker) addFromFiles() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	paths := dialog.Paths()
+	if len(paths) == 0 {
+		return
+	}
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(paths[0]))
+	list := make([]*gurps.Combatant, 0, len(paths))
+	for _, p := range paths {
+		entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+		if err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to load ")+fs.BaseName(p), err)
+			continue
+		}
+		// Library NPCs may be added more than once, so the source isn't checked here.
+		list = append(list, gurps.NewCombatantFromEntity(entity, p))
+	}
+	if len(list) != 0 {
+		t.applyChange(i18n.Text("Add Combatants"), func(c *gurps.Combat) { c.Add(list...) })
+	}
+}
+
+func (t *CombatTracker) refreshFromSheets() {
+	entities := make(map[string]*gurps.Entity)
+	for _, one := range AllDockables() {
+		if s, ok := one.(*Sheet); ok {
+			entities[s.BackingFilePath()] = s.Entity()
+		}
+	}
+	t.applyChange(i18n.Text("Update Combatants"), func(c *gurps.Combat) {
+		for _, one := range c.Combatants {
+			if one.Source == "" {
+				continue
+			}
+			entity, ok := entities[one.Source]
+			if !ok {
+				var err error
+				if entity, err = gurps.NewEntityFromFile(os.DirFS(filepath.Dir(one.Source)),
+					filepath.Base(one.Source)); err != nil {
+					errs.Log(err, "path", one.Source)
+					continue
+				}
+				entities[one.Source] = entity
+			}
+			one.UpdateFromEntity(entity)
+		}
+		c.SortByInitiative()
+	})
+}
+
+// MarkModified implements ModifiableRoot.
+func (t *CombatTracker) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(t)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (t *CombatTracker) UndoManager() *unison.UndoManager {
+	return t.undoMgr
+}
+
+// TitleIcon implements workspace.FileBackedDockable
+func (t *CombatTracker) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  gurps.FileInfoFor(t.path).SVG,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements workspace.FileBackedDockable
+func (t *CombatTracker) Title() string {
+	return fs.BaseName(t.path)
+}
+
+func (t *CombatTracker) String() string {
+	return t.Title()
+}
+
+// Tooltip implements workspace.FileBackedDockable
+func (t *CombatTracker) Tooltip() string {
+	return t.path
+}
+
+// Modified implements workspace.FileBackedDockable
+func (t *CombatTracker) Modified() bool {
+	return t.crc != t.combat.CRC64()
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (t *CombatTracker) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(t)
+}
+
+// AttemptClose implements unison.TabCloser
+func (t *CombatTracker) AttemptClose() bool {
+	if !CloseGroup(t) {
+		return false
+	}
+	if t.Modified() {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), t.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			if !t.save(false) {
+				return false
+			}
+		case unison.ModalResponseCancel:
+			return false
+		}
+	}
+	return AttemptCloseForDockable(t)
+}
+
+// BackingFilePath implements workspace.FileBackedDockable
+func (t *CombatTracker) BackingFilePath() string {
+	return t.path
+}
+
+// SetBackingFilePath implements workspace.FileBackedDockable
+func (t *CombatTracker) SetBackingFilePath(p string) {
+	t.path = p
+	UpdateTitleForDockable(t)
+}
+
+func (t *CombatTracker) save(forceSaveAs bool) bool {
+	success := false
+	if forceSaveAs || t.needsSaveAsPrompt {
+		success = SaveDockableAs(t, gurps.CombatExt, t.combat.Save, func(path string) {
+			t.crc = t.combat.CRC64()
+			t.path = path
+		})
+	} else {
+		success = SaveDockable(t, t.combat.Save, func() { t.crc = t.combat.CRC64() })
+	}
+	if success {
+		t.needsSaveAsPrompt = false
+	}
+	return success
+}
@@ -0,0 +1,456 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const companionStatColumns = 8
+
+var (
+	_ unison.Dockable            = &companionsEditor{}
+	_ unison.TabCloser           = &companionsEditor{}
+	_ ModifiableRoot             = &companionsEditor{}
+	_ unison.UndoManagerProvider = &companionsEditor{}
+	_ GroupedCloser              = &companionsEditor{}
+	_ Rebuildable                = &companionsEditor{}
+)
+
+type companionsEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.Companion
+	current          []*gurps.Companion
+	promptForSave    bool
+}
+
+func displayCompanionsEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*companionsEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &companionsEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.CloneCompanionList(entity.Companions),
+		current: gurps.CloneCompanionList(entity.Companions),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 3,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+}
+
+func (e *companionsEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Companion or Mount"))
+	addButton.ClickCallback = func() {
+		e.current = slices.Insert(e.current, 0, gurps.NewCompanion())
+		e.rebuildContent()
+	}
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *companionsEditor) rebuildContent() {
+	e.content.RemoveAllChildren()
+	e.initContent()
+	e.content.Pack()
+	MarkForLayoutWithinDockable(e.content)
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+}
+
+func (e *companionsEditor) initContent() {
+	for _, one := range e.current {
+		e.content.AddChild(e.createCompanionPanel(one))
+	}
+}
+
+func (e *companionsEditor) createCompanionPanel(c *gurps.Companion) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	panel.AddChild(e.createIdentityRow(c))
+	panel.AddChild(e.createStats(c))
+	panel.AddChild(e.createAttacks(c))
+
+	notesText := i18n.Text("Notes")
+	notes := NewMultiLineStringField(nil, "", notesText,
+		func() string { return c.Notes },
+		func(value string) {
+			c.Notes = value
+			MarkModified(e.content)
+		})
+	notes.Watermark = notesText
+	notes.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(notes)
+	return panel
+}
+
+func (e *companionsEditor) createIdentityRow(c *gurps.Companion) *unison.Panel {
+	row := unison.NewPanel()
+	row.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VAlign:   align.Middle,
+	})
+	row.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current, c); i != -1 {
+			e.current = slices.Delete(e.current, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	row.AddChild(deleteButton)
+	e.addStringField(row, i18n.Text("Name"), &c.Name)
+	e.addStringField(row, i18n.Text("Kind, e.g. Riding Horse"), &c.Kind)
+	return row
+}
+
+func (e *companionsEditor) addStringField(parent *unison.Panel, title string, value *string) {
+	field := NewStringField(nil, "", title,
+		func() string { return *value },
+		func(s string) {
+			*value = s
+			MarkModified(e.content)
+		})
+	field.Watermark = title
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	parent.AddChild(field)
+}
+
+func (e *companionsEditor) createStats(c *gurps.Companion) *unison.Panel {
+	stats := unison.NewPanel()
+	stats.SetLayout(&unison.FlexLayout{
+		Columns:  companionStatColumns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	e.addIntegerField(stats, i18n.Text("ST"), &c.ST, 0, 9999)
+	e.addIntegerField(stats, i18n.Text("DX"), &c.DX, 0, 99)
+	e.addIntegerField(stats, i18n.Text("IQ"), &c.IQ, 0, 99)
+	e.addIntegerField(stats, i18n.Text("HT"), &c.HT, 0, 99)
+	e.addIntegerField(stats, i18n.Text("Will"), &c.Will, 0, 99)
+	e.addIntegerField(stats, i18n.Text("Per"), &c.Per, 0, 99)
+	speedText := i18n.Text("Speed")
+	stats.AddChild(NewFieldLeadingLabel(speedText, false))
+	stats.AddChild(NewDecimalField(nil, "", speedText,
+		func() fxp.Int { return c.Speed },
+		func(value fxp.Int) {
+			c.Speed = value
+			MarkModified(e.content)
+		}, 0, fxp.From(999), false, false))
+	e.addIntegerField(stats, i18n.Text("Move"), &c.Move, 0, 9999)
+	e.addIntegerField(stats, i18n.Text("Dodge"), &c.Dodge, 0, 99)
+	e.addIntegerField(stats, i18n.Text("SM"), &c.SM, -99, 99)
+	e.addIntegerField(stats, i18n.Text("DR"), &c.DR, 0, 9999)
+	e.addIntegerField(stats, i18n.Text("HP"), &c.HP, 1, 9999)
+	currentHPText := i18n.Text("Current HP")
+	stats.AddChild(NewFieldLeadingLabel(currentHPText, false))
+	stats.AddChild(NewIntegerField(nil, "", currentHPText,
+		func() int { return c.CurrentHP() },
+		func(value int) {
+			c.SetCurrentHP(value)
+			MarkModified(e.content)
+		}, -9999, 9999, false, false))
+	return stats
+}
+
+func (e *companionsEditor) addIntegerField(parent *unison.Panel, title string, value *int, minValue, maxValue int) {
+	parent.AddChild(NewFieldLeadingLabel(title, false))
+	parent.AddChild(NewIntegerField(nil, "", title,
+		func() int { return *value },
+		func(v int) {
+			*value = v
+			MarkModified(e.content)
+		}, minValue, maxValue, false, false))
+}
+
+func (e *companionsEditor) createAttacks(c *gurps.Companion) *unison.Panel {
+	attacks := unison.NewPanel()
+	attacks.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	attacks.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Attack"))
+	addButton.ClickCallback = func() {
+		c.Attacks = append(c.Attacks, &gurps.CompanionAttack{Skill: c.DX})
+		e.rebuildContent()
+	}
+	attacks.AddChild(addButton)
+	label := NewFieldTrailingLabel(i18n.Text("Attacks"), false)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 4})
+	attacks.AddChild(label)
+	for _, one := range c.Attacks {
+		attack := one
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Attack"))
+		deleteButton.ClickCallback = func() {
+			if i := slices.Index(c.Attacks, attack); i != -1 {
+				c.Attacks = slices.Delete(c.Attacks, i, i+1)
+				e.rebuildContent()
+			}
+		}
+		attacks.AddChild(deleteButton)
+		e.addStringField(attacks, i18n.Text("Attack"), &attack.Name)
+		skill := NewIntegerField(nil, "", i18n.Text("Skill"),
+			func() int { return attack.Skill },
+			func(value int) {
+				attack.Skill = value
+				MarkModified(e.content)
+			}, 0, 99, false, false)
+		skill.Tooltip = newWrappedTooltip(i18n.Text("Skill"))
+		attacks.AddChild(skill)
+		e.addStringField(attacks, i18n.Text("Damage"), &attack.Damage)
+		e.addStringField(attacks, i18n.Text("Reach"), &attack.Reach)
+	}
+	return attacks
+}
+
+func (e *companionsEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+func (e *companionsEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Companions & Mounts for %s"), e.owner.String())
+}
+
+func (e *companionsEditor) String() string {
+	return e.Title()
+}
+
+func (e *companionsEditor) Tooltip() string {
+	return ""
+}
+
+func (e *companionsEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *companionsEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *companionsEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *companionsEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *companionsEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *companionsEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *companionsEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *companionsEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[[]*gurps.Companion]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Companions & Mounts Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.Companion]) {
+				entity.SetCompanions(edit.BeforeData)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.Companion]) {
+				entity.SetCompanions(edit.AfterData)
+				owner.Rebuild(true)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetCompanions(e.current)
+	owner.Rebuild(true)
+}
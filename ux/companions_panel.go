@@ -0,0 +1,110 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+const companionsPanelFieldPrefix = "companion:"
+
+// CompanionsPanel holds the contents of the companions & mounts block on the sheet.
+type CompanionsPanel struct {
+	unison.Panel
+	entity    *gurps.Entity
+	targetMgr *TargetMgr
+}
+
+// NewCompanionsPanel creates a new companions & mounts panel.
+func NewCompanionsPanel(entity *gurps.Entity, targetMgr *TargetMgr, companions []*gurps.Companion) *CompanionsPanel {
+	p := &CompanionsPanel{
+		entity:    entity,
+		targetMgr: targetMgr,
+	}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: 4,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.SetBorder(unison.NewCompoundBorder(&TitledBorder{Title: i18n.Text("Companions & Mounts")},
+		unison.NewEmptyBorder(unison.Insets{
+			Top:    1,
+			Left:   2,
+			Bottom: 1,
+			Right:  2,
+		})))
+	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
+		gc.DrawRect(rect, unison.ThemeBelowSurface.Paint(gc, rect, paintstyle.Fill))
+	}
+	for i, one := range companions {
+		p.addCompanion(i, one)
+	}
+	return p
+}
+
+func (p *CompanionsPanel) addCompanion(index int, c *gurps.Companion) {
+	title := NewPageLabel(c.Title())
+	title.Font = fonts.PageFieldPrimary
+	title.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  3,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.AddChild(title)
+
+	stats := NewPageLabel(c.StatLine())
+	stats.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	p.AddChild(stats)
+	hpTitle := i18n.Text("Current HP")
+	p.AddChild(NewPageLabelEnd(hpTitle))
+	p.AddChild(NewIntegerPageField(p.targetMgr, fmt.Sprintf("%s%d:hp", companionsPanelFieldPrefix, index),
+		fmt.Sprintf(i18n.Text("%s for %s"), hpTitle, c.Title()),
+		func() int { return c.CurrentHP() },
+		func(v int) {
+			c.SetCurrentHP(v)
+			stats.SetTitle(c.StatLine())
+			MarkForLayoutWithinDockable(stats)
+		}, -99999, c.HP, false, false))
+
+	for _, attack := range c.Attacks {
+		p.addSpanningLabel(attack.String())
+	}
+	if notes := strings.TrimSpace(c.Notes); notes != "" {
+		for _, line := range strings.Split(notes, "\n") {
+			p.addSpanningLabel(line)
+		}
+	}
+}
+
+func (p *CompanionsPanel) addSpanningLabel(text string) {
+	label := NewPageLabel(text)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  3,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.AddChild(label)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/condition"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+const (
+	conditionsPanelFieldPrefix = "condition:"
+	conditionsPanelColumns     = 6
+)
+
+// ConditionsPanel holds the contents of the conditions block on the sheet.
+type ConditionsPanel struct {
+	unison.Panel
+	entity    *gurps.Entity
+	targetMgr *TargetMgr
+}
+
+// NewConditionsPanel creates a new conditions panel.
+func NewConditionsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *ConditionsPanel {
+	p := &ConditionsPanel{
+		entity:    entity,
+		targetMgr: targetMgr,
+	}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{
+		Columns:      conditionsPanelColumns,
+		HSpacing:     4,
+		VSpacing:     1,
+		EqualColumns: true,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.SetBorder(unison.NewCompoundBorder(&TitledBorder{Title: i18n.Text("Conditions")}, unison.NewEmptyBorder(unison.Insets{
+		Top:    1,
+		Left:   2,
+		Bottom: 1,
+		Right:  2,
+	})))
+	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
+		gc.DrawRect(rect, unison.ThemeBelowSurface.Paint(gc, rect, paintstyle.Fill))
+	}
+	for _, c := range condition.Types {
+		if c == condition.Shock {
+			p.AddChild(p.createShock())
+		} else {
+			p.AddChild(p.createCheckBox(c))
+		}
+	}
+	return p
+}
+
+func (p *ConditionsPanel) createCheckBox(c condition.Type) *CheckBox {
+	checkbox := NewCheckBox(p.targetMgr, conditionsPanelFieldPrefix+c.Key(), c.String(),
+		func() check.Enum { return check.FromBool(p.entity.HasCondition(c)) },
+		func(state check.Enum) {
+			p.entity.SetCondition(c, state == check.On)
+			p.entity.Recalculate()
+		})
+	checkbox.Font = fonts.PageLabelPrimary
+	checkbox.Tooltip = newWrappedTooltip(p.effectsText(c))
+	return checkbox
+}
+
+func (p *ConditionsPanel) createShock() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: 4,
+	})
+	panel.AddChild(p.createCheckBox(condition.Shock))
+	title := i18n.Text("Shock Penalty")
+	field := NewIntegerPageField(p.targetMgr, conditionsPanelFieldPrefix+"shock_penalty", title,
+		func() int {
+			if p.entity.HasCondition(condition.Shock) {
+				return p.entity.EffectiveShockPenalty()
+			}
+			return 0
+		},
+		func(v int) {
+			p.entity.SetCondition(condition.Shock, v > 0)
+			p.entity.ShockPenalty = max(v, 0)
+			p.entity.Recalculate()
+		}, 0, gurps.MaxShockPenalty, false, false)
+	field.Tooltip = newWrappedTooltip(i18n.Text("The penalty to DX and IQ from shock; usually equal to the HP of injury taken last turn, to a maximum of 4"))
+	panel.AddChild(field)
+	return panel
+}
+
+func (p *ConditionsPanel) effectsText(c condition.Type) string {
+	var list []string
+	for _, f := range p.entity.ConditionFeatures(c) {
+		if bonus, ok := f.(*gurps.AttributeBonus); ok {
+			var name string
+			switch bonus.Attribute {
+			case gurps.DexterityID:
+				name = i18n.Text("DX")
+			case gurps.IntelligenceID:
+				name = i18n.Text("IQ")
+			case gurps.DodgeID:
+				name = i18n.Text("Dodge")
+			default:
+				name = p.entity.ResolveAttributeName(bonus.Attribute)
+			}
+			list = append(list, fmt.Sprintf("%s %s", name, bonus.Amount.StringWithSign()))
+		}
+	}
+	switch c {
+	case condition.Prone:
+		list = append(list, i18n.Text("Move limited to 1"))
+	case condition.Grappled:
+		list = append(list, i18n.Text("Move limited to 0"))
+	}
+	if len(list) == 0 {
+		return c.String()
+	}
+	return strings.Join(list, ", ")
+}
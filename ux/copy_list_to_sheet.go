@@ -0,0 +1,51 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+)
+
+// installCopyListToSheetHandler installs the handler for copying the entire contents of the list into the same list
+// of other open sheets.
+func (p *PageList[T]) installCopyListToSheetHandler(owner Rebuildable) {
+	sheet, ok := owner.AsPanel().Self.(*Sheet)
+	if !ok {
+		return
+	}
+	p.InstallCmdHandlers(CopyListToSheetItemID,
+		func(_ any) bool { return len(p.provider.RootData()) != 0 && len(OpenSheets(sheet)) != 0 },
+		func(_ any) { p.copyListToSheets(sheet) })
+}
+
+func (p *PageList[T]) copyListToSheets(from *Sheet) {
+	rows := p.provider.RootRows()
+	if len(rows) == 0 {
+		return
+	}
+	for _, s := range PromptForDestination(OpenSheets(from)) {
+		if target := matchingPageList[T](s, p.Table.RefKey); target != nil {
+			// Each destination sheet has its own undo manager, so this records a single undo edit in each.
+			CopyRowsTo(target.Table, rows, func(_ []*Node[T]) { target.provider.ProcessDropData(nil, target.Table) }, true)
+			ProcessModifiersForSelection(target.Table)
+			ProcessNameablesForSelection(target.Table)
+		}
+	}
+}
+
+// matchingPageList returns the list within the sheet that has the given reference key, or nil.
+func matchingPageList[T gurps.NodeTypes](s *Sheet, refKey string) *PageList[T] {
+	for _, one := range []any{s.Traits, s.Skills, s.Spells, s.CarriedEquipment, s.OtherEquipment, s.Notes} {
+		if list, ok := one.(*PageList[T]); ok && list != nil && list.Table.RefKey == refKey {
+			return list
+		}
+	}
+	return nil
+}
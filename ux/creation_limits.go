@@ -0,0 +1,31 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// applyWithinCreationLimits calls apply and, if the owner is a sheet in creation mode whose creation limits would be
+// exceeded further by the change, calls revert and tells the user why. Returns true if the change was kept.
+func applyWithinCreationLimits(owner any, apply, revert func()) bool {
+	sheet, ok := owner.(*Sheet)
+	if !ok {
+		apply()
+		return true
+	}
+	if sheet.entity.ApplyWithinCreationLimits(apply, revert) {
+		return true
+	}
+	unison.ErrorDialogWithMessage(i18n.Text("Creation Limits Exceeded"),
+		i18n.Text("This change would exceed the character's creation limits. Switch the sheet to play mode to make it anyway."))
+	return false
+}
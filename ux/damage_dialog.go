@@ -0,0 +1,150 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// ShowApplyDamage displays a dialog that takes the details of an incoming attack, determines the injury it inflicts
+// after DR, wounding multipliers and injury tolerance, then subtracts that injury from the appropriate pool.
+func ShowApplyDamage(sheet *Sheet) {
+	entity := sheet.Entity()
+	d := entity.NewDamageApplication()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	result := unison.NewLabel()
+	update := func() {
+		r := entity.ResolveDamage(d)
+		result.SetTitle(fmt.Sprintf(i18n.Text("DR %d, %d penetrating ×%s = %d injury to %s"), r.DR, r.Penetrating,
+			r.Multiplier.String(), r.Injury, entity.ResolveAttributeName(r.PoolID)))
+		result.MarkForLayoutAndRedraw()
+		if p := result.Parent(); p != nil {
+			p.MarkForLayoutAndRedraw()
+		}
+	}
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Damage"), false))
+	panel.AddChild(NewIntegerField(nil, "", i18n.Text("Damage"),
+		func() int { return d.Damage },
+		func(v int) {
+			d.Damage = v
+			update()
+		}, 0, math.MaxInt, false, false))
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Type"), false))
+	typePopup := unison.NewPopupMenu[wound.Type]()
+	typePopup.AddItem(wound.Types...)
+	typePopup.Select(d.Type)
+	typePopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Type]) {
+		if item, ok := p.Selected(); ok {
+			d.Type = item
+			update()
+		}
+	}
+	panel.AddChild(typePopup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Location"), false))
+	locations := entity.SheetSettings.BodyType.UniqueHitLocations(entity)
+	locationPopup := unison.NewPopupMenu[string]()
+	for i, loc := range locations {
+		locationPopup.AddItem(loc.ChoiceName)
+		if loc.LocID == d.LocationID {
+			locationPopup.SelectIndex(i)
+		}
+	}
+	locationPopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		if i := p.SelectedIndex(); i >= 0 && i < len(locations) {
+			d.LocationID = locations[i].LocID
+			update()
+		}
+	}
+	panel.AddChild(locationPopup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Armor Divisor"), false))
+	divisorField := NewDecimalField(nil, "", i18n.Text("Armor Divisor"),
+		func() fxp.Int { return d.ArmorDivisor },
+		func(v fxp.Int) {
+			d.ArmorDivisor = v
+			update()
+		}, fxp.Tenth, fxp.Hundred, false, false)
+	divisorField.Tooltip = newWrappedTooltip(i18n.Text("The armor divisor of the attack; DR is divided by this amount before being subtracted from the damage"))
+	panel.AddChild(divisorField)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Injury Tolerance"), false))
+	tolerancePopup := unison.NewPopupMenu[wound.Tolerance]()
+	tolerancePopup.AddItem(wound.Tolerances...)
+	tolerancePopup.Select(d.Tolerance)
+	tolerancePopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Tolerance]) {
+		if item, ok := p.Selected(); ok {
+			d.Tolerance = item
+			update()
+		}
+	}
+	panel.AddChild(tolerancePopup)
+
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(result)
+	update()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	r := entity.ResolveDamage(d)
+	attr := entity.ResolveAttribute(r.PoolID)
+	if r.Injury == 0 || attr == nil {
+		return
+	}
+	before := attr.Damage
+	entity.ApplyInjury(r.PoolID, r.Injury)
+	sheet.undoMgr.Add(&unison.UndoEdit[fxp.Int]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Apply Damage"),
+		UndoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { applyPoolDamage(sheet, r.PoolID, edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { applyPoolDamage(sheet, r.PoolID, edit.AfterData) },
+		BeforeData: before,
+		AfterData:  attr.Damage,
+	})
+	entity.Recalculate()
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+	if entity.PendingDeathChecks() != 0 {
+		ShowDeathAndDying(sheet)
+	}
+}
+
+func applyPoolDamage(sheet *Sheet, poolID string, damage fxp.Int) {
+	entity := sheet.Entity()
+	if attr := entity.ResolveAttribute(poolID); attr != nil {
+		attr.Damage = damage
+		entity.Recalculate()
+		MarkModified(sheet)
+		sheet.Rebuild(true)
+	}
+}
@@ -27,12 +27,14 @@ type defaultsPanel struct {
 	unison.Panel
 	entity   *gurps.Entity
 	defaults *[]*gurps.SkillDefault
+	flags    gurps.AttributeFlags
 }
 
-func newDefaultsPanel(entity *gurps.Entity, defaults *[]*gurps.SkillDefault) *defaultsPanel {
+func newDefaultsPanel(entity *gurps.Entity, title string, flags gurps.AttributeFlags, defaults *[]*gurps.SkillDefault) *defaultsPanel {
 	p := &defaultsPanel{
 		entity:   entity,
 		defaults: defaults,
+		flags:    flags,
 	}
 	p.Self = p
 	p.SetLayout(&unison.FlexLayout{
@@ -47,7 +49,7 @@ func newDefaultsPanel(entity *gurps.Entity, defaults *[]*gurps.SkillDefault) *de
 	})
 	p.SetBorder(unison.NewCompoundBorder(
 		&TitledBorder{
-			Title: i18n.Text("Defaults"),
+			Title: title,
 			Font:  unison.LabelFont,
 		},
 		unison.NewEmptyBorder(unison.NewUniformInsets(2))))
@@ -112,8 +114,7 @@ func (p *defaultsPanel) insertDefaultsPanel(index int, def *gurps.SkillDefault)
 		func() fxp.Int { return def.Modifier },
 		func(v fxp.Int) { def.Modifier = v },
 		-fxp.Thousand, fxp.Thousand, true, false)
-	attrChoicePopup := addAttributeChoicePopup(panel, p.entity, "", &def.DefaultType,
-		gurps.TenFlag|gurps.ParryFlag|gurps.BlockFlag|gurps.SkillFlag)
+	attrChoicePopup := addAttributeChoicePopup(panel, p.entity, "", &def.DefaultType, p.flags)
 	callback := attrChoicePopup.SelectionChangedCallback
 	attrChoicePopup.SelectionChangedCallback = func(popup *unison.PopupMenu[*gurps.AttributeChoice]) {
 		if item, ok := popup.Selected(); ok {
@@ -0,0 +1,132 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type dependentState struct {
+	dependent *gurps.Dependent
+	disabled  bool
+	equipped  bool
+}
+
+// confirmDeleteImpact shows the items that depend upon those about to be deleted and asks for confirmation. Returns
+// false if the deletion should not proceed. If the user asked for the dependents to be disabled, the returned list will
+// contain the ones that can be.
+func confirmDeleteImpact(dependents []*gurps.Dependent) (toDisable []*gurps.Dependent, proceed bool) {
+	list := unison.NewPanel()
+	list.SetBorder(unison.NewEmptyBorder(unison.StdInsets()))
+	list.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	var canDisable []*gurps.Dependent
+	seen := make(map[any]bool)
+	for _, one := range dependents {
+		label := unison.NewLabel()
+		label.SetTitle(fmt.Sprintf(i18n.Text("%s %s"), one.Name, one.Reason))
+		list.AddChild(label)
+		if !seen[one.Item] {
+			seen[one.Item] = true
+			if one.CanDisable() {
+				canDisable = append(canDisable, one)
+			}
+		}
+	}
+
+	scroll := unison.NewScrollPanel()
+	scroll.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroll.SetContent(list, behavior.Fill, behavior.Fill)
+	scroll.BackgroundInk = unison.ThemeSurface
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(fmt.Sprintf(i18n.Text("%d references to the selection will be broken by deleting it:"), len(dependents)))
+	panel.AddChild(label)
+	panel.AddChild(scroll)
+	var cascade *unison.CheckBox
+	if len(canDisable) != 0 {
+		cascade = unison.NewCheckBox()
+		cascade.SetTitle(i18n.Text("Also disable the dependent traits and unequip the dependent equipment"))
+		panel.AddChild(cascade)
+	} else {
+		label = unison.NewLabel()
+		label.Font = fonts.FieldSecondary
+		label.SetTitle(i18n.Text("None of the dependents can be disabled automatically."))
+		panel.AddChild(label)
+	}
+
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.WarningIcon,
+		unison.DefaultDialogTheme.WarningIconInk, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Delete"))})
+	if err != nil {
+		errs.Log(err)
+		return nil, true
+	}
+	if dialog.RunModal() == unison.ModalResponseCancel {
+		return nil, false
+	}
+	if cascade != nil && cascade.State == check.On {
+		return canDisable, true
+	}
+	return nil, true
+}
+
+func disableDependents(dependents []*gurps.Dependent) []*dependentState {
+	states := make([]*dependentState, 0, len(dependents))
+	for _, one := range dependents {
+		state := &dependentState{dependent: one}
+		switch item := one.Item.(type) {
+		case *gurps.Trait:
+			state.disabled = item.Disabled
+		case *gurps.Equipment:
+			state.equipped = item.Equipped
+		}
+		states = append(states, state)
+		one.Disable()
+	}
+	return states
+}
+
+func restoreDependents(states []*dependentState) {
+	for _, state := range states {
+		switch item := state.dependent.Item.(type) {
+		case *gurps.Trait:
+			item.Disabled = state.disabled
+		case *gurps.Equipment:
+			item.Equipped = state.equipped
+		}
+	}
+}
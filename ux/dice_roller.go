@@ -0,0 +1,308 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &DiceRoller{}
+	_ unison.UndoManagerProvider = &DiceRoller{}
+	_ unison.TabCloser           = &DiceRoller{}
+)
+
+// rollHistory holds the rolls made during this session.
+var rollHistory gurps.RollHistory
+
+// DiceRoller provides free-form dice and success rolls, along with a log of all rolls made.
+type DiceRoller struct {
+	unison.Panel
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	history *unison.Panel
+	scroll  *unison.ScrollPanel
+	dice    string
+	target  int
+	scale   int
+}
+
+// ShowDiceRoller displays the dice roller.
+func ShowDiceRoller() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*DiceRoller)
+		return ok
+	}) {
+		return
+	}
+	d := &DiceRoller{
+		dice:   "3d",
+		target: 10,
+		scale:  gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	d.Self = d
+	d.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.createContent()
+	d.scroll = unison.NewScrollPanel()
+	d.scroll.SetContent(d.content, behavior.HintedFill, behavior.Fill)
+	d.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.scroll)
+	d.refreshHistory()
+	PlaceInDock(d, dgroup.Editors, false)
+}
+
+func (d *DiceRoller) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return d.scale },
+			func(scale int) { d.scale = scale },
+			nil,
+			false,
+			d.scroll,
+		),
+	)
+	clearButton := unison.NewSVGButton(svg.Trash)
+	clearButton.Tooltip = newWrappedTooltip(i18n.Text("Clear the roll history"))
+	clearButton.ClickCallback = func() {
+		rollHistory.Clear()
+		d.refreshHistory()
+	}
+	toolbar.AddChild(clearButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (d *DiceRoller) createContent() {
+	d.content = unison.NewPanel()
+	d.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.AddChild(NewFieldLeadingLabel(i18n.Text("Dice"), false))
+	diceField := NewStringField(nil, "", i18n.Text("Dice"), func() string { return d.dice },
+		func(s string) { d.dice = s })
+	diceField.Tooltip = newWrappedTooltip(i18n.Text("The dice to roll, such as 3d, 2d+1 or 1d6x2"))
+	diceField.SetMinimumTextWidthUsing("100d+100x10")
+	wrapper.AddChild(diceField)
+	rollButton := unison.NewButton()
+	rollButton.SetTitle(i18n.Text("Roll"))
+	rollButton.ClickCallback = func() {
+		if r, ok := gurps.RollDiceIn(d.dice); ok {
+			showRoll(nil, d.dice, r.String())
+		} else {
+			unison.ErrorDialogWithMessage(i18n.Text("Unable to roll"),
+				i18n.Text("No dice specification could be found."))
+		}
+	}
+	wrapper.AddChild(rollButton)
+
+	wrapper.AddChild(NewFieldLeadingLabel(i18n.Text("Roll Against"), false))
+	wrapper.AddChild(NewIntegerField(nil, "", i18n.Text("Roll Against"),
+		func() int { return d.target },
+		func(v int) { d.target = v },
+		-99, 99, false, false))
+	successButton := unison.NewButton()
+	successButton.SetTitle(i18n.Text("Roll 3d"))
+	successButton.ClickCallback = func() {
+		showSuccessRoll(nil, i18n.Text("Success Roll"), gurps.RollAgainst(d.target))
+	}
+	wrapper.AddChild(successButton)
+	d.content.AddChild(wrapper)
+
+	header := unison.NewLabel()
+	header.Font = unison.LabelFont.Face().Font(unison.LabelFont.Size() + 2)
+	header.SetTitle(i18n.Text("History"))
+	header.SetBorder(unison.NewEmptyBorder(unison.Insets{Top: unison.StdVSpacing * 2}))
+	d.content.AddChild(header)
+
+	d.history = unison.NewPanel()
+	d.history.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.history.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.content.AddChild(d.history)
+}
+
+func (d *DiceRoller) refreshHistory() {
+	d.history.RemoveAllChildren()
+	if len(rollHistory.Entries) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No rolls have been made yet"))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+		d.history.AddChild(label)
+	}
+	for _, entry := range rollHistory.Entries {
+		when := unison.NewLabel()
+		when.Font = fonts.FieldSecondary
+		when.SetTitle(entry.When.Format("15:04:05"))
+		d.history.AddChild(when)
+		title := unison.NewLabel()
+		title.Font = unison.EmphasizedSystemFont
+		title.SetTitle(entry.Title)
+		d.history.AddChild(title)
+		detail := unison.NewLabel()
+		detail.SetTitle(entry.Detail)
+		detail.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		d.history.AddChild(detail)
+	}
+	d.content.MarkForLayoutRecursively()
+	d.content.MarkForRedraw()
+}
+
+// TitleIcon implements unison.Dockable
+func (d *DiceRoller) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Randomize,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *DiceRoller) Title() string {
+	return i18n.Text("Dice Roller")
+}
+
+func (d *DiceRoller) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *DiceRoller) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *DiceRoller) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *DiceRoller) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *DiceRoller) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (d *DiceRoller) UndoManager() *unison.UndoManager {
+	return d.undoMgr
+}
+
+// showRoll records the outcome of a roll in the roll history. If the dice roller is open, its history is updated to
+// show the roll; otherwise, the outcome is shown in a dialog. The roll is also forwarded to the webhook configured in
+// the campaign of the entity making the roll, if any.
+func showRoll(entity *gurps.Entity, title, detail string) {
+	rollHistory.Add(title, detail)
+	forwardRoll(entity, title, detail)
+	shown := false
+	for _, one := range AllDockables() {
+		if d, ok := one.(*DiceRoller); ok {
+			d.refreshHistory()
+			shown = true
+		}
+	}
+	if !shown {
+		showSuccessRollMessage(title, detail)
+	}
+}
+
+func forwardRoll(entity *gurps.Entity, title, detail string) {
+	c := campaignFor(entity)
+	if c == nil || c.campaign.RollWebhookURL == "" {
+		return
+	}
+	webhookURL := c.campaign.RollWebhookURL
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := gurps.ForwardRoll(ctx, &http.Client{}, webhookURL, title, detail); err != nil {
+			errs.Log(errs.NewWithCause("unable to forward roll", err))
+		}
+	}()
+}
+
+// rollTitle prefixes the title with the name of the character making the roll, if known.
+func rollTitle(entity *gurps.Entity, title string) string {
+	if entity != nil && entity.Profile.Name != "" {
+		return entity.Profile.Name + ": " + title
+	}
+	return title
+}
+
+// rollCell makes the roll requested by a table cell.
+func rollCell(entity *gurps.Entity, subject string, c *gurps.CellData) {
+	switch c.Roll {
+	case gurps.SuccessCellRoll:
+		if target, err := strconv.Atoi(c.Primary); err == nil {
+			makeSuccessRoll(entity, rollTitle(entity, subject), target)
+		}
+	case gurps.DamageCellRoll:
+		if r, ok := gurps.RollDiceIn(c.Primary); ok {
+			showRoll(entity, rollTitle(entity, fmt.Sprintf(i18n.Text("%s Damage"), subject)), r.String())
+		}
+	default:
+	}
+}
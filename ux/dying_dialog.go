@@ -0,0 +1,113 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+const (
+	deathCheckResponse = unison.ModalResponseUserBase + iota
+	mortalWoundCheckResponse
+	clearDyingStatusResponse
+)
+
+// ShowDeathAndDying displays the conditions that apply to the sheet's character because of HP loss and offers to make
+// any rolls they require. The outcome of each roll is recorded in the character's change log.
+func ShowDeathAndDying(sheet *Sheet) {
+	entity := sheet.Entity()
+	if entity.UpdateDyingStatus() {
+		sheet.Rebuild(true)
+	}
+	for {
+		panel := unison.NewPanel()
+		panel.SetLayout(&unison.FlexLayout{
+			Columns:  1,
+			HSpacing: unison.StdHSpacing,
+			VSpacing: unison.StdVSpacing,
+		})
+		conditions := entity.DyingConditions()
+		if len(conditions) == 0 {
+			conditions = []string{i18n.Text("The character is not dying.")}
+		}
+		for _, one := range conditions {
+			label := unison.NewLabel()
+			label.SetTitle(one)
+			panel.AddChild(label)
+		}
+		buttons := []*unison.DialogButtonInfo{unison.NewCancelButtonInfo()}
+		if entity.Dying != nil {
+			buttons = append(buttons, &unison.DialogButtonInfo{
+				Title:        i18n.Text("Clear Status"),
+				ResponseCode: clearDyingStatusResponse,
+			})
+		}
+		if entity.Dying != nil && entity.Dying.MortallyWounded && !entity.Dying.Dead {
+			buttons = append(buttons, &unison.DialogButtonInfo{
+				Title:        i18n.Text("Roll Mortal Wound Check"),
+				ResponseCode: mortalWoundCheckResponse,
+			})
+		}
+		if entity.PendingDeathChecks() != 0 {
+			buttons = append(buttons, &unison.DialogButtonInfo{
+				Title:        i18n.Text("Roll Death Check"),
+				ResponseCode: deathCheckResponse,
+			})
+		}
+		buttons[0].Title = i18n.Text("Close")
+		dialog, err := unison.NewDialog(nil, nil, panel, buttons)
+		if err != nil {
+			errs.Log(err)
+			return
+		}
+		var title string
+		var roll func(e *gurps.Entity) string
+		switch dialog.RunModal() {
+		case deathCheckResponse:
+			title = i18n.Text("Death Check")
+			roll = func(e *gurps.Entity) string { return e.MakeDeathCheck(gurps.RollAgainst) }
+		case mortalWoundCheckResponse:
+			title = i18n.Text("Mortal Wound Check")
+			roll = func(e *gurps.Entity) string { return e.MakeMortalWoundCheck(gurps.RollAgainst) }
+		case clearDyingStatusResponse:
+			title = i18n.Text("Clear Death & Dying Status")
+			roll = func(e *gurps.Entity) string {
+				e.ClearDyingStatus()
+				return ""
+			}
+		default:
+			return
+		}
+		before := entity.DyingState()
+		result := roll(entity)
+		sheet.undoMgr.Add(&unison.UndoEdit[*gurps.DyingState]{
+			ID:         unison.NextUndoID(),
+			EditName:   title,
+			UndoFunc:   func(edit *unison.UndoEdit[*gurps.DyingState]) { applyDyingState(sheet, edit.BeforeData) },
+			RedoFunc:   func(edit *unison.UndoEdit[*gurps.DyingState]) { applyDyingState(sheet, edit.AfterData) },
+			BeforeData: before,
+			AfterData:  entity.DyingState(),
+		})
+		MarkModified(sheet)
+		sheet.Rebuild(true)
+		if result != "" {
+			showRoll(entity, rollTitle(entity, title), result)
+		}
+	}
+}
+
+func applyDyingState(sheet *Sheet, state *gurps.DyingState) {
+	sheet.Entity().ApplyDyingState(state)
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
@@ -179,7 +179,9 @@ func (e *editor[N, D]) createToolbar(helpMD string, initToolbar func(*editor[N,
 			unison.KeyNumPadEnter))
 	e.applyButton.SetEnabled(false)
 	e.applyButton.ClickCallback = func() {
-		e.apply()
+		if !e.apply() {
+			return
+		}
 		e.promptForSave = false
 		e.AttemptClose()
 	}
@@ -229,6 +231,7 @@ func (e *editor[N, D]) prepareForSubstitutions() (tmpNode N, m map[string]string
 	e.editorData.ApplyTo(tmpNode)
 	m = make(map[string]string)
 	tmpNode.FillWithNameableKeys(m, nil)
+	gurps.SheetSettingsFor(gurps.EntityFromNode(node)).PrefillNameables(m)
 	return tmpNode, m
 }
 
@@ -312,7 +315,9 @@ func (e *editor[N, D]) AttemptClose() bool {
 		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
 		case unison.ModalResponseDiscard:
 		case unison.ModalResponseOK:
-			e.apply()
+			if !e.apply() {
+				return false
+			}
 		default:
 			return false
 		}
@@ -334,11 +339,17 @@ func (e *editor[N, D]) UndoManager() *unison.UndoManager {
 	return e.undoMgr
 }
 
-func (e *editor[N, D]) apply() {
+func (e *editor[N, D]) apply() bool {
 	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
 	if e.preApplyCallback != nil {
 		e.preApplyCallback(e.editorData)
 	}
+	MarkDirtyFor(e.owner, e.target)
+	if !applyWithinCreationLimits(e.owner, func() { e.editorData.ApplyTo(e.target) },
+		func() { e.beforeData.ApplyTo(e.target) }) {
+		e.owner.Rebuild(true)
+		return false
+	}
 	if mgr := unison.UndoManagerFor(e.owner); mgr != nil {
 		owner := e.owner
 		target := e.target
@@ -346,17 +357,22 @@ func (e *editor[N, D]) apply() {
 			ID:       unison.NextUndoID(),
 			EditName: fmt.Sprintf(i18n.Text("%s Changes"), gurps.AsNode(target).Kind()),
 			UndoFunc: func(edit *unison.UndoEdit[D]) {
+				MarkDirtyFor(owner, target)
 				edit.BeforeData.ApplyTo(target)
+				MarkDirtyFor(owner, target)
 				owner.Rebuild(true)
 			},
 			RedoFunc: func(edit *unison.UndoEdit[D]) {
+				MarkDirtyFor(owner, target)
 				edit.AfterData.ApplyTo(target)
+				MarkDirtyFor(owner, target)
 				owner.Rebuild(true)
 			},
 			BeforeData: e.beforeData,
 			AfterData:  e.editorData,
 		})
 	}
-	e.editorData.ApplyTo(e.target)
+	MarkDirtyFor(e.owner, e.target)
 	e.owner.Rebuild(true)
+	return true
 }
@@ -14,6 +14,8 @@ import (
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/ectype"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/recharge"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
@@ -25,6 +27,18 @@ func EditEquipment(owner Rebuildable, equipment *gurps.Equipment, carried bool)
 		"md:Help/Interface/Equipment", nil,
 		func(e *editor[*gurps.Equipment, *gurps.EquipmentEditData], content *unison.Panel) func() {
 			addNameLabelAndField(content, &e.editorData.Name)
+			updateSuggestion := func() {}
+			if e.target.Source.ShouldOmit() && !e.target.Container() {
+				updateSuggestion = addLibraryNameSuggestion(content, &e.editorData.Name, gurps.MatchEquipmentName,
+					func(m *gurps.LibraryNameMatch[*gurps.Equipment, *gurps.EquipmentModifier]) {
+						quantity := e.editorData.Quantity
+						equipped := e.editorData.Equipped
+						e.editorData.CopyFrom(m.Equipment(e.target.DataOwner()))
+						e.editorData.Quantity = quantity
+						e.editorData.Equipped = equipped
+						e.Rebuild(false)
+					})
+			}
 			addNotesLabelAndField(content, &e.editorData.LocalNotes)
 			addVTTNotesLabelAndField(content, &e.editorData.VTTNotes)
 			addLabelAndStringField(content, i18n.Text("Tech Level"), gurps.TechLevelInfo(), &e.editorData.TechLevel)
@@ -67,20 +81,42 @@ func EditEquipment(owner Rebuildable, equipment *gurps.Equipment, carried bool)
 				defUnits := gurps.SheetSettingsFor(entity).DefaultWeightUnits
 				if e.editorData.Quantity > 0 {
 					weight = gurps.ExtendedWeightAdjustedForModifiers(e.target, defUnits, e.editorData.Quantity,
-						e.editorData.Weight, e.editorData.Modifiers, e.editorData.Features, e.target.Children, false,
-						false)
+						e.editorData.Weight, e.editorData.Modifiers, e.editorData.Features, e.editorData.WeightRules,
+						e.target.Children, false, false)
 				}
 				field.SetTitle(defUnits.Format(weight))
 				field.MarkForLayoutAndRedraw()
 			}))
 			content.AddChild(unison.NewPanel())
 			addCheckBox(content, i18n.Text("Ignore weight for skills"), &e.editorData.WeightIgnoredForSkills)
+			addWeightRulesPanel(content, &e.editorData.WeightRules)
+			if e.target.Container() && entity == nil {
+				content.AddChild(unison.NewPanel())
+				addCheckBox(content, i18n.Text("Kit (expands into its contents when placed onto a sheet)"),
+					&e.editorData.Kit)
+			}
+			if e.target.Container() {
+				popup := addLabelAndPopup(content, i18n.Text("Container Type"), "", ectype.Types,
+					&e.editorData.ContainerType)
+				popup.Tooltip = newWrappedTooltip(e.editorData.ContainerType.AltString())
+				popup.SelectionChangedCallback = func(p *unison.PopupMenu[ectype.Type]) {
+					if item, ok := p.Selected(); ok {
+						e.editorData.ContainerType = item
+						p.Tooltip = newWrappedTooltip(item.AltString())
+						MarkModified(content)
+					}
+				}
+			}
 			usesLabel := i18n.Text("Uses")
 			wrapper = addFlowWrapper(content, usesLabel, 3)
 			usesField := addIntegerField(wrapper, nil, "", usesLabel, "", &e.editorData.Uses, 0, 9999999)
 			maxUsesLabel := i18n.Text("Maximum Uses")
 			wrapper.AddChild(NewFieldInteriorLeadingLabel(maxUsesLabel, false))
 			addIntegerField(wrapper, nil, "", maxUsesLabel, "", &e.editorData.MaxUses, 0, 9999999)
+			wrapper = addFlowWrapper(content, i18n.Text("Recharge"), 2)
+			addPopup(wrapper, recharge.Types, &e.editorData.Recharge)
+			removeEmptyCheckBox := addCheckBox(wrapper, i18n.Text("Remove when used up"), &e.editorData.RemoveEmpty)
+			removeEmptyCheckBox.SetEnabled(e.editorData.Recharge == recharge.Consumable)
 			addLabelAndDecimalField(content, nil, "", i18n.Text("Rated ST"), i18n.Text("Equipment with a rated ST use this value instead of the user's ST"), &e.editorData.RatedST, 0, fxp.Max)
 			addLabelAndDecimalField(content, nil, "", i18n.Text("Level"), i18n.Text("Level can be used with features and modifiers that have per-level effects"), &e.editorData.Level, 0, fxp.Max)
 			addTagsLabelAndField(content, &e.editorData.Tags)
@@ -99,10 +135,12 @@ func EditEquipment(owner Rebuildable, equipment *gurps.Equipment, carried bool)
 			e.InstallCmdHandlers(NewEquipmentContainerModifierItemID, unison.AlwaysEnabled,
 				func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant) })
 			return func() {
+				updateSuggestion()
 				if e.editorData.Uses > e.editorData.MaxUses {
 					usesField.SetText(strconv.Itoa(e.editorData.MaxUses))
 				}
 				adjustFieldBlank(usesField, e.editorData.MaxUses <= 0)
+				removeEmptyCheckBox.SetEnabled(e.editorData.Recharge == recharge.Consumable)
 			}
 		}, nil)
 }
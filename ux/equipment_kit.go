@@ -0,0 +1,51 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+func installBundleIntoKitHandler(p *unison.Panel, table *unison.Table[*Node[*gurps.Equipment]]) {
+	p.InstallCmdHandlers(BundleIntoKitItemID,
+		func(_ any) bool { return table.HasSelection() },
+		func(_ any) { bundleSelectionIntoKit(table) })
+}
+
+func bundleSelectionIntoKit(table *unison.Table[*Node[*gurps.Equipment]]) {
+	sel := ExtractNodeDataFromList(table.SelectedRows(true))
+	if len(sel) == 0 {
+		return
+	}
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(gurps.GlobalSettings().Libraries().User().Path())
+	dialog.SetAllowedExtensions(gurps.EquipmentExt)
+	name := sel[0].Name
+	if len(sel) > 1 {
+		name = i18n.Text("Kit")
+	}
+	dialog.SetInitialFileName(xfs.SanitizeName(name))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), gurps.EquipmentExt, false); ok {
+			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			kit := gurps.NewKit(xfs.BaseName(filePath), sel)
+			if err := gurps.SaveEquipment([]*gurps.Equipment{kit}, filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to save kit"), err)
+				return
+			}
+			Workspace.Navigator.EventuallyReload()
+		}
+	}
+}
@@ -48,8 +48,10 @@ func NewEquipmentModifierTableDockableFromFile(filePath string) (unison.Dockable
 // NewEquipmentModifierTableDockable creates a new unison.Dockable for equipment modifier list files.
 func NewEquipmentModifierTableDockable(filePath string, modifiers []*gurps.EquipmentModifier) *TableDockable[*gurps.EquipmentModifier] {
 	provider := &equipmentModifierListProvider{modifiers: modifiers}
-	return NewTableDockable(filePath, gurps.EquipmentModifiersExt,
+	d := NewTableDockable(filePath, gurps.EquipmentModifiersExt,
 		NewEquipmentModifiersProvider(provider, false),
 		func(path string) error { return gurps.SaveEquipmentModifiers(provider.EquipmentModifierList(), path) },
 		NewEquipmentModifierItemID, NewEquipmentContainerModifierItemID)
+	installToggleFavoriteModifierHandler(d.table, nil)
+	return d
 }
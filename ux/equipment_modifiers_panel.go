@@ -38,11 +38,24 @@ func newEquipmentModifiersPanel(owner gurps.DataOwner, modifiers *[]*gurps.Equip
 	})
 	p.SetBorder(unison.NewLineBorder(unison.ThemeAboveSurface, 0, unison.NewUniformInsets(1), false))
 	p.provider = NewEquipmentModifiersProvider(p, true)
+	chips := newFavoriteModifierChips(p.applyFavorite)
+	p.AddChild(chips)
 	p.table = newEditorTable(p.AsPanel(), p.provider)
 	p.table.RefKey = "equipment-modifiers-" + uuid.New().String()
+	installToggleFavoriteModifierHandler(p.table, chips.sync)
 	return p
 }
 
+func (p *equipmentModifiersPanel) applyFavorite(mod *gurps.EquipmentModifier) {
+	if owner := unison.AncestorOrSelf[Rebuildable](p.table); owner != nil {
+		InsertItems[*gurps.EquipmentModifier](owner, p.table, p.EquipmentModifierList, p.SetEquipmentModifierList,
+			func(_ *unison.Table[*Node[*gurps.EquipmentModifier]]) []*Node[*gurps.EquipmentModifier] {
+				return p.provider.RootRows()
+			},
+			mod.Clone(gurps.LibraryFile{}, p.owner, nil, false))
+	}
+}
+
 func (p *equipmentModifiersPanel) DataOwner() gurps.DataOwner {
 	return p.owner
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/collection/dict"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/unison"
 )
@@ -115,6 +116,7 @@ func (p *equipmentProvider) DropShouldMoveData(from, to *unison.Table[*Node[*gur
 }
 
 func (p *equipmentProvider) ProcessDropData(from, to *unison.Table[*Node[*gurps.Equipment]]) {
+	p.expandKits(to)
 	if p.carried && from != to {
 		for _, row := range to.SelectedRows(true) {
 			if equipmentRow, ok := any(row).(*Node[*gurps.Equipment]); ok {
@@ -127,6 +129,35 @@ func (p *equipmentProvider) ProcessDropData(from, to *unison.Table[*Node[*gurps.
 	}
 }
 
+// expandKits replaces any kits that were placed into a sheet with their contents, selecting the contents in place of
+// the kit.
+func (p *equipmentProvider) expandKits(table *unison.Table[*Node[*gurps.Equipment]]) {
+	if owner := p.DataOwner(); toolbox.IsNil(owner) || toolbox.IsNil(owner.OwningEntity()) {
+		return
+	}
+	selMap := make(map[tid.TID]bool)
+	var collect func(eqp *gurps.Equipment)
+	collect = func(eqp *gurps.Equipment) {
+		if eqp.Kit && eqp.Container() {
+			for _, child := range eqp.Children {
+				collect(child)
+			}
+		} else {
+			selMap[eqp.TID] = true
+		}
+	}
+	for _, row := range table.SelectedRows(false) {
+		collect(row.Data())
+	}
+	list, expanded := gurps.ExpandKits(p.RootData(), nil)
+	if !expanded {
+		return
+	}
+	p.SetRootData(list)
+	table.SyncToModel()
+	table.SetSelectionMap(selMap)
+}
+
 func (p *equipmentProvider) AltDropSupport() *AltDropSupport {
 	return &AltDropSupport{
 		DragKey: equipmentModifierDragKey,
@@ -202,7 +233,7 @@ func (p *equipmentProvider) ColumnIDs() []int {
 	}
 	columnIDs = append(columnIDs, gurps.EquipmentReferenceColumn)
 	if p.forPage {
-		if entity := p.DataOwner().OwningEntity(); entity == nil || !entity.SheetSettings.HideSourceMismatch {
+		if entity := p.DataOwner().OwningEntity(); entity == nil || entity.SheetSettings.ShowLibrarySourceColumn() {
 			columnIDs = append(columnIDs, gurps.EquipmentLibSrcColumn)
 		}
 	}
@@ -277,5 +308,9 @@ func (p *equipmentProvider) ContextMenuItems() []ContextMenuItem {
 			ContextMenuItem{i18n.Text("New Other Equipment Container"), NewOtherEquipmentContainerItemID},
 		)
 	}
+	list = append(list,
+		ContextMenuItem{saveLoadoutAction.Title, SaveLoadoutItemID},
+		ContextMenuItem{switchLoadoutAction.Title, SwitchLoadoutItemID},
+	)
 	return AppendDefaultContextMenuItems(list)
 }
@@ -73,5 +73,8 @@ func NewEquipmentTableDockable(filePath string, equipment []*gurps.Equipment) *T
 	d.InstallCmdHandlers(DecrementEquipmentLevelItemID,
 		func(_ any) bool { return canAdjustEquipmentLevel(d.table, -fxp.One) },
 		func(_ any) { adjustEquipmentLevel(d, d.table, -fxp.One) })
+	installBundleIntoKitHandler(d.AsPanel(), d.table)
+	installApplyFavoriteEquipmentModifierHandler(d.AsPanel(), d, d.table)
+	installApplyQualityPresetHandler(d.AsPanel(), d, d.table)
 	return d
 }
@@ -0,0 +1,233 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// Export formats supported by ExportCmd.
+const (
+	ExportFormatPDF            = "pdf"
+	ExportFormatPNG            = "png"
+	ExportFormatWEBP           = "webp"
+	ExportFormatJPEG           = "jpeg"
+	ExportFormatText           = "text"
+	ExportFormatFoundry        = "foundry"
+	ExportFormatRoll20         = "roll20"
+	ExportFormatFantasyGrounds = "fgu"
+	ExportFormatStatBlock      = "statblock"
+	ExportFormatBBCode         = "bbcode"
+	ExportFormatMarkdown       = "markdown"
+)
+
+var exportFormats = []string{
+	ExportFormatPDF,
+	ExportFormatPNG,
+	ExportFormatWEBP,
+	ExportFormatJPEG,
+	ExportFormatText,
+	ExportFormatFoundry,
+	ExportFormatRoll20,
+	ExportFormatFantasyGrounds,
+	ExportFormatStatBlock,
+	ExportFormatBBCode,
+	ExportFormatMarkdown,
+}
+
+var _ cmdline.Cmd = &ExportCmd{}
+
+// ExportCmd is the "export" command-line sub-command, which exports character sheets without bringing up the user
+// interface.
+type ExportCmd struct{}
+
+// Name implements cmdline.Cmd.
+func (c *ExportCmd) Name() string {
+	return "export"
+}
+
+// Usage implements cmdline.Cmd.
+func (c *ExportCmd) Usage() string {
+	return i18n.Text("Exports character sheets, or folders of them, as PDF, PNG, WEBP, JPEG, text, a GM stat block, a forum post or for a virtual tabletop without bringing up the user interface")
+}
+
+// Run implements cmdline.Cmd.
+func (c *ExportCmd) Run(cl *cmdline.CmdLine, args []string) error {
+	format := ExportFormatPDF
+	var outDir, textTmplPath, namePattern, layoutPath string
+	var recursive, strip bool
+	var resolution int
+	var htmlOptions gurps.HTMLExportOptions
+	cl.Description = c.Usage()
+	cl.NewGeneralOption(&format).SetName("format").SetSingle('f').SetArg("type").
+		SetUsage(fmt.Sprintf(i18n.Text("The format to export to: %s"), strings.Join(exportFormats, ", ")))
+	cl.NewGeneralOption(&outDir).SetName("output").SetSingle('o').SetArg("dir").
+		SetUsage(i18n.Text("The directory to write the exported files into. Defaults to the directory each sheet is in"))
+	cl.NewGeneralOption(&namePattern).SetName("name").SetSingle('n').SetArg("pattern").
+		SetUsage(fmt.Sprintf(i18n.Text("The pattern used to name the exported files, without extension. May contain %s. Defaults to %s"),
+			gurps.FileNamePatternHelp, gurps.DefaultFileNamePattern))
+	cl.NewGeneralOption(&recursive).SetName("recursive").SetSingle('r').
+		SetUsage(i18n.Text("Also export the sheets in the subfolders of any folders given"))
+	cl.NewGeneralOption(&layoutPath).SetName("layout").SetSingle('l').SetArg("file").
+		SetUsage(i18n.Text("A sheet layout to use when exporting as PDF, PNG, WEBP or JPEG"))
+	cl.NewGeneralOption(&resolution).SetName("resolution").SetArg("ppi").
+		SetUsage(fmt.Sprintf(i18n.Text("The resolution to render at when exporting as PNG, WEBP or JPEG, from %d to %d pixels per inch. Defaults to the image export resolution in the general settings"),
+			gurps.ImageResolutionMin, gurps.ImageResolutionMax))
+	cl.NewGeneralOption(&strip).SetName("strip").
+		SetUsage(i18n.Text("Combine the pages into a single vertical strip when exporting as PNG, WEBP or JPEG, rather than writing one numbered file per page"))
+	cl.NewGeneralOption(&textTmplPath).SetName("template").SetSingle('t').SetArg("file").
+		SetUsage(i18n.Text("The template file to use when exporting as text"))
+	cl.NewGeneralOption(&htmlOptions.ThemePath).SetName("theme").SetArg("file").
+		SetUsage(i18n.Text("A CSS theme to apply when exporting with an HTML template"))
+	cl.NewGeneralOption(&htmlOptions.SelfContained).SetName("self-contained").
+		SetUsage(i18n.Text("Embed style sheets, fonts and images when exporting with an HTML template, producing a single file"))
+	fileList := cl.Parse(args)
+	if len(fileList) == 0 {
+		return errs.New(i18n.Text("No files to process."))
+	}
+	format = strings.ToLower(format)
+	switch format {
+	case ExportFormatPDF, ExportFormatPNG, ExportFormatWEBP, ExportFormatJPEG, ExportFormatFoundry,
+		ExportFormatRoll20, ExportFormatFantasyGrounds, ExportFormatStatBlock, ExportFormatBBCode, ExportFormatMarkdown:
+		if textTmplPath != "" {
+			return errs.New(i18n.Text("--template may only be used with the text format"))
+		}
+		if htmlOptions.ThemePath != "" || htmlOptions.SelfContained {
+			return errs.New(i18n.Text("--theme and --self-contained may only be used with the text format"))
+		}
+	case ExportFormatText:
+		if textTmplPath == "" {
+			return errs.New(i18n.Text("The text format requires a --template"))
+		}
+	default:
+		return errs.Newf(i18n.Text("Unknown export format: %s"), format)
+	}
+	var layout *gurps.SheetLayout
+	if layoutPath != "" {
+		switch format {
+		case ExportFormatPDF, ExportFormatPNG, ExportFormatWEBP, ExportFormatJPEG:
+		default:
+			return errs.New(i18n.Text("--layout may only be used with the pdf, png, webp and jpeg formats"))
+		}
+		var err error
+		if layout, err = loadSheetLayout(layoutPath); err != nil {
+			return err
+		}
+	}
+	imageOpts := DefaultImageExportOptions()
+	if resolution != 0 || strip {
+		switch format {
+		case ExportFormatPNG, ExportFormatWEBP, ExportFormatJPEG:
+		default:
+			return errs.New(i18n.Text("--resolution and --strip may only be used with the png, webp and jpeg formats"))
+		}
+		if resolution != 0 {
+			if resolution < gurps.ImageResolutionMin || resolution > gurps.ImageResolutionMax {
+				return errs.Newf(i18n.Text("--resolution must be from %d to %d"), gurps.ImageResolutionMin,
+					gurps.ImageResolutionMax)
+			}
+			imageOpts.Resolution = resolution
+		}
+		imageOpts.Combined = strip
+	}
+	if outDir != "" {
+		if err := os.MkdirAll(outDir, 0o750); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	configureDefaultThemes()
+	var failed int
+	for _, one := range expandExportFileList(fileList, recursive) {
+		if err := ExportSheet(one, format, textTmplPath, htmlOptions, outDir, namePattern, layout, imageOpts); err != nil {
+			errs.Log(err, "file", one)
+			failed++
+		}
+	}
+	if failed != 0 {
+		return errs.Newf(i18n.Text("%d file(s) failed to export"), failed)
+	}
+	return nil
+}
+
+// expandExportFileList replaces any folders in the list with the character sheets they contain.
+func expandExportFileList(fileList []string, recursive bool) []string {
+	list := make([]string, 0, len(fileList))
+	for _, one := range fileList {
+		if !fs.IsDir(one) {
+			list = append(list, one)
+			continue
+		}
+		sheets, err := sheetFilesIn(one, recursive)
+		if err != nil {
+			errs.Log(err, "file", one)
+			continue
+		}
+		list = append(list, sheets...)
+	}
+	return list
+}
+
+// ExportSheet loads the character sheet at filePath and exports it in the given format, without creating any windows.
+// The output is written into outDir, or the directory the sheet is in if outDir is empty, using the sheet's file name
+// with the extension appropriate to the format. textTmplPath and htmlOptions are only used by the text format, the
+// latter only when the template is an HTML template. Multi-page image formats produce one file per page, unless
+// imageOpts combines them into a single vertical strip; imageOpts is otherwise ignored. namePattern is passed to
+// gurps.ExpandFileNamePattern to produce the base name of the exported files. layout, if not nil, is used in place of
+// the sheet's own layout by the PDF and image formats.
+func ExportSheet(filePath, format, textTmplPath string, htmlOptions gurps.HTMLExportOptions, outDir, namePattern string, layout *gurps.SheetLayout, imageOpts ImageExportOptions) error {
+	if !gurps.FileInfoFor(filePath).IsExportable {
+		return errs.Newf(i18n.Text("Not an exportable file: %s"), filePath)
+	}
+	entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		return err
+	}
+	if outDir == "" {
+		outDir = filepath.Dir(filePath)
+	}
+	base := filepath.Join(outDir, gurps.ExpandFileNamePattern(namePattern, entity, filePath, time.Now()))
+	switch format {
+	case ExportFormatPDF:
+		return exportWithSheetLayout(entity, layout, func(p *pageExporter) error { return p.exportAsPDFFile(base + ".pdf") })
+	case ExportFormatPNG:
+		return exportWithSheetLayout(entity, layout, func(p *pageExporter) error { return p.exportAsPNGs(base, imageOpts) })
+	case ExportFormatWEBP:
+		return exportWithSheetLayout(entity, layout, func(p *pageExporter) error { return p.exportAsWEBPs(base, imageOpts) })
+	case ExportFormatJPEG:
+		return exportWithSheetLayout(entity, layout, func(p *pageExporter) error { return p.exportAsJPEGs(base, imageOpts) })
+	case ExportFormatFoundry:
+		// The virtual tabletop formats share an extension, so the format is included in the name to keep them apart.
+		return gurps.ExportFoundryActor(entity, base+"-"+ExportFormatFoundry+gurps.FoundryActorExt)
+	case ExportFormatRoll20:
+		return gurps.ExportRoll20Character(entity, base+"-"+ExportFormatRoll20+gurps.Roll20CharacterExt)
+	case ExportFormatFantasyGrounds:
+		return gurps.ExportFantasyGrounds(entity, base+gurps.FantasyGroundsExt)
+	case ExportFormatStatBlock:
+		return gurps.ExportStatBlock(entity, base+gurps.StatBlockExt)
+	case ExportFormatBBCode, ExportFormatMarkdown:
+		// The BBCode output shares its extension with the stat block, so the format is included in the name.
+		options := gurps.ForumPostOptions{Markdown: format == ExportFormatMarkdown}
+		return gurps.ExportForumPost(entity, base+"-"+format+options.Ext(), options)
+	case ExportFormatText:
+		return gurps.ExportWithOptions(entity, textTmplPath, base+filepath.Ext(textTmplPath), htmlOptions)
+	default:
+		return errs.Newf(i18n.Text("Unknown export format: %s"), format)
+	}
+}
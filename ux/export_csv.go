@@ -0,0 +1,51 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// installExportCSVHandler installs the handler for exporting the contents of the list as comma-separated values.
+// headerData provides the column titles, which should be the plain ones a library table would use rather than those
+// decorated for display on a page.
+func (p *PageList[T]) installExportCSVHandler(owner Rebuildable, headerData func(columnID int) gurps.HeaderData) {
+	p.InstallCmdHandlers(ExportAsCSVItemID,
+		func(_ any) bool { return len(p.provider.RootData()) != 0 },
+		func(_ any) { p.exportCSV(owner, headerData) })
+}
+
+func (p *PageList[T]) exportCSV(owner Rebuildable, headerData func(columnID int) gurps.HeaderData) {
+	_, plural := p.provider.ItemNames()
+	name := plural
+	dialog := unison.NewSaveDialog()
+	if fbd, ok := owner.AsPanel().Self.(FileBackedDockable); ok {
+		backingFilePath := fbd.BackingFilePath()
+		dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
+		name = fs.BaseName(backingFilePath) + " - " + plural
+	}
+	ext := strings.TrimPrefix(gurps.CSVExt, ".")
+	dialog.SetAllowedExtensions(ext)
+	dialog.SetInitialFileName(fs.SanitizeName(name))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), ext, false); ok {
+			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := gurps.ExportCSV(filePath, p.provider.ColumnIDs(), headerData, p.provider.RootData()); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export as CSV!"), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+// lastForumPostOptions holds the options used for the previous forum post export, so that they may be offered again.
+var lastForumPostOptions gurps.ForumPostOptions
+
+func (s *Sheet) exportForumPost() {
+	options, ok := promptForForumPostOptions(lastForumPostOptions)
+	if !ok {
+		return
+	}
+	lastForumPostOptions = options
+	s.exportToFile(options.Ext(), i18n.Text("Unable to export as a forum post!"), func(filePath string) error {
+		return gurps.ExportForumPost(s.entity, filePath, options)
+	})
+}
+
+func promptForForumPostOptions(options gurps.ForumPostOptions) (gurps.ForumPostOptions, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Format"), false))
+	bbCode := i18n.Text("BBCode")
+	markdown := i18n.Text("Markdown (Reddit)")
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(bbCode, markdown)
+	if options.Markdown {
+		popup.Select(markdown)
+	} else {
+		popup.Select(bbCode)
+	}
+	panel.AddChild(popup)
+	omitZero := addForumPostCheckBox(panel, i18n.Text("Omit zero-point traits"), options.OmitZeroPointTraits)
+	collapse := addForumPostCheckBox(panel, i18n.Text("Collapse equipment containers"),
+		options.CollapseEquipmentContainers)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Export")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return options, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return options, false
+	}
+	return gurps.ForumPostOptions{
+		Markdown:                    popup.SelectedIndex() == 1,
+		OmitZeroPointTraits:         omitZero.State == check.On,
+		CollapseEquipmentContainers: collapse.State == check.On,
+	}, true
+}
+
+func addForumPostCheckBox(panel *unison.Panel, title string, checked bool) *unison.CheckBox {
+	panel.AddChild(unison.NewPanel())
+	checkbox := unison.NewCheckBox()
+	checkbox.SetTitle(title)
+	checkbox.State = check.FromBool(checked)
+	panel.AddChild(checkbox)
+	return checkbox
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+func isHTMLTemplate(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".html" || ext == ".htm"
+}
+
+// promptForHTMLExportOptions asks the user which CSS theme to apply and whether the output should be a single,
+// self-contained file. Returns false if the user cancels.
+func promptForHTMLExportOptions() (gurps.HTMLExportOptions, bool) {
+	var options gurps.HTMLExportOptions
+	themes := gurps.OutputThemes()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Theme"), false))
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(i18n.Text("None"))
+	for _, one := range themes {
+		popup.AddItem(gurps.OutputThemeName(one))
+	}
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+	panel.AddChild(unison.NewPanel())
+	selfContained := unison.NewCheckBox()
+	selfContained.SetTitle(i18n.Text("Self-contained (single file)"))
+	selfContained.Tooltip = newWrappedTooltip(i18n.Text("Embeds the portrait, style sheets and fonts directly within the exported file"))
+	panel.AddChild(selfContained)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Export")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return options, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return options, false
+	}
+	if i := popup.SelectedIndex(); i > 0 {
+		options.ThemePath = themes[i-1]
+	}
+	options.SelfContained = selfContained.State == check.On
+	return options, true
+}
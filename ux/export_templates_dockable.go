@@ -0,0 +1,239 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+type exportTemplatesDockable struct {
+	SettingsDockable
+	content *unison.Panel
+}
+
+// ShowExportTemplates shows the Export Templates.
+func ShowExportTemplates() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*exportTemplatesDockable)
+		return ok
+	}) {
+		return
+	}
+	d := &exportTemplatesDockable{}
+	d.Self = d
+	d.TabTitle = i18n.Text("Export Templates")
+	d.TabIcon = svg.Settings
+	d.Resetter = d.reset
+	d.Setup(d.addToStartToolbar, nil, d.initContent)
+}
+
+func (d *exportTemplatesDockable) addToStartToolbar(toolbar *unison.Panel) {
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add an export template"))
+	addButton.ClickCallback = d.addTemplate
+	toolbar.AddChild(addButton)
+	helpButton := unison.NewSVGButton(svg.Help)
+	helpButton.Tooltip = newWrappedTooltip(i18n.Text("Functions available to export templates"))
+	helpButton.ClickCallback = showExportTemplateFunctions
+	toolbar.AddChild(helpButton)
+}
+
+func (d *exportTemplatesDockable) initContent(content *unison.Panel) {
+	d.content = content
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.sync()
+}
+
+func (d *exportTemplatesDockable) reset() {
+	gurps.GlobalSettings().ExportTemplates = nil
+	d.sync()
+}
+
+func (d *exportTemplatesDockable) sync() {
+	d.content.RemoveAllChildren()
+	list := gurps.GlobalSettings().ExportTemplates
+	if len(list) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text(`No export templates have been added. Templates added here appear in the "Export To…" menu.`))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 5})
+		d.content.AddChild(label)
+	} else {
+		for _, title := range []string{"", i18n.Text("Name"), i18n.Text("Extension"), i18n.Text("Mime Type"),
+			i18n.Text("Template")} {
+			label := NewFieldLeadingLabel(title, false)
+			label.HAlign = align.Start
+			label.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+			d.content.AddChild(label)
+		}
+		for _, one := range list {
+			d.createTrashField(one)
+			d.createNameField(one)
+			d.createExtensionField(one)
+			d.createMimeTypeField(one)
+			d.createPathField(one)
+		}
+	}
+	d.MarkForLayoutRecursively()
+	d.MarkForRedraw()
+}
+
+func (d *exportTemplatesDockable) addTemplate() {
+	if p := askUserForExportTemplatePath(); p != "" {
+		settings := gurps.GlobalSettings()
+		settings.ExportTemplates = append(settings.ExportTemplates, &gurps.ExportTemplate{Path: p})
+		d.sync()
+	}
+}
+
+func askUserForExportTemplatePath() string {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return ""
+	}
+	p := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(p))
+	return p
+}
+
+func (d *exportTemplatesDockable) createTrashField(tmpl *gurps.ExportTemplate) {
+	b := unison.NewSVGButton(svg.Trash)
+	b.ClickCallback = func() {
+		if unison.QuestionDialog(fmt.Sprintf(i18n.Text("Are you sure you want to remove\n%s?"), tmpl.Title()),
+			"") == unison.ModalResponseOK {
+			settings := gurps.GlobalSettings()
+			if i := slices.Index(settings.ExportTemplates, tmpl); i != -1 {
+				settings.ExportTemplates = slices.Delete(settings.ExportTemplates, i, i+1)
+			}
+			d.sync()
+		}
+	}
+	b.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Middle,
+		VAlign: align.Middle,
+	})
+	d.content.AddChild(b)
+}
+
+func (d *exportTemplatesDockable) createNameField(tmpl *gurps.ExportTemplate) {
+	field := NewStringField(nil, "", i18n.Text("Name"),
+		func() string { return tmpl.Name },
+		func(s string) { tmpl.Name = s })
+	field.Watermark = tmpl.Title()
+	field.SetMinimumTextWidthUsing("Community Stat Block")
+	field.Tooltip = newWrappedTooltip(i18n.Text("The name shown in the export menu. If empty, the template's file name is used."))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+	})
+	d.content.AddChild(field)
+}
+
+func (d *exportTemplatesDockable) createExtensionField(tmpl *gurps.ExportTemplate) {
+	field := NewStringField(nil, "", i18n.Text("Extension"),
+		func() string { return tmpl.Extension },
+		func(s string) { tmpl.Extension = s })
+	field.Watermark = filepath.Ext(tmpl.Path)
+	field.SetMinimumTextWidthUsing(".bbcode")
+	field.Tooltip = newWrappedTooltip(i18n.Text("The extension given to exported files. If empty, the template's own extension is used."))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+	})
+	d.content.AddChild(field)
+}
+
+func (d *exportTemplatesDockable) createMimeTypeField(tmpl *gurps.ExportTemplate) {
+	field := NewStringField(nil, "", i18n.Text("Mime Type"),
+		func() string { return tmpl.MimeType },
+		func(s string) { tmpl.MimeType = s })
+	field.SetMinimumTextWidthUsing("application/json")
+	field.Tooltip = newWrappedTooltip(i18n.Text("The mime type of exported files. If empty, the type normally associated with the extension is used."))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+	})
+	d.content.AddChild(field)
+}
+
+func (d *exportTemplatesDockable) createPathField(tmpl *gurps.ExportTemplate) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	b := unison.NewSVGButton(svg.Edit)
+	b.Tooltip = newWrappedTooltip(i18n.Text("Choose a different template file"))
+	b.ClickCallback = func() {
+		if p := askUserForExportTemplatePath(); p != "" {
+			tmpl.Path = p
+			d.sync()
+		}
+	}
+	b.SetLayoutData(&unison.FlexLayoutData{VAlign: align.Middle})
+	panel.AddChild(b)
+	label := unison.NewLabel()
+	label.SetTitle(filepath.Base(tmpl.Path))
+	label.Tooltip = newWrappedTooltip(tmpl.Path)
+	label.SetLayoutData(&unison.FlexLayoutData{VAlign: align.Middle})
+	panel.AddChild(label)
+	d.content.AddChild(panel)
+}
+
+// showExportTemplateFunctions displays the functions available to export templates, along with a reminder of how the
+// template engine is selected.
+func showExportTemplateFunctions() {
+	var buffer strings.Builder
+	buffer.WriteString(i18n.Text(`## Export Template Functions
+Start a template with "GCS Text Template v1" or "GCS HTML Template v1" on its first line to use Go's template syntax.
+The character is available as "." with fields such as .Name, .Player, .Points, .Attributes, .Traits, .Skills, .Spells,
+.Equipment and .Notes. In addition to Go's built-in functions, templates may call:
+`))
+	buffer.WriteString("\n| | |\n|-|-|\n")
+	for _, one := range gurps.ExportTemplateFunctions() {
+		fmt.Fprintf(&buffer, "|`%s`|%s|\n", one.Usage, one.Description)
+	}
+	md := unison.NewMarkdown(true)
+	md.SetBorder(unison.NewEmptyBorder(unison.StdInsets()))
+	md.SetContent(buffer.String(), 0)
+	scroll := unison.NewScrollPanel()
+	scroll.SetContent(md, behavior.Unmodified, behavior.Unmodified)
+	dialog, err := unison.NewDialog(nil, nil, scroll, []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
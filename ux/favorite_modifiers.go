@@ -0,0 +1,209 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+type modifierTypes interface {
+	*gurps.TraitModifier | *gurps.EquipmentModifier
+	gurps.NodeTypes
+}
+
+func favoriteModifiers[T modifierTypes]() []T {
+	favorites := gurps.GlobalSettings().FavoriteModifiers
+	var zero T
+	switch any(zero).(type) {
+	case *gurps.TraitModifier:
+		return any(favorites.Traits).([]T)
+	case *gurps.EquipmentModifier:
+		return any(favorites.Equipment).([]T)
+	default:
+		return nil
+	}
+}
+
+func toggleFavoriteModifier[T modifierTypes](mod T) {
+	favorites := gurps.GlobalSettings().FavoriteModifiers
+	switch m := any(mod).(type) {
+	case *gurps.TraitModifier:
+		favorites.ToggleFavoriteTraitModifier(m)
+	case *gurps.EquipmentModifier:
+		favorites.ToggleFavoriteEquipmentModifier(m)
+	}
+}
+
+// installToggleFavoriteModifierHandler installs the handler for marking the selected modifiers in the table as
+// favorites, or unmarking them if they already are. changed, if not nil, is called after the favorites are updated.
+func installToggleFavoriteModifierHandler[T modifierTypes](table *unison.Table[*Node[T]], changed func()) {
+	table.InstallCmdHandlers(ToggleFavoriteModifierItemID,
+		func(_ any) bool {
+			for _, row := range table.SelectedRows(false) {
+				if !row.dataAsNode.Container() {
+					return true
+				}
+			}
+			return false
+		},
+		func(_ any) {
+			for _, row := range table.SelectedRows(false) {
+				if !row.dataAsNode.Container() {
+					toggleFavoriteModifier(row.Data())
+				}
+			}
+			if changed != nil {
+				changed()
+			}
+		})
+}
+
+// favoriteModifierChips holds a button for each favorite modifier, which applies that modifier when clicked.
+type favoriteModifierChips[T modifierTypes] struct {
+	unison.Panel
+	apply func(mod T)
+}
+
+func newFavoriteModifierChips[T modifierTypes](apply func(mod T)) *favoriteModifierChips[T] {
+	c := &favoriteModifierChips[T]{apply: apply}
+	c.Self = c
+	c.SetLayout(&unison.FlowLayout{
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	c.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	c.sync()
+	return c
+}
+
+// sync rebuilds the buttons from the current favorites.
+func (c *favoriteModifierChips[T]) sync() {
+	c.RemoveAllChildren()
+	favorites := favoriteModifiers[T]()
+	if len(favorites) == 0 {
+		c.SetBorder(nil)
+	} else {
+		c.SetBorder(unison.NewEmptyBorder(unison.StdInsets()))
+		label := unison.NewLabel()
+		label.Font = unison.FieldFont
+		label.SetTitle(i18n.Text("Favorites:"))
+		c.AddChild(label)
+		for _, mod := range favorites {
+			b := unison.NewButton()
+			b.Font = unison.FieldFont
+			b.SetTitle(mod.String())
+			b.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Add %s"), mod.String()))
+			b.ClickCallback = func() { c.apply(mod) }
+			c.AddChild(b)
+		}
+	}
+	c.MarkForLayoutRecursivelyUpward()
+	c.MarkForRedraw()
+}
+
+func canApplyFavoriteModifier[T gurps.NodeTypes, M modifierTypes](table *unison.Table[*Node[T]]) bool {
+	return table.HasSelection() && len(favoriteModifiers[M]()) != 0
+}
+
+// applyFavoriteModifier asks which favorite modifier to use and then adds it to each selected row in the table.
+func applyFavoriteModifier[T gurps.NodeTypes, M modifierTypes](owner Rebuildable, table *unison.Table[*Node[T]], apply func(target T, mod M) bool) {
+	mod, ok := pickFavoriteModifier[M]()
+	if !ok {
+		return
+	}
+	var undo *unison.UndoEdit[*TableUndoEditData[T]]
+	mgr := unison.UndoManagerFor(table)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*TableUndoEditData[T]]{
+			ID:         unison.NextUndoID(),
+			EditName:   fmt.Sprintf(i18n.Text("Apply %s"), mod.String()),
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
+			BeforeData: NewTableUndoEditData(table),
+		}
+	}
+	changed := false
+	for _, row := range table.SelectedRows(false) {
+		if apply(row.Data(), mod) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	table.SyncToModel()
+	if mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		mgr.Add(undo)
+	}
+	MarkModified(table)
+	owner.Rebuild(true)
+}
+
+func pickFavoriteModifier[M modifierTypes]() (M, bool) {
+	var zero M
+	favorites := favoriteModifiers[M]()
+	if len(favorites) == 0 {
+		return zero, false
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Modifier"), false))
+	popup := unison.NewPopupMenu[M]()
+	popup.AddItem(favorites...)
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return zero, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return zero, false
+	}
+	return popup.Selected()
+}
+
+func installApplyFavoriteTraitModifierHandler(p *unison.Panel, owner Rebuildable, table *unison.Table[*Node[*gurps.Trait]]) {
+	p.InstallCmdHandlers(ApplyFavoriteModifierItemID,
+		func(_ any) bool { return canApplyFavoriteModifier[*gurps.Trait, *gurps.TraitModifier](table) },
+		func(_ any) {
+			applyFavoriteModifier(owner, table, func(target *gurps.Trait, mod *gurps.TraitModifier) bool {
+				return target.ApplyModifier(mod)
+			})
+		})
+}
+
+func installApplyFavoriteEquipmentModifierHandler(p *unison.Panel, owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]]) {
+	p.InstallCmdHandlers(ApplyFavoriteModifierItemID,
+		func(_ any) bool { return canApplyFavoriteModifier[*gurps.Equipment, *gurps.EquipmentModifier](table) },
+		func(_ any) {
+			applyFavoriteModifier(owner, table, func(target *gurps.Equipment, mod *gurps.EquipmentModifier) bool {
+				return target.ApplyModifier(mod)
+			})
+		})
+}
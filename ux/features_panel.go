@@ -109,6 +109,8 @@ func (p *featuresPanel) insertFeaturePanel(index int, f gurps.Feature) {
 		panel = p.createReactionBonusPanel(one)
 	case *gurps.SkillBonus:
 		panel = p.createSkillBonusPanel(one)
+	case *gurps.SkillGrant:
+		panel = p.createSkillGrantPanel(one)
 	case *gurps.SkillPointBonus:
 		panel = p.createSkillPointBonusPanel(one)
 	case *gurps.SpellBonus:
@@ -218,6 +220,20 @@ func (p *featuresPanel) createDRBonusPanel(f *gurps.DRBonus) *unison.Panel {
 	wrapper.AddChild(field)
 	wrapper.AddChild(NewFieldTrailingLabel(i18n.Text("attacks"), false))
 	panel.AddChild(wrapper)
+	panel.AddChild(unison.NewPanel())
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.AddChild(NewCheckBox(nil, "", i18n.Text("Flexible"),
+		func() check.Enum { return check.FromBool(f.Flexible) },
+		func(in check.Enum) { f.Flexible = in == check.On }))
+	wrapper.AddChild(NewCheckBox(nil, "", i18n.Text("Tough Skin"),
+		func() check.Enum { return check.FromBool(f.ToughSkin) },
+		func(in check.Enum) { f.ToughSkin = in == check.On }))
+	panel.AddChild(wrapper)
 	return panel
 }
 
@@ -434,6 +450,32 @@ func (p *featuresPanel) createSkillPointBonusPanel(f *gurps.SkillPointBonus) *un
 	return panel
 }
 
+func (p *featuresPanel) createSkillGrantPanel(f *gurps.SkillGrant) *unison.Panel {
+	panel := p.createBasePanel(f)
+	wrapper := unison.NewPanel()
+	p.addTypeSwitcher(wrapper, f)
+	wrapper.AddChild(NewFieldInteriorLeadingLabel(i18n.Text("at level"), false))
+	addDecimalField(wrapper, nil, "", i18n.Text("Level"), i18n.Text("The level of the granted skill"), &f.Level,
+		-fxp.NinetyNine, fxp.NinetyNine)
+	p.addWrapperAtIndex(panel, wrapper, -1, false)
+	panel.AddChild(unison.NewPanel())
+	wrapper = unison.NewPanel()
+	nameField := addStringField(wrapper, i18n.Text("Skill Name"), "", &f.Name)
+	nameField.Watermark = i18n.Text("Skill Name")
+	nameField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	specField := addStringField(wrapper, i18n.Text("Specialization"), "", &f.Specialization)
+	specField.Watermark = i18n.Text("Specialization")
+	specField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.addWrapperAtIndex(panel, wrapper, -1, true)
+	return panel
+}
+
 func (p *featuresPanel) createSpellBonusPanel(f *gurps.SpellBonus) *unison.Panel {
 	panel := p.createBasePanel(f)
 	p.addLeveledModifierLine(panel, f, &f.LeveledAmount)
@@ -679,7 +721,10 @@ func (p *featuresPanel) featureTypesList() []feature.Type {
 	if e, ok := p.owner.(*gurps.Equipment); ok && e.Container() {
 		return feature.Types
 	}
-	return feature.TypesWithoutContainedWeightReduction
+	if _, ok := p.owner.(*gurps.Equipment); ok || p.forEquipmentModifier {
+		return feature.TypesWithoutContainedWeightReduction
+	}
+	return feature.TypesWithoutEquipmentOnly
 }
 
 func (p *featuresPanel) addTypeSwitcher(parent *unison.Panel, f gurps.Feature) *unison.PopupMenu[feature.Type] {
@@ -718,6 +763,8 @@ func (p *featuresPanel) createFeatureForType(featureType feature.Type) gurps.Fea
 		bonus = gurps.NewReactionBonus()
 	case feature.SkillBonus:
 		bonus = gurps.NewSkillBonus()
+	case feature.SkillGrant:
+		return gurps.NewSkillGrant()
 	case feature.SkillPointBonus:
 		bonus = gurps.NewSkillPointBonus()
 	case feature.SpellBonus:
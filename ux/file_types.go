@@ -111,9 +111,10 @@ func RegisterGCSFileTypes() {
 	registerExportableGCSFileInfo("GCS Sheet", gurps.SheetExt, svg.GCSSheet, NewSheetFromFile)
 	registerGCSFileInfo("GCS Template", gurps.TemplatesExt, []string{gurps.TemplatesExt}, svg.GCSTemplate,
 		NewTemplateFromFile)
-	// TODO: Re-enable Campaign files
-	// registerGCSFileInfo("GCS Campaign", gurps.CampaignExt, []string{gurps.CampaignExt}, svg.GCSCampaign,
-	// 	NewCampaignFromFile)
+	registerGCSFileInfo("GCS Campaign", gurps.CampaignExt, []string{gurps.CampaignExt}, svg.GCSCampaign,
+		NewCampaignFromFile)
+	registerGCSFileInfo("GCS Combat", gurps.CombatExt, []string{gurps.CombatExt}, svg.MeleeWeapon,
+		NewCombatTrackerFromFile)
 	groupWith := []string{
 		gurps.TraitsExt,
 		gurps.TraitModifiersExt,
@@ -133,6 +134,24 @@ func RegisterGCSFileTypes() {
 	registerGCSFileInfo("GCS Skills", gurps.SkillsExt, groupWith, svg.GCSSkills, NewSkillTableDockableFromFile)
 	registerGCSFileInfo("GCS Spells", gurps.SpellsExt, groupWith, svg.GCSSpells, NewSpellTableDockableFromFile)
 	registerGCSFileInfo("GCS Notes", gurps.NotesExt, groupWith, svg.GCSNotes, NewNoteTableDockableFromFile)
+	registerBodyTypeFileInfo()
+}
+
+// registerBodyTypeFileInfo registers the body type files so that they can be opened for editing. They aren't flagged
+// as GCS data since they remain secondary files, e.g. for the purposes of conversion and file associations.
+func registerBodyTypeFileInfo() {
+	extensions := []string{gurps.BodyExt, gurps.BodyExtAlt}
+	fi := gurps.FileInfo{
+		Name:       "GCS Body Type",
+		UTI:        cmdline.AppIdentifier + gurps.BodyExt,
+		ConformsTo: []string{"public.data"},
+		Extensions: extensions,
+		GroupWith:  extensions,
+		MimeTypes:  []string{"application/x-gcs-" + gurps.BodyExt[1:]},
+		SVG:        svg.BodyType,
+		Load:       func(filePath string, _ int) (unison.Dockable, error) { return NewBodyTypeDockableFromFile(filePath) },
+	}
+	fi.Register()
 }
 
 func registerGCSFileInfo(name, ext string, groupWith []string, icon *unison.SVG, loader func(filePath string) (unison.Dockable, error)) {
@@ -41,12 +41,17 @@ type generalSettingsDockable struct {
 	autoAddNaturalAttacksCheckbox  *CheckBox
 	groupContainersOnSortCheckbox  *CheckBox
 	initialClickSelectsAllCheckbox *CheckBox
+	autoBackupCheckbox             *CheckBox
 	pointsField                    *DecimalField
 	techLevelField                 *StringField
 	calendarPopup                  *unison.PopupMenu[string]
+	newCharTemplateField           *StringField
+	newCharPortraitField           *StringField
+	newCharAttributesField         *StringField
 	initialListScaleField          *PercentageField
 	initialEditorScaleField        *PercentageField
 	initialSheetScaleField         *PercentageField
+	defaultSheetScaleField         *PercentageField
 	initialPDFScaleField           *PercentageField
 	initialMarkdownScaleField      *PercentageField
 	initialImageScaleField         *PercentageField
@@ -54,10 +59,12 @@ type generalSettingsDockable struct {
 	maxAutoColWidthField           *IntegerField
 	monitorResolutionField         *IntegerField
 	exportResolutionField          *IntegerField
+	autoBackupRetentionField       *IntegerField
 	tooltipDelayField              *DecimalField
 	tooltipDismissalField          *DecimalField
 	scrollWheelMultiplierField     *DecimalField
 	externalPDFCmdlineField        *StringField
+	fileNamePatternField           *StringField
 	localeField                    *StringField
 }
 
@@ -100,6 +107,7 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 	d.createInitialPointsFields(content)
 	d.createTechLevelField(content)
 	d.createCalendarPopup(content)
+	d.createNewCharacterFields(content)
 	initialListScaleTitle := i18n.Text("Initial List Scale")
 	content.AddChild(NewFieldLeadingLabel(initialListScaleTitle, false))
 	d.initialListScaleField = NewPercentageField(nil, "", initialListScaleTitle,
@@ -121,6 +129,14 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 		func(v int) { gurps.GlobalSettings().General.InitialSheetUIScale = v },
 		gurps.InitialUIScaleMin, gurps.InitialUIScaleMax, false, false)
 	content.AddChild(WrapWithSpan(2, d.initialSheetScaleField))
+	defaultSheetScaleTitle := i18n.Text("Default Sheet Scale")
+	content.AddChild(NewFieldLeadingLabel(defaultSheetScaleTitle, false))
+	d.defaultSheetScaleField = NewPercentageField(nil, "", defaultSheetScaleTitle,
+		func() int { return gurps.GlobalSettings().General.DefaultSheetUIScale },
+		func(v int) { gurps.GlobalSettings().General.DefaultSheetUIScale = v },
+		gurps.InitialUIScaleMin, gurps.InitialUIScaleMax, false, false)
+	d.defaultSheetScaleField.Tooltip = newWrappedTooltip(i18n.Text("The scale sheets and templates return to when the default scale is chosen. This is separate from the scale they are initially opened with."))
+	content.AddChild(WrapWithSpan(2, d.defaultSheetScaleField))
 
 	initialPDFScaleTitle := i18n.Text("Initial PDF Scale")
 	content.AddChild(NewFieldLeadingLabel(initialPDFScaleTitle, false))
@@ -157,13 +173,16 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 	d.createCellAutoMaxWidthField(content)
 	d.createMonitorResolutionField(content)
 	d.createImageResolutionField(content)
+	d.createAutoBackupRetentionField(content)
 	d.createTooltipDelayField(content)
 	d.createTooltipDismissalField(content)
 	d.createScrollWheelMultiplierField(content)
 	d.createPathInfoField(content, i18n.Text("Settings Path"), gurps.SettingsPath)
 	d.createPathInfoField(content, i18n.Text("Translations Path"), i18n.Dir)
 	d.createPathInfoField(content, i18n.Text("Log Path"), rotation.PathToLog)
+	d.createPathInfoField(content, i18n.Text("Backups Path"), gurps.BackupDir())
 	d.createExternalPDFCmdLineField(content)
+	d.createFileNamePatternField(content)
 	d.createLocaleField(content)
 }
 
@@ -225,6 +244,18 @@ func (d *generalSettingsDockable) createCheckboxBlock(content *unison.Panel) {
 	d.initialClickSelectsAllCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
 	content.AddChild(NewFieldLeadingLabel("", false))
 	content.AddChild(d.initialClickSelectsAllCheckbox)
+
+	d.autoBackupCheckbox = NewCheckBox(nil, "", i18n.Text("Back up files before bulk operations"),
+		func() check.Enum {
+			return check.FromBool(!gurps.GlobalSettings().General.DisableAutoBackup)
+		},
+		func(state check.Enum) {
+			gurps.GlobalSettings().General.DisableAutoBackup = state != check.On
+		})
+	d.autoBackupCheckbox.Tooltip = newWrappedTooltip(i18n.Text("Before applying a template, syncing with library sources or merging from another file, write a copy of the file into the backups directory"))
+	d.autoBackupCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(NewFieldLeadingLabel("", false))
+	content.AddChild(d.autoBackupCheckbox)
 }
 
 func (d *generalSettingsDockable) createInitialPointsFields(content *unison.Panel) {
@@ -270,6 +301,57 @@ func (d *generalSettingsDockable) createCalendarPopup(content *unison.Panel) {
 	content.AddChild(d.calendarPopup)
 }
 
+func (d *generalSettingsDockable) createNewCharacterFields(content *unison.Panel) {
+	d.newCharTemplateField = d.createNewCharacterField(content, i18n.Text("New Character Template"),
+		i18n.Text("The path to a template to apply to new character sheets. Leave blank to not apply one."),
+		func(defaults *gurps.NewCharacterDefaults) string { return defaults.TemplatePath },
+		func(defaults *gurps.NewCharacterDefaults, s string) { defaults.TemplatePath = s })
+	d.newCharPortraitField = d.createNewCharacterField(content, i18n.Text("New Character Portrait"),
+		i18n.Text("The path to an image to use as the placeholder portrait for new character sheets. Leave blank to not use one."),
+		func(defaults *gurps.NewCharacterDefaults) string { return defaults.PortraitPath },
+		func(defaults *gurps.NewCharacterDefaults, s string) { defaults.PortraitPath = s })
+	d.newCharAttributesField = d.createNewCharacterField(content, i18n.Text("New Character Attributes"),
+		i18n.Text(`The starting values for attributes of new character sheets, as a comma-separated list of attribute IDs and values, such as "st=11, dx=12". Attributes not listed use their normal starting values.`),
+		func(defaults *gurps.NewCharacterDefaults) string { return defaults.AttributesText() },
+		func(defaults *gurps.NewCharacterDefaults, s string) {
+			defaults.ParseAttributesText(s) //nolint:errcheck // Invalid text is flagged by validation and leaves the prior values.
+		})
+	d.newCharAttributesField.ValidateCallback = func() bool {
+		var defaults gurps.NewCharacterDefaults
+		return defaults.ParseAttributesText(d.newCharAttributesField.Text()) == nil
+	}
+	d.newCharAttributesField.Watermark = "st=11, dx=12"
+}
+
+func (d *generalSettingsDockable) createNewCharacterField(content *unison.Panel, title, tooltip string, get func(defaults *gurps.NewCharacterDefaults) string, set func(defaults *gurps.NewCharacterDefaults, s string)) *StringField {
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	field := NewStringField(nil, "", title,
+		func() string {
+			if defaults := gurps.GlobalSettings().General.NewCharacter; defaults != nil {
+				return get(defaults)
+			}
+			return ""
+		},
+		func(s string) {
+			gs := gurps.GlobalSettings().General
+			if gs.NewCharacter == nil {
+				gs.NewCharacter = &gurps.NewCharacterDefaults{}
+			}
+			set(gs.NewCharacter, strings.TrimSpace(s))
+			if gs.NewCharacter.Empty() {
+				gs.NewCharacter = nil
+			}
+		})
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	field.Tooltip = newWrappedTooltip(tooltip)
+	content.AddChild(field)
+	return field
+}
+
 func (d *generalSettingsDockable) createCellAutoMaxWidthField(content *unison.Panel) {
 	title := i18n.Text("Max Auto Column Width")
 	content.AddChild(NewFieldLeadingLabel(title, false))
@@ -303,6 +385,16 @@ func (d *generalSettingsDockable) createImageResolutionField(content *unison.Pan
 	content.AddChild(WrapWithSpan(2, d.exportResolutionField, NewFieldTrailingLabel(i18n.Text("ppi"), false)))
 }
 
+func (d *generalSettingsDockable) createAutoBackupRetentionField(content *unison.Panel) {
+	title := i18n.Text("Backups to Keep")
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	d.autoBackupRetentionField = NewIntegerField(nil, "", title,
+		func() int { return gurps.GlobalSettings().General.AutoBackupRetention },
+		func(v int) { gurps.GlobalSettings().General.AutoBackupRetention = v },
+		gurps.AutoBackupRetentionMin, gurps.AutoBackupRetentionMax, false, false)
+	content.AddChild(WrapWithSpan(2, d.autoBackupRetentionField, NewFieldTrailingLabel(i18n.Text("per file"), false)))
+}
+
 func (d *generalSettingsDockable) createTooltipDelayField(content *unison.Panel) {
 	title := i18n.Text("Tooltip Delay")
 	content.AddChild(NewFieldLeadingLabel(title, false))
@@ -376,6 +468,28 @@ In most cases, you'll want to surround the $FILE variable with quotes.`))
 	content.AddChild(d.externalPDFCmdlineField)
 }
 
+func (d *generalSettingsDockable) createFileNamePatternField(content *unison.Panel) {
+	title := i18n.Text("File Name Pattern")
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	d.fileNamePatternField = NewStringField(nil, "", title,
+		func() string { return gurps.GlobalSettings().General.FileNamePattern },
+		func(s string) { gurps.GlobalSettings().General.FileNamePattern = strings.TrimSpace(s) })
+	d.fileNamePatternField.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.fileNamePatternField.Watermark = "{name} - {points}pts - {date}"
+	d.fileNamePatternField.Tooltip = newWrappedTooltip(i18n.Text(`When not empty, used to build the file name suggested when saving as or exporting.
+Use {name} for the character's name.
+Use {player} for the player's name.
+Use {points} for the character's total points.
+Use {tl} for the character's tech level.
+Use {date} for today's date.
+Use {file} for the current file name.`))
+	content.AddChild(d.fileNamePatternField)
+}
+
 func (d *generalSettingsDockable) createLocaleField(content *unison.Panel) {
 	title := i18n.Text("Interface Locale")
 	content.AddChild(NewFieldLeadingLabel(title, false))
@@ -406,12 +520,22 @@ func (d *generalSettingsDockable) sync() {
 	SetCheckBoxState(d.groupContainersOnSortCheckbox, gs.GroupContainersOnSort)
 	SetCheckBoxState(d.autoAddNaturalAttacksCheckbox, gs.AutoAddNaturalAttacks)
 	SetCheckBoxState(d.initialClickSelectsAllCheckbox, gs.InitialFieldClickSelectsAll)
+	SetCheckBoxState(d.autoBackupCheckbox, !gs.DisableAutoBackup)
 	d.pointsField.SetText(gs.InitialPoints.String())
 	d.techLevelField.SetText(gs.DefaultTechLevel)
 	d.calendarPopup.Select(gs.CalendarRef(s.Libraries()).Name)
+	var newCharTemplate, newCharPortrait string
+	if gs.NewCharacter != nil {
+		newCharTemplate = gs.NewCharacter.TemplatePath
+		newCharPortrait = gs.NewCharacter.PortraitPath
+	}
+	SetFieldValue(d.newCharTemplateField.Field, newCharTemplate)
+	SetFieldValue(d.newCharPortraitField.Field, newCharPortrait)
+	SetFieldValue(d.newCharAttributesField.Field, gs.NewCharacter.AttributesText())
 	SetFieldValue(d.initialListScaleField.Field, d.initialListScaleField.Format(gs.InitialListUIScale))
 	SetFieldValue(d.initialEditorScaleField.Field, d.initialEditorScaleField.Format(gs.InitialEditorUIScale))
 	SetFieldValue(d.initialSheetScaleField.Field, d.initialSheetScaleField.Format(gs.InitialSheetUIScale))
+	SetFieldValue(d.defaultSheetScaleField.Field, d.defaultSheetScaleField.Format(gs.DefaultSheetUIScale))
 	SetFieldValue(d.initialPDFScaleField.Field, d.initialPDFScaleField.Format(gs.InitialPDFUIScale))
 	d.autoScalingPopup.Select(gs.PDFAutoScaling)
 	SetFieldValue(d.initialMarkdownScaleField.Field, d.initialMarkdownScaleField.Format(gs.InitialMarkdownUIScale))
@@ -419,10 +543,12 @@ func (d *generalSettingsDockable) sync() {
 	d.maxAutoColWidthField.SetText(strconv.Itoa(gs.MaximumAutoColWidth))
 	d.monitorResolutionField.SetText(strconv.Itoa(gs.MonitorResolution))
 	d.exportResolutionField.SetText(strconv.Itoa(gs.ImageResolution))
+	d.autoBackupRetentionField.SetText(strconv.Itoa(gs.AutoBackupRetention))
 	d.tooltipDelayField.SetText(gs.TooltipDelay.String())
 	d.tooltipDismissalField.SetText(gs.TooltipDismissal.String())
 	d.scrollWheelMultiplierField.SetText(gs.ScrollWheelMultiplier.String())
 	SetFieldValue(d.externalPDFCmdlineField.Field, gs.ExternalPDFCmdLine)
+	SetFieldValue(d.fileNamePatternField.Field, gs.FileNamePattern)
 	SetFieldValue(d.localeField.Field, languageSetting)
 	d.MarkForRedraw()
 }
@@ -0,0 +1,290 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ unison.Dockable            = &GMScreen{}
+	_ unison.UndoManagerProvider = &GMScreen{}
+)
+
+// GMScreen shows reference tables side by side for use while running a game. Along with the built-in tables, any
+// markdown files the user has pinned are shown as additional panels.
+type GMScreen struct {
+	unison.Panel
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// ShowGMScreen displays the GM screen.
+func ShowGMScreen() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*GMScreen)
+		return ok
+	}) {
+		return
+	}
+	g := &GMScreen{scale: gurps.GlobalSettings().General.InitialEditorUIScale}
+	g.Self = g
+	g.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	g.SetLayout(&unison.FlexLayout{Columns: 1})
+	g.content = unison.NewPanel()
+	g.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	g.scroll = unison.NewScrollPanel()
+	g.scroll.SetContent(g.content, behavior.HintedFill, behavior.Unmodified)
+	g.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	g.AddChild(g.createToolbar())
+	g.AddChild(g.scroll)
+	g.refresh()
+	PlaceInDock(g, dgroup.Editors, false)
+}
+
+func (g *GMScreen) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return g.scale },
+			func(scale int) { g.scale = scale },
+			nil,
+			false,
+			g.scroll,
+		),
+	)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("Columns"), false))
+	toolbar.AddChild(NewIntegerField(nil, "", i18n.Text("Columns"),
+		func() int { return gurps.GlobalSettings().GMScreen.Columns },
+		func(columns int) {
+			gurps.GlobalSettings().GMScreen.Columns = columns
+			g.refresh()
+		}, gurps.GMScreenColumnsMin, gurps.GMScreenColumnsMax, false, false))
+	tablesButton := unison.NewSVGButton(svg.Menu)
+	tablesButton.Tooltip = newWrappedTooltip(i18n.Text("Choose the reference tables to show"))
+	tablesButton.ClickCallback = func() { g.showTablesMenu(tablesButton) }
+	toolbar.AddChild(tablesButton)
+	pinButton := unison.NewSVGButton(svg.MarkdownFile)
+	pinButton.Tooltip = newWrappedTooltip(i18n.Text("Pin markdown files as additional panels"))
+	pinButton.ClickCallback = g.pinFiles
+	toolbar.AddChild(pinButton)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Reload the pinned markdown files"))
+	refreshButton.ClickCallback = g.refresh
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (g *GMScreen) showTablesMenu(button *unison.Button) {
+	settings := gurps.GlobalSettings().GMScreen
+	f := unison.DefaultMenuFactory()
+	cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	for i, one := range gurps.GMScreenTables(nil) {
+		item := f.NewItem(unison.PopupMenuTemporaryBaseID+i+1, one.Title, unison.KeyBinding{}, nil,
+			func(_ unison.MenuItem) {
+				settings.SetHidden(one.Key, !settings.IsHidden(one.Key))
+				g.refresh()
+			})
+		item.SetCheckState(check.FromBool(!settings.IsHidden(one.Key)))
+		cm.InsertItem(-1, item)
+	}
+	button.FlushDrawing()
+	cm.Popup(button.RectToRoot(button.ContentRect(true)), 0)
+	cm.Dispose()
+}
+
+func (g *GMScreen) pinFiles() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.MarkdownExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	paths := dialog.Paths()
+	if len(paths) == 0 {
+		return
+	}
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(paths[0]))
+	for _, one := range paths {
+		global.GMScreen.Pin(one)
+	}
+	g.refresh()
+}
+
+func (g *GMScreen) refresh() {
+	settings := gurps.GlobalSettings().GMScreen
+	g.content.RemoveAllChildren()
+	g.content.SetLayout(&unison.FlexLayout{
+		Columns:      settings.Columns,
+		HSpacing:     unison.StdHSpacing * 2,
+		VSpacing:     unison.StdVSpacing * 2,
+		EqualColumns: true,
+	})
+	for _, one := range gurps.GMScreenTables(gurps.GlobalSettings().Sheet.BodyType) {
+		if !settings.IsHidden(one.Key) {
+			g.addPanel(one.Title, one.Markdown, nil)
+		}
+	}
+	for _, one := range settings.Pinned {
+		filePath := one
+		content := ""
+		if data, err := os.ReadFile(filePath); err != nil {
+			content = fmt.Sprintf(i18n.Text("Unable to read %s"), filePath)
+			errs.Log(err, "path", filePath)
+		} else {
+			content = txt.NormalizeLineEndings(string(data))
+		}
+		unpin := unison.NewSVGButton(svg.Trash)
+		unpin.Tooltip = newWrappedTooltip(i18n.Text("Remove this panel from the GM screen"))
+		unpin.ClickCallback = func() {
+			settings.Unpin(filePath)
+			g.refresh()
+		}
+		g.addPanel(fs.BaseName(filePath), content, unpin).ClientData()[WorkingDirKey] = filepath.Dir(filePath)
+	}
+	if len(g.content.Children()) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No reference tables or pinned files are being shown"))
+		g.content.AddChild(label)
+	}
+	g.content.MarkForLayoutRecursively()
+	g.MarkForRedraw()
+}
+
+// addPanel adds a titled panel showing the markdown content and returns the markdown widget. If button isn't nil, it
+// is placed alongside the title.
+func (g *GMScreen) addPanel(title, content string, button *unison.Button) *unison.Markdown {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Start,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(title)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.AddChild(label)
+	if button != nil {
+		header.AddChild(button)
+	}
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  len(header.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.AddChild(header)
+	markdown := unison.NewMarkdown(true)
+	markdown.SetContent(content, 0)
+	markdown.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(markdown)
+	g.content.AddChild(panel)
+	return markdown
+}
+
+// TitleIcon implements unison.Dockable
+func (g *GMScreen) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Stack,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (g *GMScreen) Title() string {
+	return i18n.Text("GM Screen")
+}
+
+func (g *GMScreen) String() string {
+	return g.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (g *GMScreen) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (g *GMScreen) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (g *GMScreen) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (g *GMScreen) AttemptClose() bool {
+	return AttemptCloseForDockable(g)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (g *GMScreen) UndoManager() *unison.UndoManager {
+	return g.undoMgr
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/paper"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/thememode"
+)
+
+const gmSummaryCellPadding = 2
+
+var _ unison.PageProvider = &gmSummaryExporter{}
+
+type gmSummaryColumn struct {
+	title string
+	width float32 // Percentage of the available width
+	value func(s *gurps.GMSummary) string
+}
+
+type gmSummaryRow struct {
+	cells  [][]*unison.Text
+	height float32
+}
+
+type gmSummaryExporter struct {
+	page    *gurps.PageSettings
+	columns []*gmSummaryColumn
+	header  *gmSummaryRow
+	pages   [][]*gmSummaryRow
+}
+
+func gmSummaryColumns() []*gmSummaryColumn {
+	return []*gmSummaryColumn{
+		{
+			title: i18n.Text("Character"),
+			width: 13,
+			value: func(s *gurps.GMSummary) string {
+				if s.Player != "" {
+					return s.Name + "\n(" + s.Player + ")"
+				}
+				return s.Name
+			},
+		},
+		{title: i18n.Text("HP"), width: 5, value: func(s *gurps.GMSummary) string { return s.HP }},
+		{title: i18n.Text("FP"), width: 5, value: func(s *gurps.GMSummary) string { return s.FP }},
+		{title: i18n.Text("Dodge"), width: 4, value: func(s *gurps.GMSummary) string { return s.Dodge }},
+		{title: i18n.Text("Parry"), width: 9, value: func(s *gurps.GMSummary) string { return s.Parry }},
+		{title: i18n.Text("Block"), width: 9, value: func(s *gurps.GMSummary) string { return s.Block }},
+		{title: i18n.Text("DR"), width: 4, value: func(s *gurps.GMSummary) string { return s.DR }},
+		{title: i18n.Text("Per"), width: 4, value: func(s *gurps.GMSummary) string { return s.Perception }},
+		{title: i18n.Text("Will"), width: 4, value: func(s *gurps.GMSummary) string { return s.Will }},
+		{
+			title: i18n.Text("Senses"),
+			width: 13,
+			value: func(s *gurps.GMSummary) string { return strings.Join(s.Senses, "\n") },
+		},
+		{
+			title: i18n.Text("Key Skills"),
+			width: 19,
+			value: func(s *gurps.GMSummary) string { return strings.Join(s.KeySkills, "\n") },
+		},
+		{
+			title: i18n.Text("Languages"),
+			width: 11,
+			value: func(s *gurps.GMSummary) string { return strings.Join(s.Languages, "\n") },
+		},
+	}
+}
+
+func newGMSummaryExporter(summaries []*gurps.GMSummary) *gmSummaryExporter {
+	page := *gurps.GlobalSettings().Sheet.Page
+	page.Orientation = paper.Landscape
+	p := &gmSummaryExporter{
+		page:    &page,
+		columns: gmSummaryColumns(),
+	}
+	titles := make([]string, len(p.columns))
+	for i, col := range p.columns {
+		titles[i] = col.title
+	}
+	p.header = p.newRow(titles, fonts.PageFieldPrimary)
+	size := p.PageSize()
+	available := size.Height - (p.page.TopMargin.Pixels() + p.page.BottomMargin.Pixels() + p.header.height)
+	var current []*gmSummaryRow
+	var used float32
+	for _, s := range summaries {
+		values := make([]string, len(p.columns))
+		for i, col := range p.columns {
+			values[i] = col.value(s)
+		}
+		row := p.newRow(values, fonts.PageLabelPrimary)
+		if len(current) != 0 && used+row.height > available {
+			p.pages = append(p.pages, current)
+			current = nil
+			used = 0
+		}
+		current = append(current, row)
+		used += row.height
+	}
+	if len(current) != 0 {
+		p.pages = append(p.pages, current)
+	}
+	return p
+}
+
+func (p *gmSummaryExporter) contentWidth() float32 {
+	return p.PageSize().Width - (p.page.LeftMargin.Pixels() + p.page.RightMargin.Pixels())
+}
+
+func (p *gmSummaryExporter) newRow(values []string, font unison.Font) *gmSummaryRow {
+	decoration := &unison.TextDecoration{
+		Font:            font,
+		OnBackgroundInk: unison.ThemeOnSurface,
+	}
+	width := p.contentWidth()
+	row := &gmSummaryRow{cells: make([][]*unison.Text, len(values))}
+	for i, value := range values {
+		var height float32
+		if value != "" {
+			row.cells[i] = unison.NewTextWrappedLines(value, decoration,
+				width*p.columns[i].width/100-2*gmSummaryCellPadding)
+			for _, line := range row.cells[i] {
+				height += line.Height()
+			}
+		}
+		row.height = max(row.height, height+2*gmSummaryCellPadding)
+	}
+	return row
+}
+
+// HasPage implements unison.PageProvider.
+func (p *gmSummaryExporter) HasPage(pageNumber int) bool {
+	return pageNumber > 0 && pageNumber <= len(p.pages)
+}
+
+// PageSize implements unison.PageProvider.
+func (p *gmSummaryExporter) PageSize() unison.Size {
+	w, h := p.page.Orientation.Dimensions(p.page.Size.Dimensions())
+	return unison.NewSize(w.Pixels(), h.Pixels())
+}
+
+// DrawPage implements unison.PageProvider.
+func (p *gmSummaryExporter) DrawPage(canvas *unison.Canvas, pageNumber int) error {
+	if !p.HasPage(pageNumber) {
+		return errs.New("invalid page number")
+	}
+	size := p.PageSize()
+	r := unison.Rect{Size: size}
+	canvas.DrawRect(r, unison.ThemeBelowSurface.Paint(canvas, r, paintstyle.Fill))
+	y := p.page.TopMargin.Pixels()
+	y = p.drawRow(canvas, p.header, y, true)
+	for _, row := range p.pages[pageNumber-1] {
+		y = p.drawRow(canvas, row, y, false)
+	}
+	return nil
+}
+
+func (p *gmSummaryExporter) drawRow(canvas *unison.Canvas, row *gmSummaryRow, y float32, header bool) float32 {
+	left := p.page.LeftMargin.Pixels()
+	width := p.contentWidth()
+	r := unison.NewRect(left, y, width, row.height)
+	if header {
+		canvas.DrawRect(r, unison.ThemeSurface.Paint(canvas, r, paintstyle.Fill))
+	}
+	x := left
+	for i, lines := range row.cells {
+		colWidth := width * p.columns[i].width / 100
+		ty := y + gmSummaryCellPadding
+		for _, line := range lines {
+			line.Draw(canvas, x+gmSummaryCellPadding, ty+line.Baseline())
+			ty += line.Height()
+		}
+		if i != 0 {
+			canvas.DrawLine(x, y, x, y+row.height, unison.ThemeSurfaceEdge.Paint(canvas, r, paintstyle.Stroke))
+		}
+		x += colWidth
+	}
+	canvas.DrawRect(r, unison.ThemeSurfaceEdge.Paint(canvas, r, paintstyle.Stroke))
+	return y + row.height
+}
+
+func (p *gmSummaryExporter) exportAsPDFFile(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err)
+	}
+	stream, err := unison.NewFileStream(filePath)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	savedColorMode := unison.CurrentThemeMode()
+	unison.SetThemeMode(thememode.Light)
+	unison.ThemeChanged()
+	unison.RebuildDynamicColors()
+	defer func() {
+		unison.SetThemeMode(savedColorMode)
+		unison.ThemeChanged()
+		unison.RebuildDynamicColors()
+	}()
+	return unison.CreatePDF(stream, &unison.PDFMetaData{
+		Title:           i18n.Text("GM Screen Summary"),
+		Author:          toolbox.CurrentUserName(),
+		Subject:         i18n.Text("GM Screen Summary"),
+		Keywords:        "GCS GM Screen Summary",
+		Creator:         "GCS",
+		RasterDPI:       300,
+		EncodingQuality: 101,
+	}, p)
+}
+
+// gmSummariesFromOpenSheets returns a GMSummary for each open sheet.
+func gmSummariesFromOpenSheets() []*gurps.GMSummary {
+	var list []*gurps.GMSummary
+	for _, one := range AllDockables() {
+		if s, ok := one.(*Sheet); ok {
+			list = append(list, gurps.NewGMSummary(s.Entity()))
+		}
+	}
+	return list
+}
+
+// gmSummariesFromFiles asks the user for sheet and campaign files and returns a GMSummary for each character found
+// within them.
+func gmSummariesFromFiles() []*gurps.GMSummary {
+	entities := charactersFromFiles()
+	list := make([]*gurps.GMSummary, 0, len(entities))
+	for _, e := range entities {
+		list = append(list, gurps.NewGMSummary(e))
+	}
+	return list
+}
+
+// charactersFromFiles asks the user for sheet and campaign files and returns the characters found within them.
+func charactersFromFiles() []*gurps.Entity {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt[1:], gurps.CampaignExt[1:])
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return nil
+	}
+	var list []*gurps.Entity
+	for _, one := range dialog.Paths() {
+		global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(one))
+		fileSystem := os.DirFS(filepath.Dir(one))
+		name := filepath.Base(one)
+		if strings.EqualFold(filepath.Ext(one), gurps.CampaignExt) {
+			campaign, err := gurps.NewCampaignFromFile(fileSystem, name)
+			if err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to load campaign"), err)
+				return nil
+			}
+			list = append(list, campaign.Characters...)
+		} else {
+			entity, err := gurps.NewEntityFromFile(fileSystem, name)
+			if err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to load sheet"), err)
+				return nil
+			}
+			list = append(list, entity)
+		}
+	}
+	return list
+}
+
+// ExportGMSummary exports a condensed, one row per character summary suitable for a GM screen insert as a PDF. The
+// characters in all open sheets are used. If no sheets are open, the user is asked to choose sheet or campaign files.
+func ExportGMSummary() {
+	summaries := gmSummariesFromOpenSheets()
+	if len(summaries) == 0 {
+		if summaries = gmSummariesFromFiles(); len(summaries) == 0 {
+			return
+		}
+	}
+	dialog := unison.NewSaveDialog()
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	dialog.SetAllowedExtensions("pdf")
+	dialog.SetInitialFileName(i18n.Text("GM Screen Summary"))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "pdf", false); ok {
+			global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := newGMSummaryExporter(summaries).exportAsPDFFile(filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export GM screen summary!"), err)
+			}
+		}
+	}
+}
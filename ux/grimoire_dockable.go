@@ -0,0 +1,345 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &GrimoireDockable{}
+	_ unison.UndoManagerProvider = &GrimoireDockable{}
+	_ GroupedCloser              = &GrimoireDockable{}
+)
+
+// GrimoireDockable displays the Ritual Path Magic rituals of a character, along with the energy gathered for each.
+type GrimoireDockable struct {
+	unison.Panel
+	sheet   *Sheet
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// DisplayGrimoire displays the grimoire for the given Sheet.
+func DisplayGrimoire(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if g, ok := d.AsPanel().Self.(*GrimoireDockable); ok {
+			return g.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateGrimoire(sheet)
+		return
+	}
+	g := &GrimoireDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	g.Self = g
+	g.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	g.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	g.content = unison.NewPanel()
+	g.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	g.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	g.scroll = unison.NewScrollPanel()
+	g.scroll.SetContent(g.content, behavior.HintedFill, behavior.Unmodified)
+	g.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	g.AddChild(g.createToolbar())
+	g.AddChild(g.scroll)
+	g.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	g.refresh()
+	PlaceInDock(g, dgroup.Editors, false)
+}
+
+// UpdateGrimoire refreshes the grimoire for the given Sheet, if it is being displayed.
+func UpdateGrimoire(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if g, ok := other.(*GrimoireDockable); ok && g.sheet == sheet {
+			g.refresh()
+			break
+		}
+	}
+}
+
+func (g *GrimoireDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return g.scale },
+			func(scale int) { g.scale = scale },
+			nil,
+			false,
+			g.scroll,
+		),
+	)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add a ritual"))
+	addButton.ClickCallback = g.addRitual
+	toolbar.AddChild(addButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (g *GrimoireDockable) addRitual() {
+	entity := g.sheet.Entity()
+	if r := buildRitual(entity, gurps.NewRitual()); r != nil {
+		changeGrimoire(g.sheet, i18n.Text("Add Ritual"), func() {
+			entity.Grimoire = append(entity.Grimoire, r)
+		})
+	}
+}
+
+func (g *GrimoireDockable) editRitual(ritual *gurps.Ritual) {
+	entity := g.sheet.Entity()
+	if r := buildRitual(entity, ritual); r != nil {
+		changeGrimoire(g.sheet, i18n.Text("Edit Ritual"), func() {
+			if i := slices.Index(entity.Grimoire, ritual); i != -1 {
+				entity.Grimoire[i] = r
+			}
+		})
+	}
+}
+
+func (g *GrimoireDockable) removeRitual(ritual *gurps.Ritual) {
+	entity := g.sheet.Entity()
+	changeGrimoire(g.sheet, i18n.Text("Remove Ritual"), func() {
+		entity.Grimoire = slices.DeleteFunc(entity.Grimoire, func(r *gurps.Ritual) bool { return r == ritual })
+	})
+}
+
+func (g *GrimoireDockable) gatherEnergy(ritual *gurps.Ritual) {
+	entity := g.sheet.Entity()
+	amount := 1
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(ritualSkillText(entity, ritual))
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Energy Gathered"), false))
+	panel.AddChild(NewIntegerField(nil, "", i18n.Text("Energy Gathered"),
+		func() int { return amount },
+		func(v int) { amount = v }, 1, max(ritual.Remaining(), 1), false, false))
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Gather")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	changeGrimoire(g.sheet, i18n.Text("Gather Energy"), func() { entity.GatherRitualEnergy(ritual, amount) })
+}
+
+func (g *GrimoireDockable) castRitual(ritual *gurps.Ritual) {
+	entity := g.sheet.Entity()
+	changeGrimoire(g.sheet, i18n.Text("Cast Ritual"), func() { entity.CastRitual(ritual) })
+}
+
+func (g *GrimoireDockable) refresh() {
+	g.content.RemoveAllChildren()
+	entity := g.sheet.Entity()
+	if len(entity.Grimoire) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No rituals have been added"))
+		g.content.AddChild(label)
+	}
+	for _, ritual := range entity.Grimoire {
+		g.addEntry(entity, ritual)
+	}
+	g.content.MarkForLayoutRecursively()
+	g.MarkForRedraw()
+}
+
+func (g *GrimoireDockable) addEntry(entity *gurps.Entity, ritual *gurps.Ritual) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(ritual.Name)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.AddChild(label)
+	gatherButton := unison.NewButton()
+	gatherButton.SetTitle(i18n.Text("Gather…"))
+	gatherButton.Tooltip = newWrappedTooltip(i18n.Text("Record energy gathered for this ritual"))
+	gatherButton.ClickCallback = func() { g.gatherEnergy(ritual) }
+	gatherButton.SetEnabled(ritual.Remaining() > 0)
+	header.AddChild(gatherButton)
+	castButton := unison.NewButton()
+	castButton.SetTitle(i18n.Text("Cast"))
+	castButton.Tooltip = newWrappedTooltip(i18n.Text("Spend the energy gathered for this ritual"))
+	castButton.ClickCallback = func() { g.castRitual(ritual) }
+	castButton.SetEnabled(ritual.Remaining() == 0)
+	header.AddChild(castButton)
+	editButton := unison.NewSVGButton(svg.Edit)
+	editButton.Tooltip = newWrappedTooltip(i18n.Text("Edit this ritual"))
+	editButton.ClickCallback = func() { g.editRitual(ritual) }
+	header.AddChild(editButton)
+	removeButton := unison.NewSVGButton(svg.Trash)
+	removeButton.Tooltip = newWrappedTooltip(i18n.Text("Remove this ritual"))
+	removeButton.ClickCallback = func() { g.removeRitual(ritual) }
+	header.AddChild(removeButton)
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  len(header.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.AddChild(header)
+	lines := []string{
+		ritual.EffectsText(),
+		fmt.Sprintf(i18n.Text("Energy: %d gathered of %d (base %d × %d)"), ritual.Gathered, ritual.EnergyCost(),
+			ritual.BaseEnergy(), ritual.EnergyMultiplier()),
+		ritualSkillText(entity, ritual),
+	}
+	for _, one := range ritual.Modifiers {
+		lines = append(lines, fmt.Sprintf(i18n.Text("%s (+%d)"), one.Name, one.Energy))
+	}
+	if ritual.Notes != "" {
+		lines = append(lines, ritual.Notes)
+	}
+	for _, line := range lines {
+		text := unison.NewLabel()
+		text.SetTitle(line)
+		panel.AddChild(text)
+	}
+	g.content.AddChild(panel)
+}
+
+// changeGrimoire makes a change to the sheet's grimoire that can be undone.
+func changeGrimoire(sheet *Sheet, editName string, change func()) {
+	before := sheet.Entity().GrimoireState()
+	change()
+	sheet.undoMgr.Add(&unison.UndoEdit[*gurps.GrimoireState]{
+		ID:         unison.NextUndoID(),
+		EditName:   editName,
+		UndoFunc:   func(edit *unison.UndoEdit[*gurps.GrimoireState]) { applyGrimoireState(sheet, edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[*gurps.GrimoireState]) { applyGrimoireState(sheet, edit.AfterData) },
+		BeforeData: before,
+		AfterData:  sheet.Entity().GrimoireState(),
+	})
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+func applyGrimoireState(sheet *Sheet, state *gurps.GrimoireState) {
+	sheet.Entity().ApplyGrimoireState(state)
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+// TitleIcon implements unison.Dockable
+func (g *GrimoireDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSpells,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (g *GrimoireDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Grimoire for %s"), g.sheet.String())
+}
+
+func (g *GrimoireDockable) String() string {
+	return g.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (g *GrimoireDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (g *GrimoireDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (g *GrimoireDockable) CloseWithGroup(other unison.Paneler) bool {
+	return g.sheet != nil && g.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (g *GrimoireDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(g)
+}
+
+// AttemptClose implements GroupedCloser
+func (g *GrimoireDockable) AttemptClose() bool {
+	if !CloseGroup(g) {
+		return false
+	}
+	return AttemptCloseForDockable(g)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (g *GrimoireDockable) UndoManager() *unison.UndoManager {
+	return g.undoMgr
+}
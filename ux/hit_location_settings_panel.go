@@ -165,7 +165,7 @@ func (p *hitLocationSettingsPanel) createContent() *unison.Panel {
 		func() int { return p.loc.Slots },
 		func(v int) { p.loc.Slots = v },
 		0, 999999, false, false)
-	intField.Tooltip = newWrappedTooltip(i18n.Text("The number of consecutive numbers this hit location fills in the table"))
+	intField.Tooltip = newWrappedTooltip(i18n.Text("The number of consecutive numbers this hit location fills in the table, or its relative weight if the table is weighted"))
 	content.AddChild(intField)
 
 	text = i18n.Text("Hit Penalty")
@@ -204,6 +204,8 @@ func (p *hitLocationSettingsPanel) createContent() *unison.Panel {
 		field.SetMinimumTextWidthUsing("100d1000")
 		field.Tooltip = newWrappedTooltip(i18n.Text("The dice to roll on the sub-table"))
 		content.AddChild(field)
+		content.AddChild(unison.NewPanel())
+		content.AddChild(newWeightedTableCheckBox(p.dockable, p.loc.SubTable))
 
 		content.AddChild(newBodySettingsSubTablePanel(p.dockable, p.loc.SubTable))
 	}
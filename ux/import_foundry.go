@@ -0,0 +1,47 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// importFoundryActor asks for a Foundry VTT actor file and opens a new, unsaved character sheet created from it.
+func importFoundryActor() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.FoundryActorExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	filePath := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	e, err := gurps.ImportFoundryActor(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to import Foundry VTT actor"), err)
+		return
+	}
+	name := e.Profile.Name
+	if name == "" {
+		name = xfs.TrimExtension(filepath.Base(filePath))
+	}
+	DisplayNewDockable(NewSheet(name+gurps.SheetExt, e))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/thememode"
+)
+
+const (
+	itemCardWidth        = 4 * 72
+	itemCardPadding      = 8
+	itemCardCopyResponse = unison.ModalResponseUserBase + 1
+)
+
+type itemCardFormat int
+
+const (
+	itemCardPNG itemCardFormat = iota
+	itemCardMarkdown
+	itemCardHTML
+)
+
+var (
+	itemCardFormatTitles = []string{i18n.Text("PNG Image"), i18n.Text("Markdown"), i18n.Text("HTML")}
+	itemCardFormatExts   = []string{"png", strings.TrimPrefix(gurps.MarkdownExt, "."), "html"}
+	// lastItemCardFormat holds the format used for the previous item card export, so that it may be offered again.
+	lastItemCardFormat = itemCardMarkdown
+)
+
+func canExportItemCard[T gurps.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	var t T
+	switch any(t).(type) {
+	case *gurps.Trait, *gurps.Spell, *gurps.Equipment:
+		return len(table.SelectedRows(false)) == 1
+	default:
+		return false
+	}
+}
+
+func exportItemCard[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
+	rows := table.SelectedRows(false)
+	if len(rows) != 1 {
+		return
+	}
+	card := gurps.NewItemCard(rows[0].Data())
+	if card == nil {
+		return
+	}
+	format, response := promptForItemCardFormat(card)
+	switch response {
+	case itemCardCopyResponse:
+		if format == itemCardHTML {
+			unison.GlobalClipboard.SetText(card.HTML(false))
+		} else {
+			unison.GlobalClipboard.SetText(card.Markdown())
+		}
+	case unison.ModalResponseOK:
+		saveItemCard(card, format)
+	}
+}
+
+func promptForItemCardFormat(card *gurps.ItemCard) (itemCardFormat, int) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(card.Title)
+	label.Font = unison.EmphasizedSystemFont
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Format"), false))
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(itemCardFormatTitles...)
+	popup.Select(itemCardFormatTitles[lastItemCardFormat])
+	panel.AddChild(popup)
+	var dialog *unison.Dialog
+	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		// Images can only be saved, not copied as text.
+		dialog.Button(itemCardCopyResponse).SetEnabled(itemCardFormat(p.SelectedIndex()) != itemCardPNG)
+	}
+	var err error
+	if dialog, err = unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		{Title: i18n.Text("Copy"), ResponseCode: itemCardCopyResponse},
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Save…")),
+	}); err != nil {
+		errs.Log(err)
+		return lastItemCardFormat, unison.ModalResponseCancel
+	}
+	dialog.Button(itemCardCopyResponse).SetEnabled(lastItemCardFormat != itemCardPNG)
+	response := dialog.RunModal()
+	if response != unison.ModalResponseCancel {
+		lastItemCardFormat = itemCardFormat(popup.SelectedIndex())
+	}
+	return lastItemCardFormat, response
+}
+
+func saveItemCard(card *gurps.ItemCard, format itemCardFormat) {
+	ext := itemCardFormatExts[format]
+	dialog := unison.NewSaveDialog()
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	dialog.SetAllowedExtensions(ext)
+	dialog.SetInitialFileName(fs.SanitizeName(card.Title))
+	if !dialog.RunModal() {
+		return
+	}
+	filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), ext, false)
+	if !ok {
+		return
+	}
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	var data []byte
+	var err error
+	switch format {
+	case itemCardPNG:
+		data, err = renderItemCardPNG(card)
+	case itemCardHTML:
+		data = []byte(card.HTML(true))
+	default:
+		data = []byte(card.Markdown())
+	}
+	if err == nil {
+		err = os.WriteFile(filePath, data, 0o640)
+	}
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to export item card!"), err)
+	}
+}
+
+func itemCardParagraphs(card *gurps.ItemCard) []string {
+	list := []string{card.Title, card.Kind}
+	if len(card.Fields) != 0 {
+		fields := make([]string, len(card.Fields))
+		for i, field := range card.Fields {
+			fields[i] = field.Label + ": " + field.Value
+		}
+		list = append(list, strings.Join(fields, "; "))
+	}
+	for _, mod := range card.Modifiers {
+		list = append(list, "• "+mod)
+	}
+	if card.Notes != "" {
+		list = append(list, strings.Split(card.Notes, "\n")...)
+	}
+	if card.Reference != "" {
+		list = append(list, i18n.Text("Reference: ")+card.Reference)
+	}
+	return list
+}
+
+func renderItemCardPNG(card *gurps.ItemCard) ([]byte, error) {
+	savedColorMode := unison.CurrentThemeMode()
+	unison.SetThemeMode(thememode.Light)
+	unison.ThemeChanged()
+	unison.RebuildDynamicColors()
+	defer func() {
+		unison.SetThemeMode(savedColorMode)
+		unison.ThemeChanged()
+		unison.RebuildDynamicColors()
+	}()
+	titleFont := fonts.PageFieldPrimary.Face().Font(fonts.PageFieldPrimary.Size() * 1.5)
+	kindFont := fonts.PageFieldSecondary.Face().Font(fonts.PageFieldSecondary.Size())
+	var lines [][]*unison.Text
+	height := float32(itemCardPadding * 2)
+	for i, para := range itemCardParagraphs(card) {
+		var font unison.Font
+		switch i {
+		case 0:
+			font = titleFont
+		case 1:
+			font = kindFont
+		default:
+			font = fonts.PageLabelPrimary
+		}
+		wrapped := unison.NewTextWrappedLines(para, &unison.TextDecoration{
+			Font:            font,
+			OnBackgroundInk: unison.ThemeOnSurface,
+		}, itemCardWidth-itemCardPadding*2)
+		for _, line := range wrapped {
+			height += line.Height()
+		}
+		height += 2
+		lines = append(lines, wrapped)
+	}
+	img, err := unison.NewImageFromDrawing(itemCardWidth, int(height+0.5), gurps.GlobalSettings().General.ImageResolution,
+		func(canvas *unison.Canvas) {
+			r := unison.NewRect(0, 0, itemCardWidth, height)
+			canvas.DrawRect(r, unison.ThemeSurface.Paint(canvas, r, paintstyle.Fill))
+			canvas.DrawRect(r.Inset(unison.NewUniformInsets(0.5)), unison.ThemeSurfaceEdge.Paint(canvas, r,
+				paintstyle.Stroke))
+			y := float32(itemCardPadding)
+			for _, para := range lines {
+				for _, line := range para {
+					line.Draw(canvas, itemCardPadding, y+line.Baseline())
+					y += line.Height()
+				}
+				y += 2
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	return img.ToPNG(6)
+}
@@ -0,0 +1,427 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/fluency"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &languagesEditor{}
+	_ unison.TabCloser           = &languagesEditor{}
+	_ ModifiableRoot             = &languagesEditor{}
+	_ unison.UndoManagerProvider = &languagesEditor{}
+	_ GroupedCloser              = &languagesEditor{}
+	_ Rebuildable                = &languagesEditor{}
+)
+
+type languagesData struct {
+	languages     []*gurps.Language
+	familiarities []*gurps.CulturalFamiliarity
+}
+
+func newLanguagesData(entity *gurps.Entity) *languagesData {
+	return &languagesData{
+		languages:     gurps.CloneLanguageList(entity.Languages),
+		familiarities: gurps.CloneCulturalFamiliarityList(entity.CulturalFamiliarities),
+	}
+}
+
+type languagesEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           *languagesData
+	current          *languagesData
+	promptForSave    bool
+}
+
+func displayLanguagesEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*languagesEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &languagesEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  newLanguagesData(entity),
+		current: newLanguagesData(entity),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+}
+
+func (e *languagesEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addLanguageButton := unison.NewSVGButton(svg.CircledAdd)
+	addLanguageButton.Tooltip = newWrappedTooltip(i18n.Text("Add Language"))
+	addLanguageButton.ClickCallback = func() {
+		e.current.languages = slices.Insert(e.current.languages, 0, gurps.NewLanguage())
+		e.rebuildContent()
+	}
+	toolbar.AddChild(addLanguageButton)
+
+	addFamiliarityButton := unison.NewSVGButton(svg.CircledAdd)
+	addFamiliarityButton.Tooltip = newWrappedTooltip(i18n.Text("Add Cultural Familiarity"))
+	addFamiliarityButton.ClickCallback = func() {
+		e.current.familiarities = slices.Insert(e.current.familiarities, 0, &gurps.CulturalFamiliarity{})
+		e.rebuildContent()
+	}
+	toolbar.AddChild(addFamiliarityButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *languagesEditor) rebuildContent() {
+	e.content.RemoveAllChildren()
+	e.initContent()
+	e.content.Pack()
+	MarkForLayoutWithinDockable(e.content)
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+}
+
+func (e *languagesEditor) initContent() {
+	e.content.AddChild(NewFieldLeadingLabel(i18n.Text("Languages"), false))
+	languages := e.createSection(6)
+	for _, one := range e.current.languages {
+		e.createLanguageRow(languages, one)
+	}
+	e.content.AddChild(NewFieldLeadingLabel(i18n.Text("Cultural Familiarities"), false))
+	familiarities := e.createSection(4)
+	for _, one := range e.current.familiarities {
+		e.createFamiliarityRow(familiarities, one)
+	}
+}
+
+func (e *languagesEditor) createSection(columns int) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  columns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChild(panel)
+	return panel
+}
+
+func (e *languagesEditor) createLanguageRow(parent *unison.Panel, lang *gurps.Language) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Language"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current.languages, lang); i != -1 {
+			e.current.languages = slices.Delete(e.current.languages, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	parent.AddChild(deleteButton)
+
+	cost := e.createCostLabel(lang.Cost, func() fxp.Int { return e.entity.LanguageTraitPoints(lang.Name) })
+	nameText := i18n.Text("Language")
+	name := NewStringField(nil, "", nameText,
+		func() string { return lang.Name },
+		func(value string) {
+			lang.Name = value
+			cost.update()
+			MarkModified(parent)
+		})
+	name.Watermark = nameText
+	name.Tooltip = newWrappedTooltip(i18n.Text("The name of the language, which should also appear in the name of the corresponding traits tagged as a Language"))
+	name.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	parent.AddChild(name)
+
+	addCheckBox(parent, i18n.Text("Native"), &lang.Native).OnSet = cost.update
+	parent.AddChild(NewPopup[fluency.Level](nil, "", i18n.Text("Spoken"),
+		func() fluency.Level { return lang.Spoken },
+		func(level fluency.Level) {
+			lang.Spoken = level
+			cost.update()
+		}, fluency.Levels...))
+	parent.AddChild(NewPopup[fluency.Level](nil, "", i18n.Text("Written"),
+		func() fluency.Level { return lang.Written },
+		func(level fluency.Level) {
+			lang.Written = level
+			cost.update()
+		}, fluency.Levels...))
+	parent.AddChild(cost)
+}
+
+func (e *languagesEditor) createFamiliarityRow(parent *unison.Panel, cf *gurps.CulturalFamiliarity) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Cultural Familiarity"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current.familiarities, cf); i != -1 {
+			e.current.familiarities = slices.Delete(e.current.familiarities, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	parent.AddChild(deleteButton)
+
+	cost := e.createCostLabel(cf.Cost, func() fxp.Int { return e.entity.CulturalFamiliarityTraitPoints(cf.Name) })
+	nameText := i18n.Text("Culture")
+	name := NewStringField(nil, "", nameText,
+		func() string { return cf.Name },
+		func(value string) {
+			cf.Name = value
+			cost.update()
+			MarkModified(parent)
+		})
+	name.Watermark = nameText
+	name.Tooltip = newWrappedTooltip(i18n.Text(`The name of the culture, which should match the name of the corresponding "Cultural Familiarity (name)" trait`))
+	name.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	parent.AddChild(name)
+
+	addCheckBox(parent, i18n.Text("Native"), &cf.Native).OnSet = cost.update
+	parent.AddChild(cost)
+}
+
+type languageCostLabel struct {
+	*unison.Label
+	update func()
+}
+
+func (e *languagesEditor) createCostLabel(cost, spent func() fxp.Int) *languageCostLabel {
+	label := &languageCostLabel{Label: NewFieldTrailingLabel("", false)}
+	label.update = func() {
+		c := cost()
+		s := spent()
+		label.SetTitle(fmt.Sprintf(i18n.Text("%s pts (traits: %s)"), c.String(), s.String()))
+		if c != s {
+			label.OnBackgroundInk = unison.ThemeError
+		} else {
+			label.OnBackgroundInk = unison.ThemeOnSurface
+		}
+		label.MarkForLayoutAndRedraw()
+	}
+	label.update()
+	return label
+}
+
+func (e *languagesEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+func (e *languagesEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Languages for %s"), e.owner.String())
+}
+
+func (e *languagesEditor) String() string {
+	return e.Title()
+}
+
+func (e *languagesEditor) Tooltip() string {
+	return ""
+}
+
+func (e *languagesEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *languagesEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *languagesEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *languagesEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *languagesEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *languagesEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *languagesEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *languagesEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[*languagesData]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Language Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[*languagesData]) {
+				entity.SetLanguages(edit.BeforeData.languages, edit.BeforeData.familiarities)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[*languagesData]) {
+				entity.SetLanguages(edit.AfterData.languages, edit.AfterData.familiarities)
+				owner.Rebuild(true)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetLanguages(e.current.languages, e.current.familiarities)
+	owner.Rebuild(true)
+}
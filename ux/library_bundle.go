@@ -0,0 +1,191 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+func exportLibraryBundle() {
+	libs, ok := promptForBundleLibraries()
+	if !ok {
+		return
+	}
+	filePath, ok := chooseLibraryBundleSavePath(i18n.Text("Libraries"))
+	if !ok {
+		return
+	}
+	if err := gurps.ExportLibraryBundle(filePath, libs); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to export library bundle"), err)
+		return
+	}
+	showSuccessRollMessage(i18n.Text("Export Complete"),
+		fmt.Sprintf(i18n.Text("Bundled %d libraries into %s."), len(libs), filepath.Base(filePath)))
+}
+
+func exportLibraryDependencyBundle() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt, gurps.TemplatesExt, gurps.CampaignExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	paths := dialog.Paths()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(paths[0]))
+	dependencies, err := gurps.LibraryDependencies(paths...)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to determine library dependencies"), err)
+		return
+	}
+	if len(dependencies) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Nothing to bundle"),
+			i18n.Text("None of the data in the selected files was copied from a library."))
+		return
+	}
+	filePath, ok := chooseLibraryBundleSavePath(filepath.Base(paths[0]))
+	if !ok {
+		return
+	}
+	missing, err := gurps.ExportLibraryDependencyBundle(filePath, dependencies)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to export library bundle"), err)
+		return
+	}
+	if len(missing) != 0 {
+		list := make([]string, 0, len(missing))
+		for _, one := range missing {
+			list = append(list, one.Library+": "+one.Path)
+		}
+		unison.ErrorDialogWithMessage(fmt.Sprintf(i18n.Text("Bundled %d files; %d could not be found"),
+			len(dependencies)-len(missing), len(missing)), strings.Join(list, "\n"))
+		return
+	}
+	showSuccessRollMessage(i18n.Text("Export Complete"),
+		fmt.Sprintf(i18n.Text("Bundled %d library files into %s."), len(dependencies), filepath.Base(filePath)))
+}
+
+func importLibraryBundle() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.LibraryBundleExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	filePath := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	bundle, err := gurps.ReadLibraryBundle(filePath)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to read library bundle"), err)
+		return
+	}
+	libs := global.Libraries()
+	var buffer strings.Builder
+	for _, entry := range bundle.Libraries {
+		if buffer.Len() != 0 {
+			buffer.WriteByte('\n')
+		}
+		if lib, exists := libs[entry.Key]; exists {
+			fmt.Fprintf(&buffer, i18n.Text("• %s: %d files will be added to %s"), entry.Title, entry.FileCount,
+				lib.PathOnDisk)
+		} else {
+			fmt.Fprintf(&buffer, i18n.Text("• %s: %d files will be placed in a new library"), entry.Title,
+				entry.FileCount)
+		}
+	}
+	buffer.WriteString("\n\n")
+	buffer.WriteString(i18n.Text("Existing files with the same names will be replaced."))
+	if unison.QuestionDialog(i18n.Text("Import this library bundle?"), buffer.String()) != unison.ModalResponseOK {
+		return
+	}
+	imported, err := gurps.ImportLibraryBundle(filePath)
+	if len(imported) != 0 {
+		Workspace.Navigator.Reload()
+	}
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to import library bundle"), err)
+		return
+	}
+	showSuccessRollMessage(i18n.Text("Import Complete"),
+		fmt.Sprintf(i18n.Text("Imported %d libraries."), len(imported)))
+}
+
+func promptForBundleLibraries() ([]*gurps.Library, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("Bundle which libraries?"))
+	panel.AddChild(label)
+	libs := gurps.GlobalSettings().Libraries().List()
+	checkBoxes := make([]*unison.CheckBox, len(libs))
+	for i, lib := range libs {
+		checkBoxes[i] = unison.NewCheckBox()
+		checkBoxes[i].SetTitle(lib.Title)
+		checkBoxes[i].Tooltip = newWrappedTooltip(lib.PathOnDisk)
+		if !lib.IsMaster() {
+			checkBoxes[i].State = check.On
+		}
+		panel.AddChild(checkBoxes[i])
+	}
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Export"))})
+	if err != nil {
+		errs.Log(err)
+		return nil, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return nil, false
+	}
+	var selected []*gurps.Library
+	for i, lib := range libs {
+		if checkBoxes[i].State == check.On {
+			selected = append(selected, lib)
+		}
+	}
+	return selected, len(selected) != 0
+}
+
+func chooseLibraryBundleSavePath(initialName string) (string, bool) {
+	ext := strings.TrimPrefix(gurps.LibraryBundleExt, ".")
+	dialog := unison.NewSaveDialog()
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	dialog.SetAllowedExtensions(ext)
+	dialog.SetInitialFileName(initialName)
+	if !dialog.RunModal() {
+		return "", false
+	}
+	filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), ext, false)
+	if ok {
+		global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	}
+	return filePath, ok
+}
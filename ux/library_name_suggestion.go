@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// addLibraryNameSuggestion adds a spot below the name field that offers to replace the data being edited with a library
+// item and the modifiers whose names make up the typed name. The returned function should be called whenever the
+// editor's data is modified.
+func addLibraryNameSuggestion[T, M gurps.NodeTypes](content *unison.Panel, name *string, match func(string) *gurps.LibraryNameMatch[T, M], apply func(*gurps.LibraryNameMatch[T, M])) func() {
+	content.AddChild(unison.NewPanel())
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
+	content.AddChild(wrapper)
+	lastName := *name
+	return func() {
+		if lastName == *name {
+			return
+		}
+		lastName = *name
+		wrapper.RemoveAllChildren()
+		if m := match(lastName); m != nil {
+			modNames := make([]string, len(m.Modifiers))
+			for i, one := range m.Modifiers {
+				modNames[i] = one.String()
+			}
+			button := unison.NewButton()
+			button.SetTitle(fmt.Sprintf(i18n.Text("Use library item “%s” with “%s”"), m.Item.String(),
+				strings.Join(modNames, "”, “")))
+			button.Tooltip = newWrappedTooltip(i18n.Text("Replaces the data being edited with the library item and applies the modifiers named in it"))
+			button.ClickCallback = func() {
+				wrapper.RemoveAllChildren()
+				apply(m)
+				MarkForLayoutWithinDockable(wrapper)
+			}
+			wrapper.AddChild(button)
+		}
+		MarkForLayoutWithinDockable(wrapper)
+	}
+}
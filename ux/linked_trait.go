@@ -0,0 +1,55 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+type linkedTraitChoice struct {
+	id    tid.TID
+	title string
+}
+
+func (c linkedTraitChoice) String() string {
+	return c.title
+}
+
+// linkedTraitChoices returns the traits of the entity that a structured entry may be linked to for point validation.
+// The first choice is always the absence of a link.
+func linkedTraitChoices(entity *gurps.Entity) []linkedTraitChoice {
+	choices := []linkedTraitChoice{{title: i18n.Text("No linked trait")}}
+	gurps.Traverse(func(t *gurps.Trait) bool {
+		choices = append(choices, linkedTraitChoice{id: t.TID, title: t.String()})
+		return false
+	}, false, true, entity.Traits...)
+	return choices
+}
+
+func newLinkedTraitPopup(choices []linkedTraitChoice, traitID *tid.TID, onSet func()) *Popup[linkedTraitChoice] {
+	selected := choices[0]
+	for _, one := range choices {
+		if one.id == *traitID {
+			selected = one
+			break
+		}
+	}
+	popup := NewPopup[linkedTraitChoice](nil, "", i18n.Text("Linked Trait"),
+		func() linkedTraitChoice { return selected },
+		func(choice linkedTraitChoice) {
+			selected = choice
+			*traitID = choice.id
+			onSet()
+		}, choices...)
+	popup.Tooltip = newWrappedTooltip(i18n.Text("The trait whose points are validated against the cost of this entry"))
+	return popup
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+const deleteLoadoutResponse = unison.ModalResponseUserBase + 1
+
+// loadoutState holds enough of the equipment state of an entity to undo a change to its loadouts.
+type loadoutState struct {
+	carried  []*gurps.Equipment
+	other    []*gurps.Equipment
+	equipped []*gurps.Equipment
+	loadouts []*gurps.Loadout
+}
+
+func newLoadoutState(entity *gurps.Entity) *loadoutState {
+	state := &loadoutState{
+		carried:  slices.Clone(entity.CarriedEquipment),
+		other:    slices.Clone(entity.OtherEquipment),
+		loadouts: gurps.CloneLoadoutList(entity.Loadouts),
+	}
+	gurps.Traverse(func(eqp *gurps.Equipment) bool {
+		if eqp.Equipped {
+			state.equipped = append(state.equipped, eqp)
+		}
+		return false
+	}, false, false, append(slices.Clone(state.carried), state.other...)...)
+	return state
+}
+
+func (s *loadoutState) apply(sheet *Sheet) {
+	entity := sheet.Entity()
+	entity.CarriedEquipment = slices.Clone(s.carried)
+	entity.OtherEquipment = slices.Clone(s.other)
+	entity.SetLoadouts(s.loadouts)
+	gurps.Traverse(func(eqp *gurps.Equipment) bool {
+		eqp.Equipped = slices.Contains(s.equipped, eqp)
+		return false
+	}, false, false, append(slices.Clone(s.carried), s.other...)...)
+	entity.Recalculate()
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+func (s *Sheet) installLoadoutCmdHandlers() {
+	s.InstallCmdHandlers(SaveLoadoutItemID, unison.AlwaysEnabled, func(_ any) { saveLoadout(s) })
+	s.InstallCmdHandlers(SwitchLoadoutItemID, func(_ any) bool { return len(s.entity.Loadouts) != 0 },
+		func(_ any) { switchLoadout(s) })
+}
+
+// saveLoadout asks for a name and then records the sheet's current carried and equipped equipment as a loadout with
+// that name.
+func saveLoadout(sheet *Sheet) {
+	entity := sheet.Entity()
+	var name string
+	for _, one := range entity.Loadouts {
+		if entity.IsLoadoutActive(one) {
+			name = one.Name
+			break
+		}
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Name"), false))
+	field := unison.NewField()
+	field.SetText(name)
+	field.SetMinimumTextWidthUsing("Dungeon Delving")
+	field.Tooltip = newWrappedTooltip(i18n.Text("Saving with the name of an existing loadout replaces it"))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Save")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.Button(unison.ModalResponseOK).SetEnabled(strings.TrimSpace(name) != "")
+	field.ModifiedCallback = func(_, after *unison.FieldState) {
+		dialog.Button(unison.ModalResponseOK).SetEnabled(strings.TrimSpace(after.Text) != "")
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	name = strings.TrimSpace(field.Text())
+	changeLoadouts(sheet, fmt.Sprintf(i18n.Text("Save Loadout %s"), name), func() {
+		entity.CaptureLoadout(name)
+	})
+}
+
+// switchLoadout asks which loadout to use and then applies it to the sheet, or removes it if requested.
+func switchLoadout(sheet *Sheet) {
+	entity := sheet.Entity()
+	if len(entity.Loadouts) == 0 {
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Loadout"), false))
+	popup := unison.NewPopupMenu[*gurps.Loadout]()
+	popup.AddItem(entity.Loadouts...)
+	popup.SelectIndex(0)
+	for i, one := range entity.Loadouts {
+		if entity.IsLoadoutActive(one) {
+			popup.SelectIndex(i)
+			break
+		}
+	}
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		{Title: i18n.Text("Delete"), ResponseCode: deleteLoadoutResponse},
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Switch")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	response := dialog.RunModal()
+	l, ok := popup.Selected()
+	if !ok {
+		return
+	}
+	switch response {
+	case unison.ModalResponseOK:
+		changeLoadouts(sheet, fmt.Sprintf(i18n.Text("Switch to Loadout %s"), l.Name), func() {
+			entity.ApplyLoadout(l)
+		})
+	case deleteLoadoutResponse:
+		changeLoadouts(sheet, fmt.Sprintf(i18n.Text("Delete Loadout %s"), l.Name), func() {
+			entity.RemoveLoadout(l)
+		})
+	default:
+	}
+}
+
+func changeLoadouts(sheet *Sheet, editName string, change func()) {
+	before := newLoadoutState(sheet.Entity())
+	change()
+	sheet.undoMgr.Add(&unison.UndoEdit[*loadoutState]{
+		ID:         unison.NextUndoID(),
+		EditName:   editName,
+		UndoFunc:   func(edit *unison.UndoEdit[*loadoutState]) { edit.BeforeData.apply(sheet) },
+		RedoFunc:   func(edit *unison.UndoEdit[*loadoutState]) { edit.AfterData.apply(sheet) },
+		BeforeData: before,
+		AfterData:  newLoadoutState(sheet.Entity()),
+	})
+	sheet.Entity().Recalculate()
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
@@ -32,8 +32,14 @@ import (
 // Menu, Item & Action IDs
 const (
 	NewSheetItemID = unison.UserBaseID + iota
+	NewSheetWizardItemID
+	MergeFromFileItemID
+	ImportFoundryActorItemID
+	PasteStatBlockItemID
+	ImportLibraryBundleItemID
 	NewTemplateItemID
 	NewCampaignItemID
+	NewCombatTrackerItemID
 	NewTraitsLibraryItemID
 	NewTraitModifiersLibraryItemID
 	NewEquipmentLibraryItemID
@@ -43,15 +49,30 @@ const (
 	NewSpellsLibraryItemID
 	NewMarkdownFileItemID
 	OpenItemID
+	QuickOpenItemID
 	CloseTabID
 	RecentFilesMenuID
 	SaveItemID
 	SaveAsItemID
 	ExportToMenuID
 	ExportAsPDFItemID
+	ExportAsPDFWithLayoutItemID
 	ExportAsWEBPItemID
 	ExportAsPNGItemID
 	ExportAsJPEGItemID
+	ExportAsFoundryItemID
+	ExportAsRoll20ItemID
+	ExportAsFantasyGroundsItemID
+	ExportGMSummaryItemID
+	ExportNPCCardsItemID
+	ExportAsStatBlockItemID
+	ExportAsCSVItemID
+	ExportItemCardItemID
+	ExportAsForumPostItemID
+	ExportAllOpenSheetsAsPDFItemID
+	ExportFolderAsPDFItemID
+	ExportLibraryBundleItemID
+	ExportDependencyBundleItemID
 	PrintItemID
 	UndoItemID
 	RedoItemID
@@ -67,6 +88,13 @@ const (
 	DecrementItemID
 	IncrementUsesItemID
 	DecrementUsesItemID
+	RechargeDailyUsesItemID
+	RechargeSessionUsesItemID
+	FireWeaponItemID
+	ReloadWeaponItemID
+	CastSpellItemID
+	SaveLoadoutItemID
+	SwitchLoadoutItemID
 	IncrementSkillLevelItemID
 	DecrementSkillLevelItemID
 	IncrementTechLevelItemID
@@ -76,25 +104,52 @@ const (
 	SwapDefaultsItemID
 	MoveToOtherEquipmentItemID
 	MoveToCarriedEquipmentItemID
+	BundleIntoKitItemID
+	ToggleFavoriteModifierItemID
+	ApplyFavoriteModifierItemID
+	ApplyQualityPresetItemID
+	TogglePinnedItemID
+	ToggleOrderLockItemID
 	ItemMenuID
 	AddNaturalAttacksItemID
 	OpenEditorItemID
 	CopyToSheetItemID
+	CopyListToSheetItemID
 	CopyToTemplateItemID
 	ApplyTemplateItemID
 	NewSheetFromTemplateItemID
+	CheckTemplateUpdatesItemID
+	RerollAncestryItemID
 	OpenOnePageReferenceItemID
 	OpenEachPageReferenceItemID
 	SettingsMenuID
 	PerSheetSettingsItemID
 	PerSheetAttributeSettingsItemID
 	PerSheetBodyTypeSettingsItemID
+	PerSheetVariablesItemID
+	PerSheetLanguagesItemID
+	PerSheetAssociatesItemID
+	PerSheetCompanionsItemID
+	PerSheetReputationsItemID
+	PerSheetTimelineItemID
+	PerSheetDeathAndDyingItemID
+	PerSheetApplyDamageItemID
+	PerSheetArmorTableItemID
+	PerSheetSessionTimerItemID
+	PerSheetAlternateFormItemID
+	PerSheetHistoryItemID
+	PerSheetPointsJournalItemID
+	PerSheetNotesJournalItemID
+	PerSheetGrimoireItemID
+	PerSheetPowersItemID
+	PerSheetRollModifiersItemID
 	DefaultSheetSettingsItemID
 	DefaultAttributeSettingsItemID
 	DefaultBodyTypeSettingsItemID
 	GeneralSettingsItemID
 	WebSettingsItemID
 	PageRefMappingsItemID
+	ExportTemplatesItemID
 	ColorSettingsItemID
 	FontSettingsItemID
 	MenuKeySettingsItemID
@@ -120,6 +175,13 @@ const (
 	Scale400ItemID
 	Scale500ItemID
 	Scale600ItemID
+	CompareSideBySideItemID
+	SyncScrollingItemID
+	DiceRollerItemID
+	GMScreenItemID
+	QuickReferenceItemID
+	NameGeneratorItemID
+	ReferenceSearchItemID
 	DockUnDockItemID
 
 	FirstNonContainerMarker // Keep this block grouped together
@@ -145,6 +207,7 @@ const (
 	LastContainerMarker
 
 	FirstAlternateNonContainerMarker // Keep this block grouped together
+	NewNoteFromTemplateItemID
 	NewRitualMagicSpellItemID
 	NewTechniqueItemID
 	LastAlternateNonContainerMarker
@@ -197,9 +260,10 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 	f := bar.Factory()
 	m := bar.Menu(unison.FileMenuID)
 	i := s.insertMenuItem(m, 0, newCharacterSheetAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, newCharacterWizardAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newCharacterTemplateAction.NewMenuItem(f))
-	// TODO: Re-enable Campaign files
-	// i = s.insertMenuItem(m, i, newCampaignAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, newCampaignAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, newCombatTrackerAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newMarkdownFileAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
@@ -213,7 +277,12 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, openAction.NewMenuItem(f))
-	s.insertMenu(m, i, f.NewMenu(RecentFilesMenuID, i18n.Text("Recent Files"), s.recentFilesUpdater))
+	i = s.insertMenuItem(m, i, quickOpenAction.NewMenuItem(f))
+	i = s.insertMenu(m, i, f.NewMenu(RecentFilesMenuID, i18n.Text("Recent Files"), s.recentFilesUpdater))
+	i = s.insertMenuItem(m, i, mergeFromFileAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, importFoundryActorAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, pasteStatBlockAction.NewMenuItem(f))
+	s.insertMenuItem(m, i, importLibraryBundleAction.NewMenuItem(f))
 
 	i = m.Item(unison.CloseItemID).Index()
 	m.RemoveItem(i)
@@ -249,16 +318,28 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, moveToCarriedEquipmentAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, moveToOtherEquipmentAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, bundleIntoKitAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, saveLoadoutAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, switchLoadoutAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, copyToSheetAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, copyListToSheetAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, copyToTemplateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, exportItemCardAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, applyTemplateAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newSheetFromTemplateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, checkTemplateUpdatesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, rerollAncestryAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, incrementAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, decrementAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, increaseUsesAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, decreaseUsesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, rechargeDailyUsesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, rechargeSessionUsesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, fireWeaponAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, reloadWeaponAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, castSpellAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, increaseSkillLevelAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, decreaseSkillLevelAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, increaseTechLevelAction.NewMenuItem(f))
@@ -268,6 +349,11 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, toggleStateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, toggleFavoriteModifierAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, applyFavoriteModifierAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, applyQualityPresetAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, togglePinnedAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, toggleOrderLockAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, swapDefaultsAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, convertToContainerAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, convertToNonContainerAction.NewMenuItem(f))
@@ -307,6 +393,7 @@ func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newNoteAction.NewMenuItem(f))
 	m.InsertItem(-1, newNoteContainerAction.NewMenuItem(f))
+	m.InsertItem(-1, newNoteFromTemplateAction.NewMenuItem(f))
 
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newMeleeWeaponAction.NewMenuItem(f))
@@ -323,6 +410,23 @@ func (s menuBarScope) createSettingsMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertItem(-1, perSheetSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, perSheetAttributeSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, perSheetBodyTypeSettingsAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetVariablesAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetLanguagesAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetAssociatesAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetCompanionsAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetReputationsAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetTimelineAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetDeathAndDyingAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetApplyDamageAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetArmorTableAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetSessionTimerAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetAlternateFormAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetHistoryAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetPointsJournalAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetNotesJournalAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetGrimoireAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetPowersAction.NewMenuItem(f))
+	m.InsertItem(-1, perSheetRollModifiersAction.NewMenuItem(f))
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, defaultSheetSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, defaultAttributeSettingsAction.NewMenuItem(f))
@@ -331,6 +435,7 @@ func (s menuBarScope) createSettingsMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertItem(-1, generalSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, webSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, pageRefMappingsAction.NewMenuItem(f))
+	m.InsertItem(-1, exportTemplatesAction.NewMenuItem(f))
 	m.InsertItem(-1, colorSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, fontSettingsAction.NewMenuItem(f))
 	m.InsertItem(-1, menuKeySettingsAction.NewMenuItem(f))
@@ -384,6 +489,15 @@ func (s menuBarScope) createViewMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertItem(-1, scale400Action.NewMenuItem(f))
 	m.InsertItem(-1, scale500Action.NewMenuItem(f))
 	m.InsertItem(-1, scale600Action.NewMenuItem(f))
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, compareSideBySideAction.NewMenuItem(f))
+	m.InsertItem(-1, syncScrollingAction.NewMenuItem(f))
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, diceRollerAction.NewMenuItem(f))
+	m.InsertItem(-1, gmScreenAction.NewMenuItem(f))
+	m.InsertItem(-1, quickReferenceAction.NewMenuItem(f))
+	m.InsertItem(-1, nameGeneratorAction.NewMenuItem(f))
+	m.InsertItem(-1, referenceSearchAction.NewMenuItem(f))
 	platformViewMenuAddition(m)
 	return m
 }
@@ -444,10 +558,26 @@ func (s menuBarScope) exportToUpdater(menu unison.Menu) {
 	menu.RemoveAll()
 	factory := menu.Factory()
 	menu.InsertItem(-1, exportAsPDFAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsPDFWithLayoutAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsWEBPAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsPNGAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsJPEGAction.NewMenuItem(factory))
 	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, exportAllOpenSheetsAsPDFAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportFolderAsPDFAction.NewMenuItem(factory))
+	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, exportLibraryBundleAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportDependencyBundleAction.NewMenuItem(factory))
+	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, exportAsFoundryAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsRoll20Action.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsFantasyGroundsAction.NewMenuItem(factory))
+	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, exportAsStatBlockAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsForumPostAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportGMSummaryAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportNPCCardsAction.NewMenuItem(factory))
+	menu.InsertSeparator(-1, false)
 	index := 0
 	for _, lib := range gurps.GlobalSettings().Libraries().List() {
 		dir := lib.Path()
@@ -470,33 +600,48 @@ func (s menuBarScope) exportToUpdater(menu unison.Menu) {
 			s.appendDisabledMenuItem(menu, lib.Title)
 			txt.SortStringsNaturalAscending(list)
 			for _, one := range list {
-				menu.InsertItem(-1, s.createExportToTextAction(index, one).NewMenuItem(factory))
+				menu.InsertItem(-1, s.createExportToTextAction(index, xfs.TrimExtension(filepath.Base(one)), one,
+					filepath.Ext(one)).NewMenuItem(factory))
 				index++
 			}
 		}
 	}
-	if menu.Count() == 2 {
+	if templates := gurps.GlobalSettings().ExportTemplates; len(templates) != 0 {
+		s.appendDisabledMenuItem(menu, i18n.Text("User Templates"))
+		for _, one := range templates {
+			menu.InsertItem(-1, s.createExportToTextAction(index, one.Title(), one.Path,
+				one.OutputExtension()).NewMenuItem(factory))
+			index++
+		}
+	}
+	if menu.Count() == 4 {
 		s.appendDisabledMenuItem(menu, i18n.Text("No export templates available"))
 	}
 }
 
-func (s menuBarScope) createExportToTextAction(index int, path string) *unison.Action {
+func (s menuBarScope) createExportToTextAction(index int, title, path, ext string) *unison.Action {
 	return &unison.Action{
 		ID:              ExportToTextBaseItemID + index,
-		Title:           "    " + xfs.TrimExtension(filepath.Base(path)),
+		Title:           "    " + title,
 		EnabledCallback: actionEnabledForSheet,
 		ExecuteCallback: func(_ *unison.Action, _ any) {
 			if sheet := ActiveSheet(); sheet != nil {
+				var options gurps.HTMLExportOptions
+				if isHTMLTemplate(path) {
+					var ok bool
+					if options, ok = promptForHTMLExportOptions(); !ok {
+						return
+					}
+				}
 				dialog := unison.NewSaveDialog()
-				ext := filepath.Ext(path)
 				settings := gurps.GlobalSettings()
 				dialog.SetInitialDirectory(settings.LastDir(gurps.DefaultLastDirKey))
 				dialog.SetAllowedExtensions(ext)
-				dialog.SetInitialFileName(xfs.SanitizeName(xfs.BaseName(sheet.BackingFilePath())))
+				dialog.SetInitialFileName(suggestedFileName(sheet))
 				if dialog.RunModal() {
 					if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), ext, false); ok {
 						settings.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
-						if err := gurps.Export(sheet.Entity(), path, filePath); err != nil {
+						if err := gurps.ExportWithOptions(sheet.Entity(), path, filePath, options); err != nil {
 							unison.ErrorDialogWithError(i18n.Text("Export failed"), err)
 						}
 					}
@@ -580,13 +725,18 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 		ContextMenuItem{newSheetFromTemplateAction.Title, NewSheetFromTemplateItemID},
 		ContextMenuItem{moveToCarriedEquipmentAction.Title, MoveToCarriedEquipmentItemID},
 		ContextMenuItem{moveToOtherEquipmentAction.Title, MoveToOtherEquipmentItemID},
+		ContextMenuItem{bundleIntoKitAction.Title, BundleIntoKitItemID},
 		ContextMenuItem{copyToSheetAction.Title, CopyToSheetItemID},
+		ContextMenuItem{copyListToSheetAction.Title, CopyListToSheetItemID},
 		ContextMenuItem{copyToTemplateAction.Title, CopyToTemplateItemID},
 		ContextMenuItem{"", -1},
 		ContextMenuItem{incrementAction.Title, IncrementItemID},
 		ContextMenuItem{decrementAction.Title, DecrementItemID},
 		ContextMenuItem{increaseUsesAction.Title, IncrementUsesItemID},
 		ContextMenuItem{decreaseUsesAction.Title, DecrementUsesItemID},
+		ContextMenuItem{fireWeaponAction.Title, FireWeaponItemID},
+		ContextMenuItem{reloadWeaponAction.Title, ReloadWeaponItemID},
+		ContextMenuItem{castSpellAction.Title, CastSpellItemID},
 		ContextMenuItem{increaseSkillLevelAction.Title, IncrementSkillLevelItemID},
 		ContextMenuItem{decreaseSkillLevelAction.Title, DecrementSkillLevelItemID},
 		ContextMenuItem{increaseTechLevelAction.Title, IncrementTechLevelItemID},
@@ -595,10 +745,18 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 		ContextMenuItem{decreaseEquipmentLevelAction.Title, DecrementEquipmentLevelItemID},
 		ContextMenuItem{"", -1},
 		ContextMenuItem{toggleStateAction.Title, ToggleStateItemID},
+		ContextMenuItem{toggleFavoriteModifierAction.Title, ToggleFavoriteModifierItemID},
+		ContextMenuItem{applyFavoriteModifierAction.Title, ApplyFavoriteModifierItemID},
+		ContextMenuItem{applyQualityPresetAction.Title, ApplyQualityPresetItemID},
+		ContextMenuItem{togglePinnedAction.Title, TogglePinnedItemID},
+		ContextMenuItem{toggleOrderLockAction.Title, ToggleOrderLockItemID},
 		ContextMenuItem{swapDefaultsAction.Title, SwapDefaultsItemID},
 		ContextMenuItem{convertToContainerAction.Title, ConvertToContainerItemID},
 		ContextMenuItem{convertToNonContainerAction.Title, ConvertToNonContainerItemID},
 		ContextMenuItem{"", -1},
+		ContextMenuItem{exportAsCSVAction.Title, ExportAsCSVItemID},
+		ContextMenuItem{exportItemCardAction.Title, ExportItemCardItemID},
+		ContextMenuItem{"", -1},
 		ContextMenuItem{openOnePageReferenceAction.Title, OpenOnePageReferenceItemID},
 		ContextMenuItem{openEachPageReferenceAction.Title, OpenEachPageReferenceItemID},
 		ContextMenuItem{"", -1},
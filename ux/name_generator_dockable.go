@@ -0,0 +1,275 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const (
+	minGeneratedNames = 1
+	maxGeneratedNames = 100
+)
+
+var (
+	_ unison.Dockable            = &NameGeneratorDockable{}
+	_ unison.UndoManagerProvider = &NameGeneratorDockable{}
+	_ unison.TabCloser           = &NameGeneratorDockable{}
+)
+
+// NameGeneratorDockable generates names using the name generators of an ancestry, without involving a sheet.
+type NameGeneratorDockable struct {
+	unison.Panel
+	undoMgr      *unison.UndoManager
+	content      *unison.Panel
+	scroll       *unison.ScrollPanel
+	genderHolder *unison.Panel
+	genderPopup  *unison.PopupMenu[string]
+	results      *unison.Field
+	ancestry     string
+	count        int
+	scale        int
+}
+
+// ShowNameGenerator displays the name generator.
+func ShowNameGenerator() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*NameGeneratorDockable)
+		return ok
+	}) {
+		return
+	}
+	d := &NameGeneratorDockable{
+		ancestry: gurps.DefaultAncestry,
+		count:    10,
+		scale:    gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	if sheet := ActiveSheet(); sheet != nil {
+		d.ancestry = sheet.Entity().Ancestry().Name
+	}
+	d.Self = d
+	d.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.createContent()
+	d.scroll = unison.NewScrollPanel()
+	d.scroll.SetContent(d.content, behavior.HintedFill, behavior.Fill)
+	d.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.scroll)
+	PlaceInDock(d, dgroup.Editors, false)
+}
+
+func (d *NameGeneratorDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return d.scale },
+			func(scale int) { d.scale = scale },
+			nil,
+			false,
+			d.scroll,
+		),
+	)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (d *NameGeneratorDockable) createContent() {
+	d.content = unison.NewPanel()
+	d.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+
+	d.content.AddChild(NewFieldLeadingLabel(i18n.Text("Ancestry"), false))
+	ancestryPopup := unison.NewPopupMenu[string]()
+	first := ""
+	found := false
+	for _, set := range gurps.AvailableAncestries(gurps.GlobalSettings().Libraries()) {
+		for _, ref := range set.List {
+			ancestryPopup.AddItem(ref.Name)
+			if first == "" {
+				first = ref.Name
+			}
+			if ref.Name == d.ancestry {
+				found = true
+			}
+		}
+	}
+	if !found && first != "" {
+		d.ancestry = first
+	}
+	ancestryPopup.Select(d.ancestry)
+	ancestryPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[string]) {
+		if name, ok := popup.Selected(); ok && name != d.ancestry {
+			d.ancestry = name
+			d.rebuildGenderPopup()
+		}
+	}
+	d.content.AddChild(ancestryPopup)
+
+	d.content.AddChild(NewFieldLeadingLabel(i18n.Text("Gender"), false))
+	d.genderHolder = unison.NewPanel()
+	d.genderHolder.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.content.AddChild(d.genderHolder)
+	d.rebuildGenderPopup()
+
+	d.content.AddChild(NewFieldLeadingLabel(i18n.Text("Count"), false))
+	countField := NewIntegerField(nil, "", i18n.Text("Count"),
+		func() int { return d.count },
+		func(v int) { d.count = v },
+		minGeneratedNames, maxGeneratedNames, false, false)
+	countField.Tooltip = newWrappedTooltip(i18n.Text("The number of names to generate"))
+	d.content.AddChild(countField)
+
+	d.content.AddChild(unison.NewPanel())
+	generateButton := unison.NewButton()
+	generateButton.SetTitle(i18n.Text("Generate"))
+	generateButton.ClickCallback = d.generate
+	d.content.AddChild(generateButton)
+
+	d.results = unison.NewMultiLineField()
+	d.results.SetWrap(true)
+	d.results.MinimumTextWidth = 300
+	d.results.Tooltip = newWrappedTooltip(i18n.Text("The generated names, which may be copied from here"))
+	d.results.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.Size{Height: 200},
+		HSpan:   2,
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	d.content.AddChild(d.results)
+}
+
+func (d *NameGeneratorDockable) rebuildGenderPopup() {
+	var current string
+	if d.genderPopup != nil {
+		current, _ = d.genderPopup.Selected()
+	}
+	d.genderHolder.RemoveAllChildren()
+	d.genderPopup = unison.NewPopupMenu[string]()
+	anyGender := i18n.Text("Any")
+	d.genderPopup.AddItem(anyGender)
+	d.genderPopup.Select(anyGender)
+	if a := gurps.LookupAncestry(d.ancestry, gurps.GlobalSettings().Libraries()); a != nil {
+		for _, gender := range a.GenderNames() {
+			d.genderPopup.AddItem(gender)
+			if gender == current {
+				d.genderPopup.Select(gender)
+			}
+		}
+	}
+	d.genderHolder.AddChild(d.genderPopup)
+	d.content.MarkForLayoutRecursively()
+	d.content.MarkForRedraw()
+}
+
+func (d *NameGeneratorDockable) generate() {
+	libraries := gurps.GlobalSettings().Libraries()
+	a := gurps.LookupAncestry(d.ancestry, libraries)
+	if a == nil {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to generate names"),
+			i18n.Text("The ancestry could not be loaded."))
+		return
+	}
+	gender := ""
+	if d.genderPopup.SelectedIndex() > 0 {
+		gender, _ = d.genderPopup.Selected()
+	}
+	refs := gurps.AvailableNameGenerators(libraries)
+	generated := make([]string, 0, d.count)
+	for range d.count {
+		g := gender
+		if g == "" {
+			g = a.RandomGender("")
+		}
+		if name := a.RandomName(refs, g); name != "" {
+			generated = append(generated, name)
+		}
+	}
+	if len(generated) == 0 {
+		generated = append(generated, i18n.Text("The ancestry has no name generators for this gender."))
+	}
+	d.results.SetText(strings.Join(generated, "\n"))
+}
+
+// TitleIcon implements unison.Dockable
+func (d *NameGeneratorDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *NameGeneratorDockable) Title() string {
+	return i18n.Text("Name Generator")
+}
+
+func (d *NameGeneratorDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *NameGeneratorDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *NameGeneratorDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *NameGeneratorDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *NameGeneratorDockable) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (d *NameGeneratorDockable) UndoManager() *unison.UndoManager {
+	return d.undoMgr
+}
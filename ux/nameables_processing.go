@@ -36,10 +36,12 @@ func ProcessNameables[T gurps.NodeTypes](owner unison.Paneler, rows []T) {
 	for _, row := range rows {
 		gurps.Traverse(func(row T) bool {
 			m := make(map[string]string)
-			gurps.AsNode(row).FillWithNameableKeys(m, nil)
+			node := gurps.AsNode(row)
+			node.FillWithNameableKeys(m, nil)
+			gurps.SheetSettingsFor(gurps.EntityFromNode(node)).PrefillNameables(m)
 			if len(m) > 0 {
 				data = append(data, row)
-				titles = append(titles, gurps.AsNode(row).String())
+				titles = append(titles, node.String())
 				nameables = append(nameables, m)
 			}
 			return false
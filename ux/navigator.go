@@ -27,6 +27,7 @@ import (
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/behavior"
@@ -963,9 +964,25 @@ func (n *Navigator) search(text string, rows []*NavigatorNode) {
 								prepareForContentCache(data.Notes),
 							}, "\n"))
 						}
-					// TODO: Re-enable Campaign files
-					// case gurps.CampaignExt:
-					// TODO: Implement
+					case gurps.CampaignExt:
+						if data, err := gurps.NewCampaignFromFile(dir, fileName); err == nil {
+							parts := make([]string, 0, len(data.Characters)+len(data.Members))
+							for _, one := range data.Characters {
+								parts = append(parts, one.Profile.Name)
+							}
+							for _, one := range data.Members {
+								parts = append(parts, fs.BaseName(one.Path))
+							}
+							content = n.addToContentCache(p, strings.Join(parts, "\n"))
+						}
+					case gurps.CombatExt:
+						if data, err := gurps.NewCombatFromFile(dir, fileName); err == nil {
+							parts := make([]string, 0, len(data.Combatants)*2)
+							for _, one := range data.Combatants {
+								parts = append(parts, one.Name, one.Notes)
+							}
+							content = n.addToContentCache(p, strings.Join(parts, "\n"))
+						}
 					case gurps.TraitModifiersExt:
 						if data, err := gurps.NewTraitModifiersFromFile(dir, fileName); err == nil {
 							content = n.addToContentCache(p, prepareForContentCache(data))
@@ -1139,10 +1156,12 @@ func DisplayNewDockable(dockable unison.Dockable) {
 		case fi.Extensions[0] == gurps.TemplatesExt:
 			g := dgroup.CharacterTemplates
 			group = &g
-		// TODO: Re-enable Campaign files
-		// case fi.Extensions[0] == gurps.CampaignExt:
-		// 	g := dgroup.Campaigns
-		// 	group = &g
+		case fi.Extensions[0] == gurps.CampaignExt:
+			g := dgroup.Campaigns
+			group = &g
+		case fi.Extensions[0] == gurps.CombatExt:
+			g := dgroup.Editors
+			group = &g
 		case fi.Extensions[0] == gurps.TraitsExt,
 			fi.Extensions[0] == gurps.TraitModifiersExt,
 			fi.Extensions[0] == gurps.EquipmentExt,
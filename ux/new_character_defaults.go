@@ -0,0 +1,43 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// newCharacterSheet creates a new character sheet, applying the new character defaults from the general settings, and
+// displays it.
+func newCharacterSheet() {
+	e := gurps.NewEntity()
+	sheet := NewSheet(e.Profile.Name+gurps.SheetExt, e)
+	DisplayNewDockable(sheet)
+	if applyDefaultTemplate(sheet, gurps.GlobalSettings().General.NewCharacter.TemplatePathOrEmpty()) {
+		sheet.undoMgr.Clear()
+		sheet.crc = 0
+		sheet.SetBackingFilePath(e.Profile.Name + gurps.SheetExt)
+	}
+}
+
+// applyDefaultTemplate applies the template at templatePath to the sheet. Returns true if a template was applied.
+func applyDefaultTemplate(sheet *Sheet, templatePath string) bool {
+	if templatePath == "" {
+		return false
+	}
+	d, err := NewTemplateFromFile(templatePath)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load the default template"), err)
+		return false
+	}
+	t, ok := d.(*Template)
+	return ok && t.applyTemplateToSheet(sheet, true)
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+const wizardBackResponse = unison.ModalResponseUserBase + 1
+
+type wizardChoice struct {
+	title string
+	lib   *gurps.Library
+	ref   *gurps.NamedFileRef
+}
+
+func (c *wizardChoice) String() string {
+	return c.title
+}
+
+func (c *wizardChoice) fullPath() string {
+	return filepath.Join(c.lib.Path(), filepath.FromSlash(c.ref.FilePath))
+}
+
+type wizardStep struct {
+	title       string
+	description string
+	build       func(content *unison.Panel) (commit func())
+}
+
+type newCharacterWizard struct {
+	entity           *gurps.Entity
+	appliedCampaign  *wizardChoice
+	campaignDefaults *gurps.NewCharacterDefaults
+	campaign         *wizardChoice
+	ancestry         *wizardChoice
+	template         *wizardChoice
+	kit              *wizardChoice
+	loadedKit        *wizardChoice
+	campaigns        []*wizardChoice
+	ancestries       []*wizardChoice
+	templates        []*wizardChoice
+	kits             []*wizardChoice
+	kitEquipment     []*gurps.Equipment
+	wealth           fxp.Int
+	wealthSet        bool
+	addUnspentWealth bool
+}
+
+// ShowNewCharacterWizard walks the user through the choices needed to create a new character and then opens the
+// resulting sheet.
+func ShowNewCharacterWizard() {
+	w := &newCharacterWizard{entity: gurps.NewEntity()}
+	libraries := gurps.GlobalSettings().Libraries()
+	w.campaigns = libraryChoices(libraries, gurps.CampaignExt)
+	w.ancestries = ancestryChoices(libraries)
+	w.templates = libraryChoices(libraries, gurps.TemplatesExt)
+	w.kits = libraryChoices(libraries, gurps.EquipmentExt)
+	w.campaign = w.campaigns[0]
+	w.appliedCampaign = w.campaign
+	w.ancestry = w.ancestries[0]
+	w.template = w.templates[0]
+	w.kit = w.kits[0]
+	steps := []*wizardStep{
+		{
+			title:       i18n.Text("Campaign"),
+			description: i18n.Text("Choose the campaign whose settings the character should use."),
+			build: func(content *unison.Panel) func() {
+				return w.addChoicePopup(content, i18n.Text("Campaign"), w.campaigns, &w.campaign)
+			},
+		},
+		{
+			title:       i18n.Text("Point Budget"),
+			description: i18n.Text("Set the points available to the character and the limits on disadvantages and quirks."),
+			build:       w.buildPointBudget,
+		},
+		{
+			title:       i18n.Text("Ancestry"),
+			description: i18n.Text("Choose the ancestry used to randomize the character's profile."),
+			build: func(content *unison.Panel) func() {
+				return w.addChoicePopup(content, i18n.Text("Ancestry"), w.ancestries, &w.ancestry)
+			},
+		},
+		{
+			title:       i18n.Text("Template"),
+			description: i18n.Text("Choose a character template to apply."),
+			build: func(content *unison.Panel) func() {
+				return w.addChoicePopup(content, i18n.Text("Template"), w.templates, &w.template)
+			},
+		},
+		{
+			title:       i18n.Text("Attributes"),
+			description: i18n.Text("Adjust the character's attributes."),
+			build:       w.buildAttributes,
+		},
+		{
+			title:       i18n.Text("Starting Kit"),
+			description: i18n.Text("Choose an equipment list or kit to start the character with."),
+			build: func(content *unison.Panel) func() {
+				return w.addChoicePopup(content, i18n.Text("Equipment"), w.kits, &w.kit)
+			},
+		},
+		{
+			title:       i18n.Text("Starting Wealth"),
+			description: i18n.Text("Spend the character's starting wealth on the starting kit."),
+			build:       w.buildStartingWealth,
+		},
+	}
+	for i := 0; i < len(steps); {
+		commit, response := w.runStep(steps, i)
+		switch response {
+		case unison.ModalResponseOK:
+			commit()
+			i++
+		case wizardBackResponse:
+			commit()
+			i--
+		default:
+			return
+		}
+	}
+	w.createSheet()
+}
+
+func libraryChoices(libraries gurps.Libraries, ext string) []*wizardChoice {
+	choices := []*wizardChoice{{title: i18n.Text("None")}}
+	list := libraries.List()
+	for _, lib := range list {
+		for _, ref := range lib.ScanForFiles(ext) {
+			title := ref.Name
+			if len(list) > 1 {
+				title = fmt.Sprintf("%s (%s)", ref.Name, lib.Title)
+			}
+			choices = append(choices, &wizardChoice{
+				title: title,
+				lib:   lib,
+				ref:   ref,
+			})
+		}
+	}
+	return choices
+}
+
+func ancestryChoices(libraries gurps.Libraries) []*wizardChoice {
+	choices := []*wizardChoice{{title: i18n.Text("None")}}
+	for _, set := range gurps.AvailableAncestries(libraries) {
+		for _, ref := range set.List {
+			choices = append(choices, &wizardChoice{
+				title: ref.Name,
+				ref:   ref,
+			})
+		}
+	}
+	return choices
+}
+
+func (w *newCharacterWizard) runStep(steps []*wizardStep, index int) (commit func(), response int) {
+	step := steps[index]
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	header := unison.NewLabel()
+	header.SetTitle(fmt.Sprintf(i18n.Text("Step %d of %d: %s"), index+1, len(steps), step.title))
+	panel.AddChild(header)
+	label := unison.NewLabel()
+	label.Font = fonts.FieldSecondary
+	label.SetTitle(step.description)
+	panel.AddChild(label)
+	content := unison.NewPanel()
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	commit = step.build(content)
+	panel.AddChild(content)
+	buttons := []*unison.DialogButtonInfo{unison.NewCancelButtonInfo()}
+	if index > 0 {
+		buttons = append(buttons, &unison.DialogButtonInfo{
+			Title:        i18n.Text("Back"),
+			ResponseCode: wizardBackResponse,
+		})
+	}
+	next := i18n.Text("Next")
+	if index == len(steps)-1 {
+		next = i18n.Text("Create")
+	}
+	buttons = append(buttons, unison.NewOKButtonInfoWithTitle(next))
+	dialog, err := unison.NewDialog(nil, nil, panel, buttons)
+	if err != nil {
+		errs.Log(err)
+		return nil, unison.ModalResponseCancel
+	}
+	return commit, dialog.RunModal()
+}
+
+func (w *newCharacterWizard) addChoicePopup(content *unison.Panel, title string, choices []*wizardChoice, current **wizardChoice) func() {
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	popup := unison.NewPopupMenu[*wizardChoice]()
+	popup.AddItem(choices...)
+	popup.Select(*current)
+	content.AddChild(popup)
+	return func() {
+		if choice, ok := popup.Selected(); ok {
+			*current = choice
+		}
+	}
+}
+
+func (w *newCharacterWizard) applyCampaign() {
+	if w.appliedCampaign == w.campaign {
+		return
+	}
+	w.appliedCampaign = w.campaign
+	w.campaignDefaults = nil
+	e := gurps.NewEntity()
+	if w.campaign.ref != nil {
+		c, err := gurps.NewCampaignFromFile(w.campaign.ref.FileSystem, w.campaign.ref.FilePath)
+		if err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to load campaign"), err)
+		} else {
+			if c.SheetSettings != nil {
+				e.SheetSettings = c.SheetSettings.Clone(e)
+				e.Attributes = gurps.NewAttributes(e)
+				gurps.GlobalSettings().General.NewCharacter.Apply(e)
+			}
+			c.NewCharacter.Apply(e)
+			w.campaignDefaults = c.NewCharacter
+			e.Recalculate()
+		}
+	}
+	w.entity = e
+}
+
+func (w *newCharacterWizard) buildPointBudget(content *unison.Panel) func() {
+	w.applyCampaign()
+	e := w.entity
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Points"), false))
+	content.AddChild(NewDecimalField(nil, "", i18n.Text("Points"),
+		func() fxp.Int { return e.TotalPoints },
+		func(value fxp.Int) { e.TotalPoints = value },
+		gurps.InitialPointsMin, gurps.InitialPointsMax, false, false))
+	limitTooltip := i18n.Text("Zero means there is no limit")
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Disadvantage Limit"), false))
+	field := NewDecimalField(nil, "", i18n.Text("Disadvantage Limit"),
+		func() fxp.Int { return e.SheetSettings.CreationDisadvantageLimit },
+		func(value fxp.Int) { e.SheetSettings.CreationDisadvantageLimit = value },
+		0, gurps.InitialPointsMax, false, false)
+	field.Tooltip = newWrappedTooltip(limitTooltip)
+	content.AddChild(field)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Quirk Limit"), false))
+	field = NewDecimalField(nil, "", i18n.Text("Quirk Limit"),
+		func() fxp.Int { return e.SheetSettings.CreationQuirkLimit },
+		func(value fxp.Int) { e.SheetSettings.CreationQuirkLimit = value },
+		0, gurps.InitialPointsMax, false, false)
+	field.Tooltip = newWrappedTooltip(limitTooltip)
+	content.AddChild(field)
+	return func() {}
+}
+
+func (w *newCharacterWizard) buildAttributes(content *unison.Panel) func() {
+	w.applyCampaign()
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	content.AddChild(unison.NewPanel())
+	for _, title := range []string{i18n.Text("Adjustment"), i18n.Text("Value"), i18n.Text("Points")} {
+		label := unison.NewLabel()
+		label.Font = fonts.FieldSecondary
+		label.SetTitle(title)
+		content.AddChild(label)
+	}
+	var syncers []*NonEditableField
+	total := NewNonEditableField(func(field *NonEditableField) {
+		var points fxp.Int
+		for _, attr := range w.entity.Attributes.List() {
+			if def := attr.AttributeDef(); def != nil && !def.IsSeparator() {
+				points += attr.PointCost()
+			}
+		}
+		field.SetTitle(points.String())
+		field.MarkForLayoutAndRedraw()
+	})
+	for _, one := range w.entity.Attributes.List() {
+		attr := one
+		def := attr.AttributeDef()
+		if def == nil || def.IsSeparator() {
+			continue
+		}
+		content.AddChild(NewFieldLeadingLabel(def.Name, false))
+		content.AddChild(NewDecimalField(nil, "", def.Name,
+			func() fxp.Int { return attr.Adjustment },
+			func(value fxp.Int) {
+				attr.Adjustment = value
+				w.entity.Recalculate()
+				for _, syncer := range syncers {
+					syncer.Sync()
+				}
+			}, fxp.Min, fxp.Max, true, false))
+		value := NewNonEditableField(func(field *NonEditableField) {
+			field.SetTitle(attr.Maximum().String())
+			field.MarkForLayoutAndRedraw()
+		})
+		content.AddChild(value)
+		points := NewNonEditableField(func(field *NonEditableField) {
+			field.SetTitle(attr.PointCost().String())
+			field.MarkForLayoutAndRedraw()
+		})
+		content.AddChild(points)
+		syncers = append(syncers, value, points)
+	}
+	remaining := NewNonEditableField(func(field *NonEditableField) {
+		points := w.entity.TotalPoints
+		for _, attr := range w.entity.Attributes.List() {
+			if def := attr.AttributeDef(); def != nil && !def.IsSeparator() {
+				points -= attr.PointCost()
+			}
+		}
+		field.SetTitle(points.String())
+		field.MarkForLayoutAndRedraw()
+	})
+	syncers = append(syncers, total, remaining)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Total"), false))
+	content.AddChild(unison.NewPanel())
+	content.AddChild(unison.NewPanel())
+	content.AddChild(total)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Remaining Budget"), false))
+	content.AddChild(unison.NewPanel())
+	content.AddChild(unison.NewPanel())
+	content.AddChild(remaining)
+	return func() {}
+}
+
+// loadKit returns the equipment in the chosen starting kit, loading it if the choice has changed.
+func (w *newCharacterWizard) loadKit() []*gurps.Equipment {
+	if w.loadedKit != w.kit {
+		w.loadedKit = w.kit
+		w.kitEquipment = nil
+		if w.kit.ref != nil {
+			list, err := gurps.NewEquipmentFromFile(w.kit.ref.FileSystem, w.kit.ref.FilePath)
+			if err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to load starting kit"), err)
+			} else {
+				w.kitEquipment = list
+			}
+		}
+	}
+	return w.kitEquipment
+}
+
+func (w *newCharacterWizard) kitCost() fxp.Int {
+	var cost fxp.Int
+	for _, one := range w.loadKit() {
+		cost += one.ExtendedValue()
+	}
+	return cost
+}
+
+func (w *newCharacterWizard) buildStartingWealth(content *unison.Panel) func() {
+	w.applyCampaign()
+	if !w.wealthSet {
+		w.wealth = gurps.StartingWealthForTechLevel(w.entity.Profile.TechLevel)
+	}
+	cost := w.kitCost()
+	remaining := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle("$" + (w.wealth - cost).Comma())
+		if w.wealth < cost {
+			field.Tooltip = newWrappedTooltip(i18n.Text("The starting kit costs more than the starting wealth"))
+		} else {
+			field.Tooltip = nil
+		}
+		field.MarkForLayoutAndRedraw()
+	})
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Starting Wealth"), false))
+	wealthField := NewDecimalField(nil, "", i18n.Text("Starting Wealth"),
+		func() fxp.Int { return w.wealth },
+		func(value fxp.Int) {
+			w.wealth = value
+			w.wealthSet = true
+			remaining.Sync()
+		}, 0, fxp.Max, false, false)
+	wealthField.Tooltip = newWrappedTooltip(i18n.Text("Defaults to the standard starting wealth for the character's tech level"))
+	content.AddChild(wealthField)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Starting Kit"), false))
+	content.AddChild(NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle("$" + cost.Comma())
+		field.MarkForLayoutAndRedraw()
+	}))
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Remaining"), false))
+	content.AddChild(remaining)
+	content.AddChild(unison.NewPanel())
+	checkbox := unison.NewCheckBox()
+	checkbox.SetTitle(i18n.Text("Add the remaining wealth to the carried equipment as cash"))
+	checkbox.State = check.FromBool(w.addUnspentWealth)
+	content.AddChild(checkbox)
+	return func() { w.addUnspentWealth = checkbox.State == check.On }
+}
+
+func (w *newCharacterWizard) createSheet() {
+	w.applyCampaign()
+	e := w.entity
+	if w.ancestry.ref != nil {
+		t := gurps.NewTrait(e, nil, true)
+		t.Name = w.ancestry.ref.Name
+		t.ContainerType = container.Ancestry
+		t.Ancestry = w.ancestry.ref.Name
+		e.Traits = append(e.Traits, t)
+		if gurps.GlobalSettings().General.AutoFillProfile {
+			e.Profile.ApplyRandomizers(e)
+		}
+	}
+	if list := w.loadKit(); len(list) != 0 {
+		from := gurps.LibraryFile{
+			Library: w.kit.lib.Key(),
+			Path:    filepath.FromSlash(w.kit.ref.FilePath),
+		}
+		for _, one := range list {
+			e.CarriedEquipment = append(e.CarriedEquipment, one.Clone(from, e, nil, false))
+		}
+		e.CarriedEquipment, _ = gurps.ExpandKits(e.CarriedEquipment, nil)
+	}
+	if unspent := w.wealth - w.kitCost(); w.addUnspentWealth && unspent > 0 {
+		cash := gurps.NewEquipment(e, nil, false)
+		cash.Name = i18n.Text("Cash")
+		cash.Value = unspent
+		e.CarriedEquipment = append(e.CarriedEquipment, cash)
+	}
+	e.Recalculate()
+	sheet := NewSheet(e.Profile.Name+gurps.SheetExt, e)
+	DisplayNewDockable(sheet)
+	if w.template.ref != nil {
+		d, err := NewTemplateFromFile(w.template.fullPath())
+		if err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to load template"), err)
+		} else if t, ok := d.(*Template); ok && t.applyTemplateToSheet(sheet, true) {
+			sheet.undoMgr.Clear()
+		}
+	} else {
+		// No template was chosen, so fall back to the default template for new characters, preferring the campaign's.
+		templatePath := w.campaignDefaults.TemplatePathOrEmpty()
+		if templatePath == "" {
+			templatePath = gurps.GlobalSettings().General.NewCharacter.TemplatePathOrEmpty()
+		}
+		if applyDefaultTemplate(sheet, templatePath) {
+			sheet.undoMgr.Clear()
+		}
+	}
+	sheet.crc = 0
+	sheet.SetBackingFilePath(e.Profile.Name + gurps.SheetExt)
+}
@@ -10,6 +10,8 @@
 package ux
 
 import (
+	"fmt"
+
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -69,12 +71,12 @@ func initNoteEditor(e *editor[*gurps.Note, *gurps.NoteEditData], content *unison
 		func() string { return e.editorData.Text },
 		func(value string) {
 			e.editorData.Text = value
-			markdown.SetContent(gurps.EvalEmbeddedRegex.ReplaceAllStringFunc(value,
-				gurps.EntityFromNode(e.target).EmbeddedEval), 0)
+			markdown.SetContent(gurps.EntityFromNode(e.target).ResolveNoteText(value), 0)
 			content.MarkForLayoutAndRedraw()
 			MarkModified(content)
 		})
 	field.AutoScroll = false
+	field.Tooltip = newWrappedTooltip(i18n.Text("Live fields, such as {{ST}}, {{HP}}, {{dodge}}, {{skill:Stealth}} or {{spell:Fireball}}, are replaced with the character's current values"))
 	field.Font = &unison.DynamicFont{
 		Resolver: func() unison.FontDescriptor {
 			fd := unison.MonospacedFont.Font.Descriptor()
@@ -84,6 +86,9 @@ func initNoteEditor(e *editor[*gurps.Note, *gurps.NoteEditData], content *unison
 	}
 	content.AddChild(field)
 
+	addLabelAndStringField(content, i18n.Text("Date"),
+		fmt.Sprintf(i18n.Text("The date this note is about, in the form %s; notes with a date are shown in the notes journal"),
+			gurps.NoteDateFormat), &e.editorData.Date)
 	addPageRefLabelAndField(content, &e.editorData.PageRef)
 	addPageRefHighlightLabelAndField(content, &e.editorData.PageRefHighlight)
 	addSourceFields(content, &e.target.SourcedID)
@@ -104,8 +109,7 @@ func initNoteEditor(e *editor[*gurps.Note, *gurps.NoteEditData], content *unison
 	)
 	content.AddChild(label)
 
-	markdown.SetContent(gurps.EvalEmbeddedRegex.ReplaceAllStringFunc(e.editorData.Text,
-		gurps.EntityFromNode(e.target).EmbeddedEval), 0)
+	markdown.SetContent(gurps.EntityFromNode(e.target).ResolveNoteText(e.editorData.Text), 0)
 
 	markdownWrapper := unison.NewPanel()
 	markdownWrapper.SetScale(1.33)
@@ -49,7 +49,7 @@ func NewNoteTableDockable(filePath string, notes []*gurps.Note) *TableDockable[*
 	provider := &noteListProvider{notes: notes}
 	d := NewTableDockable(filePath, gurps.NotesExt, NewNotesProvider(provider, false),
 		func(path string) error { return gurps.SaveNotes(provider.NoteList(), path) },
-		NewNoteItemID, NewNoteContainerItemID)
+		NewNoteItemID, NewNoteContainerItemID, NewNoteFromTemplateItemID)
 	InstallContainerConversionHandlers(d, d, d.table)
 	return d
 }
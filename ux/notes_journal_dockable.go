@@ -0,0 +1,236 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &NotesJournalDockable{}
+	_ unison.UndoManagerProvider = &NotesJournalDockable{}
+	_ GroupedCloser              = &NotesJournalDockable{}
+)
+
+// NotesJournalDockable displays the dated notes of a character in chronological order.
+type NotesJournalDockable struct {
+	unison.Panel
+	sheet   *Sheet
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// DisplayNotesJournal displays the notes journal for the given Sheet.
+func DisplayNotesJournal(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if j, ok := d.AsPanel().Self.(*NotesJournalDockable); ok {
+			return j.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateNotesJournal(sheet)
+		return
+	}
+	j := &NotesJournalDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	j.Self = j
+	j.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	j.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	j.content = unison.NewPanel()
+	j.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	j.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	j.scroll = unison.NewScrollPanel()
+	j.scroll.SetContent(j.content, behavior.HintedFill, behavior.Unmodified)
+	j.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	j.AddChild(j.createToolbar())
+	j.AddChild(j.scroll)
+	j.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	j.refresh()
+	PlaceInDock(j, dgroup.Editors, false)
+}
+
+// UpdateNotesJournal refreshes the notes journal for the given Sheet, if it is being displayed.
+func UpdateNotesJournal(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if j, ok := other.(*NotesJournalDockable); ok && j.sheet == sheet {
+			j.refresh()
+			break
+		}
+	}
+}
+
+func (j *NotesJournalDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return j.scale },
+			func(scale int) { j.scale = scale },
+			nil,
+			false,
+			j.scroll,
+		),
+	)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add a note from a template"))
+	addButton.ClickCallback = func() { j.sheet.Notes.CreateItem(j.sheet, AlternateItemVariant) }
+	toolbar.AddChild(addButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (j *NotesJournalDockable) refresh() {
+	j.content.RemoveAllChildren()
+	entity := j.sheet.Entity()
+	notes := gurps.JournalNotes(entity.Notes)
+	if len(notes) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No notes have a date"))
+		j.content.AddChild(label)
+	}
+	for _, note := range notes {
+		j.addEntry(entity, note)
+	}
+	j.content.MarkForLayoutRecursively()
+	j.MarkForRedraw()
+}
+
+func (j *NotesJournalDockable) addEntry(entity *gurps.Entity, note *gurps.Note) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	when, _ := note.JournalDate()
+	label.SetTitle(when.Format(gurps.NoteDateFormat))
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.AddChild(label)
+	editButton := unison.NewSVGButton(svg.Edit)
+	editButton.Tooltip = newWrappedTooltip(i18n.Text("Edit this note"))
+	editButton.ClickCallback = func() { EditNote(j.sheet, note) }
+	header.AddChild(editButton)
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  len(header.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.AddChild(header)
+	markdown := unison.NewMarkdown(true)
+	markdown.ClientData()[WorkingDirKey] = WorkingDirProvider(j.sheet)
+	markdown.SetContent(entity.ResolveNoteText(note.TextWithReplacements()), 0)
+	markdown.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(markdown)
+	j.content.AddChild(panel)
+}
+
+// TitleIcon implements unison.Dockable
+func (j *NotesJournalDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSNotes,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (j *NotesJournalDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Notes Journal for %s"), j.sheet.String())
+}
+
+func (j *NotesJournalDockable) String() string {
+	return j.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (j *NotesJournalDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (j *NotesJournalDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (j *NotesJournalDockable) CloseWithGroup(other unison.Paneler) bool {
+	return j.sheet != nil && j.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (j *NotesJournalDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(j)
+}
+
+// AttemptClose implements GroupedCloser
+func (j *NotesJournalDockable) AttemptClose() bool {
+	if !CloseGroup(j) {
+		return false
+	}
+	return AttemptCloseForDockable(j)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (j *NotesJournalDockable) UndoManager() *unison.UndoManager {
+	return j.undoMgr
+}
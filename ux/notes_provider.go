@@ -10,9 +10,12 @@
 package ux
 
 import (
+	"time"
+
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 )
@@ -117,7 +120,7 @@ func (p *notesProvider) ColumnIDs() []int {
 		gurps.NoteReferenceColumn,
 	}
 	if p.forPage {
-		if entity := p.DataOwner().OwningEntity(); entity == nil || !entity.SheetSettings.HideSourceMismatch {
+		if entity := p.DataOwner().OwningEntity(); entity == nil || entity.SheetSettings.ShowLibrarySourceColumn() {
 			columnIDs = append(columnIDs, gurps.NoteLibSrcColumn)
 		}
 	}
@@ -137,7 +140,16 @@ func (p *notesProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node[
 }
 
 func (p *notesProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*gurps.Note]], variant ItemVariant) {
-	item := gurps.NewNote(p.DataOwner(), nil, variant == ContainerItemVariant)
+	var item *gurps.Note
+	if variant == AlternateItemVariant {
+		tmpl := askForNoteTemplate()
+		if tmpl == nil {
+			return
+		}
+		item = tmpl.NewNote(p.DataOwner(), time.Now())
+	} else {
+		item = gurps.NewNote(p.DataOwner(), nil, variant == ContainerItemVariant)
+	}
 	InsertItems[*gurps.Note](owner, table, p.provider.NoteList, p.provider.SetNoteList,
 		func(_ *unison.Table[*Node[*gurps.Note]]) []*Node[*gurps.Note] { return p.RootRows() }, item)
 	EditNote(owner, item)
@@ -161,6 +173,40 @@ func (p *notesProvider) ContextMenuItems() []ContextMenuItem {
 	list = append(list,
 		ContextMenuItem{i18n.Text("New Note"), NewNoteItemID},
 		ContextMenuItem{i18n.Text("New Note Container"), NewNoteContainerItemID},
+		ContextMenuItem{i18n.Text("New Note from Template…"), NewNoteFromTemplateItemID},
 	)
 	return AppendDefaultContextMenuItems(list)
 }
+
+// askForNoteTemplate asks which note template to use, returning nil if the user cancels.
+func askForNoteTemplate() *gurps.NoteTemplate {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Template"), false))
+	popup := unison.NewPopupMenu[*gurps.NoteTemplate]()
+	for _, one := range gurps.NoteTemplates() {
+		popup.AddItem(one)
+	}
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Create")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return nil
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return nil
+	}
+	tmpl, ok := popup.Selected()
+	if !ok {
+		return nil
+	}
+	return tmpl
+}
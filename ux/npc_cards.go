@@ -0,0 +1,246 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/imgutil"
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/paper"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/filtermode"
+	"github.com/richardwilkes/unison/enums/mipmapmode"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
+	"github.com/richardwilkes/unison/enums/thememode"
+)
+
+// NPC cards are the size of a standard 5" x 3" index card.
+const (
+	npcCardWidth        = 5 * 72
+	npcCardHeight       = 3 * 72
+	npcCardPadding      = 6
+	npcCardPortraitSize = 54
+)
+
+var _ unison.PageProvider = &npcCardExporter{}
+
+type npcCardExporter struct {
+	page    *gurps.PageSettings
+	cards   []*gurps.NPCCard
+	columns int
+	rows    int
+}
+
+func newNPCCardExporter(cards []*gurps.NPCCard) *npcCardExporter {
+	page := *gurps.GlobalSettings().Sheet.Page
+	p := &npcCardExporter{
+		page:  &page,
+		cards: cards,
+	}
+	// Use whichever orientation fits the most cards on a page.
+	best := paper.Portrait
+	for _, orientation := range []paper.Orientation{paper.Portrait, paper.Landscape} {
+		page.Orientation = orientation
+		if columns, rows := p.grid(); columns*rows > p.cardsPerPage() {
+			best = orientation
+			p.columns = columns
+			p.rows = rows
+		}
+	}
+	page.Orientation = best
+	if p.cardsPerPage() == 0 {
+		// The page is too small for even a single card, so just put one on each page and let it be clipped.
+		p.columns = 1
+		p.rows = 1
+	}
+	return p
+}
+
+func (p *npcCardExporter) grid() (columns, rows int) {
+	size := p.PageSize()
+	width := size.Width - (p.page.LeftMargin.Pixels() + p.page.RightMargin.Pixels())
+	height := size.Height - (p.page.TopMargin.Pixels() + p.page.BottomMargin.Pixels())
+	return int(width / npcCardWidth), int(height / npcCardHeight)
+}
+
+func (p *npcCardExporter) cardsPerPage() int {
+	return p.columns * p.rows
+}
+
+// HasPage implements unison.PageProvider.
+func (p *npcCardExporter) HasPage(pageNumber int) bool {
+	return pageNumber > 0 && (pageNumber-1)*p.cardsPerPage() < len(p.cards)
+}
+
+// PageSize implements unison.PageProvider.
+func (p *npcCardExporter) PageSize() unison.Size {
+	w, h := p.page.Orientation.Dimensions(p.page.Size.Dimensions())
+	return unison.NewSize(w.Pixels(), h.Pixels())
+}
+
+// DrawPage implements unison.PageProvider.
+func (p *npcCardExporter) DrawPage(canvas *unison.Canvas, pageNumber int) error {
+	if !p.HasPage(pageNumber) {
+		return errs.New("invalid page number")
+	}
+	size := p.PageSize()
+	r := unison.Rect{Size: size}
+	canvas.DrawRect(r, unison.ThemeBelowSurface.Paint(canvas, r, paintstyle.Fill))
+	// Center the grid of cards within the margins so that the cut lines are balanced.
+	left := p.page.LeftMargin.Pixels()
+	top := p.page.TopMargin.Pixels()
+	left += (size.Width - (left + p.page.RightMargin.Pixels()) - float32(p.columns*npcCardWidth)) / 2
+	top += (size.Height - (top + p.page.BottomMargin.Pixels()) - float32(p.rows*npcCardHeight)) / 2
+	first := (pageNumber - 1) * p.cardsPerPage()
+	for i, card := range p.cards[first:min(first+p.cardsPerPage(), len(p.cards))] {
+		p.drawCard(canvas, card, unison.NewRect(left+float32((i%p.columns)*npcCardWidth),
+			top+float32((i/p.columns)*npcCardHeight), npcCardWidth, npcCardHeight))
+	}
+	return nil
+}
+
+func (p *npcCardExporter) drawCard(canvas *unison.Canvas, card *gurps.NPCCard, r unison.Rect) {
+	edge := unison.ThemeSurfaceEdge.Paint(canvas, r, paintstyle.Stroke)
+	edge.SetPathEffect(unison.NewDashPathEffect([]float32{4, 2}, 0))
+	canvas.DrawRect(r, edge)
+	canvas.Save()
+	defer canvas.Restore()
+	content := r.Inset(unison.NewUniformInsets(npcCardPadding))
+	canvas.ClipRect(content, pathop.Intersect, false)
+	var portraitBottom float32
+	if img := imgutil.ThumbnailNow(card.Portrait, portraitThumbnailDimension); img != nil {
+		pr := unison.NewRect(content.X, content.Y, npcCardPortraitSize, npcCardPortraitSize)
+		imgSize := img.LogicalSize()
+		scale := min(pr.Width/imgSize.Width, pr.Height/imgSize.Height)
+		pr.X += (pr.Width - imgSize.Width*scale) / 2
+		pr.Width = imgSize.Width * scale
+		pr.Height = imgSize.Height * scale
+		img.DrawInRect(canvas, pr, &unison.SamplingOptions{
+			UseCubic:       true,
+			CubicResampler: unison.MitchellResampler(),
+			FilterMode:     filtermode.Linear,
+			MipMapMode:     mipmapmode.Linear,
+		}, nil)
+		portraitBottom = content.Y + npcCardPortraitSize + npcCardPadding
+	}
+	nameFont := fonts.PageFieldPrimary.Face().Font(fonts.PageFieldPrimary.Size() * 1.5)
+	y := content.Y
+	for i, para := range npcCardParagraphs(card) {
+		font := unison.Font(fonts.PageLabelPrimary)
+		if i == 0 {
+			font = nameFont
+		}
+		// Paragraphs that start beside the portrait are wrapped to the space to its right.
+		x := content.X
+		width := content.Width
+		if y < portraitBottom {
+			x += npcCardPortraitSize + npcCardPadding
+			width -= npcCardPortraitSize + npcCardPadding
+		}
+		for _, line := range unison.NewTextWrappedLines(para, &unison.TextDecoration{
+			Font:            font,
+			OnBackgroundInk: unison.ThemeOnSurface,
+		}, width) {
+			line.Draw(canvas, x, y+line.Baseline())
+			y += line.Height()
+		}
+		y += 2
+	}
+}
+
+func npcCardParagraphs(card *gurps.NPCCard) []string {
+	name := card.Name
+	if name == "" {
+		name = i18n.Text("Unnamed")
+	}
+	list := []string{name, strings.Join(card.Stats, "; "), strings.Join(card.Defenses, "; ")}
+	for _, attack := range card.Attacks {
+		list = append(list, "• "+attack)
+	}
+	if card.AttackMore != 0 {
+		list = append(list, fmt.Sprintf(i18n.Text("…and %d more attacks"), card.AttackMore))
+	}
+	if len(card.Skills) != 0 {
+		list = append(list, i18n.Text("Skills: ")+strings.Join(card.Skills, "; "))
+	}
+	return list
+}
+
+func (p *npcCardExporter) exportAsPDFFile(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err)
+	}
+	stream, err := unison.NewFileStream(filePath)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	savedColorMode := unison.CurrentThemeMode()
+	unison.SetThemeMode(thememode.Light)
+	unison.ThemeChanged()
+	unison.RebuildDynamicColors()
+	defer func() {
+		unison.SetThemeMode(savedColorMode)
+		unison.ThemeChanged()
+		unison.RebuildDynamicColors()
+	}()
+	return unison.CreatePDF(stream, &unison.PDFMetaData{
+		Title:           i18n.Text("NPC Reference Cards"),
+		Author:          toolbox.CurrentUserName(),
+		Subject:         i18n.Text("NPC Reference Cards"),
+		Keywords:        "GCS NPC Reference Cards",
+		Creator:         "GCS",
+		RasterDPI:       300,
+		EncodingQuality: 101,
+	}, p)
+}
+
+// ExportNPCCards exports index-card-sized reference cards for characters as a PDF, laid out as many per page as will
+// fit, with dashed cut lines around each. The characters in all open sheets are used. If no sheets are open, the user
+// is asked to choose sheet or campaign files.
+func ExportNPCCards() {
+	var entities []*gurps.Entity
+	for _, s := range openSheets() {
+		entities = append(entities, s.Entity())
+	}
+	if len(entities) == 0 {
+		if entities = charactersFromFiles(); len(entities) == 0 {
+			return
+		}
+	}
+	cards := make([]*gurps.NPCCard, 0, len(entities))
+	for _, e := range entities {
+		cards = append(cards, gurps.NewNPCCard(e))
+	}
+	dialog := unison.NewSaveDialog()
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	dialog.SetAllowedExtensions("pdf")
+	dialog.SetInitialFileName(i18n.Text("NPC Reference Cards"))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "pdf", false); ok {
+			global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := newNPCCardExporter(cards).exportAsPDFFile(filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export NPC reference cards!"), err)
+			}
+		}
+	}
+}
@@ -11,16 +11,28 @@ package ux
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/richardwilkes/gcs/v5/imgutil"
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/toolbox/cmdline"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/filtermode"
+	"github.com/richardwilkes/unison/enums/mipmapmode"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 )
 
+const (
+	watermarkImageDimension = 1200
+	watermarkFontSize       = 72
+	watermarkPageFraction   = 0.6
+)
+
 // Page holds a logical page worth of content.
 type Page struct {
 	unison.Panel
@@ -45,6 +57,7 @@ func NewPage(entity *gurps.Entity) *Page {
 	p.SetBorder(unison.NewEmptyBorder(p.lastInsets))
 	p.SetLayout(p)
 	p.DrawCallback = p.drawSelf
+	p.DrawOverCallback = p.drawWatermark
 	return p
 }
 
@@ -90,9 +103,27 @@ func (p *Page) insets() unison.Insets {
 	}
 	height := fonts.PageFooterSecondary.LineHeight()
 	insets.Bottom += xmath.Ceil(max(fonts.PageFooterPrimary.LineHeight(), height) + height)
+	if decorations := p.exportDecorations(); decorations != nil {
+		if decorations.Header != "" {
+			insets.Top += xmath.Ceil(height)
+		}
+		if decorations.Footer != "" {
+			insets.Bottom += xmath.Ceil(height)
+		}
+	}
 	return insets
 }
 
+// exportDecorations returns the decorations to draw on this page, or nil if the page isn't being exported.
+func (p *Page) exportDecorations() *gurps.ExportDecorations {
+	if parent := p.Parent(); parent != nil {
+		if _, ok := parent.Self.(*pageExporter); ok {
+			return gurps.SheetSettingsFor(p.entity).ExportDecorations
+		}
+	}
+	return nil
+}
+
 func (p *Page) drawSelf(gc *unison.Canvas, _ unison.Rect) {
 	insets := p.insets()
 	_, prefSize, _ := p.LayoutSizes(nil, unison.Size{})
@@ -114,6 +145,23 @@ func (p *Page) drawSelf(gc *unison.Canvas, _ unison.Rect) {
 		OnBackgroundInk: unison.ThemeOnSurface,
 	}
 
+	if decorations := p.exportDecorations(); decorations != nil {
+		now := time.Now()
+		pageCount := len(parent.Children())
+		if decorations.Header != "" {
+			header := unison.NewText(decorations.Expand(decorations.Header, p.entity, pageNumber, pageCount, now),
+				secondaryDecorations)
+			header.Draw(gc, r.X+(r.Width-header.Width())/2,
+				gurps.SheetSettingsFor(p.entity).Page.TopMargin.Pixels()+header.Baseline())
+		}
+		if decorations.Footer != "" {
+			footer := unison.NewText(decorations.Expand(decorations.Footer, p.entity, pageNumber, pageCount, now),
+				secondaryDecorations)
+			footer.Draw(gc, r.X+(r.Width-footer.Width())/2, r.Y+footer.Baseline())
+			r.Y += xmath.Ceil(fonts.PageFooterSecondary.LineHeight())
+		}
+	}
+
 	var title string
 	if gurps.SheetSettingsFor(p.entity).UseTitleInFooter {
 		title = p.entity.Profile.Title
@@ -144,3 +192,50 @@ func (p *Page) drawSelf(gc *unison.Canvas, _ unison.Rect) {
 	center.Draw(gc, r.X+(r.Width-center.Width())/2, y)
 	right.Draw(gc, r.Right()-right.Width(), y)
 }
+
+// watermarkImage returns the watermark image referenced by the decorations, or nil if there is none or it can't be read.
+func watermarkImage(decorations *gurps.ExportDecorations) *unison.Image {
+	p := decorations.WatermarkImagePathOnDisk()
+	if p == "" {
+		return nil
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil
+	}
+	return imgutil.ThumbnailNow(data, watermarkImageDimension)
+}
+
+// drawWatermark draws the export watermark over the page content.
+func (p *Page) drawWatermark(gc *unison.Canvas, _ unison.Rect) {
+	decorations := p.exportDecorations()
+	if decorations == nil || !decorations.HasWatermark() {
+		return
+	}
+	_, prefSize, _ := p.LayoutSizes(nil, unison.Size{})
+	r := unison.Rect{Size: prefSize}
+	opacity := float32(decorations.WatermarkOpacity) / 100
+	if img := watermarkImage(decorations); img != nil {
+		size := img.LogicalSize()
+		size = size.Mul(min(r.Width*watermarkPageFraction/size.Width, r.Height*watermarkPageFraction/size.Height))
+		paint := unison.NewPaint()
+		paint.SetColor(unison.Black.SetAlphaIntensity(opacity))
+		gc.DrawImageInRect(img, unison.NewRect(r.X+(r.Width-size.Width)/2, r.Y+(r.Height-size.Height)/2, size.Width,
+			size.Height), &unison.SamplingOptions{
+			FilterMode: filtermode.Linear,
+			MipMapMode: mipmapmode.Linear,
+		}, paint)
+	}
+	if text := strings.TrimSpace(decorations.WatermarkText); text != "" {
+		decoration := &unison.TextDecoration{
+			Font:            fonts.PageFooterPrimary.Face().Font(watermarkFontSize),
+			OnBackgroundInk: unison.Black.SetAlphaIntensity(opacity),
+		}
+		watermark := unison.NewText(text, decoration)
+		if maxWidth := r.Width * watermarkPageFraction; watermark.Width() > maxWidth {
+			decoration.Font = fonts.PageFooterPrimary.Face().Font(watermarkFontSize * maxWidth / watermark.Width())
+			watermark = unison.NewText(text, decoration)
+		}
+		watermark.Draw(gc, r.X+(r.Width-watermark.Width())/2, r.Y+(r.Height-watermark.Height())/2+watermark.Baseline())
+	}
+}
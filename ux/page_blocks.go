@@ -85,6 +85,7 @@ func createPageSecondRow(entity *gurps.Entity, targetMgr *TargetMgr) *unison.Pan
 	})
 	endWrapper.AddChild(NewEncumbrancePanel(entity))
 	endWrapper.AddChild(NewLiftingPanel(entity))
+	endWrapper.AddChild(NewSensesPanel(entity))
 
 	p.AddChild(NewPrimaryAttrPanel(entity, targetMgr))
 	p.AddChild(NewSecondaryAttrPanel(entity, targetMgr))
@@ -95,3 +96,66 @@ func createPageSecondRow(entity *gurps.Entity, targetMgr *TargetMgr) *unison.Pan
 
 	return p
 }
+
+// createPageCustomTopBlock creates the first page with a header made from the given rows of header keys, as described
+// by a sheet layout.
+func createPageCustomTopBlock(entity *gurps.Entity, targetMgr *TargetMgr, rows [][]string) *Page {
+	page := NewPage(entity)
+	for _, row := range rows {
+		p := unison.NewPanel()
+		p.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			VAlign: align.Fill,
+			HGrab:  true,
+		})
+		for _, key := range row {
+			if block := createPageHeaderBlock(entity, targetMgr, key); block != nil {
+				p.AddChild(block)
+			}
+		}
+		if count := len(p.Children()); count != 0 {
+			p.SetLayout(&unison.FlexLayout{
+				Columns:  count,
+				HSpacing: 1,
+				VSpacing: 1,
+				HAlign:   align.Fill,
+				VAlign:   align.Fill,
+			})
+			page.AddChild(p)
+		}
+	}
+	return page
+}
+
+func createPageHeaderBlock(entity *gurps.Entity, targetMgr *TargetMgr, key string) unison.Paneler {
+	switch key {
+	case gurps.HeaderPortraitKey:
+		return NewPortraitPanel(entity)
+	case gurps.HeaderIdentityKey:
+		return NewIdentityPanel(entity, targetMgr)
+	case gurps.HeaderMiscKey:
+		return NewMiscPanel(entity, targetMgr)
+	case gurps.HeaderPointsKey:
+		return NewPointsPanel(entity, targetMgr)
+	case gurps.HeaderDescriptionKey:
+		return NewDescriptionPanel(entity, targetMgr)
+	case gurps.HeaderPrimaryAttributesKey:
+		return NewPrimaryAttrPanel(entity, targetMgr)
+	case gurps.HeaderSecondaryAttributesKey:
+		return NewSecondaryAttrPanel(entity, targetMgr)
+	case gurps.HeaderPointPoolsKey:
+		return NewPointPoolsPanel(entity, targetMgr)
+	case gurps.HeaderBodyKey:
+		return NewBodyPanel(entity, targetMgr)
+	case gurps.HeaderEncumbranceKey:
+		return NewEncumbrancePanel(entity)
+	case gurps.HeaderLiftingKey:
+		return NewLiftingPanel(entity)
+	case gurps.HeaderSensesKey:
+		return NewSensesPanel(entity)
+	case gurps.HeaderDamageKey:
+		return NewDamagePanel(entity)
+	default:
+		return nil
+	}
+}
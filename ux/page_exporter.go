@@ -16,6 +16,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/errs"
@@ -28,6 +29,24 @@ var _ Rebuildable = &pageExporter{}
 
 const pageKey = "pageKey"
 
+// ImageExportOptions holds the options used when exporting the pages of a sheet as images.
+type ImageExportOptions struct {
+	// Resolution is the number of pixels per inch to render at.
+	Resolution int
+	// Combined causes the pages to be stacked into a single vertical strip, rather than written as one numbered file
+	// per page.
+	Combined bool
+}
+
+// DefaultImageExportOptions returns the image export options from the general settings.
+func DefaultImageExportOptions() ImageExportOptions {
+	general := gurps.GlobalSettings().General
+	return ImageExportOptions{
+		Resolution: general.ImageResolution,
+		Combined:   general.CombineImagePages,
+	}
+}
+
 type pageExporter struct {
 	unison.Panel
 	entity      *gurps.Entity
@@ -37,11 +56,22 @@ type pageExporter struct {
 }
 
 func newPageExporter(entity *gurps.Entity) *pageExporter {
+	return newPageExporterWithHeader(entity, nil)
+}
+
+// newPageExporterWithHeader creates a page exporter whose first page starts with the given rows of header keys. If
+// headerRows is empty, the standard header is used.
+func newPageExporterWithHeader(entity *gurps.Entity, headerRows [][]string) *pageExporter {
 	p := &pageExporter{entity: entity}
 	p.targetMgr = NewTargetMgr(p)
 	pageSize := p.PageSize()
 	r := unison.Rect{Size: pageSize}
-	page, _ := createPageTopBlock(entity, p.targetMgr)
+	var page *Page
+	if len(headerRows) == 0 {
+		page, _ = createPageTopBlock(entity, p.targetMgr)
+	} else {
+		page = createPageCustomTopBlock(entity, p.targetMgr, headerRows)
+	}
 	p.AddChild(page)
 	p.pages = append(p.pages, page)
 	for _, col := range entity.SheetSettings.BlockLayout.ByRow() {
@@ -57,7 +87,9 @@ func newPageExporter(entity *gurps.Entity) *pageExporter {
 				case gurps.BlockLayoutReactionsKey:
 					addRowPanel(rowPanel, NewReactionsPageList(entity), gurps.BlockLayoutReactionsKey, startAt)
 				case gurps.BlockLayoutConditionalModifiersKey:
-					addRowPanel(rowPanel, NewConditionalModifiersPageList(entity), gurps.BlockLayoutConditionalModifiersKey, startAt)
+					if !entity.SheetSettings.SimplifiedDisplay {
+						addRowPanel(rowPanel, NewConditionalModifiersPageList(entity), gurps.BlockLayoutConditionalModifiersKey, startAt)
+					}
 				case gurps.BlockLayoutMeleeKey:
 					addRowPanel(rowPanel, NewMeleeWeaponsPageList(entity), gurps.BlockLayoutMeleeKey, startAt)
 				case gurps.BlockLayoutRangedKey:
@@ -152,6 +184,20 @@ func newPageExporter(entity *gurps.Entity) *pageExporter {
 			}
 		}
 	}
+	if companions := entity.CompanionsForExport(); len(companions) != 0 {
+		panel := NewCompanionsPanel(entity, p.targetMgr, companions)
+		page.AddChild(panel)
+		page.SetFrameRect(r)
+		page.MarkForLayoutRecursively()
+		page.ValidateLayout()
+		if _, pref, _ := page.Sizes(unison.Size{Width: r.Width}); pref.Height > pageSize.Height {
+			page.RemoveChild(panel)
+			page = NewPage(entity)
+			p.AddChild(page)
+			p.pages = append(p.pages, page)
+			page.AddChild(panel)
+		}
+	}
 	for _, page = range p.pages {
 		page.Force = true
 		page.SetFrameRect(r)
@@ -244,29 +290,33 @@ func (p *pageExporter) exportAsPDF(stream unison.Stream) error {
 	}, p)
 }
 
-func (p *pageExporter) exportAsPNGs(filePathBase string) error {
-	return p.exportAsImages(filePathBase, ".png", func(img *unison.Image) ([]byte, error) {
+func (p *pageExporter) exportAsPNGs(filePathBase string, opts ImageExportOptions) error {
+	return p.exportAsImages(filePathBase, ".png", opts, func(img *unison.Image) ([]byte, error) {
 		return img.ToPNG(6)
 	})
 }
 
-func (p *pageExporter) exportAsWEBPs(filePathBase string) error {
-	return p.exportAsImages(filePathBase, ".webp", func(img *unison.Image) ([]byte, error) {
+func (p *pageExporter) exportAsWEBPs(filePathBase string, opts ImageExportOptions) error {
+	return p.exportAsImages(filePathBase, ".webp", opts, func(img *unison.Image) ([]byte, error) {
 		return img.ToWebp(80, true)
 	})
 }
 
-func (p *pageExporter) exportAsJPEGs(filePathBase string) error {
-	return p.exportAsImages(filePathBase, ".jpeg", func(img *unison.Image) ([]byte, error) {
+func (p *pageExporter) exportAsJPEGs(filePathBase string, opts ImageExportOptions) error {
+	return p.exportAsImages(filePathBase, ".jpeg", opts, func(img *unison.Image) ([]byte, error) {
 		return img.ToJPEG(80)
 	})
 }
 
-func (p *pageExporter) exportAsImages(filePathBase, extension string, f func(img *unison.Image) ([]byte, error)) error {
+func (p *pageExporter) exportAsImages(filePathBase, extension string, opts ImageExportOptions, f func(img *unison.Image) ([]byte, error)) error {
 	filePathBase = strings.TrimSuffix(filePathBase, extension)
 	savedColorMode := p.saveTheme()
 	defer p.restoreTheme(savedColorMode)
-	resolution := gurps.GlobalSettings().General.ImageResolution
+	resolution := fxp.ResetIfOutOfRange(opts.Resolution, gurps.ImageResolutionMin, gurps.ImageResolutionMax,
+		gurps.ImageResolutionDef)
+	if opts.Combined {
+		return p.exportAsImageStrip(filePathBase+extension, resolution, f)
+	}
 	pageNumber := 1
 	for p.HasPage(pageNumber) {
 		size := p.PageSize()
@@ -292,6 +342,33 @@ func (p *pageExporter) exportAsImages(filePathBase, extension string, f func(img
 	return nil
 }
 
+// exportAsImageStrip draws all of the pages, one above the other, into a single image.
+func (p *pageExporter) exportAsImageStrip(filePath string, resolution int, f func(img *unison.Image) ([]byte, error)) error {
+	size := p.PageSize()
+	pageHeight := int(size.Height)
+	count := len(p.pages)
+	var drawErr error
+	img, err := unison.NewImageFromDrawing(int(size.Width), pageHeight*count, resolution, func(c *unison.Canvas) {
+		for pageNumber := 1; pageNumber <= count && drawErr == nil; pageNumber++ {
+			c.Save()
+			c.Translate(0, float32(pageHeight*(pageNumber-1)))
+			drawErr = p.DrawPage(c, pageNumber)
+			c.Restore()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if drawErr != nil {
+		return drawErr
+	}
+	var data []byte
+	if data, err = f(img); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0o640)
+}
+
 func (p *pageExporter) saveTheme() thememode.Enum {
 	savedColorMode := unison.CurrentThemeMode()
 	unison.SetThemeMode(thememode.Light)
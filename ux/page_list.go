@@ -13,10 +13,12 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/skillsort"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
 )
 
 var (
@@ -38,6 +40,8 @@ func NewTraitsPageList(owner Rebuildable, provider gurps.ListProvider) *PageList
 	p.installToggleDisabledHandler(owner)
 	p.installIncrementLevelHandler(owner)
 	p.installDecrementLevelHandler(owner)
+	installApplyFavoriteTraitModifierHandler(p.AsPanel(), owner, p.Table)
+	p.installExportCSVHandler(owner, gurps.TraitsHeaderData)
 	return p
 }
 
@@ -50,6 +54,12 @@ func NewCarriedEquipmentPageList(owner Rebuildable, provider gurps.ListProvider)
 	p.installContainerConversionHandlers(owner)
 	p.installMoveToOtherEquipmentHandler(owner)
 	installEquipmentLevelHandlers(p, owner)
+	installBundleIntoKitHandler(p.AsPanel(), p.Table)
+	installApplyFavoriteEquipmentModifierHandler(p.AsPanel(), owner, p.Table)
+	installApplyQualityPresetHandler(p.AsPanel(), owner, p.Table)
+	p.installExportCSVHandler(owner, func(columnID int) gurps.HeaderData {
+		return gurps.EquipmentHeaderData(columnID, nil, true, false)
+	})
 	return p
 }
 
@@ -61,6 +71,12 @@ func NewOtherEquipmentPageList(owner Rebuildable, provider gurps.ListProvider) *
 	p.installContainerConversionHandlers(owner)
 	p.installMoveToCarriedEquipmentHandler(owner)
 	installEquipmentLevelHandlers(p, owner)
+	installBundleIntoKitHandler(p.AsPanel(), p.Table)
+	installApplyFavoriteEquipmentModifierHandler(p.AsPanel(), owner, p.Table)
+	installApplyQualityPresetHandler(p.AsPanel(), owner, p.Table)
+	p.installExportCSVHandler(owner, func(columnID int) gurps.HeaderData {
+		return gurps.EquipmentHeaderData(columnID, nil, false, false)
+	})
 	return p
 }
 
@@ -73,6 +89,8 @@ func NewSkillsPageList(owner Rebuildable, provider gurps.ListProvider) *PageList
 	p.installDecrementSkillHandler(owner)
 	p.installIncrementTechLevelHandler(owner)
 	p.installDecrementTechLevelHandler(owner)
+	p.installExportCSVHandler(owner, gurps.SkillsHeaderData)
+	p.installOrderMenu(owner, func(s *gurps.SheetSettings) *skillsort.Order { return &s.SkillOrder })
 	return p
 }
 
@@ -83,6 +101,9 @@ func NewSpellsPageList(owner Rebuildable, provider gurps.SpellListProvider) *Pag
 	p.installDecrementPointsHandler(owner)
 	p.installIncrementSkillHandler(owner)
 	p.installDecrementSkillHandler(owner)
+	installCastSpellHandler(p, owner)
+	p.installExportCSVHandler(owner, gurps.SpellsHeaderData)
+	p.installOrderMenu(owner, func(s *gurps.SheetSettings) *skillsort.Order { return &s.SpellOrder })
 	return p
 }
 
@@ -90,6 +111,7 @@ func NewSpellsPageList(owner Rebuildable, provider gurps.SpellListProvider) *Pag
 func NewNotesPageList(owner Rebuildable, provider gurps.ListProvider) *PageList[*gurps.Note] {
 	p := newPageList(owner, NewNotesProvider(provider, true))
 	p.installContainerConversionHandlers(owner)
+	p.installExportCSVHandler(owner, gurps.NotesHeaderData)
 	return p
 }
 
@@ -132,6 +154,8 @@ func newPageList[T gurps.NodeTypes](owner Rebuildable, provider TableProvider[T]
 	p.AddChild(p.Table)
 	if owner != nil {
 		InstallTableDropSupport(p.Table, p.provider)
+		p.installRowPinningHandlers(owner)
+		p.installCopyListToSheetHandler(owner)
 		p.InstallCmdHandlers(OpenEditorItemID,
 			func(_ any) bool { return p.Table.HasSelection() },
 			func(_ any) { p.provider.OpenEditor(owner, p.Table) })
@@ -157,6 +181,48 @@ func newPageList[T gurps.NodeTypes](owner Rebuildable, provider TableProvider[T]
 	return p
 }
 
+// installOrderMenu adds a menu to the column headers, shown by right-clicking on them, that chooses the order the rows
+// are listed in. The choice is kept in the sheet settings.
+func (p *PageList[T]) installOrderMenu(owner Rebuildable, order func(s *gurps.SheetSettings) *skillsort.Order) {
+	sheet, ok := owner.AsPanel().Self.(*Sheet)
+	if !ok {
+		return
+	}
+	previous := p.tableHeader.MouseDownCallback
+	p.tableHeader.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if button != unison.ButtonRight || clickCount != 1 {
+			if previous != nil {
+				return previous(where, button, clickCount, mod)
+			}
+			return false
+		}
+		f := unison.DefaultMenuFactory()
+		cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+		current := *order(sheet.entity.SheetSettings)
+		for i, one := range skillsort.Orders {
+			item := f.NewItem(unison.PopupMenuTemporaryBaseID+i+1, one.String(), unison.KeyBinding{}, nil,
+				func(_ unison.MenuItem) {
+					if *order(sheet.entity.SheetSettings) != one {
+						*order(sheet.entity.SheetSettings) = one
+						sheet.SheetSettingsUpdated(sheet.entity, false)
+					}
+				})
+			item.SetCheckState(check.FromBool(one == current))
+			cm.InsertItem(-1, item)
+		}
+		p.tableHeader.FlushDrawing()
+		cm.Popup(unison.Rect{
+			Point: p.tableHeader.PointToRoot(where),
+			Size: unison.Size{
+				Width:  1,
+				Height: 1,
+			},
+		}, 0)
+		cm.Dispose()
+		return true
+	}
+}
+
 func (p *PageList[T]) needReconstruction() bool {
 	if p == nil {
 		return true
@@ -302,6 +368,12 @@ func installEquipmentLevelHandlers(p *PageList[*gurps.Equipment], owner Rebuilda
 		func(_ any) { adjustEquipmentLevel(owner, p.Table, -fxp.One) })
 }
 
+func installCastSpellHandler(p *PageList[*gurps.Spell], owner Rebuildable) {
+	p.InstallCmdHandlers(CastSpellItemID,
+		func(_ any) bool { return canCastSpell(p.Table) },
+		func(_ any) { castSpell(owner, p.Table) })
+}
+
 func (p *PageList[T]) installContainerConversionHandlers(owner Rebuildable) {
 	if t, ok := (any(p.Table)).(*unison.Table[*Node[*gurps.Equipment]]); ok {
 		InstallContainerConversionHandlers(p, owner, t)
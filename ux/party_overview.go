@@ -0,0 +1,310 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/weight"
+)
+
+var (
+	_ unison.Dockable = &PartyOverviewDockable{}
+	_ GroupedCloser   = &PartyOverviewDockable{}
+)
+
+// PartyOverviewDockable shows the player characters of a campaign side by side, so that the GM can look over the whole
+// group at once.
+type PartyOverviewDockable struct {
+	unison.Panel
+	campaign *Campaign
+	content  *unison.Panel
+	scroll   *unison.ScrollPanel
+	scale    int
+}
+
+type partyOverviewRow struct {
+	title string
+	value func(s *gurps.GMSummary) []string
+}
+
+type partyMember struct {
+	path    string
+	summary *gurps.GMSummary
+	err     error
+}
+
+func partyOverviewRows() []*partyOverviewRow {
+	single := func(f func(s *gurps.GMSummary) string) func(s *gurps.GMSummary) []string {
+		return func(s *gurps.GMSummary) []string {
+			if value := f(s); value != "" {
+				return []string{value}
+			}
+			return nil
+		}
+	}
+	return []*partyOverviewRow{
+		{title: i18n.Text("Player"), value: single(func(s *gurps.GMSummary) string { return s.Player })},
+		{title: i18n.Text("HP"), value: single(func(s *gurps.GMSummary) string { return s.HP })},
+		{title: i18n.Text("FP"), value: single(func(s *gurps.GMSummary) string { return s.FP })},
+		{title: i18n.Text("Per"), value: single(func(s *gurps.GMSummary) string { return s.Perception })},
+		{title: i18n.Text("Will"), value: single(func(s *gurps.GMSummary) string { return s.Will })},
+		{title: i18n.Text("Move"), value: single(func(s *gurps.GMSummary) string { return s.Move })},
+		{title: i18n.Text("Key Skills"), value: func(s *gurps.GMSummary) []string { return s.KeySkills }},
+		{title: i18n.Text("Languages"), value: func(s *gurps.GMSummary) []string { return s.Languages }},
+	}
+}
+
+// DisplayPartyOverview displays the party overview for the given Campaign.
+func DisplayPartyOverview(campaign *Campaign) {
+	if Activate(func(d unison.Dockable) bool {
+		if p, ok := d.AsPanel().Self.(*PartyOverviewDockable); ok {
+			return p.campaign == campaign
+		}
+		return false
+	}) {
+		UpdatePartyOverview(campaign)
+		return
+	}
+	p := &PartyOverviewDockable{
+		campaign: campaign,
+		scale:    gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{Columns: 1})
+	p.content = unison.NewPanel()
+	p.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	p.scroll = unison.NewScrollPanel()
+	p.scroll.SetContent(p.content, behavior.Unmodified, behavior.Unmodified)
+	p.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	p.AddChild(p.createToolbar())
+	p.AddChild(p.scroll)
+	p.refresh()
+	PlaceInDock(p, dgroup.Campaigns, false)
+}
+
+// UpdatePartyOverview refreshes the party overview for the given Campaign, if it is being displayed.
+func UpdatePartyOverview(campaign *Campaign) {
+	for _, one := range AllDockables() {
+		if p, ok := one.(*PartyOverviewDockable); ok && p.campaign == campaign {
+			p.refresh()
+			break
+		}
+	}
+}
+
+// updatePartyOverviewsForSheet refreshes any party overview the sheet appears in.
+func updatePartyOverviewsForSheet(sheet *Sheet) {
+	sheetPath := filepath.Clean(sheet.BackingFilePath())
+	for _, one := range AllDockables() {
+		if p, ok := one.(*PartyOverviewDockable); ok && slices.Contains(p.campaign.campaign.PartySheetPaths(), sheetPath) {
+			p.refresh()
+		}
+	}
+}
+
+func (p *PartyOverviewDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return p.scale },
+			func(scale int) { p.scale = scale },
+			nil,
+			false,
+			p.scroll,
+		),
+	)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Reload the sheets that aren't open"))
+	refreshButton.ClickCallback = p.refresh
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+// members returns the party's characters. Sheets that are open are used as they currently are, while the rest are
+// loaded from disk.
+func (p *PartyOverviewDockable) members() []*partyMember {
+	open := make(map[string]*Sheet)
+	for _, one := range AllDockables() {
+		if sheet, ok := one.(*Sheet); ok {
+			open[filepath.Clean(sheet.BackingFilePath())] = sheet
+		}
+	}
+	paths := p.campaign.campaign.PartySheetPaths()
+	list := make([]*partyMember, 0, len(paths))
+	for _, one := range paths {
+		member := &partyMember{path: one}
+		if sheet, ok := open[one]; ok {
+			member.summary = gurps.NewGMSummary(sheet.Entity())
+		} else {
+			var entity *gurps.Entity
+			if entity, member.err = gurps.NewEntityFromFile(os.DirFS(filepath.Dir(one)), filepath.Base(one)); member.err == nil {
+				member.summary = gurps.NewGMSummary(entity)
+			} else {
+				errs.Log(member.err, "path", one)
+			}
+		}
+		list = append(list, member)
+	}
+	return list
+}
+
+func (p *PartyOverviewDockable) refresh() {
+	p.content.RemoveAllChildren()
+	members := p.members()
+	p.content.SetLayout(&unison.FlexLayout{
+		Columns:  len(members) + 1,
+		HSpacing: unison.StdHSpacing * 3,
+		VSpacing: unison.StdVSpacing,
+	})
+	if len(members) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("The campaign has no player character sheets"))
+		p.content.AddChild(label)
+	} else {
+		p.content.AddChild(unison.NewPanel())
+		for _, member := range members {
+			name := fs.BaseName(member.path)
+			if member.summary != nil && member.summary.Name != "" {
+				name = member.summary.Name
+			}
+			p.addCell(member, []string{name}, true)
+		}
+		for _, row := range partyOverviewRows() {
+			p.addRowTitle(row.title)
+			for _, member := range members {
+				lines := []string{i18n.Text("Unable to load")}
+				if member.summary != nil {
+					lines = row.value(member.summary)
+				}
+				p.addCell(member, lines, false)
+			}
+		}
+	}
+	p.content.MarkForLayoutRecursively()
+	p.MarkForRedraw()
+}
+
+func (p *PartyOverviewDockable) addRowTitle(title string) {
+	label := NewFieldLeadingLabel(title, false)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.End,
+		VAlign: align.Start,
+	})
+	p.content.AddChild(label)
+}
+
+// addCell adds a cell showing one line per entry. Clicking the cell opens the member's sheet.
+func (p *PartyOverviewDockable) addCell(member *partyMember, lines []string, bold bool) {
+	cell := unison.NewPanel()
+	cell.SetLayout(&unison.FlexLayout{Columns: 1})
+	cell.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Start,
+	})
+	if len(lines) == 0 {
+		lines = []string{"–"}
+	}
+	for _, line := range lines {
+		label := unison.NewLabel()
+		if bold {
+			desc := label.Font.Descriptor()
+			desc.Weight = weight.Bold
+			label.Font = desc.Font()
+		}
+		label.SetTitle(line)
+		cell.AddChild(label)
+	}
+	cell.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Click to open %s"), member.path))
+	cell.MouseDownCallback = func(_ unison.Point, button, _ int, _ unison.Modifiers) bool {
+		if button == unison.ButtonLeft {
+			p.campaign.openFile(member.path)
+		}
+		return true
+	}
+	p.content.AddChild(cell)
+}
+
+// TitleIcon implements unison.Dockable
+func (p *PartyOverviewDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSCampaign,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (p *PartyOverviewDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Party Overview for %s"), p.campaign.Title())
+}
+
+func (p *PartyOverviewDockable) String() string {
+	return p.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (p *PartyOverviewDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (p *PartyOverviewDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (p *PartyOverviewDockable) CloseWithGroup(other unison.Paneler) bool {
+	return p.campaign != nil && p.campaign == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (p *PartyOverviewDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(p)
+}
+
+// AttemptClose implements GroupedCloser
+func (p *PartyOverviewDockable) AttemptClose() bool {
+	if !CloseGroup(p) {
+		return false
+	}
+	return AttemptCloseForDockable(p)
+}
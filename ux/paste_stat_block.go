@@ -0,0 +1,126 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+const addStatBlockToSheetResponse = unison.ModalResponseUserBase
+
+type statBlockRow[T any] interface {
+	Clone(from gurps.LibraryFile, owner gurps.DataOwner, parent T, preserveID bool) T
+}
+
+// pasteStatBlock asks for the text of a stat block, prefilled from the clipboard, and either opens a new, unsaved
+// character sheet created from it or adds its rows to the active sheet.
+func pasteStatBlock() {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("Stat block in the standard published format:"))
+	panel.AddChild(label)
+	field := unison.NewMultiLineField()
+	field.SetWrap(true)
+	field.SetText(unison.GlobalClipboard.GetText())
+	field.MinimumTextWidth = 500
+	field.Tooltip = newWrappedTooltip(i18n.Text("Anything that can't be recognized is kept in the notes"))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.Size{Height: 300},
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	panel.AddChild(field)
+	buttons := []*unison.DialogButtonInfo{unison.NewCancelButtonInfo()}
+	sheet := ActiveSheet()
+	if sheet != nil {
+		buttons = append(buttons, &unison.DialogButtonInfo{
+			Title:        i18n.Text("Add to Sheet"),
+			ResponseCode: addStatBlockToSheetResponse,
+		})
+	}
+	buttons = append(buttons, unison.NewOKButtonInfoWithTitle(i18n.Text("New Sheet")))
+	dialog, err := unison.NewDialog(nil, nil, panel, buttons)
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	response := dialog.RunModal()
+	if response != unison.ModalResponseOK && response != addStatBlockToSheetResponse {
+		return
+	}
+	text := field.Text()
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	e := gurps.ParseStatBlock(text)
+	if response == addStatBlockToSheetResponse {
+		sheet.addStatBlockRows(e)
+		return
+	}
+	name := e.Profile.Name
+	if name == "" {
+		name = i18n.Text("Stat Block")
+	}
+	DisplayNewDockable(NewSheet(name+gurps.SheetExt, e))
+}
+
+// addStatBlockRows adds the traits, skills, spells, equipment and notes parsed from a stat block to the sheet.
+func (s *Sheet) addStatBlockRows(from *gurps.Entity) {
+	var undo *unison.UndoEdit[*sheetTablesUndoData]
+	mgr := unison.UndoManagerFor(s)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*sheetTablesUndoData]{
+			ID:         unison.NextUndoID(),
+			EditName:   pasteStatBlockAction.Title,
+			UndoFunc:   func(e *unison.UndoEdit[*sheetTablesUndoData]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*sheetTablesUndoData]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*sheetTablesUndoData], _ unison.Undoable) bool { return false },
+			BeforeData: newSheetTablesUndoData(s),
+		}
+	}
+	s.entity.Traits = append(s.entity.Traits, cloneStatBlockRows(s.entity, from.Traits)...)
+	s.entity.Skills = append(s.entity.Skills, cloneStatBlockRows(s.entity, from.Skills)...)
+	s.entity.Spells = append(s.entity.Spells, cloneStatBlockRows(s.entity, from.Spells)...)
+	s.entity.CarriedEquipment = append(s.entity.CarriedEquipment, cloneStatBlockRows(s.entity, from.CarriedEquipment)...)
+	s.entity.Notes = append(s.entity.Notes, cloneStatBlockRows(s.entity, from.Notes)...)
+	s.Traits.Table.SyncToModel()
+	s.Skills.Table.SyncToModel()
+	s.Spells.Table.SyncToModel()
+	s.CarriedEquipment.Table.SyncToModel()
+	s.Notes.Table.SyncToModel()
+	if mgr != nil && undo != nil {
+		undo.AfterData = newSheetTablesUndoData(s)
+		mgr.Add(undo)
+	}
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+func cloneStatBlockRows[T statBlockRow[T]](owner gurps.DataOwner, list []T) []T {
+	var parent T
+	result := make([]T, 0, len(list))
+	for _, one := range list {
+		result = append(result, one.Clone(gurps.LibraryFile{}, owner, parent, false))
+	}
+	return result
+}
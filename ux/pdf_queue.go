@@ -11,6 +11,7 @@ package ux
 
 import (
 	"runtime"
+	"sync"
 )
 
 type pdfQueueParams struct {
@@ -27,6 +28,9 @@ type pdfQueueData struct {
 
 var pdfQueue = newPDFQueue()
 
+// pdfRenderLock must be held while the underlying C library is rendering or searching a page.
+var pdfRenderLock sync.Mutex
+
 func newPDFQueue() *pdfQueueData {
 	q := &pdfQueueData{
 		in:   make(chan *pdfQueueParams, runtime.NumCPU()*2),
@@ -85,6 +89,8 @@ func (q *pdfQueueData) addToBacklog(p *pdfQueueParams) {
 
 func (q *pdfQueueData) worker() {
 	for p := range q.work {
+		pdfRenderLock.Lock()
 		p.pdf.render(&p.params)
+		pdfRenderLock.Unlock()
 	}
 }
@@ -0,0 +1,56 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/colors"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func pointsDeltaColors(delta fxp.Int) (background, onBackground unison.Ink) {
+	if delta > 0 {
+		return colors.Gain, colors.OnGain
+	}
+	return colors.Loss, colors.OnLoss
+}
+
+func pointsDeltaTooltip(delta fxp.Int) string {
+	return fmt.Sprintf(i18n.Text("%s points since last save"), delta.StringWithSign())
+}
+
+func newPointsDeltaTag(delta fxp.Int, font unison.Font) *unison.Tag {
+	tag := unison.NewTag()
+	tag.BackgroundInk, tag.OnBackgroundInk = pointsDeltaColors(delta)
+	tag.Font = font
+	tag.SetTitle(delta.StringWithSign())
+	tag.Tooltip = newWrappedTooltip(pointsDeltaTooltip(delta))
+	tag.ClientData()[noInvertColorsMarker] = true
+	return tag
+}
+
+// syncPointsDeltaField colors the field to reflect the change in points since the last save, restoring the given ink
+// when there has been no change.
+func syncPointsDeltaField(f *NonEditablePageField, delta fxp.Int, tooltip string, normalInk unison.Ink) {
+	ink := normalInk
+	if delta != 0 {
+		ink, _ = pointsDeltaColors(delta)
+		tooltip += "\n\n" + pointsDeltaTooltip(delta)
+	}
+	if f.OnBackgroundInk != ink {
+		f.OnBackgroundInk = ink
+		f.Text.AdjustDecorations(func(d *unison.TextDecoration) { d.OnBackgroundInk = ink })
+		f.MarkForRedraw()
+	}
+	f.Tooltip = newWrappedTooltip(tooltip)
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &earmarksEditor{}
+	_ unison.TabCloser           = &earmarksEditor{}
+	_ ModifiableRoot             = &earmarksEditor{}
+	_ unison.UndoManagerProvider = &earmarksEditor{}
+	_ GroupedCloser              = &earmarksEditor{}
+	_ Rebuildable                = &earmarksEditor{}
+)
+
+type earmarksEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.PointsEarmark
+	current          []*gurps.PointsEarmark
+	promptForSave    bool
+}
+
+func displayEarmarksEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*earmarksEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &earmarksEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.ClonePointsEarmarkList(entity.PointsEarmarks),
+		current: gurps.ClonePointsEarmarkList(entity.PointsEarmarks),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+	if children := e.content.Children(); len(children) != 0 {
+		children[1].RequestFocus()
+	}
+}
+
+func (e *earmarksEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	helpButton := unison.NewSVGButton(svg.Help)
+	helpButton.Tooltip = newWrappedTooltip(i18n.Text("Help"))
+	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Points Record") }
+	toolbar.AddChild(helpButton)
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Earmark"))
+	addButton.ClickCallback = e.addEntry
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *earmarksEditor) initContent() {
+	for _, earmark := range e.current {
+		e.createRow(earmark, -1)
+	}
+}
+
+func (e *earmarksEditor) createRow(earmark *gurps.PointsEarmark, index int) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Earmark"))
+	deleteButton.ClickCallback = func() { e.removeEntry(earmark) }
+	e.content.AddChildAtIndex(deleteButton, index)
+	if index != -1 {
+		index++
+	}
+
+	pts := NewDecimalField(nil, "", i18n.Text("Points"),
+		func() fxp.Int { return earmark.Points },
+		func(value fxp.Int) {
+			earmark.Points = value
+			MarkModified(e.content)
+		}, 0, fxp.Max, true, false)
+	e.content.AddChildAtIndex(pts, index)
+	if index != -1 {
+		index++
+	}
+
+	reasonText := i18n.Text("Reason")
+	reason := NewStringField(nil, "", reasonText,
+		func() string { return earmark.Reason },
+		func(value string) {
+			earmark.Reason = value
+			MarkModified(e.content)
+		})
+	reason.Watermark = reasonText
+	reason.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChildAtIndex(reason, index)
+}
+
+func (e *earmarksEditor) addEntry() {
+	earmark := &gurps.PointsEarmark{}
+	e.current = slices.Insert(e.current, 0, earmark)
+	e.createRow(earmark, 0)
+	e.content.Pack()
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+	e.content.Children()[1].RequestFocus()
+}
+
+func (e *earmarksEditor) removeEntry(earmark *gurps.PointsEarmark) {
+	for i, one := range e.current {
+		if one != earmark {
+			continue
+		}
+		e.current = slices.Delete(e.current, i, i+1)
+		i *= 3
+		for j := 2; j >= 0; j-- {
+			e.content.RemoveChildAtIndex(i + j)
+		}
+		e.content.Pack()
+		MarkForLayoutWithinDockable(e.content)
+		e.content.MarkForRedraw()
+		MarkModified(e.content)
+		break
+	}
+}
+
+func (e *earmarksEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Bookmark,
+		Size: suggestedSize,
+	}
+}
+
+func (e *earmarksEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Points Earmarks for %s"), e.owner.String())
+}
+
+func (e *earmarksEditor) String() string {
+	return e.Title()
+}
+
+func (e *earmarksEditor) Tooltip() string {
+	return ""
+}
+
+func (e *earmarksEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *earmarksEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *earmarksEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *earmarksEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *earmarksEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *earmarksEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *earmarksEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *earmarksEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[[]*gurps.PointsEarmark]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Points Earmark Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.PointsEarmark]) {
+				entity.SetPointsEarmarks(edit.BeforeData)
+				owner.Rebuild(false)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.PointsEarmark]) {
+				entity.SetPointsEarmarks(edit.AfterData)
+				owner.Rebuild(false)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetPointsEarmarks(e.current)
+	owner.Rebuild(true)
+}
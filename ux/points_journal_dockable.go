@@ -0,0 +1,263 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/weight"
+)
+
+const pointsJournalColumns = 5
+
+var (
+	_ unison.Dockable            = &PointsJournalDockable{}
+	_ unison.UndoManagerProvider = &PointsJournalDockable{}
+	_ GroupedCloser              = &PointsJournalDockable{}
+)
+
+// PointsJournalDockable displays the points awarded to and spent on a character over time, along with the resulting
+// balance of unspent points.
+type PointsJournalDockable struct {
+	unison.Panel
+	sheet   *Sheet
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// DisplayPointsJournal displays the points journal for the given Sheet.
+func DisplayPointsJournal(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if j, ok := d.AsPanel().Self.(*PointsJournalDockable); ok {
+			return j.sheet == sheet
+		}
+		return false
+	}) {
+		UpdatePointsJournal(sheet)
+		return
+	}
+	j := &PointsJournalDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	j.Self = j
+	j.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	j.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	j.content = unison.NewPanel()
+	j.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	j.content.SetLayout(&unison.FlexLayout{
+		Columns:  pointsJournalColumns,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	j.scroll = unison.NewScrollPanel()
+	j.scroll.SetContent(j.content, behavior.HintedFill, behavior.Fill)
+	j.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	j.AddChild(j.createToolbar())
+	j.AddChild(j.scroll)
+	j.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	j.refresh()
+	PlaceInDock(j, dgroup.Editors, false)
+}
+
+// UpdatePointsJournal refreshes the points journal for the given Sheet, if it is being displayed.
+func UpdatePointsJournal(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if j, ok := other.(*PointsJournalDockable); ok && j.sheet == sheet {
+			j.refresh()
+			break
+		}
+	}
+}
+
+func (j *PointsJournalDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return j.scale },
+			func(scale int) { j.scale = scale },
+			nil,
+			false,
+			j.scroll,
+		),
+	)
+	awardButton := unison.NewSVGButton(svg.Edit)
+	awardButton.Tooltip = newWrappedTooltip(i18n.Text("Edit the points awarded"))
+	awardButton.ClickCallback = func() { displayPointsEditor(j.sheet, j.sheet.Entity()) }
+	toolbar.AddChild(awardButton)
+	recordButton := unison.NewSVGButton(svg.CircledAdd)
+	recordButton.Tooltip = newWrappedTooltip(i18n.Text("Record the points spent since the last expenditure"))
+	recordButton.ClickCallback = j.recordExpenditure
+	toolbar.AddChild(recordButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (j *PointsJournalDockable) recordExpenditure() {
+	entity := j.sheet.Entity()
+	before := gurps.ClonePointsExpenditureList(entity.PointsExpenditures)
+	if entity.RecordPointsExpenditure("") == nil {
+		unison.WarningDialogWithMessage(i18n.Text("No points have been spent since the last expenditure was recorded."),
+			"")
+		return
+	}
+	j.sheet.undoMgr.Add(&unison.UndoEdit[[]*gurps.PointsExpenditure]{
+		ID:       unison.NextUndoID(),
+		EditName: i18n.Text("Record Points Expenditure"),
+		UndoFunc: func(edit *unison.UndoEdit[[]*gurps.PointsExpenditure]) {
+			j.applyExpenditures(edit.BeforeData)
+		},
+		RedoFunc: func(edit *unison.UndoEdit[[]*gurps.PointsExpenditure]) {
+			j.applyExpenditures(edit.AfterData)
+		},
+		BeforeData: before,
+		AfterData:  gurps.ClonePointsExpenditureList(entity.PointsExpenditures),
+	})
+	MarkModified(j.sheet)
+	j.refresh()
+}
+
+func (j *PointsJournalDockable) applyExpenditures(list []*gurps.PointsExpenditure) {
+	j.sheet.Entity().SetPointsExpenditures(list)
+	MarkModified(j.sheet)
+	UpdatePointsJournal(j.sheet)
+}
+
+func (j *PointsJournalDockable) refresh() {
+	j.content.RemoveAllChildren()
+	j.addHeader(i18n.Text("Date"), false)
+	j.addHeader(i18n.Text("Reason"), false)
+	j.addHeader(i18n.Text("Awarded"), true)
+	j.addHeader(i18n.Text("Spent"), true)
+	j.addHeader(i18n.Text("Unspent"), true)
+	for _, one := range j.sheet.Entity().PointsJournal() {
+		j.addCell(one.When.String(), false, false)
+		j.addCell(one.Reason, false, true)
+		j.addCell(formatJournalPoints(one.Awarded), true, false)
+		j.addCell(formatJournalPoints(one.Spent), true, false)
+		j.addCell(one.Unspent.Comma(), true, false)
+	}
+	j.content.MarkForLayoutRecursively()
+	j.content.MarkForRedraw()
+}
+
+func (j *PointsJournalDockable) addHeader(title string, trailing bool) {
+	label := unison.NewLabel()
+	desc := label.Font.Descriptor()
+	desc.Weight = weight.Bold
+	label.Font = desc.Font()
+	label.SetTitle(title)
+	if trailing {
+		label.HAlign = align.End
+	}
+	label.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	j.content.AddChild(label)
+}
+
+func (j *PointsJournalDockable) addCell(text string, trailing, grab bool) {
+	label := unison.NewLabel()
+	label.SetTitle(text)
+	if trailing {
+		label.HAlign = align.End
+	}
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  grab,
+	})
+	j.content.AddChild(label)
+}
+
+func formatJournalPoints(points fxp.Int) string {
+	if points == 0 {
+		return ""
+	}
+	return points.Comma()
+}
+
+// TitleIcon implements unison.Dockable
+func (j *PointsJournalDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSheet,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (j *PointsJournalDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Points Journal for %s"), j.sheet.String())
+}
+
+func (j *PointsJournalDockable) String() string {
+	return j.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (j *PointsJournalDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (j *PointsJournalDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (j *PointsJournalDockable) CloseWithGroup(other unison.Paneler) bool {
+	return j.sheet != nil && j.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (j *PointsJournalDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(j)
+}
+
+// AttemptClose implements GroupedCloser
+func (j *PointsJournalDockable) AttemptClose() bool {
+	if !CloseGroup(j) {
+		return false
+	}
+	return AttemptCloseForDockable(j)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (j *PointsJournalDockable) UndoManager() *unison.UndoManager {
+	return j.undoMgr
+}
@@ -11,9 +11,11 @@ package ux
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -66,7 +68,7 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 
 	hdri := unison.NewPanel()
 	hdri.SetLayout(&unison.FlexLayout{
-		Columns:  2,
+		Columns:  4,
 		HSpacing: 4,
 	})
 	hdri.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
@@ -94,6 +96,28 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 		displayPointsEditor(unison.AncestorOrSelf[Rebuildable](p), p.entity)
 	}
 	hdri.AddChild(editButton)
+	earmarksButton := unison.NewSVGButton(svg.Bookmark)
+	earmarksButton.OnBackgroundInk = colors.OnHeader
+	earmarksButton.OnSelectionInk = colors.OnHeader
+	earmarksButton.Font = fonts.PageLabelPrimary
+	earmarksButton.Drawable.(*unison.DrawableSVG).Size = unison.NewSize(height, height)
+	earmarksButton.Tooltip = newWrappedTooltip(i18n.Text("Edit points earmarks"))
+	earmarksButton.ClickCallback = func() {
+		displayEarmarksEditor(unison.AncestorOrSelf[Rebuildable](p), p.entity)
+	}
+	hdri.AddChild(earmarksButton)
+	journalButton := unison.NewSVGButton(svg.Coins)
+	journalButton.OnBackgroundInk = colors.OnHeader
+	journalButton.OnSelectionInk = colors.OnHeader
+	journalButton.Font = fonts.PageLabelPrimary
+	journalButton.Drawable.(*unison.DrawableSVG).Size = unison.NewSize(height, height)
+	journalButton.Tooltip = newWrappedTooltip(i18n.Text("Show the points journal"))
+	journalButton.ClickCallback = func() {
+		if sheet := unison.AncestorOrSelf[*Sheet](p); sheet != nil {
+			DisplayPointsJournal(sheet)
+		}
+	}
+	hdri.AddChild(journalButton)
 	p.AddChild(hdr)
 
 	p.ptsList = unison.NewPanel()
@@ -122,8 +146,13 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 	})))
 	p.ptsList.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
 		drawBandedBackground(p.ptsList, gc, rect, 0, 2, func(rowIndex int, ink unison.Ink) unison.Ink {
-			if rowIndex == 0 && p.overSpent == -1 {
-				return unison.ThemeError
+			if rowIndex == 0 {
+				switch p.overSpent {
+				case -1:
+					return unison.ThemeError
+				case 2:
+					return unison.ThemeWarning
+				}
 			}
 			return ink
 		})
@@ -132,53 +161,38 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 	p.unspentField = NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		if text := p.entity.UnspentPoints().String(); text != f.Text.String() {
 			f.SetTitle(text)
-			p.adjustUnspent()
 			MarkForLayoutWithinDockable(f)
 		}
+		p.adjustUnspent()
 	})
-	p.unspentLabel = p.addPointsField(p.unspentField, i18n.Text("Unspent"), i18n.Text("Points earned but not yet spent"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Ancestry.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Ancestry"), i18n.Text("Total points spent on an ancestry package"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Attributes.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Attributes"), i18n.Text("Total points spent on attributes"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Advantages.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Advantages"), i18n.Text("Total points spent on advantages"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Disadvantages.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Disadvantages"), i18n.Text("Total points spent on disadvantages"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Quirks.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Quirks"), i18n.Text("Total points spent on quirks"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Skills.String(); text != f.Text.String() {
+	p.unspentLabel = p.addPointsField(p.unspentField, i18n.Text("Unspent"),
+		i18n.Text("Points earned but not yet spent, as tracked by the points journal"))
+	var earmarkedLabel *unison.Label
+	earmarkedField := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		if text := p.entity.EarmarkedPoints().String(); text != f.Text.String() {
 			f.SetTitle(text)
 			MarkForLayoutWithinDockable(f)
 		}
-	}), i18n.Text("Skills"), i18n.Text("Total points spent on skills"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Spells.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
+		f.Tooltip = newWrappedTooltip(p.earmarksTooltip())
+		if earmarkedLabel != nil {
+			earmarkedLabel.Tooltip = f.Tooltip
 		}
-	}), i18n.Text("Spells"), i18n.Text("Total points spent on spells"))
+	})
+	earmarkedLabel = p.addPointsField(earmarkedField, i18n.Text("Earmarked"), p.earmarksTooltip())
+	p.addBreakdownField(i18n.Text("Ancestry"), i18n.Text("Total points spent on an ancestry package"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Ancestry })
+	p.addBreakdownField(i18n.Text("Attributes"), i18n.Text("Total points spent on attributes"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Attributes })
+	p.addBreakdownField(i18n.Text("Advantages"), i18n.Text("Total points spent on advantages"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Advantages })
+	p.addBreakdownField(i18n.Text("Disadvantages"), i18n.Text("Total points spent on disadvantages"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Disadvantages })
+	p.addBreakdownField(i18n.Text("Quirks"), i18n.Text("Total points spent on quirks"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Quirks })
+	p.addBreakdownField(i18n.Text("Skills"), i18n.Text("Total points spent on skills"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Skills })
+	p.addBreakdownField(i18n.Text("Spells"), i18n.Text("Total points spent on spells"),
+		func(pb *gurps.PointsBreakdown) fxp.Int { return pb.Spells })
 	p.adjustUnspent()
 	return p
 }
@@ -192,10 +206,23 @@ func (p *PointsPanel) addPointsField(field *NonEditablePageField, title, tooltip
 	return label
 }
 
+func (p *PointsPanel) addBreakdownField(title, tooltip string, value func(pb *gurps.PointsBreakdown) fxp.Int) {
+	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		pb := p.entity.PointsBreakdown()
+		if text := value(pb).String(); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		delta := p.entity.SavedPoints().BreakdownDelta(pb)
+		syncPointsDeltaField(f, value(&delta), tooltip, unison.DefaultLabelTheme.OnBackgroundInk)
+	}), title, tooltip)
+}
+
 func (p *PointsPanel) adjustUnspent() {
 	if p.unspentLabel != nil {
 		last := p.overSpent
-		if p.entity.UnspentPoints() < 0 {
+		switch {
+		case p.entity.UnspentPoints() < 0:
 			if p.overSpent != -1 {
 				p.overSpent = -1
 				p.unspentField.OnBackgroundInk = unison.ThemeOnError
@@ -207,7 +234,19 @@ func (p *PointsPanel) adjustUnspent() {
 					OnBackgroundInk: unison.ThemeOnError,
 				})
 			}
-		} else {
+		case p.entity.SpendingIntoEarmarks():
+			if p.overSpent != 2 {
+				p.overSpent = 2
+				p.unspentField.OnBackgroundInk = unison.ThemeOnWarning
+				p.unspentField.Text.AdjustDecorations(func(decoration *unison.TextDecoration) {
+					decoration.OnBackgroundInk = unison.ThemeOnWarning
+				})
+				p.unspentLabel.Text = unison.NewSmallCapsText(i18n.Text("Unspent"), &unison.TextDecoration{
+					Font:            fonts.PageLabelPrimary,
+					OnBackgroundInk: unison.ThemeOnWarning,
+				})
+			}
+		default:
 			if p.overSpent != 1 {
 				p.overSpent = 1
 				p.unspentField.OnBackgroundInk = unison.DefaultLabelTheme.OnBackgroundInk
@@ -226,6 +265,24 @@ func (p *PointsPanel) adjustUnspent() {
 	}
 }
 
+func (p *PointsPanel) earmarksTooltip() string {
+	var buffer strings.Builder
+	buffer.WriteString(i18n.Text("Unspent points that have been set aside for a particular purpose"))
+	for _, one := range p.entity.PointsEarmarks {
+		buffer.WriteString("\n")
+		reason := one.Reason
+		if reason == "" {
+			reason = i18n.Text("Unspecified")
+		}
+		fmt.Fprintf(&buffer, i18n.Text("• %s: %s"), one.Points.String(), reason)
+	}
+	if p.entity.SpendingIntoEarmarks() {
+		buffer.WriteString("\n\n")
+		fmt.Fprintf(&buffer, i18n.Text("Spending has dipped %s points into the earmarks"), (-p.entity.AvailablePoints()).String())
+	}
+	return buffer.String()
+}
+
 // Sync the panel to the current data.
 func (p *PointsPanel) Sync() {
 	var overallTotal string
@@ -25,6 +25,9 @@ import (
 	"github.com/richardwilkes/unison/enums/paintstyle"
 )
 
+// portraitThumbnailDimension is the largest number of pixels the on-sheet portrait will be decoded to on either side.
+const portraitThumbnailDimension = 400
+
 // PortraitPanel holds the contents of the portrait block on the sheet.
 type PortraitPanel struct {
 	unison.Panel
@@ -58,7 +61,16 @@ func (p *PortraitPanel) drawSelf(gc *unison.Canvas, _ unison.Rect) {
 	r := p.ContentRect(false)
 	paint := unison.ThemeBelowSurface.Paint(gc, r, paintstyle.Fill)
 	gc.DrawRect(r, paint)
-	if img := p.entity.Profile.Portrait(); img != nil {
+	var img *unison.Image
+	if unison.Ancestor[*pageExporter](p) != nil {
+		// Exports draw synchronously and should carry the portrait at its full resolution.
+		img = p.entity.Profile.Portrait()
+	} else {
+		// Decoding happens in the background, so a large portrait doesn't stall opening or rebuilding the sheet. Until
+		// it is ready, only the background is drawn.
+		img = imgutil.Thumbnail(p.entity.Profile.PortraitData, portraitThumbnailDimension, p.MarkForRedraw)
+	}
+	if img != nil {
 		size := img.LogicalSize()
 		pr := r
 		if size != pr.Size {
@@ -0,0 +1,313 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ unison.Dockable            = &PowersDockable{}
+	_ unison.UndoManagerProvider = &PowersDockable{}
+	_ GroupedCloser              = &PowersDockable{}
+)
+
+// PowersDockable displays the powers of a character, grouped by power source and talent along with the skills linked to
+// each talent, and toggles for suppressing each power source.
+type PowersDockable struct {
+	unison.Panel
+	sheet   *Sheet
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// DisplayPowers displays the powers for the given Sheet.
+func DisplayPowers(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if p, ok := d.AsPanel().Self.(*PowersDockable); ok {
+			return p.sheet == sheet
+		}
+		return false
+	}) {
+		UpdatePowers(sheet)
+		return
+	}
+	p := &PowersDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	p.Self = p
+	p.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	p.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	p.content = unison.NewPanel()
+	p.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	p.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	p.scroll = unison.NewScrollPanel()
+	p.scroll.SetContent(p.content, behavior.HintedFill, behavior.Unmodified)
+	p.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	p.AddChild(p.createToolbar())
+	p.AddChild(p.scroll)
+	p.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	p.refresh()
+	PlaceInDock(p, dgroup.Editors, false)
+}
+
+// UpdatePowers refreshes the powers for the given Sheet, if they are being displayed.
+func UpdatePowers(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if p, ok := other.(*PowersDockable); ok && p.sheet == sheet {
+			p.refresh()
+			break
+		}
+	}
+}
+
+func (p *PowersDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return p.scale },
+			func(scale int) { p.scale = scale },
+			nil,
+			false,
+			p.scroll,
+		),
+	)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (p *PowersDockable) refresh() {
+	p.content.RemoveAllChildren()
+	entity := p.sheet.Entity()
+	if sources := entity.PowerSources(); len(sources) != 0 {
+		p.addCountermeasures(entity, sources)
+	}
+	powers := entity.Powers()
+	if len(powers) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No traits have been given a power source or power talent"))
+		p.content.AddChild(label)
+	}
+	for _, power := range powers {
+		p.addEntry(power)
+	}
+	p.content.MarkForLayoutRecursively()
+	p.MarkForRedraw()
+}
+
+func (p *PowersDockable) addCountermeasures(entity *gurps.Entity, sources []string) {
+	panel := p.newEntryPanel(i18n.Text("Countermeasures"))
+	for _, source := range sources {
+		checkBox := unison.NewCheckBox()
+		checkBox.SetTitle(fmt.Sprintf(i18n.Text("Suppress %s"), source))
+		checkBox.State = check.FromBool(entity.PowerSourceSuppressed(source))
+		checkBox.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Disables all abilities drawing on the %s power source, such as when within an area that negates it"),
+			source))
+		checkBox.ClickCallback = func() {
+			suppressed := checkBox.State == check.On
+			var editName string
+			if suppressed {
+				editName = fmt.Sprintf(i18n.Text("Suppress %s"), source)
+			} else {
+				editName = fmt.Sprintf(i18n.Text("Restore %s"), source)
+			}
+			changeSuppressedPowers(p.sheet, editName, func() { entity.SetPowerSourceSuppressed(source, suppressed) })
+		}
+		panel.AddChild(checkBox)
+	}
+	p.content.AddChild(panel)
+}
+
+func (p *PowersDockable) addEntry(power *gurps.Power) {
+	title := power.Name
+	if power.Suppressed {
+		title = fmt.Sprintf(i18n.Text("%s (Suppressed)"), title)
+	}
+	panel := p.newEntryPanel(title)
+	var lines []string
+	if power.Source != "" {
+		lines = append(lines, fmt.Sprintf(i18n.Text("Source: %s"), power.Source))
+	}
+	switch {
+	case power.Talent != nil:
+		lines = append(lines, fmt.Sprintf(i18n.Text("Talent: %s %s"), power.Talent.String(),
+			power.TalentLevel.StringWithSign()))
+	case power.TalentName != "":
+		lines = append(lines, fmt.Sprintf(i18n.Text("Talent: %s (not taken)"), power.TalentName))
+	}
+	if len(power.Modifiers) != 0 {
+		mods := make([]string, len(power.Modifiers))
+		for i, mod := range power.Modifiers {
+			mods[i] = mod.String() + " " + mod.CostDescription()
+		}
+		lines = append(lines, fmt.Sprintf(i18n.Text("Power Modifier: %s"), strings.Join(mods, ", ")))
+	}
+	lines = append(lines, fmt.Sprintf(i18n.Text("Points: %s"), power.Points().String()))
+	for _, line := range lines {
+		text := unison.NewLabel()
+		text.SetTitle(line)
+		panel.AddChild(text)
+	}
+	for _, ability := range power.Abilities {
+		text := unison.NewLabel()
+		if ability.IsLeveled() {
+			text.SetTitle(fmt.Sprintf(i18n.Text("• %s: level %s, effective level %s"), ability.String(),
+				ability.Levels.String(), power.EffectiveLevel(ability).String()))
+		} else {
+			text.SetTitle(fmt.Sprintf(i18n.Text("• %s: %s to rolls"), ability.String(),
+				power.EffectiveLevel(ability).StringWithSign()))
+		}
+		text.SetEnabled(ability.Enabled())
+		panel.AddChild(text)
+	}
+	for _, skill := range power.Skills {
+		text := unison.NewLabel()
+		if skill.LevelData.Level > 0 {
+			text.SetTitle(fmt.Sprintf(i18n.Text("• %s-%s (skill)"), skill.String(), skill.LevelData.Level.Trunc().String()))
+		} else {
+			text.SetTitle(fmt.Sprintf(i18n.Text("• %s (skill)"), skill.String()))
+		}
+		panel.AddChild(text)
+	}
+	p.content.AddChild(panel)
+}
+
+func (p *PowersDockable) newEntryPanel(title string) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(title)
+	panel.AddChild(label)
+	return panel
+}
+
+// changeSuppressedPowers makes a change to the sheet's suppressed power sources that can be undone.
+func changeSuppressedPowers(sheet *Sheet, editName string, change func()) {
+	before := slices.Clone(sheet.Entity().SuppressedPowers)
+	change()
+	sheet.undoMgr.Add(&unison.UndoEdit[[]string]{
+		ID:         unison.NextUndoID(),
+		EditName:   editName,
+		UndoFunc:   func(edit *unison.UndoEdit[[]string]) { applySuppressedPowers(sheet, edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[[]string]) { applySuppressedPowers(sheet, edit.AfterData) },
+		BeforeData: before,
+		AfterData:  slices.Clone(sheet.Entity().SuppressedPowers),
+	})
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+func applySuppressedPowers(sheet *Sheet, suppressed []string) {
+	entity := sheet.Entity()
+	entity.SuppressedPowers = slices.Clone(suppressed)
+	entity.Recalculate()
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+// TitleIcon implements unison.Dockable
+func (p *PowersDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSTraits,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (p *PowersDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Powers for %s"), p.sheet.String())
+}
+
+func (p *PowersDockable) String() string {
+	return p.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (p *PowersDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (p *PowersDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (p *PowersDockable) CloseWithGroup(other unison.Paneler) bool {
+	return p.sheet != nil && p.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (p *PowersDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(p)
+}
+
+// AttemptClose implements GroupedCloser
+func (p *PowersDockable) AttemptClose() bool {
+	if !CloseGroup(p) {
+		return false
+	}
+	return AttemptCloseForDockable(p)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (p *PowersDockable) UndoManager() *unison.UndoManager {
+	return p.undoMgr
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func installApplyQualityPresetHandler(p *unison.Panel, owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]]) {
+	p.InstallCmdHandlers(ApplyQualityPresetItemID,
+		func(_ any) bool { return table.HasSelection() },
+		func(_ any) { applyQualityPreset(owner, table) })
+}
+
+// applicableQualityPresets returns the presets that apply to at least one of the selected, non-container rows.
+func applicableQualityPresets(table *unison.Table[*Node[*gurps.Equipment]]) (applicable, all []*gurps.QualityPreset) {
+	all = gurps.AvailableQualityPresets(gurps.GlobalSettings().Libraries())
+	for _, preset := range all {
+		for _, row := range table.SelectedRows(false) {
+			if eqp := row.Data(); eqp != nil && !eqp.Container() && preset.AppliesTo(eqp) {
+				applicable = append(applicable, preset)
+				break
+			}
+		}
+	}
+	return applicable, all
+}
+
+// applyQualityPreset asks which quality preset to use and then applies it to each selected row in the table that it is
+// appropriate for.
+func applyQualityPreset(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]]) {
+	applicable, all := applicableQualityPresets(table)
+	if len(applicable) == 0 {
+		unison.WarningDialogWithMessage(i18n.Text("No quality presets apply to the selection."),
+			fmt.Sprintf(i18n.Text("Quality presets are loaded from %s files in the Settings folder of each library."),
+				gurps.QualityPresetsExt))
+		return
+	}
+	preset, ok := pickQualityPreset(applicable)
+	if !ok {
+		return
+	}
+	var undo *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]
+	mgr := unison.UndoManagerFor(table)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]{
+			ID:         unison.NextUndoID(),
+			EditName:   fmt.Sprintf(i18n.Text("Apply %s"), preset.String()),
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[*gurps.Equipment]], _ unison.Undoable) bool { return false },
+			BeforeData: NewTableUndoEditData(table),
+		}
+	}
+	changed := false
+	for _, row := range table.SelectedRows(false) {
+		if eqp := row.Data(); eqp != nil && !eqp.Container() && preset.AppliesTo(eqp) &&
+			eqp.ApplyQualityPreset(preset, all) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	table.SyncToModel()
+	if mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		mgr.Add(undo)
+	}
+	MarkModified(table)
+	owner.Rebuild(true)
+}
+
+func pickQualityPreset(presets []*gurps.QualityPreset) (*gurps.QualityPreset, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Quality"), false))
+	popup := unison.NewPopupMenu[*gurps.QualityPreset]()
+	popup.AddItem(presets...)
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return nil, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return nil, false
+	}
+	return popup.Selected()
+}
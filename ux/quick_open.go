@@ -0,0 +1,403 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/imgutil"
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const quickOpenPreviewWidth = 240
+
+// quickOpenFilter limits the files shown in the quick open dialog to a group of file types.
+type quickOpenFilter struct {
+	title  string
+	accept func(ext string, fi *gurps.FileInfo) bool
+}
+
+func (f *quickOpenFilter) String() string {
+	return f.title
+}
+
+func quickOpenFilters() []*quickOpenFilter {
+	return []*quickOpenFilter{
+		{
+			title:  i18n.Text("All Supported Files"),
+			accept: func(_ string, fi *gurps.FileInfo) bool { return !fi.IsSpecial },
+		},
+		{
+			title:  i18n.Text("GCS Files"),
+			accept: func(_ string, fi *gurps.FileInfo) bool { return fi.IsGCSData },
+		},
+		{
+			title:  i18n.Text("Sheets"),
+			accept: func(ext string, _ *gurps.FileInfo) bool { return ext == gurps.SheetExt },
+		},
+		{
+			title:  i18n.Text("Templates"),
+			accept: func(ext string, _ *gurps.FileInfo) bool { return ext == gurps.TemplatesExt },
+		},
+		{
+			title: i18n.Text("Libraries"),
+			accept: func(ext string, fi *gurps.FileInfo) bool {
+				return fi.IsGCSData && ext != gurps.SheetExt && ext != gurps.TemplatesExt
+			},
+		},
+		{
+			title:  i18n.Text("Images"),
+			accept: func(_ string, fi *gurps.FileInfo) bool { return fi.IsImage },
+		},
+		{
+			title:  i18n.Text("PDFs"),
+			accept: func(_ string, fi *gurps.FileInfo) bool { return fi.IsPDF },
+		},
+	}
+}
+
+// quickOpenEntry is a single row in the quick open dialog's file list.
+type quickOpenEntry struct {
+	name  string
+	path  string
+	isDir bool
+}
+
+func (e *quickOpenEntry) String() string {
+	return e.name
+}
+
+func (e *quickOpenEntry) icon() *unison.SVG {
+	if e.isDir {
+		return gurps.FileInfoFor(gurps.ClosedFolder).SVG
+	}
+	return gurps.FileInfoFor(e.path).SVG
+}
+
+// quickOpenCellFactory adds the file type icon to each row of the list.
+type quickOpenCellFactory struct {
+	unison.DefaultCellFactory
+}
+
+func (f *quickOpenCellFactory) CreateCell(owner unison.Paneler, element any, row int, foreground, background unison.Ink, selected, focused bool) unison.Paneler {
+	cell := f.DefaultCellFactory.CreateCell(owner, element, row, foreground, background, selected, focused)
+	if label, ok := cell.(*unison.Label); ok {
+		if entry, isEntry := element.(*quickOpenEntry); isEntry {
+			size := label.Font.Baseline()
+			label.Drawable = &unison.DrawableSVG{
+				SVG:  entry.icon(),
+				Size: unison.NewSize(size, size),
+			}
+		}
+	}
+	return cell
+}
+
+type quickOpenDialog struct {
+	dialog   *unison.Dialog
+	dir      string
+	dirLabel *unison.Label
+	filter   *unison.PopupMenu[*quickOpenFilter]
+	list     *unison.List[*quickOpenEntry]
+	preview  *unison.Panel
+	upButton *unison.Button
+}
+
+// ShowQuickOpen displays a dialog for opening files that offers a preview of the selected file and filters for the
+// various groups of file types. Any mix of file types may be selected and opened together.
+func ShowQuickOpen() {
+	d := &quickOpenDialog{dir: gurps.GlobalSettings().LastDir(gurps.DefaultLastDirKey)}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(d.createHeader())
+	panel.AddChild(d.createList())
+	d.preview = unison.NewPanel()
+	d.preview.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.preview.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(quickOpenPreviewWidth, 0),
+		HAlign:   align.Fill,
+		VAlign:   align.Start,
+	})
+	panel.AddChild(d.preview)
+	var err error
+	d.dialog, err = unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Open")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	d.dialog.Window().SetTitle(i18n.Text("Quick Open"))
+	d.load()
+	if d.dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	if paths := d.selectedFiles(); len(paths) != 0 {
+		gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, d.dir)
+		OpenFiles(paths)
+	}
+}
+
+func (d *quickOpenDialog) createHeader() *unison.Panel {
+	header := unison.NewPanel()
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.upButton = unison.NewButton()
+	d.upButton.SetTitle(i18n.Text("Up"))
+	d.upButton.Tooltip = newWrappedTooltip(i18n.Text("Go to the enclosing folder"))
+	d.upButton.ClickCallback = func() { d.changeDir(filepath.Dir(d.dir)) }
+	header.AddChild(d.upButton)
+	d.dirLabel = unison.NewLabel()
+	d.dirLabel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	header.AddChild(d.dirLabel)
+	d.filter = unison.NewPopupMenu[*quickOpenFilter]()
+	filters := quickOpenFilters()
+	d.filter.AddItem(filters...)
+	d.filter.Select(filters[0])
+	d.filter.SelectionChangedCallback = func(_ *unison.PopupMenu[*quickOpenFilter]) { d.load() }
+	header.AddChild(d.filter)
+	return header
+}
+
+func (d *quickOpenDialog) createList() *unison.ScrollPanel {
+	d.list = unison.NewList[*quickOpenEntry]()
+	d.list.Factory = &quickOpenCellFactory{}
+	d.list.SetAllowMultipleSelection(true)
+	d.list.NewSelectionCallback = d.selectionChanged
+	d.list.DoubleClickCallback = func() {
+		if d.list.Selection.Count() == 1 {
+			if entry := d.list.DataAtIndex(d.list.Selection.FirstSet()); entry.isDir {
+				d.changeDir(entry.path)
+				return
+			}
+		}
+		if len(d.selectedFiles()) != 0 {
+			d.dialog.Button(unison.ModalResponseOK).Click()
+		}
+	}
+	scroll := unison.NewScrollPanel()
+	scroll.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroll.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(360, 400),
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+		HGrab:    true,
+		VGrab:    true,
+	})
+	return scroll
+}
+
+func (d *quickOpenDialog) changeDir(dir string) {
+	d.dir = dir
+	d.load()
+}
+
+// load fills the list with the folders and accepted files within the current directory.
+func (d *quickOpenDialog) load() {
+	if abs, err := filepath.Abs(d.dir); err == nil {
+		d.dir = abs
+	}
+	d.dirLabel.SetTitle(d.dir)
+	d.upButton.SetEnabled(filepath.Dir(d.dir) != d.dir)
+	filter, _ := d.filter.Selected()
+	var entries []*quickOpenEntry
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		errs.Log(err, "path", d.dir)
+	}
+	for _, one := range dirEntries {
+		name := one.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		entry := &quickOpenEntry{
+			name:  name,
+			path:  filepath.Join(d.dir, name),
+			isDir: one.IsDir(),
+		}
+		if !entry.isDir {
+			ext := strings.ToLower(filepath.Ext(name))
+			if filter != nil && !filter.accept(ext, gurps.FileInfoFor(name)) {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b *quickOpenEntry) int {
+		if a.isDir != b.isDir {
+			if a.isDir {
+				return -1
+			}
+			return 1
+		}
+		return txt.NaturalCmp(a.name, b.name, true)
+	})
+	d.list.Clear()
+	d.list.Append(entries...)
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.list.MarkForRedraw()
+	d.selectionChanged()
+}
+
+func (d *quickOpenDialog) selectedFiles() []string {
+	var paths []string
+	for i := d.list.Selection.FirstSet(); i != -1; i = d.list.Selection.NextSet(i + 1) {
+		if entry := d.list.DataAtIndex(i); !entry.isDir {
+			paths = append(paths, entry.path)
+		}
+	}
+	return paths
+}
+
+func (d *quickOpenDialog) selectionChanged() {
+	paths := d.selectedFiles()
+	if d.dialog != nil {
+		d.dialog.Button(unison.ModalResponseOK).SetEnabled(len(paths) != 0)
+	}
+	d.preview.RemoveAllChildren()
+	switch len(paths) {
+	case 0:
+		d.addPreviewNote(i18n.Text("Select one or more files to open. Files of different types may be opened together."))
+	case 1:
+		d.previewFile(paths[0])
+	default:
+		d.addPreviewNote(fmt.Sprintf(i18n.Text("%d files selected"), len(paths)))
+	}
+	d.preview.MarkForLayoutRecursivelyUpward()
+	d.preview.MarkForRedraw()
+}
+
+func (d *quickOpenDialog) previewFile(filePath string) {
+	fi := gurps.FileInfoFor(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
+	var meta *gurps.FileMetadata
+	if fi.IsGCSData {
+		var err error
+		if meta, err = gurps.LoadFileMetadata(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath)); err != nil {
+			errs.Log(err, "path", filePath)
+		}
+	}
+	if meta != nil {
+		if img := imgutil.ThumbnailNow(meta.Portrait, portraitThumbnailDimension); img != nil {
+			imgSize := img.LogicalSize()
+			scale := min(quickOpenPreviewWidth/imgSize.Width, (quickOpenPreviewWidth/2)/imgSize.Height, 1)
+			portrait := unison.NewLabel()
+			portrait.Drawable = &unison.SizedDrawable{
+				Drawable: img,
+				Size:     unison.NewSize(imgSize.Width*scale, imgSize.Height*scale),
+			}
+			d.preview.AddChild(portrait)
+		}
+	}
+	title := unison.NewLabel()
+	title.Font = unison.EmphasizedSystemFont
+	if meta != nil && meta.Name != "" {
+		title.SetTitle(meta.Name)
+	} else {
+		title.SetTitle(filepath.Base(filePath))
+	}
+	d.preview.AddChild(title)
+	d.addPreviewLine(i18n.Text("Type"), fi.Name)
+	if info, err := os.Stat(filePath); err == nil {
+		d.addPreviewLine(i18n.Text("Modified"), jio.Time(info.ModTime()).String())
+	}
+	if meta == nil {
+		if fi.IsGCSData {
+			d.addPreviewNote(i18n.Text("Unable to read this file's contents."))
+		}
+		return
+	}
+	switch ext {
+	case gurps.SheetExt:
+		d.addPreviewLine(i18n.Text("Player"), meta.PlayerName)
+		d.addPreviewLine(i18n.Text("Title"), meta.Title)
+		d.addPreviewLine(i18n.Text("Points"), meta.TotalPoints.Comma())
+		d.addPreviewContents(meta)
+	case gurps.TemplatesExt:
+		if meta.Revision > 0 {
+			d.addPreviewLine(i18n.Text("Revision"), fmt.Sprint(meta.Revision))
+		}
+		d.addPreviewContents(meta)
+	default:
+		d.addPreviewLine(i18n.Text("Entries"), fmt.Sprint(meta.Rows))
+	}
+}
+
+func (d *quickOpenDialog) addPreviewContents(meta *gurps.FileMetadata) {
+	for _, one := range []struct {
+		title string
+		count int
+	}{
+		{i18n.Text("Traits"), meta.Traits},
+		{i18n.Text("Skills"), meta.Skills},
+		{i18n.Text("Spells"), meta.Spells},
+		{i18n.Text("Equipment"), meta.Equipment},
+		{i18n.Text("Notes"), meta.Notes},
+	} {
+		if one.count != 0 {
+			d.addPreviewLine(one.title, fmt.Sprint(one.count))
+		}
+	}
+}
+
+func (d *quickOpenDialog) addPreviewLine(title, value string) {
+	if value == "" {
+		return
+	}
+	label := unison.NewLabel()
+	label.Font = fonts.FieldSecondary
+	label.SetTitle(fmt.Sprintf("%s: %s", title, value))
+	d.preview.AddChild(label)
+}
+
+func (d *quickOpenDialog) addPreviewNote(text string) {
+	for _, line := range unison.NewTextWrappedLines(text, &unison.TextDecoration{
+		Font:            fonts.FieldSecondary,
+		OnBackgroundInk: unison.ThemeOnSurface,
+	}, quickOpenPreviewWidth) {
+		label := unison.NewLabel()
+		label.Text = line
+		d.preview.AddChild(label)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &QuickReference{}
+	_ unison.UndoManagerProvider = &QuickReference{}
+)
+
+// QuickReference shows the commonly needed tables found in the quick reference files. Tables in a library's Settings
+// folder replace the built-in tables of the same name, so a group can substitute their own house rules.
+type QuickReference struct {
+	unison.Panel
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// ShowQuickReference displays the quick reference panel.
+func ShowQuickReference() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*QuickReference)
+		return ok
+	}) {
+		return
+	}
+	q := &QuickReference{scale: gurps.GlobalSettings().General.InitialEditorUIScale}
+	q.Self = q
+	q.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	q.SetLayout(&unison.FlexLayout{Columns: 1})
+	q.content = unison.NewPanel()
+	q.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	q.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	q.scroll = unison.NewScrollPanel()
+	q.scroll.SetContent(q.content, behavior.HintedFill, behavior.Unmodified)
+	q.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	q.AddChild(q.createToolbar())
+	q.AddChild(q.scroll)
+	q.refresh()
+	PlaceInDock(q, dgroup.Editors, false)
+}
+
+func (q *QuickReference) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return q.scale },
+			func(scale int) { q.scale = scale },
+			nil,
+			false,
+			q.scroll,
+		),
+	)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Reload the tables from the %s files in the Settings folder of each library"),
+		gurps.QuickReferenceExt))
+	refreshButton.ClickCallback = q.refresh
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (q *QuickReference) refresh() {
+	q.content.RemoveAllChildren()
+	tables := gurps.AvailableQuickReferenceTables(gurps.GlobalSettings().Libraries())
+	if len(tables) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No quick reference tables are available"))
+		q.content.AddChild(label)
+	}
+	for _, table := range tables {
+		q.addTable(table)
+	}
+	q.content.MarkForLayoutRecursively()
+	q.MarkForRedraw()
+}
+
+func (q *QuickReference) addTable(table *gurps.QuickReferenceTable) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(table.Name)
+	panel.AddChild(label)
+	markdown := unison.NewMarkdown(true)
+	markdown.SetContent(table.Markdown(), 0)
+	markdown.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(markdown)
+	q.content.AddChild(panel)
+}
+
+// TitleIcon implements unison.Dockable
+func (q *QuickReference) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Stack,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (q *QuickReference) Title() string {
+	return i18n.Text("Quick Reference")
+}
+
+func (q *QuickReference) String() string {
+	return q.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (q *QuickReference) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (q *QuickReference) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (q *QuickReference) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (q *QuickReference) AttemptClose() bool {
+	return AttemptCloseForDockable(q)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (q *QuickReference) UndoManager() *unison.UndoManager {
+	return q.undoMgr
+}
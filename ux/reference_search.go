@@ -0,0 +1,321 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/pdf"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+// referenceSearchDPI is the resolution pages are rendered at while searching them. The image is discarded, so this is
+// kept as small as possible.
+const referenceSearchDPI = 9
+
+var (
+	_ unison.Dockable            = &ReferenceSearchDockable{}
+	_ unison.UndoManagerProvider = &ReferenceSearchDockable{}
+	_ unison.TabCloser           = &ReferenceSearchDockable{}
+)
+
+// referenceSearchIndex holds the pages of each reference PDF known to contain a search term, so that repeated searches
+// don't need to scan the PDF again. Entries are keyed by the file, its modification time and the lowercased term.
+var referenceSearchIndex = struct {
+	lock  sync.Mutex
+	pages map[string][]int
+}{pages: make(map[string][]int)}
+
+type referenceSearchHit struct {
+	ref  *gurps.PageRef
+	page int
+}
+
+// ReferenceSearchDockable searches the text of the PDFs mapped to page reference keys.
+type ReferenceSearchDockable struct {
+	unison.Panel
+	undoMgr     *unison.UndoManager
+	searchField *unison.Field
+	status      *unison.Label
+	content     *unison.Panel
+	scroll      *unison.ScrollPanel
+	generation  atomic.Int64
+	scale       int
+}
+
+// ShowReferenceSearch displays the reference search.
+func ShowReferenceSearch() {
+	if Activate(func(d unison.Dockable) bool {
+		_, ok := d.AsPanel().Self.(*ReferenceSearchDockable)
+		return ok
+	}) {
+		return
+	}
+	d := &ReferenceSearchDockable{scale: gurps.GlobalSettings().General.InitialEditorUIScale}
+	d.Self = d
+	d.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.content = unison.NewPanel()
+	d.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.status = unison.NewLabel()
+	d.status.SetTitle(i18n.Text("Enter a keyword to search the mapped reference PDFs"))
+	d.status.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.content.AddChild(d.status)
+	d.scroll = unison.NewScrollPanel()
+	d.scroll.SetContent(d.content, behavior.HintedFill, behavior.Fill)
+	d.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.scroll)
+	PlaceInDock(d, dgroup.Editors, false)
+	FocusFirstContent(nil, d.searchField.AsPanel())
+}
+
+func (d *ReferenceSearchDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return d.scale },
+			func(scale int) { d.scale = scale },
+			nil,
+			false,
+			d.scroll,
+		),
+	)
+	d.searchField = NewSearchField(i18n.Text("Reference Search"), nil)
+	d.searchField.SetMinimumTextWidthUsing("Rapid Strike Penalties")
+	d.searchField.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		if keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter {
+			d.search()
+			return true
+		}
+		return d.searchField.DefaultKeyDown(keyCode, mod, repeat)
+	}
+	toolbar.AddChild(d.searchField)
+	searchButton := unison.NewButton()
+	searchButton.SetTitle(i18n.Text("Search"))
+	searchButton.ClickCallback = d.search
+	toolbar.AddChild(searchButton)
+	mappingsButton := unison.NewSVGButton(svg.Bookmark)
+	mappingsButton.Tooltip = newWrappedTooltip(i18n.Text("Page Reference Mappings"))
+	mappingsButton.ClickCallback = ShowPageRefMappings
+	toolbar.AddChild(mappingsButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+// search starts a new search of the mapped reference PDFs for the text in the search field, abandoning any search that
+// is still in progress.
+func (d *ReferenceSearchDockable) search() {
+	term := strings.TrimSpace(d.searchField.Text())
+	generation := d.generation.Add(1)
+	d.clearResults()
+	if term == "" {
+		return
+	}
+	refs := gurps.GlobalSettings().PageRefs.List()
+	if len(refs) == 0 {
+		d.setStatus(i18n.Text("No page reference keys have been mapped to PDFs"))
+		return
+	}
+	cancelled := func() bool { return d.generation.Load() != generation }
+	go func() {
+		var hits []*referenceSearchHit
+		for i, ref := range refs {
+			if cancelled() {
+				return
+			}
+			status := fmt.Sprintf(i18n.Text("Searching %s (%d of %d)…"), filepath.Base(ref.Path), i+1, len(refs))
+			unison.InvokeTask(func() {
+				if !cancelled() {
+					d.setStatus(status)
+				}
+			})
+			pages, err := searchReferencePDF(ref.Path, term, cancelled)
+			if err != nil {
+				errs.Log(err, "path", ref.Path)
+				continue
+			}
+			for _, page := range pages {
+				hits = append(hits, &referenceSearchHit{
+					ref:  ref,
+					page: page + 1 - ref.Offset,
+				})
+			}
+		}
+		unison.InvokeTask(func() {
+			if !cancelled() {
+				d.showResults(term, hits)
+			}
+		})
+	}()
+}
+
+// searchReferencePDF returns the zero-based numbers of the pages in the PDF that contain the term.
+func searchReferencePDF(filePath, term string, cancelled func() bool) ([]int, error) {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	key := filePath + "\n" + fi.ModTime().String() + "\n" + strings.ToLower(term)
+	referenceSearchIndex.lock.Lock()
+	pages, ok := referenceSearchIndex.pages[key]
+	referenceSearchIndex.lock.Unlock()
+	if ok {
+		return pages, nil
+	}
+	var data []byte
+	if data, err = os.ReadFile(filePath); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var doc *pdf.Document
+	if doc, err = pdf.New(data, 0); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	pages = make([]int, 0)
+	count := doc.PageCount()
+	for i := 0; i < count; i++ {
+		if cancelled() {
+			return nil, nil
+		}
+		pdfRenderLock.Lock()
+		page, renderErr := doc.RenderPage(i, referenceSearchDPI, 1, term)
+		pdfRenderLock.Unlock()
+		if renderErr != nil {
+			return nil, errs.Wrap(renderErr)
+		}
+		if len(page.SearchHits) != 0 {
+			pages = append(pages, i)
+		}
+	}
+	referenceSearchIndex.lock.Lock()
+	referenceSearchIndex.pages[key] = pages
+	referenceSearchIndex.lock.Unlock()
+	return pages, nil
+}
+
+func (d *ReferenceSearchDockable) setStatus(text string) {
+	d.status.SetTitle(text)
+	d.content.MarkForLayoutRecursively()
+	d.content.MarkForRedraw()
+}
+
+func (d *ReferenceSearchDockable) clearResults() {
+	children := d.content.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i] != d.status.AsPanel() {
+			d.content.RemoveChildAtIndex(i)
+		}
+	}
+	d.setStatus("")
+}
+
+func (d *ReferenceSearchDockable) showResults(term string, hits []*referenceSearchHit) {
+	if len(hits) == 0 {
+		d.setStatus(fmt.Sprintf(i18n.Text("No pages contain \"%s\""), term))
+		return
+	}
+	for _, hit := range hits {
+		ref := hit.ref.ID + strconv.Itoa(hit.page)
+		d.content.AddChild(unison.NewLink(ref, i18n.Text("Open this page"), "", unison.DefaultLinkTheme,
+			func(_ unison.Paneler, _ string) { OpenPageReference(ref, term, nil) }))
+		label := unison.NewLabel()
+		label.SetTitle(filepath.Base(hit.ref.Path))
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		d.content.AddChild(label)
+	}
+	d.setStatus(fmt.Sprintf(i18n.Text("%d page(s) contain \"%s\""), len(hits), term))
+}
+
+// TitleIcon implements unison.Dockable
+func (d *ReferenceSearchDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.PDFFile,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *ReferenceSearchDockable) Title() string {
+	return i18n.Text("Reference Search")
+}
+
+func (d *ReferenceSearchDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *ReferenceSearchDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *ReferenceSearchDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *ReferenceSearchDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *ReferenceSearchDockable) AttemptClose() bool {
+	d.generation.Add(1)
+	return AttemptCloseForDockable(d)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (d *ReferenceSearchDockable) UndoManager() *unison.UndoManager {
+	return d.undoMgr
+}
@@ -0,0 +1,372 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const reputationsEditorColumns = 8
+
+var (
+	_ unison.Dockable            = &reputationsEditor{}
+	_ unison.TabCloser           = &reputationsEditor{}
+	_ ModifiableRoot             = &reputationsEditor{}
+	_ unison.UndoManagerProvider = &reputationsEditor{}
+	_ GroupedCloser              = &reputationsEditor{}
+	_ Rebuildable                = &reputationsEditor{}
+)
+
+type reputationsEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.Reputation
+	current          []*gurps.Reputation
+	promptForSave    bool
+}
+
+func displayReputationsEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*reputationsEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &reputationsEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.CloneReputationList(entity.Reputations),
+		current: gurps.CloneReputationList(entity.Reputations),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  reputationsEditorColumns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+}
+
+func (e *reputationsEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Reputation"))
+	addButton.ClickCallback = func() {
+		e.current = slices.Insert(e.current, 0, gurps.NewReputation())
+		e.rebuildContent()
+	}
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *reputationsEditor) rebuildContent() {
+	e.content.RemoveAllChildren()
+	e.initContent()
+	e.content.Pack()
+	MarkForLayoutWithinDockable(e.content)
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+}
+
+func (e *reputationsEditor) initContent() {
+	choices := linkedTraitChoices(e.entity)
+	for _, one := range e.current {
+		e.createRow(one, choices)
+	}
+}
+
+func (e *reputationsEditor) createRow(r *gurps.Reputation, choices []linkedTraitChoice) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Reputation"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current, r); i != -1 {
+			e.current = slices.Delete(e.current, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	e.content.AddChild(deleteButton)
+
+	cost := NewFieldTrailingLabel("", false)
+	updateCost := func() {
+		cost.OnBackgroundInk = unison.ThemeOnSurface
+		if r.TraitID != "" {
+			if t := e.entity.TraitByID(r.TraitID); t == nil || t.AdjustedPoints() != r.Cost() {
+				cost.OnBackgroundInk = unison.ThemeError
+			}
+		}
+		cost.SetTitle(fmt.Sprintf(i18n.Text("%s pts"), r.Cost().String()))
+		cost.MarkForLayoutAndRedraw()
+	}
+
+	nameText := i18n.Text("Known for")
+	name := NewStringField(nil, "", nameText,
+		func() string { return r.Name },
+		func(value string) {
+			r.Name = value
+			MarkModified(e.content)
+		})
+	name.Watermark = nameText
+	name.Tooltip = newWrappedTooltip(i18n.Text("What the character is known for"))
+	name.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChild(name)
+
+	whoText := i18n.Text("Who is affected")
+	who := NewStringField(nil, "", whoText,
+		func() string { return r.AffectedBy },
+		func(value string) {
+			r.AffectedBy = value
+			MarkModified(e.content)
+		})
+	who.Watermark = whoText
+	who.Tooltip = newWrappedTooltip(i18n.Text("The people who react to the reputation, used to describe the reaction modifier"))
+	who.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChild(who)
+
+	modifier := NewIntegerField(nil, "", i18n.Text("Reaction Modifier"),
+		func() int { return r.Modifier },
+		func(value int) {
+			r.Modifier = value
+			updateCost()
+			MarkModified(e.content)
+		}, -4, 4, true, false)
+	modifier.Tooltip = newWrappedTooltip(i18n.Text("The reaction modifier from those who recognize the character"))
+	e.content.AddChild(modifier)
+
+	e.content.AddChild(NewPopup[reputation.Affected](nil, "", i18n.Text("People Affected"),
+		func() reputation.Affected { return r.Affected },
+		func(affected reputation.Affected) {
+			r.Affected = affected
+			updateCost()
+		}, reputation.Affecteds...))
+
+	e.content.AddChild(NewPopup[reputation.Recognition](nil, "", i18n.Text("Frequency of Recognition"),
+		func() reputation.Recognition { return r.Recognition },
+		func(recognition reputation.Recognition) {
+			r.Recognition = recognition
+			updateCost()
+		}, reputation.Recognitions...))
+
+	e.content.AddChild(newLinkedTraitPopup(choices, &r.TraitID, updateCost))
+
+	updateCost()
+	e.content.AddChild(cost)
+}
+
+func (e *reputationsEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+func (e *reputationsEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Reputations for %s"), e.owner.String())
+}
+
+func (e *reputationsEditor) String() string {
+	return e.Title()
+}
+
+func (e *reputationsEditor) Tooltip() string {
+	return ""
+}
+
+func (e *reputationsEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *reputationsEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *reputationsEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *reputationsEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *reputationsEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *reputationsEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *reputationsEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *reputationsEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[[]*gurps.Reputation]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Reputation Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.Reputation]) {
+				entity.SetReputations(edit.BeforeData)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.Reputation]) {
+				entity.SetReputations(edit.AfterData)
+				owner.Rebuild(true)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetReputations(e.current)
+	owner.Rebuild(true)
+}
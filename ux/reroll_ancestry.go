@@ -0,0 +1,113 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type rerollAncestryUndoData struct {
+	sheet  *Sheet
+	random gurps.ProfileRandom
+	locked []string
+}
+
+func newRerollAncestryUndoData(sheet *Sheet) *rerollAncestryUndoData {
+	return &rerollAncestryUndoData{
+		sheet:  sheet,
+		random: sheet.entity.Profile.ProfileRandom,
+		locked: slices.Clone(sheet.entity.Profile.LockedFields),
+	}
+}
+
+func (d *rerollAncestryUndoData) apply() {
+	d.sheet.entity.Profile.ProfileRandom = d.random
+	d.sheet.entity.Profile.LockedFields = slices.Clone(d.locked)
+	updateRandomizedProfileFieldsWithoutUndo(d.sheet)
+	d.sheet.Rebuild(true)
+}
+
+// askToRerollAncestry shows the randomizable profile fields with a lock for each and returns true if the user chose to
+// re-roll the unlocked ones. The lock choices are stored in the profile when true is returned.
+func askToRerollAncestry(profile *gurps.Profile, prompt string) bool {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(prompt)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+	fields := gurps.ProfileRandomFields()
+	checkboxes := make([]*unison.CheckBox, len(fields))
+	for i, field := range fields {
+		checkbox := unison.NewCheckBox()
+		checkbox.SetTitle(field.Title)
+		checkbox.State = check.FromBool(profile.IsLocked(field.Key))
+		checkbox.Tooltip = newWrappedTooltip(i18n.Text("Lock to keep the current value"))
+		checkboxes[i] = checkbox
+		panel.AddChild(checkbox)
+		value := unison.NewLabel()
+		value.SetTitle(profile.RandomFieldValue(field.Key))
+		value.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		panel.AddChild(value)
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Re-roll Unlocked")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return false
+	}
+	for i, field := range fields {
+		profile.SetLocked(field.Key, checkboxes[i].State == check.On)
+	}
+	return true
+}
+
+// rerollAncestry re-runs the ancestry randomizers for the fields of the profile that the user hasn't locked.
+func (s *Sheet) rerollAncestry() {
+	before := newRerollAncestryUndoData(s)
+	if !askToRerollAncestry(&s.entity.Profile,
+		i18n.Text("Lock the fields to keep. The rest will be re-rolled using the current ancestry.")) {
+		return
+	}
+	s.entity.Profile.RerollUnlocked(s.entity)
+	updateRandomizedProfileFieldsWithoutUndo(s)
+	if mgr := unison.UndoManagerFor(s); mgr != nil {
+		mgr.Add(&unison.UndoEdit[*rerollAncestryUndoData]{
+			ID:         unison.NextUndoID(),
+			EditName:   rerollAncestryAction.Title,
+			UndoFunc:   func(e *unison.UndoEdit[*rerollAncestryUndoData]) { e.BeforeData.apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*rerollAncestryUndoData]) { e.AfterData.apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*rerollAncestryUndoData], _ unison.Undoable) bool { return false },
+			BeforeData: before,
+			AfterData:  newRerollAncestryUndoData(s),
+		})
+	}
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rest"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+const (
+	resetSessionTimeResponse = unison.ModalResponseUserBase + iota
+	advanceSessionTimeResponse
+	restResponseBase
+)
+
+// ShowSessionTimer displays the time that has passed in the current session, along with the character's fatigue, energy
+// reserve and hit point pools, and offers to advance the time, reset it or take a rest. Each rest is recorded in the
+// character's change log.
+func ShowSessionTimer(sheet *Sheet) {
+	entity := sheet.Entity()
+	minutes := 0
+	for {
+		panel := unison.NewPanel()
+		panel.SetLayout(&unison.FlexLayout{
+			Columns:  2,
+			HSpacing: unison.StdHSpacing,
+			VSpacing: unison.StdVSpacing,
+		})
+		panel.AddChild(NewFieldLeadingLabel(i18n.Text("Session Time"), false))
+		timeLabel := unison.NewLabel()
+		timeLabel.SetTitle(gurps.FormatSessionTime(entity.SessionMinutes))
+		panel.AddChild(timeLabel)
+		for _, id := range []string{gurps.FatiguePointsID, gurps.EnergyReserveID, gurps.HitPointsID} {
+			attr, ok := entity.Attributes.Set[id]
+			if !ok {
+				continue
+			}
+			def := attr.AttributeDef()
+			if def == nil {
+				continue
+			}
+			panel.AddChild(NewFieldLeadingLabel(def.Name, false))
+			label := unison.NewLabel()
+			label.SetTitle(fmt.Sprintf(i18n.Text("%s of %s"), attr.Current().Comma(), attr.Maximum().Comma()))
+			panel.AddChild(label)
+		}
+		panel.AddChild(NewFieldLeadingLabel(i18n.Text("Minutes to Advance"), false))
+		panel.AddChild(NewIntegerField(nil, "", i18n.Text("Minutes to Advance"),
+			func() int { return minutes },
+			func(v int) { minutes = v }, 0, 99999, false, false))
+
+		buttons := []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			{Title: i18n.Text("Reset Timer"), ResponseCode: resetSessionTimeResponse},
+			{Title: i18n.Text("Advance Time"), ResponseCode: advanceSessionTimeResponse},
+		}
+		buttons[0].Title = i18n.Text("Close")
+		for _, kind := range rest.Kinds {
+			buttons = append(buttons, &unison.DialogButtonInfo{
+				Title:        kind.String(),
+				ResponseCode: restResponseBase + int(kind),
+			})
+		}
+		dialog, err := unison.NewDialog(nil, nil, panel, buttons)
+		if err != nil {
+			errs.Log(err)
+			return
+		}
+		var title string
+		var apply func(e *gurps.Entity)
+		switch response := dialog.RunModal(); response {
+		case resetSessionTimeResponse:
+			title = i18n.Text("Reset Session Timer")
+			apply = func(e *gurps.Entity) { e.ResetSessionTime() }
+		case advanceSessionTimeResponse:
+			if minutes <= 0 {
+				continue
+			}
+			title = i18n.Text("Advance Session Timer")
+			amount := minutes
+			apply = func(e *gurps.Entity) { e.AdvanceSessionTime(amount) }
+		default:
+			if response < restResponseBase || response > restResponseBase+int(rest.LastKind) {
+				return
+			}
+			kind := rest.Kind(response - restResponseBase)
+			title = kind.String()
+			apply = func(e *gurps.Entity) { e.Rest(kind) }
+		}
+		before := entity.RestState()
+		apply(entity)
+		sheet.undoMgr.Add(&unison.UndoEdit[*gurps.RestState]{
+			ID:         unison.NextUndoID(),
+			EditName:   title,
+			UndoFunc:   func(edit *unison.UndoEdit[*gurps.RestState]) { applyRestState(sheet, edit.BeforeData) },
+			RedoFunc:   func(edit *unison.UndoEdit[*gurps.RestState]) { applyRestState(sheet, edit.AfterData) },
+			BeforeData: before,
+			AfterData:  entity.RestState(),
+		})
+		MarkModified(sheet)
+		sheet.Rebuild(true)
+	}
+}
+
+func applyRestState(sheet *Sheet, state *gurps.RestState) {
+	sheet.Entity().ApplyRestState(state)
+	MarkModified(sheet)
+	sheet.Rebuild(true)
+}
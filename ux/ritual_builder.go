@@ -0,0 +1,256 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type ritualBuilder struct {
+	entity     *gurps.Entity
+	ritual     *gurps.Ritual
+	dialog     *unison.Dialog
+	effects    *unison.Panel
+	modifiers  *unison.Panel
+	costLabel  *unison.Label
+	skillLabel *unison.Label
+}
+
+// buildRitual displays the ritual builder for a copy of the ritual and returns the edited copy, or nil if the changes
+// were cancelled. The energy cost is recomputed from the effects and modifiers as they are changed.
+func buildRitual(entity *gurps.Entity, ritual *gurps.Ritual) *gurps.Ritual {
+	b := &ritualBuilder{
+		entity: entity,
+		ritual: ritual.Clone(),
+	}
+	content := unison.NewPanel()
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Name"), false))
+	nameField := NewStringField(nil, "", i18n.Text("Name"),
+		func() string { return b.ritual.Name },
+		func(value string) {
+			b.ritual.Name = strings.TrimSpace(value)
+			b.update()
+		})
+	nameField.SetMinimumTextWidthUsing("Greater Create Energy")
+	nameField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	content.AddChild(nameField)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Notes"), false))
+	notesField := NewMultiLineStringField(nil, "", i18n.Text("Notes"),
+		func() string { return b.ritual.Notes },
+		func(value string) { b.ritual.Notes = value })
+	notesField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	content.AddChild(notesField)
+
+	b.addSectionHeader(content, i18n.Text("Effects"), i18n.Text("Add an effect"), func() {
+		b.ritual.Effects = append(b.ritual.Effects, &gurps.RitualEffect{})
+		b.rebuildEffects()
+	})
+	b.effects = b.addSection(content, 4)
+	b.rebuildEffects()
+	b.addSectionHeader(content, i18n.Text("Modifiers"), i18n.Text("Add a modifier"), func() {
+		b.ritual.Modifiers = append(b.ritual.Modifiers, &gurps.RitualModifier{})
+		b.rebuildModifiers()
+	})
+	b.modifiers = b.addSection(content, 3)
+	b.rebuildModifiers()
+
+	b.costLabel = unison.NewLabel()
+	b.costLabel.Font = unison.EmphasizedSystemFont
+	b.costLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(b.costLabel)
+	b.skillLabel = unison.NewLabel()
+	b.skillLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(b.skillLabel)
+
+	var err error
+	if b.dialog, err = unison.NewDialog(nil, nil, content, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfo(),
+	}); err != nil {
+		errs.Log(err)
+		return nil
+	}
+	b.dialog.Window().SetTitle(i18n.Text("Ritual Builder"))
+	b.update()
+	if b.dialog.RunModal() != unison.ModalResponseOK {
+		return nil
+	}
+	b.ritual.Gathered = min(b.ritual.Gathered, b.ritual.EnergyCost())
+	return b.ritual
+}
+
+func (b *ritualBuilder) addSectionHeader(content *unison.Panel, title, addTooltip string, add func()) {
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(title)
+	header.AddChild(label)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(addTooltip)
+	addButton.ClickCallback = add
+	header.AddChild(addButton)
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  len(header.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	content.AddChild(header)
+}
+
+func (b *ritualBuilder) addSection(content *unison.Panel, columns int) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  columns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	content.AddChild(panel)
+	return panel
+}
+
+func (b *ritualBuilder) rebuildEffects() {
+	b.effects.RemoveAllChildren()
+	for _, one := range b.ritual.Effects {
+		effectPopup := unison.NewPopupMenu[rpm.Effect]()
+		effectPopup.AddItem(rpm.Effects...)
+		effectPopup.Select(one.Effect)
+		effectPopup.SelectionChangedCallback = func(p *unison.PopupMenu[rpm.Effect]) {
+			if item, ok := p.Selected(); ok {
+				one.Effect = item
+				b.update()
+			}
+		}
+		b.effects.AddChild(effectPopup)
+		pathPopup := unison.NewPopupMenu[rpm.Path]()
+		pathPopup.AddItem(rpm.Paths...)
+		pathPopup.Select(one.Path)
+		pathPopup.SelectionChangedCallback = func(p *unison.PopupMenu[rpm.Path]) {
+			if item, ok := p.Selected(); ok {
+				one.Path = item
+				b.update()
+			}
+		}
+		b.effects.AddChild(pathPopup)
+		greater := unison.NewCheckBox()
+		greater.SetTitle(i18n.Text("Greater"))
+		greater.State = check.FromBool(one.Greater)
+		greater.Tooltip = newWrappedTooltip(i18n.Text("Each greater effect adds 2 to the multiplier applied to the energy cost"))
+		greater.ClickCallback = func() {
+			one.Greater = greater.State == check.On
+			b.update()
+		}
+		b.effects.AddChild(greater)
+		b.effects.AddChild(b.newRemoveButton(i18n.Text("Remove this effect"), func() {
+			b.ritual.Effects = slices.DeleteFunc(b.ritual.Effects, func(e *gurps.RitualEffect) bool { return e == one })
+			b.rebuildEffects()
+		}))
+	}
+	b.sectionChanged()
+}
+
+func (b *ritualBuilder) rebuildModifiers() {
+	b.modifiers.RemoveAllChildren()
+	for _, one := range b.ritual.Modifiers {
+		nameField := NewStringField(nil, "", i18n.Text("Modifier"),
+			func() string { return one.Name },
+			func(value string) { one.Name = strings.TrimSpace(value) })
+		nameField.Watermark = i18n.Text("Modifier, e.g. Duration, 1 day")
+		nameField.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		b.modifiers.AddChild(nameField)
+		energyField := NewIntegerField(nil, "", i18n.Text("Energy"),
+			func() int { return one.Energy },
+			func(value int) {
+				one.Energy = value
+				b.update()
+			}, 0, 99999, false, false)
+		energyField.Tooltip = newWrappedTooltip(i18n.Text("The energy the modifier adds to the base cost"))
+		b.modifiers.AddChild(energyField)
+		b.modifiers.AddChild(b.newRemoveButton(i18n.Text("Remove this modifier"), func() {
+			b.ritual.Modifiers = slices.DeleteFunc(b.ritual.Modifiers,
+				func(m *gurps.RitualModifier) bool { return m == one })
+			b.rebuildModifiers()
+		}))
+	}
+	b.sectionChanged()
+}
+
+func (b *ritualBuilder) newRemoveButton(tooltip string, remove func()) *unison.Button {
+	button := unison.NewSVGButton(svg.Trash)
+	button.Tooltip = newWrappedTooltip(tooltip)
+	button.ClickCallback = remove
+	return button
+}
+
+func (b *ritualBuilder) sectionChanged() {
+	if b.dialog == nil {
+		return
+	}
+	b.update()
+	b.dialog.Window().Pack()
+}
+
+func (b *ritualBuilder) update() {
+	if b.dialog == nil {
+		return
+	}
+	b.costLabel.SetTitle(fmt.Sprintf(i18n.Text("Energy Cost: %d (base %d × %d)"), b.ritual.EnergyCost(),
+		b.ritual.BaseEnergy(), b.ritual.EnergyMultiplier()))
+	b.skillLabel.SetTitle(ritualSkillText(b.entity, b.ritual))
+	b.dialog.Button(unison.ModalResponseOK).SetEnabled(b.ritual.Name != "" && len(b.ritual.Effects) != 0)
+	b.costLabel.MarkForLayoutRecursivelyUpward()
+	b.costLabel.MarkForRedraw()
+}
+
+// ritualSkillText returns a description of the skill rolled against when gathering energy for and casting the ritual.
+func ritualSkillText(entity *gurps.Entity, ritual *gurps.Ritual) string {
+	path, level, ok := entity.RitualSkill(ritual)
+	switch {
+	case ok:
+		return fmt.Sprintf(i18n.Text("Roll against %s-%s"), path.SkillName(), level.Trunc().String())
+	case len(ritual.Effects) == 0:
+		return i18n.Text("Add an effect to determine the skill to roll against")
+	default:
+		return fmt.Sprintf(i18n.Text("Requires the %s skill"), path.SkillName())
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+const rollModifiersTrayColumns = 4
+
+var (
+	_ unison.Dockable            = &RollModifiersTrayDockable{}
+	_ unison.UndoManagerProvider = &RollModifiersTrayDockable{}
+	_ GroupedCloser              = &RollModifiersTrayDockable{}
+)
+
+// RollModifiersTrayDockable holds the situational modifiers queued for a character. The queued modifiers are applied
+// to the next success roll made from the character's sheet and then cleared.
+type RollModifiersTrayDockable struct {
+	unison.Panel
+	sheet          *Sheet
+	undoMgr        *unison.UndoManager
+	content        *unison.Panel
+	scroll         *unison.ScrollPanel
+	scale          int
+	newDescription string
+	newModifier    int
+}
+
+// DisplayRollModifiersTray displays the modifiers tray for the given Sheet.
+func DisplayRollModifiersTray(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if t, ok := d.AsPanel().Self.(*RollModifiersTrayDockable); ok {
+			return t.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateRollModifiersTray(sheet)
+		return
+	}
+	t := &RollModifiersTrayDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	t.Self = t
+	t.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	t.SetLayout(&unison.FlexLayout{Columns: 1})
+	t.content = unison.NewPanel()
+	t.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	t.content.SetLayout(&unison.FlexLayout{
+		Columns:  rollModifiersTrayColumns,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	t.scroll = unison.NewScrollPanel()
+	t.scroll.SetContent(t.content, behavior.HintedFill, behavior.Fill)
+	t.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	t.AddChild(t.createToolbar())
+	t.AddChild(t.scroll)
+	t.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	t.refresh()
+	PlaceInDock(t, dgroup.Editors, false)
+}
+
+// UpdateRollModifiersTray refreshes the modifiers tray for the given Sheet, if it is being displayed.
+func UpdateRollModifiersTray(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if t, ok := other.(*RollModifiersTrayDockable); ok && t.sheet == sheet {
+			t.refresh()
+			break
+		}
+	}
+}
+
+// takeRollModifiers empties the modifiers tray of the entity, which may be nil, returning the total of the modifiers
+// that were in it and a description of them.
+func takeRollModifiers(entity *gurps.Entity) (total int, description string) {
+	if entity == nil || len(entity.RollModifiers) == 0 {
+		return 0, ""
+	}
+	total, description = entity.TakeRollModifiers()
+	for _, one := range AllDockables() {
+		if sheet, ok := one.(*Sheet); ok && sheet.Entity() == entity {
+			MarkModified(sheet)
+			UpdateRollModifiersTray(sheet)
+		}
+	}
+	return total, description
+}
+
+func (t *RollModifiersTrayDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return t.scale },
+			func(scale int) { t.scale = scale },
+			nil,
+			false,
+			t.scroll,
+		),
+	)
+	commonButton := unison.NewSVGButton(svg.Stack)
+	commonButton.Tooltip = newWrappedTooltip(i18n.Text("Queue a commonly used modifier"))
+	commonButton.ClickCallback = func() { t.showStandardMenu(commonButton) }
+	toolbar.AddChild(commonButton)
+	clearButton := unison.NewSVGButton(svg.Trash)
+	clearButton.Tooltip = newWrappedTooltip(i18n.Text("Remove all queued modifiers"))
+	clearButton.ClickCallback = func() {
+		t.applyChange(i18n.Text("Clear Modifiers Tray"), func(e *gurps.Entity) { e.RollModifiers = nil })
+	}
+	toolbar.AddChild(clearButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (t *RollModifiersTrayDockable) showStandardMenu(button *unison.Button) {
+	f := unison.DefaultMenuFactory()
+	cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	for i, one := range gurps.StandardRollModifiers() {
+		cm.InsertItem(-1, f.NewItem(unison.PopupMenuTemporaryBaseID+i+1, one.String(), unison.KeyBinding{}, nil,
+			func(_ unison.MenuItem) { t.queue(one) }))
+	}
+	button.FlushDrawing()
+	cm.Popup(button.RectToRoot(button.ContentRect(true)), 0)
+	cm.Dispose()
+}
+
+func (t *RollModifiersTrayDockable) queue(m *gurps.RollModifier) {
+	t.applyChange(fmt.Sprintf(i18n.Text("Queue %s"), m), func(e *gurps.Entity) {
+		e.QueueRollModifier(m.Description, m.Modifier)
+	})
+}
+
+// applyChange makes an undoable change to the character's queued modifiers.
+func (t *RollModifiersTrayDockable) applyChange(title string, f func(e *gurps.Entity)) {
+	entity := t.sheet.Entity()
+	before := gurps.CloneRollModifiers(entity.RollModifiers)
+	f(entity)
+	t.sheet.undoMgr.Add(&unison.UndoEdit[[]*gurps.RollModifier]{
+		ID:         unison.NextUndoID(),
+		EditName:   title,
+		UndoFunc:   func(edit *unison.UndoEdit[[]*gurps.RollModifier]) { t.applyModifiers(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[[]*gurps.RollModifier]) { t.applyModifiers(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  gurps.CloneRollModifiers(entity.RollModifiers),
+	})
+	MarkModified(t.sheet)
+	t.refresh()
+}
+
+func (t *RollModifiersTrayDockable) applyModifiers(list []*gurps.RollModifier) {
+	t.sheet.Entity().RollModifiers = gurps.CloneRollModifiers(list)
+	MarkModified(t.sheet)
+	UpdateRollModifiersTray(t.sheet)
+}
+
+func (t *RollModifiersTrayDockable) refresh() {
+	t.content.RemoveAllChildren()
+	entity := t.sheet.Entity()
+	t.addHeader(i18n.Text("Queued for the next success roll"))
+	if len(entity.RollModifiers) == 0 {
+		t.addSpanningLabel(i18n.Text("No modifiers are queued"))
+	} else {
+		for _, one := range entity.RollModifiers {
+			remove := unison.NewSVGButton(svg.Trash)
+			remove.Tooltip = newWrappedTooltip(i18n.Text("Remove this modifier"))
+			remove.ClickCallback = func() {
+				t.applyChange(fmt.Sprintf(i18n.Text("Remove %s"), one), func(e *gurps.Entity) {
+					e.RemoveRollModifier(one)
+				})
+			}
+			t.addModifierRow(one, remove, unison.NewPanel())
+		}
+		t.addSpanningLabel(fmt.Sprintf(i18n.Text("Total: %+d"), entity.RollModifiersTotal()))
+	}
+	t.addEntryRow()
+	presets := gurps.GlobalSettings().RollModifiers
+	if len(presets) != 0 {
+		t.addHeader(i18n.Text("Saved modifiers"))
+		for _, one := range presets {
+			queue := unison.NewSVGButton(svg.CircledAdd)
+			queue.Tooltip = newWrappedTooltip(i18n.Text("Queue this modifier"))
+			queue.ClickCallback = func() { t.queue(one) }
+			forget := unison.NewSVGButton(svg.Trash)
+			forget.Tooltip = newWrappedTooltip(i18n.Text("Forget this saved modifier"))
+			forget.ClickCallback = func() {
+				settings := gurps.GlobalSettings()
+				if i := slices.Index(settings.RollModifiers, one); i != -1 {
+					settings.RollModifiers = slices.Delete(settings.RollModifiers, i, i+1)
+				}
+				t.refresh()
+			}
+			t.addModifierRow(one, queue, forget)
+		}
+	}
+	t.content.MarkForLayoutRecursively()
+	t.content.MarkForRedraw()
+}
+
+func (t *RollModifiersTrayDockable) addHeader(title string) {
+	label := unison.NewLabel()
+	label.Font = unison.EmphasizedSystemFont
+	label.SetTitle(title)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: rollModifiersTrayColumns})
+	t.content.AddChild(label)
+}
+
+func (t *RollModifiersTrayDockable) addSpanningLabel(title string) {
+	label := unison.NewLabel()
+	label.SetTitle(title)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: rollModifiersTrayColumns})
+	t.content.AddChild(label)
+}
+
+func (t *RollModifiersTrayDockable) addModifierRow(m *gurps.RollModifier, first, second unison.Paneler) {
+	amount := unison.NewLabel()
+	amount.HAlign = align.End
+	amount.SetTitle(fmt.Sprintf("%+d", m.Modifier))
+	amount.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	t.content.AddChild(amount)
+	description := unison.NewLabel()
+	description.SetTitle(m.Description)
+	description.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	t.content.AddChild(description)
+	t.content.AddChild(first)
+	t.content.AddChild(second)
+}
+
+// addEntryRow adds the fields for entering a new modifier, which may be queued or saved for later use.
+func (t *RollModifiersTrayDockable) addEntryRow() {
+	amount := NewIntegerField(nil, "", i18n.Text("Modifier"), func() int { return t.newModifier },
+		func(v int) { t.newModifier = v }, -99, 99, true, false)
+	t.content.AddChild(amount)
+	description := NewStringField(nil, "", i18n.Text("Description"), func() string { return t.newDescription },
+		func(s string) { t.newDescription = s })
+	description.Watermark = i18n.Text("Description")
+	description.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	t.content.AddChild(description)
+	queueButton := unison.NewSVGButton(svg.CircledAdd)
+	queueButton.Tooltip = newWrappedTooltip(i18n.Text("Queue this modifier"))
+	queueButton.ClickCallback = func() {
+		if t.newModifier != 0 {
+			t.queue(&gurps.RollModifier{Description: t.newDescription, Modifier: t.newModifier})
+		}
+	}
+	t.content.AddChild(queueButton)
+	saveButton := unison.NewSVGButton(svg.Bookmark)
+	saveButton.Tooltip = newWrappedTooltip(i18n.Text("Save this modifier for later use"))
+	saveButton.ClickCallback = func() {
+		if t.newModifier != 0 {
+			settings := gurps.GlobalSettings()
+			settings.RollModifiers = append(settings.RollModifiers, &gurps.RollModifier{
+				Description: t.newDescription,
+				Modifier:    t.newModifier,
+			})
+			t.refresh()
+		}
+	}
+	t.content.AddChild(saveButton)
+}
+
+// TitleIcon implements unison.Dockable
+func (t *RollModifiersTrayDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSheet,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (t *RollModifiersTrayDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Modifiers Tray for %s"), t.sheet.String())
+}
+
+func (t *RollModifiersTrayDockable) String() string {
+	return t.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (t *RollModifiersTrayDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (t *RollModifiersTrayDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (t *RollModifiersTrayDockable) CloseWithGroup(other unison.Paneler) bool {
+	return t.sheet != nil && t.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (t *RollModifiersTrayDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(t)
+}
+
+// AttemptClose implements GroupedCloser
+func (t *RollModifiersTrayDockable) AttemptClose() bool {
+	if !CloseGroup(t) {
+		return false
+	}
+	return AttemptCloseForDockable(t)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (t *RollModifiersTrayDockable) UndoManager() *unison.UndoManager {
+	return t.undoMgr
+}
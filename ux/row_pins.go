@@ -0,0 +1,106 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+)
+
+type rowPinsUndoEdit = *unison.UndoEdit[*rowPinsState]
+
+type rowPinsState struct {
+	Sheet       *Sheet
+	PinnedRows  []tid.TID
+	LockedLists []string
+}
+
+func newRowPinsState(sheet *Sheet) *rowPinsState {
+	return &rowPinsState{
+		Sheet:       sheet,
+		PinnedRows:  slices.Clone(sheet.entity.PinnedRows),
+		LockedLists: slices.Clone(sheet.entity.OrderLockedLists),
+	}
+}
+
+func (s *rowPinsState) Apply() {
+	s.Sheet.entity.PinnedRows = slices.Clone(s.PinnedRows)
+	s.Sheet.entity.OrderLockedLists = slices.Clone(s.LockedLists)
+	s.Sheet.Rebuild(true)
+	MarkModified(s.Sheet)
+}
+
+// installRowPinningHandlers installs the handlers for pinning rows to the top of the list and for locking the list
+// against manual reordering. These are only available for lists within a sheet, as that is where the state is kept.
+func (p *PageList[T]) installRowPinningHandlers(owner Rebuildable) {
+	sheet, ok := owner.AsPanel().Self.(*Sheet)
+	if !ok {
+		return
+	}
+	listKey := p.Table.RefKey
+	p.InstallCmdHandlers(TogglePinnedItemID,
+		func(_ any) bool { return len(p.selectedTopLevelIDs()) != 0 },
+		func(_ any) {
+			before := newRowPinsState(sheet)
+			for _, id := range p.selectedTopLevelIDs() {
+				sheet.entity.SetPinned(id, !sheet.entity.IsPinned(id))
+			}
+			p.finishRowPinsChange(sheet, i18n.Text("Toggle Pinned"), before)
+		})
+	p.InstallCmdHandlers(ToggleOrderLockItemID,
+		func(_ any) bool { return true },
+		func(_ any) {
+			before := newRowPinsState(sheet)
+			sheet.entity.SetOrderLocked(listKey, !sheet.entity.IsOrderLocked(listKey))
+			p.finishRowPinsChange(sheet, i18n.Text("Toggle Ordering Lock"), before)
+		})
+	// Refuse drags that originate in this same list while its ordering is locked. Drags from elsewhere are still
+	// accepted, as they add rows rather than reorder them.
+	originalDataDragOverCallback := p.Table.DataDragOverCallback
+	p.Table.DataDragOverCallback = func(where unison.Point, data map[string]any) bool {
+		if sheet.entity.IsOrderLocked(listKey) {
+			if dd, exists := data[p.provider.DragKey()]; exists {
+				if dragData, isTableData := dd.(*unison.TableDragData[*Node[T]]); isTableData && dragData.Table == p.Table {
+					return false
+				}
+			}
+		}
+		return originalDataDragOverCallback(where, data)
+	}
+}
+
+func (p *PageList[T]) selectedTopLevelIDs() []tid.TID {
+	var zero T
+	var ids []tid.TID
+	for _, row := range p.Table.SelectedRows(false) {
+		if row.Data() != zero && row.dataAsNode.Parent() == zero {
+			ids = append(ids, row.dataAsNode.ID())
+		}
+	}
+	return ids
+}
+
+func (p *PageList[T]) finishRowPinsChange(sheet *Sheet, name string, before *rowPinsState) {
+	after := newRowPinsState(sheet)
+	if mgr := unison.UndoManagerFor(p.Table); mgr != nil {
+		mgr.Add(&unison.UndoEdit[*rowPinsState]{
+			ID:         unison.NextUndoID(),
+			EditName:   name,
+			UndoFunc:   func(edit rowPinsUndoEdit) { edit.BeforeData.Apply() },
+			RedoFunc:   func(edit rowPinsUndoEdit) { edit.AfterData.Apply() },
+			BeforeData: before,
+			AfterData:  after,
+		})
+	}
+	after.Apply()
+}
@@ -10,14 +10,20 @@
 package ux
 
 import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison"
 )
 
 // ScaleDelta is the delta used when adjusting the view scale incrementally.
 const ScaleDelta = 10
 
+var pageScalePresets = []int{50, 75, 100, 125}
+
 // NewScaleField creates a new scale field and hooks it into the target.
 func NewScaleField(minValue, maxValue int, defValue, get func() int, set func(int), afterApply func(), attemptCenter bool, scroller *unison.ScrollPanel) *PercentageField {
 	applyFunc := func() {
@@ -114,6 +120,79 @@ func NewScaleField(minValue, maxValue int, defValue, get func() int, set func(in
 	return scaleField
 }
 
+// NewPageScaleControls creates a scale field for a page-based view, along with buttons for commonly used scales and a
+// toggle that keeps the page scaled to fit the width of the view as it is resized. fitToWidth holds the state of the
+// toggle for the view.
+func NewPageScaleControls(minValue, maxValue int, defValue, get func() int, set func(int), fitToWidth *bool, scroller *unison.ScrollPanel) *unison.Panel {
+	scaleField := NewScaleField(minValue, maxValue, defValue, get, set, nil, false, scroller)
+	applyScale := func(scale int) {
+		scale = min(max(scale, minValue), maxValue)
+		if get() != scale {
+			enabled := scaleField.Enabled()
+			scaleField.SetEnabled(true)
+			SetFieldValue(scaleField.Field, scaleField.Format(scale))
+			scaleField.SetEnabled(enabled)
+		}
+	}
+	fit := func() {
+		if !*fitToWidth {
+			return
+		}
+		_, pref, _ := scroller.Content().AsPanel().Sizes(unison.Size{})
+		if pref.Width > 0 {
+			applyScale(int(xmath.Floor(scroller.ContentView().ContentRect(false).Width / pref.Width * 100)))
+		}
+	}
+	panel := unison.NewPanel()
+	panel.AddChild(scaleField)
+	presetButtons := make([]*unison.Button, 0, len(pageScalePresets))
+	for _, preset := range pageScalePresets {
+		if preset < minValue || preset > maxValue {
+			continue
+		}
+		button := unison.NewButton()
+		button.SetTitle(fmt.Sprintf("%d%%", preset))
+		button.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Set the scale to %d%%"), preset))
+		button.ClickCallback = func() { applyScale(preset) }
+		presetButtons = append(presetButtons, button)
+		panel.AddChild(button)
+	}
+	syncEnablement := func() {
+		scaleField.SetEnabled(!*fitToWidth)
+		for _, button := range presetButtons {
+			button.SetEnabled(!*fitToWidth)
+		}
+	}
+	fitButton := unison.NewSVGButton(svg.SizeToFit)
+	fitButton.Sticky = *fitToWidth
+	fitButton.Tooltip = newWrappedTooltip(i18n.Text("Toggle scaling the page to fit the width of the view"))
+	fitButton.ClickCallback = func() {
+		*fitToWidth = !*fitToWidth
+		fitButton.Sticky = *fitToWidth
+		fitButton.MarkForRedraw()
+		syncEnablement()
+		fit()
+	}
+	panel.AddChild(fitButton)
+	view := scroller.ContentView()
+	previous := view.FrameChangeCallback
+	view.FrameChangeCallback = func() {
+		if previous != nil {
+			previous()
+		}
+		if *fitToWidth {
+			// Adjusting the scale forces a layout, so wait until the current one has finished.
+			unison.InvokeTask(fit)
+		}
+	}
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  len(panel.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	syncEnablement()
+	return panel
+}
+
 func installViewScaleHandlers(paneler unison.Paneler, def func() int, minValue, maxValue int, current func() int, adjuster func(scale int)) {
 	p := paneler.AsPanel()
 	installViewScaleHandler(p, ScaleDefaultItemID, def(), minValue, maxValue, current, adjuster)
@@ -16,6 +16,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/check"
 )
@@ -128,6 +129,27 @@ func searchSheetTableRows[T gurps.NodeTypes](refList *[]*searchRef, text string,
 	}
 }
 
+func findSheetTableRowByID[T gurps.NodeTypes](refList *[]*searchRef, id tid.TID, pageList *PageList[T]) {
+	for _, row := range pageList.Table.RootRows() {
+		findSheetTableRowsByID(refList, id, pageList.Table, row)
+	}
+}
+
+func findSheetTableRowsByID[T gurps.NodeTypes](refList *[]*searchRef, id tid.TID, table *unison.Table[*Node[T]], row *Node[T]) {
+	if row.ID() == id {
+		*refList = append(*refList, &searchRef{
+			table: table,
+			row:   row,
+		})
+		return
+	}
+	if row.CanHaveChildren() {
+		for _, child := range row.Children() {
+			findSheetTableRowsByID(refList, id, table, child)
+		}
+	}
+}
+
 func (s *searchTracker) previousMatch() {
 	if s.searchIndex > 0 {
 		s.searchIndex--
@@ -0,0 +1,80 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sense"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+// SensesPanel holds the contents of the senses block on the sheet.
+type SensesPanel struct {
+	unison.Panel
+	entity *gurps.Entity
+}
+
+// NewSensesPanel creates a new senses panel.
+func NewSensesPanel(entity *gurps.Entity) *SensesPanel {
+	p := &SensesPanel{entity: entity}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: 4,
+		HAlign:   align.Middle,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.SetBorder(unison.NewCompoundBorder(&TitledBorder{Title: i18n.Text("Senses")}, unison.NewEmptyBorder(unison.Insets{
+		Top:    1,
+		Left:   2,
+		Bottom: 1,
+		Right:  2,
+	})))
+	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) { drawBandedBackground(p, gc, rect, 0, 2, nil) }
+	for _, one := range sense.Types {
+		p.addSense(one)
+	}
+	return p
+}
+
+func (p *SensesPanel) addSense(s sense.Type) {
+	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		level := p.entity.SenseLevel(s)
+		text := "–"
+		if !level.Unavailable {
+			text = level.Level.Trunc().String()
+		}
+		if text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		f.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Click to roll"), level.Tooltip)
+	})
+	makeRollable(p.entity, field.Label, func() string {
+		return rollTitle(p.entity, fmt.Sprintf(i18n.Text("%s Roll"), s.String()))
+	}, func() (int, bool) {
+		level := p.entity.SenseLevel(s)
+		if level.Unavailable {
+			return 0, false
+		}
+		return fxp.As[int](level.Level.Trunc()), true
+	})
+	p.AddChild(field)
+	p.AddChild(NewPageLabel(s.String()))
+}
@@ -52,10 +52,17 @@ type SettingsDockable struct {
 
 // Setup the dockable and display it.
 func (d *SettingsDockable) Setup(addToStartToolbar, addToEndToolbar, initContent func(*unison.Panel)) {
+	toolbar, content := d.SetupWithoutDisplay(addToStartToolbar, addToEndToolbar, initContent)
+	PlaceInDock(d, dgroup.Settings, false)
+	FocusFirstContent(toolbar, content)
+}
+
+// SetupWithoutDisplay sets up the dockable without displaying it, returning the toolbar and content panels.
+func (d *SettingsDockable) SetupWithoutDisplay(addToStartToolbar, addToEndToolbar, initContent func(*unison.Panel)) (toolbar, content *unison.Panel) {
 	d.SetLayout(&unison.FlexLayout{Columns: 1})
-	toolbar := d.createToolbar(addToStartToolbar, addToEndToolbar)
+	toolbar = d.createToolbar(addToStartToolbar, addToEndToolbar)
 	d.AddChild(toolbar)
-	content := unison.NewPanel()
+	content = unison.NewPanel()
 	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
 	initContent(content)
 	scroller := unison.NewScrollPanel()
@@ -67,8 +74,7 @@ func (d *SettingsDockable) Setup(addToStartToolbar, addToEndToolbar, initContent
 		VGrab:  true,
 	})
 	d.AddChild(scroller)
-	PlaceInDock(d, dgroup.Settings, false)
-	FocusFirstContent(toolbar, content)
+	return toolbar, content
 }
 
 // TitleIcon implements unison.Dockable
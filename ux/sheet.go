@@ -15,13 +15,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/sheetmode"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
@@ -66,6 +69,8 @@ type Sheet struct {
 	scroll               *unison.ScrollPanel
 	entity               *gurps.Entity
 	crc                  uint64
+	changeSnapshot       *gurps.ChangeSnapshot
+	eventTracker         gurps.EntityEventTracker
 	content              *unison.Panel
 	modifiedFunc         func()
 	Reactions            *PageList[*gurps.ConditionalModifier]
@@ -80,8 +85,16 @@ type Sheet struct {
 	Notes                *PageList[*gurps.Note]
 	dragReroutePanel     *unison.Panel
 	scale                int
+	houseRulesTokens     []*gurps.MonitorToken
 	awaitingUpdate       bool
+	awaitingHouseRules   bool
+	fitToWidth           bool
 	needsSaveAsPrompt    bool
+	dirty                map[string]bool
+	unmetPrereqsKey      string
+	sideBySide           *Sheet
+	scrollPartner        *Sheet
+	syncingScroll        bool
 }
 
 // ActiveSheet returns the currently active sheet.
@@ -113,6 +126,7 @@ func NewSheetFromFile(filePath string) (unison.Dockable, error) {
 	if err != nil {
 		return nil, err
 	}
+	entity.SyncWithHouseRules()
 	s := NewSheet(filePath, entity)
 	s.needsSaveAsPrompt = false
 	return s, nil
@@ -126,11 +140,13 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 		scroll:            unison.NewScrollPanel(),
 		entity:            entity,
 		crc:               entity.CRC64(),
+		changeSnapshot:    entity.TakeChangeSnapshot(),
 		scale:             gurps.GlobalSettings().General.InitialSheetUIScale,
 		content:           unison.NewPanel(),
 		needsSaveAsPrompt: true,
 	}
 	s.Self = s
+	entity.MarkPointsSaved()
 	s.targetMgr = NewTargetMgr(s)
 	s.SetLayout(&unison.FlexLayout{
 		Columns: 1,
@@ -193,6 +209,7 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 	})
 	s.createToolbar()
 	s.AddChild(s.scroll)
+	s.installScrollSync()
 
 	s.InstallCmdHandlers(SaveItemID, func(_ any) bool { return s.Modified() }, func(_ any) { s.save(false) })
 	s.InstallCmdHandlers(SaveAsItemID, unison.AlwaysEnabled, func(_ any) { s.save(true) })
@@ -206,6 +223,8 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 	s.installNewItemCmdHandlers(NewOtherEquipmentItemID, NewOtherEquipmentContainerItemID,
 		s.OtherEquipment)
 	s.installNewItemCmdHandlers(NewNoteItemID, NewNoteContainerItemID, s.Notes)
+	s.installNewItemCmdHandlers(NewNoteFromTemplateItemID, -1, s.Notes)
+	s.installLoadoutCmdHandlers()
 	s.InstallCmdHandlers(AddNaturalAttacksItemID, unison.AlwaysEnabled, func(_ any) {
 		InsertItems[*gurps.Trait](s, s.Traits.Table, s.entity.TraitList, s.entity.SetTraitList,
 			func(_ *unison.Table[*Node[*gurps.Trait]]) []*Node[*gurps.Trait] {
@@ -214,11 +233,38 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 	})
 	s.InstallCmdHandlers(SwapDefaultsItemID, s.canSwapDefaults, s.swapDefaults)
 	s.InstallCmdHandlers(ExportAsPDFItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPDF() })
+	s.InstallCmdHandlers(ExportAsPDFWithLayoutItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPDFWithLayout() })
 	s.InstallCmdHandlers(ExportAsWEBPItemID, unison.AlwaysEnabled, func(_ any) { s.exportToWEBP() })
 	s.InstallCmdHandlers(ExportAsPNGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPNG() })
 	s.InstallCmdHandlers(ExportAsJPEGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToJPEG() })
+	s.InstallCmdHandlers(ExportAsFoundryItemID, unison.AlwaysEnabled, func(_ any) {
+		s.exportToFile(gurps.FoundryActorExt, i18n.Text("Unable to export as a Foundry VTT actor!"), func(filePath string) error {
+			return gurps.ExportFoundryActor(s.entity, filePath)
+		})
+	})
+	s.InstallCmdHandlers(ExportAsRoll20ItemID, unison.AlwaysEnabled, func(_ any) {
+		s.exportToFile(gurps.Roll20CharacterExt, i18n.Text("Unable to export as a Roll20 character!"), func(filePath string) error {
+			return gurps.ExportRoll20Character(s.entity, filePath)
+		})
+	})
+	s.InstallCmdHandlers(ExportAsFantasyGroundsItemID, unison.AlwaysEnabled, func(_ any) {
+		s.exportToFile(gurps.FantasyGroundsExt, i18n.Text("Unable to export as a Fantasy Grounds character!"), func(filePath string) error {
+			return gurps.ExportFantasyGrounds(s.entity, filePath)
+		})
+	})
+	s.InstallCmdHandlers(ExportAsStatBlockItemID, unison.AlwaysEnabled, func(_ any) {
+		s.exportToFile(gurps.StatBlockExt, i18n.Text("Unable to export as a stat block!"), func(filePath string) error {
+			return gurps.ExportStatBlock(s.entity, filePath)
+		})
+	})
+	s.InstallCmdHandlers(ExportAsForumPostItemID, unison.AlwaysEnabled, func(_ any) { s.exportForumPost() })
+	s.InstallCmdHandlers(CompareSideBySideItemID, func(_ any) bool { return s.canCompareSideBySide() },
+		func(_ any) { s.compareSideBySide() })
+	s.InstallCmdHandlers(SyncScrollingItemID, func(_ any) bool { return s.canSyncScrolling() },
+		func(_ any) { s.toggleSyncScrolling() })
 	s.InstallCmdHandlers(PrintItemID, unison.AlwaysEnabled, func(_ any) { s.print() })
 	s.InstallCmdHandlers(ClearPortraitItemID, s.canClearPortrait, s.clearPortrait)
+	s.watchHouseRules()
 	return s
 }
 
@@ -239,14 +285,13 @@ func (s *Sheet) createToolbar() {
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Character Sheet") }
 	s.toolbar.AddChild(helpButton)
 	s.toolbar.AddChild(
-		NewScaleField(
+		NewPageScaleControls(
 			gurps.InitialUIScaleMin,
 			gurps.InitialUIScaleMax,
-			func() int { return gurps.GlobalSettings().General.InitialSheetUIScale },
+			func() int { return gurps.GlobalSettings().General.DefaultSheetUIScale },
 			func() int { return s.scale },
 			func(scale int) { s.scale = scale },
-			nil,
-			false,
+			&s.fitToWidth,
 			s.scroll,
 		),
 	)
@@ -272,22 +317,37 @@ func (s *Sheet) createToolbar() {
 	s.toolbar.AddChild(syncSourceButton)
 
 	calcButton := unison.NewSVGButton(svg.Calculator)
-	calcButton.Tooltip = newWrappedTooltip(i18n.Text("Calculators (jumping, throwing, hiking, etc.)"))
+	calcButton.Tooltip = newWrappedTooltip(i18n.Text("Calculators (jumping, throwing, hiking, grappling, etc.)"))
 	calcButton.ClickCallback = func() { DisplayCalculator(s) }
 	s.toolbar.AddChild(calcButton)
 
-	installSearchTracker(s.toolbar, func() {
-		s.Reactions.Table.ClearSelection()
-		s.ConditionalModifiers.Table.ClearSelection()
-		s.MeleeWeapons.Table.ClearSelection()
-		s.RangedWeapons.Table.ClearSelection()
-		s.Traits.Table.ClearSelection()
-		s.Skills.Table.ClearSelection()
-		s.Spells.Table.ClearSelection()
-		s.CarriedEquipment.Table.ClearSelection()
-		s.OtherEquipment.Table.ClearSelection()
-		s.Notes.Table.ClearSelection()
-	}, func(refList *[]*searchRef, text string, namesOnly bool) {
+	formsButton := unison.NewSVGButton(svg.Stack)
+	formsButton.Tooltip = newWrappedTooltip(i18n.Text("Switch Alternate Form"))
+	formsButton.ClickCallback = func() { ShowAlternateFormSwitcher(s) }
+	s.toolbar.AddChild(formsButton)
+
+	diceButton := unison.NewSVGButton(svg.Randomize)
+	diceButton.Tooltip = newWrappedTooltip(i18n.Text("Dice Roller"))
+	diceButton.ClickCallback = ShowDiceRoller
+	s.toolbar.AddChild(diceButton)
+
+	modePopup := NewPopup[sheetmode.Mode](s.targetMgr, "sheet_mode", i18n.Text("Sheet Mode"),
+		func() sheetmode.Mode { return s.entity.Mode },
+		func(mode sheetmode.Mode) { s.setMode(mode) }, sheetmode.Modes...)
+	modePopup.Tooltip = newWrappedTooltip(i18n.Text(`In creation mode, the creation limits are enforced and creation-only validation rules are checked. In play mode, these are relaxed, but changes are recorded in the change log when saving.`))
+	s.toolbar.AddChild(modePopup)
+
+	changeLogButton := unison.NewSVGButton(svg.ReleaseNotes)
+	changeLogButton.Tooltip = newWrappedTooltip(i18n.Text("Change Log"))
+	changeLogButton.ClickCallback = s.showChangeLog
+	s.toolbar.AddChild(changeLogButton)
+
+	validationButton := unison.NewSVGButton(unison.TriangleExclamationSVG)
+	validationButton.Tooltip = newWrappedTooltip(i18n.Text("Check validation rules"))
+	validationButton.ClickCallback = func() { DisplayValidation(s) }
+	s.toolbar.AddChild(validationButton)
+
+	installSearchTracker(s.toolbar, s.clearTableSelections, func(refList *[]*searchRef, text string, namesOnly bool) {
 		searchSheetTable(refList, text, namesOnly, s.Traits)
 		searchSheetTable(refList, text, namesOnly, s.Skills)
 		searchSheetTable(refList, text, namesOnly, s.Spells)
@@ -302,6 +362,66 @@ func (s *Sheet) createToolbar() {
 	})
 }
 
+func (s *Sheet) setMode(mode sheetmode.Mode) {
+	if s.entity.Mode != mode {
+		if mode == sheetmode.Play {
+			// Only changes made from this point forward should be recorded
+			s.changeSnapshot = s.entity.TakeChangeSnapshot()
+		}
+		s.entity.Mode = mode
+	}
+}
+
+func (s *Sheet) showChangeLog() {
+	var buffer strings.Builder
+	buffer.WriteString("# ")
+	buffer.WriteString(i18n.Text("Change Log"))
+	buffer.WriteString("\n\n")
+	if len(s.entity.ChangeLog) == 0 {
+		buffer.WriteString(i18n.Text("No changes have been recorded. Changes are recorded when the sheet is saved while in play mode."))
+		buffer.WriteString("\n")
+	} else {
+		var last string
+		for i := len(s.entity.ChangeLog) - 1; i >= 0; i-- {
+			entry := s.entity.ChangeLog[i]
+			if when := entry.When.String(); when != last {
+				last = when
+				fmt.Fprintf(&buffer, "\n## %s\n\n", when)
+			}
+			fmt.Fprintf(&buffer, "- %s\n", entry.Description)
+		}
+	}
+	ShowReadOnlyMarkdown(fmt.Sprintf(i18n.Text("%s Change Log"), s.String()), buffer.String())
+}
+
+func (s *Sheet) clearTableSelections() {
+	s.Reactions.Table.ClearSelection()
+	s.ConditionalModifiers.Table.ClearSelection()
+	s.MeleeWeapons.Table.ClearSelection()
+	s.RangedWeapons.Table.ClearSelection()
+	s.Traits.Table.ClearSelection()
+	s.Skills.Table.ClearSelection()
+	s.Spells.Table.ClearSelection()
+	s.CarriedEquipment.Table.ClearSelection()
+	s.OtherEquipment.Table.ClearSelection()
+	s.Notes.Table.ClearSelection()
+}
+
+func (s *Sheet) revealRow(id tid.TID) {
+	var refList []*searchRef
+	findSheetTableRowByID(&refList, id, s.Traits)
+	findSheetTableRowByID(&refList, id, s.Skills)
+	findSheetTableRowByID(&refList, id, s.Spells)
+	findSheetTableRowByID(&refList, id, s.CarriedEquipment)
+	findSheetTableRowByID(&refList, id, s.OtherEquipment)
+	findSheetTableRowByID(&refList, id, s.Notes)
+	if len(refList) != 0 {
+		ActivateDockable(s)
+		s.clearTableSelections()
+		showSearchRef(refList[0])
+	}
+}
+
 // DataOwner implements gurps.DataOwnerProvider.
 func (s *Sheet) DataOwner() gurps.DataOwner {
 	return s.entity
@@ -438,6 +558,7 @@ func (s *Sheet) MarkModified(src unison.Paneler) {
 		s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 		s.scroll.SetPosition(h, v)
 		UpdateCalculator(s)
+		UpdateValidation(s)
 	}
 }
 
@@ -462,25 +583,65 @@ func (s *Sheet) AttemptClose() bool {
 			return false
 		}
 	}
-	return AttemptCloseForDockable(s)
+	if !AttemptCloseForDockable(s) {
+		return false
+	}
+	s.stopWatchingHouseRules()
+	return true
 }
 
 func (s *Sheet) save(forceSaveAs bool) bool {
 	success := false
 	if forceSaveAs || s.needsSaveAsPrompt {
-		success = SaveDockableAs(s, gurps.SheetExt, s.entity.Save, func(path string) {
+		success = SaveDockableAs(s, gurps.SheetExt, s.saveWithHistory, func(path string) {
 			s.crc = s.entity.CRC64()
 			s.path = path
 		})
 	} else {
-		success = SaveDockable(s, s.entity.Save, func() { s.crc = s.entity.CRC64() })
+		success = SaveDockable(s, s.saveWithHistory, func() { s.crc = s.entity.CRC64() })
 	}
 	if success {
 		s.needsSaveAsPrompt = false
+		s.entity.MarkPointsSaved()
+		s.Rebuild(true)
+		gurps.PublishEntityEvents(gurps.NewFileSavedEntityEvent(s.entity, s.path))
+		if (s.entity.SheetSettings.ValidateOnSave || s.entity.Mode == sheetmode.Creation) &&
+			len(s.entity.Validate()) != 0 {
+			DisplayValidation(s)
+		}
 	}
 	return success
 }
 
+// saveWithHistory adds the change log entries, snapshot and points expenditure that a save records to the entity, then
+// writes it to the file. If the write fails, they are removed again, so that only successful saves leave a record.
+func (s *Sheet) saveWithHistory(filePath string) error {
+	e := s.entity
+	changeLogCount := len(e.ChangeLog)
+	snapshotCount := len(e.Snapshots)
+	expenditureCount := len(e.PointsExpenditures)
+	var changeSnapshot *gurps.ChangeSnapshot
+	if e.Mode == sheetmode.Play && e.LogChangesSince(s.changeSnapshot) {
+		changeSnapshot = e.TakeChangeSnapshot()
+	}
+	if e.SheetSettings.RecordSnapshotOnSave {
+		e.RecordSnapshot("")
+	}
+	if e.Mode != sheetmode.Creation {
+		e.RecordPointsExpenditure("")
+	}
+	if err := e.Save(filePath); err != nil {
+		e.ChangeLog = e.ChangeLog[:changeLogCount]
+		e.Snapshots = e.Snapshots[:snapshotCount]
+		e.PointsExpenditures = e.PointsExpenditures[:expenditureCount]
+		return err
+	}
+	if changeSnapshot != nil {
+		s.changeSnapshot = changeSnapshot
+	}
+	return nil
+}
+
 func (s *Sheet) print() {
 	data, err := newPageExporter(s.entity).exportAsPDFBytes()
 	if err != nil {
@@ -510,7 +671,7 @@ func (s *Sheet) exportToPDF() {
 	backingFilePath := s.BackingFilePath()
 	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
 	dialog.SetAllowedExtensions("pdf")
-	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(backingFilePath)))
+	dialog.SetInitialFileName(suggestedFileName(s))
 	if dialog.RunModal() {
 		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "pdf", false); ok {
 			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
@@ -521,17 +682,29 @@ func (s *Sheet) exportToPDF() {
 	}
 }
 
+func (s *Sheet) exportToPDFWithLayout() {
+	layout, ok := chooseSheetLayout()
+	if !ok {
+		return
+	}
+	s.exportToFile("pdf", i18n.Text("Unable to export as PDF!"), func(filePath string) error {
+		return exportWithSheetLayout(s.entity, layout, func(p *pageExporter) error {
+			return p.exportAsPDFFile(filePath)
+		})
+	})
+}
+
 func (s *Sheet) exportToWEBP() {
 	s.Window().ShowCursor()
 	dialog := unison.NewSaveDialog()
 	backingFilePath := s.BackingFilePath()
 	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
 	dialog.SetAllowedExtensions("webp")
-	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(backingFilePath)))
+	dialog.SetInitialFileName(suggestedFileName(s))
 	if dialog.RunModal() {
 		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "webp", false); ok {
 			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
-			if err := newPageExporter(s.entity).exportAsWEBPs(filePath); err != nil {
+			if err := newPageExporter(s.entity).exportAsWEBPs(filePath, DefaultImageExportOptions()); err != nil {
 				unison.ErrorDialogWithError(i18n.Text("Unable to export as WEBP!"), err)
 			}
 		}
@@ -539,39 +712,108 @@ func (s *Sheet) exportToWEBP() {
 }
 
 func (s *Sheet) exportToPNG() {
+	opts, ok := chooseImageExportOptions()
+	if !ok {
+		return
+	}
 	s.Window().ShowCursor()
 	dialog := unison.NewSaveDialog()
 	backingFilePath := s.BackingFilePath()
 	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
 	dialog.SetAllowedExtensions("png")
-	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(backingFilePath)))
+	dialog.SetInitialFileName(suggestedFileName(s))
 	if dialog.RunModal() {
 		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "png", false); ok {
 			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
-			if err := newPageExporter(s.entity).exportAsPNGs(filePath); err != nil {
+			if err := newPageExporter(s.entity).exportAsPNGs(filePath, opts); err != nil {
 				unison.ErrorDialogWithError(i18n.Text("Unable to export as PNG!"), err)
 			}
 		}
 	}
 }
 
+// chooseImageExportOptions asks the user for the resolution to render at and whether the pages should be combined into
+// a single image. The choices are remembered in the general settings.
+func chooseImageExportOptions() (ImageExportOptions, bool) {
+	opts := DefaultImageExportOptions()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	title := i18n.Text("Resolution")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	panel.AddChild(NewIntegerField(nil, "", title,
+		func() int { return opts.Resolution },
+		func(v int) { opts.Resolution = v },
+		gurps.ImageResolutionMin, gurps.ImageResolutionMax, false, false))
+	panel.AddChild(NewFieldTrailingLabel(i18n.Text("ppi"), false))
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Pages"), false))
+	choices := []string{i18n.Text("One numbered file per page"), i18n.Text("A single vertical strip")}
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(choices...)
+	if opts.Combined {
+		popup.Select(choices[1])
+	} else {
+		popup.Select(choices[0])
+	}
+	popup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Export…")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return opts, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return opts, false
+	}
+	opts.Combined = popup.SelectedIndex() == 1
+	general := gurps.GlobalSettings().General
+	general.ImageResolution = opts.Resolution
+	general.CombineImagePages = opts.Combined
+	return opts, true
+}
+
 func (s *Sheet) exportToJPEG() {
 	s.Window().ShowCursor()
 	dialog := unison.NewSaveDialog()
 	backingFilePath := s.BackingFilePath()
 	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
 	dialog.SetAllowedExtensions("jpeg")
-	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(backingFilePath)))
+	dialog.SetInitialFileName(suggestedFileName(s))
 	if dialog.RunModal() {
 		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "jpeg", false); ok {
 			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
-			if err := newPageExporter(s.entity).exportAsJPEGs(filePath); err != nil {
+			if err := newPageExporter(s.entity).exportAsJPEGs(filePath, DefaultImageExportOptions()); err != nil {
 				unison.ErrorDialogWithError(i18n.Text("Unable to export as JPEG!"), err)
 			}
 		}
 	}
 }
 
+// exportToFile asks the user where to export to, using the given extension, then calls f to write the file.
+func (s *Sheet) exportToFile(ext, failure string, f func(filePath string) error) {
+	s.Window().ShowCursor()
+	ext = strings.TrimPrefix(ext, ".")
+	dialog := unison.NewSaveDialog()
+	backingFilePath := s.BackingFilePath()
+	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
+	dialog.SetAllowedExtensions(ext)
+	dialog.SetInitialFileName(suggestedFileName(s))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), ext, false); ok {
+			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := f(filePath); err != nil {
+				unison.ErrorDialogWithError(failure, err)
+			}
+		}
+	}
+}
+
 func (s *Sheet) createLists() {
 	children := s.content.Children()
 	if len(children) == 0 {
@@ -588,6 +830,7 @@ func (s *Sheet) createLists() {
 	for i := len(children) - 1; i > 1; i-- {
 		page.RemoveChildAtIndex(i)
 	}
+	page.AddChild(NewConditionsPanel(s.entity, s.targetMgr))
 	// Add the various blocks, based on the layout preference.
 	for _, col := range s.entity.SheetSettings.BlockLayout.ByRow() {
 		rowPanel := unison.NewPanel()
@@ -610,7 +853,7 @@ func (s *Sheet) createLists() {
 					s.ConditionalModifiers.Sync()
 				}
 				SetDataOwnerProvider(s.ConditionalModifiers.Table, s)
-				if s.ConditionalModifiers.Table.RootRowCount() > 0 {
+				if !s.entity.SheetSettings.SimplifiedDisplay && s.ConditionalModifiers.Table.RootRowCount() > 0 {
 					rowPanel.AddChild(s.ConditionalModifiers)
 				}
 			case gurps.BlockLayoutMeleeKey:
@@ -624,7 +867,7 @@ func (s *Sheet) createLists() {
 					rowPanel.AddChild(s.MeleeWeapons)
 				}
 			case gurps.BlockLayoutRangedKey:
-				if s.RangedWeapons == nil {
+				if s.RangedWeapons.needReconstruction() {
 					s.RangedWeapons = NewRangedWeaponsPageList(s.entity)
 				} else {
 					s.RangedWeapons.Sync()
@@ -692,6 +935,9 @@ func (s *Sheet) createLists() {
 			page.AddChild(rowPanel)
 		}
 	}
+	if len(s.entity.Companions) != 0 {
+		page.AddChild(NewCompanionsPanel(s.entity, s.targetMgr, s.entity.Companions))
+	}
 	page.ApplyPreferredSize()
 }
 
@@ -741,6 +987,8 @@ func (s *Sheet) swapDefaults(_ any) {
 // SheetSettingsUpdated implements gurps.SheetSettingsResponder.
 func (s *Sheet) SheetSettingsUpdated(entity *gurps.Entity, blockLayout bool) {
 	if s.entity == entity {
+		s.watchHouseRules()
+		s.entity.SyncWithHouseRules()
 		s.MarkModified(nil)
 		s.Rebuild(blockLayout)
 	}
@@ -776,6 +1024,7 @@ func (s *sheetTablesUndoData) Apply() {
 }
 
 func (s *Sheet) syncWithAllSources() {
+	backupBeforeBulkOperation(s.BackingFilePath(), s.entity, syncWithSourceAction.Title)
 	var undo *unison.UndoEdit[*sheetTablesUndoData]
 	mgr := unison.UndoManagerFor(s)
 	if mgr != nil {
@@ -804,9 +1053,20 @@ func (s *Sheet) syncWithAllSources() {
 
 // Rebuild implements widget.Rebuildable.
 func (s *Sheet) Rebuild(full bool) {
+	dirty := s.takeDirty()
 	h, v := s.scroll.Position()
 	focusRefKey := s.targetMgr.CurrentFocusRef()
+	s.entity.ApplyPinnedRows()
 	s.entity.Recalculate()
+	unmetPrereqsKey := s.entity.UnmetPrereqsKey()
+	prereqsChanged := unmetPrereqsKey != s.unmetPrereqsKey
+	s.unmetPrereqsKey = unmetPrereqsKey
+	if full && dirty != nil && !prereqsChanged && s.syncDirtyLists(dirty) {
+		// Only the dirty lists needed their rows rebuilt; everything else just needs its values refreshed.
+		deepSyncSkippingLists(s)
+		s.finishRebuild(focusRefKey, h, v)
+		return
+	}
 	if full {
 		reactionsSelMap := s.Reactions.RecordSelection()
 		conditionalModifiersSelMap := s.ConditionalModifiers.RecordSelection()
@@ -833,10 +1093,23 @@ func (s *Sheet) Rebuild(full bool) {
 		s.createLists()
 	}
 	DeepSync(s)
+	s.finishRebuild(focusRefKey, h, v)
+}
+
+func (s *Sheet) finishRebuild(focusRefKey *FocusRef, h, v float32) {
 	UpdateTitleForDockable(s)
 	s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 	s.scroll.SetPosition(h, v)
 	UpdateCalculator(s)
+	UpdateValidation(s)
+	UpdatePointsJournal(s)
+	UpdateNotesJournal(s)
+	UpdateGrimoire(s)
+	UpdatePowers(s)
+	UpdateArmorTable(s)
+	UpdateRollModifiersTray(s)
+	updatePartyOverviewsForSheet(s)
+	gurps.PublishEntityEvents(s.eventTracker.Update(s.entity, s.path)...)
 }
 
 func drawBandedBackground(p unison.Paneler, gc *unison.Canvas, rect unison.Rect, start, step int, overrideFunc func(rowIndex int, ink unison.Ink) unison.Ink) {
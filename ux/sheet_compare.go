@@ -0,0 +1,117 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/side"
+)
+
+// sideBySideCandidates returns the other sheets within the same dock as this sheet, which are the ones that may be
+// placed beside it.
+func (s *Sheet) sideBySideCandidates() []*Sheet {
+	dock := unison.Ancestor[*unison.Dock](s)
+	if dock == nil {
+		return nil
+	}
+	var list []*Sheet
+	for _, one := range OpenSheets(s) {
+		if unison.Ancestor[*unison.Dock](one) == dock {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+func (s *Sheet) canCompareSideBySide() bool {
+	return len(s.sideBySideCandidates()) != 0
+}
+
+// compareSideBySide moves another sheet into its own dock container to the right of this one, so that both are visible
+// at once.
+func (s *Sheet) compareSideBySide() {
+	dc := unison.Ancestor[*unison.DockContainer](s)
+	if dc == nil {
+		return
+	}
+	choices := PromptForDestination(s.sideBySideCandidates())
+	if len(choices) == 0 {
+		return
+	}
+	other := choices[0]
+	s.setScrollPartner(nil)
+	other.setScrollPartner(nil)
+	dc.Dock.DockTo(other, dc, side.Right)
+	s.sideBySide = other
+	other.sideBySide = s
+	ActivateDockable(s)
+}
+
+func (s *Sheet) canSyncScrolling() bool {
+	return s.sideBySide != nil && s.sideBySide.Window() != nil
+}
+
+func (s *Sheet) toggleSyncScrolling() {
+	if s.scrollPartner != nil {
+		s.setScrollPartner(nil)
+		return
+	}
+	if s.canSyncScrolling() {
+		s.setScrollPartner(s.sideBySide)
+		h, v := s.scroll.Position()
+		s.scrollPartner.scroll.SetPosition(h, v)
+	}
+}
+
+// setScrollPartner links the scrolling of this sheet with the other sheet, or removes the link if other is nil.
+func (s *Sheet) setScrollPartner(other *Sheet) {
+	if s.scrollPartner != nil {
+		s.scrollPartner.scrollPartner = nil
+	}
+	s.scrollPartner = other
+	if other != nil {
+		if other.scrollPartner != nil {
+			other.scrollPartner.scrollPartner = nil
+		}
+		other.scrollPartner = s
+	}
+}
+
+// installScrollSync hooks the scroll bars so that a linked sheet follows this sheet's scroll position.
+func (s *Sheet) installScrollSync() {
+	for _, horizontal := range []bool{true, false} {
+		bar := s.scroll.Bar(horizontal)
+		original := bar.ChangedCallback
+		bar.ChangedCallback = func() {
+			if original != nil {
+				original()
+			}
+			s.syncScrollPartner()
+		}
+	}
+}
+
+func (s *Sheet) syncScrollPartner() {
+	partner := s.scrollPartner
+	if partner == nil || s.syncingScroll {
+		return
+	}
+	if partner.Window() == nil {
+		// The partner has been closed.
+		s.setScrollPartner(nil)
+		return
+	}
+	s.syncingScroll = true
+	partner.syncingScroll = true
+	h, v := s.scroll.Position()
+	partner.scroll.SetPosition(h, v)
+	partner.syncingScroll = false
+	s.syncingScroll = false
+}
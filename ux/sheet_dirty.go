@@ -0,0 +1,177 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+// DirtyTracker defines the methods a Rebuildable that can limit a full rebuild to just the regions that changed should
+// provide.
+type DirtyTracker interface {
+	// MarkDirty marks the blocks (using the gurps.BlockLayout*Key values) that need to be refreshed on the next full
+	// rebuild. If nothing was marked, a full rebuild refreshes everything.
+	MarkDirty(keys ...string)
+}
+
+// MarkDirtyFor marks the block that holds data of the given type, along with the blocks that depend on it, as dirty
+// on the owner, if the owner supports it. Data that has features, which can alter anything, doesn't limit the next
+// rebuild. Since removing features has the same reach as adding them, editors call this both before and after applying
+// a change.
+func MarkDirtyFor(owner any, data any) {
+	tracker, ok := owner.(DirtyTracker)
+	if !ok {
+		return
+	}
+	switch d := data.(type) {
+	case *gurps.Skill:
+		if d != nil && len(d.FeatureList()) != 0 {
+			tracker.MarkDirty(allBlockKeys...)
+			return
+		}
+		// Skill levels feed weapon levels and any spells or techniques that default to them.
+		tracker.MarkDirty(gurps.BlockLayoutSkillsKey, gurps.BlockLayoutSpellsKey, gurps.BlockLayoutMeleeKey,
+			gurps.BlockLayoutRangedKey)
+	case *gurps.Spell:
+		tracker.MarkDirty(gurps.BlockLayoutSpellsKey, gurps.BlockLayoutMeleeKey, gurps.BlockLayoutRangedKey)
+	case *gurps.Note:
+		tracker.MarkDirty(gurps.BlockLayoutNotesKey)
+	default:
+		tracker.MarkDirty(allBlockKeys...)
+	}
+}
+
+var allBlockKeys = []string{
+	gurps.BlockLayoutReactionsKey,
+	gurps.BlockLayoutConditionalModifiersKey,
+	gurps.BlockLayoutMeleeKey,
+	gurps.BlockLayoutRangedKey,
+	gurps.BlockLayoutTraitsKey,
+	gurps.BlockLayoutSkillsKey,
+	gurps.BlockLayoutSpellsKey,
+	gurps.BlockLayoutEquipmentKey,
+	gurps.BlockLayoutOtherEquipmentKey,
+	gurps.BlockLayoutNotesKey,
+}
+
+// MarkDirty implements DirtyTracker.
+func (s *Sheet) MarkDirty(keys ...string) {
+	if s.dirty == nil {
+		s.dirty = make(map[string]bool)
+	}
+	for _, key := range keys {
+		s.dirty[key] = true
+	}
+	// Notes may contain live field references to values from anywhere on the sheet.
+	if !s.dirty[gurps.BlockLayoutNotesKey] && s.entity.NotesHaveLiveFields() {
+		s.dirty[gurps.BlockLayoutNotesKey] = true
+	}
+}
+
+// takeDirty returns the current set of dirty blocks and clears it. A nil return means everything should be refreshed.
+func (s *Sheet) takeDirty() map[string]bool {
+	dirty := s.dirty
+	s.dirty = nil
+	if len(dirty) == len(allBlockKeys) {
+		return nil
+	}
+	return dirty
+}
+
+// pageListForKey returns the page list for the block key, or nil if there isn't one.
+func (s *Sheet) pageListForKey(key string) pageListSyncer {
+	switch key {
+	case gurps.BlockLayoutReactionsKey:
+		return asPageListSyncer(s.Reactions)
+	case gurps.BlockLayoutConditionalModifiersKey:
+		return asPageListSyncer(s.ConditionalModifiers)
+	case gurps.BlockLayoutMeleeKey:
+		return asPageListSyncer(s.MeleeWeapons)
+	case gurps.BlockLayoutRangedKey:
+		return asPageListSyncer(s.RangedWeapons)
+	case gurps.BlockLayoutTraitsKey:
+		return asPageListSyncer(s.Traits)
+	case gurps.BlockLayoutSkillsKey:
+		return asPageListSyncer(s.Skills)
+	case gurps.BlockLayoutSpellsKey:
+		return asPageListSyncer(s.Spells)
+	case gurps.BlockLayoutEquipmentKey:
+		return asPageListSyncer(s.CarriedEquipment)
+	case gurps.BlockLayoutOtherEquipmentKey:
+		return asPageListSyncer(s.OtherEquipment)
+	case gurps.BlockLayoutNotesKey:
+		return asPageListSyncer(s.Notes)
+	default:
+		return nil
+	}
+}
+
+type pageListSyncer interface {
+	unison.Paneler
+	Syncer
+	needReconstruction() bool
+	RowCount() int
+}
+
+func asPageListSyncer[T gurps.NodeTypes](list *PageList[T]) pageListSyncer {
+	if list == nil {
+		return nil
+	}
+	return list
+}
+
+// syncDirtyLists syncs just the dirty page lists. Returns false if the block structure itself needs to change, i.e. a
+// list needs to be reconstructed or shown or hidden, in which case the caller must fall back to recreating the lists.
+func (s *Sheet) syncDirtyLists(dirty map[string]bool) bool {
+	lists := make(map[string]pageListSyncer, len(dirty))
+	for key := range dirty {
+		list := s.pageListForKey(key)
+		if list == nil || list.needReconstruction() {
+			return false
+		}
+		lists[key] = list
+	}
+	for key, list := range lists {
+		list.Sync()
+		// These blocks are only present on the page when they have content, so a change in whether they have any
+		// requires the page to be laid out again.
+		switch key {
+		case gurps.BlockLayoutReactionsKey, gurps.BlockLayoutMeleeKey, gurps.BlockLayoutRangedKey:
+			if (list.AsPanel().Parent() != nil) != (list.RowCount() > 0) {
+				return false
+			}
+		case gurps.BlockLayoutConditionalModifiersKey:
+			if (list.AsPanel().Parent() != nil) != (!s.entity.SheetSettings.SimplifiedDisplay && list.RowCount() > 0) {
+				return false
+			}
+		}
+	}
+	if children := s.content.Children(); len(children) != 0 {
+		if page, ok := children[0].Self.(*Page); ok {
+			page.ApplyPreferredSize()
+		}
+	}
+	return true
+}
+
+// deepSyncSkippingLists works like DeepSync, but skips over page lists, which are handled separately.
+func deepSyncSkippingLists(panel unison.Paneler) {
+	p := panel.AsPanel()
+	if _, ok := p.Self.(pageListSyncer); ok {
+		return
+	}
+	for _, child := range p.Children() {
+		deepSyncSkippingLists(child)
+	}
+	if syncer, ok := p.Self.(Syncer); ok {
+		syncer.Sync()
+	}
+}
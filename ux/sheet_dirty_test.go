@@ -0,0 +1,47 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+type testDirtyTracker map[string]bool
+
+func (t testDirtyTracker) MarkDirty(keys ...string) {
+	for _, key := range keys {
+		t[key] = true
+	}
+}
+
+func TestMarkDirtyForSkill(t *testing.T) {
+	skill := gurps.NewSkill(gurps.NewEntity(), nil, false)
+	tracker := make(testDirtyTracker)
+	MarkDirtyFor(tracker, skill)
+	check.True(t, tracker[gurps.BlockLayoutSkillsKey])
+	check.True(t, tracker[gurps.BlockLayoutMeleeKey])
+	check.False(t, tracker[gurps.BlockLayoutReactionsKey])
+	check.False(t, tracker[gurps.BlockLayoutConditionalModifiersKey])
+
+	skill.Features = gurps.Features{gurps.NewReactionBonus()}
+	tracker = make(testDirtyTracker)
+	MarkDirtyFor(tracker, skill)
+	check.Equal(t, len(allBlockKeys), len(tracker), "a skill with features may affect any block")
+	check.True(t, tracker[gurps.BlockLayoutReactionsKey])
+	check.True(t, tracker[gurps.BlockLayoutConditionalModifiersKey])
+
+	tracker = make(testDirtyTracker)
+	MarkDirtyFor(tracker, (*gurps.Skill)(nil))
+	check.True(t, tracker[gurps.BlockLayoutSkillsKey], "a nil skill doesn't panic")
+	check.False(t, tracker[gurps.BlockLayoutReactionsKey])
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+	"github.com/rjeczalik/notify"
+)
+
+// watchHouseRules watches the libraries that hold the sheet's house rules files, replacing any previous watches. Nothing
+// is watched when the sheet has no house rules files.
+func (s *Sheet) watchHouseRules() {
+	s.stopWatchingHouseRules()
+	libs := gurps.GlobalSettings().Libraries()
+	watched := make(map[string]bool)
+	for _, libFile := range s.entity.SheetSettings.HouseRules {
+		if watched[libFile.Library] {
+			continue
+		}
+		watched[libFile.Library] = true
+		if lib, ok := libs[libFile.Library]; ok {
+			s.houseRulesTokens = append(s.houseRulesTokens, lib.Watch(s.houseRulesWatchCallback, true))
+		}
+	}
+}
+
+func (s *Sheet) stopWatchingHouseRules() {
+	for _, token := range s.houseRulesTokens {
+		token.Stop()
+	}
+	s.houseRulesTokens = nil
+}
+
+func (s *Sheet) houseRulesWatchCallback(lib *gurps.Library, fullPath string, _ notify.Event) {
+	if s.awaitingHouseRules {
+		return
+	}
+	rel, err := filepath.Rel(lib.Path(), fullPath)
+	if err != nil {
+		return
+	}
+	if !s.entity.SheetSettings.IsHouseRulesFile(gurps.LibraryFile{Library: lib.Key(), Path: rel}) {
+		return
+	}
+	// Saves generally arrive as a burst of events, so wait for them to settle before re-reading the file.
+	s.awaitingHouseRules = true
+	unison.InvokeTaskAfter(func() {
+		s.awaitingHouseRules = false
+		if s.Window() == nil {
+			return
+		}
+		crc := s.entity.CRC64()
+		s.entity.SyncWithHouseRules()
+		if crc != s.entity.CRC64() {
+			s.MarkModified(nil)
+			s.Rebuild(true)
+		}
+	}, 500*time.Millisecond)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// lastSheetLayoutPath holds the path of the sheet layout chosen most recently, so that it may be offered again.
+var lastSheetLayoutPath string
+
+type sheetLayoutChoice struct {
+	path  string
+	title string
+}
+
+func (c *sheetLayoutChoice) String() string {
+	return c.title
+}
+
+// exportWithSheetLayout calls f with a page exporter built using the layout. The entity's page settings and block
+// layout are replaced with those from the layout for the duration of the call, since pages consult them as they draw.
+func exportWithSheetLayout(entity *gurps.Entity, layout *gurps.SheetLayout, f func(p *pageExporter) error) error {
+	if layout == nil {
+		return f(newPageExporter(entity))
+	}
+	savedPage := entity.SheetSettings.Page
+	savedBlocks := entity.SheetSettings.BlockLayout
+	defer func() {
+		entity.SheetSettings.Page = savedPage
+		entity.SheetSettings.BlockLayout = savedBlocks
+	}()
+	if layout.Page != nil {
+		entity.SheetSettings.Page = layout.Page.Clone()
+	}
+	if layout.Blocks != nil {
+		entity.SheetSettings.BlockLayout = layout.Blocks.Clone()
+	}
+	return f(newPageExporterWithHeader(entity, layout.HeaderRows()))
+}
+
+// loadSheetLayout loads the sheet layout at the path.
+func loadSheetLayout(filePath string) (*gurps.SheetLayout, error) {
+	return gurps.NewSheetLayoutFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+}
+
+// chooseSheetLayout asks the user to pick one of the sheet layouts found in the libraries.
+func chooseSheetLayout() (*gurps.SheetLayout, bool) {
+	paths := gurps.SheetLayouts()
+	if len(paths) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("No sheet layouts are available"),
+			fmt.Sprintf(i18n.Text("Sheet layouts are loaded from files with the %s extension in the %q folder of a library."),
+				gurps.SheetLayoutExt, gurps.SheetLayoutsDirName))
+		return nil, false
+	}
+	choices := make([]*sheetLayoutChoice, 0, len(paths))
+	var selected *sheetLayoutChoice
+	for _, p := range paths {
+		choice := &sheetLayoutChoice{path: p, title: fs.BaseName(p)}
+		choices = append(choices, choice)
+		if p == lastSheetLayoutPath {
+			selected = choice
+		}
+	}
+	if selected == nil {
+		selected = choices[0]
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Sheet Layout"), false))
+	popup := unison.NewPopupMenu[*sheetLayoutChoice]()
+	popup.AddItem(choices...)
+	popup.Select(selected)
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Export")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return nil, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return nil, false
+	}
+	if choice, ok := popup.Selected(); ok {
+		selected = choice
+	}
+	layout, err := loadSheetLayout(selected.path)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load sheet layout"), err)
+		return nil, false
+	}
+	lastSheetLayoutPath = selected.path
+	return layout, true
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/merge"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type sheetMergeUndoEditData struct {
+	sheet            *Sheet
+	traits           PreservedTableData[*gurps.Trait]
+	skills           PreservedTableData[*gurps.Skill]
+	spells           PreservedTableData[*gurps.Spell]
+	carriedEquipment PreservedTableData[*gurps.Equipment]
+	otherEquipment   PreservedTableData[*gurps.Equipment]
+	notes            PreservedTableData[*gurps.Note]
+}
+
+func newSheetMergeUndoEditData(sheet *Sheet) (*sheetMergeUndoEditData, error) {
+	data := &sheetMergeUndoEditData{sheet: sheet}
+	if err := data.traits.Collect(sheet.Traits.Table); err != nil {
+		return nil, err
+	}
+	if err := data.skills.Collect(sheet.Skills.Table); err != nil {
+		return nil, err
+	}
+	if err := data.spells.Collect(sheet.Spells.Table); err != nil {
+		return nil, err
+	}
+	if err := data.carriedEquipment.Collect(sheet.CarriedEquipment.Table); err != nil {
+		return nil, err
+	}
+	if err := data.otherEquipment.Collect(sheet.OtherEquipment.Table); err != nil {
+		return nil, err
+	}
+	if err := data.notes.Collect(sheet.Notes.Table); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (d *sheetMergeUndoEditData) Apply() {
+	if err := d.traits.Apply(d.sheet.Traits.Table); err != nil {
+		errs.Log(err)
+	}
+	if err := d.skills.Apply(d.sheet.Skills.Table); err != nil {
+		errs.Log(err)
+	}
+	if err := d.spells.Apply(d.sheet.Spells.Table); err != nil {
+		errs.Log(err)
+	}
+	if err := d.carriedEquipment.Apply(d.sheet.CarriedEquipment.Table); err != nil {
+		errs.Log(err)
+	}
+	if err := d.otherEquipment.Apply(d.sheet.OtherEquipment.Table); err != nil {
+		errs.Log(err)
+	}
+	if err := d.notes.Apply(d.sheet.Notes.Table); err != nil {
+		errs.Log(err)
+	}
+	d.sheet.Rebuild(true)
+}
+
+func (s *Sheet) mergeFromFile() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	filePath := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	other, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load character sheet"), err)
+		return
+	}
+	options, ok := promptForMergeOptions(other)
+	if !ok {
+		return
+	}
+	backupBeforeBulkOperation(s.BackingFilePath(), s.entity, i18n.Text("Merge From File"))
+	var undo *unison.UndoEdit[*sheetMergeUndoEditData]
+	if beforeData, collectErr := newSheetMergeUndoEditData(s); collectErr != nil {
+		errs.Log(collectErr)
+	} else {
+		undo = &unison.UndoEdit[*sheetMergeUndoEditData]{
+			ID:         unison.NextUndoID(),
+			EditName:   i18n.Text("Merge From File"),
+			UndoFunc:   func(e *unison.UndoEdit[*sheetMergeUndoEditData]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*sheetMergeUndoEditData]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*sheetMergeUndoEditData], _ unison.Undoable) bool { return false },
+			BeforeData: beforeData,
+		}
+	}
+	s.entity.MergeFrom(other, options)
+	s.Traits.Table.SyncToModel()
+	s.Skills.Table.SyncToModel()
+	s.Spells.Table.SyncToModel()
+	s.CarriedEquipment.Table.SyncToModel()
+	s.OtherEquipment.Table.SyncToModel()
+	s.Notes.Table.SyncToModel()
+	s.Rebuild(true)
+	if undo != nil {
+		if undo.AfterData, err = newSheetMergeUndoEditData(s); err != nil {
+			errs.Log(err)
+		} else {
+			s.undoMgr.Add(undo)
+		}
+	}
+}
+
+func promptForMergeOptions(other *gurps.Entity) (*gurps.MergeOptions, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(fmt.Sprintf(i18n.Text("Merge which lists from %s?"), other.Profile.Name))
+	panel.AddChild(label)
+	type block struct {
+		title    string
+		count    int
+		checkBox *unison.CheckBox
+		target   *bool
+	}
+	var options gurps.MergeOptions
+	blocks := []*block{
+		{title: i18n.Text("Traits"), count: len(other.Traits), target: &options.Traits},
+		{title: i18n.Text("Skills"), count: len(other.Skills), target: &options.Skills},
+		{title: i18n.Text("Spells"), count: len(other.Spells), target: &options.Spells},
+		{title: i18n.Text("Carried Equipment"), count: len(other.CarriedEquipment), target: &options.CarriedEquipment},
+		{title: i18n.Text("Other Equipment"), count: len(other.OtherEquipment), target: &options.OtherEquipment},
+		{title: i18n.Text("Notes"), count: len(other.Notes), target: &options.Notes},
+	}
+	for _, one := range blocks {
+		one.checkBox = unison.NewCheckBox()
+		one.checkBox.SetTitle(fmt.Sprintf(i18n.Text("%s (%d)"), one.title, one.count))
+		one.checkBox.SetEnabled(one.count != 0)
+		panel.AddChild(one.checkBox)
+	}
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.AddChild(NewFieldLeadingLabel(i18n.Text("When an item already exists"), false))
+	popup := unison.NewPopupMenu[merge.Resolution]()
+	popup.AddItem(merge.Resolutions...)
+	popup.Select(merge.KeepBoth)
+	wrapper.AddChild(popup)
+	panel.AddChild(wrapper)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Merge"))})
+	if err != nil {
+		errs.Log(err)
+		return nil, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return nil, false
+	}
+	selected := false
+	for _, one := range blocks {
+		*one.target = one.checkBox.State == check.On
+		selected = selected || *one.target
+	}
+	options.Resolution, _ = popup.Selected() //nolint:errcheck // The default of KeepBoth on failure is acceptable
+	return &options, selected
+}
@@ -10,18 +10,26 @@
 package ux
 
 import (
+	"fmt"
 	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/progression"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/vtarget"
 	"github.com/richardwilkes/gcs/v5/model/paper"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/check"
+	"github.com/richardwilkes/unison/enums/imgfmt"
 	"github.com/richardwilkes/unison/enums/weight"
 )
 
@@ -41,10 +49,13 @@ type sheetSettingsDockable struct {
 	showEquipmentModifier              *unison.CheckBox
 	showSpellAdjustments               *unison.CheckBox
 	hideSourceMismatch                 *unison.CheckBox
+	simplifiedDisplay                  *unison.CheckBox
 	showTitleInsteadOfNameInPageFooter *unison.CheckBox
 	useMultiplicativeModifiers         *unison.CheckBox
 	useModifyDicePlusAdds              *unison.CheckBox
 	excludeUnspentPointsFromTotal      *unison.CheckBox
+	includeCompanionsInExports         *unison.CheckBox
+	recordSnapshotOnSave               *unison.CheckBox
 	useHalfStatDefaults                *unison.CheckBox
 	lengthUnitsPopup                   *unison.PopupMenu[fxp.LengthUnit]
 	weightUnitsPopup                   *unison.PopupMenu[fxp.WeightUnit]
@@ -59,6 +70,24 @@ type sheetSettingsDockable struct {
 	bottomMarginField                  *unison.Field
 	rightMarginField                   *unison.Field
 	blockLayoutField                   *unison.Field
+	validateOnSave                     *unison.CheckBox
+	spellsRequireMagery                *unison.CheckBox
+	blockUnmetSpellsInCreation         *unison.CheckBox
+	creationDisadvantageLimitField     *DecimalField
+	creationQuirkLimitField            *DecimalField
+	validationRules                    *unison.Panel
+	houseRules                         *unison.Panel
+	nameables                          *unison.Panel
+	restFields                         []*IntegerField
+	hpPerSleepField                    *DecimalField
+	thresholdMagicEnabled              *unison.CheckBox
+	thresholdField                     *DecimalField
+	thresholdRecoveryField             *DecimalField
+	exportHeaderField                  *unison.Field
+	exportFooterField                  *unison.Field
+	watermarkTextField                 *unison.Field
+	watermarkImageLabel                *unison.Label
+	watermarkOpacityField              *IntegerField
 }
 
 // ShowSheetSettings the Sheet Settings. Pass in nil to edit the defaults or a sheet to edit the sheet's.
@@ -116,6 +145,12 @@ func (d *sheetSettingsDockable) initContent(content *unison.Panel) {
 	d.createWhereToDisplay(content)
 	d.createPageSettings(content)
 	d.createBlockLayout(content)
+	d.createExportDecorations(content)
+	d.createValidation(content)
+	d.createHouseRules(content)
+	d.createNameables(content)
+	d.createRest(content)
+	d.createThresholdMagic(content)
 }
 
 func (d *sheetSettingsDockable) createDamageProgression(content *unison.Panel) {
@@ -150,6 +185,12 @@ func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 		HSpacing: unison.StdHSpacing,
 		VSpacing: unison.StdVSpacing,
 	})
+	d.simplifiedDisplay = d.addCheckBox(panel,
+		i18n.Text("Use simplified display (hides advanced columns, blocks & modifier details)"), s.SimplifiedDisplay,
+		func() {
+			d.settings().SimplifiedDisplay = d.simplifiedDisplay.State == check.On
+			d.syncSheet(true)
+		})
 	d.hideSourceMismatch = d.addCheckBox(panel, i18n.Text("Show library source column"),
 		!s.HideSourceMismatch, func() {
 			d.settings().HideSourceMismatch = d.hideSourceMismatch.State != check.On
@@ -195,6 +236,15 @@ func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 			d.settings().ExcludeUnspentPointsFromTotal = d.excludeUnspentPointsFromTotal.State == check.On
 			d.syncSheet(false)
 		})
+	d.includeCompanionsInExports = d.addCheckBox(panel, i18n.Text("Include companions & mounts in exports"),
+		s.IncludeCompanionsInExports, func() {
+			d.settings().IncludeCompanionsInExports = d.includeCompanionsInExports.State == check.On
+			d.syncSheet(false)
+		})
+	d.recordSnapshotOnSave = d.addCheckBox(panel, i18n.Text("Record a snapshot in the character's history when saving"),
+		s.RecordSnapshotOnSave, func() {
+			d.settings().RecordSnapshotOnSave = d.recordSnapshotOnSave.State == check.On
+		})
 	content.AddChild(panel)
 }
 
@@ -334,6 +384,503 @@ func (d *sheetSettingsDockable) createBlockLayout(content *unison.Panel) {
 	content.AddChild(panel)
 }
 
+func (d *sheetSettingsDockable) createExportDecorations(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.createHeader(panel, i18n.Text("Export Header, Footer & Watermark"), 2)
+	placeholders := fmt.Sprintf(i18n.Text("Drawn on each page of PDF and image exports. %s, %s, %s, %s, %s and %s are replaced by the page number, page count, character name, title, player name and date."),
+		gurps.ExportPagePlaceholder, gurps.ExportPageCountPlaceholder, gurps.ExportNamePlaceholder,
+		gurps.ExportTitlePlaceholder, gurps.ExportPlayerPlaceholder, gurps.ExportDatePlaceholder)
+	d.exportHeaderField = d.addExportDecorationField(panel, i18n.Text("Header"), placeholders,
+		func(decorations *gurps.ExportDecorations) *string { return &decorations.Header })
+	d.exportFooterField = d.addExportDecorationField(panel, i18n.Text("Footer"), placeholders,
+		func(decorations *gurps.ExportDecorations) *string { return &decorations.Footer })
+	d.watermarkTextField = d.addExportDecorationField(panel, i18n.Text("Watermark Text"),
+		i18n.Text("Drawn across the middle of each page of PDF and image exports"),
+		func(decorations *gurps.ExportDecorations) *string { return &decorations.WatermarkText })
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Watermark Image"), false))
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+	})
+	d.watermarkImageLabel = unison.NewLabel()
+	d.syncWatermarkImageLabel()
+	wrapper.AddChild(d.watermarkImageLabel)
+	chooseButton := unison.NewButton()
+	chooseButton.SetTitle(i18n.Text("Choose…"))
+	chooseButton.Tooltip = newWrappedTooltip(i18n.Text("The image file is referenced rather than copied into the sheet, so it should be placed within one of your libraries"))
+	chooseButton.ClickCallback = d.chooseWatermarkImage
+	wrapper.AddChild(chooseButton)
+	clearButton := unison.NewButton()
+	clearButton.SetTitle(i18n.Text("Clear"))
+	clearButton.ClickCallback = func() {
+		d.settings().ExportDecorations.WatermarkImage = nil
+		d.syncWatermarkImageLabel()
+	}
+	wrapper.AddChild(clearButton)
+	panel.AddChild(wrapper)
+
+	title := i18n.Text("Watermark Opacity")
+	wrapper = addFlowWrapper(panel, title, 2)
+	d.watermarkOpacityField = NewIntegerField(nil, "", title,
+		func() int { return d.settings().ExportDecorations.WatermarkOpacity },
+		func(v int) { d.settings().ExportDecorations.WatermarkOpacity = v },
+		gurps.WatermarkOpacityMin, gurps.WatermarkOpacityMax, false, false)
+	wrapper.AddChild(d.watermarkOpacityField)
+	wrapper.AddChild(NewFieldTrailingLabel("%", false))
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) addExportDecorationField(panel *unison.Panel, title, tooltip string, value func(decorations *gurps.ExportDecorations) *string) *unison.Field {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	field := unison.NewField()
+	field.SetText(*value(d.settings().ExportDecorations))
+	field.Watermark = i18n.Text("None")
+	field.Tooltip = newWrappedTooltip(tooltip)
+	field.ModifiedCallback = func(_, after *unison.FieldState) {
+		*value(d.settings().ExportDecorations) = strings.TrimSpace(after.Text)
+	}
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(field)
+	return field
+}
+
+func (d *sheetSettingsDockable) chooseWatermarkImage() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(imgfmt.AllReadableExtensions()...)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.ImagesLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	file := dialog.Path()
+	global.SetLastDir(gurps.ImagesLastDirKey, filepath.Dir(file))
+	data, err := xio.RetrieveData(file)
+	if err == nil {
+		_, err = unison.NewImageFromBytes(data, 0.5)
+	}
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load the watermark image"), err)
+		return
+	}
+	libFile, ok := gurps.LibraryFileForPath(file)
+	if !ok {
+		libFile = gurps.LibraryFile{Path: file}
+	}
+	d.settings().ExportDecorations.WatermarkImage = &libFile
+	d.syncWatermarkImageLabel()
+}
+
+func (d *sheetSettingsDockable) syncWatermarkImageLabel() {
+	if img := d.settings().ExportDecorations.WatermarkImage; img == nil {
+		d.watermarkImageLabel.SetTitle(i18n.Text("None"))
+	} else {
+		d.watermarkImageLabel.SetTitle(filepath.Base(img.Path))
+	}
+	d.watermarkImageLabel.MarkForLayoutAndRedraw()
+}
+
+func (d *sheetSettingsDockable) createRest(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.createHeader(panel, i18n.Text("Rest & Recovery"), 2)
+	d.restFields = []*IntegerField{
+		d.addRestField(panel, i18n.Text("Short Rest Minutes"), func(r *gurps.RestSettings) *int { return &r.ShortRestMinutes }, 1),
+		d.addRestField(panel, i18n.Text("Long Rest Minutes"), func(r *gurps.RestSettings) *int { return &r.LongRestMinutes }, 1),
+		d.addRestField(panel, i18n.Text("Sleep Minutes"), func(r *gurps.RestSettings) *int { return &r.SleepMinutes }, 1),
+		d.addRestField(panel, i18n.Text("Minutes per FP Recovered"), func(r *gurps.RestSettings) *int { return &r.FPMinutesPerPoint }, 0),
+		d.addRestField(panel, i18n.Text("Minutes per ER Recovered"), func(r *gurps.RestSettings) *int { return &r.ERMinutesPerPoint }, 0),
+	}
+	title := i18n.Text("HP Recovered per Sleep")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	d.hpPerSleepField = NewDecimalField(nil, "", title,
+		func() fxp.Int { return d.settings().Rest.HPPerSleep },
+		func(v fxp.Int) { d.settings().Rest.HPPerSleep = v }, 0, fxp.Thousand, false, false)
+	panel.AddChild(d.hpPerSleepField)
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) addRestField(panel *unison.Panel, title string, value func(r *gurps.RestSettings) *int, minValue int) *IntegerField {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	field := NewIntegerField(nil, "", title,
+		func() int { return *value(d.settings().Rest) },
+		func(v int) { *value(d.settings().Rest) = v }, minValue, 99999, false, false)
+	if minValue == 0 {
+		field.Tooltip = newWrappedTooltip(i18n.Text("Use 0 to prevent recovery while resting"))
+	}
+	panel.AddChild(field)
+	return field
+}
+
+func (d *sheetSettingsDockable) createThresholdMagic(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.createHeader(panel, i18n.Text("Threshold-Limited Magic"), 2)
+	d.thresholdMagicEnabled = d.addCheckBox(panel, i18n.Text("Track a threshold tally for spell casting"),
+		d.settings().ThresholdMagic.Enabled, func() {
+			d.settings().ThresholdMagic.Enabled = d.thresholdMagicEnabled.State == check.On
+			d.syncSheet(true)
+		})
+	d.thresholdMagicEnabled.Tooltip = newWrappedTooltip(i18n.Text("When enabled, the energy cost of each spell cast is added to the threshold tally shown with the point pools instead of being paid from FP or ER"))
+	d.thresholdMagicEnabled.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	title := i18n.Text("Calamity Threshold")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	d.thresholdField = NewDecimalField(nil, "", title,
+		func() fxp.Int { return d.settings().ThresholdMagic.Threshold },
+		func(v fxp.Int) {
+			d.settings().ThresholdMagic.Threshold = v
+			d.syncSheet(false)
+		}, fxp.One, fxp.Thousand, false, false)
+	d.thresholdField.Tooltip = newWrappedTooltip(i18n.Text("A calamity check is required for each spell cast while the tally exceeds this"))
+	panel.AddChild(d.thresholdField)
+	title = i18n.Text("Tally Recovered per Sleep")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	d.thresholdRecoveryField = NewDecimalField(nil, "", title,
+		func() fxp.Int { return d.settings().ThresholdMagic.RecoveryRate },
+		func(v fxp.Int) { d.settings().ThresholdMagic.RecoveryRate = v }, 0, fxp.Thousand, false, false)
+	panel.AddChild(d.thresholdRecoveryField)
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) createValidation(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.createHeader(panel, i18n.Text("Validation Rules"), 1)
+	d.validateOnSave = d.addCheckBox(panel, i18n.Text("Check validation rules when saving"), s.ValidateOnSave,
+		func() { d.settings().ValidateOnSave = d.validateOnSave.State == check.On })
+	d.spellsRequireMagery = d.addCheckBox(panel, i18n.Text("Spells require Magery"), s.SpellsRequireMagery,
+		func() {
+			d.settings().SpellsRequireMagery = d.spellsRequireMagery.State == check.On
+			d.syncSheet(false)
+		})
+	d.spellsRequireMagery.Tooltip = newWrappedTooltip(i18n.Text("Spells, other than ritual magic spells, are marked as unsatisfied when the character has no Magery"))
+	d.blockUnmetSpellsInCreation = d.addCheckBox(panel,
+		i18n.Text("Block adding spells with unmet requirements while in creation mode"), s.BlockUnmetSpellsInCreation,
+		func() { d.settings().BlockUnmetSpellsInCreation = d.blockUnmetSpellsInCreation.State == check.On })
+	limits := unison.NewPanel()
+	limits.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.creationDisadvantageLimitField = d.addCreationLimitField(limits, i18n.Text("Creation Disadvantage Limit"),
+		func() fxp.Int { return d.settings().CreationDisadvantageLimit },
+		func(v fxp.Int) { d.settings().CreationDisadvantageLimit = v })
+	d.creationQuirkLimitField = d.addCreationLimitField(limits, i18n.Text("Creation Quirk Limit"),
+		func() fxp.Int { return d.settings().CreationQuirkLimit },
+		func(v fxp.Int) { d.settings().CreationQuirkLimit = v })
+	panel.AddChild(limits)
+	d.validationRules = unison.NewPanel()
+	d.validationRules.SetLayout(&unison.FlexLayout{
+		Columns:  6,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.validationRules.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.validationRules)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Validation Rule"))
+	addButton.ClickCallback = func() {
+		localSettings := d.settings()
+		localSettings.ValidationRules = append(localSettings.ValidationRules, &gurps.ValidationRule{Target: vtarget.Skills})
+		d.rebuildValidationRules()
+	}
+	panel.AddChild(addButton)
+	d.rebuildValidationRules()
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) addCreationLimitField(panel *unison.Panel, title string, get func() fxp.Int, set func(fxp.Int)) *DecimalField {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	field := NewDecimalField(nil, "", title, get, func(v fxp.Int) {
+		set(v)
+		d.syncSheet(false)
+	}, 0, fxp.Thousand, false, false)
+	field.Tooltip = newWrappedTooltip(i18n.Text("The limit applied while a character is in creation mode. Use 0 for no limit."))
+	panel.AddChild(field)
+	return field
+}
+
+func (d *sheetSettingsDockable) rebuildValidationRules() {
+	d.validationRules.RemoveAllChildren()
+	for _, rule := range d.settings().ValidationRules {
+		d.addValidationRule(rule)
+	}
+	d.validationRules.MarkForLayoutRecursivelyUpward()
+	d.validationRules.MarkForRedraw()
+}
+
+func (d *sheetSettingsDockable) addValidationRule(rule *gurps.ValidationRule) {
+	enabled := unison.NewCheckBox()
+	enabled.State = check.FromBool(!rule.Disabled)
+	enabled.Tooltip = newWrappedTooltip(i18n.Text("Enabled"))
+	enabled.ClickCallback = func() { rule.Disabled = enabled.State != check.On }
+	d.validationRules.AddChild(enabled)
+
+	targetPopup := unison.NewPopupMenu[vtarget.Type]()
+	for _, one := range vtarget.Types {
+		targetPopup.AddItem(one)
+	}
+	targetPopup.Select(rule.Target)
+	targetPopup.SelectionChangedCallback = func(p *unison.PopupMenu[vtarget.Type]) {
+		if item, ok := p.Selected(); ok {
+			rule.Target = item
+		}
+	}
+	d.validationRules.AddChild(targetPopup)
+
+	creationOnly := unison.NewCheckBox()
+	creationOnly.SetTitle(i18n.Text("Creation only"))
+	creationOnly.State = check.FromBool(rule.CreationOnly)
+	creationOnly.Tooltip = newWrappedTooltip(i18n.Text("Only check this rule while the character is in creation mode"))
+	creationOnly.ClickCallback = func() { rule.CreationOnly = creationOnly.State == check.On }
+	d.validationRules.AddChild(creationOnly)
+
+	expressionText := i18n.Text("Expression which must be true, e.g. $level <= $attribute + 4")
+	expression := unison.NewField()
+	expression.SetText(rule.Expression)
+	expression.Watermark = expressionText
+	expression.Tooltip = newWrappedTooltip(expressionText)
+	expression.ModifiedCallback = func(_, after *unison.FieldState) { rule.Expression = after.Text }
+	expression.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.validationRules.AddChild(expression)
+
+	messageText := i18n.Text("Message to show when the rule fails")
+	message := unison.NewField()
+	message.SetText(rule.Message)
+	message.Watermark = messageText
+	message.Tooltip = newWrappedTooltip(messageText)
+	message.ModifiedCallback = func(_, after *unison.FieldState) { rule.Message = after.Text }
+	message.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.validationRules.AddChild(message)
+
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Validation Rule"))
+	deleteButton.ClickCallback = func() {
+		localSettings := d.settings()
+		if i := slices.Index(localSettings.ValidationRules, rule); i != -1 {
+			localSettings.ValidationRules = slices.Delete(localSettings.ValidationRules, i, i+1)
+			d.rebuildValidationRules()
+		}
+	}
+	d.validationRules.AddChild(deleteButton)
+}
+
+func (d *sheetSettingsDockable) createHouseRules(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.createHeader(panel, i18n.Text("House Rules"), 1)
+	label := unison.NewLabel()
+	label.Font = fonts.FieldSecondary
+	label.SetTitle(i18n.Text("Items copied from these library files are kept in sync whenever the files change."))
+	panel.AddChild(label)
+	d.houseRules = unison.NewPanel()
+	d.houseRules.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.houseRules.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.houseRules)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add House Rules File"))
+	addButton.ClickCallback = d.addHouseRulesFile
+	panel.AddChild(addButton)
+	d.rebuildHouseRules()
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) rebuildHouseRules() {
+	d.houseRules.RemoveAllChildren()
+	libs := gurps.GlobalSettings().Libraries()
+	for _, one := range d.settings().HouseRules {
+		libFile := one
+		label := unison.NewLabel()
+		if lib, ok := libs[libFile.Library]; ok {
+			label.SetTitle(fmt.Sprintf("%s: %s", lib.Title, libFile.Path))
+		} else {
+			label.SetTitle(libFile.String())
+		}
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		d.houseRules.AddChild(label)
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove House Rules File"))
+		deleteButton.ClickCallback = func() {
+			localSettings := d.settings()
+			if i := slices.Index(localSettings.HouseRules, libFile); i != -1 {
+				localSettings.HouseRules = slices.Delete(localSettings.HouseRules, i, i+1)
+				d.rebuildHouseRules()
+				d.syncSheet(false)
+			}
+		}
+		d.houseRules.AddChild(deleteButton)
+	}
+	d.houseRules.MarkForLayoutRecursivelyUpward()
+	d.houseRules.MarkForRedraw()
+}
+
+func (d *sheetSettingsDockable) addHouseRulesFile() {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.TraitsExt, gurps.TraitModifiersExt, gurps.SkillsExt, gurps.SpellsExt,
+		gurps.EquipmentExt, gurps.EquipmentModifiersExt, gurps.NotesExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	dialog.SetInitialDirectory(gurps.GlobalSettings().Libraries().User().Path())
+	if !dialog.RunModal() {
+		return
+	}
+	libFile, ok := gurps.LibraryFileForPath(dialog.Path())
+	if !ok {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to add house rules file"),
+			i18n.Text("House rules files must reside within one of the configured libraries."))
+		return
+	}
+	localSettings := d.settings()
+	if !slices.Contains(localSettings.HouseRules, libFile) {
+		localSettings.HouseRules = append(localSettings.HouseRules, libFile)
+		d.rebuildHouseRules()
+		d.syncSheet(false)
+	}
+}
+
+func (d *sheetSettingsDockable) createNameables(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.createHeader(panel, i18n.Text("Substitutions"), 1)
+	label := unison.NewLabel()
+	label.Font = fonts.FieldSecondary
+	label.SetTitle(i18n.Text("These values pre-populate the prompts for substitutions, such as deity or guild names."))
+	panel.AddChild(label)
+	d.nameables = unison.NewPanel()
+	d.nameables.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.nameables.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.nameables)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Substitution"))
+	addButton.ClickCallback = func() {
+		localSettings := d.settings()
+		localSettings.Nameables = append(localSettings.Nameables, &gurps.NameableSubstitution{})
+		d.rebuildNameables()
+	}
+	panel.AddChild(addButton)
+	d.rebuildNameables()
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) rebuildNameables() {
+	d.nameables.RemoveAllChildren()
+	for _, one := range d.settings().Nameables {
+		substitution := one
+		keyText := i18n.Text("Key, e.g. Deity")
+		key := unison.NewField()
+		key.SetText(substitution.Key)
+		key.Watermark = keyText
+		key.Tooltip = newWrappedTooltip(keyText)
+		key.SetMinimumTextWidthUsing("Something reasonable")
+		key.ModifiedCallback = func(_, after *unison.FieldState) { substitution.Key = strings.TrimSpace(after.Text) }
+		d.nameables.AddChild(key)
+
+		valueText := i18n.Text("Value to substitute")
+		value := unison.NewField()
+		value.SetText(substitution.Value)
+		value.Watermark = valueText
+		value.Tooltip = newWrappedTooltip(valueText)
+		value.ModifiedCallback = func(_, after *unison.FieldState) { substitution.Value = after.Text }
+		value.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		d.nameables.AddChild(value)
+
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Substitution"))
+		deleteButton.ClickCallback = func() {
+			localSettings := d.settings()
+			if i := slices.Index(localSettings.Nameables, substitution); i != -1 {
+				localSettings.Nameables = slices.Delete(localSettings.Nameables, i, i+1)
+				d.rebuildNameables()
+			}
+		}
+		d.nameables.AddChild(deleteButton)
+	}
+	d.nameables.MarkForLayoutRecursivelyUpward()
+	d.nameables.MarkForRedraw()
+}
+
 func (d *sheetSettingsDockable) createPaperMarginField(panel *unison.Panel, title string, current paper.Length, set func(value paper.Length)) *unison.Field {
 	panel.AddChild(NewFieldLeadingLabel(title, false))
 	field := unison.NewField()
@@ -403,6 +950,7 @@ func (d *sheetSettingsDockable) reset() {
 func (d *sheetSettingsDockable) sync() {
 	s := d.settings()
 	d.damageProgressionPopup.Select(s.DamageProgression)
+	d.simplifiedDisplay.State = check.FromBool(s.SimplifiedDisplay)
 	d.hideSourceMismatch.State = check.FromBool(!s.HideSourceMismatch)
 	d.showTraitModifier.State = check.FromBool(s.ShowTraitModifierAdj)
 	d.showEquipmentModifier.State = check.FromBool(s.ShowEquipmentModifierAdj)
@@ -412,6 +960,8 @@ func (d *sheetSettingsDockable) sync() {
 	d.useHalfStatDefaults.State = check.FromBool(s.UseHalfStatDefaults)
 	d.useModifyDicePlusAdds.State = check.FromBool(s.UseModifyingDicePlusAdds)
 	d.excludeUnspentPointsFromTotal.State = check.FromBool(s.ExcludeUnspentPointsFromTotal)
+	d.includeCompanionsInExports.State = check.FromBool(s.IncludeCompanionsInExports)
+	d.recordSnapshotOnSave.State = check.FromBool(s.RecordSnapshotOnSave)
 	d.lengthUnitsPopup.Select(s.DefaultLengthUnits)
 	d.weightUnitsPopup.Select(s.DefaultWeightUnits)
 	d.userDescDisplayPopup.Select(s.UserDescriptionDisplay)
@@ -425,6 +975,26 @@ func (d *sheetSettingsDockable) sync() {
 	d.bottomMarginField.SetText(s.Page.BottomMargin.String())
 	d.rightMarginField.SetText(s.Page.RightMargin.String())
 	d.blockLayoutField.SetText(s.BlockLayout.String())
+	d.validateOnSave.State = check.FromBool(s.ValidateOnSave)
+	d.spellsRequireMagery.State = check.FromBool(s.SpellsRequireMagery)
+	d.blockUnmetSpellsInCreation.State = check.FromBool(s.BlockUnmetSpellsInCreation)
+	d.creationDisadvantageLimitField.Sync()
+	d.creationQuirkLimitField.Sync()
+	d.rebuildValidationRules()
+	d.rebuildHouseRules()
+	d.rebuildNameables()
+	d.exportHeaderField.SetText(s.ExportDecorations.Header)
+	d.exportFooterField.SetText(s.ExportDecorations.Footer)
+	d.watermarkTextField.SetText(s.ExportDecorations.WatermarkText)
+	d.syncWatermarkImageLabel()
+	d.watermarkOpacityField.Sync()
+	for _, field := range d.restFields {
+		field.Sync()
+	}
+	d.hpPerSleepField.Sync()
+	d.thresholdMagicEnabled.State = check.FromBool(s.ThresholdMagic.Enabled)
+	d.thresholdField.Sync()
+	d.thresholdRecoveryField.Sync()
 	d.MarkForRedraw()
 }
 
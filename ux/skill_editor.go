@@ -39,6 +39,9 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 	addNotesLabelAndField(content, &e.editorData.LocalNotes)
 	addVTTNotesLabelAndField(content, &e.editorData.VTTNotes)
 	addTagsLabelAndField(content, &e.editorData.Tags)
+	addLabelAndStringField(content, i18n.Text("Power Talent"),
+		i18n.Text("The name of the talent whose level is added to this skill. Contained skills inherit it."),
+		&e.editorData.PowerTalent)
 	entity := gurps.EntityFromNode(e.target)
 	if e.target.Container() {
 		addTemplateChoices(content, nil, "", &e.editorData.TemplatePicker)
@@ -143,6 +146,8 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 			wrapper := addFlowWrapper(content, encLabel, 2)
 			addDecimalField(wrapper, nil, "", encLabel, "", &e.editorData.EncumbrancePenaltyMultiplier, 0, fxp.Nine)
 			wrapper.AddChild(NewFieldTrailingLabel(i18n.Text("times the current encumbrance level"), false))
+			addLabelAndListField(content, i18n.Text("Wildcard Covers"), i18n.Text("skills"),
+				&e.editorData.WildcardCovers)
 		}
 
 		if ownerIsSheet || ownerIsTemplate {
@@ -156,10 +161,11 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 				points := gurps.AdjustedPointsForNonContainerSkillOrTechnique(entity, e.editorData.Points, localName,
 					localSpec, e.editorData.Tags, nil)
 				var level gurps.Level
+				var techDef *gurps.SkillDefault
 				if e.target.IsTechnique() {
-					level = gurps.CalculateTechniqueLevel(entity, e.target.NameableReplacements(), localName, localSpec,
-						e.editorData.Tags, e.editorData.TechniqueDefault, e.editorData.Difficulty.Difficulty, points,
-						true, e.editorData.TechniqueLimitModifier, nil)
+					level, techDef = gurps.CalculateBestTechniqueLevel(entity, e.target.NameableReplacements(), localName,
+						localSpec, e.editorData.Tags, e.editorData.TechniqueDefaults(), e.editorData.Difficulty.Difficulty,
+						points, true, e.editorData.TechniqueLimitModifier, nil)
 				} else {
 					level = gurps.CalculateSkillLevel(entity, localName, localSpec, e.editorData.Tags,
 						e.editorData.DefaultedFrom, e.editorData.Difficulty, points,
@@ -170,8 +176,8 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 					field.SetTitle("-")
 				} else {
 					rsl := level.RelativeLevel
-					if e.target.IsTechnique() {
-						rsl += e.editorData.TechniqueDefault.Modifier
+					if techDef != nil {
+						rsl += techDef.Modifier
 					}
 					field.SetTitle(lvl.String() + "/" + gurps.FormatRelativeSkill(entity,
 						e.target.IsTechnique(), e.editorData.Difficulty, rsl))
@@ -191,7 +197,13 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 	addSourceFields(content, &e.target.SourcedID)
 	if !e.target.Container() {
 		content.AddChild(newPrereqPanel(entity, &e.editorData.Prereq))
-		content.AddChild(newDefaultsPanel(entity, &e.editorData.Defaults))
+		if e.target.IsTechnique() {
+			content.AddChild(newDefaultsPanel(entity, i18n.Text("Alternate Technique Defaults"),
+				gurps.TenFlag|gurps.SkillFlag|gurps.ParryFlag|gurps.BlockFlag|gurps.DodgeFlag,
+				&e.editorData.TechniqueAlternateDefaults))
+		}
+		content.AddChild(newDefaultsPanel(entity, i18n.Text("Defaults"),
+			gurps.TenFlag|gurps.ParryFlag|gurps.BlockFlag|gurps.SkillFlag, &e.editorData.Defaults))
 		content.AddChild(newFeaturesPanel(entity, e.target, &e.editorData.Features, false))
 		content.AddChild(newWeaponsPanel(e, e.target, true, &e.editorData.Weapons))
 		content.AddChild(newWeaponsPanel(e, e.target, false, &e.editorData.Weapons))
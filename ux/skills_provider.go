@@ -57,6 +57,11 @@ func (p *skillsProvider) AllTags() []string {
 
 func (p *skillsProvider) SetTable(table *unison.Table[*Node[*gurps.Skill]]) {
 	p.table = table
+	if entity, ok := p.provider.(*gurps.Entity); ok && p.forPage {
+		table.ClientData()[nodeOrderKey] = func(list []*gurps.Skill) []*gurps.Skill {
+			return gurps.SortedSkills(entity, list, entity.SheetSettings.SkillOrder)
+		}
+	}
 }
 
 func (p *skillsProvider) RootRowCount() int {
@@ -64,7 +69,7 @@ func (p *skillsProvider) RootRowCount() int {
 }
 
 func (p *skillsProvider) RootRows() []*Node[*gurps.Skill] {
-	data := p.provider.SkillList()
+	data := orderedNodeData(p.table, p.provider.SkillList())
 	rows := make([]*Node[*gurps.Skill], 0, len(data))
 	for _, one := range data {
 		rows = append(rows, NewNode[*gurps.Skill](p.table, nil, one, p.forPage))
@@ -143,11 +148,11 @@ func (p *skillsProvider) ColumnIDs() []int {
 	columnIDs := make([]int, 0, 5)
 	columnIDs = append(columnIDs, gurps.SkillDescriptionColumn)
 	if p.forPage {
-		if _, ok := p.provider.(*gurps.Entity); ok {
-			columnIDs = append(columnIDs,
-				gurps.SkillLevelColumn,
-				gurps.SkillRelativeLevelColumn,
-			)
+		if entity, ok := p.provider.(*gurps.Entity); ok {
+			columnIDs = append(columnIDs, gurps.SkillLevelColumn)
+			if !entity.SheetSettings.SimplifiedDisplay {
+				columnIDs = append(columnIDs, gurps.SkillRelativeLevelColumn)
+			}
 		}
 		columnIDs = append(columnIDs, gurps.SkillPointsColumn)
 	} else {
@@ -158,7 +163,7 @@ func (p *skillsProvider) ColumnIDs() []int {
 	}
 	columnIDs = append(columnIDs, gurps.SkillReferenceColumn)
 	if p.forPage {
-		if entity := p.DataOwner().OwningEntity(); entity == nil || !entity.SheetSettings.HideSourceMismatch {
+		if entity := p.DataOwner().OwningEntity(); entity == nil || entity.SheetSettings.ShowLibrarySourceColumn() {
 			columnIDs = append(columnIDs, gurps.SkillLibSrcColumn)
 		}
 	}
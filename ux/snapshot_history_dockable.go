@@ -0,0 +1,341 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/weight"
+)
+
+var (
+	_ unison.Dockable            = &SnapshotHistoryDockable{}
+	_ unison.UndoManagerProvider = &SnapshotHistoryDockable{}
+	_ GroupedCloser              = &SnapshotHistoryDockable{}
+)
+
+type snapshotChoice struct {
+	snapshot *gurps.CharacterSnapshot
+}
+
+func (c *snapshotChoice) String() string {
+	if c.snapshot == nil {
+		return i18n.Text("Current")
+	}
+	if c.snapshot.Label != "" {
+		return fmt.Sprintf("%s (%s)", c.snapshot.Label, c.snapshot.When.String())
+	}
+	return c.snapshot.When.String()
+}
+
+// SnapshotHistoryDockable displays the differences between snapshots of a character taken at different times.
+type SnapshotHistoryDockable struct {
+	unison.Panel
+	sheet      *Sheet
+	undoMgr    *unison.UndoManager
+	fromPopup  *unison.PopupMenu[*snapshotChoice]
+	toPopup    *unison.PopupMenu[*snapshotChoice]
+	content    *unison.Panel
+	scroll     *unison.ScrollPanel
+	scale      int
+	rebuilding bool
+}
+
+// DisplaySnapshotHistory displays the snapshot history for the given Sheet.
+func DisplaySnapshotHistory(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if h, ok := d.AsPanel().Self.(*SnapshotHistoryDockable); ok {
+			return h.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateSnapshotHistory(sheet)
+		return
+	}
+	h := &SnapshotHistoryDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	h.Self = h
+	h.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	h.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	h.content = unison.NewPanel()
+	h.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	h.content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	h.scroll = unison.NewScrollPanel()
+	h.scroll.SetContent(h.content, behavior.HintedFill, behavior.Fill)
+	h.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	h.AddChild(h.createToolbar())
+	h.AddChild(h.scroll)
+	h.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	h.rebuildChoices()
+	PlaceInDock(h, dgroup.Editors, false)
+}
+
+// UpdateSnapshotHistory refreshes the snapshot history for the given Sheet, if it is being displayed.
+func UpdateSnapshotHistory(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if h, ok := other.(*SnapshotHistoryDockable); ok && h.sheet == sheet {
+			h.rebuildChoices()
+			break
+		}
+	}
+}
+
+func (h *SnapshotHistoryDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return h.scale },
+			func(scale int) { h.scale = scale },
+			nil,
+			false,
+			h.scroll,
+		),
+	)
+	recordButton := unison.NewSVGButton(svg.CircledAdd)
+	recordButton.Tooltip = newWrappedTooltip(i18n.Text("Record a snapshot of the character as it is now"))
+	recordButton.ClickCallback = h.recordSnapshot
+	toolbar.AddChild(recordButton)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Compare the snapshots again"))
+	refreshButton.ClickCallback = h.refresh
+	toolbar.AddChild(refreshButton)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("From"), false))
+	h.fromPopup = unison.NewPopupMenu[*snapshotChoice]()
+	h.fromPopup.SelectionChangedCallback = func(_ *unison.PopupMenu[*snapshotChoice]) { h.refresh() }
+	toolbar.AddChild(h.fromPopup)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("To"), false))
+	h.toPopup = unison.NewPopupMenu[*snapshotChoice]()
+	h.toPopup.SelectionChangedCallback = func(_ *unison.PopupMenu[*snapshotChoice]) { h.refresh() }
+	toolbar.AddChild(h.toPopup)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (h *SnapshotHistoryDockable) recordSnapshot() {
+	entity := h.sheet.Entity()
+	before := gurps.CloneSnapshots(entity.Snapshots)
+	if entity.RecordSnapshot("") == nil {
+		unison.WarningDialogWithMessage(i18n.Text("Nothing has changed since the most recent snapshot."), "")
+		return
+	}
+	h.sheet.undoMgr.Add(&unison.UndoEdit[[]*gurps.CharacterSnapshot]{
+		ID:       unison.NextUndoID(),
+		EditName: i18n.Text("Record Snapshot"),
+		UndoFunc: func(edit *unison.UndoEdit[[]*gurps.CharacterSnapshot]) {
+			h.applySnapshots(edit.BeforeData)
+		},
+		RedoFunc: func(edit *unison.UndoEdit[[]*gurps.CharacterSnapshot]) {
+			h.applySnapshots(edit.AfterData)
+		},
+		BeforeData: before,
+		AfterData:  gurps.CloneSnapshots(entity.Snapshots),
+	})
+	MarkModified(h.sheet)
+	h.rebuildChoices()
+}
+
+func (h *SnapshotHistoryDockable) applySnapshots(list []*gurps.CharacterSnapshot) {
+	h.sheet.Entity().Snapshots = gurps.CloneSnapshots(list)
+	MarkModified(h.sheet)
+	UpdateSnapshotHistory(h.sheet)
+}
+
+// rebuildChoices reloads the snapshots into the popups, comparing the most recent snapshot with the character's
+// current state by default.
+func (h *SnapshotHistoryDockable) rebuildChoices() {
+	h.rebuilding = true
+	snapshots := h.sheet.Entity().Snapshots
+	h.fromPopup.RemoveAllItems()
+	h.toPopup.RemoveAllItems()
+	for _, one := range snapshots {
+		h.fromPopup.AddItem(&snapshotChoice{snapshot: one})
+		h.toPopup.AddItem(&snapshotChoice{snapshot: one})
+	}
+	h.toPopup.AddItem(&snapshotChoice{})
+	if len(snapshots) != 0 {
+		h.fromPopup.SelectIndex(len(snapshots) - 1)
+	}
+	h.toPopup.SelectIndex(len(snapshots))
+	h.rebuilding = false
+	h.refresh()
+}
+
+func (h *SnapshotHistoryDockable) selectedSnapshot(popup *unison.PopupMenu[*snapshotChoice]) *gurps.CharacterSnapshot {
+	choice, ok := popup.Selected()
+	if !ok {
+		return nil
+	}
+	if choice.snapshot == nil {
+		return h.sheet.Entity().TakeCharacterSnapshot("")
+	}
+	return choice.snapshot
+}
+
+func (h *SnapshotHistoryDockable) refresh() {
+	if h.rebuilding {
+		return
+	}
+	h.content.RemoveAllChildren()
+	from := h.selectedSnapshot(h.fromPopup)
+	to := h.selectedSnapshot(h.toPopup)
+	switch {
+	case from == nil:
+		h.addNote(i18n.Text("No snapshots have been recorded yet"))
+	case to == nil:
+		h.addNote(i18n.Text("Choose the snapshot to compare against"))
+	default:
+		diff := gurps.DiffSnapshots(from, to)
+		if diff.Empty() {
+			h.addNote(i18n.Text("No changes"))
+		} else {
+			h.addLine(i18n.Text("Points Earned"), diff.TotalPointsDelta.StringWithSign())
+			h.addLine(i18n.Text("Points Spent"), diff.SpentPointsDelta.StringWithSign())
+			h.addCategory(i18n.Text("Attributes"), &diff.Attributes)
+			h.addCategory(i18n.Text("Traits"), &diff.Traits)
+			h.addCategory(i18n.Text("Skills"), &diff.Skills)
+			h.addCategory(i18n.Text("Spells"), &diff.Spells)
+		}
+	}
+	h.content.MarkForLayoutRecursively()
+	h.content.MarkForRedraw()
+}
+
+func (h *SnapshotHistoryDockable) addNote(text string) {
+	label := unison.NewLabel()
+	label.SetTitle(text)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	h.content.AddChild(label)
+}
+
+func (h *SnapshotHistoryDockable) addCategory(title string, diff *gurps.SnapshotCategoryDiff) {
+	if diff.Empty() {
+		return
+	}
+	header := unison.NewLabel()
+	desc := header.Font.Descriptor()
+	desc.Weight = weight.Bold
+	header.Font = desc.Font()
+	header.SetTitle(title)
+	header.SetBorder(unison.NewEmptyBorder(unison.Insets{Top: unison.StdVSpacing * 2}))
+	header.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	h.content.AddChild(header)
+	for _, row := range diff.Added {
+		h.addLine(row.Name, fmt.Sprintf(i18n.Text("Added (%s)"), formatSnapshotRow(row)))
+	}
+	for _, change := range diff.Changed {
+		h.addLine(change.After.Name, fmt.Sprintf("%s → %s", formatSnapshotRow(change.Before),
+			formatSnapshotRow(change.After)))
+	}
+	for _, row := range diff.Removed {
+		h.addLine(row.Name, fmt.Sprintf(i18n.Text("Removed (%s)"), formatSnapshotRow(row)))
+	}
+}
+
+func (h *SnapshotHistoryDockable) addLine(title, text string) {
+	h.content.AddChild(NewFieldLeadingLabel(title, false))
+	label := unison.NewLabel()
+	label.SetTitle(text)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	h.content.AddChild(label)
+}
+
+func formatSnapshotRow(row *gurps.SnapshotRow) string {
+	if row.Level == 0 {
+		return fmt.Sprintf(i18n.Text("%s pts"), row.Points.Comma())
+	}
+	return fmt.Sprintf(i18n.Text("level %s, %s pts"), row.Level.Comma(), row.Points.Comma())
+}
+
+// TitleIcon implements unison.Dockable
+func (h *SnapshotHistoryDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSheet,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (h *SnapshotHistoryDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("History for %s"), h.sheet.String())
+}
+
+func (h *SnapshotHistoryDockable) String() string {
+	return h.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (h *SnapshotHistoryDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (h *SnapshotHistoryDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (h *SnapshotHistoryDockable) CloseWithGroup(other unison.Paneler) bool {
+	return h.sheet != nil && h.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (h *SnapshotHistoryDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(h)
+}
+
+// AttemptClose implements GroupedCloser
+func (h *SnapshotHistoryDockable) AttemptClose() bool {
+	if !CloseGroup(h) {
+		return false
+	}
+	return AttemptCloseForDockable(h)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (h *SnapshotHistoryDockable) UndoManager() *unison.UndoManager {
+	return h.undoMgr
+}
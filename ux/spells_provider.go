@@ -10,6 +10,8 @@
 package ux
 
 import (
+	"strings"
+
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -18,6 +20,7 @@ import (
 	"github.com/richardwilkes/toolbox/collection/dict"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/unison"
 )
@@ -57,6 +60,11 @@ func (p *spellsProvider) AllTags() []string {
 
 func (p *spellsProvider) SetTable(table *unison.Table[*Node[*gurps.Spell]]) {
 	p.table = table
+	if entity, ok := p.provider.(*gurps.Entity); ok && p.forPage {
+		table.ClientData()[nodeOrderKey] = func(list []*gurps.Spell) []*gurps.Spell {
+			return gurps.SortedSpells(entity, list, entity.SheetSettings.SpellOrder)
+		}
+	}
 }
 
 func (p *spellsProvider) RootRowCount() int {
@@ -64,7 +72,7 @@ func (p *spellsProvider) RootRowCount() int {
 }
 
 func (p *spellsProvider) RootRows() []*Node[*gurps.Spell] {
-	data := p.provider.SpellList()
+	data := orderedNodeData(p.table, p.provider.SpellList())
 	rows := make([]*Node[*gurps.Spell], 0, len(data))
 	for _, one := range data {
 		rows = append(rows, NewNode[*gurps.Spell](p.table, nil, one, p.forPage))
@@ -100,7 +108,7 @@ func (p *spellsProvider) DropShouldMoveData(from, to *unison.Table[*Node[*gurps.
 	return from == to
 }
 
-func (p *spellsProvider) ProcessDropData(_, to *unison.Table[*Node[*gurps.Spell]]) {
+func (p *spellsProvider) ProcessDropData(from, to *unison.Table[*Node[*gurps.Spell]]) {
 	if dataOwnerProvider := unison.Ancestor[gurps.DataOwnerProvider](to); !toolbox.IsNil(dataOwnerProvider) {
 		if dataOwner := dataOwnerProvider.DataOwner(); !toolbox.IsNil(dataOwner) {
 			if entity := dataOwner.OwningEntity(); entity != nil {
@@ -118,6 +126,56 @@ func (p *spellsProvider) ProcessDropData(_, to *unison.Table[*Node[*gurps.Spell]
 	}
 }
 
+// removeBlockedSpells removes any of the rows just added to the table that hold spells the character may not add while
+// in creation mode, then lets the user know which ones were removed. Every path that adds rows to a sheet's table calls
+// this, so tables that don't hold spells are left alone.
+func removeBlockedSpells[T gurps.NodeTypes](table *unison.Table[*Node[T]], rows []*Node[T]) {
+	spellTable, ok := any(table).(*unison.Table[*Node[*gurps.Spell]])
+	if !ok || len(rows) == 0 {
+		return
+	}
+	var p TableProvider[*gurps.Spell]
+	if p, ok = spellTable.ClientData()[TableProviderClientKey].(TableProvider[*gurps.Spell]); !ok {
+		return
+	}
+	dataOwner := p.DataOwner()
+	if toolbox.IsNil(dataOwner) {
+		return
+	}
+	entity := dataOwner.OwningEntity()
+	if entity == nil {
+		return
+	}
+	candidates := make([]*gurps.Spell, 0, len(rows))
+	for _, row := range rows {
+		if spell, isSpell := any(row.Data()).(*gurps.Spell); isSpell {
+			candidates = append(candidates, spell)
+		}
+	}
+	list, removed := entity.RemoveSpellsBlockedInCreation(p.RootData(), candidates)
+	if len(removed) == 0 {
+		return
+	}
+	p.SetRootData(list)
+	spellTable.SyncToModel()
+	remaining := make(map[*gurps.Spell]bool)
+	gurps.Traverse(func(spell *gurps.Spell) bool {
+		remaining[spell] = true
+		return false
+	}, false, false, list...)
+	selMap := make(map[tid.TID]bool)
+	for _, spell := range candidates {
+		if remaining[spell] {
+			selMap[spell.TID] = true
+		}
+	}
+	spellTable.SetSelectionMap(selMap)
+	unison.InvokeTask(func() {
+		unison.WarningDialogWithMessage(i18n.Text("Spells with unmet requirements may not be added in creation mode."),
+			strings.Join(removed, "\n"))
+	})
+}
+
 func (p *spellsProvider) AltDropSupport() *AltDropSupport {
 	return nil
 }
@@ -141,13 +199,15 @@ func (p *spellsProvider) SyncHeader(_ []unison.TableColumnHeader[*Node[*gurps.Sp
 func (p *spellsProvider) ColumnIDs() []int {
 	columnIDs := make([]int, 0, 11)
 	if p.forPage {
-		if _, ok := p.provider.(*gurps.Entity); ok {
+		if entity, ok := p.provider.(*gurps.Entity); ok {
 			columnIDs = append(columnIDs,
 				gurps.SpellDescriptionForPageColumn,
 				gurps.SpellLevelColumn,
-				gurps.SpellRelativeLevelColumn,
-				gurps.SpellPointsColumn,
 			)
+			if !entity.SheetSettings.SimplifiedDisplay {
+				columnIDs = append(columnIDs, gurps.SpellRelativeLevelColumn)
+			}
+			columnIDs = append(columnIDs, gurps.SpellPointsColumn)
 		} else {
 			columnIDs = append(columnIDs,
 				gurps.SpellDescriptionForPageColumn,
@@ -172,7 +232,7 @@ func (p *spellsProvider) ColumnIDs() []int {
 	}
 	columnIDs = append(columnIDs, gurps.SpellReferenceColumn)
 	if p.forPage {
-		if entity := p.DataOwner().OwningEntity(); entity == nil || !entity.SheetSettings.HideSourceMismatch {
+		if entity := p.DataOwner().OwningEntity(); entity == nil || entity.SheetSettings.ShowLibrarySourceColumn() {
 			columnIDs = append(columnIDs, gurps.SpellLibSrcColumn)
 		}
 	}
@@ -32,10 +32,7 @@ func Start(files []string) {
 	go libs.PerformUpdateChecks()
 	unison.Start(
 		unison.StartupFinishedCallback(func() {
-			unison.DefaultTableColumnHeaderTheme.OnBackgroundInk = colors.OnHeader
-			unison.DefaultMarkdownTheme.LinkHandler = HandleLink
-			unison.DefaultMarkdownTheme.WorkingDirProvider = WorkingDirProvider
-			unison.DefaultMarkdownTheme.AltLinkPrefixes = []string{"md:"}
+			configureDefaultThemes()
 			if appIcon, err := unison.NewImageFromBytes(appIconBytes, 0.5); err != nil {
 				errs.Log(err)
 			} else {
@@ -71,3 +68,11 @@ func Start(files []string) {
 func AppDescription() string {
 	return i18n.Text("GURPS Character Sheet is an interactive character sheet editor for the GURPS Fourth Edition roleplaying game.")
 }
+
+// configureDefaultThemes adjusts the unison default themes for use by GCS.
+func configureDefaultThemes() {
+	unison.DefaultTableColumnHeaderTheme.OnBackgroundInk = colors.OnHeader
+	unison.DefaultMarkdownTheme.LinkHandler = HandleLink
+	unison.DefaultMarkdownTheme.WorkingDirProvider = WorkingDirProvider
+	unison.DefaultMarkdownTheme.AltLinkPrefixes = []string{"md:"}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison"
+)
+
+// makeRollable turns the label into a clickable target that makes a success roll against the level returned by the
+// provided function and displays the outcome on behalf of the entity, which may be nil. If the function returns false,
+// no roll is made. The title is determined at the time of the roll.
+func makeRollable(entity *gurps.Entity, label *unison.Label, title func() string, level func() (int, bool)) {
+	label.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		return true
+	}
+	label.MouseUpCallback = func(where unison.Point, _ int, _ unison.Modifiers) bool {
+		if where.In(label.ContentRect(false)) {
+			if target, ok := level(); ok {
+				makeSuccessRoll(entity, title(), target)
+			}
+		}
+		return true
+	}
+	label.UpdateCursorCallback = func(_ unison.Point) *unison.Cursor {
+		return unison.PointingCursor()
+	}
+}
+
+// makeSuccessRoll makes a success roll against the target on behalf of the entity, which may be nil, and displays the
+// outcome. Any modifiers waiting in the entity's modifiers tray are applied to the target and the tray is emptied.
+func makeSuccessRoll(entity *gurps.Entity, title string, target int) {
+	total, modifiers := takeRollModifiers(entity)
+	detail := gurps.RollAgainst(target + total).String()
+	if modifiers != "" {
+		detail += "\n" + modifiers
+	}
+	showRoll(entity, title, detail)
+}
+
+func showSuccessRoll(entity *gurps.Entity, title string, r gurps.SuccessRoll) {
+	showRoll(entity, title, r.String())
+}
+
+func showSuccessRollMessage(title, detail string) {
+	dialog, err := unison.NewDialog(nil, nil, unison.NewMessagePanel(title, detail),
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
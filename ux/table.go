@@ -11,7 +11,6 @@ package ux
 
 import (
 	"fmt"
-	"path/filepath"
 	"slices"
 	"strings"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/txt"
@@ -162,6 +162,8 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 		func(_ any) { copySelectionToSheet(table) })
 	table.InstallCmdHandlers(CopyToTemplateItemID, func(_ any) bool { return canCopySelectionToTemplate(table) },
 		func(_ any) { copySelectionToTemplate(table) })
+	table.InstallCmdHandlers(ExportItemCardItemID, func(_ any) bool { return canExportItemCard(table) },
+		func(_ any) { exportItemCard(table) })
 	if t, ok := (any(table)).(*unison.Table[*Node[*gurps.Equipment]]); ok {
 		t.InstallCmdHandlers(IncrementItemID,
 			func(_ any) bool { return canAdjustQuantity(t, true) },
@@ -176,6 +178,14 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 			func(_ any) bool { return canAdjustUses(t, -1) },
 			func(_ any) { adjustUses(unison.AncestorOrSelf[Rebuildable](t), t, -1) })
 	}
+	if t, ok := (any(table)).(*unison.Table[*Node[*gurps.Weapon]]); ok {
+		t.InstallCmdHandlers(FireWeaponItemID,
+			func(_ any) bool { return canAdjustAmmo(t, true) },
+			func(_ any) { adjustAmmo(unison.AncestorOrSelf[Rebuildable](t), t, true) })
+		t.InstallCmdHandlers(ReloadWeaponItemID,
+			func(_ any) bool { return canAdjustAmmo(t, false) },
+			func(_ any) { adjustAmmo(unison.AncestorOrSelf[Rebuildable](t), t, false) })
+	}
 
 	return header, table
 }
@@ -201,15 +211,8 @@ func canCopySelectionToTemplate[T gurps.NodeTypes](table *unison.Table[*Node[T]]
 
 func libraryFileFromTable[T gurps.NodeTypes](table *unison.Table[*Node[T]]) gurps.LibraryFile {
 	if d := unison.Ancestor[*TableDockable[T]](table); d != nil {
-		for _, lib := range gurps.GlobalSettings().Libraries() {
-			libPathOnDisk := lib.PathOnDisk + string(filepath.Separator)
-			filePathOnDisk := d.BackingFilePath()
-			if strings.HasPrefix(filePathOnDisk, libPathOnDisk) {
-				return gurps.LibraryFile{
-					Library: lib.Key(),
-					Path:    strings.TrimPrefix(filePathOnDisk, libPathOnDisk),
-				}
-			}
+		if libFile, ok := gurps.LibraryFileForPath(d.BackingFilePath()); ok {
+			return libFile
 		}
 	}
 	return gurps.LibraryFile{}
@@ -354,18 +357,39 @@ func DeleteSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]], recordUnd
 				list = append(list, target)
 			}
 		}
+		var toDisable []*gurps.Dependent
+		if recordUndo {
+			// Only direct deletions are checked, since other callers are moving the rows elsewhere
+			if provider := DetermineDataOwnerProvider(table); !toolbox.IsNil(provider) {
+				if owner := provider.DataOwner(); !toolbox.IsNil(owner) {
+					if dependents := gurps.FindDependents(owner.OwningEntity(), list); len(dependents) != 0 {
+						var proceed bool
+						if toDisable, proceed = confirmDeleteImpact(dependents); !proceed {
+							return
+						}
+					}
+				}
+			}
+		}
 		if !CloseID(ids) {
 			return
 		}
+		states := disableDependents(toDisable)
 		var undo *unison.UndoEdit[*TableUndoEditData[T]]
 		var mgr *unison.UndoManager
 		if recordUndo {
 			if mgr = unison.UndoManagerFor(table); mgr != nil {
 				undo = &unison.UndoEdit[*TableUndoEditData[T]]{
-					ID:         unison.NextUndoID(),
-					EditName:   i18n.Text("Delete Selection"),
-					UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
-					RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+					ID:       unison.NextUndoID(),
+					EditName: i18n.Text("Delete Selection"),
+					UndoFunc: func(e *unison.UndoEdit[*TableUndoEditData[T]]) {
+						restoreDependents(states)
+						e.BeforeData.Apply()
+					},
+					RedoFunc: func(e *unison.UndoEdit[*TableUndoEditData[T]]) {
+						disableDependents(toDisable)
+						e.AfterData.Apply()
+					},
 					AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
 					BeforeData: NewTableUndoEditData(table),
 				}
@@ -570,6 +594,7 @@ func CopyRowsTo[T gurps.NodeTypes](table *unison.Table[*Node[T]], rows []*Node[T
 		selMap[row.ID()] = true
 	}
 	table.SetSelectionMap(selMap)
+	removeBlockedSpells(table, rows)
 	if postProcessor != nil {
 		postProcessor(rows)
 	}
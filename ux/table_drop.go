@@ -108,6 +108,9 @@ func didDropCallback[T gurps.NodeTypes](undo *unison.UndoEdit[*TableDragUndoEdit
 			tableProvider.ProcessDropData(from, to)
 		}
 	}
+	if from != to {
+		removeBlockedSpells(to, to.SelectedRows(true))
+	}
 	if toEntityProvider := unison.Ancestor[gurps.DataOwnerProvider](to); !toolbox.IsNil(toEntityProvider) {
 		if owner := toEntityProvider.DataOwner(); !toolbox.IsNil(owner) && !toolbox.IsNil(owner.OwningEntity()) {
 			if rebuilder := unison.Ancestor[Rebuildable](to); rebuilder != nil {
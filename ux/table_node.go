@@ -26,7 +26,11 @@ import (
 	"github.com/richardwilkes/unison/enums/align"
 )
 
-const noInvertColorsMarker = "no_invert"
+const (
+	noInvertColorsMarker = "no_invert"
+	// nodeOrderKey is the table client data key for a func([]T) []T that arranges row data into display order.
+	nodeOrderKey = "node_order"
+)
 
 var _ unison.TableRowData[*Node[*gurps.Trait]] = &Node[*gurps.Trait]{}
 
@@ -114,7 +118,7 @@ func (n *Node[T]) CanHaveChildren() bool {
 // Children implements unison.TableRowData.
 func (n *Node[T]) Children() []*Node[T] {
 	if n.dataAsNode.Container() && n.children == nil {
-		children := n.dataAsNode.NodeChildren()
+		children := orderedNodeData(n.table, n.dataAsNode.NodeChildren())
 		n.children = make([]*Node[T], len(children))
 		for i, one := range children {
 			n.children[i] = NewNode[T](n.table, n, one, n.forPage)
@@ -123,6 +127,14 @@ func (n *Node[T]) Children() []*Node[T] {
 	return n.children
 }
 
+// orderedNodeData returns the data in the order the table should display it.
+func orderedNodeData[T gurps.NodeTypes](table *unison.Table[*Node[T]], data []T) []T {
+	if order, ok := table.ClientData()[nodeOrderKey].(func([]T) []T); ok {
+		return order(data)
+	}
+	return data
+}
+
 // SetChildren implements unison.TableRowData.
 func (n *Node[T]) SetChildren(children []*Node[T]) {
 	if n.dataAsNode.Container() {
@@ -143,9 +155,13 @@ func (n *Node[T]) CellDataForSort(index int) string {
 }
 
 // ColumnCell implements unison.TableRowData.
-func (n *Node[T]) ColumnCell(row, col int, foreground, background unison.Ink, _, _, _ bool) unison.Paneler {
+func (n *Node[T]) ColumnCell(row, col int, foreground, background unison.Ink, selected, _, _ bool) unison.Paneler {
 	var cellData gurps.CellData
 	n.dataAsNode.CellData(n.table.Columns[col].ID, &cellData)
+	if cellData.Type == cell.Stepper && !selected {
+		// Step controls are only offered on selected rows, which also keeps them out of exported output.
+		cellData.Type = cell.Text
+	}
 	width := n.table.CellWidth(row, col)
 	if n.cellCache[col].Matches(width, &cellData) {
 		applyInkRecursively(n.cellCache[col].Panel.AsPanel(), foreground, background)
@@ -270,6 +286,8 @@ func (n *Node[T]) CellFromCellData(c *gurps.CellData, width float32, foreground,
 		return n.createPageRefCell(c, foreground)
 	case cell.Markdown:
 		return n.createMarkdownCell(c, width, foreground)
+	case cell.Stepper:
+		return n.createStepperCell(c, width, foreground, background)
 	default:
 		return unison.NewPanel()
 	}
@@ -316,6 +334,20 @@ func (n *Node[T]) createLabelCell(c *gurps.CellData, width float32, foreground,
 		p.AddChild(tag)
 		tooltip = c.UnsatisfiedReason
 	}
+	if c.Warning != "" {
+		tag := unison.NewTag()
+		tag.BackgroundInk = unison.ThemeWarning
+		tag.OnBackgroundInk = unison.ThemeOnWarning
+		tag.Font = n.secondaryFieldFont()
+		height := tag.Font.LineHeight() - 2
+		tag.Drawable = &unison.DrawableSVG{
+			SVG:  unison.TriangleExclamationSVG,
+			Size: unison.NewSize(height, height),
+		}
+		tag.SetTitle(c.Warning)
+		tag.ClientData()[noInvertColorsMarker] = true
+		p.AddChild(tag)
+	}
 	if c.TemplateInfo != "" {
 		tag := unison.NewTag()
 		tag.BackgroundInk = foreground
@@ -330,7 +362,13 @@ func (n *Node[T]) createLabelCell(c *gurps.CellData, width float32, foreground,
 		tag.ClientData()[noInvertColorsMarker] = true
 		p.AddChild(tag)
 	}
-	if tooltip != "" {
+	if n.forPage && c.PointsDelta != 0 {
+		p.AddChild(newPointsDeltaTag(c.PointsDelta, n.secondaryFieldFont()))
+	}
+	switch {
+	case n.forPage && c.Roll != gurps.NoCellRoll:
+		p.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Click to roll"), tooltip)
+	case tooltip != "":
 		p.Tooltip = newWrappedTooltip(tooltip)
 	}
 	return p
@@ -380,6 +418,9 @@ func (n *Node[T]) addLabelCell(c *gurps.CellData, parent *unison.Panel, width fl
 		label.OnBackgroundInk = foreground
 		label.SetTitle(line.String())
 		label.SetEnabled(!c.Dim)
+		if primary && n.forPage && c.Roll != gurps.NoCellRoll {
+			n.installCellRoll(label, c)
+		}
 		if tag != nil {
 			wrapper := unison.NewPanel()
 			wrapper.SetLayout(&unison.FlexLayout{
@@ -436,6 +477,55 @@ func (n *Node[T]) createToggleCell(c *gurps.CellData, foreground unison.Ink) uni
 	return check
 }
 
+func (n *Node[T]) createStepperCell(c *gurps.CellData, width float32, foreground, background unison.Ink) unison.Paneler {
+	p := unison.NewPanel()
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		HAlign:   c.Alignment,
+	})
+	p.AddChild(n.createStepButton("−", i18n.Text("Decrease"), -1, foreground))
+	p.AddChild(n.createLabelCell(c, width, foreground, background))
+	p.AddChild(n.createStepButton("+", i18n.Text("Increase"), 1, foreground))
+	return p
+}
+
+func (n *Node[T]) createStepButton(text, tooltip string, amount int, foreground unison.Ink) *unison.Label {
+	label := unison.NewLabel()
+	label.Font = n.primaryFieldFont()
+	label.OnBackgroundInk = foreground
+	label.SetTitle(text)
+	label.Tooltip = newWrappedTooltip(tooltip)
+	label.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		handleStep(n.table, n.data, amount)
+		return true
+	}
+	return label
+}
+
+// installCellRoll makes a single click on the label roll against the cell's contents.
+func (n *Node[T]) installCellRoll(label *unison.Label, c *gurps.CellData) {
+	data := *c
+	label.MouseDownCallback = func(_ unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if button != unison.ButtonLeft || clickCount != 1 || mod != 0 {
+			return false
+		}
+		rollCell(gurps.EntityFromNode(n.dataAsNode), n.dataAsNode.String(), &data)
+		return true
+	}
+	label.UpdateCursorCallback = func(_ unison.Point) *unison.Cursor {
+		return unison.PointingCursor()
+	}
+}
+
+func handleStep(table, data any, amount int) {
+	if item, ok := data.(*gurps.Equipment); ok {
+		if t, ok2 := table.(*unison.Table[*Node[*gurps.Equipment]]); ok2 {
+			adjustUsesOf(unison.AncestorOrSelf[Rebuildable](t), t, []*gurps.Equipment{item}, amount)
+		}
+	}
+}
+
 func handleCheck(data any, check unison.Paneler, checked bool) {
 	switch item := data.(type) {
 	case *gurps.Equipment:
@@ -638,6 +728,12 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 	if len(items) == 0 {
 		return
 	}
+	before := NewTableUndoEditData(table)
+	if !applyWithinCreationLimits(owner, func() { insertItemsAtSelection(table, topList, setTopList, items) },
+		before.Apply) {
+		owner.Rebuild(true)
+		return
+	}
 	var undo *unison.UndoEdit[*TableUndoEditData[T]]
 	mgr := unison.UndoManagerFor(table)
 	if mgr != nil {
@@ -647,9 +743,34 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
 			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
 			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
-			BeforeData: NewTableUndoEditData(table),
+			BeforeData: before,
 		}
 	}
+	MarkModified(table)
+	table.SetRootRows(rowData(table))
+	table.ValidateScrollRoot()
+	table.RequestFocus()
+	selMap := make(map[tid.TID]bool)
+	for _, item := range items {
+		selMap[gurps.AsNode(item).ID()] = true
+	}
+	table.SetSelectionMap(selMap)
+	removeBlockedSpells(table, table.SelectedRows(true))
+	table.ScrollRowCellIntoView(table.LastSelectedRowIndex(), 0)
+	table.ScrollRowCellIntoView(table.FirstSelectedRowIndex(), 0)
+	if mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		mgr.Add(undo)
+	}
+	for _, item := range items {
+		MarkDirtyFor(owner, item)
+	}
+	owner.Rebuild(true)
+}
+
+// insertItemsAtSelection places the items within the selected container, after the selected row, or, if nothing is
+// selected, at the end of the top-level list.
+func insertItemsAtSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]], topList func() []T, setTopList func([]T), items []T) {
 	var target, zero T
 	i := table.FirstSelectedRowIndex()
 	if i != -1 {
@@ -680,22 +801,6 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 		SetParents(items, zero)
 		setTopList(append(topList(), items...))
 	}
-	MarkModified(table)
-	table.SetRootRows(rowData(table))
-	table.ValidateScrollRoot()
-	table.RequestFocus()
-	selMap := make(map[tid.TID]bool)
-	for _, item := range items {
-		selMap[gurps.AsNode(item).ID()] = true
-	}
-	table.SetSelectionMap(selMap)
-	table.ScrollRowCellIntoView(table.LastSelectedRowIndex(), 0)
-	table.ScrollRowCellIntoView(table.FirstSelectedRowIndex(), 0)
-	if mgr != nil && undo != nil {
-		undo.AfterData = NewTableUndoEditData(table)
-		mgr.Add(undo)
-	}
-	owner.Rebuild(true)
 }
 
 // SetParents of each item.
@@ -59,6 +59,7 @@ type Template struct {
 	dragReroutePanel  *unison.Panel
 	scale             int
 	needsSaveAsPrompt bool
+	fitToWidth        bool
 }
 
 // OpenTemplates returns the currently open templates.
@@ -160,6 +161,7 @@ func NewTemplate(filePath string, template *gurps.Template) *Template {
 	t.installNewItemCmdHandlers(NewCarriedEquipmentItemID,
 		NewCarriedEquipmentContainerItemID, t.Equipment)
 	t.installNewItemCmdHandlers(NewNoteItemID, NewNoteContainerItemID, t.Notes)
+	t.installNewItemCmdHandlers(NewNoteFromTemplateItemID, -1, t.Notes)
 	t.InstallCmdHandlers(AddNaturalAttacksItemID, unison.AlwaysEnabled, func(_ any) {
 		InsertItems[*gurps.Trait](t, t.Traits.Table, t.template.TraitList, t.template.SetTraitList,
 			func(_ *unison.Table[*Node[*gurps.Trait]]) []*Node[*gurps.Trait] {
@@ -191,14 +193,13 @@ func (t *Template) createToolbar() {
 	t.toolbar.AddChild(helpButton)
 
 	t.toolbar.AddChild(
-		NewScaleField(
+		NewPageScaleControls(
 			gurps.InitialUIScaleMin,
 			gurps.InitialUIScaleMax,
-			func() int { return gurps.GlobalSettings().General.InitialSheetUIScale },
+			func() int { return gurps.GlobalSettings().General.DefaultSheetUIScale },
 			func() int { return t.scale },
 			func(scale int) { t.scale = scale },
-			nil,
-			false,
+			&t.fitToWidth,
 			t.scroll,
 		),
 	)
@@ -305,6 +306,7 @@ func ApplyTemplate(filePath string) {
 func (t *Template) applyTemplate(suppressRandomizePromptAsBool any) {
 	suppressRandomizePrompt, _ := suppressRandomizePromptAsBool.(bool) //nolint:errcheck // The default of false on failure is acceptable
 	for _, sheet := range PromptForDestination(OpenSheets(nil)) {
+		backupBeforeBulkOperation(sheet.BackingFilePath(), sheet.entity, i18n.Text("Apply Template"))
 		t.applyTemplateToSheet(sheet, suppressRandomizePrompt)
 	}
 }
@@ -375,14 +377,18 @@ Disable your character's existing Ancestry (%s)?`),
 	if len(templateAncestries) != 0 && gurps.GlobalSettings().General.AutoFillProfile {
 		randomize := true
 		if !suppressRandomizePrompt {
-			randomize = unison.YesNoDialog(i18n.Text("Would you like to apply the initial randomization again?"), "") == unison.ModalResponseOK
+			randomize = askToRerollAncestry(&e.Profile,
+				i18n.Text("Would you like to apply the initial randomization again? Lock the fields to keep."))
 		}
 		if randomize {
-			e.Profile.ApplyRandomizers(e)
+			e.Profile.RerollUnlocked(e)
 			updateRandomizedProfileFieldsWithoutUndo(sheet)
 			sheet.Rebuild(true)
 		}
 	}
+	if !t.needsSaveAsPrompt && t.path != "" {
+		e.RecordAppliedTemplate(gurps.NewAppliedTemplate(t.path, t.template))
+	}
 	if mgr != nil && undo != nil {
 		var err error
 		if undo.AfterData, err = NewApplyTemplateUndoEditData(sheet); err != nil {
@@ -458,6 +464,7 @@ func appendRows[T gurps.NodeTypes](table *unison.Table[*Node[T]], rows []*Node[T
 		selMap[row.ID()] = true
 	}
 	table.SetSelectionMap(selMap)
+	removeBlockedSpells(table, rows)
 	if provider, ok := table.ClientData()[TableProviderClientKey]; ok {
 		var tableProvider TableProvider[T]
 		if tableProvider, ok = provider.(TableProvider[T]); ok {
@@ -733,6 +740,11 @@ func (t *Template) createContent() unison.Paneler {
 }
 
 func (t *Template) save(forceSaveAs bool) bool {
+	// Each saved change gets a new revision, so that sheets it was applied to can detect that an update is available.
+	revision := t.template.Revision
+	if t.Modified() {
+		t.template.Revision++
+	}
 	success := false
 	if forceSaveAs || t.needsSaveAsPrompt {
 		success = SaveDockableAs(t, gurps.TemplatesExt, t.template.Save, func(path string) {
@@ -744,6 +756,8 @@ func (t *Template) save(forceSaveAs bool) bool {
 	}
 	if success {
 		t.needsSaveAsPrompt = false
+	} else {
+		t.template.Revision = revision
 	}
 	return success
 }
@@ -910,6 +924,7 @@ func (t *templateTablesUndoData) Apply() {
 }
 
 func (t *Template) syncWithAllSources() {
+	backupBeforeBulkOperation(t.BackingFilePath(), t.template, syncWithSourceAction.Title)
 	var undo *unison.UndoEdit[*templateTablesUndoData]
 	mgr := unison.UndoManagerFor(t)
 	if mgr != nil {
@@ -0,0 +1,138 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+// maxTemplateDiffNames is the most row names listed for each kind of change when offering a template update.
+const maxTemplateDiffNames = 12
+
+func (s *Sheet) checkForTemplateUpdates() {
+	if len(s.entity.AppliedTemplates) == 0 {
+		showSuccessRollMessage(i18n.Text("No Templates"),
+			i18n.Text("No saved templates have been applied to this character sheet."))
+		return
+	}
+	updates, problems := s.entity.TemplateUpdates()
+	if len(problems) != 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to check some templates"), strings.Join(problems, "\n"))
+	}
+	if len(updates) == 0 {
+		if len(problems) == 0 {
+			showSuccessRollMessage(i18n.Text("Templates Up to Date"),
+				i18n.Text("All templates applied to this character sheet are up to date."))
+		}
+		return
+	}
+	for _, update := range updates {
+		if !s.offerTemplateUpdate(update) {
+			return
+		}
+	}
+}
+
+// offerTemplateUpdate shows what re-applying the updated template would change and performs the re-apply if the user
+// agrees. Returns false if the user cancelled.
+func (s *Sheet) offerTemplateUpdate(update *gurps.TemplateUpdate) bool {
+	diff := s.entity.DiffTemplate(update.Template)
+	if len(diff.Added) == 0 && len(diff.Changed) == 0 {
+		// Nothing on the sheet would change, so just note that the newer revision has been seen.
+		s.entity.ReapplyTemplate(update.Applied, update.Template, false)
+		s.MarkModified(nil)
+		return true
+	}
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, i18n.Text("Revision %d is available; revision %d was applied."), update.Template.Revision,
+		update.Applied.Revision)
+	describeTemplateDiffNames(&buffer, i18n.Text("Will be added:"), diff.Added)
+	describeTemplateDiffNames(&buffer, i18n.Text("Have changed:"), diff.Changed)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(unison.NewMessagePanel(fmt.Sprintf(i18n.Text("The template %s has been updated"),
+		update.Applied.Title()), buffer.String()))
+	replace := unison.NewCheckBox()
+	replace.SetTitle(i18n.Text("Replace changed items (points spent on them will be reset)"))
+	replace.SetEnabled(len(diff.Changed) != 0)
+	panel.AddChild(replace)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			{Title: i18n.Text("Skip"), ResponseCode: unison.ModalResponseDiscard},
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Re-apply")),
+		})
+	if err != nil {
+		errs.Log(err)
+		return false
+	}
+	switch dialog.RunModal() {
+	case unison.ModalResponseOK:
+	case unison.ModalResponseDiscard:
+		return true
+	default:
+		return false
+	}
+	var undo *unison.UndoEdit[*sheetMergeUndoEditData]
+	if beforeData, collectErr := newSheetMergeUndoEditData(s); collectErr != nil {
+		errs.Log(collectErr)
+	} else {
+		undo = &unison.UndoEdit[*sheetMergeUndoEditData]{
+			ID:         unison.NextUndoID(),
+			EditName:   i18n.Text("Re-apply Template"),
+			UndoFunc:   func(e *unison.UndoEdit[*sheetMergeUndoEditData]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*sheetMergeUndoEditData]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*sheetMergeUndoEditData], _ unison.Undoable) bool { return false },
+			BeforeData: beforeData,
+		}
+	}
+	s.entity.ReapplyTemplate(update.Applied, update.Template, replace.State == check.On)
+	s.Traits.Table.SyncToModel()
+	s.Skills.Table.SyncToModel()
+	s.Spells.Table.SyncToModel()
+	s.CarriedEquipment.Table.SyncToModel()
+	s.Notes.Table.SyncToModel()
+	s.Rebuild(true)
+	if undo != nil {
+		if undo.AfterData, err = newSheetMergeUndoEditData(s); err != nil {
+			errs.Log(err)
+		} else {
+			s.undoMgr.Add(undo)
+		}
+	}
+	return true
+}
+
+func describeTemplateDiffNames(buffer *strings.Builder, title string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	buffer.WriteString("\n\n")
+	buffer.WriteString(title)
+	for i, name := range names {
+		if i == maxTemplateDiffNames {
+			fmt.Fprintf(buffer, i18n.Text("\n…and %d more"), len(names)-i)
+			break
+		}
+		buffer.WriteString("\n• ")
+		buffer.WriteString(name)
+	}
+}
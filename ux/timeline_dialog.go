@@ -0,0 +1,143 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+type timelineStep struct {
+	title               string
+	years, months, days int
+}
+
+func (s *timelineStep) String() string {
+	return s.title
+}
+
+func timelineSteps() []*timelineStep {
+	return []*timelineStep{
+		{title: i18n.Text("days"), days: 1},
+		{title: i18n.Text("weeks"), days: 7},
+		{title: i18n.Text("months"), months: 1},
+		{title: i18n.Text("years"), years: 1},
+	}
+}
+
+// ShowTimeline displays the timeline dialog for the sheet, which allows the campaign date to be advanced, updating the
+// character's age and making any aging rolls that come due.
+func ShowTimeline(sheet *Sheet) {
+	entity := sheet.Entity()
+	timeline := entity.Timeline.Clone()
+	if timeline == nil {
+		timeline = gurps.NewTimeline(time.Now())
+	}
+	steps := timelineSteps()
+	step := steps[0]
+	amount := 0
+
+	content := unison.NewPanel()
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	dateFormat := fmt.Sprintf(i18n.Text("Use the format %s"), gurps.TimelineDateLayout)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Campaign Date"), false))
+	dateField := NewStringField(nil, "", i18n.Text("Campaign Date"),
+		func() string { return timeline.Date },
+		func(value string) { timeline.Date = strings.TrimSpace(value) })
+	dateField.Tooltip = newWrappedTooltip(dateFormat)
+	dateField.ValidateCallback = func() bool { return isValidTimelineDate(timeline.Date) }
+	content.AddChild(dateField)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Last Birthday"), false))
+	birthdayField := NewStringField(nil, "", i18n.Text("Last Birthday"),
+		func() string { return timeline.Birthday },
+		func(value string) { timeline.Birthday = strings.TrimSpace(value) })
+	birthdayField.Tooltip = newWrappedTooltip(i18n.Text("The date on which the character reached their current age. ") +
+		dateFormat)
+	birthdayField.ValidateCallback = func() bool { return isValidTimelineDate(timeline.Birthday) }
+	content.AddChild(birthdayField)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Aging Roll Modifier"), false))
+	modifierField := NewIntegerField(nil, "", i18n.Text("Aging Roll Modifier"),
+		func() int { return timeline.AgingModifier },
+		func(value int) { timeline.AgingModifier = value }, -20, 20, true, false)
+	modifierField.Tooltip = newWrappedTooltip(i18n.Text("The modifier applied to aging rolls, such as for the tech level of available medical care"))
+	content.AddChild(modifierField)
+	wrapper := addFlowWrapper(content, i18n.Text("Advance By"), 2)
+	wrapper.AddChild(NewIntegerField(nil, "", i18n.Text("Advance By"),
+		func() int { return amount },
+		func(value int) { amount = value }, 0, 9999, false, false))
+	stepPopup := unison.NewPopupMenu[*timelineStep]()
+	stepPopup.AddItem(steps...)
+	stepPopup.Select(step)
+	stepPopup.SelectionChangedCallback = func(p *unison.PopupMenu[*timelineStep]) {
+		if item, ok := p.Selected(); ok {
+			step = item
+		}
+	}
+	wrapper.AddChild(stepPopup)
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+
+	dialog, err := unison.NewDialog(nil, nil, content, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	if !isValidTimelineDate(timeline.Date) || !isValidTimelineDate(timeline.Birthday) {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to apply timeline changes"), dateFormat)
+		return
+	}
+	from, _ := time.Parse(gurps.TimelineDateLayout, timeline.Date) //nolint:errcheck // Already validated
+	to := from.AddDate(step.years*amount, step.months*amount, step.days*amount)
+
+	before := entity.TimelineState()
+	entity.Timeline = timeline
+	results := entity.AdvanceTimeline(to)
+	sheet.undoMgr.Add(&unison.UndoEdit[*gurps.TimelineState]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Advance Timeline"),
+		UndoFunc:   func(edit *unison.UndoEdit[*gurps.TimelineState]) { applyTimelineState(sheet, edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[*gurps.TimelineState]) { applyTimelineState(sheet, edit.AfterData) },
+		BeforeData: before,
+		AfterData:  entity.TimelineState(),
+	})
+	sheet.Rebuild(true)
+	if len(results) != 0 {
+		showSuccessRollMessage(fmt.Sprintf(i18n.Text("Advanced to %s"), timeline.Date), strings.Join(results, "\n"))
+	}
+}
+
+func applyTimelineState(sheet *Sheet, state *gurps.TimelineState) {
+	sheet.Entity().ApplyTimelineState(state)
+	sheet.Rebuild(true)
+}
+
+func isValidTimelineDate(date string) bool {
+	_, err := time.Parse(gurps.TimelineDateLayout, date)
+	return err == nil
+}
@@ -28,6 +28,14 @@ func EditTrait(owner Rebuildable, t *gurps.Trait) {
 
 func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *unison.Panel) func() {
 	addNameLabelAndField(content, &e.editorData.Name)
+	updateSuggestion := func() {}
+	if e.target.Source.ShouldOmit() && !e.target.Container() {
+		updateSuggestion = addLibraryNameSuggestion(content, &e.editorData.Name, gurps.MatchTraitName,
+			func(m *gurps.LibraryNameMatch[*gurps.Trait, *gurps.TraitModifier]) {
+				e.editorData.CopyFrom(m.Trait(e.target.DataOwner()))
+				e.Rebuild(false)
+			})
+	}
 	addNotesLabelAndField(content, &e.editorData.LocalNotes)
 	addVTTNotesLabelAndField(content, &e.editorData.VTTNotes)
 	addUserDescLabelAndField(content, &e.editorData.UserDesc)
@@ -73,6 +81,11 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 			&e.editorData.PointsPerLevel, -fxp.MaxBasePoints, fxp.MaxBasePoints)
 		adjustFieldBlank(perLevelField, !e.editorData.CanLevel)
 		adjustFieldBlank(levelField, !e.editorData.CanLevel)
+		if e.target.IsSorcerySpell() {
+			addLabelAndDecimalField(content, nil, "", i18n.Text("Empowerment"),
+				i18n.Text("The minimum level of Sorcerous Empowerment needed for this spell"),
+				&e.editorData.Empowerment, 0, fxp.MaxBasePoints)
+		}
 	}
 	addLabelAndPopup(content, i18n.Text("Self-Control Roll"), "", selfctrl.Rolls, &e.editorData.CR)
 	crAdjPopup := addLabelAndPopup(content, i18n.Text("CR Adjustment"), i18n.Text("Self-Control Roll Adjustment"),
@@ -80,10 +93,21 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 	if e.editorData.CR == selfctrl.NoCR {
 		crAdjPopup.SetEnabled(false)
 	}
+	addLabelAndStringField(content, i18n.Text("Power Source"),
+		i18n.Text("The source of the power this trait belongs to, e.g. Magic or Psionic. Contained traits inherit it."),
+		&e.editorData.PowerSource)
+	addLabelAndStringField(content, i18n.Text("Power Talent"),
+		i18n.Text("The name of the talent whose level is added to this trait's level. Contained traits inherit it."),
+		&e.editorData.PowerTalent)
 	var ancestryPopup *unison.PopupMenu[string]
+	var costRule *unison.Markdown
 	if e.target.Container() {
 		addLabelAndPopup(content, i18n.Text("Container Type"), "", container.Types,
 			&e.editorData.ContainerType)
+		content.AddChild(unison.NewPanel())
+		costRule = unison.NewMarkdown(true)
+		costRule.SetContent(e.editorData.ContainerType.CostRule(), -1)
+		content.AddChild(costRule)
 		var choices []string
 		for _, lib := range gurps.AvailableAncestries(gurps.GlobalSettings().Libraries()) {
 			for _, one := range lib.List {
@@ -113,6 +137,7 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 	e.InstallCmdHandlers(NewTraitContainerModifierItemID, unison.AlwaysEnabled,
 		func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant) })
 	return func() {
+		updateSuggestion()
 		if perLevelField != nil {
 			adjustFieldBlank(perLevelField, !e.editorData.CanLevel)
 		}
@@ -125,6 +150,11 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 		} else {
 			crAdjPopup.SetEnabled(true)
 		}
+		if costRule != nil {
+			costRule.SetContent(e.editorData.ContainerType.CostRule(), -1)
+			costRule.MarkForLayoutRecursivelyUpward()
+			costRule.MarkForRedraw()
+		}
 		if ancestryPopup != nil {
 			if e.editorData.ContainerType == container.Ancestry {
 				if !ancestryPopup.Enabled() {
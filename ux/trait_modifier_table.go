@@ -48,8 +48,10 @@ func NewTraitModifierTableDockableFromFile(filePath string) (unison.Dockable, er
 // NewTraitModifierTableDockable creates a new unison.Dockable for trait modifier list files.
 func NewTraitModifierTableDockable(filePath string, modifiers []*gurps.TraitModifier) *TableDockable[*gurps.TraitModifier] {
 	provider := &traitModifierListProvider{modifiers: modifiers}
-	return NewTableDockable(filePath, gurps.TraitModifiersExt,
+	d := NewTableDockable(filePath, gurps.TraitModifiersExt,
 		NewTraitModifiersProvider(provider, false),
 		func(path string) error { return gurps.SaveTraitModifiers(provider.TraitModifierList(), path) },
 		NewTraitModifierItemID, NewTraitContainerModifierItemID)
+	installToggleFavoriteModifierHandler(d.table, nil)
+	return d
 }
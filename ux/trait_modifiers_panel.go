@@ -38,11 +38,24 @@ func newTraitModifiersPanel(owner gurps.DataOwner, modifiers *[]*gurps.TraitModi
 	})
 	p.SetBorder(unison.NewLineBorder(unison.ThemeAboveSurface, 0, unison.NewUniformInsets(1), false))
 	p.provider = NewTraitModifiersProvider(p, true)
+	chips := newFavoriteModifierChips(p.applyFavorite)
+	p.AddChild(chips)
 	p.table = newEditorTable(p.AsPanel(), p.provider)
 	p.table.RefKey = "trait-modifiers-" + uuid.New().String()
+	installToggleFavoriteModifierHandler(p.table, chips.sync)
 	return p
 }
 
+func (p *traitModifiersPanel) applyFavorite(mod *gurps.TraitModifier) {
+	if owner := unison.AncestorOrSelf[Rebuildable](p.table); owner != nil {
+		InsertItems[*gurps.TraitModifier](owner, p.table, p.TraitModifierList, p.SetTraitModifierList,
+			func(_ *unison.Table[*Node[*gurps.TraitModifier]]) []*Node[*gurps.TraitModifier] {
+				return p.provider.RootRows()
+			},
+			mod.Clone(gurps.LibraryFile{}, p.owner, nil, false))
+	}
+}
+
 func (p *traitModifiersPanel) DataOwner() gurps.DataOwner {
 	return p.owner
 }
@@ -47,7 +47,9 @@ func NewTraitTableDockableFromFile(filePath string) (unison.Dockable, error) {
 // NewTraitTableDockable creates a new unison.Dockable for trait list files.
 func NewTraitTableDockable(filePath string, traits []*gurps.Trait) *TableDockable[*gurps.Trait] {
 	provider := &traitListProvider{traits: traits}
-	return NewTableDockable(filePath, gurps.TraitsExt, NewTraitsProvider(provider, false),
+	d := NewTableDockable(filePath, gurps.TraitsExt, NewTraitsProvider(provider, false),
 		func(path string) error { return gurps.SaveTraits(provider.TraitList(), path) },
 		NewTraitItemID, NewTraitContainerItemID)
+	installApplyFavoriteTraitModifierHandler(d.AsPanel(), d, d.table)
+	return d
 }
@@ -0,0 +1,224 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &ValidationDockable{}
+	_ unison.UndoManagerProvider = &ValidationDockable{}
+	_ GroupedCloser              = &ValidationDockable{}
+)
+
+// ValidationDockable displays the results of checking a sheet against its validation rules.
+type ValidationDockable struct {
+	unison.Panel
+	sheet   *Sheet
+	undoMgr *unison.UndoManager
+	content *unison.Panel
+	scroll  *unison.ScrollPanel
+	scale   int
+}
+
+// DisplayValidation displays the validation results for the given Sheet.
+func DisplayValidation(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if v, ok := d.AsPanel().Self.(*ValidationDockable); ok {
+			return v.sheet == sheet
+		}
+		return false
+	}) {
+		UpdateValidation(sheet)
+		return
+	}
+	v := &ValidationDockable{
+		sheet: sheet,
+		scale: gurps.GlobalSettings().General.InitialEditorUIScale,
+	}
+	v.Self = v
+	v.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	v.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	v.content = unison.NewPanel()
+	v.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	v.content.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	v.scroll = unison.NewScrollPanel()
+	v.scroll.SetContent(v.content, behavior.HintedFill, behavior.Fill)
+	v.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	v.AddChild(v.createToolbar())
+	v.AddChild(v.scroll)
+	v.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	v.refresh()
+	PlaceInDock(v, dgroup.Editors, false)
+}
+
+// UpdateValidation refreshes the validation results for the given Sheet, if they are being displayed.
+func UpdateValidation(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		if v, ok := other.(*ValidationDockable); ok && v.sheet == sheet {
+			v.refresh()
+			break
+		}
+	}
+}
+
+func (v *ValidationDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	toolbar.AddChild(NewDefaultInfoPop())
+	toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return v.scale },
+			func(scale int) { v.scale = scale },
+			nil,
+			false,
+			v.scroll,
+		),
+	)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Check the validation rules again"))
+	refreshButton.ClickCallback = v.refresh
+	toolbar.AddChild(refreshButton)
+	settingsButton := unison.NewSVGButton(svg.Settings)
+	settingsButton.Tooltip = newWrappedTooltip(i18n.Text("Edit the validation rules"))
+	settingsButton.ClickCallback = func() { ShowSheetSettings(v.sheet) }
+	toolbar.AddChild(settingsButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	return toolbar
+}
+
+func (v *ValidationDockable) refresh() {
+	v.content.RemoveAllChildren()
+	issues := v.sheet.Entity().Validate()
+	if len(issues) == 0 {
+		label := unison.NewLabel()
+		if len(v.sheet.Entity().SheetSettings.ValidationRules) == 0 {
+			label.SetTitle(i18n.Text("No validation rules have been defined"))
+		} else {
+			label.SetTitle(i18n.Text("All validation rules passed"))
+		}
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+		v.content.AddChild(label)
+	}
+	for _, issue := range issues {
+		icon := unison.NewLabel()
+		height := icon.Font.LineHeight() - 2
+		icon.Drawable = &unison.DrawableSVG{
+			SVG:  unison.TriangleExclamationSVG,
+			Size: unison.NewSize(height, height),
+		}
+		icon.OnBackgroundInk = unison.ThemeWarning
+		v.content.AddChild(icon)
+		subject := issue.Subject
+		if subject == "" {
+			subject = issue.Target.String()
+		}
+		if issue.ID != "" {
+			id := issue.ID
+			v.content.AddChild(unison.NewLink(subject, i18n.Text("Show this row on the sheet"), "",
+				unison.DefaultLinkTheme, func(_ unison.Paneler, _ string) { v.sheet.revealRow(id) }))
+		} else {
+			label := unison.NewLabel()
+			label.SetTitle(subject)
+			v.content.AddChild(label)
+		}
+		msg := unison.NewLabel()
+		msg.SetTitle(issue.Message)
+		msg.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		v.content.AddChild(msg)
+	}
+	v.content.MarkForLayoutRecursively()
+	v.content.MarkForRedraw()
+}
+
+// TitleIcon implements unison.Dockable
+func (v *ValidationDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  unison.TriangleExclamationSVG,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (v *ValidationDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Validation for %s"), v.sheet.String())
+}
+
+func (v *ValidationDockable) String() string {
+	return v.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (v *ValidationDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (v *ValidationDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (v *ValidationDockable) CloseWithGroup(other unison.Paneler) bool {
+	return v.sheet != nil && v.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (v *ValidationDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(v)
+}
+
+// AttemptClose implements GroupedCloser
+func (v *ValidationDockable) AttemptClose() bool {
+	if !CloseGroup(v) {
+		return false
+	}
+	return AttemptCloseForDockable(v)
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (v *ValidationDockable) UndoManager() *unison.UndoManager {
+	return v.undoMgr
+}
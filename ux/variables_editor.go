@@ -0,0 +1,369 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable            = &variablesEditor{}
+	_ unison.TabCloser           = &variablesEditor{}
+	_ ModifiableRoot             = &variablesEditor{}
+	_ unison.UndoManagerProvider = &variablesEditor{}
+	_ GroupedCloser              = &variablesEditor{}
+	_ Rebuildable                = &variablesEditor{}
+)
+
+type variablesEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.EntityVariable
+	current          []*gurps.EntityVariable
+	promptForSave    bool
+}
+
+func displayVariablesEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*variablesEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &variablesEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.CloneEntityVariableList(entity.Variables),
+		current: gurps.CloneEntityVariableList(entity.Variables),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+	if children := e.content.Children(); len(children) != 0 {
+		children[1].RequestFocus()
+	}
+}
+
+func (e *variablesEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Variable"))
+	addButton.ClickCallback = e.addEntry
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *variablesEditor) initContent() {
+	for _, v := range e.current {
+		e.createRow(v, -1)
+	}
+}
+
+func (e *variablesEditor) createRow(v *gurps.EntityVariable, index int) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Variable"))
+	deleteButton.ClickCallback = func() { e.removeEntry(v) }
+	e.content.AddChildAtIndex(deleteButton, index)
+	if index != -1 {
+		index++
+	}
+
+	nameText := i18n.Text("Name")
+	name := NewStringField(nil, "", nameText,
+		func() string { return v.Name },
+		func(value string) {
+			v.Name = value
+			MarkModified(e.content)
+		})
+	name.Watermark = nameText
+	name.Tooltip = newWrappedTooltip(i18n.Text("The name used to reference the variable, e.g. mana_level is referenced as $mana_level"))
+	name.ValidateCallback = func() bool { return e.isNameValid(v) }
+	e.content.AddChildAtIndex(name, index)
+	if index != -1 {
+		index++
+	}
+
+	exprText := i18n.Text("Value or expression")
+	expr := NewStringField(nil, "", exprText,
+		func() string { return v.Expression },
+		func(value string) {
+			v.Expression = value
+			MarkModified(e.content)
+		})
+	expr.Watermark = exprText
+	expr.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	e.content.AddChildAtIndex(expr, index)
+}
+
+func (e *variablesEditor) isNameValid(v *gurps.EntityVariable) bool {
+	if !gurps.IsValidEntityVariableName(v.Name) {
+		return false
+	}
+	if _, exists := e.entity.Attributes.Set[v.Name]; exists {
+		return false
+	}
+	for _, one := range e.current {
+		if one != v && one.Name == v.Name {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *variablesEditor) addEntry() {
+	v := &gurps.EntityVariable{}
+	e.current = slices.Insert(e.current, 0, v)
+	e.createRow(v, 0)
+	e.content.Pack()
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+	e.content.Children()[1].RequestFocus()
+}
+
+func (e *variablesEditor) removeEntry(v *gurps.EntityVariable) {
+	for i, one := range e.current {
+		if one != v {
+			continue
+		}
+		e.current = slices.Delete(e.current, i, i+1)
+		i *= 3
+		for j := 2; j >= 0; j-- {
+			e.content.RemoveChildAtIndex(i + j)
+		}
+		e.content.Pack()
+		MarkForLayoutWithinDockable(e.content)
+		e.content.MarkForRedraw()
+		MarkModified(e.content)
+		break
+	}
+}
+
+func (e *variablesEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Naming,
+		Size: suggestedSize,
+	}
+}
+
+func (e *variablesEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Variables for %s"), e.owner.String())
+}
+
+func (e *variablesEditor) String() string {
+	return e.Title()
+}
+
+func (e *variablesEditor) Tooltip() string {
+	return ""
+}
+
+func (e *variablesEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	valid := true
+	for _, v := range e.current {
+		if !e.isNameValid(v) {
+			valid = false
+			break
+		}
+	}
+	e.applyButton.SetEnabled(modified && valid)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *variablesEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *variablesEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *variablesEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *variablesEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *variablesEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			if !e.applyButton.Enabled() {
+				unison.ErrorDialogWithMessage(i18n.Text("Unable to apply changes"),
+					i18n.Text("Variable names must be unique, may not match an attribute ID, and may only contain letters, digits, and underscores."))
+				return false
+			}
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *variablesEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *variablesEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		mgr.Add(&unison.UndoEdit[[]*gurps.EntityVariable]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Variable Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.EntityVariable]) {
+				entity.SetVariables(edit.BeforeData)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.EntityVariable]) {
+				entity.SetVariables(edit.AfterData)
+				owner.Rebuild(true)
+			},
+			BeforeData: e.before,
+			AfterData:  e.current,
+		})
+	}
+	entity.SetVariables(e.current)
+	owner.Rebuild(true)
+}
@@ -57,7 +57,8 @@ func (we *weaponEditor) initWeaponEditor(e *editor[*gurps.Weapon, *gurps.Weapon]
 		we.addRecoilBlock(w, content)
 	}
 	we.addStrengthBlock(w, content)
-	content.AddChild(newDefaultsPanel(gurps.EntityFromNode(w), &w.Defaults))
+	content.AddChild(newDefaultsPanel(gurps.EntityFromNode(w), i18n.Text("Defaults"),
+		gurps.TenFlag|gurps.ParryFlag|gurps.BlockFlag|gurps.SkillFlag, &w.Defaults))
 	if w.IsRanged() {
 		we.jetCheckBox.OnSet = func() {
 			state := we.jetCheckBox.State == check.Off
@@ -245,6 +246,39 @@ func (we *weaponEditor) addShotsBlock(w *gurps.Weapon, content *unison.Panel) {
 	addLabelAndDecimalField(wrapper, nil, "", text, text, &shots.ReloadTime, 0, fxp.Thousand)
 	addCheckBox(wrapper, i18n.Text("Per Shot"), &shots.ReloadTimeIsPerShot)
 	addCheckBox(wrapper, i18n.Text("Thrown Weapon"), &shots.Thrown)
+	we.addAmmoBlock(w, content)
+}
+
+func (we *weaponEditor) addAmmoBlock(w *gurps.Weapon, content *unison.Panel) {
+	entity := w.Entity()
+	if entity == nil {
+		return
+	}
+	candidates := entity.AmmoCandidates()
+	text := i18n.Text("Ammunition")
+	wrapper := addFlowWrapper(content, text, 3)
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(i18n.Text("None"))
+	popup.SelectIndex(0)
+	for i, eqp := range candidates {
+		popup.AddItem(eqp.String())
+		if eqp.TID == w.AmmoID {
+			popup.SelectIndex(i + 1)
+		}
+	}
+	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		if i := p.SelectedIndex(); i > 0 && i <= len(candidates) {
+			w.AmmoID = candidates[i-1].TID
+		} else {
+			w.AmmoID = ""
+		}
+		MarkModified(wrapper)
+	}
+	popup.Tooltip = newWrappedTooltip(i18n.Text("The equipment consumed when this weapon is fired or reloaded"))
+	wrapper.AddChild(popup)
+	text = i18n.Text("Loaded")
+	wrapper.AddChild(NewFieldInteriorLeadingLabel(text, false))
+	addDecimalField(wrapper, nil, "", text, text, &w.Loaded, 0, fxp.MillionMinusOne)
 }
 
 func (we *weaponEditor) addBulkBlock(w *gurps.Weapon, content *unison.Panel) {
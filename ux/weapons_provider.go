@@ -140,9 +140,13 @@ func (p *weaponsProvider) ColumnIDs() []int {
 			gurps.WeaponRangeColumn,
 			gurps.WeaponRoFColumn,
 			gurps.WeaponShotsColumn,
-			gurps.WeaponBulkColumn,
-			gurps.WeaponRecoilColumn,
 		)
+		if entity, ok := p.provider.(*gurps.Entity); !ok || !p.forPage || !entity.SheetSettings.SimplifiedDisplay {
+			columnIDs = append(columnIDs,
+				gurps.WeaponBulkColumn,
+				gurps.WeaponRecoilColumn,
+			)
+		}
 	}
 	return append(columnIDs, gurps.WeaponSTColumn)
 }
@@ -0,0 +1,79 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wtcond"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+type weightRulesPanel struct {
+	unison.Panel
+	rules *[]*gurps.WeightRule
+}
+
+func addWeightRulesPanel(parent *unison.Panel, rules *[]*gurps.WeightRule) {
+	label := NewFieldLeadingLabel(i18n.Text("Weight Rules"), false)
+	label.Tooltip = newWrappedTooltip(i18n.Text("Rules that adjust how much of this item's own weight counts, based on how it is being carried"))
+	parent.AddChild(label)
+	p := &weightRulesPanel{rules: rules}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		VAlign:   align.Middle,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.rebuild()
+	parent.AddChild(p)
+}
+
+func (p *weightRulesPanel) rebuild() {
+	p.RemoveAllChildren()
+	for _, one := range *p.rules {
+		rule := one
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Weight Rule"))
+		deleteButton.ClickCallback = func() {
+			if i := slices.Index(*p.rules, rule); i != -1 {
+				*p.rules = slices.Delete(*p.rules, i, i+1)
+				p.rebuild()
+				MarkModified(p)
+			}
+		}
+		p.AddChild(deleteButton)
+		p.AddChild(NewFieldInteriorLeadingLabel(i18n.Text("Counts at"), false))
+		addDecimalField(p.AsPanel(), nil, "", i18n.Text("Weight Percentage"), i18n.Text("The percentage of the item's weight that counts"),
+			&rule.Percentage, 0, fxp.Thousand)
+		addPopup(p.AsPanel(), wtcond.Conditions, &rule.Condition)
+	}
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Weight Rule"))
+	addButton.ClickCallback = func() {
+		*p.rules = append(*p.rules, &gurps.WeightRule{Condition: wtcond.Equipped, Percentage: fxp.Fifty})
+		p.rebuild()
+		MarkModified(p)
+	}
+	addButton.SetLayoutData(&unison.FlexLayoutData{HSpan: 4})
+	p.AddChild(addButton)
+	MarkForLayoutWithinDockable(p)
+	p.MarkForRedraw()
+}
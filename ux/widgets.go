@@ -654,6 +654,11 @@ func addLeveledAmountPanel(parent *unison.Panel, targetMgr *TargetMgr, targetKey
 			amount.Amount = value
 			MarkModified(parent)
 		}, fxp.Min, fxp.Max, true, false))
+	exprText := i18n.Text("Expression")
+	field := addStringField(parent, exprText,
+		i18n.Text("An optional expression, such as $mana_level * 2, that replaces the amount when used on a sheet"),
+		&amount.Expression)
+	field.Watermark = exprText
 	addCheckBox(parent, title, &amount.PerLevel)
 }
 
@@ -525,7 +525,7 @@ func SaveDockableAs(d FileBackedDockable, extension string, saver func(filePath
 		dialog.SetInitialDirectory(gurps.GlobalSettings().LastDir(gurps.DefaultLastDirKey))
 	}
 	dialog.SetAllowedExtensions(extension)
-	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(existingPath)))
+	dialog.SetInitialFileName(suggestedFileName(d))
 	if dialog.RunModal() {
 		filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), extension, false)
 		if !ok {
@@ -544,6 +544,17 @@ func SaveDockableAs(d FileBackedDockable, extension string, saver func(filePath
 	return false
 }
 
+// suggestedFileName returns the file name to suggest when saving or exporting the dockable's content, built from the
+// file name pattern in the general settings.
+func suggestedFileName(d FileBackedDockable) string {
+	var entity *gurps.Entity
+	if provider, ok := d.(interface{ Entity() *gurps.Entity }); ok {
+		entity = provider.Entity()
+	}
+	return gurps.ExpandFileNamePattern(gurps.GlobalSettings().General.FileNamePattern, entity, d.BackingFilePath(),
+		time.Now())
+}
+
 // PromptForDestination puts up a modal dialog to choose one or more destinations if choices contains more than one
 // choice. Return an empty list if canceled or there are no selections made.
 func PromptForDestination[T FileBackedDockable](choices []T) []T {